@@ -2,7 +2,7 @@
 #
 # MIT License
 #
-# (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+# (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 #
 # Permission is hereby granted, free of charge, to any person obtaining a
 # copy of this software and associated documentation files (the "Software"),
@@ -22,5 +22,56 @@
 # ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
 # OTHER DEALINGS IN THE SOFTWARE.
 #
-# wrapper to call cmstools test
-exec @BB_BASE_DIR@/run_cmstools_test.sh barebones_image_test "$@"
+# Wrapper which runs the barebones image boot test (cmsdev test barebones), accepting the options
+# of the Python test which it replaces. The settings are passed to cmsdev in the CMSDEV_BAREBONES
+# environment variable.
+
+function err_exit
+{
+    echo "ERROR: $*" 1>&2
+    exit 1
+}
+
+SETTINGS=()
+while [[ $# -gt 0 ]]; do
+    OPTION="$1"
+    shift
+    VALUE=""
+    if [[ ${OPTION} == --*=* ]]; then
+        VALUE="${OPTION#*=}"
+        OPTION="${OPTION%%=*}"
+    elif [[ ${OPTION} != --no-cleanup && ${OPTION} != -h && ${OPTION} != --help ]]; then
+        [[ $# -gt 0 ]] || err_exit "Option ${OPTION} requires a value"
+        VALUE="$1"
+        shift
+    fi
+    [[ ${VALUE} != *,* ]] || err_exit "Option ${OPTION} value may not contain commas: ${VALUE}"
+    case "${OPTION}" in
+        --arch)         SETTINGS+=("arch=${VALUE}") ;;
+        --csm-version)  SETTINGS+=("csm_version=${VALUE}") ;;
+        --base-id)      SETTINGS+=("base_image_id=${VALUE}") ;;
+        --id)           SETTINGS+=("image_id=${VALUE}") ;;
+        --cfs-config)   SETTINGS+=("cfs_configuration=${VALUE}") ;;
+        --vcs-url)      SETTINGS+=("vcs_url=${VALUE}") ;;
+        --git-commit)   SETTINGS+=("git_commit=${VALUE}") ;;
+        --playbook)     SETTINGS+=("playbook=${VALUE}") ;;
+        --xname)        SETTINGS+=("xname=${VALUE}") ;;
+        --no-cleanup)   SETTINGS+=("keep_on_success=true") ;;
+        -h|--help)
+            echo "usage: barebones_image_test [--arch {x86,arm}] [--csm-version VERSION] [--base-id ID] [--id ID]"
+            echo "           [--cfs-config NAME] [--vcs-url URL] [--git-commit COMMIT] [--playbook PLAYBOOK]"
+            echo "           [--xname XNAME] [--no-cleanup]"
+            echo
+            echo "Runs 'cmsdev test barebones'. See 'cmsdev test --help' for details."
+            exit 0
+            ;;
+        *) err_exit "Unknown option: ${OPTION}" ;;
+    esac
+done
+
+if [[ ${#SETTINGS[@]} -gt 0 ]]; then
+    SETTINGS_STRING=$(IFS=,; echo "${SETTINGS[*]}")
+    export CMSDEV_BAREBONES="${CMSDEV_BAREBONES:+${CMSDEV_BAREBONES},}${SETTINGS_STRING}"
+fi
+
+exec /usr/local/bin/cmsdev test barebones
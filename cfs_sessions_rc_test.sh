@@ -2,7 +2,7 @@
 #
 # MIT License
 #
-# (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+# (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 #
 # Permission is hereby granted, free of charge, to any person obtaining a
 # copy of this software and associated documentation files (the "Software"),
@@ -22,5 +22,70 @@
 # ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
 # OTHER DEALINGS IN THE SOFTWARE.
 #
-# wrapper to call cmstools test
-exec @BB_BASE_DIR@/run_cmstools_test.sh cfs_sessions_rc_test "$@"
+# Wrapper which runs the CFS sessions race condition test (cmsdev test cfs-sessions-rc), accepting
+# the options of the Python test which it replaces. The settings are passed to cmsdev in the
+# CMSDEV_CFS_SESSIONS_RC environment variable, and the subtests with the --only and --skip options.
+
+function err_exit
+{
+    echo "ERROR: $*" 1>&2
+    exit 1
+}
+
+# Convert a comma-separated list of Python subtest names (e.g. multi_delete_single_get) into
+# cmsdev subtest names (e.g. cfs-sessions-rc.multi-delete-single-get)
+function subtest_names
+{
+    local name names=()
+    for name in ${1//,/ }; do
+        names+=("cfs-sessions-rc.${name//_/-}")
+    done
+    local IFS=,
+    echo "${names[*]}"
+}
+
+SETTINGS=()
+CMSDEV_ARGS=()
+while [[ $# -gt 0 ]]; do
+    OPTION="$1"
+    shift
+    VALUE=""
+    if [[ ${OPTION} == --*=* ]]; then
+        VALUE="${OPTION#*=}"
+        OPTION="${OPTION%%=*}"
+    elif [[ ${OPTION} != --delete-previous-sessions && ${OPTION} != -h && ${OPTION} != --help ]]; then
+        [[ $# -gt 0 ]] || err_exit "Option ${OPTION} requires a value"
+        VALUE="$1"
+        shift
+    fi
+    case "${OPTION}" in
+        --name)                     SETTINGS+=("name_prefix=${VALUE}") ;;
+        --max-sessions)             SETTINGS+=("max_sessions=${VALUE}") ;;
+        --max-multi-get-reqs)       SETTINGS+=("max_multi_get_requests=${VALUE}") ;;
+        --max-multi-delete-reqs)    SETTINGS+=("max_multi_delete_requests=${VALUE}") ;;
+        --max-single-get-reqs)      SETTINGS+=("max_single_get_requests=${VALUE}") ;;
+        --max-single-delete-reqs)   SETTINGS+=("max_single_delete_requests=${VALUE}") ;;
+        --delete-previous-sessions) SETTINGS+=("delete_previous_sessions=true") ;;
+        --cfs-version)              SETTINGS+=("cfs_version=${VALUE}") ;;
+        --page-size)                SETTINGS+=("page_size=${VALUE}") ;;
+        --run-subtests)             CMSDEV_ARGS+=(--only "$(subtest_names "${VALUE}")") ;;
+        --skip-subtests)            CMSDEV_ARGS+=(--skip "$(subtest_names "${VALUE}")") ;;
+        -h|--help)
+            echo "usage: cfs_sessions_rc_test [--name PREFIX] [--max-sessions N] [--max-multi-get-reqs N]"
+            echo "           [--max-multi-delete-reqs N] [--max-single-get-reqs N] [--max-single-delete-reqs N]"
+            echo "           [--delete-previous-sessions] [--cfs-version {v2,v3}] [--page-size N]"
+            echo "           [--run-subtests NAMES | --skip-subtests NAMES]"
+            echo
+            echo "Runs 'cmsdev test cfs-sessions-rc'. See 'cmsdev test --help' for details."
+            exit 0
+            ;;
+        *) err_exit "Unknown option: ${OPTION}" ;;
+    esac
+done
+
+if [[ ${#SETTINGS[@]} -gt 0 ]]; then
+    SETTINGS_STRING=$(IFS=,; echo "${SETTINGS[*]}")
+    export CMSDEV_CFS_SESSIONS_RC="${CMSDEV_CFS_SESSIONS_RC:+${CMSDEV_CFS_SESSIONS_RC},}${SETTINGS_STRING}"
+fi
+
+exec /usr/local/bin/cmsdev test cfs-sessions-rc "${CMSDEV_ARGS[@]}"
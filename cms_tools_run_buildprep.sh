@@ -2,7 +2,7 @@
 #
 # MIT License
 #
-# (C) Copyright 2024-2025 Hewlett Packard Enterprise Development LP
+# (C) Copyright 2024-2026 Hewlett Packard Enterprise Development LP
 #
 # Permission is hereby granted, free of charge, to any person obtaining a
 # copy of this software and associated documentation files (the "Software"),
@@ -25,16 +25,6 @@
 
 set -exuo pipefail
 
-source ./vars.sh
-sed -i "s#@BB_BASE_DIR@#${INSTALL_VENV_PYTHON_BASE_DIR}#" barebones_image_test.sh cfs_sessions_rc_test.sh run_cmstools_test.sh
-if [[ -d ./${LOCAL_VENV_PYTHON_SUBDIR_NAME} ]]; then
-    rm -rvf "./${LOCAL_VENV_PYTHON_SUBDIR_NAME}"
-fi
-if [[ -e ${LOCAL_VENV_PYTHON_SUBDIR_NAME} ]]; then
-    echo "'${LOCAL_VENV_PYTHON_SUBDIR_NAME}' exists, but it should not"
-    exit 1
-fi
-mkdir -pv "${LOCAL_VENV_PYTHON_SUBDIR_NAME}"
 ./cms_meta_tools/scripts/runBuildPrep.sh
 
 # If the `build` directory exists, delete it
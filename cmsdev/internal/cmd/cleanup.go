@@ -0,0 +1,206 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * cleanup.go
+ *
+ * cleanup command: finds and deletes resources left behind by earlier test runs
+ *
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+)
+
+// A leftover resource, and the service test which found it
+type foundLeftover struct {
+	service string
+	registry.Leftover
+}
+
+// Return the service tests which can find leftover resources, sorted by name
+func cleanupServiceTests() (serviceTests []*registry.ServiceTest) {
+	for _, serviceTest := range registry.ServiceTests() {
+		if serviceTest.FindLeftovers != nil {
+			serviceTests = append(serviceTests, serviceTest)
+		}
+	}
+	return
+}
+
+func cleanupServiceNames() []string {
+	names := []string{}
+	for _, serviceTest := range cleanupServiceTests() {
+		names = append(names, serviceTest.Name)
+	}
+	return names
+}
+
+// Find the leftover resources for the specified service tests. Also returns the names
+// of the services whose search did not complete.
+func findLeftovers(serviceTests []*registry.ServiceTest, opts registry.CleanupOptions) (found []foundLeftover, incomplete []string) {
+	ctx := opts.Context
+	for _, serviceTest := range serviceTests {
+		common.Infof(ctx, "Searching for %s resources left behind by cmsdev tests", serviceTest.Name)
+		common.SetTestService(ctx, serviceTest.Name)
+		leftovers, ok := serviceTest.FindLeftovers(opts)
+		common.UnsetTestService(ctx)
+		if !ok {
+			incomplete = append(incomplete, serviceTest.Name)
+		}
+		for _, leftover := range leftovers {
+			found = append(found, foundLeftover{service: serviceTest.Name, Leftover: leftover})
+		}
+	}
+	return
+}
+
+func (leftover foundLeftover) String() string {
+	if len(leftover.Tenant) > 0 {
+		return fmt.Sprintf("%s %s (tenant: %s)", leftover.Kind, leftover.Name, leftover.Tenant)
+	}
+	return fmt.Sprintf("%s %s", leftover.Kind, leftover.Name)
+}
+
+// Delete the leftover resources, in order, returning the ones which could not be deleted
+func deleteLeftovers(ctx context.Context, found []foundLeftover) (failed []string) {
+	defer common.SetTenantName(ctx, "")
+	for _, leftover := range found {
+		common.Infof(ctx, "Deleting %s", leftover)
+		common.SetTestService(ctx, leftover.service)
+		// Delete the resource as the tenant that owns it
+		common.SetTenantName(ctx, leftover.Tenant)
+		if !leftover.Delete() {
+			common.Errorf(ctx, "Unable to delete %s", leftover)
+			failed = append(failed, leftover.String())
+		}
+		common.UnsetTestService(ctx)
+	}
+	return
+}
+
+var cleanupLongHelpText = fmt.Sprintf(`cleanup command finds resources which cmsdev tests created but did not delete
+(for example, because the test was killed), and optionally deletes them. Resources are
+found by the names that the tests generate for them, for every tenant. By default the
+resources are only listed.
+
+Services which can be cleaned up: %s
+
+Example Commands:
+
+cmsdev cleanup
+  # lists the leftover test resources for all services
+cmsdev cleanup vcs cfs
+  # lists the leftover test resources for vcs and cfs
+cmsdev cleanup --yes
+  # deletes the leftover test resources for all services
+cmsdev cleanup ims --yes --purge-deleted
+  # deletes the leftover ims test records, and permanently deletes soft-deleted ones`, strings.Join(cleanupServiceNames(), ", "))
+
+// cleanupCmd command functions
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup [service ...]",
+	Short: "find and delete resources left behind by cmsdev tests",
+	Long:  cleanupLongHelpText,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := common.MainContext()
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		purgeDeleted, _ := cmd.Flags().GetBool("purge-deleted")
+		noLogs, _ := cmd.Flags().GetBool("no-log")
+		logsDir, _ := cmd.Flags().GetString("log-dir")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		applyBaseURLFlag(ctx, cmd)
+		applyLogFormatFlag(ctx, cmd)
+		applyInsecureFlag(cmd)
+
+		if quiet && verbose {
+			common.Usagef(ctx, "--quiet and --verbose are mutually exclusive")
+		} else if yes && cmd.Flags().Changed("dry-run") && dryRun {
+			common.Usagef(ctx, "--dry-run and --yes are mutually exclusive")
+		} else if !dryRun && !yes {
+			common.Usagef(ctx, "--yes is required to delete resources")
+		}
+
+		serviceTests := []*registry.ServiceTest{}
+		if len(args) == 0 {
+			serviceTests = cleanupServiceTests()
+		}
+		for _, a := range args {
+			serviceTest, ok := registry.Lookup(strings.TrimSpace(a))
+			if !ok || serviceTest.FindLeftovers == nil {
+				common.Usagef(ctx, "Invalid service: '%s'. Services which can be cleaned up are: %s", a, strings.Join(cleanupServiceNames(), ", "))
+			}
+			serviceTests = append(serviceTests, serviceTest)
+		}
+
+		// cmsdevVersion is found in version.go
+		common.CreateLogFile(ctx, logsDir, cmsdevVersion, !noLogs, false, quiet, verbose, false, false)
+
+		found, incomplete := findLeftovers(serviceTests, registry.CleanupOptions{Context: ctx, PurgeDeleted: purgeDeleted})
+		for _, leftover := range found {
+			common.Resultsf(ctx, "Found %s", leftover)
+		}
+		if len(incomplete) > 0 {
+			common.Warnf(ctx, "The search for leftover resources did not complete for: %s", strings.Join(incomplete, ", "))
+		}
+
+		if !yes {
+			if len(incomplete) > 0 {
+				common.Failuref(ctx, "Found %d leftover test resources, but the search did not complete for: %s", len(found), strings.Join(incomplete, ", "))
+			} else if len(found) > 0 {
+				common.Successf(ctx, "Found %d leftover test resources; use --yes to delete them", len(found))
+			}
+			common.Successf(ctx, "No leftover test resources found")
+		}
+
+		failed := deleteLeftovers(ctx, found)
+		if len(failed) > 0 {
+			common.Failuref(ctx, "Unable to delete %d of %d leftover test resources: %s", len(failed), len(found), strings.Join(failed, ", "))
+		} else if len(incomplete) > 0 {
+			common.Failuref(ctx, "Deleted %d leftover test resources, but the search did not complete for: %s", len(found), strings.Join(incomplete, ", "))
+		}
+		common.Successf(ctx, "Deleted %d leftover test resources", len(found))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+	cleanupCmd.Flags().BoolP("dry-run", "", true, "only list the leftover resources, without deleting them")
+	cleanupCmd.Flags().BoolP("yes", "y", false, "delete the leftover resources")
+	cleanupCmd.Flags().BoolP("purge-deleted", "", false, "also permanently delete soft-deleted IMS records created by tests")
+	cleanupCmd.Flags().StringP("log-dir", "", "", "specify log directory")
+	cleanupCmd.Flags().StringP("log-format", "", "", "log file format: text or json (overrides log_format)")
+	cleanupCmd.Flags().BoolP("no-log", "", false, "do not log to a file")
+	cleanupCmd.Flags().BoolP("quiet", "q", false, "quiet mode")
+	cleanupCmd.Flags().BoolP("verbose", "v", false, "verbose mode")
+	cleanupCmd.Flags().StringP("base-url", "", "", "send API requests to the specified URL instead of the API gateway (overrides base_url)")
+	cleanupCmd.Flags().BoolP("insecure", "", false, "do not verify the TLS certificates of the API gateway (overrides insecure)")
+}
@@ -0,0 +1,543 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * config.go
+ *
+ * Loading of the cmsdev configuration, and the config command
+ *
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+)
+
+// Environment variables override the config file. For example, CMSDEV_NAMESPACE overrides namespace.
+const configEnvPrefix = "CMSDEV"
+
+// The map settings cannot be set through viper's automatic environment variable binding, so
+// they have their own environment variables, with comma-separated values:
+// CMSDEV_TEST_TIMEOUTS="cfs=600,vcs=300", CMSDEV_POD_COUNTS="bos=3:-1,ims=1:1",
+// CMSDEV_CFS_SESSIONS_RC="max_sessions=50,cfs_version=v2", CMSDEV_BAREBONES="arch=arm",
+// CMSDEV_AUTH="token_file=/root/token" and CMSDEV_RETRIES="api=5:2:60,kubectl=0"
+const testTimeoutsEnvVar = configEnvPrefix + "_TEST_TIMEOUTS"
+const podCountsEnvVar = configEnvPrefix + "_POD_COUNTS"
+const retriesEnvVar = configEnvPrefix + "_RETRIES"
+const cfsSessionsRCEnvVar = configEnvPrefix + "_CFS_SESSIONS_RC"
+const barebonesEnvVar = configEnvPrefix + "_BAREBONES"
+const authEnvVar = configEnvPrefix + "_AUTH"
+
+// The CFS sessions race condition test appends a number to the name prefix to name each session,
+// and session names must be valid Kubernetes names
+var cfsSessionNamePrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+const maxCFSSessionNamePrefixLength = 40
+
+// Set the viper defaults for the scalar configuration settings, so that viper will find their
+// environment variables
+func setConfigDefaults() {
+	defaults := common.DefaultConfig()
+	viper.SetDefault("base_host", defaults.BaseHost)
+	viper.SetDefault("base_url", defaults.BaseURL)
+	viper.SetDefault("namespace", defaults.Namespace)
+	viper.SetDefault("api_timeout_seconds", defaults.APITimeoutSeconds)
+	viper.SetDefault("api_retry_count", defaults.APIRetryCount)
+	viper.SetDefault("api_retry_wait_seconds", defaults.APIRetryWaitSeconds)
+	viper.SetDefault("cli_timeout_seconds", defaults.CLITimeoutSeconds)
+	viper.SetDefault("log_dir", defaults.LogDir)
+	viper.SetDefault("log_format", defaults.LogFormat)
+	viper.SetDefault("latency_budgets", defaults.LatencyBudgets)
+	viper.SetDefault("openapi_dir", defaults.OpenAPIDir)
+	viper.SetDefault("contract", defaults.Contract)
+	viper.SetDefault("ca_bundle", defaults.CABundle)
+	viper.SetDefault("insecure", defaults.Insecure)
+}
+
+// Parse a comma-separated list of name=value pairs from an environment variable
+func parseEnvPairs(envVar, value string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if len(pair) == 0 {
+			continue
+		}
+		name, value, found := strings.Cut(pair, "=")
+		if !found || len(name) == 0 {
+			return nil, fmt.Errorf("%s: expected name=value, found '%s'", envVar, pair)
+		}
+		pairs[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return pairs, nil
+}
+
+// Read the test timeouts from the environment or config file
+func loadTestTimeouts(cfg *common.Config) error {
+	if envValue, ok := os.LookupEnv(testTimeoutsEnvVar); ok {
+		pairs, err := parseEnvPairs(testTimeoutsEnvVar, envValue)
+		if err != nil {
+			return err
+		}
+		for service, value := range pairs {
+			timeout, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s: invalid timeout for %s: '%s'", testTimeoutsEnvVar, service, value)
+			}
+			cfg.TestTimeouts[service] = timeout
+		}
+		return nil
+	}
+	if err := viper.UnmarshalKey("test_timeouts", &cfg.TestTimeouts); err != nil {
+		return fmt.Errorf("test_timeouts: %v", err)
+	}
+	return nil
+}
+
+// Read the expected pod counts from the environment or config file. Only the counts which are
+// specified are changed from their defaults.
+func loadPodCounts(cfg *common.Config) error {
+	overrides := make(map[string]map[string]int)
+	if envValue, ok := os.LookupEnv(podCountsEnvVar); ok {
+		pairs, err := parseEnvPairs(podCountsEnvVar, envValue)
+		if err != nil {
+			return err
+		}
+		for pkey, value := range pairs {
+			minString, maxString, found := strings.Cut(value, ":")
+			minCount, err := strconv.Atoi(minString)
+			if err != nil {
+				return fmt.Errorf("%s: invalid minimum count for %s: '%s'", podCountsEnvVar, pkey, minString)
+			}
+			overrides[pkey] = map[string]int{"min": minCount}
+			if found {
+				maxCount, err := strconv.Atoi(maxString)
+				if err != nil {
+					return fmt.Errorf("%s: invalid maximum count for %s: '%s'", podCountsEnvVar, pkey, maxString)
+				}
+				overrides[pkey]["max"] = maxCount
+			}
+		}
+	} else if err := viper.UnmarshalKey("pod_counts", &overrides); err != nil {
+		return fmt.Errorf("pod_counts: %v", err)
+	}
+	for pkey, override := range overrides {
+		count, ok := cfg.PodCounts[pkey]
+		if !ok {
+			return fmt.Errorf("pod_counts: unknown pod key '%s' (valid keys: %s)", pkey, strings.Join(common.PodCountKeys(), ", "))
+		}
+		for field, value := range override {
+			switch field {
+			case "min":
+				count.Min = value
+			case "max":
+				count.Max = value
+			default:
+				return fmt.Errorf("pod_counts: %s: unknown field '%s' (valid fields: min, max)", pkey, field)
+			}
+		}
+		cfg.PodCounts[pkey] = count
+	}
+	return nil
+}
+
+// Read the retry policies from the environment or config file. The api and token policies
+// default to api_retry_count and api_retry_wait_seconds. Only the settings which are specified
+// are changed from their defaults.
+func loadRetries(cfg *common.Config) error {
+	for _, class := range []string{common.RetryClassAPI, common.RetryClassToken} {
+		policy := cfg.Retries[class]
+		policy.MaxRetries = cfg.APIRetryCount
+		policy.WaitSeconds = float64(cfg.APIRetryWaitSeconds)
+		if policy.MaxWaitSeconds < policy.WaitSeconds {
+			policy.MaxWaitSeconds = policy.WaitSeconds
+		}
+		cfg.Retries[class] = policy
+	}
+	overrides := make(map[string]map[string]float64)
+	if envValue, ok := os.LookupEnv(retriesEnvVar); ok {
+		pairs, err := parseEnvPairs(retriesEnvVar, envValue)
+		if err != nil {
+			return err
+		}
+		fields := []string{"max_retries", "wait_seconds", "max_wait_seconds"}
+		for class, value := range pairs {
+			overrides[class] = make(map[string]float64)
+			for i, field := range strings.Split(value, ":") {
+				if i >= len(fields) {
+					return fmt.Errorf("%s: too many values for %s: '%s'", retriesEnvVar, class, value)
+				}
+				number, err := strconv.ParseFloat(field, 64)
+				if err != nil {
+					return fmt.Errorf("%s: invalid %s for %s: '%s'", retriesEnvVar, fields[i], class, field)
+				}
+				overrides[class][fields[i]] = number
+			}
+		}
+	} else if err := viper.UnmarshalKey("retries", &overrides); err != nil {
+		return fmt.Errorf("retries: %v", err)
+	}
+	for class, override := range overrides {
+		policy, ok := cfg.Retries[class]
+		if !ok {
+			return fmt.Errorf("retries: unknown class '%s' (valid classes: %s)", class, strings.Join(common.RetryClasses(), ", "))
+		}
+		for field, value := range override {
+			switch field {
+			case "max_retries":
+				if value != float64(int(value)) {
+					return fmt.Errorf("retries: %s: max_retries must be a whole number", class)
+				}
+				policy.MaxRetries = int(value)
+			case "wait_seconds":
+				policy.WaitSeconds = value
+			case "max_wait_seconds":
+				policy.MaxWaitSeconds = value
+			default:
+				return fmt.Errorf("retries: %s: unknown field '%s' (valid fields: max_retries, wait_seconds, max_wait_seconds)", class, field)
+			}
+		}
+		cfg.Retries[class] = policy
+	}
+	return nil
+}
+
+// Read a group of settings (like those of a service test) from its environment variable or the
+// config file, into result (which holds the defaults). Only the settings which are specified are
+// changed.
+func loadTestSettings(envVar, key string, result interface{}) error {
+	settings := make(map[string]interface{})
+	if envValue, ok := os.LookupEnv(envVar); ok {
+		pairs, err := parseEnvPairs(envVar, envValue)
+		if err != nil {
+			return err
+		}
+		for name, value := range pairs {
+			settings[name] = value
+		}
+	} else if err := viper.UnmarshalKey(key, &settings); err != nil {
+		return fmt.Errorf("%s: %v", key, err)
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           result,
+		WeaklyTypedInput: true,
+		ErrorUnused:      true,
+	})
+	if err != nil {
+		return err
+	} else if err = decoder.Decode(settings); err != nil {
+		return fmt.Errorf("%s: %v", key, err)
+	}
+	return nil
+}
+
+// Validate the CFS sessions race condition test settings
+func validateCFSSessionsRC(rc common.CFSSessionsRCConfig) error {
+	if len(rc.NamePrefix) == 0 || len(rc.NamePrefix) > maxCFSSessionNamePrefixLength {
+		return fmt.Errorf("cfs_sessions_rc: name_prefix must be between 1 and %d characters", maxCFSSessionNamePrefixLength)
+	} else if !cfsSessionNamePrefixPattern.MatchString(rc.NamePrefix) {
+		return fmt.Errorf("cfs_sessions_rc: name_prefix must match pattern: %s", cfsSessionNamePrefixPattern)
+	} else if rc.MaxSessions < 1 {
+		return fmt.Errorf("cfs_sessions_rc: max_sessions must be at least 1")
+	} else if rc.MaxMultiGetRequests < 1 || rc.MaxMultiDeleteRequests < 1 || rc.MaxSingleGetRequests < 1 || rc.MaxSingleDeleteRequests < 1 {
+		return fmt.Errorf("cfs_sessions_rc: max_multi_get_requests, max_multi_delete_requests, max_single_get_requests and max_single_delete_requests must be at least 1")
+	} else if rc.CFSVersion != "v2" && rc.CFSVersion != "v3" {
+		return fmt.Errorf("cfs_sessions_rc: cfs_version must be v2 or v3")
+	} else if rc.PageSize < 0 {
+		return fmt.Errorf("cfs_sessions_rc: page_size may not be negative")
+	} else if rc.CFSVersion == "v2" && rc.PageSize > 0 && rc.PageSize < rc.MaxSessions {
+		// CFS v2 has no paging, so listing the sessions fails if there are more than a page of them
+		return fmt.Errorf("cfs_sessions_rc: with cfs_version v2, page_size must be 0 (automatic) or at least max_sessions")
+	}
+	return nil
+}
+
+// Validate the barebones image boot test settings. The settings which name a node or image
+// determine the architecture, and an already customized image needs no CFS settings.
+func validateBarebones(bb common.BarebonesConfig) error {
+	if len(bb.Arch) > 0 && bb.Arch != "x86" && bb.Arch != "arm" {
+		return fmt.Errorf("barebones: arch must be x86 or arm")
+	}
+	set := map[string]bool{
+		"arch":              len(bb.Arch) > 0,
+		"base_image_id":     len(bb.BaseImageID) > 0,
+		"image_id":          len(bb.ImageID) > 0,
+		"cfs_configuration": len(bb.CFSConfiguration) > 0,
+		"vcs_url":           len(bb.VCSURL) > 0,
+		"git_commit":        len(bb.GitCommit) > 0,
+		"playbook":          len(bb.Playbook) > 0,
+		"xname":             len(bb.Xname) > 0,
+	}
+	// The first setting of each list may not be combined with the others
+	exclusive := [][]string{
+		{"arch", "base_image_id", "image_id", "xname"},
+		{"image_id", "base_image_id", "cfs_configuration", "vcs_url", "git_commit", "playbook"},
+		{"cfs_configuration", "vcs_url", "git_commit", "playbook"},
+	}
+	for _, names := range exclusive {
+		if !set[names[0]] {
+			continue
+		}
+		for _, name := range names[1:] {
+			if set[name] {
+				return fmt.Errorf("barebones: %s and %s may not both be set", names[0], name)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate the access token provider settings
+func validateAuth(auth common.AuthConfig) error {
+	if len(auth.TokenFile) > 0 && len(auth.CredentialsFile) > 0 {
+		return fmt.Errorf("auth: token_file and credentials_file may not both be set")
+	} else if len(auth.ClientID) == 0 {
+		return fmt.Errorf("auth: client_id may not be empty")
+	} else if len(auth.Realm) == 0 {
+		return fmt.Errorf("auth: realm may not be empty")
+	} else if auth.ClientID != common.DefaultConfig().Auth.ClientID && len(auth.ClientSecretFile) == 0 {
+		// The admin-client-auth Kubernetes secret only holds the secret of the default client
+		return fmt.Errorf("auth: client_secret_file must be set when client_id is not %s", common.DefaultConfig().Auth.ClientID)
+	} else if auth.RefreshMarginSeconds < 0 {
+		return fmt.Errorf("auth: refresh_margin_seconds may not be negative")
+	}
+	return nil
+}
+
+// Validate the configuration settings
+func validateConfig(cfg common.Config) error {
+	if len(cfg.BaseHost) == 0 {
+		return fmt.Errorf("base_host may not be empty")
+	} else if len(cfg.BaseURL) > 0 && !strings.HasPrefix(cfg.BaseURL, "http://") && !strings.HasPrefix(cfg.BaseURL, "https://") {
+		return fmt.Errorf("base_url must begin with http:// or https://")
+	} else if len(cfg.Namespace) == 0 {
+		return fmt.Errorf("namespace may not be empty")
+	} else if len(cfg.LogDir) == 0 {
+		return fmt.Errorf("log_dir may not be empty")
+	} else if cfg.LogFormat != common.LogFormatText && cfg.LogFormat != common.LogFormatJSON {
+		return fmt.Errorf("log_format must be %s or %s", common.LogFormatText, common.LogFormatJSON)
+	} else if cfg.APITimeoutSeconds < 1 {
+		return fmt.Errorf("api_timeout_seconds must be at least 1")
+	} else if cfg.APIRetryCount < 0 {
+		return fmt.Errorf("api_retry_count may not be negative")
+	} else if cfg.APIRetryWaitSeconds < 0 {
+		return fmt.Errorf("api_retry_wait_seconds may not be negative")
+	} else if cfg.CLITimeoutSeconds < 1 {
+		return fmt.Errorf("cli_timeout_seconds must be at least 1")
+	}
+	for service, timeout := range cfg.TestTimeouts {
+		if serviceTest, ok := registry.Lookup(service); !ok || serviceTest.Name != service {
+			return fmt.Errorf("test_timeouts: unknown service test '%s' (valid tests: %s)", service, GetTestNamesString(true))
+		} else if timeout < 1 {
+			return fmt.Errorf("test_timeouts: %s: timeout must be at least 1", service)
+		}
+	}
+	for pkey, count := range cfg.PodCounts {
+		if count.Min < 0 {
+			return fmt.Errorf("pod_counts: %s: min may not be negative", pkey)
+		} else if count.Max != -1 && count.Max < count.Min {
+			return fmt.Errorf("pod_counts: %s: max must be -1 (no maximum) or at least min", pkey)
+		}
+	}
+	for class, policy := range cfg.Retries {
+		if policy.MaxRetries < 0 {
+			return fmt.Errorf("retries: %s: max_retries may not be negative", class)
+		} else if policy.WaitSeconds < 0 {
+			return fmt.Errorf("retries: %s: wait_seconds may not be negative", class)
+		} else if policy.MaxWaitSeconds < policy.WaitSeconds {
+			return fmt.Errorf("retries: %s: max_wait_seconds must be at least wait_seconds", class)
+		}
+	}
+	if err := validateCFSSessionsRC(cfg.CFSSessionsRC); err != nil {
+		return err
+	}
+	if err := validateBarebones(cfg.Barebones); err != nil {
+		return err
+	}
+	return validateAuth(cfg.Auth)
+}
+
+// Build the effective configuration from the defaults, config file, and environment variables
+func loadConfig() (common.Config, error) {
+	cfg := common.DefaultConfig()
+	cfg.BaseHost = viper.GetString("base_host")
+	cfg.BaseURL = viper.GetString("base_url")
+	cfg.Namespace = viper.GetString("namespace")
+	cfg.APITimeoutSeconds = viper.GetInt("api_timeout_seconds")
+	cfg.APIRetryCount = viper.GetInt("api_retry_count")
+	cfg.APIRetryWaitSeconds = viper.GetInt("api_retry_wait_seconds")
+	cfg.CLITimeoutSeconds = viper.GetInt("cli_timeout_seconds")
+	cfg.LogDir = viper.GetString("log_dir")
+	cfg.LogFormat = viper.GetString("log_format")
+	cfg.LatencyBudgets = viper.GetString("latency_budgets")
+	cfg.OpenAPIDir = viper.GetString("openapi_dir")
+	cfg.Contract = viper.GetBool("contract")
+	cfg.CABundle = viper.GetString("ca_bundle")
+	cfg.Insecure = viper.GetBool("insecure")
+	if err := loadTestTimeouts(&cfg); err != nil {
+		return cfg, err
+	}
+	if err := loadPodCounts(&cfg); err != nil {
+		return cfg, err
+	}
+	if err := loadRetries(&cfg); err != nil {
+		return cfg, err
+	}
+	if err := loadTestSettings(cfsSessionsRCEnvVar, "cfs_sessions_rc", &cfg.CFSSessionsRC); err != nil {
+		return cfg, err
+	}
+	if err := loadTestSettings(barebonesEnvVar, "barebones", &cfg.Barebones); err != nil {
+		return cfg, err
+	}
+	if err := loadTestSettings(authEnvVar, "auth", &cfg.Auth); err != nil {
+		return cfg, err
+	}
+	return cfg, validateConfig(cfg)
+}
+
+// Override base_url with the --base-url option of a command, if it was specified
+func applyBaseURLFlag(ctx context.Context, cmd *cobra.Command) {
+	if !cmd.Flags().Changed("base-url") {
+		return
+	}
+	cfg := common.GetConfig()
+	cfg.BaseURL, _ = cmd.Flags().GetString("base-url")
+	if err := validateConfig(cfg); err != nil {
+		common.Usagef(ctx, "--base-url: %v", err)
+	}
+	common.SetConfig(cfg)
+}
+
+// Override log_format with the --log-format option of a command, if it was specified
+func applyLogFormatFlag(ctx context.Context, cmd *cobra.Command) {
+	if !cmd.Flags().Changed("log-format") {
+		return
+	}
+	cfg := common.GetConfig()
+	cfg.LogFormat, _ = cmd.Flags().GetString("log-format")
+	if err := validateConfig(cfg); err != nil {
+		common.Usagef(ctx, "--log-format: %v", err)
+	}
+	common.SetConfig(cfg)
+}
+
+// Override latency_budgets with the --latency-budgets option of a command, if it was specified
+func applyLatencyBudgetsFlag(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("latency-budgets") {
+		return
+	}
+	cfg := common.GetConfig()
+	cfg.LatencyBudgets, _ = cmd.Flags().GetString("latency-budgets")
+	common.SetConfig(cfg)
+}
+
+// Override openapi_dir with the --openapi-dir option of a command, if it was specified
+func applyOpenAPIDirFlag(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("openapi-dir") {
+		return
+	}
+	cfg := common.GetConfig()
+	cfg.OpenAPIDir, _ = cmd.Flags().GetString("openapi-dir")
+	common.SetConfig(cfg)
+}
+
+// Override contract with the --contract option of a command, if it was specified
+func applyContractFlag(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("contract") {
+		return
+	}
+	cfg := common.GetConfig()
+	cfg.Contract, _ = cmd.Flags().GetBool("contract")
+	common.SetConfig(cfg)
+}
+
+// Override insecure with the --insecure option of a command, if it was specified
+func applyInsecureFlag(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("insecure") {
+		return
+	}
+	cfg := common.GetConfig()
+	cfg.Insecure, _ = cmd.Flags().GetBool("insecure")
+	common.SetConfig(cfg)
+}
+
+// configCmd command functions
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "cmsdev configuration commands",
+	Long: `config commands display the cmsdev configuration.
+
+Settings are read from the config file ($HOME/.cmsdev.yaml, or the file specified with --config),
+and can be overridden by CMSDEV_* environment variables (for example, CMSDEV_NAMESPACE).`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "display the effective cmsdev configuration",
+	Long: `show displays the effective cmsdev configuration, after merging the defaults, the config
+file, and CMSDEV_* environment variables. Test timeouts are shown for every service test.
+Example Commands:
+
+cmsdev config show
+  # displays the effective configuration
+CMSDEV_NAMESPACE=test-services cmsdev config show
+  # displays the configuration with the namespace overridden`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := common.MainContext()
+		if len(args) > 0 {
+			common.Usagef(ctx, "Invalid arguments: %s", strings.Join(args, " "))
+		}
+		cfg := common.GetConfig()
+		effective := cfg
+		effective.TestTimeouts = make(map[string]int64)
+		for _, serviceTest := range registry.ServiceTests() {
+			effective.TestTimeouts[serviceTest.Name] = common.TestTimeout(serviceTest.Name, serviceTest.DefaultTimeout)
+		}
+		data, err := yaml.Marshal(effective)
+		if err != nil {
+			common.Usagef(ctx, "Error encoding configuration: %v", err)
+		}
+		if configFile := viper.ConfigFileUsed(); len(configFile) > 0 {
+			fmt.Printf("# Config file: %s\n", configFile)
+		} else {
+			fmt.Printf("# No config file found; using defaults and environment variables\n")
+		}
+		fmt.Printf("%s", data)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
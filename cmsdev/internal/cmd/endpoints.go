@@ -0,0 +1,135 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * endpoints command: displays the endpoint catalog of the CMS services
+ *
+ */
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+// Print the catalog as a table, with one row for each method of each version of each endpoint
+func printEndpointsTable(catalog []common.CatalogEndpoint) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SERVICE\tNAME\tMETHOD\tURL\tPARAMETERS\tRESPONSES")
+	for _, endpoint := range catalog {
+		for _, method := range endpoint.Methods {
+			responses := make([]string, 0, len(method.Responses))
+			for _, code := range method.Responses {
+				responses = append(responses, strconv.Itoa(code))
+			}
+			parameters := method.Parameters
+			if len(parameters) == 0 {
+				parameters = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", endpoint.Service, endpoint.Name, method.Method,
+				endpoint.FullURL, parameters, strings.Join(responses, ","))
+		}
+	}
+	w.Flush()
+}
+
+// endpointsCmd command functions
+var endpointsCmd = &cobra.Command{
+	Use:   "endpoints [service] [name]",
+	Short: "display the endpoints of the CMS services which the tests use",
+	Long: `endpoints displays the endpoint catalog: the endpoints of the CMS services which the tests
+use, as defined by the OpenAPI specs built into cmsdev (or those in the --openapi-dir directory).
+For each method of each API version of each endpoint, it shows the URL which the tests use (the
+base URL, followed by the Url, version and Uri of the endpoint), the parameters which follow it,
+and the documented response status codes. The endpoints are sorted by service and name, and their
+versions from oldest to newest (the path without a version first).
+Example Commands:
+
+cmsdev endpoints
+  # displays all of the endpoints
+cmsdev endpoints cfs
+  # displays the cfs endpoints
+cmsdev endpoints bos sessions --format json
+  # displays the bos sessions endpoint in JSON
+cmsdev endpoints ims --base-url http://localhost:5000
+  # displays the ims endpoints, with the URLs used against "cmsdev mockserver"`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := common.MainContext()
+		format, _ := cmd.Flags().GetString("format")
+		applyBaseURLFlag(ctx, cmd)
+		applyOpenAPIDirFlag(cmd)
+
+		if format != "table" && format != "json" && format != "yaml" {
+			common.Usagef(ctx, "Invalid --format '%s': must be table, json or yaml", format)
+		}
+		if specDir := common.GetConfig().OpenAPIDir; len(specDir) > 0 {
+			if err := common.LoadOpenAPIDir(specDir); err != nil {
+				common.Usagef(ctx, "%v", err)
+			}
+		}
+
+		var service, name string
+		if len(args) > 0 {
+			service = args[0]
+		}
+		if len(args) > 1 {
+			name = args[1]
+		}
+		catalog, err := common.EndpointCatalog(service, name)
+		if err != nil {
+			common.Usagef(ctx, "%v", err)
+		}
+
+		switch format {
+		case "table":
+			printEndpointsTable(catalog)
+		case "json":
+			data, err := json.MarshalIndent(catalog, "", "  ")
+			if err != nil {
+				common.Usagef(ctx, "Error encoding endpoints: %v", err)
+			}
+			fmt.Printf("%s\n", data)
+		case "yaml":
+			data, err := yaml.Marshal(catalog)
+			if err != nil {
+				common.Usagef(ctx, "Error encoding endpoints: %v", err)
+			}
+			fmt.Printf("%s", data)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(endpointsCmd)
+	endpointsCmd.Flags().StringP("format", "o", "table", "output format: table, json or yaml")
+	endpointsCmd.Flags().StringP("base-url", "", "", "show URLs which use the specified base URL instead of the API gateway (overrides base_url)")
+	endpointsCmd.Flags().StringP("openapi-dir", "", "", "use the OpenAPI specs in the specified directory, instead of the built-in ones (overrides openapi_dir)")
+}
@@ -0,0 +1,120 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * logs command: displays or saves the logs of the pods of a CMS service
+ *
+ */
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cms"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+)
+
+// logsCmd command functions
+var logsCmd = &cobra.Command{
+	Use:   "logs <service>",
+	Short: "display or save the logs of the pods of a CMS service",
+	Long: fmt.Sprintf(`logs displays the logs of every container (including init containers) of every pod of a
+CMS service, with each line preceded by [pod/container]. The pods are found by the same name
+prefixes that the tests use. With --tarball, the logs are instead saved in a gzipped tar file,
+with one file for each container.
+
+Services: %s
+
+Example Commands:
+
+cmsdev logs cfs
+  # displays the logs of all of the cfs containers
+cmsdev logs bos --since 1h --grep 'ERROR|Traceback'
+  # displays the lines of the bos logs from the last hour which contain ERROR or Traceback
+cmsdev logs ims --follow
+  # streams the logs of all of the ims containers (including those of new pods) until interrupted
+cmsdev logs vcs --previous
+  # displays the logs of the previous instance of each vcs container which has restarted
+cmsdev logs cfs --tarball /tmp/cfs-logs.tar.gz
+  # saves the logs of all of the cfs containers in /tmp/cfs-logs.tar.gz`, strings.Join(cms.ServiceNames(), ", ")),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := common.MainContext()
+		since, _ := cmd.Flags().GetDuration("since")
+		follow, _ := cmd.Flags().GetBool("follow")
+		previous, _ := cmd.Flags().GetBool("previous")
+		grep, _ := cmd.Flags().GetString("grep")
+		tarball, _ := cmd.Flags().GetString("tarball")
+		fakeClusterFile, _ := cmd.Flags().GetString("fake-cluster")
+
+		if len(args) != 1 {
+			common.Usagef(ctx, "Exactly one service is required. Services are: %s", strings.Join(cms.ServiceNames(), ", "))
+		} else if !common.StringInArray(args[0], cms.ServiceNames()) {
+			common.Usagef(ctx, "Invalid service: '%s'. Services are: %s", args[0], strings.Join(cms.ServiceNames(), ", "))
+		} else if since < 0 {
+			common.Usagef(ctx, "--since must not be negative")
+		} else if follow && previous {
+			common.Usagef(ctx, "--follow and --previous are mutually exclusive")
+		} else if follow && len(tarball) > 0 {
+			common.Usagef(ctx, "--follow and --tarball are mutually exclusive")
+		}
+		opts := cms.LogOptions{Since: since, Follow: follow, Previous: previous}
+		if len(grep) > 0 {
+			re, err := regexp.Compile(grep)
+			if err != nil {
+				common.Usagef(ctx, "--grep: %v", err)
+			}
+			opts.Grep = re
+		}
+
+		// Query the Kubernetes objects in the specified file instead of the cluster, if requested
+		if err := k8s.UseFakeClusterFile(fakeClusterFile); err != nil {
+			common.Usagef(ctx, "--fake-cluster: %v", err)
+		}
+
+		// Stop getting the logs on SIGINT or SIGTERM, so that the streams are closed and the
+		// temporary file for the tar file is removed
+		handleSignals(ctx, "getting the logs")
+
+		if len(tarball) > 0 {
+			if err := cms.WriteServiceLogsTarball(ctx, args[0], tarball, opts); err != nil {
+				common.Failuref(ctx, "%v", err)
+			}
+			common.Successf(ctx, "Saved the %s logs in %s", args[0], tarball)
+		} else if err := cms.PrintServiceLogs(ctx, args[0], opts); err != nil {
+			common.Failuref(ctx, "%v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+	logsCmd.Flags().DurationP("since", "", 0, "only show logs newer than the specified duration, like 10m or 2h")
+	logsCmd.Flags().BoolP("follow", "f", false, "stream new logs until interrupted")
+	logsCmd.Flags().BoolP("previous", "p", false, "show the logs of the previous instance of each container which has restarted")
+	logsCmd.Flags().StringP("grep", "", "", "only show the lines which match the specified regular expression")
+	logsCmd.Flags().StringP("tarball", "", "", "save the logs in the specified gzipped tar file instead of displaying them")
+	logsCmd.Flags().StringP("fake-cluster", "", "", "query the Kubernetes objects in the specified YAML file instead of the cluster")
+}
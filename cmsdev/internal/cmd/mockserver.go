@@ -0,0 +1,80 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * mockserver.go
+ *
+ * mockserver command: serves mock BOS, CFS, and IMS endpoints for running tests without a system
+ *
+ */
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/mockserver"
+)
+
+// mockserverCmd command functions
+var mockserverCmd = &cobra.Command{
+	Use:   "mockserver",
+	Short: "serve mock BOS, CFS, and IMS endpoints",
+	Long: `mockserver serves in-memory implementations of the BOS v2, CFS v2/v3, and IMS v2/v3
+endpoints that cmsdev tests, so that the API tests for those services can be run without a
+system, using the --base-url option of the test command. Records are lost when the server exits.
+Requests made on behalf of a tenant which was not specified with --tenant are rejected.
+Example Commands:
+
+cmsdev mockserver
+  # serves the mock endpoints on localhost:5000
+cmsdev mockserver --listen :8080 --tenant vcluster-blue
+  # serves the mock endpoints on port 8080 of every interface, with one tenant
+cmsdev test bos cfs ims --base-url http://localhost:5000
+  # runs the bos, cfs, and ims tests against the mock server`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := common.MainContext()
+		listen, _ := cmd.Flags().GetString("listen")
+		tenants, _ := cmd.Flags().GetStringSlice("tenant")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+
+		if len(args) > 0 {
+			common.Usagef(ctx, "Invalid arguments: %s", strings.Join(args, " "))
+		}
+		// cmsdevVersion is found in version.go
+		common.CreateLogFile(ctx, "", cmsdevVersion, false, false, quiet, false, false, false)
+
+		common.Infof(ctx, "Serving mock BOS, CFS, and IMS endpoints on %s", listen)
+		if err := mockserver.New(tenants).ListenAndServe(listen); err != nil {
+			common.Failuref(ctx, "%v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mockserverCmd)
+	mockserverCmd.Flags().StringP("listen", "", strings.TrimPrefix(common.LOCALHOST, "http://"), "address to listen on")
+	mockserverCmd.Flags().StringSliceP("tenant", "", nil, "tenant which the mock services accept (may be repeated or comma-separated)")
+	mockserverCmd.Flags().BoolP("quiet", "q", false, "do not log each request")
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,6 +28,7 @@ import (
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
 var cfgFile string
@@ -84,10 +85,24 @@ func initConfig() {
 		viper.SetConfigName(".cmsdev")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	// read in environment variables that match, with the CMSDEV_ prefix
+	viper.SetEnvPrefix(configEnvPrefix)
+	viper.AutomaticEnv()
+	setConfigDefaults()
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Println("Using config file:", viper.ConfigFileUsed())
+	} else if _, notFound := err.(viper.ConfigFileNotFoundError); !notFound {
+		// Not having a config file is fine, but one which cannot be read is an error
+		fmt.Println("Error reading config file:", err)
+		os.Exit(1)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Println("Invalid cmsdev configuration:", err)
+		os.Exit(1)
 	}
+	common.SetConfig(cfg)
 }
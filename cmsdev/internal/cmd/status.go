@@ -0,0 +1,168 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * status command: displays the status of the pods and PVCs of the CMS services
+ *
+ */
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cms"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+)
+
+// Escape sequence which clears the terminal, between refreshes of the --watch table
+const clearScreen = "\033[H\033[2J"
+
+// A snapshot of the status of the CMS services, as written with --format json
+type statusSnapshot struct {
+	Time      string              `json:"time"`
+	Namespace string              `json:"namespace"`
+	Services  []cms.ServiceStatus `json:"services"`
+}
+
+// Returns the names of the services which are not healthy
+func unhealthyServices(statuses []cms.ServiceStatus) (names []string) {
+	for _, status := range statuses {
+		if !status.Healthy() {
+			names = append(names, status.Service)
+		}
+	}
+	return
+}
+
+// statusCmd command functions
+var statusCmd = &cobra.Command{
+	Use:   "status [service ...]",
+	Short: "display the status of the pods and PVCs of the CMS services",
+	Long: fmt.Sprintf(`status displays the status of the pods and PVCs of the CMS services in the configured
+namespace: for each pod, its phase, ready containers, restart count and node, and for each PVC,
+its phase and capacity. A service is OK if it has pods, all of which are running with all of
+their containers ready (or have completed), and all of its PVCs are bound. Without --watch, the
+exit status is 1 if any service is not OK. With --watch, a failure to get the status is reported
+as a warning, and the status is tried again at the next refresh.
+
+Services: %s
+
+Example Commands:
+
+cmsdev status
+  # displays the status of all of the CMS services
+cmsdev status cfs ims
+  # displays the status of cfs and ims
+cmsdev status --watch --interval 10s
+  # redisplays the status of all of the CMS services every 10 seconds, for example during an upgrade
+cmsdev status --format json
+  # displays the status in JSON (with --watch, one JSON object per line for each refresh)`, strings.Join(cms.ServiceNames(), ", ")),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := common.MainContext()
+		format, _ := cmd.Flags().GetString("format")
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		fakeClusterFile, _ := cmd.Flags().GetString("fake-cluster")
+
+		if format != "table" && format != "json" {
+			common.Usagef(ctx, "Invalid --format '%s': must be table or json", format)
+		} else if interval <= 0 {
+			common.Usagef(ctx, "--interval must be positive")
+		} else if cmd.Flags().Changed("interval") && !watch {
+			common.Usagef(ctx, "--interval is only valid with --watch")
+		}
+		for _, service := range args {
+			if !common.StringInArray(service, cms.ServiceNames()) {
+				common.Usagef(ctx, "Invalid service: '%s'. Services are: %s", service, strings.Join(cms.ServiceNames(), ", "))
+			}
+		}
+
+		// Query the Kubernetes objects in the specified file instead of the cluster, if requested
+		if err := k8s.UseFakeClusterFile(fakeClusterFile); err != nil {
+			common.Usagef(ctx, "--fake-cluster: %v", err)
+		}
+
+		for {
+			statuses, err := cms.GetServiceStatus(ctx, args...)
+			if err != nil && watch {
+				// The cluster may be briefly unreachable; try again at the next refresh. The warning
+				// goes to stderr, so that it does not break up the JSON lines on stdout.
+				fmt.Fprintf(os.Stderr, "WARNING: Unable to get the status of the CMS services (retrying in %v): %v\n", interval, err)
+				time.Sleep(interval)
+				continue
+			} else if err != nil {
+				common.Failuref(ctx, "Unable to get the status of the CMS services: %v", err)
+			}
+			now := time.Now()
+			switch {
+			case format == "json" && watch:
+				data, err := json.Marshal(statusSnapshot{Time: now.Format(time.RFC3339), Namespace: common.NAMESPACE, Services: statuses})
+				if err != nil {
+					common.Failuref(ctx, "Error encoding status: %v", err)
+				}
+				fmt.Printf("%s\n", data)
+			case format == "json":
+				data, err := json.MarshalIndent(statusSnapshot{Time: now.Format(time.RFC3339), Namespace: common.NAMESPACE, Services: statuses}, "", "  ")
+				if err != nil {
+					common.Failuref(ctx, "Error encoding status: %v", err)
+				}
+				fmt.Printf("%s\n", data)
+			default:
+				if watch {
+					fmt.Print(clearScreen)
+					fmt.Printf("Every %v: CMS services in namespace %s (%s)\n\n", interval, common.NAMESPACE, now.Format(time.RFC1123))
+				}
+				cms.ListServices(statuses)
+			}
+			if watch {
+				time.Sleep(interval)
+				continue
+			}
+
+			unhealthy := unhealthyServices(statuses)
+			if len(unhealthy) == 0 {
+				if format == "table" {
+					fmt.Printf("All %d CMS services are OK\n", len(statuses))
+				}
+				return
+			}
+			if format == "table" {
+				fmt.Printf("%d CMS services are NOT OK: %s\n", len(unhealthy), strings.Join(unhealthy, ", "))
+			}
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().StringP("format", "o", "table", "output format: table or json")
+	statusCmd.Flags().BoolP("watch", "w", false, "redisplay the status until interrupted")
+	statusCmd.Flags().DurationP("interval", "", 5*time.Second, "time between refreshes with --watch")
+	statusCmd.Flags().StringP("fake-cluster", "", "", "query the Kubernetes objects in the specified YAML file instead of the cluster")
+}
@@ -1,7 +1,7 @@
 //
 //  MIT License
 //
-//  (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+//  (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 //  Permission is hereby granted, free of charge, to any person obtaining a
 //  copy of this software and associated documentation files (the "Software"),
@@ -30,63 +30,69 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/bos"
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/cfs"
-	con "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/conman"
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/ims"
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/ipxe_tftp"
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/vcs"
-)
-
-// Test timeouts (in seconds)
-// (test will not retry after this amount of time)
-var TestTimeouts = map[string]int64{
-	"bos":    300,
-	"cfs":    300,
-	"conman": 300,
-	"ipxe":   300,
-	"tftp":   300,
-	"vcs":    300,
-	"gitea":  300,
-}
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/report"
 
-// Default test timeout (in seconds)
-// This value is used if service is not listed in previous map
-const DefaultTestTimeout int64 = 120
+	// Service test packages register their tests when they are initialized
+	_ "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/barebones"
+	_ "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/bos"
+	_ "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/cfs"
+	_ "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/cfs_sessions_rc"
+	_ "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/conman"
+	_ "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/ims"
+	_ "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/ipxe_tftp"
+	_ "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/vcs"
+)
 
 // Run the specified test
-func RunTest(service string, includeCLI, includeTenant bool) bool {
-	switch service {
-	case "bos":
-		return bos.IsBOSRunning(includeCLI, includeTenant)
-	case "cfs":
-		return cfs.IsCFSRunning(includeCLI, includeTenant)
-	case "conman":
-		return con.IsConmanRunning()
-	case "ims":
-		return ims.IsIMSRunning(includeCLI)
-	case "ipxe", "tftp":
-		return ipxe_tftp.AreTheyRunning()
-	case "vcs", "gitea":
-		return vcs.IsVCSRunning()
+func RunTest(ctx context.Context, service string, includeCLI, includeTenant bool) (passed bool) {
+	serviceTest, ok := registry.Lookup(service)
+	if !ok {
+		common.Usagef(ctx, "Programming logic error: this line should never be reached. Invalid service (%s), but it should already have been validated!", service)
+		return false
 	}
-	common.Usagef("Programming logic error: this line should never be reached. Invalid service (%s), but it should already have been validated!", service)
-	return false
+	// Whatever happens, delete any resources this attempt created but did not delete
+	defer common.RunCleanups(ctx)
+	// A panic in a test fails that attempt, rather than ending the whole run
+	defer func() {
+		if r := recover(); r != nil {
+			common.Errorf(ctx, "%s test panicked: %v\n%s", service, r, debug.Stack())
+			passed = false
+		}
+	}()
+	common.ResetInsecureFallback(ctx)
+	passed = serviceTest.Run(registry.RunOptions{Context: ctx, IncludeCLI: includeCLI, IncludeTenant: includeTenant})
+	// We return failure if we had to retry API requests insecurely
+	if passed && common.UsedInsecureFallback(ctx) {
+		common.Errorf(ctx, "Even though all operations succeeded, test failed because insecure operations were required")
+		passed = false
+	}
+	return
 }
 
-func GetTimeout(service string) (timeout int64) {
-	timeout, ok := TestTimeouts[service]
-	if !ok {
-		timeout = DefaultTestTimeout
+// Test timeout (in seconds) for the specified service
+// (test will not retry after this amount of time)
+// This can be overridden using test_timeouts in the cmsdev configuration
+func GetTimeout(service string) int64 {
+	if serviceTest, ok := registry.Lookup(service); ok {
+		return common.TestTimeout(serviceTest.Name, serviceTest.DefaultTimeout)
 	}
-	return
+	return registry.DefaultTimeout
 }
 
 // The first sleep time is 5 seconds, then it is increased by 5 seconds for each
@@ -112,45 +118,58 @@ func GetSleepDuration(attempt int, stopTime, timeout int64) time.Duration {
 	return time.Duration(sleepSeconds) * time.Second
 }
 
-func DoTestWithRetry(service string, includeCLI, includeTenant bool) bool {
+func DoTestWithRetry(ctx context.Context, service string, includeCLI, includeTenant bool) bool {
 	testPassed, finalTry := false, false
 	timeout := GetTimeout(service)
-	common.Infof("Test retry timeout for this service test is %d seconds", timeout)
+	common.Infof(ctx, "Test retry timeout for this service test is %d seconds", timeout)
 	stopTime := timeout + time.Now().Unix()
 	for n := 1; ; n++ {
 		if (time.Now().Unix() + 1) >= stopTime {
-			common.Infof("Final attempt")
+			common.Infof(ctx, "Final attempt")
 			finalTry = true
 		} else {
-			common.Infof("Attempt #%d", n)
+			common.Infof(ctx, "Attempt #%d", n)
 		}
-		common.SetRunSubTag(strconv.Itoa(n))
-		testPassed = RunTest(service, includeCLI, includeTenant)
-		common.UnsetRunSubTag()
+		common.SetRunSubTag(ctx, strconv.Itoa(n))
+		common.SetTestAttempt(ctx, n)
+		testPassed = RunTest(ctx, service, includeCLI, includeTenant)
+		common.UnsetRunSubTag(ctx)
 		if testPassed {
 			return true
-		} else if finalTry {
+		} else if finalTry || common.Cancelled() {
 			return false
 		} else if time.Now().Unix() >= (stopTime + 30) {
-			common.Infof("Not retrying because stop time has already been exceeded by at least 30 seconds")
+			common.Infof(ctx, "Not retrying because stop time has already been exceeded by at least 30 seconds")
 			return false
 		}
 		sleepDuration := GetSleepDuration(n, stopTime, timeout)
-		common.Infof("Attempt failed; waiting %v before retrying", sleepDuration)
-		time.Sleep(sleepDuration)
+		common.Infof(ctx, "Attempt failed; waiting %v before retrying", sleepDuration)
+		if !common.Sleep(ctx, sleepDuration) {
+			common.Infof(ctx, "Not retrying because cmsdev was interrupted")
+			return false
+		}
 	}
-	common.Errorf("PROGRAMMING LOGIC ERROR: DoTestWithRetry: This line should never be reached")
-	return false
 }
 
-func DoTest(service string, retry, includeCLI, includeTenant bool) bool {
+func DoTest(ctx context.Context, service string, retry, includeCLI, includeTenant bool) bool {
 	if retry {
-		return DoTestWithRetry(service, includeCLI, includeTenant)
+		return DoTestWithRetry(ctx, service, includeCLI, includeTenant)
 	} else {
-		return RunTest(service, includeCLI, includeTenant)
+		common.SetTestAttempt(ctx, 1)
+		return RunTest(ctx, service, includeCLI, includeTenant)
 	}
 }
 
+// The service tests run by "all", which leaves out those that are only run when named
+func GetAllTestNamesList() (services []string) {
+	for _, s := range GetTestNamesList(true) {
+		if serviceTest, _ := registry.Lookup(s); !serviceTest.ExplicitOnly {
+			services = append(services, s)
+		}
+	}
+	return
+}
+
 func GetTestNamesList(excludeAliases bool) []string {
 	var s string
 	services := make([]string, 0)
@@ -159,53 +178,169 @@ func GetTestNamesList(excludeAliases bool) []string {
 		// Start with the "all" alias
 		services = append(services, "all")
 	}
-	// Append the remaining services (omitting aliases if specified)
-	for _, s = range common.CMSServices {
-		if excludeAliases && common.StringInArray(s, common.CMSServicesDuplicates) {
-			// Skip this one, as it is an alias of another one
-			continue
-		}
+	// Append the registered services (omitting aliases if specified)
+	for _, s = range registry.Names(!excludeAliases) {
 		services = append(services, s)
 	}
 	return services
 }
 
+// Describe the valid service tests, one per line
+func GetTestDescriptions(excludeAliases, listSubtests bool) string {
+	var lines []string
+
+	width := 8
+	for _, s := range GetTestNamesList(excludeAliases) {
+		if len(s) > width {
+			width = len(s)
+		}
+	}
+	if !excludeAliases {
+		lines = append(lines, fmt.Sprintf("%-*s %s", width, "all", "Run all service tests, except those not run by all"))
+	}
+	for _, s := range GetTestNamesList(true) {
+		serviceTest, _ := registry.Lookup(s)
+		description := serviceTest.Description
+		var modes []string
+		if serviceTest.SupportsCLI {
+			modes = append(modes, "--include-cli")
+		}
+		if serviceTest.SupportsTenant {
+			modes = append(modes, "--include-tenant")
+		}
+		if len(modes) > 0 {
+			description = fmt.Sprintf("%s (supports %s)", description, strings.Join(modes, ", "))
+		}
+		if serviceTest.ExplicitOnly {
+			description += " (not run by all)"
+		}
+		lines = append(lines, fmt.Sprintf("%-*s %s", width, s, description))
+		if listSubtests {
+			for _, subtest := range serviceTest.Subtests {
+				lines = append(lines, fmt.Sprintf("%-*s   %s", width, "", subtest))
+			}
+		}
+		if !excludeAliases {
+			for _, alias := range serviceTest.Aliases {
+				lines = append(lines, fmt.Sprintf("%-*s Alias of %s", width, alias, s))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func GetTestNamesString(excludeAliases bool) string {
 	return strings.Join(GetTestNamesList(excludeAliases), " ")
 }
 
-func RunTests(services []string, retry, noclean, includeCLI, includeTenant bool) (passed, failed []string) {
+// Run the specified service tests, up to parallel of them at a time. Each test runs in
+// its own goroutine with a buffered run of its own, so its output is written as a single block
+// when it completes. Results are returned in the order the services were specified.
+func RunTestsInParallel(ctx context.Context, services []string, parallel int, retry, includeCLI, includeTenant bool) (passed, failed []string, results []*common.ServiceResult) {
+	var wg sync.WaitGroup
+
+	results = make([]*common.ServiceResult, len(services))
+	slots := make(chan struct{}, parallel)
+	common.Infof(ctx, "Running %d service tests, up to %d at a time", len(services), parallel)
+	for i, s := range services {
+		wg.Add(1)
+		go func(i int, s string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if common.Cancelled() {
+				results[i] = notRunResult(s)
+				return
+			}
+			common.RunBuffered(ctx, func(ctx context.Context) {
+				common.SetTestService(ctx, s)
+				results[i] = common.StartServiceResult(ctx, s)
+				common.EndServiceResult(ctx, results[i], DoTest(ctx, s, retry, includeCLI, includeTenant))
+				common.UnsetTestService(ctx)
+			})
+		}(i, s)
+	}
+	wg.Wait()
+
+	for i, s := range services {
+		if results[i].Passed {
+			passed = append(passed, s)
+		} else {
+			failed = append(failed, s)
+		}
+	}
+	return
+}
+
+func RunTests(ctx context.Context, services []string, parallel int, retry, noclean, includeCLI, includeTenant bool) (passed, failed []string, results []*common.ServiceResult) {
 	var s string
 
 	// Create temporary directory
-	if err := common.CreateTmpDir(); err != nil {
-		common.Failuref("Failed creating temporary directory: %v", err)
+	if err := common.CreateTmpDir(ctx); err != nil {
+		common.Failuref(ctx, "Failed creating temporary directory: %v", err)
 	}
 	if noclean {
-		common.Infof("no-cleanup specified; temporary directory will not be removed at end of execution: '%s'", common.TmpDir)
+		common.Infof(ctx, "no-cleanup specified; temporary directory will not be removed at end of execution: '%s'", common.TmpDir)
 	} else {
 		// Remove temporary directory on function exit
-		defer common.DeleteTmpDir()
+		defer common.DeleteTmpDir(ctx)
 	}
 
-	for _, s = range services {
-		common.SetTestService(s)
-		if DoTest(s, retry, includeCLI, includeTenant) {
-			passed = append(passed, s)
-		} else {
-			failed = append(failed, s)
+	if parallel > 1 && len(services) > 1 {
+		passed, failed, results = RunTestsInParallel(ctx, services, parallel, retry, includeCLI, includeTenant)
+	} else {
+		for _, s = range services {
+			if common.Cancelled() {
+				failed = append(failed, s)
+				results = append(results, notRunResult(s))
+				continue
+			}
+			common.SetTestService(ctx, s)
+			result := common.StartServiceResult(ctx, s)
+			if DoTest(ctx, s, retry, includeCLI, includeTenant) {
+				passed = append(passed, s)
+				common.EndServiceResult(ctx, result, true)
+			} else {
+				failed = append(failed, s)
+				common.EndServiceResult(ctx, result, false)
+			}
+			results = append(results, result)
+			common.UnsetTestService(ctx)
 		}
-		common.UnsetTestService()
 	}
 	// Capture OS specific information after test failure.
 	if len(failed) > 0 {
-		common.ArtifactGetAdditionalInfo()
+		common.ArtifactGetAdditionalInfo(ctx)
 	}
 	// Compress test artifacts, if any
-	common.CompressArtifacts()
+	common.CompressArtifacts(ctx)
 	return
 }
 
+// The signal which interrupted cmsdev, if any
+var interruptSignal atomic.Value
+
+// On SIGINT or SIGTERM, cancel the runs, so that what they are doing (described by stopping,
+// like "the tests") stops. For the test command, RunTests returns once the tests have stopped,
+// and cmsdev then exits with failure, which deletes any resources the tests have created but
+// not deleted. A second signal is not caught, so it ends cmsdev immediately.
+func handleSignals(ctx context.Context, stopping string) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigs
+		signal.Stop(sigs)
+		interruptSignal.Store(sig)
+		common.Warnf(ctx, "Received %v signal; stopping %s", sig, stopping)
+		common.CancelRuns()
+	}()
+}
+
+// The result of a service test which was not run because cmsdev was interrupted
+func notRunResult(service string) *common.ServiceResult {
+	return &common.ServiceResult{Name: service, Start: time.Now(), Errors: []string{"Not run because cmsdev was interrupted"}}
+}
+
 var longHelpText = fmt.Sprintf(`test command runs service tests.
 
 Valid service tests: %s
@@ -216,6 +351,8 @@ cmsdev test -l
   # list all valid services to test
 cmsdev test -l --exclude-aliases
   # list all valid services to test, excluding aliases
+cmsdev test -l --subtests
+  # list all valid services to test, and the names of their subtests
 cmsdev test conman
   # runs conman tests
 cmsdev test tftp --no-log -q
@@ -223,7 +360,37 @@ cmsdev test tftp --no-log -q
 cmsdev test cfs -r --verbose
   # runs cfs tests with verbosity and retry on failure
 cmsdev test bos --include-cli --include-tenant
-  # runs bos tests including both CLI and tenant tests`, GetTestNamesString(false))
+  # runs bos tests including both CLI and tenant tests
+cmsdev test ims --only signingkeys
+  # runs only the ims signing keys subtest
+cmsdev test all --skip vcs.clone --skip ims.cli
+  # runs all service tests except the vcs clone subtest and the ims CLI subtests
+cmsdev test all -r --parallel 6
+  # runs all service tests with retry, up to 6 at a time
+cmsdev test all --report-junit results.xml --report-json results.json
+  # runs all service tests and writes JUnit XML and JSON reports of the results
+cmsdev test cfs --include-cli --record /tmp/cfs-run
+  # runs cfs tests, saving their API requests and CLI commands in /tmp/cfs-run
+cmsdev test cfs --include-cli --replay /tmp/cfs-run --fake-cluster cfs-objects.yaml
+  # runs cfs tests against the requests and commands saved in /tmp/cfs-run, and the Kubernetes
+  # objects in cfs-objects.yaml (Kubernetes API queries are not recorded, so without --fake-cluster
+  # the subtests which need the cluster are skipped)
+cmsdev test bos cfs ims --base-url http://localhost:5000
+  # runs bos, cfs, and ims tests against "cmsdev mockserver", skipping the subtests which need the cluster
+cmsdev test bos --only pods --fake-cluster bos-pods.yaml
+  # runs the bos pod checks against the Kubernetes objects in bos-pods.yaml instead of the cluster
+cmsdev test cfs ims --latency-budgets budgets.yaml
+  # runs cfs and ims tests, failing if any endpoint's p95 latency exceeds its budget in budgets.yaml
+cmsdev test bos --openapi-dir /tmp/specs
+  # runs bos tests, validating the responses against /tmp/specs/bos.yaml instead of the built-in BOS spec
+cmsdev test bos cfs ims --contract
+  # runs bos, cfs, and ims tests, failing if any response has a status code not documented in the OpenAPI specs
+CMSDEV_CA_BUNDLE=/etc/pki/trust/anchors/platform-ca-certs.crt cmsdev test bos
+  # runs bos tests, trusting the platform CA certificates for the API gateway
+CMSDEV_CFS_SESSIONS_RC="max_sessions=50,cfs_version=v2" cmsdev test cfs-sessions-rc --only multi-delete
+  # runs the CFS sessions race condition multi-delete subtest with 50 sessions, using the CFS v2 API
+CMSDEV_BAREBONES="arch=arm,keep_on_success=true" cmsdev test barebones
+  # customizes the CSM barebones arm image and boots an arm compute node with it, keeping the resources`, GetTestNamesString(false))
 
 // testCmd command functions
 var testCmd = &cobra.Command{
@@ -231,6 +398,7 @@ var testCmd = &cobra.Command{
 	Short: "cms test services command",
 	Long:  longHelpText,
 	Run: func(cmd *cobra.Command, args []string) {
+		ctx := common.MainContext()
 		noCleanup, _ := cmd.Flags().GetBool("no-cleanup")
 		noLogs, _ := cmd.Flags().GetBool("no-log")
 		logsDir, _ := cmd.Flags().GetString("log-dir")
@@ -241,27 +409,50 @@ var testCmd = &cobra.Command{
 		excludeAliases, _ := cmd.Flags().GetBool("exclude-aliases")
 		includeCLI, _ := cmd.Flags().GetBool("include-cli")
 		includeTenant, _ := cmd.Flags().GetBool("include-tenant")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		reportJUnit, _ := cmd.Flags().GetString("report-junit")
+		reportJSON, _ := cmd.Flags().GetString("report-json")
+		listSubtests, _ := cmd.Flags().GetBool("subtests")
+		onlySubtests, _ := cmd.Flags().GetStringSlice("only")
+		skipSubtests, _ := cmd.Flags().GetStringSlice("skip")
+		recordDir, _ := cmd.Flags().GetString("record")
+		replayDir, _ := cmd.Flags().GetString("replay")
+		fakeClusterFile, _ := cmd.Flags().GetString("fake-cluster")
+		applyBaseURLFlag(ctx, cmd)
+		applyLogFormatFlag(ctx, cmd)
+		applyLatencyBudgetsFlag(cmd)
+		applyOpenAPIDirFlag(cmd)
+		applyContractFlag(cmd)
+		applyInsecureFlag(cmd)
 
 		if quiet && verbose {
-			common.Usagef("--quiet and --verbose are mutually exclusive")
+			common.Usagef(ctx, "--quiet and --verbose are mutually exclusive")
+		} else if parallel < 1 {
+			common.Usagef(ctx, "--parallel must be at least 1")
+		} else if recordDir != "" && replayDir != "" {
+			common.Usagef(ctx, "--record and --replay are mutually exclusive")
+		} else if (recordDir != "" || replayDir != "") && parallel > 1 {
+			common.Usagef(ctx, "--record and --replay are not valid with --parallel")
 		}
 
 		if listTests {
 			// --list was passed
-			if noCleanup || noLogs || logsDir != "" || retry || quiet || verbose || includeCLI || includeTenant {
-				common.Usagef("--include-cli, --include-tenant, --no-cleanup, --no-log, --log-dir, --retry, --quiet, and --verbose are not valid with --list")
+			if noCleanup || noLogs || logsDir != "" || retry || quiet || verbose || includeCLI || includeTenant || parallel > 1 || reportJUnit != "" || reportJSON != "" || len(onlySubtests) > 0 || len(skipSubtests) > 0 || recordDir != "" || replayDir != "" || fakeClusterFile != "" || cmd.Flags().Changed("latency-budgets") || cmd.Flags().Changed("openapi-dir") || cmd.Flags().Changed("contract") || cmd.Flags().Changed("insecure") {
+				common.Usagef(ctx, "--contract, --fake-cluster, --include-cli, --insecure, --latency-budgets, --include-tenant, --openapi-dir, --no-cleanup, --no-log, --log-dir, --only, --parallel, --record, --replay, --report-junit, --report-json, --retry, --skip, --quiet, and --verbose are not valid with --list")
 			} else if len(args) > 0 {
-				common.Usagef("Invalid arguments specified with --list: %s", strings.Join(args, " "))
+				common.Usagef(ctx, "Invalid arguments specified with --list: %s", strings.Join(args, " "))
 			}
-			common.Infof(GetTestNamesString(excludeAliases))
+			common.Infof(ctx, "%s", GetTestDescriptions(excludeAliases, listSubtests))
 			return
 		}
 
 		// --list was not passed
 		if excludeAliases {
-			common.Usagef("--exclude-aliases is only valid with --list")
+			common.Usagef(ctx, "--exclude-aliases is only valid with --list")
+		} else if listSubtests {
+			common.Usagef(ctx, "--subtests is only valid with --list")
 		} else if len(args) < 1 {
-			common.Usagef("Argument required, provide one or more of the following: %s\n", GetTestNamesString(false))
+			common.Usagef(ctx, "Argument required, provide one or more of the following: %s\n", GetTestNamesString(false))
 		}
 
 		var s string
@@ -272,38 +463,122 @@ var testCmd = &cobra.Command{
 			// do some command line args checking
 			if s == "all" {
 				allServices = true
-			} else if !common.StringInArray(s, common.CMSServices) {
-				common.Usagef("Invalid test: '%s'. Supported tests are: %s", s, GetTestNamesString(false))
+			} else if _, ok := registry.Lookup(s); !ok {
+				common.Usagef(ctx, "Invalid test: '%s'. Supported tests are: %s", s, GetTestNamesString(false))
 			} else if !allServices {
 				services = append(services, s)
 			}
 		}
 		if allServices {
-			services = GetTestNamesList(true)
+			services = GetAllTestNamesList()
 		} else if len(services) == 0 {
-			common.Usagef("Argument required, provide one or more of the following: %s\n", GetTestNamesString(false))
+			common.Usagef(ctx, "Argument required, provide one or more of the following: %s\n", GetTestNamesString(false))
+		}
+
+		if err := registry.SetSubtestSelection(onlySubtests, skipSubtests); err != nil {
+			common.Usagef(ctx, "%s", err.Error())
+		}
+
+		// Load the OpenAPI specs from the specified directory, if any, in place of the embedded ones
+		if specDir := common.GetConfig().OpenAPIDir; len(specDir) > 0 {
+			if err := common.LoadOpenAPIDir(specDir); err != nil {
+				common.Usagef(ctx, "%v", err)
+			}
+		}
+
+		// Load the latency budgets, if any, before running anything (their endpoints are checked against the specs)
+		var latencyBudgets map[string]time.Duration
+		if budgetsFile := common.GetConfig().LatencyBudgets; len(budgetsFile) > 0 {
+			var err error
+			if latencyBudgets, err = common.LoadLatencyBudgets(budgetsFile); err != nil {
+				common.Usagef(ctx, "%v", err)
+			}
+		}
+
+		// Query the Kubernetes objects in the specified file instead of the cluster, if requested
+		if len(fakeClusterFile) > 0 {
+			fakeCluster, err := k8s.LoadFakeCluster(fakeClusterFile)
+			if err != nil {
+				common.Usagef(ctx, "--fake-cluster: %v", err)
+			}
+			k8s.SetCluster(fakeCluster)
 		}
 
 		logs := !noLogs
 
 		// create log file if logs, ignore logsDir if !logs
 		// cmsdevVersion is found in version.go
-		common.CreateLogFile(logsDir, cmsdevVersion, logs, retry, quiet, verbose, includeCLI, noCleanup)
+		common.CreateLogFile(ctx, logsDir, cmsdevVersion, logs, retry, quiet, verbose, includeCLI, noCleanup)
 
 		// Initialize variables related to saving CT test artifacts
-		common.InitArtifacts()
+		common.InitArtifacts(ctx)
+
+		// Record or replay the API requests and CLI commands, if requested
+		if len(recordDir) > 0 {
+			if err := common.StartRecording(ctx, recordDir, cmsdevVersion); err != nil {
+				common.Failuref(ctx, "%v", err)
+			}
+		} else if len(replayDir) > 0 {
+			if err := common.StartReplay(ctx, replayDir); err != nil {
+				common.Failuref(ctx, "%v", err)
+			}
+		}
+
+		// Make sure test resources are deleted if cmsdev is interrupted
+		handleSignals(ctx, "the tests")
+
+		startTime := time.Now()
+		passed, failed, results := RunTests(ctx, services, parallel, retry, noCleanup, includeCLI, includeTenant)
+		if sig := interruptSignal.Load(); sig != nil {
+			// The tests have stopped, so their resources can be deleted without racing with them
+			common.Failuref(ctx, "Received %v signal; exiting", sig)
+		}
 
-		passed, failed := RunTests(services, retry, noCleanup, includeCLI, includeTenant)
+		// Summarize the API latencies and retries, and fail if any endpoint is over its budget
+		common.PrintLatencySummary(ctx)
+		common.PrintRetrySummary(ctx)
+		if len(latencyBudgets) > 0 {
+			result := common.CheckLatencyBudgets(ctx, latencyBudgets)
+			results = append(results, result)
+			if result.Passed {
+				passed = append(passed, result.Name)
+			} else {
+				failed = append(failed, result.Name)
+			}
+		}
+
+		// In contract mode, fail if any response had a status code which is not in the OpenAPI spec
+		if common.GetConfig().Contract {
+			result := common.CheckContract(ctx)
+			results = append(results, result)
+			if result.Passed {
+				passed = append(passed, result.Name)
+			} else {
+				failed = append(failed, result.Name)
+			}
+		}
+
+		// Write machine-readable reports, if requested
+		if len(reportJUnit) > 0 {
+			if err := report.WriteJUnit(ctx, reportJUnit, startTime, results); err != nil {
+				common.Error(ctx, err)
+			}
+		}
+		if len(reportJSON) > 0 {
+			if err := report.WriteJSON(ctx, reportJSON, cmsdevVersion, startTime, results, common.LatencySummary(), common.RetrySummary()); err != nil {
+				common.Error(ctx, err)
+			}
+		}
 
 		if len(failed) == 0 {
-			common.Successf("All %d service tests passed: %s", len(passed), strings.Join(passed, ", "))
+			common.Successf(ctx, "All %d service tests passed: %s", len(passed), strings.Join(passed, ", "))
 		} else if len(passed) == 0 {
-			common.Failuref("%d service tests FAILED (%s), 0 passed", len(failed), strings.Join(failed, ", "))
+			common.Failuref(ctx, "%d service tests FAILED (%s), 0 passed", len(failed), strings.Join(failed, ", "))
 		}
-		common.Failuref("%d service tests FAILED (%s), %d passed (%s)", len(failed), strings.Join(failed, ", "),
+		common.Failuref(ctx, "%d service tests FAILED (%s), %d passed (%s)", len(failed), strings.Join(failed, ", "),
 			len(passed), strings.Join(passed, ", "))
 
-		common.Errorf("PROGRAMMING LOGIC ERROR: test.go: This line should never be reached")
+		common.Errorf(ctx, "PROGRAMMING LOGIC ERROR: test.go: This line should never be reached")
 		return
 	},
 }
@@ -311,6 +586,7 @@ var testCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(testCmd)
 	testCmd.Flags().StringP("log-dir", "", "", "specify log directory")
+	testCmd.Flags().StringP("log-format", "", "", "log file format: text or json (overrides log_format)")
 	testCmd.Flags().BoolP("no-cleanup", "", false, "do not remove temporary test files")
 	testCmd.Flags().BoolP("no-log", "", false, "do not log to a file")
 	testCmd.Flags().BoolP("retry", "r", false, "retry on failure")
@@ -318,6 +594,20 @@ func init() {
 	testCmd.Flags().BoolP("verbose", "v", false, "verbose mode")
 	testCmd.Flags().BoolP("list", "l", false, "list valid service tests")
 	testCmd.Flags().BoolP("exclude-aliases", "", false, "exclude aliases from list of valid service tests")
+	testCmd.Flags().BoolP("subtests", "", false, "include subtest names in list of valid service tests")
 	testCmd.Flags().BoolP("include-cli", "", false, "run both CLI and API tests")
 	testCmd.Flags().BoolP("include-tenant", "", false, "run tenant tests")
+	testCmd.Flags().IntP("parallel", "", 1, "maximum number of service tests to run at the same time")
+	testCmd.Flags().StringP("report-junit", "", "", "write a JUnit XML report of the test results to the specified file")
+	testCmd.Flags().StringP("report-json", "", "", "write a JSON report of the test results to the specified file")
+	testCmd.Flags().StringSliceP("only", "", nil, "run only the specified subtests (may be repeated or comma-separated)")
+	testCmd.Flags().StringSliceP("skip", "", nil, "skip the specified subtests (may be repeated or comma-separated)")
+	testCmd.Flags().StringP("record", "", "", "save the API requests and CLI commands made by the tests to the specified directory")
+	testCmd.Flags().StringP("replay", "", "", "replay the API requests and CLI commands saved in the specified directory by --record")
+	testCmd.Flags().StringP("base-url", "", "", "send API requests to the specified URL instead of the API gateway (overrides base_url)")
+	testCmd.Flags().StringP("latency-budgets", "", "", "fail if the p95 latency of an endpoint exceeds its budget in the specified YAML file (overrides latency_budgets)")
+	testCmd.Flags().BoolP("contract", "", false, "fail if any API response has a status code which is not documented in the OpenAPI spec of its service (overrides contract)")
+	testCmd.Flags().StringP("openapi-dir", "", "", "validate responses against the OpenAPI specs in the specified directory, instead of the built-in ones (overrides openapi_dir)")
+	testCmd.Flags().StringP("fake-cluster", "", "", "query the Kubernetes objects in the specified YAML file instead of the cluster")
+	testCmd.Flags().BoolP("insecure", "", false, "do not verify the TLS certificates of the API gateway (overrides insecure)")
 }
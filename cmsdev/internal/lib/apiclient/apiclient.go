@@ -0,0 +1,130 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * apiclient.go
+ *
+ * Requests and error handling shared by the clients for the CMS service APIs
+ *
+ */
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	resty "gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// ProblemDetails is the body of an error response from a CMS service (RFC 7807)
+type ProblemDetails struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// APIError is returned when a service responds with an unexpected status code. Problem holds
+// the problem details from the response body, if it had any.
+type APIError struct {
+	StatusCode int
+	Problem    *ProblemDetails
+	err        error
+}
+
+func (e *APIError) Error() string {
+	if e.Problem != nil && len(e.Problem.Detail) > 0 {
+		return fmt.Sprintf("%v (%s)", e.err, e.Problem.Detail)
+	}
+	return e.err.Error()
+}
+
+func (e *APIError) Unwrap() error { return e.err }
+
+// IsStatus returns true if err is an APIError for the specified status code
+func IsStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+func IsNotFound(err error) bool { return IsStatus(err, http.StatusNotFound) }
+
+// Request makes a request to the specified URL, as the specified tenant (if not empty), with
+// the specified JSON body (if not nil), and checks that the response has the expected status
+// code. The request is logged, and the response is checked against the OpenAPI spec of the
+// service. If the status code is not the expected one, the error is an APIError.
+func Request(ctx context.Context, method, url, tenant string, params common.Params, body interface{}, expectedStatus int) (*resty.Response, error) {
+	var options []common.RequestOption
+	if body != nil {
+		options = append(options, common.WithBody(body))
+	}
+	var resp *resty.Response
+	var err error
+	if len(tenant) == 0 {
+		resp, err = test.RestfulVerifyStatus(ctx, method, url, params, expectedStatus, options...)
+	} else {
+		resp, err = test.TenantRestfulVerifyStatus(ctx, method, url, tenant, params, expectedStatus, options...)
+	}
+	if err != nil && resp != nil && resp.StatusCode() != expectedStatus {
+		apiErr := &APIError{StatusCode: resp.StatusCode(), err: err}
+		var problem ProblemDetails
+		if json.Unmarshal(resp.Body(), &problem) == nil && (len(problem.Title) > 0 || len(problem.Detail) > 0) {
+			apiErr.Problem = &problem
+		}
+		return resp, apiErr
+	}
+	return resp, err
+}
+
+// Get makes a GET request to the specified URL (see Request) and returns the response body
+func Get(ctx context.Context, url, tenant string, params common.Params) ([]byte, error) {
+	resp, err := Request(ctx, "GET", url, tenant, params, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+// Decode decodes the JSON in data into the object that v points to. The service and kind of
+// object (like "BOS" and "session") are used in the error.
+func Decode(service string, data []byte, kind string, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Error decoding %s %s: %v", service, kind, err)
+	}
+	return nil
+}
+
+// CheckName returns an error if the name (or ID) of an object is empty. The service, kind of
+// object and field (like "BOS", "session" and "name") are used in the error.
+func CheckName(service, kind, field, name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("%s %s has a 0-length %s", service, kind, field)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * token.go
+ *
+ * Access token provider, which caches the token for API requests and the cray CLI, and gets a
+ * new one before it expires
+ *
+ */
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+)
+
+// AccessTokenEnvVar is the environment variable which may hold the access token. It takes
+// precedence over the auth settings of the config file.
+const AccessTokenEnvVar = "CMSDEV_ACCESS_TOKEN"
+
+// CredentialsFileEnvVar is the environment variable which the cray CLI reads the name of its
+// credentials file from. If it is set, the file is used when the auth settings do not name a
+// token or credentials file.
+const CredentialsFileEnvVar = "CRAY_CREDENTIALS"
+
+// Token is an access token, and where it came from
+type Token struct {
+	AccessToken string
+	// Zero if the expiry time of the token is not known
+	Expiry time.Time
+	// Describes where the token came from, like "Keycloak client admin-client"
+	Source string
+	// When to get a new token, ahead of the expiry time
+	refreshAt time.Time
+}
+
+// A source of access tokens. Tokens from Keycloak are refreshed by requesting a new one; tokens
+// from a file are refreshed by reading the file again, in case it has been updated.
+type tokenSource struct {
+	name  string
+	fetch func() (Token, error)
+}
+
+var cachedToken *Token
+var warnedToken string
+var tokenLock sync.Mutex
+
+// Choose the token source from the environment and the auth settings, in the order which the
+// README documents
+func currentSource(ctx context.Context) tokenSource {
+	cfg := common.GetConfig().Auth
+	// There is no live system to get a token from when replaying a recording
+	if common.Replaying() {
+		return fixedSource("replayed recording", "replayed-token")
+	}
+	if value := strings.TrimSpace(os.Getenv(AccessTokenEnvVar)); len(value) > 0 {
+		name := "environment variable " + AccessTokenEnvVar
+		return tokenSource{name: name, fetch: func() (Token, error) {
+			return parseToken(name, []byte(value), time.Now(), false)
+		}}
+	}
+	if len(cfg.TokenFile) > 0 {
+		return fileSource("token file "+cfg.TokenFile, cfg.TokenFile, false)
+	}
+	if len(cfg.CredentialsFile) > 0 {
+		return fileSource("credentials file "+cfg.CredentialsFile, cfg.CredentialsFile, true)
+	}
+	if path := strings.TrimSpace(os.Getenv(CredentialsFileEnvVar)); len(path) > 0 {
+		return fileSource("credentials file "+path+" (from "+CredentialsFileEnvVar+")", path, true)
+	}
+	// There is no Keycloak behind base_url, and a base_url service does not check tokens
+	if common.UsingBaseURL() {
+		return fixedSource("base_url", "base-url-token")
+	}
+	name := fmt.Sprintf("Keycloak client %s (realm %s)", cfg.ClientID, cfg.Realm)
+	return tokenSource{name: name, fetch: func() (Token, error) {
+		clientSecret := ""
+		if len(cfg.ClientSecretFile) > 0 {
+			data, err := os.ReadFile(cfg.ClientSecretFile)
+			if err != nil {
+				return Token{}, fmt.Errorf("reading client_secret_file: %v", err)
+			}
+			clientSecret = strings.TrimSpace(string(data))
+		}
+		issued := time.Now()
+		data, err := k8s.GetAccessJSON(ctx, cfg.ClientID, cfg.Realm, clientSecret)
+		if err != nil {
+			return Token{}, err
+		}
+		return parseToken(name, data, issued, true)
+	}}
+}
+
+// A source of a fake token which never expires
+func fixedSource(name, accessToken string) tokenSource {
+	return tokenSource{name: name, fetch: func() (Token, error) {
+		return Token{AccessToken: accessToken, Source: name}, nil
+	}}
+}
+
+// A source which reads the token from a file. Unless requireJSON is set, the file may hold just
+// the token, rather than JSON like the cray CLI credentials file.
+func fileSource(name, path string, requireJSON bool) tokenSource {
+	return tokenSource{name: name, fetch: func() (Token, error) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return Token{}, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Token{}, err
+		}
+		// A relative expires_in counts from when the file was written
+		return parseToken(name, data, info.ModTime(), requireJSON)
+	}}
+}
+
+// Parse a token, which is either JSON (a Keycloak response or cray CLI credentials file) or just
+// the token. The expiry time is taken from expires_at, or from expires_in relative to the time the
+// token was issued, or from the exp claim of the token itself.
+func parseToken(name string, data []byte, issued time.Time, requireJSON bool) (Token, error) {
+	token := Token{Source: name}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return token, fmt.Errorf("%s: no access token found", name)
+	} else if data[0] == '{' {
+		var fields struct {
+			AccessToken string  `json:"access_token"`
+			ExpiresAt   float64 `json:"expires_at"`
+			ExpiresIn   float64 `json:"expires_in"`
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return token, fmt.Errorf("%s: %v", name, err)
+		} else if len(fields.AccessToken) == 0 {
+			return token, fmt.Errorf("%s: no access_token field", name)
+		}
+		token.AccessToken = fields.AccessToken
+		if fields.ExpiresAt > 0 {
+			seconds, fraction := math.Modf(fields.ExpiresAt)
+			token.Expiry = time.Unix(int64(seconds), int64(fraction*1e9))
+		} else if fields.ExpiresIn > 0 {
+			token.Expiry = issued.Add(time.Duration(fields.ExpiresIn * float64(time.Second)))
+		}
+	} else if requireJSON {
+		return token, fmt.Errorf("%s: expected JSON with an access_token field", name)
+	} else if strings.ContainsAny(string(data), " \t\r\n") {
+		return token, fmt.Errorf("%s: expected a single access token", name)
+	} else {
+		token.AccessToken = string(data)
+	}
+	if token.Expiry.IsZero() {
+		token.Expiry = jwtExpiry(token.AccessToken)
+	}
+	if !token.Expiry.IsZero() {
+		margin := time.Duration(common.GetConfig().Auth.RefreshMarginSeconds) * time.Second
+		// Short-lived tokens are refreshed halfway through their lifetime
+		if lifetime := token.Expiry.Sub(issued); lifetime > 0 && margin > lifetime/2 {
+			margin = lifetime / 2
+		}
+		token.refreshAt = token.Expiry.Add(-margin)
+	}
+	return token, nil
+}
+
+// Return the expiry time from the exp claim of a JWT, or zero if the token is not a JWT or has
+// no exp claim. The token is not verified; that is up to the services.
+func jwtExpiry(accessToken string) time.Time {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(claims.Exp), 0)
+}
+
+// GetToken returns the access token, from the cache if it is not due to be refreshed. Otherwise
+// a new token is fetched from the token source. If that fails, the cached token is returned for
+// as long as it has not expired.
+func GetToken(ctx context.Context) (Token, error) {
+	tokenLock.Lock()
+	defer tokenLock.Unlock()
+	source := currentSource(ctx)
+	now := time.Now()
+	cached := cachedToken != nil && cachedToken.Source == source.name
+	if cached && (cachedToken.refreshAt.IsZero() || now.Before(cachedToken.refreshAt)) {
+		return *cachedToken, nil
+	}
+	common.Debugf(ctx, "Getting an access token from %s", source.name)
+	token, err := source.fetch()
+	if err != nil {
+		if cached && now.Before(cachedToken.Expiry) {
+			common.Warnf(ctx, "Unable to refresh the access token from %s, using the current token until it expires at %s: %v",
+				source.name, cachedToken.Expiry.Format(time.RFC3339), err)
+			return *cachedToken, nil
+		}
+		return Token{}, err
+	} else if !token.Expiry.IsZero() && !now.Before(token.Expiry) {
+		return Token{}, fmt.Errorf("the access token from %s expired at %s", source.name, token.Expiry.Format(time.RFC3339))
+	}
+	common.RegisterSecret(token.AccessToken)
+	if !token.refreshAt.IsZero() && !now.Before(token.refreshAt) && warnedToken != token.AccessToken {
+		// Only a file or environment variable can hand back a token which is about to expire
+		common.Warnf(ctx, "The access token from %s expires at %s; replace it to keep cmsdev running", source.name, token.Expiry.Format(time.RFC3339))
+		warnedToken = token.AccessToken
+	}
+	if token.Expiry.IsZero() {
+		common.Debugf(ctx, "Got an access token from %s, with no known expiry time", source.name)
+	} else {
+		common.Debugf(ctx, "Got an access token from %s, which expires at %s", source.name, token.Expiry.Format(time.RFC3339))
+	}
+	cachedToken = &token
+	return token, nil
+}
+
+// CredentialsJSON returns the token in the format of a cray CLI credentials file
+func (t Token) CredentialsJSON() ([]byte, error) {
+	credentials := map[string]interface{}{
+		"access_token": t.AccessToken,
+		"token_type":   "Bearer",
+	}
+	if !t.Expiry.IsZero() {
+		credentials["expires_at"] = t.Expiry.Unix()
+	}
+	return json.Marshal(credentials)
+}
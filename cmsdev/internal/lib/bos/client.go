@@ -0,0 +1,287 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * client.go
+ *
+ * Client for the BOS v2 API
+ *
+ */
+
+package bos
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	resty "gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/apiclient"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+// The version of the BOS API which the client uses
+const APIVersion = "v2"
+
+// BaseURL returns the BOS base URL, from the endpoint catalog (see common.GetEndpoints)
+func BaseURL() (string, error) { return common.GetServiceURL("bos") }
+
+// URL returns the URL of the named BOS endpoint from the endpoint catalog (like "sessions"), in
+// the version of the API which the client uses, followed by the specified path segments
+// (escaped), like a session name
+func URL(name string, segments ...string) (string, error) {
+	endpoint, err := common.GetEndpoint("bos", name)
+	if err != nil {
+		return "", err
+	}
+	endpointURL := endpoint.URL(APIVersion)
+	for _, segment := range segments {
+		endpointURL += "/" + url.PathEscape(segment)
+	}
+	return endpointURL, nil
+}
+
+// Full URLs of a session and a session template, for cleanups and leftover checks
+func SessionURL(name string) (string, error) { return URL("sessions", name) }
+
+func SessionTemplateURL(name string) (string, error) { return URL("sessiontemplates", name) }
+
+// Client makes BOS v2 API requests, as a tenant if one is set. Each request is logged and its
+// response is checked against the expected status code and the BOS OpenAPI spec.
+type Client struct {
+	params common.Params
+	tenant string
+}
+
+func NewClient(params common.Params) Client {
+	return Client{params: params}
+}
+
+// WithTenant returns a copy of the client which makes its requests as the specified tenant. An
+// empty tenant name means no tenant.
+func (client Client) WithTenant(tenant string) Client {
+	client.tenant = tenant
+	return client
+}
+
+func (client Client) Tenant() string { return client.tenant }
+
+// Make a request to the named BOS endpoint, followed by the specified path segments, with the
+// specified JSON body (if not nil), and check that the response has the expected status code
+func (client Client) request(ctx context.Context, method string, body interface{}, expectedStatus int, name string, segments ...string) (*resty.Response, error) {
+	endpointURL, err := URL(name, segments...)
+	if err != nil {
+		return nil, err
+	}
+	return apiclient.Request(ctx, method, endpointURL, client.tenant, client.params, body, expectedStatus)
+}
+
+// Make a GET request to the named BOS endpoint, followed by the specified path segments, and
+// return the response body
+func (client Client) get(ctx context.Context, name string, segments ...string) ([]byte, error) {
+	endpointURL, err := URL(name, segments...)
+	if err != nil {
+		return nil, err
+	}
+	return apiclient.Get(ctx, endpointURL, client.tenant, client.params)
+}
+
+// Make a GET request to the specified path relative to the BOS base URL (for the roots of the
+// API and its versions, which are not endpoints in the catalog), and return the response body
+func (client Client) getRoot(ctx context.Context, path string) ([]byte, error) {
+	baseURL, err := BaseURL()
+	if err != nil {
+		return nil, err
+	}
+	return apiclient.Get(ctx, baseURL+path, client.tenant, client.params)
+}
+
+// Versions returns the BOS versions listed at the root of the API
+func (client Client) Versions(ctx context.Context) ([]Version, error) {
+	data, err := client.getRoot(ctx, "/")
+	if err != nil {
+		return nil, err
+	}
+	var versions []Version
+	err = decode(data, "version list", &versions)
+	return versions, err
+}
+
+// V2 returns the version of the BOS v2 API, from the root of the v2 API
+func (client Client) V2(ctx context.Context) (Version, error) {
+	data, err := client.getRoot(ctx, "/"+APIVersion)
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(data)
+}
+
+func (client Client) Version(ctx context.Context) (Version, error) {
+	data, err := client.get(ctx, "version")
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(data)
+}
+
+func (client Client) Healthz(ctx context.Context) (Healthz, error) {
+	data, err := client.get(ctx, "healthz")
+	if err != nil {
+		return Healthz{}, err
+	}
+	return ParseHealthz(data)
+}
+
+func (client Client) Options(ctx context.Context) (Options, error) {
+	data, err := client.get(ctx, "options")
+	if err != nil {
+		return Options{}, err
+	}
+	return ParseOptions(data)
+}
+
+// PatchOptions updates the BOS options which are set in changes, and returns the updated
+// options. The changes are a map rather than Options so that options which Options does not
+// have can be set, and so that options can be set to false or 0.
+func (client Client) PatchOptions(ctx context.Context, changes map[string]interface{}) (Options, error) {
+	resp, err := client.request(ctx, "PATCH", changes, http.StatusOK, "options")
+	if err != nil {
+		return Options{}, err
+	}
+	return ParseOptions(resp.Body())
+}
+
+// SessionTemplateTemplate returns the example session template that BOS provides
+func (client Client) SessionTemplateTemplate(ctx context.Context) (SessionTemplate, error) {
+	data, err := client.get(ctx, "sessiontemplatetemplate")
+	if err != nil {
+		return SessionTemplate{}, err
+	}
+	return ParseSessionTemplateTemplate(data)
+}
+
+func (client Client) ListSessionTemplates(ctx context.Context) ([]SessionTemplate, error) {
+	data, err := client.get(ctx, "sessiontemplates")
+	if err != nil {
+		return nil, err
+	}
+	return ParseSessionTemplates(data)
+}
+
+func (client Client) GetSessionTemplate(ctx context.Context, name string) (SessionTemplate, error) {
+	data, err := client.get(ctx, "sessiontemplates", name)
+	if err != nil {
+		return SessionTemplate{}, err
+	}
+	return ParseSessionTemplate(data)
+}
+
+// PutSessionTemplate creates the named session template, or replaces it if it exists, and
+// returns the template as BOS stored it
+func (client Client) PutSessionTemplate(ctx context.Context, name string, template SessionTemplate) (SessionTemplate, error) {
+	return client.writeSessionTemplate(ctx, "PUT", name, template)
+}
+
+// PatchSessionTemplate updates the named session template with the fields which are set in
+// template, and returns the updated template
+func (client Client) PatchSessionTemplate(ctx context.Context, name string, template SessionTemplate) (SessionTemplate, error) {
+	return client.writeSessionTemplate(ctx, "PATCH", name, template)
+}
+
+func (client Client) writeSessionTemplate(ctx context.Context, method, name string, template SessionTemplate) (SessionTemplate, error) {
+	resp, err := client.request(ctx, method, template, http.StatusOK, "sessiontemplates", name)
+	if err != nil {
+		return SessionTemplate{}, err
+	}
+	return ParseSessionTemplate(resp.Body())
+}
+
+func (client Client) DeleteSessionTemplate(ctx context.Context, name string) error {
+	_, err := client.request(ctx, "DELETE", nil, http.StatusNoContent, "sessiontemplates", name)
+	return err
+}
+
+// ValidateSessionTemplate returns the message from BOS about whether the named session template
+// is valid. BOS responds with 200 whether or not it is.
+func (client Client) ValidateSessionTemplate(ctx context.Context, name string) (message string, err error) {
+	data, err := client.get(ctx, "sessiontemplatesvalid", name)
+	if err != nil {
+		return
+	}
+	err = decode(data, "session template validation", &message)
+	return
+}
+
+func (client Client) ListSessions(ctx context.Context) ([]Session, error) {
+	data, err := client.get(ctx, "sessions")
+	if err != nil {
+		return nil, err
+	}
+	return ParseSessions(data)
+}
+
+func (client Client) GetSession(ctx context.Context, name string) (Session, error) {
+	data, err := client.get(ctx, "sessions", name)
+	if err != nil {
+		return Session{}, err
+	}
+	return ParseSession(data)
+}
+
+// CreateSession creates a session and returns it. BOS names the session if the request does
+// not.
+func (client Client) CreateSession(ctx context.Context, session SessionCreate) (Session, error) {
+	resp, err := client.request(ctx, "POST", session, http.StatusCreated, "sessions")
+	if err != nil {
+		return Session{}, err
+	}
+	return ParseSession(resp.Body())
+}
+
+func (client Client) DeleteSession(ctx context.Context, name string) error {
+	_, err := client.request(ctx, "DELETE", nil, http.StatusNoContent, "sessions", name)
+	return err
+}
+
+func (client Client) GetSessionStatus(ctx context.Context, name string) (SessionExtendedStatus, error) {
+	data, err := client.get(ctx, "sessions", name, "status")
+	if err != nil {
+		return SessionExtendedStatus{}, err
+	}
+	return ParseSessionStatus(data)
+}
+
+func (client Client) ListComponents(ctx context.Context) ([]Component, error) {
+	data, err := client.get(ctx, "components")
+	if err != nil {
+		return nil, err
+	}
+	return ParseComponents(data)
+}
+
+func (client Client) GetComponent(ctx context.Context, id string) (Component, error) {
+	data, err := client.get(ctx, "components", id)
+	if err != nil {
+		return Component{}, err
+	}
+	return ParseComponent(data)
+}
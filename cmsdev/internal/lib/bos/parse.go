@@ -0,0 +1,161 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * parse.go
+ *
+ * Decoding of BOS v2 objects, from API responses or BOS CLI output
+ *
+ */
+
+package bos
+
+import (
+	"fmt"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/apiclient"
+)
+
+// Decode the JSON in data into the object that v points to
+func decode(data []byte, kind string, v interface{}) error {
+	return apiclient.Decode("BOS", data, kind, v)
+}
+
+func ParseVersion(data []byte) (version Version, err error) {
+	if err = decode(data, "version", &version); err != nil {
+		return
+	}
+	if len(version.Major) == 0 {
+		err = fmt.Errorf("BOS version has no major version")
+	}
+	return
+}
+
+func ParseHealthz(data []byte) (healthz Healthz, err error) {
+	if err = decode(data, "health status", &healthz); err != nil {
+		return
+	}
+	if len(healthz.APIStatus) == 0 {
+		err = fmt.Errorf("BOS health status has no api_status")
+	}
+	return
+}
+
+// ParseOptions decodes the BOS options. All of the options are kept in Raw, including any which
+// Options does not have.
+func ParseOptions(data []byte) (options Options, err error) {
+	if err = decode(data, "options", &options); err != nil {
+		return
+	}
+	err = decode(data, "options", &options.Raw)
+	return
+}
+
+func ParseSessionTemplate(data []byte) (template SessionTemplate, err error) {
+	if err = decode(data, "session template", &template); err != nil {
+		return
+	}
+	err = checkName("session template", "name", template.Name)
+	return
+}
+
+func ParseSessionTemplates(data []byte) (templates []SessionTemplate, err error) {
+	if err = decode(data, "session template list", &templates); err != nil {
+		return
+	}
+	for i, template := range templates {
+		if err = checkName("session template", "name", template.Name); err != nil {
+			err = fmt.Errorf("%v (#%d in list)", err, i)
+			return
+		}
+	}
+	return
+}
+
+// ParseSessionTemplateTemplate decodes the example session template that BOS provides. Its name
+// (if any) is only a placeholder, so it is not checked, but it should have at least one boot set.
+func ParseSessionTemplateTemplate(data []byte) (template SessionTemplate, err error) {
+	if err = decode(data, "session template template", &template); err != nil {
+		return
+	}
+	if len(template.BootSets) == 0 {
+		err = fmt.Errorf("BOS session template template has no boot sets")
+	}
+	return
+}
+
+func ParseSession(data []byte) (session Session, err error) {
+	if err = decode(data, "session", &session); err != nil {
+		return
+	}
+	err = checkName("session", "name", session.Name)
+	return
+}
+
+func ParseSessions(data []byte) (sessions []Session, err error) {
+	if err = decode(data, "session list", &sessions); err != nil {
+		return
+	}
+	for i, session := range sessions {
+		if err = checkName("session", "name", session.Name); err != nil {
+			err = fmt.Errorf("%v (#%d in list)", err, i)
+			return
+		}
+	}
+	return
+}
+
+func ParseSessionStatus(data []byte) (status SessionExtendedStatus, err error) {
+	if err = decode(data, "session status", &status); err != nil {
+		return
+	}
+	if len(status.Status) == 0 {
+		err = fmt.Errorf("BOS session status has no status")
+	}
+	return
+}
+
+func ParseComponent(data []byte) (component Component, err error) {
+	if err = decode(data, "component", &component); err != nil {
+		return
+	}
+	err = checkName("component", "id", component.ID)
+	return
+}
+
+func ParseComponents(data []byte) (components []Component, err error) {
+	if err = decode(data, "component list", &components); err != nil {
+		return
+	}
+	for i, component := range components {
+		if err = checkName("component", "id", component.ID); err != nil {
+			err = fmt.Errorf("%v (#%d in list)", err, i)
+			return
+		}
+	}
+	return
+}
+
+// Session templates and sessions are identified by name, and components by ID
+func checkName(kind, field, name string) error {
+	return apiclient.CheckName("BOS", kind, field, name)
+}
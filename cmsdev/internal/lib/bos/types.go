@@ -0,0 +1,259 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * types.go
+ *
+ * BOS v2 objects, as described in the BOS OpenAPI spec
+ *
+ */
+
+package bos
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Link struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel"`
+}
+
+type Version struct {
+	Major string `json:"major"`
+	Minor string `json:"minor"`
+	Patch string `json:"patch"`
+	Links []Link `json:"links,omitempty"`
+}
+
+// Returns the version as major.minor.patch
+func (version Version) String() string {
+	return fmt.Sprintf("%s.%s.%s", version.Major, version.Minor, version.Patch)
+}
+
+type Healthz struct {
+	DBStatus  string `json:"db_status"`
+	APIStatus string `json:"api_status"`
+}
+
+// Options are the BOS service options. Durations such as CleanupCompletedSessionTTL are strings
+// like "7d"; the wait times and frequencies are in seconds. Only the options which the tests use
+// have fields, and options which are false or 0 are omitted when encoded, so Options should not
+// be used to update the options; Raw has all of the options which BOS returned, and
+// Client.PatchOptions takes a map of changes.
+type Options struct {
+	CleanupCompletedSessionTTL    string `json:"cleanup_completed_session_ttl,omitempty"`
+	ClearStage                    bool   `json:"clear_stage,omitempty"`
+	ComponentActualStateTTL       string `json:"component_actual_state_ttl,omitempty"`
+	DisableComponentsOnCompletion bool   `json:"disable_components_on_completion,omitempty"`
+	DiscoveryFrequency            int    `json:"discovery_frequency,omitempty"`
+	LoggingLevel                  string `json:"logging_level,omitempty"`
+	MaxBootWaitTime               int    `json:"max_boot_wait_time,omitempty"`
+	MaxPowerOnWaitTime            int    `json:"max_power_on_wait_time,omitempty"`
+	MaxPowerOffWaitTime           int    `json:"max_power_off_wait_time,omitempty"`
+	PollingFrequency              int    `json:"polling_frequency,omitempty"`
+	DefaultRetryPolicy            int    `json:"default_retry_policy,omitempty"`
+
+	Raw map[string]interface{} `json:"-"`
+}
+
+type CFSParameters struct {
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// BootSet is a set of nodes in a session template, with the image to boot them with
+type BootSet struct {
+	Name                      string         `json:"name,omitempty"`
+	Path                      string         `json:"path"`
+	CFS                       *CFSParameters `json:"cfs,omitempty"`
+	Type                      string         `json:"type,omitempty"`
+	Etag                      string         `json:"etag,omitempty"`
+	KernelParameters          string         `json:"kernel_parameters,omitempty"`
+	NodeList                  []string       `json:"node_list,omitempty"`
+	NodeRolesGroups           []string       `json:"node_roles_groups,omitempty"`
+	NodeGroups                []string       `json:"node_groups,omitempty"`
+	Arch                      string         `json:"arch,omitempty"`
+	RootfsProvider            string         `json:"rootfs_provider,omitempty"`
+	RootfsProviderPassthrough string         `json:"rootfs_provider_passthrough,omitempty"`
+}
+
+// SessionTemplate is a BOS session template. The same type is used to create and update
+// templates, so the fields which BOS sets (such as the name and tenant) are omitted when empty.
+// A null tenant is decoded as an empty string.
+type SessionTemplate struct {
+	Name        string             `json:"name,omitempty"`
+	Tenant      string             `json:"tenant,omitempty"`
+	Description string             `json:"description,omitempty"`
+	EnableCFS   *bool              `json:"enable_cfs,omitempty"`
+	CFS         *CFSParameters     `json:"cfs,omitempty"`
+	BootSets    map[string]BootSet `json:"boot_sets,omitempty"`
+	Links       []Link             `json:"links,omitempty"`
+}
+
+// CFSEnabled returns whether CFS is enabled for the template. BOS enables it unless enable_cfs
+// is false.
+func (template SessionTemplate) CFSEnabled() bool {
+	return template.EnableCFS == nil || *template.EnableCFS
+}
+
+// BootSetNames returns the names of the boot sets of the template, sorted
+func (template SessionTemplate) BootSetNames() []string {
+	names := make([]string, 0, len(template.BootSets))
+	for name := range template.BootSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns a string identifying the template by name and tenant
+func (template SessionTemplate) String() string {
+	return describe(template.Name, template.Tenant)
+}
+
+type SessionStatus struct {
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Session is a BOS session, as BOS returns it. A null tenant is decoded as an empty string.
+type Session struct {
+	Name            string        `json:"name"`
+	Tenant          string        `json:"tenant,omitempty"`
+	Operation       string        `json:"operation"`
+	TemplateName    string        `json:"template_name"`
+	Limit           string        `json:"limit,omitempty"`
+	Stage           bool          `json:"stage"`
+	Components      string        `json:"components,omitempty"`
+	IncludeDisabled bool          `json:"include_disabled"`
+	Status          SessionStatus `json:"status"`
+}
+
+// Returns a string identifying the session by name and tenant
+func (session Session) String() string {
+	return describe(session.Name, session.Tenant)
+}
+
+// SessionCreate is the body of a request to create a BOS session
+type SessionCreate struct {
+	Name            string `json:"name,omitempty"`
+	Operation       string `json:"operation"`
+	TemplateName    string `json:"template_name"`
+	Limit           string `json:"limit,omitempty"`
+	Stage           bool   `json:"stage,omitempty"`
+	IncludeDisabled bool   `json:"include_disabled,omitempty"`
+}
+
+type SessionPhases struct {
+	PercentComplete    float64 `json:"percent_complete"`
+	PercentPoweringOn  float64 `json:"percent_powering_on"`
+	PercentPoweringOff float64 `json:"percent_powering_off"`
+	PercentConfiguring float64 `json:"percent_configuring"`
+}
+
+type SessionTiming struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time,omitempty"`
+	Duration  string `json:"duration"`
+}
+
+// SessionExtendedStatus is the status of a BOS session and its components. ErrorSummary maps
+// each error to the components which had it.
+type SessionExtendedStatus struct {
+	Status                 string                 `json:"status"`
+	ManagedComponentsCount int                    `json:"managed_components_count"`
+	Phases                 SessionPhases          `json:"phases"`
+	PercentStaged          float64                `json:"percent_staged"`
+	PercentSuccessful      float64                `json:"percent_successful"`
+	PercentFailed          float64                `json:"percent_failed"`
+	ErrorSummary           map[string]interface{} `json:"error_summary,omitempty"`
+	Timing                 SessionTiming          `json:"timing"`
+}
+
+// Errors returns the errors in the error summary, sorted
+func (status SessionExtendedStatus) Errors() []string {
+	errors := make([]string, 0, len(status.ErrorSummary))
+	for summary := range status.ErrorSummary {
+		errors = append(errors, summary)
+	}
+	sort.Strings(errors)
+	return errors
+}
+
+type BootArtifacts struct {
+	Kernel           string `json:"kernel,omitempty"`
+	KernelParameters string `json:"kernel_parameters,omitempty"`
+	Initrd           string `json:"initrd,omitempty"`
+}
+
+// ComponentState is the actual, desired or staged state of a component. Only the desired and
+// staged states have a configuration, and only the staged state has a session.
+type ComponentState struct {
+	BootArtifacts BootArtifacts `json:"boot_artifacts"`
+	Configuration string        `json:"configuration,omitempty"`
+	BSSToken      string        `json:"bss_token,omitempty"`
+	Session       string        `json:"session,omitempty"`
+	LastUpdated   string        `json:"last_updated,omitempty"`
+}
+
+type ComponentLastAction struct {
+	LastUpdated string `json:"last_updated,omitempty"`
+	Action      string `json:"action,omitempty"`
+	Failed      bool   `json:"failed,omitempty"`
+}
+
+type ComponentEventStats struct {
+	PowerOnAttempts          int `json:"power_on_attempts"`
+	PowerOffGracefulAttempts int `json:"power_off_graceful_attempts"`
+	PowerOffForcefulAttempts int `json:"power_off_forceful_attempts"`
+}
+
+type ComponentStatus struct {
+	Phase          string `json:"phase,omitempty"`
+	Status         string `json:"status,omitempty"`
+	StatusOverride string `json:"status_override,omitempty"`
+}
+
+// Component is a node managed by BOS
+type Component struct {
+	ID           string              `json:"id"`
+	Enabled      bool                `json:"enabled"`
+	Error        string              `json:"error,omitempty"`
+	RetryPolicy  int                 `json:"retry_policy,omitempty"`
+	ActualState  ComponentState      `json:"actual_state"`
+	DesiredState ComponentState      `json:"desired_state"`
+	StagedState  ComponentState      `json:"staged_state"`
+	LastAction   ComponentLastAction `json:"last_action"`
+	EventStats   ComponentEventStats `json:"event_stats"`
+	Status       ComponentStatus     `json:"status"`
+	Session      string              `json:"session,omitempty"`
+}
+
+func describe(name, tenant string) string {
+	if len(tenant) > 0 {
+		return fmt.Sprintf("name: '%s', tenant: '%s'", name, tenant)
+	}
+	return fmt.Sprintf("name: '%s', no tenant", name)
+}
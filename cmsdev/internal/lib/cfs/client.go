@@ -0,0 +1,389 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * client.go
+ *
+ * Client for the CFS v2 and v3 APIs
+ *
+ */
+
+package cfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	resty "gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/apiclient"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+// The CFS API versions which the client supports
+const (
+	MinVersion = 2
+	MaxVersion = 3
+)
+
+// BaseURL returns the CFS base URL, from the endpoint catalog (see common.GetEndpoints)
+func BaseURL() (string, error) { return common.GetServiceURL("cfs") }
+
+// URL returns the URL of the named CFS endpoint from the endpoint catalog (like
+// "configurations"), in the specified API version (0 for the path without a version, as for
+// "healthz" and "versions"), followed by the specified path segments (escaped), like a
+// configuration name
+func URL(version int, name string, segments ...string) (string, error) {
+	endpoint, err := common.GetEndpoint("cfs", name)
+	if err != nil {
+		return "", err
+	}
+	endpointURL := endpoint.URL(versionSegment(version))
+	for _, segment := range segments {
+		endpointURL += "/" + url.PathEscape(segment)
+	}
+	return endpointURL, nil
+}
+
+// Returns the path segment of an API version, like "v3" ("" for 0)
+func versionSegment(version int) string {
+	if version == 0 {
+		return ""
+	}
+	return fmt.Sprintf("v%d", version)
+}
+
+// Returns the URL of the specified path relative to the CFS base URL, for the roots of the API
+// and its versions, which are not endpoints in the catalog
+func rootURL(path string) (string, error) {
+	baseURL, err := BaseURL()
+	return baseURL + path, err
+}
+
+// Full v3 URLs of configurations, sessions and sources, for cleanups and leftover checks
+func ConfigurationURL(name string) (string, error) { return URL(3, "configurations", name) }
+
+func SessionURL(name string) (string, error) { return URL(3, "sessions", name) }
+
+func SourceURL(name string) (string, error) { return URL(3, "sources", name) }
+
+// Client makes CFS API requests using one version of the API, as a tenant if one is set. Each
+// request is logged and its response is checked against the expected status code and the CFS
+// OpenAPI spec. Objects are always returned in their v3 form, whichever version is used.
+type Client struct {
+	params  common.Params
+	version int
+	tenant  string
+}
+
+// NewClient returns a client for the latest version of the CFS API
+func NewClient(params common.Params) Client {
+	return Client{params: params, version: MaxVersion}
+}
+
+// WithVersion returns a copy of the client which uses the specified version of the API
+func (client Client) WithVersion(version int) Client {
+	client.version = version
+	return client
+}
+
+// WithTenant returns a copy of the client which makes its requests as the specified tenant. An
+// empty tenant name means no tenant.
+func (client Client) WithTenant(tenant string) Client {
+	client.tenant = tenant
+	return client
+}
+
+func (client Client) APIVersion() int { return client.version }
+
+func (client Client) Tenant() string { return client.tenant }
+
+// Sources, and updates of components, are only supported by CFS v3
+func (client Client) v3Only(what string) error {
+	if client.version < 3 {
+		return fmt.Errorf("CFS v%d does not support %s", client.version, what)
+	}
+	return nil
+}
+
+// Make a request to the named CFS endpoint in the client's API version, followed by the
+// specified path segments, with the specified JSON body (if not nil), and check that the
+// response has the expected status code
+func (client Client) request(ctx context.Context, method string, body interface{}, expectedStatus int, name string, segments ...string) (*resty.Response, error) {
+	endpointURL, err := URL(client.version, name, segments...)
+	if err != nil {
+		return nil, err
+	}
+	return apiclient.Request(ctx, method, endpointURL, client.tenant, client.params, body, expectedStatus)
+}
+
+// Make a GET request to the specified URL and return the response body
+func (client Client) getURL(ctx context.Context, endpointURL string, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return apiclient.Get(ctx, endpointURL, client.tenant, client.params)
+}
+
+// Make a GET request to the named CFS endpoint in the client's API version, followed by the
+// specified path segments, and return the response body
+func (client Client) get(ctx context.Context, name string, segments ...string) ([]byte, error) {
+	endpointURL, err := URL(client.version, name, segments...)
+	return client.getURL(ctx, endpointURL, err)
+}
+
+// Pages returns a PageFunc which lists a collection (such as "configurations") with the API
+func (client Client) Pages(ctx context.Context, collection string) PageFunc {
+	return func(query url.Values) ([]byte, error) {
+		pageURL, err := URL(client.version, collection)
+		if len(query) > 0 {
+			pageURL += "?" + query.Encode()
+		}
+		return client.getURL(ctx, pageURL, err)
+	}
+}
+
+// Root returns the CFS version, from the root of the API
+func (client Client) Root(ctx context.Context) (Version, error) {
+	endpointURL, err := rootURL("/")
+	data, err := client.getURL(ctx, endpointURL, err)
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(data)
+}
+
+func (client Client) Versions(ctx context.Context) (Version, error) {
+	endpointURL, err := URL(0, "versions")
+	data, err := client.getURL(ctx, endpointURL, err)
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(data)
+}
+
+// Version returns the CFS version, from the root of the client's version of the API
+func (client Client) Version(ctx context.Context) (Version, error) {
+	endpointURL, err := rootURL("/" + versionSegment(client.version))
+	data, err := client.getURL(ctx, endpointURL, err)
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(data)
+}
+
+func (client Client) Healthz(ctx context.Context) (Healthz, error) {
+	endpointURL, err := URL(0, "healthz")
+	data, err := client.getURL(ctx, endpointURL, err)
+	if err != nil {
+		return Healthz{}, err
+	}
+	return ParseHealthz(data)
+}
+
+func (client Client) Options(ctx context.Context) (Options, error) {
+	data, err := client.get(ctx, "options")
+	if err != nil {
+		return Options{}, err
+	}
+	return ParseOptions(client.version, data)
+}
+
+// Components returns an iterator over the components which match the query (if any)
+func (client Client) Components(ctx context.Context, query url.Values) *ComponentIterator {
+	return NewComponentIterator(client.version, query, client.Pages(ctx, "components"))
+}
+
+// ListComponents returns every component which matches the query (if any), from every page
+func (client Client) ListComponents(ctx context.Context, query url.Values) (components []Component, err error) {
+	it := client.Components(ctx, query)
+	for it.Next() {
+		components = append(components, it.Component())
+	}
+	return components, it.Err()
+}
+
+func (client Client) GetComponent(ctx context.Context, id string) (Component, error) {
+	data, err := client.get(ctx, "components", id)
+	if err != nil {
+		return Component{}, err
+	}
+	return ParseComponent(client.version, data)
+}
+
+// UpdateComponent changes the fields of the component which are set in update, and returns
+// the updated component (v3 only)
+func (client Client) UpdateComponent(ctx context.Context, id string, update ComponentUpdate) (Component, error) {
+	if err := client.v3Only("component updates"); err != nil {
+		return Component{}, err
+	}
+	resp, err := client.request(ctx, "PATCH", update, http.StatusOK, "components", id)
+	if err != nil {
+		return Component{}, err
+	}
+	return ParseComponent(client.version, resp.Body())
+}
+
+// Configurations returns an iterator over the configurations which match the query (if any)
+func (client Client) Configurations(ctx context.Context, query url.Values) *ConfigurationIterator {
+	return NewConfigurationIterator(client.version, query, client.Pages(ctx, "configurations"))
+}
+
+// ListConfigurations returns every configuration which matches the query (if any), from every
+// page
+func (client Client) ListConfigurations(ctx context.Context, query url.Values) (configurations []Configuration, err error) {
+	it := client.Configurations(ctx, query)
+	for it.Next() {
+		configurations = append(configurations, it.Configuration())
+	}
+	return configurations, it.Err()
+}
+
+func (client Client) GetConfiguration(ctx context.Context, name string) (Configuration, error) {
+	data, err := client.get(ctx, "configurations", name)
+	if err != nil {
+		return Configuration{}, err
+	}
+	return ParseConfiguration(client.version, data)
+}
+
+// PutConfiguration creates the named configuration, or replaces it if it exists, and returns
+// the configuration as CFS stored it. With v2, the tenant of the configuration and the sources
+// and special parameters of its layers are not sent.
+func (client Client) PutConfiguration(ctx context.Context, name string, configuration Configuration) (Configuration, error) {
+	var body interface{} = configuration
+	if client.version < 3 {
+		body = v2ConfigurationOf(configuration)
+	}
+	resp, err := client.request(ctx, "PUT", body, http.StatusOK, "configurations", name)
+	if err != nil {
+		return Configuration{}, err
+	}
+	return ParseConfiguration(client.version, resp.Body())
+}
+
+func (client Client) DeleteConfiguration(ctx context.Context, name string) error {
+	_, err := client.request(ctx, "DELETE", nil, http.StatusNoContent, "configurations", name)
+	return err
+}
+
+// Sessions returns an iterator over the sessions which match the query (if any)
+func (client Client) Sessions(ctx context.Context, query url.Values) *SessionIterator {
+	return NewSessionIterator(client.version, query, client.Pages(ctx, "sessions"))
+}
+
+// ListSessions returns every session which matches the query (if any), from every page
+func (client Client) ListSessions(ctx context.Context, query url.Values) (sessions []Session, err error) {
+	it := client.Sessions(ctx, query)
+	for it.Next() {
+		sessions = append(sessions, it.Session())
+	}
+	return sessions, it.Err()
+}
+
+func (client Client) GetSession(ctx context.Context, name string) (Session, error) {
+	data, err := client.get(ctx, "sessions", name)
+	if err != nil {
+		return Session{}, err
+	}
+	return ParseSession(client.version, data)
+}
+
+// CreateSession creates a session and returns it. CFS v2 responds with 200, and v3 with 201.
+func (client Client) CreateSession(ctx context.Context, session SessionCreate) (Session, error) {
+	var body interface{} = session
+	expectedStatus := http.StatusCreated
+	if client.version < 3 {
+		body, expectedStatus = v2SessionCreateOf(session), http.StatusOK
+	}
+	resp, err := client.request(ctx, "POST", body, expectedStatus, "sessions")
+	if err != nil {
+		return Session{}, err
+	}
+	return ParseSession(client.version, resp.Body())
+}
+
+func (client Client) DeleteSession(ctx context.Context, name string) error {
+	_, err := client.request(ctx, "DELETE", nil, http.StatusNoContent, "sessions", name)
+	return err
+}
+
+// Sources returns an iterator over the sources (v3 only)
+func (client Client) Sources(ctx context.Context, query url.Values) *SourceIterator {
+	it := NewSourceIterator(query, client.Pages(ctx, "sources"))
+	it.err = client.v3Only("sources")
+	return it
+}
+
+// ListSources returns every source, from every page (v3 only)
+func (client Client) ListSources(ctx context.Context, query url.Values) (sources []Source, err error) {
+	it := client.Sources(ctx, query)
+	for it.Next() {
+		sources = append(sources, it.Source())
+	}
+	return sources, it.Err()
+}
+
+func (client Client) GetSource(ctx context.Context, name string) (Source, error) {
+	if err := client.v3Only("sources"); err != nil {
+		return Source{}, err
+	}
+	data, err := client.get(ctx, "sources", name)
+	if err != nil {
+		return Source{}, err
+	}
+	return ParseSource(data)
+}
+
+// CreateSource creates a source and returns it (v3 only)
+func (client Client) CreateSource(ctx context.Context, source Source) (Source, error) {
+	if err := client.v3Only("sources"); err != nil {
+		return Source{}, err
+	}
+	resp, err := client.request(ctx, "POST", source, http.StatusCreated, "sources")
+	if err != nil {
+		return Source{}, err
+	}
+	return ParseSource(resp.Body())
+}
+
+// UpdateSource changes the fields of the named source which are set in source, and returns
+// the updated source (v3 only)
+func (client Client) UpdateSource(ctx context.Context, name string, source Source) (Source, error) {
+	if err := client.v3Only("sources"); err != nil {
+		return Source{}, err
+	}
+	resp, err := client.request(ctx, "PATCH", source, http.StatusOK, "sources", name)
+	if err != nil {
+		return Source{}, err
+	}
+	return ParseSource(resp.Body())
+}
+
+func (client Client) DeleteSource(ctx context.Context, name string) error {
+	if err := client.v3Only("sources"); err != nil {
+		return err
+	}
+	_, err := client.request(ctx, "DELETE", nil, http.StatusNoContent, "sources", name)
+	return err
+}
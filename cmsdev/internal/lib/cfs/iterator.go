@@ -0,0 +1,223 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * iterator.go
+ *
+ * Iterators over lists of CFS objects, which fetch the pages of CFS v3 lists as they are needed
+ *
+ */
+
+package cfs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// PageFunc fetches one page of a list, with the specified query parameters, and returns the
+// response body. The pages can come from the API or from CLI output.
+type PageFunc func(query url.Values) ([]byte, error)
+
+// Iterator is implemented by the iterator for each kind of CFS object. Next moves to the next
+// object, and returns false when there are no more objects or there was an error (which Err
+// then returns). ID returns the ID of the current object (its name, except for components).
+type Iterator interface {
+	Next() bool
+	ID() string
+	Pages() int
+	Err() error
+}
+
+// A pager fetches the pages of a list and returns the objects in them one at a time, following
+// the next links of CFS v3 until the last page. It is embedded in each of the iterators.
+type pager struct {
+	version    int
+	collection string
+	fetch      PageFunc
+	query      url.Values
+	items      []json.RawMessage
+	count      int
+	pages      int
+	last       bool
+	err        error
+}
+
+func newPager(version int, collection string, query url.Values, fetch PageFunc) *pager {
+	p := &pager{version: version, collection: collection, fetch: fetch, query: url.Values{}}
+	for key, values := range query {
+		p.query[key] = append([]string(nil), values...)
+	}
+	return p
+}
+
+// Returns the next object in the list, fetching the next page if needed
+func (p *pager) next() (json.RawMessage, bool) {
+	for len(p.items) == 0 {
+		if p.last || p.err != nil {
+			return nil, false
+		}
+		p.fetchPage()
+	}
+	item := p.items[0]
+	p.items = p.items[1:]
+	p.count++
+	return item, true
+}
+
+func (p *pager) fetchPage() {
+	data, err := p.fetch(p.query)
+	if err != nil {
+		p.err = err
+		return
+	}
+	p.pages++
+	var next map[string]interface{}
+	if p.items, next, p.err = parsePage(p.version, p.collection, data); p.err != nil {
+		return
+	} else if len(next) == 0 {
+		p.last = true
+		return
+	}
+	previous := p.query.Encode()
+	for key, value := range next {
+		if value != nil {
+			p.query.Set(key, fmt.Sprint(value))
+		}
+	}
+	if p.query.Encode() == previous {
+		p.err = fmt.Errorf("CFS %s page %d links back to itself (next: %v)", p.collection, p.pages, next)
+	}
+}
+
+// Record an error decoding the current object. The iteration stops after it.
+func (p *pager) fail(err error) bool {
+	p.err = fmt.Errorf("%v (#%d in list)", err, p.count-1)
+	return false
+}
+
+// Pages returns the number of pages fetched so far
+func (p *pager) Pages() int { return p.pages }
+
+func (p *pager) Err() error { return p.err }
+
+type ComponentIterator struct {
+	*pager
+	component Component
+}
+
+func NewComponentIterator(version int, query url.Values, fetch PageFunc) *ComponentIterator {
+	return &ComponentIterator{pager: newPager(version, "components", query, fetch)}
+}
+
+func (it *ComponentIterator) Next() bool {
+	data, ok := it.next()
+	if !ok {
+		return false
+	}
+	var err error
+	if it.component, err = ParseComponent(it.version, data); err != nil {
+		return it.fail(err)
+	}
+	return true
+}
+
+func (it *ComponentIterator) Component() Component { return it.component }
+
+func (it *ComponentIterator) ID() string { return it.component.ID }
+
+type ConfigurationIterator struct {
+	*pager
+	configuration Configuration
+}
+
+func NewConfigurationIterator(version int, query url.Values, fetch PageFunc) *ConfigurationIterator {
+	return &ConfigurationIterator{pager: newPager(version, "configurations", query, fetch)}
+}
+
+func (it *ConfigurationIterator) Next() bool {
+	data, ok := it.next()
+	if !ok {
+		return false
+	}
+	var err error
+	if it.configuration, err = ParseConfiguration(it.version, data); err != nil {
+		return it.fail(err)
+	}
+	return true
+}
+
+func (it *ConfigurationIterator) Configuration() Configuration { return it.configuration }
+
+func (it *ConfigurationIterator) ID() string { return it.configuration.Name }
+
+type SessionIterator struct {
+	*pager
+	session Session
+}
+
+func NewSessionIterator(version int, query url.Values, fetch PageFunc) *SessionIterator {
+	return &SessionIterator{pager: newPager(version, "sessions", query, fetch)}
+}
+
+func (it *SessionIterator) Next() bool {
+	data, ok := it.next()
+	if !ok {
+		return false
+	}
+	var err error
+	if it.session, err = ParseSession(it.version, data); err != nil {
+		return it.fail(err)
+	}
+	return true
+}
+
+func (it *SessionIterator) Session() Session { return it.session }
+
+func (it *SessionIterator) ID() string { return it.session.Name }
+
+// Sources are v3 only
+type SourceIterator struct {
+	*pager
+	source Source
+}
+
+func NewSourceIterator(query url.Values, fetch PageFunc) *SourceIterator {
+	return &SourceIterator{pager: newPager(3, "sources", query, fetch)}
+}
+
+func (it *SourceIterator) Next() bool {
+	data, ok := it.next()
+	if !ok {
+		return false
+	}
+	var err error
+	if it.source, err = ParseSource(data); err != nil {
+		return it.fail(err)
+	}
+	return true
+}
+
+func (it *SourceIterator) Source() Source { return it.source }
+
+func (it *SourceIterator) ID() string { return it.source.Name }
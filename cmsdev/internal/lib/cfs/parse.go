@@ -0,0 +1,181 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * parse.go
+ *
+ * Decoding of CFS objects, from API responses or CFS CLI output, and encoding of configurations
+ * for CFS CLI input. The objects whose fields are named differently in v2 are decoded from (or
+ * encoded to) the form for the specified CFS version.
+ *
+ */
+
+package cfs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/apiclient"
+)
+
+// Decode the JSON in data into the object that v points to
+func decode(data []byte, kind string, v interface{}) error {
+	return apiclient.Decode("CFS", data, kind, v)
+}
+
+func ParseVersion(data []byte) (version Version, err error) {
+	if err = decode(data, "version", &version); err != nil {
+		return
+	}
+	if len(version.Major) == 0 {
+		err = fmt.Errorf("CFS version has no major version")
+	}
+	return
+}
+
+func ParseHealthz(data []byte) (healthz Healthz, err error) {
+	if err = decode(data, "health status", &healthz); err != nil {
+		return
+	}
+	if len(healthz.DBStatus) == 0 {
+		err = fmt.Errorf("CFS health status has no db_status")
+	}
+	return
+}
+
+func ParseOptions(version int, data []byte) (options Options, err error) {
+	if version < 3 {
+		var v2 v2Options
+		err = decode(data, "options", &v2)
+		return v2.v3(), err
+	}
+	err = decode(data, "options", &options)
+	return
+}
+
+func ParseComponent(version int, data []byte) (component Component, err error) {
+	if version < 3 {
+		var v2 v2Component
+		err = decode(data, "component", &v2)
+		component = v2.v3()
+	} else {
+		err = decode(data, "component", &component)
+	}
+	if err == nil {
+		err = checkName("component", "id", component.ID)
+	}
+	return
+}
+
+func ParseConfiguration(version int, data []byte) (configuration Configuration, err error) {
+	if version < 3 {
+		var v2 v2Configuration
+		err = decode(data, "configuration", &v2)
+		configuration = v2.v3()
+	} else {
+		err = decode(data, "configuration", &configuration)
+	}
+	if err == nil {
+		err = checkName("configuration", "name", configuration.Name)
+	}
+	return
+}
+
+func ParseSession(version int, data []byte) (session Session, err error) {
+	if version < 3 {
+		var v2 v2Session
+		err = decode(data, "session", &v2)
+		session = v2.v3()
+	} else {
+		err = decode(data, "session", &session)
+	}
+	if err == nil {
+		err = checkName("session", "name", session.Name)
+	}
+	return
+}
+
+// EncodeConfiguration returns the JSON form of a configuration for the specified CFS version,
+// such as for the file given to the CLI to create it
+func EncodeConfiguration(version int, configuration Configuration) ([]byte, error) {
+	var v interface{} = configuration
+	if version < 3 {
+		v = v2ConfigurationOf(configuration)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding CFS configuration: %v", err)
+	}
+	return data, nil
+}
+
+// Sources are v3 only
+func ParseSource(data []byte) (source Source, err error) {
+	if err = decode(data, "source", &source); err != nil {
+		return
+	}
+	err = checkName("source", "name", source.Name)
+	return
+}
+
+// Decode one page of a list of CFS objects. In v2, a list is not paged: it is just an array of
+// the objects. In v3, a page is a map, with the objects under the name of the collection (such
+// as "configurations"), and a next field which holds the query parameters for the next page
+// (null on the last page).
+func parsePage(version int, collection string, data []byte) (items []json.RawMessage, next map[string]interface{}, err error) {
+	kind := collection + " list"
+	if version < 3 {
+		err = decode(data, kind, &items)
+		return
+	}
+	var page map[string]json.RawMessage
+	if err = decode(data, kind, &page); err != nil {
+		return
+	}
+	rawItems, ok := page[collection]
+	if !ok {
+		err = fmt.Errorf("CFS %s is missing expected '%s' field", kind, collection)
+		return
+	}
+	rawNext, ok := page["next"]
+	if !ok {
+		err = fmt.Errorf("CFS %s is missing expected 'next' field", kind)
+		return
+	}
+	if err = decode(rawItems, kind, &items); err != nil {
+		return
+	}
+	// Numbers in the next field are kept as they are, so that they can be passed back to CFS
+	decoder := json.NewDecoder(bytes.NewReader(rawNext))
+	decoder.UseNumber()
+	if err = decoder.Decode(&next); err != nil {
+		err = fmt.Errorf("Error decoding CFS %s next field: %v", kind, err)
+	}
+	return
+}
+
+// Components are identified by ID, and everything else by name
+func checkName(kind, field, name string) error {
+	return apiclient.CheckName("CFS", kind, field, name)
+}
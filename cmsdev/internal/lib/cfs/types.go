@@ -0,0 +1,227 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * types.go
+ *
+ * Types for the objects in the CFS v3 API. The v2 forms of the objects whose fields are named
+ * differently are in v2.go.
+ *
+ */
+
+package cfs
+
+import "fmt"
+
+type Version struct {
+	Major string `json:"major"`
+	Minor string `json:"minor"`
+	Patch string `json:"patch"`
+}
+
+// Returns the version as major.minor.patch
+func (version Version) String() string {
+	return fmt.Sprintf("%s.%s.%s", version.Major, version.Minor, version.Patch)
+}
+
+type Healthz struct {
+	DBStatus    string `json:"db_status"`
+	KafkaStatus string `json:"kafka_status"`
+}
+
+// Options are the CFS service options. The intervals, windows and timeouts are in seconds.
+type Options struct {
+	HardwareSyncInterval      int    `json:"hardware_sync_interval,omitempty"`
+	BatcherCheckInterval      int    `json:"batcher_check_interval,omitempty"`
+	BatchSize                 int    `json:"batch_size,omitempty"`
+	BatchWindow               int    `json:"batch_window,omitempty"`
+	DefaultBatcherRetryPolicy int    `json:"default_batcher_retry_policy,omitempty"`
+	DefaultPlaybook           string `json:"default_playbook,omitempty"`
+	DefaultAnsibleConfig      string `json:"default_ansible_config,omitempty"`
+	SessionTTL                string `json:"session_ttl,omitempty"`
+	AdditionalInventoryURL    string `json:"additional_inventory_url,omitempty"`
+	AdditionalInventorySource string `json:"additional_inventory_source,omitempty"`
+	BatcherMaxBackoff         int    `json:"batcher_max_backoff,omitempty"`
+	BatcherDisableDriver      bool   `json:"batcher_disable_driver,omitempty"`
+	BatcherPendingTimeout     int    `json:"batcher_pending_timeout,omitempty"`
+	LoggingLevel              string `json:"logging_level,omitempty"`
+	DefaultPageSize           int    `json:"default_page_size,omitempty"`
+	DebugWaitTime             int    `json:"debug_wait_time,omitempty"`
+	IncludeARALinks           bool   `json:"include_ara_links,omitempty"`
+}
+
+// Layer is a layer of a configuration: a playbook to run from a commit or branch of a git
+// repository. The repository is identified either by its clone URL or by the name of a CFS
+// source (v3 only).
+type Layer struct {
+	Name              string                 `json:"name,omitempty"`
+	CloneURL          string                 `json:"clone_url,omitempty"`
+	Source            string                 `json:"source,omitempty"`
+	Commit            string                 `json:"commit,omitempty"`
+	Branch            string                 `json:"branch,omitempty"`
+	Playbook          string                 `json:"playbook,omitempty"`
+	SpecialParameters map[string]interface{} `json:"special_parameters,omitempty"`
+}
+
+// Configuration is a CFS configuration. The same type is used to create and replace
+// configurations, so the fields which CFS sets (such as the name and the last update time) are
+// omitted when empty. A null tenant is decoded as an empty string.
+type Configuration struct {
+	Name                string  `json:"name,omitempty"`
+	Description         string  `json:"description,omitempty"`
+	LastUpdated         string  `json:"last_updated,omitempty"`
+	Layers              []Layer `json:"layers"`
+	AdditionalInventory *Layer  `json:"additional_inventory,omitempty"`
+	TenantName          string  `json:"tenant_name,omitempty"`
+}
+
+// Returns a string identifying the configuration by name and tenant
+func (configuration Configuration) String() string {
+	return describe(configuration.Name, configuration.TenantName)
+}
+
+// Component is the configuration state of a node (or other component) managed by CFS. Each
+// entry in State is a layer which has been applied to the component.
+type Component struct {
+	ID                  string                   `json:"id"`
+	State               []map[string]interface{} `json:"state,omitempty"`
+	DesiredConfig       string                   `json:"desired_config"`
+	ErrorCount          int                      `json:"error_count"`
+	RetryPolicy         int                      `json:"retry_policy,omitempty"`
+	Enabled             *bool                    `json:"enabled,omitempty"`
+	ConfigurationStatus string                   `json:"configuration_status,omitempty"`
+	Tags                map[string]string        `json:"tags,omitempty"`
+	Logs                string                   `json:"logs,omitempty"`
+}
+
+// ComponentUpdate is the body of a request to update a component. Only the fields which are set
+// are changed, so an empty desired configuration must be requested with a pointer to "".
+type ComponentUpdate struct {
+	DesiredConfig *string `json:"desired_config,omitempty"`
+	Enabled       *bool   `json:"enabled,omitempty"`
+}
+
+type TargetGroup struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+type ImageMapping struct {
+	SourceID   string `json:"source_id"`
+	ResultName string `json:"result_name"`
+}
+
+// Target is what a session configures: the nodes in its inventory (definition dynamic or spec),
+// images (definition image), or the repositories of its configuration (definition repo)
+type Target struct {
+	Definition string         `json:"definition"`
+	Groups     []TargetGroup  `json:"groups,omitempty"`
+	ImageMap   []ImageMapping `json:"image_map,omitempty"`
+}
+
+type Artifact struct {
+	ImageID  string `json:"image_id"`
+	ResultID string `json:"result_id"`
+	Type     string `json:"type"`
+}
+
+// SessionState is the state of the Kubernetes job for a session. Succeeded is a string: "none"
+// until the session is complete, then "true" or "false".
+type SessionState struct {
+	Job            string `json:"job,omitempty"`
+	IMSJob         string `json:"ims_job,omitempty"`
+	StartTime      string `json:"start_time,omitempty"`
+	CompletionTime string `json:"completion_time,omitempty"`
+	Status         string `json:"status"`
+	Succeeded      string `json:"succeeded,omitempty"`
+}
+
+type SessionStatus struct {
+	Artifacts []Artifact   `json:"artifacts"`
+	Session   SessionState `json:"session"`
+}
+
+type SessionConfiguration struct {
+	Name  string `json:"name"`
+	Limit string `json:"limit,omitempty"`
+}
+
+// Session is a CFS session, as CFS returns it. A null tenant is decoded as an empty string.
+type Session struct {
+	Name           string                 `json:"name"`
+	Configuration  SessionConfiguration   `json:"configuration"`
+	Ansible        map[string]interface{} `json:"ansible,omitempty"`
+	Target         Target                 `json:"target"`
+	Status         SessionStatus          `json:"status"`
+	Tags           map[string]string      `json:"tags,omitempty"`
+	DebugOnFailure bool                   `json:"debug_on_failure,omitempty"`
+	Logs           string                 `json:"logs,omitempty"`
+	TenantName     string                 `json:"tenant_name,omitempty"`
+}
+
+// Returns a string identifying the session by name and tenant
+func (session Session) String() string {
+	return describe(session.Name, session.TenantName)
+}
+
+// SessionCreate is the body of a request to create a CFS session
+type SessionCreate struct {
+	Name               string            `json:"name"`
+	ConfigurationName  string            `json:"configuration_name"`
+	ConfigurationLimit string            `json:"configuration_limit,omitempty"`
+	Target             *Target           `json:"target,omitempty"`
+	Tags               map[string]string `json:"tags,omitempty"`
+	DebugOnFailure     bool              `json:"debug_on_failure,omitempty"`
+}
+
+// SourceCredentials are the credentials for cloning the repository of a source. CFS keeps them
+// in a Kubernetes secret and only returns the name of the secret, so the username and password
+// are only set when creating or updating a source.
+type SourceCredentials struct {
+	AuthenticationMethod string `json:"authentication_method"`
+	SecretName           string `json:"secret_name,omitempty"`
+	Username             string `json:"username,omitempty"`
+	Password             string `json:"password,omitempty"`
+}
+
+type SourceCACert struct {
+	ConfigmapName      string `json:"configmap_name"`
+	ConfigmapNamespace string `json:"configmap_namespace,omitempty"`
+}
+
+// Source is a git repository, with the credentials for cloning it (v3 only). The same type is
+// used to create and update sources, so every field is omitted when empty.
+type Source struct {
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	LastUpdated string             `json:"last_updated,omitempty"`
+	CloneURL    string             `json:"clone_url,omitempty"`
+	Credentials *SourceCredentials `json:"credentials,omitempty"`
+	CACert      *SourceCACert      `json:"ca_cert,omitempty"`
+}
+
+func describe(name, tenant string) string {
+	if len(tenant) > 0 {
+		return fmt.Sprintf("name: '%s', tenant: '%s'", name, tenant)
+	}
+	return fmt.Sprintf("name: '%s', no tenant", name)
+}
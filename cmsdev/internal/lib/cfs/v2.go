@@ -0,0 +1,209 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * v2.go
+ *
+ * The CFS v2 forms of the objects whose fields are named differently in v3 (camelCase rather
+ * than snake_case), and their conversions to and from the v3 forms. Sources and tenants are
+ * v3 only, so they have no v2 form.
+ *
+ */
+
+package cfs
+
+type v2Options struct {
+	HardwareSyncInterval      int    `json:"hardwareSyncInterval,omitempty"`
+	BatcherCheckInterval      int    `json:"batcherCheckInterval,omitempty"`
+	BatchSize                 int    `json:"batchSize,omitempty"`
+	BatchWindow               int    `json:"batchWindow,omitempty"`
+	DefaultBatcherRetryPolicy int    `json:"defaultBatcherRetryPolicy,omitempty"`
+	DefaultPlaybook           string `json:"defaultPlaybook,omitempty"`
+	DefaultAnsibleConfig      string `json:"defaultAnsibleConfig,omitempty"`
+	SessionTTL                string `json:"sessionTTL,omitempty"`
+	AdditionalInventoryURL    string `json:"additionalInventoryUrl,omitempty"`
+	BatcherMaxBackoff         int    `json:"batcherMaxBackoff,omitempty"`
+	BatcherDisable            bool   `json:"batcherDisable,omitempty"`
+	BatcherPendingTimeout     int    `json:"batcherPendingTimeout,omitempty"`
+	LoggingLevel              string `json:"loggingLevel,omitempty"`
+}
+
+func (options v2Options) v3() Options {
+	return Options{
+		HardwareSyncInterval:      options.HardwareSyncInterval,
+		BatcherCheckInterval:      options.BatcherCheckInterval,
+		BatchSize:                 options.BatchSize,
+		BatchWindow:               options.BatchWindow,
+		DefaultBatcherRetryPolicy: options.DefaultBatcherRetryPolicy,
+		DefaultPlaybook:           options.DefaultPlaybook,
+		DefaultAnsibleConfig:      options.DefaultAnsibleConfig,
+		SessionTTL:                options.SessionTTL,
+		AdditionalInventoryURL:    options.AdditionalInventoryURL,
+		BatcherMaxBackoff:         options.BatcherMaxBackoff,
+		BatcherDisableDriver:      options.BatcherDisable,
+		BatcherPendingTimeout:     options.BatcherPendingTimeout,
+		LoggingLevel:              options.LoggingLevel,
+	}
+}
+
+type v2Layer struct {
+	Name     string `json:"name,omitempty"`
+	CloneURL string `json:"cloneUrl,omitempty"`
+	Commit   string `json:"commit,omitempty"`
+	Branch   string `json:"branch,omitempty"`
+	Playbook string `json:"playbook,omitempty"`
+}
+
+func v2LayerOf(layer Layer) v2Layer {
+	return v2Layer{Name: layer.Name, CloneURL: layer.CloneURL, Commit: layer.Commit, Branch: layer.Branch, Playbook: layer.Playbook}
+}
+
+func (layer v2Layer) v3() Layer {
+	return Layer{Name: layer.Name, CloneURL: layer.CloneURL, Commit: layer.Commit, Branch: layer.Branch, Playbook: layer.Playbook}
+}
+
+type v2Configuration struct {
+	Name                string    `json:"name,omitempty"`
+	Description         string    `json:"description,omitempty"`
+	LastUpdated         string    `json:"lastUpdated,omitempty"`
+	Layers              []v2Layer `json:"layers"`
+	AdditionalInventory *v2Layer  `json:"additional_inventory,omitempty"`
+}
+
+// The v2 form of a configuration does not have a tenant, and its layers cannot name sources
+// or have special parameters, so those fields are dropped
+func v2ConfigurationOf(configuration Configuration) v2Configuration {
+	v2 := v2Configuration{
+		Name:        configuration.Name,
+		Description: configuration.Description,
+		LastUpdated: configuration.LastUpdated,
+		Layers:      make([]v2Layer, 0, len(configuration.Layers)),
+	}
+	for _, layer := range configuration.Layers {
+		v2.Layers = append(v2.Layers, v2LayerOf(layer))
+	}
+	if configuration.AdditionalInventory != nil {
+		inventory := v2LayerOf(*configuration.AdditionalInventory)
+		v2.AdditionalInventory = &inventory
+	}
+	return v2
+}
+
+func (configuration v2Configuration) v3() Configuration {
+	v3 := Configuration{
+		Name:        configuration.Name,
+		Description: configuration.Description,
+		LastUpdated: configuration.LastUpdated,
+		Layers:      make([]Layer, 0, len(configuration.Layers)),
+	}
+	for _, layer := range configuration.Layers {
+		v3.Layers = append(v3.Layers, layer.v3())
+	}
+	if configuration.AdditionalInventory != nil {
+		inventory := configuration.AdditionalInventory.v3()
+		v3.AdditionalInventory = &inventory
+	}
+	return v3
+}
+
+type v2Component struct {
+	ID                  string                   `json:"id"`
+	State               []map[string]interface{} `json:"state,omitempty"`
+	DesiredConfig       string                   `json:"desiredConfig"`
+	ErrorCount          int                      `json:"errorCount"`
+	RetryPolicy         int                      `json:"retryPolicy,omitempty"`
+	Enabled             *bool                    `json:"enabled,omitempty"`
+	ConfigurationStatus string                   `json:"configurationStatus,omitempty"`
+	Tags                map[string]string        `json:"tags,omitempty"`
+}
+
+func (component v2Component) v3() Component {
+	return Component{
+		ID:                  component.ID,
+		State:               component.State,
+		DesiredConfig:       component.DesiredConfig,
+		ErrorCount:          component.ErrorCount,
+		RetryPolicy:         component.RetryPolicy,
+		Enabled:             component.Enabled,
+		ConfigurationStatus: component.ConfigurationStatus,
+		Tags:                component.Tags,
+	}
+}
+
+type v2SessionState struct {
+	Job            string `json:"job,omitempty"`
+	StartTime      string `json:"startTime,omitempty"`
+	CompletionTime string `json:"completionTime,omitempty"`
+	Status         string `json:"status"`
+	Succeeded      string `json:"succeeded,omitempty"`
+}
+
+type v2Session struct {
+	Name          string                 `json:"name"`
+	Configuration SessionConfiguration   `json:"configuration"`
+	Ansible       map[string]interface{} `json:"ansible,omitempty"`
+	Target        Target                 `json:"target"`
+	Status        struct {
+		Artifacts []Artifact     `json:"artifacts"`
+		Session   v2SessionState `json:"session"`
+	} `json:"status"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+func (session v2Session) v3() Session {
+	state := session.Status.Session
+	return Session{
+		Name:          session.Name,
+		Configuration: session.Configuration,
+		Ansible:       session.Ansible,
+		Target:        session.Target,
+		Status: SessionStatus{
+			Artifacts: session.Status.Artifacts,
+			Session: SessionState{
+				Job:            state.Job,
+				StartTime:      state.StartTime,
+				CompletionTime: state.CompletionTime,
+				Status:         state.Status,
+				Succeeded:      state.Succeeded,
+			},
+		},
+		Tags: session.Tags,
+	}
+}
+
+type v2SessionCreate struct {
+	Name               string            `json:"name"`
+	ConfigurationName  string            `json:"configurationName"`
+	ConfigurationLimit string            `json:"configurationLimit,omitempty"`
+	Target             *Target           `json:"target,omitempty"`
+	Tags               map[string]string `json:"tags,omitempty"`
+}
+
+func v2SessionCreateOf(session SessionCreate) v2SessionCreate {
+	return v2SessionCreate{
+		Name:               session.Name,
+		ConfigurationName:  session.ConfigurationName,
+		ConfigurationLimit: session.ConfigurationLimit,
+		Target:             session.Target,
+		Tags:               session.Tags,
+	}
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,122 +29,94 @@ package cms
  */
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	c "github.com/fatih/color"
+	"regexp"
 	"sort"
+	"strings"
+
+	c "github.com/fatih/color"
+	coreV1 "k8s.io/api/core/v1"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
-	"strings"
 )
 
 // struct to hold CMS services data
 type serviceData struct {
-	serviceAPIName       string
-	serviceContainerName string
-	podNames             []string
-	pvcNames             []string
+	serviceAPIName string
+	podNames       []string
+	pvcNames       []string
+}
+
+// The CMS services, and the common.PodServiceNamePrefixes keys of their pods and PVCs ("" if
+// the service has no PVCs)
+var cmsServices = []struct {
+	name, podKey, pvcKey string
+}{
+	{"bos", "bos", "bosPvc"},
+	{"cfs", "cfsServices", ""},
+	{"conman", "console", "consolePvc"},
+	{"ims", "ims", "imsPvc"},
+	{"ipxe", "ipxe", ""},
+	{"tftp", "tftp", "tftpPvc"},
+	{"vcs", "vcs", "vcs"},
 }
 
 // variable that used to load current CMS services data
 var cmsServiceData = make(map[string]*serviceData)
 
 // get cms service names
-func loadCMSServiceData() {
-	for _, each := range common.CMSServices {
-		switch each {
-		case "bos":
-			podNames, _ := k8s.GetPodNames(common.NAMESPACE, common.PodServiceNamePrefixes["bos"])
-			if len(podNames) != 0 {
-				pvcNames, _ := k8s.GetPVCNames(common.NAMESPACE, common.PodServiceNamePrefixes["bosPvc"])
-				cmsServiceData["bos"] = &serviceData{
-					serviceAPIName:       podNames[0],
-					serviceContainerName: "cray-bos",
-					podNames:             podNames,
-					pvcNames:             pvcNames,
-				}
-			}
-		case "cfs":
-			podNames, _ := k8s.GetPodNames(common.NAMESPACE, common.PodServiceNamePrefixes["cfsServices"])
-			if len(podNames) != 0 {
-				cmsServiceData["cfs"] = &serviceData{
-					podNames: podNames,
-				}
-				// find cfs API pod name
-				for i := range podNames {
-					if strings.HasPrefix(podNames[i], common.PodServiceNamePrefixes["cfs-api"]) {
-						cmsServiceData["cfs"].serviceAPIName = podNames[i]
-						break
-					}
-				}
-			}
-		case "conman":
-			podNames, _ := k8s.GetPodNames(common.NAMESPACE, common.PodServiceNamePrefixes["conman"])
-			if len(podNames) != 0 {
-				pvcNames, _ := k8s.GetPVCNames(common.NAMESPACE, common.PodServiceNamePrefixes["conmanPvc"])
-				cmsServiceData["conman"] = &serviceData{
-					serviceAPIName: podNames[0],
-					podNames:       podNames,
-					pvcNames:       pvcNames,
-				}
-			}
-		case "ims":
-			podNames, _ := k8s.GetPodNames(common.NAMESPACE, common.PodServiceNamePrefixes["ims"])
-			if len(podNames) != 0 {
-				pvcNames, _ := k8s.GetPVCNames(common.NAMESPACE, common.PodServiceNamePrefixes["imsPvc"])
-				cmsServiceData["ims"] = &serviceData{
-					serviceAPIName: podNames[0],
-					podNames:       podNames,
-					pvcNames:       pvcNames,
-				}
-			}
-		case "ipxe":
-			podNames, _ := k8s.GetPodNames(common.NAMESPACE, common.PodServiceNamePrefixes["ipxe"])
-			if len(podNames) != 0 {
-				cmsServiceData["ipxe"] = &serviceData{
-					serviceAPIName: podNames[0],
-					podNames:       podNames,
-				}
-			}
-		case "tftp":
-			podNames, _ := k8s.GetPodNames(common.NAMESPACE, common.PodServiceNamePrefixes["tftp"])
-			if len(podNames) != 0 {
-				pvcNames, _ := k8s.GetPVCNames(common.NAMESPACE, common.PodServiceNamePrefixes["tftpPvc"])
-				cmsServiceData["tftp"] = &serviceData{
-					serviceAPIName: podNames[0],
-					podNames:       podNames,
-					pvcNames:       pvcNames,
-				}
-			}
-		case "vcs":
-			podNames, _ := k8s.GetPodNames(common.NAMESPACE, common.PodServiceNamePrefixes["vcs"])
-			if len(podNames) != 0 {
-				pvcNames, _ := k8s.GetPVCNames(common.NAMESPACE, common.PodServiceNamePrefixes["vcs"])
-				cmsServiceData["vcs"] = &serviceData{
-					serviceAPIName: podNames[0],
-					podNames:       podNames,
-					pvcNames:       pvcNames,
+func loadCMSServiceData(ctx context.Context) {
+	for _, service := range cmsServices {
+		podNames, _ := k8s.GetPodNames(ctx, common.NAMESPACE, common.PodServiceNamePrefixes[service.podKey])
+		if len(podNames) == 0 {
+			continue
+		}
+		data := &serviceData{
+			serviceAPIName: podNames[0],
+			podNames:       podNames,
+		}
+		if len(service.pvcKey) > 0 {
+			data.pvcNames, _ = k8s.GetPVCNames(ctx, common.NAMESPACE, common.PodServiceNamePrefixes[service.pvcKey])
+		}
+		if service.name == "cfs" {
+			// find cfs API pod name
+			data.serviceAPIName = ""
+			for i := range podNames {
+				if strings.HasPrefix(podNames[i], common.PodServiceNamePrefixes["cfs-api"]) {
+					data.serviceAPIName = podNames[i]
+					break
 				}
 			}
 		}
+		cmsServiceData[service.name] = data
 	}
 }
 
 // GetCMSServiceName returns kubernetes pod name given prefix
-func GetCMSServiceName(key string) string {
-	loadCMSServiceData()
+func GetCMSServiceName(ctx context.Context, key string) string {
+	loadCMSServiceData(ctx)
 	return cmsServiceData[key].serviceAPIName
 }
 
 // list CMS services names
 func ListServicesNames() {
-	fmt.Println(strings.Join(common.CMSServices, " "))
+	fmt.Println(strings.Join(ServiceNames(), " "))
 	return
 }
 
+// ServiceNames returns the names of the CMS services, sorted
+func ServiceNames() []string {
+	names := make([]string, 0, len(cmsServices))
+	for _, service := range cmsServices {
+		names = append(names, service.name)
+	}
+	return names
+}
+
 // retrieve names and count of services that are currently running
-func GetCMSServiceNames() (keys []string, numServices int) {
-	loadCMSServiceData()
+func GetCMSServiceNames(ctx context.Context) (keys []string, numServices int) {
+	loadCMSServiceData(ctx)
 	keys = make([]string, 0, len(cmsServiceData))
 	for k := range cmsServiceData {
 		keys = append(keys, k)
@@ -154,56 +126,161 @@ func GetCMSServiceNames() (keys []string, numServices int) {
 	return
 }
 
-// routine to list of all cms services
-// TODO: make output prettier, update to consider pod phase
-func ListServices() {
-	loadCMSServiceData()
-	keys, _ := GetCMSServiceNames()
-	for _, v := range keys {
-		fmt.Printf("%-65s%7s\n", "-----------------------------------------------------------", "------")
-		fmt.Printf("%-65s%7s\n", string(v), "STATUS")
-		fmt.Printf("%-65s%7s\n", "-----------------------------------------------------------", "------")
-		for i := range cmsServiceData[v].podNames {
-			status, _ := k8s.GetPodStatus(common.NAMESPACE, cmsServiceData[v].podNames[i])
-			fmt.Printf("%-65s ", cmsServiceData[v].podNames[i])
-			if status != "Running" {
-				c.Red("Failed")
-			} else {
-				c.HiGreen("OK")
-			}
+// PodStatus is the status of a pod of a CMS service
+type PodStatus struct {
+	Name  string `json:"name"`
+	Phase string `json:"phase"`
+	// Ready and total containers, like 2/3
+	Ready    string `json:"ready"`
+	Restarts int32  `json:"restarts"`
+	Node     string `json:"node"`
+	// True if the pod is running with all of its containers ready, or has completed
+	Healthy bool `json:"healthy"`
+}
+
+// PVCStatus is the status of a PVC of a CMS service
+type PVCStatus struct {
+	Name     string `json:"name"`
+	Phase    string `json:"phase"`
+	Capacity string `json:"capacity"`
+	// True if the PVC is bound
+	Healthy bool `json:"healthy"`
+}
+
+// ServiceStatus is the status of the pods and PVCs of a CMS service
+type ServiceStatus struct {
+	Service string      `json:"service"`
+	Pods    []PodStatus `json:"pods"`
+	PVCs    []PVCStatus `json:"pvcs"`
+}
+
+// Healthy returns true if the service has pods, and all of its pods and PVCs are healthy
+func (status ServiceStatus) Healthy() bool {
+	if len(status.Pods) == 0 {
+		return false
+	}
+	for _, pod := range status.Pods {
+		if !pod.Healthy {
+			return false
 		}
-		for i := range cmsServiceData[v].pvcNames {
-			status, _ := k8s.GetPVCStatus(common.NAMESPACE, cmsServiceData[v].pvcNames[i])
-			fmt.Printf("%-65s ", cmsServiceData[v].pvcNames[i])
-			if status != "Bound" {
-				c.Red("Failed")
-			} else {
-				c.HiGreen("OK")
-			}
+	}
+	for _, pvc := range status.PVCs {
+		if !pvc.Healthy {
+			return false
 		}
+	}
+	return true
+}
 
-		fmt.Println()
+func newPodStatus(pod coreV1.Pod) PodStatus {
+	status := PodStatus{Name: pod.GetName(), Phase: string(pod.Status.Phase), Node: pod.Spec.NodeName}
+	ready := 0
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Ready {
+			ready++
+		}
+		status.Restarts += cs.RestartCount
+	}
+	containers := len(pod.Spec.Containers)
+	if len(pod.Status.ContainerStatuses) > containers {
+		containers = len(pod.Status.ContainerStatuses)
 	}
+	status.Ready = fmt.Sprintf("%d/%d", ready, containers)
+	status.Healthy = pod.Status.Phase == coreV1.PodSucceeded || (pod.Status.Phase == coreV1.PodRunning && ready == containers)
+	return status
 }
 
-// get services logs
-func PrintServiceLogs(service string) error {
-	serviceNames, numServices := GetCMSServiceNames()
-	if numServices < 1 {
-		return errors.New("no cms services currently running")
+func newPVCStatus(pvc coreV1.PersistentVolumeClaim) PVCStatus {
+	status := PVCStatus{Name: pvc.GetName(), Phase: string(pvc.Status.Phase)}
+	if capacity, ok := pvc.Status.Capacity[coreV1.ResourceStorage]; ok {
+		status.Capacity = capacity.String()
+	}
+	status.Healthy = pvc.Status.Phase == coreV1.ClaimBound
+	return status
+}
+
+// GetServiceStatus returns the status of the pods and PVCs of the specified CMS services (all
+// of them, if none are specified), sorted by service. The pods and PVCs are each listed once,
+// so that the status is a consistent snapshot.
+func GetServiceStatus(ctx context.Context, services ...string) (statuses []ServiceStatus, err error) {
+	pods, err := k8s.GetPods(ctx, common.NAMESPACE)
+	if err != nil {
+		return nil, err
+	}
+	pvcs, err := k8s.GetPVCs(ctx, common.NAMESPACE)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].GetName() < pods[j].GetName() })
+	sort.Slice(pvcs, func(i, j int) bool { return pvcs[i].GetName() < pvcs[j].GetName() })
+	for _, service := range cmsServices {
+		if len(services) > 0 && !common.StringInArray(service.name, services) {
+			continue
+		}
+		status := ServiceStatus{Service: service.name, Pods: []PodStatus{}, PVCs: []PVCStatus{}}
+		podRe := regexp.MustCompile(common.PodServiceNamePrefixes[service.podKey])
+		for _, pod := range pods {
+			if podRe.MatchString(pod.GetName()) {
+				status.Pods = append(status.Pods, newPodStatus(pod))
+			}
+		}
+		if len(service.pvcKey) > 0 {
+			pvcRe := regexp.MustCompile(common.PodServiceNamePrefixes[service.pvcKey])
+			for _, pvc := range pvcs {
+				if pvcRe.MatchString(pvc.GetName()) {
+					status.PVCs = append(status.PVCs, newPVCStatus(pvc))
+				}
+			}
+		}
+		statuses = append(statuses, status)
 	}
-	installed := common.StringInArray(service, serviceNames)
-	if !installed {
-		err := fmt.Errorf("%s, is not running\n", service)
-		return err
+	return statuses, nil
+}
+
+// Pad a table cell to the specified width, and color it green if healthy, or red if not
+func statusCell(text string, width int, healthy bool) string {
+	cell := fmt.Sprintf("%-*s", width, text)
+	if healthy {
+		return c.HiGreenString("%s", cell)
 	}
-	logs, err := k8s.GetPodLogs(
-		common.NAMESPACE,
-		cmsServiceData[service].serviceAPIName,
-		cmsServiceData[service].serviceContainerName,
-	)
-	if err == nil {
-		fmt.Println(logs)
+	return c.RedString("%s", cell)
+}
+
+// ListServices prints the status of the pods and PVCs of each service, as tables
+func ListServices(statuses []ServiceStatus) {
+	for _, status := range statuses {
+		if status.Healthy() {
+			fmt.Printf("%s: %s\n", status.Service, statusCell("OK", 0, true))
+		} else {
+			fmt.Printf("%s: %s\n", status.Service, statusCell("NOT OK", 0, false))
+		}
+		if len(status.Pods) == 0 {
+			fmt.Printf("  %s\n", statusCell("No pods found", 0, false))
+		} else {
+			nameWidth, phaseWidth, readyWidth := len("POD"), len("STATUS"), len("READY")
+			for _, pod := range status.Pods {
+				nameWidth, phaseWidth, readyWidth = max(nameWidth, len(pod.Name)), max(phaseWidth, len(pod.Phase)), max(readyWidth, len(pod.Ready))
+			}
+			fmt.Printf("  %-*s  %-*s  %-*s  %8s  %s\n", nameWidth, "POD", phaseWidth, "STATUS", readyWidth, "READY", "RESTARTS", "NODE")
+			for _, pod := range status.Pods {
+				fmt.Printf("  %-*s  %s  %-*s  %8d  %s\n", nameWidth, pod.Name, statusCell(pod.Phase, phaseWidth, pod.Healthy),
+					readyWidth, pod.Ready, pod.Restarts, pod.Node)
+			}
+		}
+		if len(status.PVCs) > 0 {
+			nameWidth, phaseWidth := len("PVC"), len("STATUS")
+			for _, pvc := range status.PVCs {
+				nameWidth, phaseWidth = max(nameWidth, len(pvc.Name)), max(phaseWidth, len(pvc.Phase))
+			}
+			fmt.Printf("  %-*s  %-*s  %s\n", nameWidth, "PVC", phaseWidth, "STATUS", "CAPACITY")
+			for _, pvc := range status.PVCs {
+				capacity := pvc.Capacity
+				if len(capacity) == 0 {
+					capacity = "-"
+				}
+				fmt.Printf("  %-*s  %s  %s\n", nameWidth, pvc.Name, statusCell(pvc.Phase, phaseWidth, pvc.Healthy), capacity)
+			}
+		}
+		fmt.Println()
 	}
-	return err
 }
@@ -0,0 +1,333 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * logs.go
+ *
+ * Collection of the logs of all of the pods and containers of a CMS service
+ *
+ */
+package cms
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+)
+
+// LogOptions selects the logs to collect from each container
+type LogOptions struct {
+	// Only logs newer than this (all logs, if 0)
+	Since time.Duration
+	// Keep streaming new logs until interrupted
+	Follow bool
+	// The logs of the previous instance of each container which has restarted, rather than
+	// those of the current one (for example, of a crash looping container)
+	Previous bool
+	// Only lines which match this (all lines, if nil)
+	Grep *regexp.Regexp
+}
+
+// PodContainer is a container (or init container) of a pod of a CMS service
+type PodContainer struct {
+	Pod       string
+	Container string
+	Restarts  int32
+}
+
+func (pc PodContainer) String() string {
+	return pc.Pod + "/" + pc.Container
+}
+
+// ServiceContainers returns the containers of the pods of a CMS service, with the pods found
+// by the same name prefixes that the tests use (see common.PodServiceNamePrefixes). The pods
+// are sorted by name, and the init containers of each pod come first.
+func ServiceContainers(ctx context.Context, service string) ([]PodContainer, error) {
+	podKey := ""
+	for _, s := range cmsServices {
+		if s.name == service {
+			podKey = s.podKey
+		}
+	}
+	if len(podKey) == 0 {
+		return nil, fmt.Errorf("Unknown CMS service '%s'", service)
+	}
+	pods, err := k8s.GetPods(ctx, common.NAMESPACE, common.PodServiceNamePrefixes[podKey])
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].GetName() < pods[j].GetName() })
+	var containers []PodContainer
+	for _, pod := range pods {
+		restarts := map[string]int32{}
+		for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			restarts[cs.Name] = cs.RestartCount
+		}
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			containers = append(containers, PodContainer{Pod: pod.GetName(), Container: container.Name, Restarts: restarts[container.Name]})
+		}
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("No %s pods found in namespace %s", service, common.NAMESPACE)
+	}
+	return containers, nil
+}
+
+// Returns the containers of a CMS service whose logs are selected by the options: with
+// Previous, only those which have restarted, since the others have no previous logs
+func selectContainers(ctx context.Context, service string, opts LogOptions) (selected []PodContainer, err error) {
+	containers, err := ServiceContainers(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	for _, pc := range containers {
+		if opts.Previous && pc.Restarts == 0 {
+			common.Debugf(ctx, "Skipping %s, which has not restarted", pc)
+			continue
+		}
+		selected = append(selected, pc)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("None of the %d %s containers have restarted, so there are no previous logs", len(containers), service)
+	}
+	return selected, nil
+}
+
+func (opts LogOptions) podLogOptions(container string) *coreV1.PodLogOptions {
+	options := &coreV1.PodLogOptions{Container: container, Follow: opts.Follow, Previous: opts.Previous}
+	if opts.Since > 0 {
+		seconds := int64((opts.Since + time.Second - 1) / time.Second)
+		options.SinceSeconds = &seconds
+	}
+	return options
+}
+
+// Copy the lines of a log which match the options to dst, each preceded by prefix. Each line
+// is written with a single call, while holding lock (if not nil), so that lines from logs
+// which are copied concurrently are not mixed up.
+func copyLogLines(dst io.Writer, src io.Reader, prefix string, opts LogOptions, lock sync.Locker) error {
+	reader := bufio.NewReader(src)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 && (opts.Grep == nil || opts.Grep.MatchString(line)) {
+			if line[len(line)-1] != '\n' {
+				line += "\n"
+			}
+			if lock != nil {
+				lock.Lock()
+			}
+			_, writeErr := io.WriteString(dst, prefix+line)
+			if lock != nil {
+				lock.Unlock()
+			}
+			if writeErr != nil {
+				return writeErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// Copy the selected logs of a container to dst
+func copyContainerLogs(ctx context.Context, dst io.Writer, pc PodContainer, prefix string, opts LogOptions, lock sync.Locker) error {
+	stream, err := k8s.StreamPodLogs(ctx, common.NAMESPACE, pc.Pod, opts.podLogOptions(pc.Container))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	return copyLogLines(dst, stream, prefix, opts, lock)
+}
+
+// How often the pods of a service are listed while following its logs, to find new containers
+const followPollInterval = 10 * time.Second
+
+// PrintServiceLogs writes the logs of every container of the pods of a CMS service to
+// stdout, with each line preceded by [pod/container]. With Follow, the logs are streamed
+// until cmsdev is interrupted (see followServiceLogs). Otherwise, the containers whose logs
+// cannot be retrieved are reported, and an error is returned.
+func PrintServiceLogs(ctx context.Context, service string, opts LogOptions) error {
+	containers, err := selectContainers(ctx, service, opts)
+	if err != nil {
+		return err
+	} else if opts.Follow {
+		followServiceLogs(ctx, service, containers, opts)
+		return nil
+	}
+	failed := make([]bool, len(containers))
+	for i, pc := range containers {
+		if err := copyContainerLogs(ctx, os.Stdout, pc, "["+pc.String()+"] ", opts, nil); err != nil {
+			common.Warnf(ctx, "Unable to get the logs of %s: %v", pc, err)
+			failed[i] = true
+		}
+	}
+	return failedContainersError(containers, failed)
+}
+
+// Streams the logs of the containers of a CMS service concurrently, until cmsdev is
+// interrupted. The pods of the service are listed every followPollInterval, and the logs of
+// containers which were not there before (such as those of pods which replaced others), or
+// which have restarted since their logs stopped, are streamed as they are found. The
+// containers whose logs cannot be retrieved are reported as warnings.
+func followServiceLogs(ctx context.Context, service string, containers []PodContainer, opts LogOptions) {
+	// A container whose logs have been streamed, with its restart count when they started
+	type followedContainer struct {
+		restarts  int32
+		streaming bool
+	}
+	followed := map[string]*followedContainer{}
+	var lock, followedLock sync.Mutex
+	var wg sync.WaitGroup
+	for {
+		for _, pc := range containers {
+			followedLock.Lock()
+			fc, ok := followed[pc.String()]
+			if ok && (fc.streaming || pc.Restarts <= fc.restarts) {
+				followedLock.Unlock()
+				continue
+			}
+			fc = &followedContainer{restarts: pc.Restarts, streaming: true}
+			followed[pc.String()] = fc
+			followedLock.Unlock()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := copyContainerLogs(ctx, os.Stdout, pc, "["+pc.String()+"] ", opts, &lock); err != nil {
+					common.Warnf(ctx, "Unable to get the logs of %s: %v", pc, err)
+				}
+				followedLock.Lock()
+				fc.streaming = false
+				followedLock.Unlock()
+			}()
+		}
+		if !common.Sleep(ctx, followPollInterval) {
+			break
+		}
+		var err error
+		if containers, err = ServiceContainers(ctx, service); err != nil {
+			common.Warnf(ctx, "Unable to list the %s containers: %v", service, err)
+		}
+	}
+	wg.Wait()
+}
+
+// WriteServiceLogsTarball writes the logs of every container of the pods of a CMS service to
+// a gzipped tar file, with the logs of each container in <service>/<pod>/<container>.log
+// (or <container>-previous.log, with Previous). Follow is not supported.
+func WriteServiceLogsTarball(ctx context.Context, service, path string, opts LogOptions) error {
+	if opts.Follow {
+		return fmt.Errorf("Following logs is not supported when writing them to a tar file")
+	}
+	containers, err := selectContainers(ctx, service, opts)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	// The size of each file must be known before it is added to the tar file, so each log is
+	// first copied to a temporary file, rather than held in memory
+	tmpFile, err := os.CreateTemp("", "cmsdev-logs-*.log")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	now := time.Now()
+	failed := make([]bool, len(containers))
+	for i, pc := range containers {
+		if err := tmpFile.Truncate(0); err != nil {
+			return err
+		} else if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		writer := bufio.NewWriter(tmpFile)
+		if err := copyContainerLogs(ctx, writer, pc, "", opts, nil); err != nil {
+			common.Warnf(ctx, "Unable to get the logs of %s: %v", pc, err)
+			failed[i] = true
+			continue
+		} else if err := writer.Flush(); err != nil {
+			return err
+		}
+		size, err := tmpFile.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		} else if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s/%s/%s.log", service, pc.Pod, pc.Container)
+		if opts.Previous {
+			name = fmt.Sprintf("%s/%s/%s-previous.log", service, pc.Pod, pc.Container)
+		}
+		header := &tar.Header{Name: name, Mode: 0644, Size: size, ModTime: now}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		} else if _, err := io.CopyN(tw, tmpFile, size); err != nil {
+			return err
+		}
+		common.Infof(ctx, "Added the logs of %s (%d bytes) to %s", pc, size, path)
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	} else if err := gz.Close(); err != nil {
+		return err
+	}
+	return failedContainersError(containers, failed)
+}
+
+// Returns an error listing the containers whose logs could not be retrieved, if any
+func failedContainersError(containers []PodContainer, failed []bool) error {
+	var names []string
+	for i, pc := range containers {
+		if failed[i] {
+			names = append(names, pc.String())
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Unable to get the logs of %d of %d containers: %s", len(names), len(containers), strings.Join(names, ", "))
+}
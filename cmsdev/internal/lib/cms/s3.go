@@ -29,6 +29,7 @@ package cms
  */
 
 import (
+	"context"
 	"encoding/json"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
@@ -46,18 +47,18 @@ type ArtifactRecord struct {
 
 // Get a list of S3 buckets via CLI.
 // If error, logs it and returns nil.
-func GetBuckets() []string {
-	common.Debugf("Getting list of all S3 buckets via CLI")
-	cmdOut := test.RunCLICommandJSON("artifacts", "buckets", "list")
+func GetBuckets(ctx context.Context) []string {
+	common.Debugf(ctx, "Getting list of all S3 buckets via CLI")
+	cmdOut := test.RunCLICommandJSON(ctx, "artifacts", "buckets", "list")
 	if cmdOut == nil {
 		return nil
 	}
 
 	// Extract list of buckets from command output
-	common.Debugf("Decoding JSON in command output")
+	common.Debugf(ctx, "Decoding JSON in command output")
 	bucketList, err := common.DecodeJSONIntoStringList(cmdOut)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return nil
 	}
 	return bucketList
@@ -65,9 +66,9 @@ func GetBuckets() []string {
 
 // Get a list of artifacts in the specified S3 bucket (via CLI).
 // If error, logs it and returns nil.
-func GetArtifactsInBucket(bucket string) []ArtifactRecord {
-	common.Debugf("Getting list of all S3 artifacts in %s bucket via CLI", bucket)
-	cmdOut := test.RunCLICommandJSON("artifacts", "list", bucket)
+func GetArtifactsInBucket(ctx context.Context, bucket string) []ArtifactRecord {
+	common.Debugf(ctx, "Getting list of all S3 artifacts in %s bucket via CLI", bucket)
+	cmdOut := test.RunCLICommandJSON(ctx, "artifacts", "list", bucket)
 	if cmdOut == nil {
 		return nil
 	}
@@ -75,9 +76,9 @@ func GetArtifactsInBucket(bucket string) []ArtifactRecord {
 	var listArtifactsObject ListArtifacts
 
 	// Extract object from command output
-	common.Debugf("Decoding JSON in command output")
+	common.Debugf(ctx, "Decoding JSON in command output")
 	if err := json.Unmarshal(cmdOut, &listArtifactsObject); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return nil
 	}
 
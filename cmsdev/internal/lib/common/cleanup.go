@@ -0,0 +1,172 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * cleanup.go
+ *
+ * Registry of resources created by tests, so that they can be deleted even if
+ * the test does not get far enough to delete them itself
+ *
+ */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type cleanupEntry struct {
+	kind, name string
+	// The run which created the resource, and the tenant it was using at the time
+	owner  *runState
+	tenant string
+	// Deletes the resource, returning true if it was deleted or no longer exists
+	cleanup func() bool
+}
+
+// Registered cleanups, in the order they were registered
+var cleanups []*cleanupEntry
+var cleanupsLock sync.Mutex
+
+// RegisterCleanup registers a function which deletes a resource that the current test
+// has created. The function should return true if the resource was deleted or no longer
+// exists. Unless the test deletes the resource itself (and calls ResourceDeleted), the
+// function is called when the test attempt ends, or before cmsdev exits.
+// Registering the same kind and name again (for example, when a resource is updated)
+// does nothing.
+func RegisterCleanup(ctx context.Context, kind, name string, cleanup func() bool) {
+	rs := runStateOf(ctx)
+	cleanupsLock.Lock()
+	defer cleanupsLock.Unlock()
+	for _, entry := range cleanups {
+		if entry.owner == rs && entry.kind == kind && entry.name == name {
+			return
+		}
+	}
+	cleanups = append(cleanups, &cleanupEntry{
+		kind:    kind,
+		name:    name,
+		owner:   rs,
+		tenant:  rs.tenantName,
+		cleanup: cleanup,
+	})
+	Debugf(ctx, "Registered cleanup for %s %s", kind, name)
+}
+
+// ResourceDeleted removes the registered cleanup (if any) for a resource which the
+// current test has deleted itself
+func ResourceDeleted(ctx context.Context, kind, name string) {
+	rs := runStateOf(ctx)
+	cleanupsLock.Lock()
+	defer cleanupsLock.Unlock()
+	for i, entry := range cleanups {
+		if entry.owner == rs && entry.kind == kind && entry.name == name {
+			cleanups = append(cleanups[:i], cleanups[i+1:]...)
+			Debugf(ctx, "Removed cleanup for deleted %s %s", kind, name)
+			return
+		}
+	}
+}
+
+// KeepResources removes the registered cleanups of the current run without calling them, so
+// that its resources are left in place (for example, for inspection after the test). It returns
+// the kinds and names of the resources, most recently created first.
+func KeepResources(ctx context.Context) (kept []string) {
+	entries := takeCleanups(runStateOf(ctx))
+	for i := len(entries) - 1; i >= 0; i-- {
+		kept = append(kept, fmt.Sprintf("%s %s", entries[i].kind, entries[i].name))
+	}
+	return
+}
+
+// RunCleanups deletes the resources which are still registered by the current run,
+// most recently created first
+func RunCleanups(ctx context.Context) {
+	runCleanups(ctx, runStateOf(ctx))
+}
+
+// RunAllCleanups deletes the resources which are still registered by any run, most
+// recently created first. This is used when cmsdev is exiting early.
+func RunAllCleanups(ctx context.Context) {
+	runCleanups(ctx, nil)
+}
+
+// Remove and return the registered cleanups for the specified run (or for all runs, if nil)
+func takeCleanups(owner *runState) (taken []*cleanupEntry) {
+	cleanupsLock.Lock()
+	defer cleanupsLock.Unlock()
+	remaining := cleanups[:0]
+	for _, entry := range cleanups {
+		if owner == nil || entry.owner == owner {
+			taken = append(taken, entry)
+		} else {
+			remaining = append(remaining, entry)
+		}
+	}
+	cleanups = remaining
+	return
+}
+
+// Call a cleanup function, treating a panic as a failure to delete the resource
+func (entry *cleanupEntry) run(ctx context.Context) (deleted bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			Warnf(ctx, "Panic while deleting %s %s: %v", entry.kind, entry.name, r)
+			deleted = false
+		}
+	}()
+	return entry.cleanup()
+}
+
+func runCleanups(ctx context.Context, owner *runState) {
+	entries := takeCleanups(owner)
+	if len(entries) == 0 {
+		return
+	}
+	rs := runStateOf(ctx)
+	savedTenant, savedCleaningUp := rs.tenantName, rs.cleaningUp
+	rs.cleaningUp = true
+	defer func() {
+		rs.tenantName, rs.cleaningUp = savedTenant, savedCleaningUp
+	}()
+
+	Infof(ctx, "Deleting %d resources left behind by tests", len(entries))
+	var failed []string
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		Infof(ctx, "Deleting %s %s", entry.kind, entry.name)
+		// Delete the resource as the same tenant that created it
+		rs.tenantName = entry.tenant
+		if !entry.run(ctx) {
+			failed = append(failed, fmt.Sprintf("%s %s", entry.kind, entry.name))
+		}
+	}
+	if len(failed) > 0 {
+		Warnf(ctx, "Unable to delete %d test resources; they must be deleted manually: %s", len(failed), strings.Join(failed, ", "))
+	} else {
+		Infof(ctx, "Deleted all %d resources left behind by tests", len(entries))
+	}
+}
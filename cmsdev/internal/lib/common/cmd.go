@@ -1,7 +1,7 @@
 //
 //  MIT License
 //
-//  (C) Copyright 2021-2022, 2024-2025 Hewlett Packard Enterprise Development LP
+//  (C) Copyright 2021-2022, 2024-2026 Hewlett Packard Enterprise Development LP
 //
 //  Permission is hereby granted, free of charge, to any person obtaining a
 //  copy of this software and associated documentation files (the "Software"),
@@ -37,13 +37,16 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 )
 
 var CommandPaths = map[string]string{}
+var commandPathsLock sync.Mutex
 
 const CmdRcCannotGet = -1
-const CLI_TIMEOUT_SECONDS = 120 * time.Second // Timeout for CLI calls setting it to 2 minutes
+
+var CLI_TIMEOUT_SECONDS = defaultCLITimeoutSeconds * time.Second // Timeout for CLI calls (set from the cmsdev configuration)
 
 // Ran is set to true if the Run command was called on the
 // Cmd object. It does not mean that the command itself actually
@@ -59,11 +62,14 @@ type CommandResult struct {
 	Rc                 int
 	OutBytes, ErrBytes []byte
 	Ran                bool
+	// If set, the stderr of the command is written to OutBytes along with its stdout, in the
+	// order they were written (like exec.Cmd.CombinedOutput), and ErrBytes is empty
+	Combined bool
 }
 
-func (cmdResult *CommandResult) Init(cmdEnv map[string]string, cmdPath string, cmdArgs ...string) error {
+func (cmdResult *CommandResult) Init(ctx context.Context, cmdEnv map[string]string, cmdPath string, cmdArgs ...string) error {
 	if len(cmdPath) == 0 {
-		Debugf("CommandResult Init(): cmdArgs = %v", cmdArgs)
+		Debugf(ctx, "CommandResult Init(): cmdArgs = %v", cmdArgs)
 		return fmt.Errorf("CommandResult Init(): cmdPath may not be empty")
 	}
 	cmdResult.CmdPath = cmdPath
@@ -102,55 +108,39 @@ func (cmdResult *CommandResult) SetEnvVars() string {
 	return envVarNames.String()
 }
 
-// RunWithRetry executes the command and retries if Error contains "503 Service Unavailable".
-// maxRetries specifies how many times to retry (not counting the first attempt).
-// retryDelay specifies the delay between retries.
-func RunNameWithRetry(cmdName string, cmdArgs ...string) (*CommandResult, error) {
-	maxRetries := 3
-	retryDelay := 5 * time.Second
-	var cmdResult *CommandResult
-	var err error
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		// If this is not our first attempt, then, print a message that we are retrying, and sleep
-		// before the retry.
-		if attempt > 0 {
-			Infof("Retrying command due to '503 Service Unavailable' (retry attempt %d/%d)", attempt+1, maxRetries)
-			time.Sleep(retryDelay)
-		}
-		cmdResult, err = RunName(cmdName, cmdArgs...)
-		// If the error was "503 Service Unavailable" and return code was 2, go to the next
-		// iteration of the loop (or end the loop, if retries are exhausted)
-		if strings.Contains(cmdResult.ErrString(), "503 Service Unavailable") && cmdResult.Rc == 2 {
-			continue
-		}
-		// If we are here, we had a return code other than 2, or the output did not contain
-		// "503 Service Unavailable" (or both). In this case, return the results
-		return cmdResult, err
-	}
-	return cmdResult, fmt.Errorf("Command failed after %d retries: %v", maxRetries, err)
+// RunNameWithRetry executes a cray CLI command, and retries it with the cli retry policy if it
+// exits with 2 because the API gateway did not process the request ("503 Service Unavailable"
+// or "429 Too Many Requests"). See retry.go.
+func RunNameWithRetry(ctx context.Context, cmdName string, cmdArgs ...string) (*CommandResult, error) {
+	return RunNameWithRetryClass(ctx, RetryClassCLI, cmdName, cmdArgs...)
 }
 
 // The command returning non-0 does NOT constitute an error -- that
 // is communicated back via the command return code, and the calling
 // function is responsible for determining how to handle that
-func (cmdResult *CommandResult) Run() (err error) {
+func (cmdResult *CommandResult) Run(ctx context.Context) (err error) {
 	var stdout, stderr bytes.Buffer
 
-	// Create a context for CLI command.
-	ctx, cancel := context.WithTimeout(context.Background(), CLI_TIMEOUT_SECONDS)
+	// Create a context for CLI command, so that it is killed if it times out or the runs
+	// are cancelled
+	ctx, cancel := context.WithTimeout(CallContext(ctx), CLI_TIMEOUT_SECONDS)
 	defer cancel()
 
 	cmdResult.ExecCmd = exec.CommandContext(ctx, cmdResult.CmdPath, cmdResult.CmdArgs...)
 	cmdResult.CmdString = fmt.Sprintf("%s", cmdResult.ExecCmd)
 	envVarNames := cmdResult.SetEnvVars()
 	if len(envVarNames) > 0 {
-		Debugf("Running command: %s", cmdResult.CmdString)
-		Debugf("The following additional environment variables are set for the command: %s", envVarNames)
+		Debugf(ctx, "Running command: %s", cmdResult.CmdString)
+		Debugf(ctx, "The following additional environment variables are set for the command: %s", envVarNames)
 	} else {
-		Debugf("Running command with no additional environment variables set: %s", cmdResult.CmdString)
+		Debugf(ctx, "Running command with no additional environment variables set: %s", cmdResult.CmdString)
 	}
 	cmdResult.ExecCmd.Stdout = &stdout
-	cmdResult.ExecCmd.Stderr = &stderr
+	if cmdResult.Combined {
+		cmdResult.ExecCmd.Stderr = &stdout
+	} else {
+		cmdResult.ExecCmd.Stderr = &stderr
+	}
 
 	cmdResult.CmdErr = cmdResult.ExecCmd.Run()
 	cmdResult.Ran = true
@@ -158,14 +148,17 @@ func (cmdResult *CommandResult) Run() (err error) {
 	// Check for timeout first
 	if ctx.Err() == context.DeadlineExceeded {
 		cmdResult.Rc = CmdRcCannotGet
-		Error(fmt.Errorf("CLI command timed out"))
+		Error(ctx, fmt.Errorf("CLI command timed out"))
 		err = fmt.Errorf("CLI command timed out")
+	} else if ctx.Err() == context.Canceled {
+		cmdResult.Rc = CmdRcCannotGet
+		err = fmt.Errorf("CLI command was interrupted")
 	} else if cmdResult.CmdErr != nil {
 		if exitError, ok := cmdResult.CmdErr.(*exec.ExitError); ok {
 			cmdResult.Rc = exitError.ExitCode()
 		} else {
 			cmdResult.Rc = CmdRcCannotGet
-			Error(cmdResult.CmdErr)
+			Error(ctx, cmdResult.CmdErr)
 			err = fmt.Errorf("Unable to determine command return code")
 		}
 	} else {
@@ -174,30 +167,32 @@ func (cmdResult *CommandResult) Run() (err error) {
 
 	cmdResult.OutBytes, cmdResult.ErrBytes = stdout.Bytes(), stderr.Bytes()
 	if cmdResult.Rc != CmdRcCannotGet {
-		Debugf("Command return code: %d", cmdResult.Rc)
+		Debugf(ctx, "Command return code: %d", cmdResult.Rc)
 	}
 	if len(cmdResult.OutString()) > 0 {
-		Debugf("Command stdout:\n%s", cmdResult.OutString())
+		Debugf(ctx, "Command stdout:\n%s", cmdResult.OutString())
 	} else {
-		Debugf("No stdout from command")
+		Debugf(ctx, "No stdout from command")
 	}
 	if len(cmdResult.ErrString()) > 0 {
-		Debugf("Command stderr:\n%s", cmdResult.ErrString())
+		Debugf(ctx, "Command stderr:\n%s", cmdResult.ErrString())
 	} else {
-		Debugf("No stderr from command")
+		Debugf(ctx, "No stderr from command")
 	}
 	return
 }
 
 // Looks up the path of the specified command
 // Logs errors, if any
-func GetPath(cmdName string) (path string, err error) {
+func GetPath(ctx context.Context, cmdName string) (path string, err error) {
+	commandPathsLock.Lock()
+	defer commandPathsLock.Unlock()
 	path, ok := CommandPaths[cmdName]
 	if ok {
-		Debugf("Using cached value of %s path: %s", cmdName, path)
+		Debugf(ctx, "Using cached value of %s path: %s", cmdName, path)
 		return
 	}
-	Debugf("Looking up path of %s", cmdName)
+	Debugf(ctx, "Looking up path of %s", cmdName)
 	path, err = exec.LookPath(cmdName)
 	if err != nil {
 		return
@@ -205,7 +200,7 @@ func GetPath(cmdName string) (path string, err error) {
 		err = fmt.Errorf("Empty path found for %s", cmdName)
 		return
 	}
-	Debugf("Found path of %s: %s", cmdName, path)
+	Debugf(ctx, "Found path of %s: %s", cmdName, path)
 	CommandPaths[cmdName] = path
 	return
 }
@@ -213,35 +208,59 @@ func GetPath(cmdName string) (path string, err error) {
 // The command returning non-0 does NOT constitute an error -- that
 // is communicated back via the command return code, and the calling
 // function is responsible for determining how to handle that
-func RunPathWithEnv(cmdEnv map[string]string, cmdPath string, cmdArgs ...string) (cmdResult *CommandResult, err error) {
-	cmdResult = new(CommandResult)
-	err = cmdResult.Init(cmdEnv, cmdPath, cmdArgs...)
+func RunPathWithEnv(ctx context.Context, cmdEnv map[string]string, cmdPath string, cmdArgs ...string) (cmdResult *CommandResult, err error) {
+	return runPath(ctx, cmdEnv, false, cmdPath, cmdArgs...)
+}
+
+// Run the command at the specified path, with its stderr in its stdout if combined is set
+func runPath(ctx context.Context, cmdEnv map[string]string, combined bool, cmdPath string, cmdArgs ...string) (cmdResult *CommandResult, err error) {
+	cmdResult = &CommandResult{Combined: combined}
+	err = cmdResult.Init(ctx, cmdEnv, cmdPath, cmdArgs...)
 	if err != nil {
 		return
 	}
-	err = cmdResult.Run()
+	err = cmdResult.Run(ctx)
 	return
 }
 
 // Wrapper for RunPathWithEnv with no environment variables
-func RunPath(cmdPath string, cmdArgs ...string) (*CommandResult, error) {
-	return RunPathWithEnv(nil, cmdPath, cmdArgs...)
+func RunPath(ctx context.Context, cmdPath string, cmdArgs ...string) (*CommandResult, error) {
+	return RunPathWithEnv(ctx, nil, cmdPath, cmdArgs...)
 }
 
 // The command returning non-0 does NOT constitute an error -- that
 // is communicated back via the command return code, and the calling
 // function is responsible for determining how to handle that
-func RunNameWithEnv(cmdEnv map[string]string, cmdName string, cmdArgs ...string) (cmdResult *CommandResult, err error) {
+// When recording or replaying (see recorder.go), the result is recorded or replayed.
+func RunNameWithEnv(ctx context.Context, cmdEnv map[string]string, cmdName string, cmdArgs ...string) (cmdResult *CommandResult, err error) {
+	return runName(ctx, cmdEnv, false, cmdName, cmdArgs...)
+}
+
+// Run the named command, with its stderr in its stdout if combined is set, recording or
+// replaying it if that was requested
+func runName(ctx context.Context, cmdEnv map[string]string, combined bool, cmdName string, cmdArgs ...string) (cmdResult *CommandResult, err error) {
+	if Replaying() {
+		return replayCommand(ctx, cmdEnv, cmdName, cmdArgs)
+	}
 	cmdResult = new(CommandResult)
-	cmdPath, err := GetPath(cmdName)
+	cmdPath, err := GetPath(ctx, cmdName)
 	if err != nil {
 		return
 	}
-	cmdResult, err = RunPathWithEnv(cmdEnv, cmdPath, cmdArgs...)
+	cmdResult, err = runPath(ctx, cmdEnv, combined, cmdPath, cmdArgs...)
+	if Recording() {
+		recordCommand(ctx, cmdEnv, cmdName, cmdArgs, cmdResult, err)
+	}
 	return
 }
 
 // Wrapper for RunNameWithEnv with no environment variables
-func RunName(cmdName string, cmdArgs ...string) (*CommandResult, error) {
-	return RunNameWithEnv(nil, cmdName, cmdArgs...)
+func RunName(ctx context.Context, cmdName string, cmdArgs ...string) (*CommandResult, error) {
+	return RunNameWithEnv(ctx, nil, cmdName, cmdArgs...)
+}
+
+// Like RunName, but the stderr of the command is included in its stdout (see
+// CommandResult.Combined), for commands whose output is only shown or searched as a whole
+func RunNameCombined(ctx context.Context, cmdName string, cmdArgs ...string) (*CommandResult, error) {
+	return runName(ctx, nil, true, cmdName, cmdArgs...)
 }
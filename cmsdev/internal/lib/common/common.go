@@ -1,7 +1,7 @@
 //
 //  MIT License
 //
-//  (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+//  (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 //  Permission is hereby granted, free of charge, to any person obtaining a
 //  copy of this software and associated documentation files (the "Software"),
@@ -31,26 +31,32 @@
 package common
 
 import (
+	"context"
+	cryptorand "crypto/rand"
 	"fmt"
 	"io/ioutil"
-	"math/rand"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	resty "gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/openapi"
 )
 
-const BASEHOST = "api-gw-service-nmn.local"
-const BASEURL = "https://" + BASEHOST
+// These are set from the cmsdev configuration (see config.go)
+var BASEHOST = defaultBaseHost
+var BASEURL = "https://" + BASEHOST
+var NAMESPACE = defaultNamespace
+var API_TIMEOUT_SECONDS = defaultAPITimeoutSeconds * time.Second // Timeout for API calls
+
 const LOCALHOST = "http://localhost:5000"
-const NAMESPACE string = "services"
 const CSMPRODCATALOGCMNAME string = "cray-product-catalog"
-const API_TIMEOUT_SECONDS = 120 * time.Second // Timeout for API calls setting it to 2 minutes
-const API_RETRY_COUNT = 3                     // Number of times to retry API calls
-const API_RETRY_WAIT_SECONDS = 5              // Number of seconds to wait between retries
 
 // List of API versions supported by the IMS service
 var IMSAPIVERSIONS = []string{
@@ -59,10 +65,8 @@ var IMSAPIVERSIONS = []string{
 }
 
 // variable to dynamically set the API version to be used in the tests
-var imsAPIVersions string = ""
 
 // variable to dynamically set the tenant name to be used in the tests
-var tenantName string = ""
 
 // List of RPMs version captured at the start of the test. In case of failure, rpm -qa will be captured.
 var RPMLIST = []string{
@@ -74,17 +78,21 @@ var RPMLIST = []string{
 
 // struct to hold endpoint METHOD operation details
 type endpointMethod struct {
-	parameters string // TODO: change to interface
+	parameters string
 	responses  []int
 	summary    string
+	// true if the method is that of the collection path, rather than the item path
+	collection bool
 }
 
 // data structure to endpoints, URL, descriptions
 type Endpoint struct {
-	Methods map[string]*endpointMethod // endpoint METHOD data
+	Methods map[string]*endpointMethod // endpoint METHOD data (of the latest version)
 	Url     string                     // url string appended to base to reach endpoint
 	Uri     string                     // uri string appended to base to reach endpoint
-	Version string                     `default:"v1"` // endpoint version
+	Version string                     `default:"v1"` // latest endpoint version
+	// The METHOD data of each API version which has the endpoint ("" for the path without a version)
+	versions map[string]map[string]*endpointMethod
 }
 
 // Restful() parameters
@@ -103,38 +111,18 @@ var PodServiceNamePrefixes = map[string]string{
 	"cfs-operator":     "cray-cfs-operator",
 	"cfsServices":      "^(cray-cfs-operator|cray-cfs-api)",
 	"console":          "cray-console",
+	"consolePvc":       "cray-console",
 	"console-data":     "cray-console-data",
 	"console-node":     "cray-console-node",
 	"console-operator": "cray-console-operator",
 	"ims":              "cray-ims",
+	"imsPvc":           "cray-ims",
 	"ipxe":             "cray-ipxe",
 	"tftp":             "cray-tftp",
+	"tftpPvc":          "cray-tftp",
 	"vcs":              "gitea-vcs",
 }
 
-// list of CMS services
-var CMSServices = []string{
-	"bos",
-	"cfs",
-	"conman",
-	"gitea",
-	"ims",
-	"ipxe",
-	"tftp",
-	"vcs",
-}
-
-// List of services which are just aliases for other
-// services, as far as cmsdev testing is concerned.
-// Note that if service a, b, and c are identical
-// to cmsdev, all but one of them should be listed here.
-// That is, do not list all three of them here. It doesn't
-// matter which one you omit.
-var CMSServicesDuplicates = []string{
-	"gitea",
-	"ipxe",
-}
-
 // List of Kubernetes things to collect for debug in case of failure
 var kubernetesThingsToCollect = []string{
 	"nodes",
@@ -157,31 +145,41 @@ var kubernetesThingsToCollect = []string{
 	"etcdbackups",
 }
 
+// Kubernetes things in kubernetesThingsToCollect which hold credentials, and the columns which
+// are collected for them
+var secretThingsToCollect = []string{"secrets", "sealedsecrets"}
+
+const secretColumns = "custom-columns=NAMESPACE:.metadata.namespace,NAME:.metadata.name,TYPE:.type,CREATED:.metadata.creationTimestamp"
+
 var TmpDir string
 
-var runTags []string
-var testService string
-var runStartTimes []time.Time
+var artifactDirectory string
+var artifactDirectoryCreated bool
+var artifactsLogged atomic.Bool
 
-var artifactDirectory, artifactFilePrefix string
-var artifactDirectoryCreated, artifactsLogged bool
+// The IMS API version used by the tests is scoped to the current run, since service tests may
+// run in parallel
+const imsAPIVersionKey = "imsAPIVersion"
 
-func SetIMSAPIVersion(version string) {
+func SetIMSAPIVersion(ctx context.Context, version string) {
 	// Set the API version to be used in the tests
-	imsAPIVersions = version
+	SetRunValue(ctx, imsAPIVersionKey, version)
 }
-func GetIMSAPIVersion() string {
+func GetIMSAPIVersion(ctx context.Context) string {
 	// Get the API version to be used in the tests
-	return imsAPIVersions
+	if version, ok := GetRunValue(ctx, imsAPIVersionKey); ok {
+		return version.(string)
+	}
+	return ""
 }
 
-func SetTenantName(name string) {
+func SetTenantName(ctx context.Context, name string) {
 	// Set the tenant name to be used in the tests
-	tenantName = name
+	runStateOf(ctx).tenantName = name
 }
 
-func GetTenantName() string {
-	return tenantName
+func GetTenantName(ctx context.Context) string {
+	return runStateOf(ctx).tenantName
 }
 
 func GetDummyTenantName() string {
@@ -193,111 +191,132 @@ func IsDummyTenant(tenantName string) bool {
 }
 
 // Set and unset the run sub-tag
-func SetRunSubTag(tag string) {
-	if len(runTags) < 1 {
+func SetRunSubTag(ctx context.Context, tag string) {
+	rs := runStateOf(ctx)
+	if len(rs.runTags) < 1 {
 		return
 	}
-	runStartTimes = append(runStartTimes, time.Now())
-	runTags = append(runTags, tag)
-	Printf("Starting sub-run: %s\n", tag)
+	rs.runStartTimes = append(rs.runStartTimes, time.Now())
+	rs.runTags = append(rs.runTags, tag)
+	Printf(ctx, "Starting sub-run: %s\n", tag)
 }
 
-func UnsetRunSubTag() {
-	if len(runTags) <= 1 {
+func UnsetRunSubTag(ctx context.Context) {
+	rs := runStateOf(ctx)
+	if len(rs.runTags) <= 1 {
 		return
 	}
-	Printf("Ended sub-run: %s (duration: %v)\n", runTags[len(runTags)-1], time.Since(runStartTimes[len(runStartTimes)-1]))
-	runStartTimes = runStartTimes[:len(runStartTimes)-1]
-	runTags = runTags[:len(runTags)-1]
+	Printf(ctx, "Ended sub-run: %s (duration: %v)\n", rs.runTags[len(rs.runTags)-1], time.Since(rs.runStartTimes[len(rs.runStartTimes)-1]))
+	rs.runStartTimes = rs.runStartTimes[:len(rs.runStartTimes)-1]
+	rs.runTags = rs.runTags[:len(rs.runTags)-1]
 }
 
-func ChangeRunSubTag(tag string) {
-	UnsetRunSubTag()
-	SetRunSubTag(tag)
+func ChangeRunSubTag(ctx context.Context, tag string) {
+	UnsetRunSubTag(ctx)
+	SetRunSubTag(ctx, tag)
 }
 
 // Capture csmdev version used for testing
 
-func GetPackageVersion(packageName string) string {
+func GetPackageVersion(ctx context.Context, packageName string) string {
 	cmd := exec.Command("rpm", "-q", packageName)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		Warnf("Package %s not installed", packageName)
+		Warnf(ctx, "Package %s not installed", packageName)
 		return ""
 	}
 	installedPkg := strings.TrimSpace(string(output))
 	return installedPkg
 }
 
-func ArtifactCommand(label, cmdName string, cmdArgs ...string) {
+func ArtifactCommand(ctx context.Context, label, cmdName string, cmdArgs ...string) {
 	if len(artifactDirectory) == 0 {
 		return
+	} else if Replaying() {
+		// The artifacts would describe the system this runs on, not the one which was recorded
+		Debugf(ctx, "Not collecting %s artifact, because a recording is being replayed", label)
+		return
+	} else if cmdName == "kubectl" && UsingFakeCluster() {
+		Debugf(ctx, "Not collecting %s artifact, because a fake Kubernetes cluster is being used", label)
+		return
 	}
 	t := time.Now()
-	outfilename := artifactDirectory + "/" + artifactFilePrefix + label + "-" + t.Format(time.RFC3339Nano) + ".txt"
+	outfilename := artifactDirectory + "/" + runStateOf(ctx).artifactFilePrefix + label + "-" + t.Format(time.RFC3339Nano) + ".txt"
 	cmdStr := cmdName + strings.Join(cmdArgs, " ")
-	Debugf("Running command: %s", cmdStr)
+	Debugf(ctx, "Running command: %s", cmdStr)
 	cmd := exec.Command(cmdName, cmdArgs...)
-	Debugf("Storing output in %s", outfilename)
+	Debugf(ctx, "Storing output in %s", outfilename)
 	outfile, err := os.Create(outfilename)
 	if err != nil {
-		Warnf("Error creating output file; %s", err.Error())
+		Warnf(ctx, "Error creating output file; %s", err.Error())
 		return
 	}
 	defer outfile.Close()
-	cmd.Stdout, cmd.Stderr = outfile, outfile
+	// The artifacts are attached to support tickets, so credentials are redacted from the output
+	// as it is written
+	output := newRedactingWriter(outfile)
+	cmd.Stdout, cmd.Stderr = output, output
 	err = cmd.Start()
 	if err != nil {
-		Warnf("Error starting command; %s", err.Error())
+		Warnf(ctx, "Error starting command; %s", err.Error())
 		return
 	}
-	artifactsLogged = true
+	artifactsLogged.Store(true)
 	err = cmd.Wait()
+	if writeErr := output.Flush(); writeErr != nil {
+		Warnf(ctx, "Error writing output file; %s", writeErr.Error())
+	}
 	if err != nil {
-		Warnf("Command failed; %s", err.Error())
+		Warnf(ctx, "Command failed; %s", err.Error())
 	} else {
-		Debugf("Command completed without error")
+		Debugf(ctx, "Command completed without error")
 	}
 }
 
-func ArtifactGetAdditionalInfo() {
-	ArtifactCommand("rpm-qa", "rpm", "-qa")
+func ArtifactGetAdditionalInfo(ctx context.Context) {
+	ArtifactCommand(ctx, "rpm-qa", "rpm", "-qa")
 }
 
-func ArtifactGetAllThings(thing string) {
-	ArtifactCommand("k8s-get-"+thing, "kubectl", "get", thing, "-A", "-o", "wide", "--show-labels=true")
+func ArtifactGetAllThings(ctx context.Context, thing string) {
+	if StringInArray(thing, secretThingsToCollect) {
+		// Only the metadata of secrets is collected, never their data (or annotations, which
+		// can contain it, like kubectl.kubernetes.io/last-applied-configuration)
+		ArtifactCommand(ctx, "k8s-get-"+thing, "kubectl", "get", thing, "-A", "-o", secretColumns)
+		return
+	}
+	ArtifactCommand(ctx, "k8s-get-"+thing, "kubectl", "get", thing, "-A", "-o", "wide", "--show-labels=true")
 }
 
-func ArtifactDescribeNodes() {
-	ArtifactCommand("k8s-describe-nodes", "kubectl", "describe", "nodes")
+func ArtifactDescribeNodes(ctx context.Context) {
+	ArtifactCommand(ctx, "k8s-describe-nodes", "kubectl", "describe", "nodes")
 }
 
-func ArtifactDescribeNamespacePods(namespace string, podNames []string) {
-	Infof("Collecting information about current Kubernetes state of following '%s' namespace pods: %s",
+func ArtifactDescribeNamespacePods(ctx context.Context, namespace string, podNames []string) {
+	Infof(ctx, "Collecting information about current Kubernetes state of following '%s' namespace pods: %s",
 		namespace, strings.Join(podNames, " "))
 	for _, podName := range podNames {
 		describeLabel := "k8s-describe-pod-" + namespace + "-" + podName
-		ArtifactCommand(describeLabel, "kubectl", "describe", "pod", "-n", namespace, podName, "--show-events=true")
+		ArtifactCommand(ctx, describeLabel, "kubectl", "describe", "pod", "-n", namespace, podName, "--show-events=true")
 		logsLabel := "k8s-logs-" + namespace + "-" + podName
-		ArtifactCommand(logsLabel, "kubectl", "logs", "-n", namespace, podName, "--all-containers=true", "--timestamps=true", "--prefix=true")
+		ArtifactCommand(ctx, logsLabel, "kubectl", "logs", "-n", namespace, podName, "--all-containers=true", "--timestamps=true", "--prefix=true")
 	}
 }
 
-func ArtifactDescribeNamespacePvcs(namespace string, pvcNames []string) {
-	Infof("Collecting information about current Kubernetes state of following '%s' namespace PVCs: %s",
+func ArtifactDescribeNamespacePvcs(ctx context.Context, namespace string, pvcNames []string) {
+	Infof(ctx, "Collecting information about current Kubernetes state of following '%s' namespace PVCs: %s",
 		namespace, strings.Join(pvcNames, " "))
 	for _, pvcName := range pvcNames {
 		describeLabel := "k8s-describe-pvc-" + namespace + "-" + pvcName
-		ArtifactCommand(describeLabel, "kubectl", "describe", "pvc", "-n", namespace, pvcName, "--show-events=true")
+		ArtifactCommand(ctx, describeLabel, "kubectl", "describe", "pvc", "-n", namespace, pvcName, "--show-events=true")
 	}
 }
 
-func ArtifactsKubernetes() {
-	Infof("Collecting information about current Kubernetes state")
+func ArtifactsKubernetes(ctx context.Context) {
+	Infof(ctx, "Collecting information about current Kubernetes state")
 	for _, thing := range kubernetesThingsToCollect {
-		ArtifactGetAllThings(thing)
+		ArtifactGetAllThings(ctx, thing)
 	}
-	ArtifactDescribeNodes()
+	ArtifactDescribeNodes(ctx)
 }
 
 // Determines index of string in slice, otherwise returns -1
@@ -318,7 +337,7 @@ func StringInArray(str string, services []string) bool {
 // return a random string
 func GetRandomString(len int) []byte {
 	randomNum := func(min, max int) int {
-		return min + rand.Intn(max-min)
+		return min + Intn(max-min)
 	}
 	bytes := make([]byte, len)
 	for i := 0; i < len; i++ {
@@ -327,305 +346,404 @@ func GetRandomString(len int) []byte {
 	return bytes
 }
 
-// methodEndpoint constructor
-func newMethodEndpoint(parameters, summary string, responses []int) *endpointMethod {
-	return &endpointMethod{
-		parameters: parameters,
-		summary:    summary,
-		responses:  responses,
-	}
-}
+// The endpoint catalog, which is built from the specs the first time it is needed (see
+// GetEndpoints), and rebuilt when other specs are loaded (see LoadOpenAPIDir)
+var endpointCatalog map[string]map[string]*Endpoint
+var endpointCatalogOnce = new(sync.Once)
 
-// GetEndpoints() loads all CMS services endpoints into a data structure
-// TODO: load from a config file or API spec
+// GetEndpoints() returns the endpoint catalog of the CMS services, derived from their OpenAPI
+// specs. Each endpoint is named for the last fixed segment of its path (with - replaced by _),
+// and its Uri is that path without the API version or any trailing parameters. It has each API
+// version which has the path (see Versions), the latest of which is its Version, and its
+// Methods are those of that version's collection and item paths. When paths share a name, the
+// shortest one has it, and the others are named for all of their fixed segments (so the IMS
+// images endpoint is /images, and /deleted/images is the deleted_images endpoint).
+// The catalog is shared, so it must not be modified.
 func GetEndpoints() map[string]map[string]*Endpoint {
+	endpointCatalogOnce.Do(func() { endpointCatalog = buildEndpoints(openapi.Specs()) })
+	return endpointCatalog
+}
 
-	endpoints := make(map[string]map[string]*Endpoint)
+// GetEndpoint returns the named endpoint of a service from the catalog, or an error if its
+// spec does not have it
+func GetEndpoint(service, name string) (*Endpoint, error) {
+	endpoints, ok := GetEndpoints()[service]
+	if !ok {
+		return nil, fmt.Errorf("There is no OpenAPI spec for %s, so its '%s' endpoint is unknown", service, name)
+	}
+	endpoint, ok := endpoints[name]
+	if !ok {
+		return nil, fmt.Errorf("The %s OpenAPI spec has no '%s' endpoint", service, name)
+	}
+	return endpoint, nil
+}
 
-	// CFS service endpoints (only the ones we are testing with cmsdsev at the moment)
-	endpoints["cfs"] = make(map[string]*Endpoint)
-	endpoints["cfs"]["components"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Retrieve state of CFS components", []int{200, 400}),
-		},
-		Url:     "/apis/cfs/v2/components",
-		Version: "v2",
-	}
-	endpoints["cfs"]["configurations"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Retrieve CFS configurations", []int{200, 400}),
-		},
-		Url:     "/apis/cfs",
-		Uri:     "/configurations",
-		Version: "v2",
-	}
-	endpoints["cfs"]["options"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Retrieve CFS options", []int{200}),
-		},
-		Url:     "/apis/cfs/v2/options",
-		Version: "v2",
-	}
-	endpoints["cfs"]["sessions"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Retrieve all CFS sessions", []int{200}),
-		},
-		Url:     "/apis/cfs/v2/sessions",
-		Version: "v2",
-	}
-	endpoints["cfs"]["healthz"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Retrieve CFS service health", []int{200, 500}),
-		},
-		Url:     "/apis/cfs/healthz",
-		Version: "v2",
-	}
-
-	endpoints["cfs"]["sources"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET":    newMethodEndpoint("", "Retrieve all CFS sources", []int{200, 400, 404}),
-			"POST":   newMethodEndpoint("", "Create a CFS source", []int{201, 400, 409}),
-			"PATCH":  newMethodEndpoint("", "Update a CFS source", []int{200, 400, 404}),
-			"DELETE": newMethodEndpoint("", "Delete a CFS source", []int{204, 400, 404}),
-		},
-		Url:     "/apis/cfs",
-		Uri:     "/sources",
-		Version: "v3",
-	}
-
-	// IMS service endpoints
-	endpoints["ims"] = make(map[string]*Endpoint)
-	endpoints["ims"]["images"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"DELETE": newMethodEndpoint("", "Delete all image records", []int{204, 500}),
-			"GET":    newMethodEndpoint("", "Retrieve all image records", []int{200, 500}),
-			"POST":   newMethodEndpoint("", "Create an image record", []int{201, 400, 422, 500}),
-			"PATCH":  newMethodEndpoint("", "Update an image record", []int{200, 400, 404, 409, 422, 500}),
-		},
-		Url:     "/apis/ims",
-		Uri:     "/images",
-		Version: "v2",
-	}
-	endpoints["ims"]["jobs"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"DELETE": newMethodEndpoint("", "Delete all job records", []int{204, 500}),
-			"GET":    newMethodEndpoint("", "Retrieve all job records", []int{200, 500}),
-			"POST":   newMethodEndpoint("", "Create a job record", []int{201, 400, 422, 500}),
-		},
-		Url:     "/apis/ims/jobs",
-		Version: "v2",
-	}
-	endpoints["ims"]["public_keys"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"DELETE": newMethodEndpoint("", "Delete all public key records", []int{204, 500}),
-			"GET":    newMethodEndpoint("", "Retrieve all public key records", []int{200, 500}),
-			"POST":   newMethodEndpoint("", "Create a public key record", []int{201, 400, 422, 500}),
-		},
-		Url:     "/apis/ims",
-		Uri:     "/public-keys",
-		Version: "v2",
-	}
-	// The status codes for these recipes IMS endpoints don't match the IMS openapi spec currently,
-	// because of bug CASMCMS-5225. The status codes here reflect the reality of how IMS works.
-	endpoints["ims"]["recipes"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"DELETE": newMethodEndpoint("", "Delete all recipe records", []int{204, 500}),
-			"GET":    newMethodEndpoint("", "Retrieve all recipe records", []int{200, 500}),
-			"POST":   newMethodEndpoint("", "Create a recipe record", []int{201, 400, 422, 500}),
-			"PATCH":  newMethodEndpoint("", "Update a recipe record", []int{200, 400, 404, 409, 422, 500}),
-		},
-		Url:     "/apis/ims",
-		Uri:     "/recipes",
-		Version: "v2",
-	}
-	endpoints["ims"]["version"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Get API version", []int{200, 500}),
-		},
-		Url:     "/apis/ims/version",
-		Version: "v2",
-	}
-	endpoints["ims"]["live"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Retrieve IMS Liveness Probe", []int{200, 500}),
-		},
-		Url:     "/apis/ims/healthz/live",
-		Version: "v2",
-	}
-	endpoints["ims"]["ready"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Retrieve IMS Readiness Probe", []int{200, 500}),
-		},
-		Url:     "/apis/ims/healthz/ready",
-		Version: "v2",
-	}
-
-	endpoints["bos"] = make(map[string]*Endpoint)
-
-	endpoints["bos"]["sessiontemplates"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET":    newMethodEndpoint("", "Retrieve all session templates", []int{200, 404}),
-			"POST":   newMethodEndpoint("", "Create a session template", []int{201, 400, 409}),
-			"PATCH":  newMethodEndpoint("", "Update a session template", []int{200, 400, 404, 409}),
-			"DELETE": newMethodEndpoint("", "Delete a session template", []int{204, 400, 404, 409}),
-		},
-		Url:     "/apis/bos",
-		Uri:     "/sessiontemplates",
-		Version: "v2",
-	}
-	endpoints["bos"]["sessions"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET":    newMethodEndpoint("", "Retrieve all sessions", []int{200, 404}),
-			"POST":   newMethodEndpoint("", "Create a session", []int{201, 400, 409}),
-			"DELETE": newMethodEndpoint("", "Delete a session", []int{204, 400, 404}),
-		},
-		Url:     "/apis/bos",
-		Uri:     "/sessions",
-		Version: "v2",
-	}
-	endpoints["bos"]["applystaged"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"POST": newMethodEndpoint("", "Apply staged changes", []int{200, 400}),
-		},
-		Url:     "/apis/bos",
-		Uri:     "/applystaged",
-		Version: "v2",
-	}
-
-	endpoints["bos"]["sessiontemplatesvalid"] = &Endpoint{
-		Methods: map[string]*endpointMethod{
-			"GET": newMethodEndpoint("", "Validate session template", []int{200, 400, 404}),
-		},
-		Url:     "/apis/bos",
-		Uri:     "/sessiontemplatesvalid",
-		Version: "v2",
+// GetServiceURL returns the base URL of the API of a service, which its endpoints share (like
+// https://api-gw-service-nmn.local/apis/bos), or an error if there is no spec for the service
+func GetServiceURL(service string) (string, error) {
+	for _, endpoint := range GetEndpoints()[service] {
+		return BASEURL + endpoint.Url, nil
 	}
+	return "", fmt.Errorf("There is no OpenAPI spec for %s, so its URL is unknown", service)
+}
 
-	return endpoints
+// LoadOpenAPIDir loads the OpenAPI specs in the specified directory in place of the embedded
+// ones (see openapi.LoadDir), and rebuilds the endpoint catalog from them. The tests use the
+// endpoints and methods of the embedded specs, so it is an error if a loaded spec does not
+// have all of them. This must be called before the tests start.
+func LoadOpenAPIDir(dir string) error {
+	services, err := openapi.LoadDir(dir)
+	if err != nil {
+		return err
+	}
+	endpointCatalogOnce = new(sync.Once)
+	templateNamesOnce = sync.Once{}
+	endpoints := GetEndpoints()
+	embedded := buildEndpoints(openapi.EmbeddedSpecs())
+	var missing []string
+	for _, service := range services {
+		for name, embeddedEndpoint := range embedded[service] {
+			endpoint, ok := endpoints[service][name]
+			for version, methods := range embeddedEndpoint.versions {
+				for method := range methods {
+					if !ok || endpoint.versions[version][method] == nil {
+						missing = append(missing, fmt.Sprintf("%s %s (%s '%s' endpoint)", method, embeddedEndpoint.path(version), service, name))
+					}
+				}
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("The OpenAPI specs in %s do not have operations which the tests use: %s", dir, strings.Join(missing, ", "))
+	}
+	return nil
 }
 
-// PrintEndpoints() prints services endpoints
-// Optionally provided an argument will print only given service endpoint
-func PrintEndpoints(service string, params ...string) {
-	var search bool = false
-	var key string
+// The path of an endpoint on the API gateway in the specified API version ("" for the path
+// without a version), like /apis/bos/v2/sessions
+func (endpoint *Endpoint) path(version string) string {
+	if len(version) == 0 {
+		return endpoint.Url + endpoint.Uri
+	}
+	return endpoint.Url + "/" + version + endpoint.Uri
+}
 
-	// get key for searching through endpoints
-	if len(params) > 0 {
-		key = params[0]
-		search = true
+// Versions returns the API versions which have the endpoint, oldest first. "" stands for the
+// path without a version (such as /apis/ims/images).
+func (endpoint *Endpoint) Versions() []string {
+	versions := make([]string, 0, len(endpoint.versions))
+	for version := range endpoint.versions {
+		versions = append(versions, version)
 	}
+	sort.Slice(versions, func(i, j int) bool { return compareVersions(versions[i], versions[j]) < 0 })
+	return versions
+}
 
-	m := GetEndpoints()
-	for k := range m {
-		for i := range m[k] {
-			if search == true {
-				if i != key {
-					continue
+// Derive the endpoint catalog from the specified specs (see GetEndpoints)
+func buildEndpoints(specs []*openapi.Spec) map[string]map[string]*Endpoint {
+	endpoints := make(map[string]map[string]*Endpoint)
+	for _, spec := range specs {
+		// The shortest path with each name
+		shortest := make(map[string]string)
+		for _, operation := range spec.Operations {
+			if _, uri, name := splitOperationPath(operation.Path); len(name) > 0 {
+				if other, ok := shortest[name]; !ok || len(uri) < len(other) {
+					shortest[name] = uri
 				}
 			}
-			if i != "/" {
-				fmt.Printf("/%s:\n", i)
-			} else {
-				fmt.Printf("%s:\n", i)
+		}
+		serviceEndpoints := make(map[string]*Endpoint)
+		for _, operation := range spec.Operations {
+			version, uri, name := splitOperationPath(operation.Path)
+			if len(name) == 0 {
+				continue
+			} else if uri != shortest[name] {
+				name = strings.ReplaceAll(strings.ReplaceAll(strings.Trim(uri, "/"), "/", "_"), "-", "_")
 			}
+			endpoint, ok := serviceEndpoints[name]
+			if !ok {
+				endpoint = &Endpoint{Url: spec.BasePath, Uri: uri, versions: map[string]map[string]*endpointMethod{}}
+				serviceEndpoints[name] = endpoint
+			}
+			methods, ok := endpoint.versions[version]
+			if !ok {
+				methods = map[string]*endpointMethod{}
+				endpoint.versions[version] = methods
+			}
+			// The collection path's methods take precedence over the item path's
+			if method, ok := methods[operation.Method]; ok && method.collection {
+				continue
+			}
+			methods[operation.Method] = &endpointMethod{
+				parameters: pathParameters(operation.Path),
+				summary:    operation.Summary,
+				responses:  statusCodes(operation.StatusCodes),
+				collection: !strings.HasSuffix(operation.Path, "}"),
+			}
+		}
+		for _, endpoint := range serviceEndpoints {
+			versions := endpoint.Versions()
+			endpoint.Version = versions[len(versions)-1]
+			endpoint.Methods = endpoint.versions[endpoint.Version]
+		}
+		endpoints[spec.Service] = serviceEndpoints
+	}
+	return endpoints
+}
+
+// Split an OpenAPI path template into its API version (if any), its path without the version
+// or trailing parameters, and its endpoint name
+func splitOperationPath(path string) (version, uri, name string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && versionSegmentRe.MatchString(segments[0]) {
+		version, segments = segments[0], segments[1:]
+	}
+	for len(segments) > 0 && (len(segments[len(segments)-1]) == 0 || openapi.IsParameter(segments[len(segments)-1])) {
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) == 0 {
+		return version, "", ""
+	}
+	return version, "/" + strings.Join(segments, "/"), strings.ReplaceAll(segments[len(segments)-1], "-", "_")
+}
+
+// Compare two API versions (like v2 and v10), where "" is older than any version
+func compareVersions(a, b string) int {
+	numberA, _ := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	numberB, _ := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	if len(a) == 0 {
+		numberA = -1
+	}
+	if len(b) == 0 {
+		numberB = -1
+	}
+	return numberA - numberB
+}
 
-			// print methods
-			for j := range m[k][i].Methods {
-				fmt.Printf("     %s:\n", j)
-				fmt.Printf("          summary: %s\n", m[k][i].Methods[j].summary)
-				fmt.Printf("          responses: %v\n", m[k][i].Methods[j].responses)
-				if len(m[k][i].Methods[j].parameters) > 0 {
-					fmt.Printf("          parameters: %s\n", m[k][i].Methods[j].parameters)
+// Returns the parameters in an OpenAPI path template, like "session_id"
+func pathParameters(path string) string {
+	var parameters []string
+	for _, segment := range strings.Split(path, "/") {
+		if openapi.IsParameter(segment) {
+			parameters = append(parameters, strings.Trim(segment, "{}"))
+		}
+	}
+	return strings.Join(parameters, ", ")
+}
+
+// Returns the numeric status codes of an operation, omitting "default" and ranges like "4XX"
+func statusCodes(codes []string) []int {
+	numbers := []int{}
+	for _, code := range codes {
+		if number, err := strconv.Atoi(code); err == nil {
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+// CatalogEndpoint describes an endpoint of the catalog (see GetEndpoints), for display
+type CatalogEndpoint struct {
+	Service string          `json:"service" yaml:"service"`
+	Name    string          `json:"name" yaml:"name"`
+	Url     string          `json:"url" yaml:"url"`
+	Version string          `json:"version" yaml:"version"`
+	Uri     string          `json:"uri" yaml:"uri"`
+	FullURL string          `json:"full_url" yaml:"full_url"`
+	Methods []CatalogMethod `json:"methods" yaml:"methods"`
+}
+
+// CatalogMethod describes a method of an endpoint of the catalog
+type CatalogMethod struct {
+	Method     string `json:"method" yaml:"method"`
+	Summary    string `json:"summary" yaml:"summary"`
+	Parameters string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Responses  []int  `json:"responses" yaml:"responses"`
+}
+
+// FullURL returns the URL of the latest version of the endpoint: the base URL, followed by
+// the Url, Version (if any), and Uri of the endpoint
+func (endpoint *Endpoint) FullURL() string {
+	return endpoint.URL(endpoint.Version)
+}
+
+// URL returns the URL of the endpoint in the specified API version ("" for the path without a
+// version), which need not be one of its Versions (for example, to test how a service handles
+// an unknown version)
+func (endpoint *Endpoint) URL(version string) string {
+	return BASEURL + endpoint.path(version)
+}
+
+// EndpointCatalog returns the endpoints of the catalog, sorted by service and name, with an
+// entry for each API version which has the endpoint (oldest first), and the methods of each
+// version sorted. If service is not empty, only the endpoints of that service are
+// returned, and if name is also not empty, only that endpoint.
+func EndpointCatalog(service, name string) ([]CatalogEndpoint, error) {
+	endpoints := GetEndpoints()
+	services := make([]string, 0, len(endpoints))
+	for s := range endpoints {
+		services = append(services, s)
+	}
+	sort.Strings(services)
+	if len(service) > 0 {
+		if _, ok := endpoints[service]; !ok {
+			return nil, fmt.Errorf("Unknown service '%s'. Services with endpoints are: %s", service, strings.Join(services, ", "))
+		}
+		services = []string{service}
+	}
+
+	catalog := []CatalogEndpoint{}
+	for _, s := range services {
+		names := make([]string, 0, len(endpoints[s]))
+		for n := range endpoints[s] {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(name) > 0 {
+			if _, ok := endpoints[s][name]; !ok {
+				return nil, fmt.Errorf("Unknown %s endpoint '%s'. Its endpoints are: %s", s, name, strings.Join(names, ", "))
+			}
+			names = []string{name}
+		}
+		for _, n := range names {
+			endpoint := endpoints[s][n]
+			for _, version := range endpoint.Versions() {
+				entry := CatalogEndpoint{Service: s, Name: n, Url: endpoint.Url, Version: version, Uri: endpoint.Uri, FullURL: endpoint.URL(version)}
+				versionMethods := endpoint.versions[version]
+				methods := make([]string, 0, len(versionMethods))
+				for m := range versionMethods {
+					methods = append(methods, m)
 				}
+				sort.Strings(methods)
+				for _, m := range methods {
+					entry.Methods = append(entry.Methods, CatalogMethod{
+						Method:     m,
+						Summary:    versionMethods[m].summary,
+						Parameters: versionMethods[m].parameters,
+						Responses:  versionMethods[m].responses,
+					})
+				}
+				catalog = append(catalog, entry)
 			}
-			fmt.Printf("  url: %s\n", m[k][i].Url)
-			fmt.Printf("  version: %s\n", m[k][i].Version)
 		}
 	}
+	return catalog, nil
 }
 
-// doRest() performs RESTful calls using the provided client and parameters.
-func doRest(method, url string, params Params, client *resty.Client) (*resty.Response, error) {
-	var err error
-	var resp *resty.Response
+// Header which identifies each API request, so that the cmsdev log entries for a request can
+// be matched with the istio and service log entries for it
+const requestIDHeader = "X-Request-ID"
+
+// Returns a random (version 4) UUID to identify a request. This does not use the cmsdev random
+// number generator, so that it does not change the names that the tests generate.
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := cryptorand.Read(b); err != nil {
+		return fmt.Sprintf("cmsdev-%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// doRest() performs RESTful calls with the shared API client (see httpclient.go), retrying
+// them with the api retry policy (see retry.go) unless WithoutRetry is specified. POST, PATCH
+// and DELETE requests are only retried when the server did not process them.
+// When recording or replaying (see recorder.go), the calls are recorded or replayed.
+// The latency and status code of each call which gets a response are recorded (see latency.go
+// and contract.go). If the certificate of the server cannot be verified, the call is retried
+// without verifying it, and the test fails even if the retry works.
+func doRest(ctx context.Context, method, url string, params Params, options ...RequestOption) (resp *resty.Response, err error) {
+	var opts requestOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	requestID := newRequestID()
+	Debugf(ctx, "%s %s: %s %s", method, url, requestIDHeader, requestID)
+	withRetries(ctx, RetryClassAPI, method+" "+url, opts.noRetry,
+		func() { resp, err = sendVerifiedRequest(ctx, method, url, params, opts, requestID) },
+		func() retryReason { return httpRetryReason(idempotentMethods[method], resp, err) })
+
+	if resp != nil && resp.RawResponse != nil {
+		recordLatency(method, url, resp.Time())
+		recordResponseStatus(ctx, method, url, resp.StatusCode(), requestID)
+	}
+	return resp, err
+}
+
+// Make a single request, and make it again without verifying the certificate of the server if
+// that is why it failed
+func sendVerifiedRequest(ctx context.Context, method, url string, params Params, opts requestOptions, requestID string) (*resty.Response, error) {
+	insecure := UsedInsecureFallback(ctx)
+	client, err := apiClient(insecure)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := sendRequest(ctx, client, method, url, params, opts, requestID)
+	if err != nil && !insecure && !GetConfig().Insecure && isCertificateError(err) {
+		Errorf(ctx, "%s %s failed: %v", method, url, err)
+		Infof(ctx, "This is a failure, but will retry request with InsecureSkipVerify set to true")
+		Infof(ctx, "Important: This means the overall test will fail even if this request works on retry!")
+		SetRunValue(ctx, insecureFallbackKey, true)
+		if client, err = apiClient(true); err != nil {
+			return nil, err
+		}
+		Infof(ctx, "InsecureSkipVerify=true %s %s", method, url)
+		resp, err = sendRequest(ctx, client, method, url, params, opts, requestID)
+	}
+	return resp, err
+}
+
+// Make a single request
+func sendRequest(ctx context.Context, client *resty.Client, method, url string, params Params, opts requestOptions, requestID string) (*resty.Response, error) {
+	request := client.R().SetContext(CallContext(ctx)).SetHeader(requestIDHeader, requestID)
+	if len(opts.username) > 0 {
+		request.SetBasicAuth(opts.username, opts.password)
+	} else {
+		request.SetAuthToken(params.Token)
+	}
+	request.SetHeaders(opts.headers)
+	request.SetMultiValueQueryParams(opts.query)
+	if len(opts.outputFile) > 0 {
+		request.SetOutput(opts.outputFile)
+	}
 
 	switch method {
-	case "GET":
-		resp, err = client.R().Get(url)
 	case "POST":
 		if len(params.JsonStr) != 0 {
 			// payload passed as string
-			resp, err = client.R().
-				SetBody(params.JsonStr).
-				Post(url)
+			request.SetBody(params.JsonStr)
 		} else {
 			// payload passed as byte array
-			resp, err = client.R().
-				SetBody(params.JsonStrArray).
-				Post(url)
+			request.SetBody(params.JsonStrArray)
 		}
 	case "PATCH":
-		resp, err = client.R().
-			SetBody(params.JsonStrArray).
-			Patch(url)
+		request.SetBody(params.JsonStrArray)
 	case "PUT":
 		// payload passed as string
-		resp, err = client.R().
-			SetBody(params.JsonStr).
-			Put(url)
-	case "DELETE":
-		resp, err = client.R().Delete(url)
+		request.SetBody(params.JsonStr)
 	}
-
-	return resp, err
+	if opts.body != nil {
+		request.SetBody(opts.body)
+	}
+	return request.Execute(method, url)
 }
 
 // Restful() performs CMS RESTful calls
-func Restful(method, url string, params Params) (*resty.Response, error) {
-	client := resty.New()
-	client.SetTimeout(API_TIMEOUT_SECONDS)
-	client.SetRetryCount(API_RETRY_COUNT)
-	client.SetRetryWaitTime(API_RETRY_WAIT_SECONDS * time.Second)
-	client.SetHeaders(map[string]string{
-		"Accept":       "application/json",
-		"User-Agent":   "cmsdev",
-		"Content-Type": "application/json",
-	})
-	client.SetAuthToken(params.Token)
-	// Add retry condition for HTTP 503 status code
-	client.AddRetryCondition(func(r *resty.Response) (bool, error) {
-		if r.StatusCode() == 503 {
-			fmt.Printf("Received HTTP code 503 from server, Waiting for: %d seconds before retry\n", API_RETRY_WAIT_SECONDS)
-			return true, nil
-		}
-		return false, nil
-	})
-	return doRest(method, url, params, client)
+func Restful(ctx context.Context, method, url string, params Params, options ...RequestOption) (*resty.Response, error) {
+	return doRest(ctx, method, url, params, options...)
+}
+
+// RestfulNoRetry() performs CMS RESTful calls without retrying them. This is for tests of how
+// a service handles concurrent requests, where a retry would hide the response being tested.
+func RestfulNoRetry(ctx context.Context, method, url string, params Params, options ...RequestOption) (*resty.Response, error) {
+	return doRest(ctx, method, url, params, append(options, WithoutRetry())...)
 }
 
 // Restful() performs CMS RESTful calls on behalf of the specified tenant
-func RestfulTenant(method, url, tenant string, params Params) (*resty.Response, error) {
-	client := resty.New()
-	client.SetTimeout(API_TIMEOUT_SECONDS)
-	client.SetRetryCount(API_RETRY_COUNT)
-	client.SetRetryWaitTime(API_RETRY_WAIT_SECONDS * time.Second)
-	client.SetHeaders(map[string]string{
-		"Accept":           "application/json",
-		"User-Agent":       "cmsdev",
-		"Content-Type":     "application/json",
-		"Cray-Tenant-Name": tenant,
-	})
-	client.SetAuthToken(params.Token)
-	// Add retry condition for HTTP 503 status code
-	client.AddRetryCondition(func(r *resty.Response) (bool, error) {
-		if r.StatusCode() == 503 {
-			fmt.Printf("Received HTTP code 503 from server, Waiting for: %d seconds before retry\n", API_RETRY_WAIT_SECONDS)
-			return true, nil
-		}
-		return false, nil
-	})
-	return doRest(method, url, params, client)
+func RestfulTenant(ctx context.Context, method, url, tenant string, params Params, options ...RequestOption) (*resty.Response, error) {
+	return doRest(ctx, method, url, params, append(options, WithTenant(tenant))...)
 }
 
 func CreateDirectoryIfNeeded(path string) (error, bool) {
@@ -663,121 +781,123 @@ func RemoveEmptyDirectory(path string) error {
 	return os.Remove(path)
 }
 
-func SetTestService(service string) {
-	if testService == "" {
-		SetRunSubTag(service)
+func SetTestService(ctx context.Context, service string) {
+	rs := runStateOf(ctx)
+	if rs.testService == "" {
+		SetRunSubTag(ctx, service)
 	} else {
-		ChangeRunSubTag(service)
+		ChangeRunSubTag(ctx, service)
 	}
 	if len(artifactDirectory) > 0 {
-		artifactFilePrefix = service + "-"
+		rs.artifactFilePrefix = service + "-"
 	}
-	testService = service
+	rs.testService = service
 }
 
-func UnsetTestService() {
-	if testService == "" {
+func UnsetTestService(ctx context.Context) {
+	rs := runStateOf(ctx)
+	if rs.testService == "" {
 		return
 	}
-	UnsetRunSubTag()
-	testService = ""
+	UnsetRunSubTag(ctx)
+	rs.testService = ""
 	if len(artifactDirectory) > 0 {
-		artifactFilePrefix = ""
+		rs.artifactFilePrefix = ""
 	}
 }
 
-func InitArtifacts() {
+func InitArtifacts(ctx context.Context) {
 	var err error
 
 	artifactDirectory = os.Getenv("ARTIFACTS")
 	if len(artifactDirectory) == 0 {
 		if len(logFileDir) == 0 {
-			Warnf("ARTIFACTS environment variable not set and test logging disabled; no artifacts will be saved")
+			Warnf(ctx, "ARTIFACTS environment variable not set and test logging disabled; no artifacts will be saved")
 			return
 		}
 		// Default to the same directory as logs (run-specific subdirectory)
 		artifactDirectory = logFileDir
-		Debugf("ARTIFACTS environment variable not set. Using log directory: '%s'", artifactDirectory)
+		Debugf(ctx, "ARTIFACTS environment variable not set. Using log directory: '%s'", artifactDirectory)
 	} else {
-		Debugf("ARTIFACTS environment variable set to '%s'", artifactDirectory)
+		Debugf(ctx, "ARTIFACTS environment variable set to '%s'", artifactDirectory)
 		err, artifactDirectoryCreated = CreateDirectoryIfNeeded(artifactDirectory)
 		if err != nil {
-			Warnf(err.Error())
-			Warnf("Error with artifact directory \"%s\"; no artifacts will be saved", artifactDirectory)
+			Warnf(ctx, "%s", err.Error())
+			Warnf(ctx, "Error with artifact directory \"%s\"; no artifacts will be saved", artifactDirectory)
 			artifactDirectory = ""
 			return
 		}
 	}
-	Infof("artifactDirectory=%s", artifactDirectory)
+	Infof(ctx, "artifactDirectory=%s", artifactDirectory)
 }
 
-func CompressArtifacts() {
+func CompressArtifacts(ctx context.Context) {
 	if len(artifactDirectory) == 0 {
 		// No artifact directory set, so nothing to do.
 		return
 	}
-	if !artifactsLogged {
+	if !artifactsLogged.Load() {
 		// No artifacts logged, but there is an artifact directory.
 		// If we created it, then we will delete it.
 		if !artifactDirectoryCreated {
-			Debugf("No artifacts saved. We did not create the artifact directory, so we will not remove it.")
+			Debugf(ctx, "No artifacts saved. We did not create the artifact directory, so we will not remove it.")
 			return
 		}
-		Infof("No artifacts saved. Removing empty artifact directory: '%s'", artifactDirectory)
+		Infof(ctx, "No artifacts saved. Removing empty artifact directory: '%s'", artifactDirectory)
 		err := RemoveEmptyDirectory(artifactDirectory)
 		artifactDirectory = ""
 		if err != nil {
-			Warnf(err.Error())
+			Warnf(ctx, "%s", err.Error())
 		}
 		return
 	}
 	// Artifacts were logged, so compress them and delete the uncompressed artifacts
 	compressedArtifactsFile := filepath.Join(artifactDirectory, "artifacts.tgz")
-	Infof("Compressing saved test artifacts to '%s'", compressedArtifactsFile)
+	Infof(ctx, "Compressing saved test artifacts to '%s'", compressedArtifactsFile)
 
 	// Create tar archive of all files except artifacts.tgz and cmsdev.log, then remove source files
-	cmdResult, err := RunName("tar", "-C", artifactDirectory, "--remove-files",
+	cmdResult, err := RunName(ctx, "tar", "-C", artifactDirectory, "--remove-files",
 		"--exclude=cmsdev.log", "-czf", compressedArtifactsFile, ".")
 	if err != nil {
-		Warnf(err.Error())
+		Warnf(ctx, "%s", err.Error())
 		return
 	}
 	if cmdResult.Rc == 0 {
 		// Command passed - artifacts compressed and individual files removed
-		Debugf("Successfully compressed artifacts and removed individual files")
+		Debugf(ctx, "Successfully compressed artifacts and removed individual files")
 		return
 	}
-	Warnf("Error compressing artifacts (tar return code = %d)", cmdResult.Rc)
+	Warnf(ctx, "Error compressing artifacts (tar return code = %d)", cmdResult.Rc)
 }
 
 // The caller of this function is responsible for removing the directory
-func CreateTmpDir() (err error) {
+func CreateTmpDir(ctx context.Context) (err error) {
 	// Pass empty string for directory name to use the default tmp directory
-	Debugf("Creating temporary directory")
+	Debugf(ctx, "Creating temporary directory")
 	TmpDir, err = ioutil.TempDir("", "cmsdev-tmpdir")
 	return
 }
 
-func DeleteTmpDir() {
+func DeleteTmpDir(ctx context.Context) {
 	if len(TmpDir) == 0 {
 		return
 	}
-	Debugf("Removing temporary directory: '%s'", TmpDir)
+	Debugf(ctx, "Removing temporary directory: '%s'", TmpDir)
 	if err := os.RemoveAll(TmpDir); err != nil {
-		Warnf("Error removing temporary directory '%s': %v", TmpDir, err)
+		Warnf(ctx, "Error removing temporary directory '%s': %v", TmpDir, err)
 		return
 	}
-	Debugf("Successfully removed temporary directory: '%s'", TmpDir)
+	Debugf(ctx, "Successfully removed temporary directory: '%s'", TmpDir)
 	TmpDir = ""
 	return
 }
 
-func GetSSHPublicKey() (sshKey string, err error) {
+func GetSSHPublicKey(ctx context.Context) (sshKey string, err error) {
 	// Get the public key from the local machine
 	// Get the home directory of the current user
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		Errorf("Error getting home directory: %v", err)
+		Errorf(ctx, "Error getting home directory: %v", err)
 		return
 	}
 	// Construct the path to the public key file
@@ -793,17 +913,17 @@ func GetSSHPublicKey() (sshKey string, err error) {
 	return
 }
 
-func CompareSlicesOfMaps(a, b []map[string]string) bool {
+func CompareSlicesOfMaps(ctx context.Context, a, b []map[string]string) bool {
 	// Check if lengths are different
-	Infof("Comparing slices of maps: %v and %v", a, b)
+	Infof(ctx, "Comparing slices of maps: %v and %v", a, b)
 	if len(a) != len(b) {
-		Errorf("Slices are different lengths: %v and %v", a, b)
+		Errorf(ctx, "Slices are different lengths: %v and %v", a, b)
 		return false
 	}
 
 	// Compare each map in the slices
 	for i := range a {
-		if !CompareMaps(a[i], b[i]) {
+		if !CompareMaps(ctx, a[i], b[i]) {
 			return false
 		}
 	}
@@ -811,18 +931,18 @@ func CompareSlicesOfMaps(a, b []map[string]string) bool {
 	return true
 }
 
-func CompareMaps(a, b map[string]string) bool {
-	Infof("Comparing maps: %v and %v", a, b)
+func CompareMaps(ctx context.Context, a, b map[string]string) bool {
+	Infof(ctx, "Comparing maps: %v and %v", a, b)
 	// Check if lengths are different
 	if len(a) != len(b) {
-		Errorf("Maps are different lengths: %v and %v", a, b)
+		Errorf(ctx, "Maps are different lengths: %v and %v", a, b)
 		return false
 	}
 
 	// Compare each key-value pair
 	for key, value := range a {
 		if b[key] != value {
-			Errorf("Maps differ at key %s: %s != %s", key, value, b[key])
+			Errorf(ctx, "Maps differ at key %s: %s != %s", key, value, b[key])
 			return false
 		}
 	}
@@ -830,21 +950,22 @@ func CompareMaps(a, b map[string]string) bool {
 	return true
 }
 
-func PrintLog(msg string) {
+func PrintLog(ctx context.Context, msg string) {
 	msglen := len(msg)
-	Infof("%s", strings.Repeat("*", msglen+4))
-	Infof("* %s *", msg)
-	Infof("%s", strings.Repeat("*", msglen+4))
+	Infof(ctx, "%s", strings.Repeat("*", msglen+4))
+	Infof(ctx, "* %s *", msg)
+	Infof(ctx, "%s", strings.Repeat("*", msglen+4))
 }
 
 func init() {
 	// Set default values
-	runStartTimes = append(runStartTimes, time.Now())
-	runTags = append(runTags, AlnumString(5))
+	var ctx context.Context
+	ctx, cancelRuns = context.WithCancel(context.Background())
+	mainRunState = newRunState(ctx)
 	logFile, testLog = nil, nil
 	printInfo, printWarn, printError, printResults = true, true, true, true
-	artifactDirectoryCreated, artifactsLogged, printVerbose = false, false, false
-	artifactDirectory, artifactFilePrefix, testService, logFileDir, TmpDir = "", "", "", "", ""
+	artifactDirectoryCreated, printVerbose = false, false
+	artifactDirectory, logFileDir, TmpDir = "", "", ""
 	// Call the init function for the printlog source file
 	printlogInit()
 }
@@ -0,0 +1,329 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * config.go
+ *
+ * cmsdev configuration settings
+ *
+ */
+
+package common
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultBaseHost = "api-gw-service-nmn.local"
+const defaultNamespace = "services"
+const defaultAPITimeoutSeconds = 120 // Timeout for API calls: 2 minutes
+const defaultAPIRetryCount = 3       // Number of times to retry API calls
+const defaultAPIRetryWaitSeconds = 5 // Number of seconds to wait between retries
+const defaultCLITimeoutSeconds = 120 // Timeout for CLI calls: 2 minutes
+
+// Log file formats
+const LogFormatText = "text"
+const LogFormatJSON = "json"
+
+// PodCount is the expected number of pods for a pod name prefix key. A Max of -1 means
+// there is no maximum.
+type PodCount struct {
+	Min int `json:"min" yaml:"min" mapstructure:"min"`
+	Max int `json:"max" yaml:"max" mapstructure:"max"`
+}
+
+// Default expected pod counts, by PodServiceNamePrefixes key
+var defaultPodCounts = map[string]PodCount{
+	"bos":              {Min: 3, Max: -1},
+	"cfs":              {Min: 2, Max: -1},
+	"console-data":     {Min: 4, Max: -1},
+	"console-node":     {Min: 2, Max: -1},
+	"console-operator": {Min: 1, Max: 1},
+	"ims":              {Min: 1, Max: 1},
+	"ipxe":             {Min: 1, Max: 1},
+	"tftp":             {Min: 1, Max: -1},
+	"vcs":              {Min: 2, Max: -1},
+}
+
+// RetryPolicy limits the retries of a class of calls. The wait before each retry doubles, from
+// WaitSeconds up to MaxWaitSeconds, and is randomized between half and all of that, so that
+// concurrent calls do not retry in step. A Retry-After header lengthens the wait, up to
+// MaxWaitSeconds.
+type RetryPolicy struct {
+	MaxRetries     int     `json:"max_retries" yaml:"max_retries" mapstructure:"max_retries"`
+	WaitSeconds    float64 `json:"wait_seconds" yaml:"wait_seconds" mapstructure:"wait_seconds"`
+	MaxWaitSeconds float64 `json:"max_wait_seconds" yaml:"max_wait_seconds" mapstructure:"max_wait_seconds"`
+}
+
+// Default retry policies, by class. The api and token policies are built from api_retry_count
+// and api_retry_wait_seconds, unless they are set in retries.
+const defaultRetryMaxWaitSeconds = 60
+
+var defaultRetryPolicies = map[string]RetryPolicy{
+	RetryClassAPI:     {MaxRetries: defaultAPIRetryCount, WaitSeconds: defaultAPIRetryWaitSeconds, MaxWaitSeconds: defaultRetryMaxWaitSeconds},
+	RetryClassToken:   {MaxRetries: defaultAPIRetryCount, WaitSeconds: defaultAPIRetryWaitSeconds, MaxWaitSeconds: defaultRetryMaxWaitSeconds},
+	RetryClassCLI:     {MaxRetries: 3, WaitSeconds: 5, MaxWaitSeconds: defaultRetryMaxWaitSeconds},
+	RetryClassKubectl: {MaxRetries: 2, WaitSeconds: 2, MaxWaitSeconds: 30},
+}
+
+// RetryClasses returns the names of the retry classes, sorted
+func RetryClasses() []string {
+	classes := make([]string, 0, len(defaultRetryPolicies))
+	for class := range defaultRetryPolicies {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+// Settings of the CFS sessions race condition test (cfs-sessions-rc)
+const defaultCFSSessionsRCNamePrefix = "cfs-race-condition-test"
+const defaultCFSSessionsRCMaxSessions = 20
+const defaultCFSSessionsRCMaxRequests = 4
+const defaultCFSSessionsRCCFSVersion = "v3"
+
+// CFSSessionsRCConfig holds the settings of the CFS sessions race condition test. A PageSize
+// of 0 means that the test chooses the page size for listing sessions itself.
+type CFSSessionsRCConfig struct {
+	NamePrefix              string `json:"name_prefix" yaml:"name_prefix" mapstructure:"name_prefix"`
+	MaxSessions             int    `json:"max_sessions" yaml:"max_sessions" mapstructure:"max_sessions"`
+	MaxMultiGetRequests     int    `json:"max_multi_get_requests" yaml:"max_multi_get_requests" mapstructure:"max_multi_get_requests"`
+	MaxMultiDeleteRequests  int    `json:"max_multi_delete_requests" yaml:"max_multi_delete_requests" mapstructure:"max_multi_delete_requests"`
+	MaxSingleGetRequests    int    `json:"max_single_get_requests" yaml:"max_single_get_requests" mapstructure:"max_single_get_requests"`
+	MaxSingleDeleteRequests int    `json:"max_single_delete_requests" yaml:"max_single_delete_requests" mapstructure:"max_single_delete_requests"`
+	DeletePreviousSessions  bool   `json:"delete_previous_sessions" yaml:"delete_previous_sessions" mapstructure:"delete_previous_sessions"`
+	CFSVersion              string `json:"cfs_version" yaml:"cfs_version" mapstructure:"cfs_version"`
+	PageSize                int    `json:"page_size" yaml:"page_size" mapstructure:"page_size"`
+}
+
+// BarebonesConfig holds the settings of the barebones image boot test (barebones). Empty
+// settings are chosen by the test: for example, the CSM version and images come from the Cray
+// Product Catalog, and the node from HSM. See the cmsdev README for which settings may be
+// combined.
+type BarebonesConfig struct {
+	CSMVersion       string `json:"csm_version" yaml:"csm_version" mapstructure:"csm_version"`
+	Arch             string `json:"arch" yaml:"arch" mapstructure:"arch"`
+	BaseImageID      string `json:"base_image_id" yaml:"base_image_id" mapstructure:"base_image_id"`
+	ImageID          string `json:"image_id" yaml:"image_id" mapstructure:"image_id"`
+	CFSConfiguration string `json:"cfs_configuration" yaml:"cfs_configuration" mapstructure:"cfs_configuration"`
+	VCSURL           string `json:"vcs_url" yaml:"vcs_url" mapstructure:"vcs_url"`
+	GitCommit        string `json:"git_commit" yaml:"git_commit" mapstructure:"git_commit"`
+	Playbook         string `json:"playbook" yaml:"playbook" mapstructure:"playbook"`
+	Xname            string `json:"xname" yaml:"xname" mapstructure:"xname"`
+	KeepOnSuccess    bool   `json:"keep_on_success" yaml:"keep_on_success" mapstructure:"keep_on_success"`
+}
+
+// Settings of the access token provider
+const defaultAuthClientID = "admin-client"
+const defaultAuthRealm = "shasta"
+const defaultAuthRefreshMarginSeconds = 60
+
+// AuthConfig holds the settings of the access token provider. The token is read from the first
+// of these which is set: the CMSDEV_ACCESS_TOKEN environment variable, TokenFile, and
+// CredentialsFile (a cray CLI credentials file, like the one named by CRAY_CREDENTIALS).
+// Otherwise it is requested from Keycloak with the client credentials of ClientID, whose
+// secret is read from ClientSecretFile, or from the admin-client-auth Kubernetes secret.
+type AuthConfig struct {
+	TokenFile            string `json:"token_file" yaml:"token_file" mapstructure:"token_file"`
+	CredentialsFile      string `json:"credentials_file" yaml:"credentials_file" mapstructure:"credentials_file"`
+	ClientID             string `json:"client_id" yaml:"client_id" mapstructure:"client_id"`
+	Realm                string `json:"realm" yaml:"realm" mapstructure:"realm"`
+	ClientSecretFile     string `json:"client_secret_file" yaml:"client_secret_file" mapstructure:"client_secret_file"`
+	RefreshMarginSeconds int    `json:"refresh_margin_seconds" yaml:"refresh_margin_seconds" mapstructure:"refresh_margin_seconds"`
+}
+
+// Config is the cmsdev configuration. It is read from the config file ($HOME/.cmsdev.yaml by
+// default) and from CMSDEV_* environment variables, which take precedence. See the cmsdev README
+// for the schema.
+type Config struct {
+	BaseHost            string                 `json:"base_host" yaml:"base_host" mapstructure:"base_host"`
+	BaseURL             string                 `json:"base_url" yaml:"base_url" mapstructure:"base_url"`
+	Namespace           string                 `json:"namespace" yaml:"namespace" mapstructure:"namespace"`
+	APITimeoutSeconds   int                    `json:"api_timeout_seconds" yaml:"api_timeout_seconds" mapstructure:"api_timeout_seconds"`
+	APIRetryCount       int                    `json:"api_retry_count" yaml:"api_retry_count" mapstructure:"api_retry_count"`
+	APIRetryWaitSeconds int                    `json:"api_retry_wait_seconds" yaml:"api_retry_wait_seconds" mapstructure:"api_retry_wait_seconds"`
+	CLITimeoutSeconds   int                    `json:"cli_timeout_seconds" yaml:"cli_timeout_seconds" mapstructure:"cli_timeout_seconds"`
+	LogDir              string                 `json:"log_dir" yaml:"log_dir" mapstructure:"log_dir"`
+	LogFormat           string                 `json:"log_format" yaml:"log_format" mapstructure:"log_format"`
+	LatencyBudgets      string                 `json:"latency_budgets" yaml:"latency_budgets" mapstructure:"latency_budgets"`
+	OpenAPIDir          string                 `json:"openapi_dir" yaml:"openapi_dir" mapstructure:"openapi_dir"`
+	Contract            bool                   `json:"contract" yaml:"contract" mapstructure:"contract"`
+	CABundle            string                 `json:"ca_bundle" yaml:"ca_bundle" mapstructure:"ca_bundle"`
+	Insecure            bool                   `json:"insecure" yaml:"insecure" mapstructure:"insecure"`
+	TestTimeouts        map[string]int64       `json:"test_timeouts" yaml:"test_timeouts" mapstructure:"test_timeouts"`
+	PodCounts           map[string]PodCount    `json:"pod_counts" yaml:"pod_counts" mapstructure:"pod_counts"`
+	Retries             map[string]RetryPolicy `json:"retries" yaml:"retries" mapstructure:"retries"`
+	CFSSessionsRC       CFSSessionsRCConfig    `json:"cfs_sessions_rc" yaml:"cfs_sessions_rc" mapstructure:"cfs_sessions_rc"`
+	Barebones           BarebonesConfig        `json:"barebones" yaml:"barebones" mapstructure:"barebones"`
+	Auth                AuthConfig             `json:"auth" yaml:"auth" mapstructure:"auth"`
+}
+
+var config = DefaultConfig()
+var configLock sync.RWMutex
+
+// Set when the k8s functions query a fake cluster rather than the cluster of the system
+var fakeCluster bool
+
+// DefaultConfig returns the built-in cmsdev configuration. Test timeouts are not included,
+// because the defaults for those come from the service tests themselves.
+func DefaultConfig() Config {
+	podCounts := make(map[string]PodCount, len(defaultPodCounts))
+	for key, count := range defaultPodCounts {
+		podCounts[key] = count
+	}
+	retries := make(map[string]RetryPolicy, len(defaultRetryPolicies))
+	for class, policy := range defaultRetryPolicies {
+		retries[class] = policy
+	}
+	return Config{
+		BaseHost:            defaultBaseHost,
+		Namespace:           defaultNamespace,
+		APITimeoutSeconds:   defaultAPITimeoutSeconds,
+		APIRetryCount:       defaultAPIRetryCount,
+		APIRetryWaitSeconds: defaultAPIRetryWaitSeconds,
+		CLITimeoutSeconds:   defaultCLITimeoutSeconds,
+		LogDir:              DEFAULT_LOG_FILE_DIR,
+		LogFormat:           LogFormatText,
+		TestTimeouts:        map[string]int64{},
+		PodCounts:           podCounts,
+		Retries:             retries,
+		CFSSessionsRC: CFSSessionsRCConfig{
+			NamePrefix:              defaultCFSSessionsRCNamePrefix,
+			MaxSessions:             defaultCFSSessionsRCMaxSessions,
+			MaxMultiGetRequests:     defaultCFSSessionsRCMaxRequests,
+			MaxMultiDeleteRequests:  defaultCFSSessionsRCMaxRequests,
+			MaxSingleGetRequests:    defaultCFSSessionsRCMaxRequests,
+			MaxSingleDeleteRequests: defaultCFSSessionsRCMaxRequests,
+			CFSVersion:              defaultCFSSessionsRCCFSVersion,
+		},
+		Auth: AuthConfig{
+			ClientID:             defaultAuthClientID,
+			Realm:                defaultAuthRealm,
+			RefreshMarginSeconds: defaultAuthRefreshMarginSeconds,
+		},
+	}
+}
+
+// SetConfig sets the cmsdev configuration. This must be called before any tests are run.
+func SetConfig(cfg Config) {
+	// The shared HTTP clients take the config lock while they are built, so they are reset
+	// after it is released
+	defer resetHTTPClients()
+	configLock.Lock()
+	defer configLock.Unlock()
+	config = cfg
+	BASEHOST = cfg.BaseHost
+	if len(cfg.BaseURL) > 0 {
+		BASEURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	} else {
+		BASEURL = "https://" + BASEHOST
+	}
+	NAMESPACE = cfg.Namespace
+	API_TIMEOUT_SECONDS = time.Duration(cfg.APITimeoutSeconds) * time.Second
+	CLI_TIMEOUT_SECONDS = time.Duration(cfg.CLITimeoutSeconds) * time.Second
+}
+
+// GetConfig returns the current cmsdev configuration
+func GetConfig() Config {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return config
+}
+
+// ClusterAvailable returns false if the services are reached through base_url (for example,
+// the cmsdev mock server) rather than the API gateway of a system, or if a recording is being
+// replayed (Kubernetes API queries are not recorded). In that case there is no Kubernetes
+// cluster for the tests to query, unless a fake cluster is being used.
+func ClusterAvailable() bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return (len(config.BaseURL) == 0 && !Replaying()) || fakeCluster
+}
+
+// NoClusterReason returns why there is no Kubernetes cluster, when ClusterAvailable is false
+func NoClusterReason() string {
+	if Replaying() {
+		return "a recording is being replayed"
+	}
+	return "base_url is set"
+}
+
+// UsingBaseURL returns true if the services are reached through base_url rather than the
+// API gateway of a system
+func UsingBaseURL() bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return len(config.BaseURL) > 0
+}
+
+// SetFakeCluster records whether the k8s functions query a fake cluster (see k8s.SetCluster)
+func SetFakeCluster(fake bool) {
+	configLock.Lock()
+	defer configLock.Unlock()
+	fakeCluster = fake
+}
+
+// UsingFakeCluster returns true if the k8s functions query a fake cluster
+func UsingFakeCluster() bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return fakeCluster
+}
+
+// ExpectedPodCount returns the minimum and maximum (-1 if none) expected number of pods
+// for the specified PodServiceNamePrefixes key
+func ExpectedPodCount(ctx context.Context, pkey string) (minCount, maxCount int) {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	if count, ok := config.PodCounts[pkey]; ok {
+		return count.Min, count.Max
+	}
+	// This only happens if there is no default for the key, which is a programming error
+	Warnf(ctx, "No expected pod count for '%s'; expecting at least 1", pkey)
+	return 1, -1
+}
+
+// TestTimeout returns the configured timeout (in seconds) for the specified service test,
+// or the specified default if none is configured
+func TestTimeout(service string, defaultTimeout int64) int64 {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	if timeout, ok := config.TestTimeouts[service]; ok {
+		return timeout
+	}
+	return defaultTimeout
+}
+
+// PodCountKeys returns the sorted keys of the default expected pod counts
+func PodCountKeys() []string {
+	keys := make([]string, 0, len(defaultPodCounts))
+	for key := range defaultPodCounts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,131 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * contract.go
+ *
+ * Checking of the status code of every API response against the status codes
+ * documented in the OpenAPI specs (contract mode)
+ *
+ */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/openapi"
+)
+
+// Name of the pseudo service test whose result records the contract mode checks
+const ContractTest = "contract"
+
+// An undocumented status code returned by an operation, and the first response with it
+type contractViolation struct {
+	operation *openapi.Operation
+	status    int
+	count     int
+	url       string
+	requestID string
+}
+
+// Undocumented status codes returned to the API calls made in this cmsdev run, by method,
+// endpoint template, and status code, and the number of responses checked and not checked
+var contractViolations = map[string]*contractViolation{}
+var contractChecked, contractUnchecked int
+var contractLock sync.Mutex
+
+// Record the status code of an API response, and whether the OpenAPI spec of the service
+// documents it. The endpoint catalog (see GetEndpoints) lists the same status codes, since it
+// is derived from the specs. Responses from services with no spec are counted but not checked.
+func recordResponseStatus(ctx context.Context, method, url string, status int, requestID string) {
+	operation := openapi.FindOperation(method, url)
+	contractLock.Lock()
+	defer contractLock.Unlock()
+	if operation == nil {
+		contractUnchecked++
+		return
+	}
+	contractChecked++
+	if operation.DocumentsStatus(status) {
+		return
+	}
+	key := fmt.Sprintf("%s %s %d", method, operation.Endpoint(), status)
+	if violation, ok := contractViolations[key]; ok {
+		violation.count++
+		return
+	}
+	Debugf(ctx, "%s %s: status code %d is not documented in the %s OpenAPI spec", method, url, status, operation.Service)
+	contractViolations[key] = &contractViolation{operation: operation, status: status, count: 1, url: url, requestID: requestID}
+}
+
+// CheckContract checks that every API response received so far had a status code which is
+// documented in the OpenAPI spec of its service, whether or not the test which made the call
+// checked its status. Each operation which returned an undocumented status code fails a
+// subtest of a pseudo service test named ContractTest.
+func CheckContract(ctx context.Context) *ServiceResult {
+	contractLock.Lock()
+	violations := make([]*contractViolation, 0, len(contractViolations))
+	for _, violation := range contractViolations {
+		violations = append(violations, violation)
+	}
+	checked, unchecked := contractChecked, contractUnchecked
+	contractLock.Unlock()
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.operation.Endpoint() != b.operation.Endpoint() {
+			return a.operation.Endpoint() < b.operation.Endpoint()
+		} else if a.operation.Method != b.operation.Method {
+			return a.operation.Method < b.operation.Method
+		}
+		return a.status < b.status
+	})
+
+	result := StartServiceResult(ctx, ContractTest)
+	SetTestAttempt(ctx, 1)
+	Infof(ctx, "Checked the status codes of %d API responses against the OpenAPI specs (%d responses from services with no spec were not checked)", checked, unchecked)
+	passed := true
+	for i := 0; i < len(violations); {
+		// One subtest for each operation, which reports all of its undocumented status codes
+		operation := violations[i].operation
+		key := operation.Method + " " + operation.Endpoint()
+		subtestPassed := false
+		subtest := StartSubtest(ctx, key)
+		for ; i < len(violations) && violations[i].operation == operation; i++ {
+			violation := violations[i]
+			Errorf(ctx, "%s: status code %d is not documented in the %s OpenAPI spec, which documents %s (responses: %d; first: %s, %s %s)",
+				key, violation.status, operation.Service, strings.Join(operation.StatusCodes, ", "),
+				violation.count, violation.url, requestIDHeader, violation.requestID)
+		}
+		subtest.End(ctx, &subtestPassed)
+		passed = false
+	}
+	if passed {
+		Infof(ctx, "All status codes checked are documented")
+	}
+	EndServiceResult(ctx, result, passed)
+	return result
+}
@@ -25,6 +25,7 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -79,16 +80,16 @@ func DecodeJSONIntoStringMapList(mapListJsonBytes []byte) ([]map[string]interfac
 	return m, nil
 }
 
-func GetStringFieldFromFirstItem(fieldName string, listJsonBytes []byte) (fieldValue string, err error) {
+func GetStringFieldFromFirstItem(ctx context.Context, fieldName string, listJsonBytes []byte) (fieldValue string, err error) {
 	fieldValue = ""
 
-	Debugf("Getting value of \"%s\" field from first element of list in JSON object", fieldName)
+	Debugf(ctx, "Getting value of \"%s\" field from first element of list in JSON object", fieldName)
 	listObject, err := DecodeJSONIntoList(listJsonBytes)
 	if err != nil {
 		return
 	} else if len(listObject) == 0 {
 		// List is empty
-		Debugf("List is empty")
+		Debugf(ctx, "List is empty")
 		return
 	}
 
@@ -116,34 +117,34 @@ func GetStringFieldFromFirstItem(fieldName string, listJsonBytes []byte) (fieldV
 		err = fmt.Errorf("First list item has empty value for \"%s\" field", fieldName)
 		return
 	}
-	Debugf("Value of \"%s\" field in first list item is \"%s\"", fieldName, fieldValue)
+	Debugf(ctx, "Value of \"%s\" field in first list item is \"%s\"", fieldName, fieldValue)
 	return
 }
 
-func GetStringFieldFromMap(fieldName string, mapJsonBytes []byte) (fieldValue string, err error) {
+func GetStringFieldFromMap(ctx context.Context, fieldName string, mapJsonBytes []byte) (fieldValue string, err error) {
 
-	Debugf("Getting value of \"%s\" field from JSON object", fieldName)
+	Debugf(ctx, "Getting value of \"%s\" field from JSON object", fieldName)
 	mapObject, err := DecodeJSONIntoStringMap(mapJsonBytes)
 	if err != nil {
 		return
 	}
 
-	fieldValue, err = GetStringFieldFromMapObject(fieldName, mapObject)
+	fieldValue, err = GetStringFieldFromMapObject(ctx, fieldName, mapObject)
 	if err != nil {
 		return
 	}
 
-	Debugf("Value of \"%s\" field in JSON map is \"%s\"", fieldName, fieldValue)
+	Debugf(ctx, "Value of \"%s\" field in JSON map is \"%s\"", fieldName, fieldValue)
 	return
 }
 
-func GetBoolFieldFromMapObjectWithDefault(fieldName string, mapObject map[string]interface{}, defaultValue bool) (fieldValue bool, err error) {
-	Debugf("Getting value of \"%s\" field from map object (value should be boolean)", fieldName)
+func GetBoolFieldFromMapObjectWithDefault(ctx context.Context, fieldName string, mapObject map[string]interface{}, defaultValue bool) (fieldValue bool, err error) {
+	Debugf(ctx, "Getting value of \"%s\" field from map object (value should be boolean)", fieldName)
 	fieldValue = defaultValue
 
 	fieldRawValue, ok := mapObject[fieldName]
 	if !ok {
-		Debugf("Map does not have \"%s\" field, returning default value \"%t\"", fieldName, defaultValue)
+		Debugf(ctx, "Map does not have \"%s\" field, returning default value \"%t\"", fieldName, defaultValue)
 		return
 	}
 
@@ -155,17 +156,17 @@ func GetBoolFieldFromMapObjectWithDefault(fieldName string, mapObject map[string
 		return
 	}
 
-	Debugf("Value of \"%s\" field in map object is \"%t\"", fieldName, fieldValue)
+	Debugf(ctx, "Value of \"%s\" field in map object is \"%t\"", fieldName, fieldValue)
 	return
 }
 
-func GetStringFieldFromMapObjectWithDefault(fieldName string, mapObject map[string]interface{}, defaultValue string) (fieldValue string, err error) {
-	Debugf("Getting value of \"%s\" field from map object (value should be a string)", fieldName)
+func GetStringFieldFromMapObjectWithDefault(ctx context.Context, fieldName string, mapObject map[string]interface{}, defaultValue string) (fieldValue string, err error) {
+	Debugf(ctx, "Getting value of \"%s\" field from map object (value should be a string)", fieldName)
 	fieldValue = defaultValue
 
 	fieldRawValue, ok := mapObject[fieldName]
 	if !ok {
-		Debugf("Map does not have \"%s\" field, returning default value \"%s\"", fieldName, defaultValue)
+		Debugf(ctx, "Map does not have \"%s\" field, returning default value \"%s\"", fieldName, defaultValue)
 		return
 	}
 
@@ -177,12 +178,12 @@ func GetStringFieldFromMapObjectWithDefault(fieldName string, mapObject map[stri
 		return
 	}
 
-	Debugf("Value of \"%s\" field in map object is \"%s\"", fieldName, fieldValue)
+	Debugf(ctx, "Value of \"%s\" field in map object is \"%s\"", fieldName, fieldValue)
 	return
 }
 
-func GetStringFieldFromMapObject(fieldName string, mapObject map[string]interface{}) (fieldValue string, err error) {
-	Debugf("Getting value of \"%s\" field from map object (value should be a string)", fieldName)
+func GetStringFieldFromMapObject(ctx context.Context, fieldName string, mapObject map[string]interface{}) (fieldValue string, err error) {
+	Debugf(ctx, "Getting value of \"%s\" field from map object (value should be a string)", fieldName)
 
 	fieldRawValue, ok := mapObject[fieldName]
 	if !ok {
@@ -198,23 +199,23 @@ func GetStringFieldFromMapObject(fieldName string, mapObject map[string]interfac
 		return
 	}
 
-	Debugf("Value of \"%s\" field in map object is \"%s\"", fieldName, fieldValue)
+	Debugf(ctx, "Value of \"%s\" field in map object is \"%s\"", fieldName, fieldValue)
 	return
 }
 
-func ValidateStringFieldValue(objectName, fieldName, expectedFieldValue string, mapJsonBytes []byte) (err error) {
-	actualValue, err := GetStringFieldFromMap(fieldName, mapJsonBytes)
+func ValidateStringFieldValue(ctx context.Context, objectName, fieldName, expectedFieldValue string, mapJsonBytes []byte) (err error) {
+	actualValue, err := GetStringFieldFromMap(ctx, fieldName, mapJsonBytes)
 	if err != nil {
 		return
 	} else if actualValue != expectedFieldValue {
-		err = fmt.Errorf("should have \"%s\" field value of \"%s\", but it is \"%s\"", objectName, fieldName, expectedFieldValue, actualValue)
+		err = fmt.Errorf("%s should have \"%s\" field value of \"%s\", but it is \"%s\"", objectName, fieldName, expectedFieldValue, actualValue)
 		return
 	}
-	Debugf("%s has expected value for \"%s\" field", objectName, fieldName)
+	Debugf(ctx, "%s has expected value for \"%s\" field", objectName, fieldName)
 	return
 }
 
-func RunningOnMaster() (isMaster bool, err error) {
+func RunningOnMaster(ctx context.Context) (isMaster bool, err error) {
 	// Checks the hostname of the node where cmsdev is running.
 	// Returns true if name begins with ncn-m###
 	// Returns false otherwise
@@ -224,7 +225,7 @@ func RunningOnMaster() (isMaster bool, err error) {
 	if err != nil {
 		return
 	}
-	Debugf("Hostname is '%s'", hostname)
+	Debugf(ctx, "Hostname is '%s'", hostname)
 	isMaster, _ = regexp.MatchString("^ncn-m[0-9]{3}.*$", hostname)
 	return
 }
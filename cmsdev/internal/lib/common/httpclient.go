@@ -0,0 +1,234 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * httpclient.go
+ *
+ * Shared HTTP client for API requests, which reuses connections for the whole run and
+ * verifies the TLS certificates of the API gateway against the configured CA bundle
+ *
+ */
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	resty "gopkg.in/resty.v1"
+)
+
+// Run value which is set when an API request had to be retried without verifying the TLS
+// certificate of the server
+const insecureFallbackKey = "insecureFallback"
+
+// Idle connections kept per host, enough for the concurrent requests of parallel tests
+const maxIdleConnsPerHost = 16
+
+// RequestOption sets an optional part of an API request made through Restful and its variants
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	query      url.Values
+	headers    map[string]string
+	username   string
+	password   string
+	body       interface{}
+	outputFile string
+	noRetry    bool
+}
+
+// WithQuery adds query parameters to the request
+func WithQuery(query url.Values) RequestOption {
+	return func(o *requestOptions) {
+		if o.query == nil {
+			o.query = url.Values{}
+		}
+		for name, values := range query {
+			o.query[name] = append(o.query[name], values...)
+		}
+	}
+}
+
+// WithHeader sets a header of the request, overriding the default headers
+func WithHeader(name, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[name] = value
+	}
+}
+
+// WithTenant makes the request on behalf of the specified tenant
+func WithTenant(tenant string) RequestOption {
+	return WithHeader(tenantHeader, tenant)
+}
+
+// WithBasicAuth authenticates the request with a user name and password, rather than a token
+func WithBasicAuth(username, password string) RequestOption {
+	return func(o *requestOptions) {
+		o.username, o.password = username, password
+	}
+}
+
+// WithBody sets the body of the request, whatever its method, in place of the body in Params
+func WithBody(body interface{}) RequestOption {
+	return func(o *requestOptions) {
+		o.body = body
+	}
+}
+
+// WithOutputFile streams the response body to the specified file, rather than reading it into
+// memory. The body of the returned response is empty.
+func WithOutputFile(path string) RequestOption {
+	return func(o *requestOptions) {
+		o.outputFile = path
+	}
+}
+
+// WithoutRetry makes the request only once, even if it fails. This is for tests of how a service
+// handles concurrent requests, where a retry would hide the response being tested.
+func WithoutRetry() RequestOption {
+	return func(o *requestOptions) {
+		o.noRetry = true
+	}
+}
+
+// The shared transports and clients. They are built when first used, and rebuilt when the
+// configuration changes or a recording is started or replayed.
+// Both are keyed by whether certificates are verified.
+var httpLock sync.Mutex
+var httpTransports = map[bool]*http.Transport{}
+var httpClients = map[bool]*resty.Client{}
+
+// Discard the shared transports and clients, so that they are rebuilt for the new settings
+func resetHTTPClients() {
+	httpLock.Lock()
+	defer httpLock.Unlock()
+	for _, transport := range httpTransports {
+		transport.CloseIdleConnections()
+	}
+	httpTransports = map[bool]*http.Transport{}
+	httpClients = map[bool]*resty.Client{}
+}
+
+// Build the TLS configuration. Unless certificates are not verified, the certificates in the
+// CA bundle (such as the platform CA) are trusted in addition to the system ones.
+func tlsConfig(insecure bool) (*tls.Config, error) {
+	cfg := GetConfig()
+	if insecure || cfg.Insecure {
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	} else if len(cfg.CABundle) == 0 {
+		return &tls.Config{}, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	pem, err := os.ReadFile(cfg.CABundle)
+	if err != nil {
+		return nil, fmt.Errorf("ca_bundle: %v", err)
+	} else if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("ca_bundle: no PEM certificates found in '%s'", cfg.CABundle)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+func httpTransport(insecure bool) (*http.Transport, error) {
+	if transport, ok := httpTransports[insecure]; ok {
+		return transport, nil
+	}
+	tlsCfg, err := tlsConfig(insecure)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	httpTransports[insecure] = transport
+	return transport, nil
+}
+
+// HTTPTransport returns the shared transport, for HTTP clients other than the API client (such
+// as the one which requests tokens from Keycloak)
+func HTTPTransport() (http.RoundTripper, error) {
+	httpLock.Lock()
+	defer httpLock.Unlock()
+	return httpTransport(false)
+}
+
+// Return the shared API client with the specified certificate verification setting. The client
+// does not retry requests itself; doRest retries them with the api retry policy (see retry.go).
+func apiClient(insecure bool) (*resty.Client, error) {
+	httpLock.Lock()
+	defer httpLock.Unlock()
+	if client, ok := httpClients[insecure]; ok {
+		return client, nil
+	}
+	transport, err := httpTransport(insecure)
+	if err != nil {
+		return nil, err
+	}
+	client := resty.New()
+	client.SetTransport(transport)
+	client.SetTimeout(API_TIMEOUT_SECONDS)
+	client.SetHeaders(map[string]string{
+		"Accept":       "application/json",
+		"User-Agent":   "cmsdev",
+		"Content-Type": "application/json",
+	})
+	UseRecordReplay(client)
+	httpClients[insecure] = client
+	return client, nil
+}
+
+// Returns true if the error is because the certificate of the server could not be verified
+func isCertificateError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &verificationErr) || errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
+
+// ResetInsecureFallback forgets any insecure retries made earlier in the current run. It is
+// called at the start of each service test attempt.
+func ResetInsecureFallback(ctx context.Context) {
+	SetRunValue(ctx, insecureFallbackKey, false)
+}
+
+// UsedInsecureFallback returns true if an API request in the current run had to be retried
+// without verifying the certificate of the server. Like the vcs test, the test fails even
+// though the request worked.
+func UsedInsecureFallback(ctx context.Context) bool {
+	used, ok := GetRunValue(ctx, insecureFallbackKey)
+	return ok && used.(bool)
+}
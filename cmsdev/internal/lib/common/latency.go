@@ -0,0 +1,254 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * latency.go
+ *
+ * Measurement of API call latency by method and endpoint, and checking of it
+ * against per-endpoint performance budgets
+ *
+ */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/openapi"
+)
+
+// Name of the pseudo service test whose result records the latency budget checks
+const LatencyBudgetTest = "latency"
+
+var versionSegmentRe = regexp.MustCompile(`^v[0-9]+$`)
+
+// Path segments which are not resource IDs (see openapi.FixedSegments)
+var templateNames map[string]bool
+var templateNamesOnce sync.Once
+
+// Latency samples of the API calls made in this cmsdev run, by method and endpoint template
+var latencySamples = map[string][]time.Duration{}
+var latencyLock sync.Mutex
+
+// LatencyStats summarizes the latency of the calls made to one endpoint
+type LatencyStats struct {
+	Method   string
+	Endpoint string
+	Count    int
+	P50      time.Duration
+	P95      time.Duration
+	Max      time.Duration
+}
+
+// Key returns the method and endpoint template, as used in the latency budgets file
+func (stats LatencyStats) Key() string {
+	return stats.Method + " " + stats.Endpoint
+}
+
+// Collect the fixed path segments of the known paths
+func loadTemplateNames() {
+	templateNames = openapi.FixedSegments()
+}
+
+// Return the method and endpoint template of every operation in the OpenAPI specs, which are
+// the only keys the latency budgets file may have
+func catalogLatencyKeys() map[string]bool {
+	keys := map[string]bool{}
+	for _, spec := range openapi.Specs() {
+		for _, operation := range spec.Operations {
+			keys[operation.Method+" "+EndpointTemplate(spec.BasePath+operation.Path)] = true
+		}
+	}
+	return keys
+}
+
+// EndpointTemplate returns the path of the specified URL, with every segment which is not
+// an API version or a fixed segment of a known path (see openapi.FixedSegments) replaced by {id}. For example,
+// https://api-gw-service-nmn.local/apis/cfs/v3/configurations/foo?limit=5 becomes
+// /apis/cfs/v3/configurations/{id}
+func EndpointTemplate(rawUrl string) string {
+	templateNamesOnce.Do(loadTemplateNames)
+	path := rawUrl
+	if parsed, err := url.Parse(rawUrl); err == nil {
+		path = parsed.Path
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if len(segment) > 0 && !templateNames[segment] && !versionSegmentRe.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Record the latency of an API call
+func recordLatency(method, rawUrl string, latency time.Duration) {
+	key := method + " " + EndpointTemplate(rawUrl)
+	latencyLock.Lock()
+	defer latencyLock.Unlock()
+	latencySamples[key] = append(latencySamples[key], latency)
+}
+
+// Return the sample at the specified percentile of the sorted samples (nearest rank)
+func percentile(sorted []time.Duration, pct int) time.Duration {
+	rank := (pct*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// LatencySummary returns the latency statistics of the API calls made so far, sorted by
+// endpoint and method
+func LatencySummary() []LatencyStats {
+	latencyLock.Lock()
+	defer latencyLock.Unlock()
+	summary := make([]LatencyStats, 0, len(latencySamples))
+	for key, samples := range latencySamples {
+		sorted := append([]time.Duration(nil), samples...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		method, endpoint, _ := strings.Cut(key, " ")
+		summary = append(summary, LatencyStats{
+			Method:   method,
+			Endpoint: endpoint,
+			Count:    len(sorted),
+			P50:      percentile(sorted, 50),
+			P95:      percentile(sorted, 95),
+			Max:      sorted[len(sorted)-1],
+		})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Endpoint != summary[j].Endpoint {
+			return summary[i].Endpoint < summary[j].Endpoint
+		}
+		return summary[i].Method < summary[j].Method
+	})
+	return summary
+}
+
+// PrintLatencySummary prints a table of the latency statistics of the API calls made so far
+func PrintLatencySummary(ctx context.Context) {
+	summary := LatencySummary()
+	if len(summary) == 0 {
+		return
+	}
+	width := len("ENDPOINT")
+	for _, stats := range summary {
+		if len(stats.Key()) > width {
+			width = len(stats.Key())
+		}
+	}
+	Infof(ctx, "API latency by endpoint:")
+	Infof(ctx, "%-*s %6s %10s %10s %10s", width, "ENDPOINT", "CALLS", "P50", "P95", "MAX")
+	for _, stats := range summary {
+		Infof(ctx, "%-*s %6d %10v %10v %10v", width, stats.Key(), stats.Count,
+			stats.P50.Round(time.Microsecond), stats.P95.Round(time.Microsecond), stats.Max.Round(time.Microsecond))
+	}
+}
+
+// LoadLatencyBudgets reads a YAML file which maps method and endpoint template (as shown in
+// the latency summary) to the maximum allowed p95 latency, for example:
+//
+//	GET /apis/cfs/v3/configurations: 2s
+//	GET /apis/ims/v3/images/{id}: 500ms
+//
+// It is an error if a key is not the method and endpoint template of an operation in the
+// OpenAPI specs, so this must be called after any specs are loaded (see LoadOpenAPIDir).
+func LoadLatencyBudgets(path string) (budgets map[string]time.Duration, err error) {
+	var raw map[string]string
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read latency budgets file '%s': %v", path, err)
+	} else if err = yaml.UnmarshalStrict(data, &raw); err != nil {
+		return nil, fmt.Errorf("Unable to decode latency budgets file '%s': %v", path, err)
+	}
+	budgets = make(map[string]time.Duration, len(raw))
+	catalogKeys := catalogLatencyKeys()
+	var unknown []string
+	for key, value := range raw {
+		method, endpoint, found := strings.Cut(strings.TrimSpace(key), " ")
+		endpoint = strings.TrimSpace(endpoint)
+		if !found || method != strings.ToUpper(method) || !strings.HasPrefix(endpoint, "/") {
+			return nil, fmt.Errorf("%s: expected '<METHOD> <endpoint>', found '%s'", path, key)
+		}
+		budget, err := time.ParseDuration(value)
+		if err != nil || budget <= 0 {
+			return nil, fmt.Errorf("%s: %s: invalid budget '%s'", path, key, value)
+		}
+		if !catalogKeys[method+" "+endpoint] {
+			unknown = append(unknown, key)
+		}
+		budgets[method+" "+endpoint] = budget
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%s: no endpoint in the OpenAPI specs matches '%s'", path, strings.Join(unknown, "', '"))
+	}
+	return budgets, nil
+}
+
+// CheckLatencyBudgets checks the p95 latency of each endpoint which has a budget, as a
+// subtest of a pseudo service test named LatencyBudgetTest. Endpoints which were not
+// called are not checked.
+func CheckLatencyBudgets(ctx context.Context, budgets map[string]time.Duration) *ServiceResult {
+	statsByKey := map[string]LatencyStats{}
+	for _, stats := range LatencySummary() {
+		statsByKey[stats.Key()] = stats
+	}
+	keys := make([]string, 0, len(budgets))
+	for key := range budgets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := StartServiceResult(ctx, LatencyBudgetTest)
+	SetTestAttempt(ctx, 1)
+	passed := true
+	for _, key := range keys {
+		stats, ok := statsByKey[key]
+		if !ok {
+			Infof(ctx, "No calls made to %s; latency budget not checked", key)
+			continue
+		}
+		subtestPassed := true
+		subtest := StartSubtest(ctx, key)
+		if stats.P95 > budgets[key] {
+			Errorf(ctx, "%s: p95 latency %v exceeds budget of %v (%d calls)", key, stats.P95.Round(time.Microsecond), budgets[key], stats.Count)
+			subtestPassed, passed = false, false
+		} else {
+			Infof(ctx, "%s: p95 latency %v is within budget of %v", key, stats.P95.Round(time.Microsecond), budgets[key])
+		}
+		subtest.End(ctx, &subtestPassed)
+	}
+	EndServiceResult(ctx, result, passed)
+	return result
+}
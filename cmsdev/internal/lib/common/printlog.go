@@ -1,7 +1,7 @@
 //
 //  MIT License
 //
-//  (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+//  (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 //  Permission is hereby granted, free of charge, to any person obtaining a
 //  copy of this software and associated documentation files (the "Software"),
@@ -32,6 +32,7 @@ package common
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -60,6 +61,9 @@ var logFile *logrus.Logger
 var testLog *logrus.Entry
 var printInfo, printWarn, printError, printResults, printVerbose bool
 
+// True if the log file has one JSON object per entry (log_format json)
+var jsonLog bool
+
 func printlogInit() {
 	_, fn, _, _ := runtime.Caller(0) // Find our own filename
 	// We want to specify the relative paths to our source files when logging
@@ -79,173 +83,199 @@ func printlogInit() {
 }
 
 // Get the relative path of the source file within the repo
-func srcString(callerFileName string, callerLineNum int) string {
-	fname := callerFileName
+func srcFile(callerFileName string) string {
 	if len(srcPrefixSubstring) > 0 {
 		substringIndex := strings.Index(callerFileName, srcPrefixSubstring)
 		if substringIndex == 0 {
-			fname = callerFileName[len(srcPrefixSubstring):]
+			return callerFileName[len(srcPrefixSubstring):]
 		}
 	}
-	return fmt.Sprintf("%s:%d", fname, callerLineNum)
+	return callerFileName
 }
 
-func logFields(callerFileName string, callerLineNum int) logrus.Fields {
-	return logrus.Fields{"src": srcString(callerFileName, callerLineNum), "service": testService}
+func srcString(callerFileName string, callerLineNum int) string {
+	return fmt.Sprintf("%s:%d", srcFile(callerFileName), callerLineNum)
 }
 
-// Wrappers to Debugf, Infof,  Warnf, and Errorf test log functions
-func TestLogDebugf(callerFileName string, callerLineNum int, format string, a ...interface{}) {
-	testLog.WithFields(logFields(callerFileName, callerLineNum)).Debugf(format, a...)
+func logFields(ctx context.Context, callerFileName string, callerLineNum int) logrus.Fields {
+	rs := runStateOf(ctx)
+	if !jsonLog {
+		return logrus.Fields{"src": srcString(callerFileName, callerLineNum), "service": rs.testService}
+	}
+	// JSON log entries have separate fields, so that they do not need to be parsed back out
+	fields := logrus.Fields{"file": srcFile(callerFileName), "line": callerLineNum, "service": rs.testService}
+	if len(rs.runTags) > 0 {
+		fields["run"] = rs.runTags[0]
+	}
+	if len(rs.runTags) > 1 {
+		fields["subtag"] = rs.runTags[len(rs.runTags)-1]
+	}
+	return fields
 }
 
-func TestLogInfof(callerFileName string, callerLineNum int, format string, a ...interface{}) {
-	testLog.WithFields(logFields(callerFileName, callerLineNum)).Infof(format, a...)
+// Wrappers to Debugf, Infof,  Warnf, and Errorf test log functions. Credentials are redacted
+// from the messages (see redact.go).
+func TestLogDebugf(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
+	if entry := runStateOf(ctx).log(); entry != nil {
+		entry.WithFields(logFields(ctx, callerFileName, callerLineNum)).Debug(Redact(fmt.Sprintf(format, a...)))
+	}
 }
 
-func TestLogWarnf(callerFileName string, callerLineNum int, format string, a ...interface{}) {
-	testLog.WithFields(logFields(callerFileName, callerLineNum)).Warnf(format, a...)
+func TestLogInfof(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
+	if entry := runStateOf(ctx).log(); entry != nil {
+		entry.WithFields(logFields(ctx, callerFileName, callerLineNum)).Info(Redact(fmt.Sprintf(format, a...)))
+	}
 }
 
-func TestLogErrorf(callerFileName string, callerLineNum int, format string, a ...interface{}) {
-	testLog.WithFields(logFields(callerFileName, callerLineNum)).Errorf(format, a...)
+func TestLogWarnf(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
+	if entry := runStateOf(ctx).log(); entry != nil {
+		entry.WithFields(logFields(ctx, callerFileName, callerLineNum)).Warn(Redact(fmt.Sprintf(format, a...)))
+	}
+}
+
+func TestLogErrorf(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
+	if entry := runStateOf(ctx).log(); entry != nil {
+		entry.WithFields(logFields(ctx, callerFileName, callerLineNum)).Error(Redact(fmt.Sprintf(format, a...)))
+	}
 }
 
 // Wrapper for default print function, in case we want to do anything in the future to
-// control whether or where things are printed
-func Printf(format string, a ...interface{}) {
-	fmt.Printf(format, a...)
+// control whether or where things are printed.
+// Output from buffered runs is held until the run ends. Credentials are redacted.
+func Printf(ctx context.Context, format string, a ...interface{}) {
+	fmt.Fprint(runStateOf(ctx).stdout(), Redact(fmt.Sprintf(format, a...)))
 }
 
 // print and/or log messages to the appropriate level
-func InfofWithCallerInfo(callerFileName string, callerLineNum int, format string, a ...interface{}) {
+func InfofWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
 	if printInfo {
-		fmt.Printf(format+"\n", a...)
+		Printf(ctx, format+"\n", a...)
 	}
 	if testLog != nil {
-		TestLogInfof(callerFileName, callerLineNum, format, a...)
+		TestLogInfof(ctx, callerFileName, callerLineNum, format, a...)
 	}
 }
 
-func Infof(format string, a ...interface{}) {
+func Infof(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	InfofWithCallerInfo(fn, line, format, a...)
+	InfofWithCallerInfo(ctx, fn, line, format, a...)
 }
 
-func DebugfWithCallerInfo(callerFileName string, callerLineNum int, format string, a ...interface{}) {
+func DebugfWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
 	if printVerbose {
-		fmt.Printf(format+"\n", a...)
+		Printf(ctx, format+"\n", a...)
 	}
 	if testLog != nil {
-		TestLogDebugf(callerFileName, callerLineNum, format, a...)
+		TestLogDebugf(ctx, callerFileName, callerLineNum, format, a...)
 	}
 }
 
-func Debugf(format string, a ...interface{}) {
+func Debugf(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	DebugfWithCallerInfo(fn, line, format, a...)
+	DebugfWithCallerInfo(ctx, fn, line, format, a...)
 }
 
-func InfoOverridefWithCallerInfo(callerFileName string, callerLineNum int, format string, a ...interface{}) {
-	fmt.Printf(format+"\n", a...)
+func InfoOverridefWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
+	Printf(ctx, format+"\n", a...)
 	if testLog != nil {
-		TestLogInfof(callerFileName, callerLineNum, format, a...)
+		TestLogInfof(ctx, callerFileName, callerLineNum, format, a...)
 	}
 }
 
-func InfoOverridef(format string, a ...interface{}) {
+func InfoOverridef(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	InfoOverridefWithCallerInfo(fn, line, format, a...)
+	InfoOverridefWithCallerInfo(ctx, fn, line, format, a...)
 }
 
-func WarnfWithCallerInfo(callerFileName string, callerLineNum int, format string, a ...interface{}) {
+func WarnfWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
 	if printWarn {
-		fmt.Printf("WARNING: "+format+"\n", a...)
+		Printf(ctx, "WARNING: "+format+"\n", a...)
 	}
 	if testLog != nil {
-		TestLogWarnf(callerFileName, callerLineNum, format, a...)
+		TestLogWarnf(ctx, callerFileName, callerLineNum, format, a...)
 	}
 }
 
-func Warnf(format string, a ...interface{}) {
+func Warnf(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	WarnfWithCallerInfo(fn, line, format, a...)
+	WarnfWithCallerInfo(ctx, fn, line, format, a...)
 }
 
-func ErrorfWithCallerInfo(callerFileName string, callerLineNum int, format string, a ...interface{}) {
+func ErrorfWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
+	runStateOf(ctx).recordError(format, a...)
 	if printError {
-		fmt.Printf("ERROR: "+format+"\n", a...)
+		Printf(ctx, "ERROR: "+format+"\n", a...)
 	}
 	if testLog != nil {
-		TestLogErrorf(callerFileName, callerLineNum, format, a...)
+		TestLogErrorf(ctx, callerFileName, callerLineNum, format, a...)
 	}
 }
 
-func Errorf(format string, a ...interface{}) {
+func Errorf(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ErrorfWithCallerInfo(fn, line, format, a...)
+	ErrorfWithCallerInfo(ctx, fn, line, format, a...)
 }
 
-func Error(err error) {
+func Error(ctx context.Context, err error) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ErrorfWithCallerInfo(fn, line, err.Error())
+	ErrorfWithCallerInfo(ctx, fn, line, "%s", err.Error())
 }
 
 // If format is not blank, call Infof with format + a
 // In verbose mode, also print green OK
-func VerboseOkayfWithCallerInfo(callerFileName string, callerLineNum int, format string, a ...interface{}) {
+func VerboseOkayfWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
 	if len(format) > 0 {
-		InfofWithCallerInfo(callerFileName, callerLineNum, format, a...)
+		InfofWithCallerInfo(ctx, callerFileName, callerLineNum, format, a...)
 	}
 	if printVerbose {
-		c.HiGreen("OK")
+		Printf(ctx, "%s\n", c.HiGreenString("OK"))
 	}
 }
 
-func VerboseOkayf(format string, a ...interface{}) {
+func VerboseOkayf(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	VerboseOkayfWithCallerInfo(fn, line, format, a...)
+	VerboseOkayfWithCallerInfo(ctx, fn, line, format, a...)
 }
 
-func VerboseOkay() {
+func VerboseOkay(ctx context.Context) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	VerboseOkayfWithCallerInfo(fn, line, "")
+	VerboseOkayfWithCallerInfo(ctx, fn, line, "")
 }
 
 // If format is not blank, call Errorf with format + a
 // In verbose mode, also print red Failed
-func VerboseFailedfWithCallerInfo(callerFileName string, callerLineNum int, format string, a ...interface{}) {
+func VerboseFailedfWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
 	if len(format) > 0 {
-		ErrorfWithCallerInfo(callerFileName, callerLineNum, format, a...)
+		ErrorfWithCallerInfo(ctx, callerFileName, callerLineNum, format, a...)
 	}
 	if printVerbose {
-		c.Red("Failed")
+		Printf(ctx, "%s\n", c.RedString("Failed"))
 	}
 }
 
-func VerboseFailedf(format string, a ...interface{}) {
+func VerboseFailedf(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	VerboseFailedfWithCallerInfo(fn, line, format, a...)
+	VerboseFailedfWithCallerInfo(ctx, fn, line, format, a...)
 }
 
-func VerboseFailed() {
+func VerboseFailed(ctx context.Context) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	VerboseFailedfWithCallerInfo(fn, line, "")
+	VerboseFailedfWithCallerInfo(ctx, fn, line, "")
 }
 
-func Verbosef(format string, a ...interface{}) {
+func Verbosef(ctx context.Context, format string, a ...interface{}) {
 	if printVerbose {
-		fmt.Printf(format+"\n", a...)
+		Printf(ctx, format+"\n", a...)
 	}
 }
 
 // Print a dividing line to stdout if in verbose mode
-func VerbosePrintDivider() {
-	Verbosef("---\n")
+func VerbosePrintDivider(ctx context.Context) {
+	Verbosef(ctx, "---\n")
 }
 
 // pretty print resty json responses
-func PrettyPrintJSON(resp *resty.Response) {
+func PrettyPrintJSON(ctx context.Context, resp *resty.Response) {
 	if !printVerbose {
 		return
 	}
@@ -253,36 +283,43 @@ func PrettyPrintJSON(resp *resty.Response) {
 
 	err := json.Indent(&prettyJSON, resp.Body(), "", "   ")
 	if err != nil {
-		fmt.Printf("%v\n", resp)
+		Printf(ctx, "%v\n", resp)
 	} else {
-		fmt.Printf("%s\n", strings.TrimSpace(string(prettyJSON.Bytes())))
+		Printf(ctx, "%s\n", strings.TrimSpace(string(prettyJSON.Bytes())))
 	}
 }
 
-func ResultsfWithCallerInfo(callerFileName string, callerLineNum int, format string, a ...interface{}) {
+func ResultsfWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum int, format string, a ...interface{}) {
 	if printResults {
-		fmt.Printf(format+"\n", a...)
+		Printf(ctx, format+"\n", a...)
 	}
 	if testLog != nil {
-		TestLogInfof(callerFileName, callerLineNum, format, a...)
+		TestLogInfof(ctx, callerFileName, callerLineNum, format, a...)
 	}
 }
 
-func Resultsf(format string, a ...interface{}) {
+func Resultsf(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ResultsfWithCallerInfo(fn, line, format, a...)
+	ResultsfWithCallerInfo(ctx, fn, line, format, a...)
 }
 
 // print/log result and exit with specified code
-func ExitfWithCallerInfo(callerFileName string, callerLineNum, rc int, format string, a ...interface{}) {
+func ExitfWithCallerInfo(ctx context.Context, callerFileName string, callerLineNum, rc int, format string, a ...interface{}) {
 	var res string
-	for len(runTags) > 1 {
-		UnsetRunSubTag()
+	// Delete anything left behind by tests before exiting
+	RunAllCleanups(ctx)
+	rs := runStateOf(ctx)
+	for len(rs.runTags) > 1 {
+		UnsetRunSubTag(ctx)
 	}
-	if len(runTags) == 1 {
-		fmt.Printf("Ended run (duration: %v)\n", time.Since(runStartTimes[len(runStartTimes)-1]))
-		runStartTimes = runStartTimes[:len(runStartTimes)-1]
-		runTags = runTags[:len(runTags)-1]
+	if len(rs.runTags) == 1 {
+		Printf(ctx, "Ended run (duration: %v)\n", time.Since(rs.runStartTimes[len(rs.runStartTimes)-1]))
+		rs.runStartTimes = rs.runStartTimes[:len(rs.runStartTimes)-1]
+		rs.runTags = rs.runTags[:len(rs.runTags)-1]
+	}
+	if rs.buffered {
+		// Exiting from within a buffered run, so write out what it has collected so far
+		rs.flush()
 	}
 
 	switch rc {
@@ -296,9 +333,9 @@ func ExitfWithCallerInfo(callerFileName string, callerLineNum, rc int, format st
 		res = "UNKNOWN ERROR"
 	}
 	if len(format) > 0 {
-		ResultsfWithCallerInfo(callerFileName, callerLineNum, res+": "+format, a...)
+		ResultsfWithCallerInfo(ctx, callerFileName, callerLineNum, res+": "+format, a...)
 	} else {
-		ResultsfWithCallerInfo(callerFileName, callerLineNum, res)
+		ResultsfWithCallerInfo(ctx, callerFileName, callerLineNum, "%s", res)
 	}
 	if logFile != nil {
 		logFile.Exit(rc)
@@ -307,39 +344,40 @@ func ExitfWithCallerInfo(callerFileName string, callerLineNum, rc int, format st
 	}
 }
 
-func Exitf(rc int, format string, a ...interface{}) {
+func Exitf(ctx context.Context, rc int, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ExitfWithCallerInfo(fn, line, rc, format, a...)
+	ExitfWithCallerInfo(ctx, fn, line, rc, format, a...)
 }
 
-func Usagef(format string, a ...interface{}) {
+func Usagef(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ExitfWithCallerInfo(fn, line, 2, format, a...)
+	ExitfWithCallerInfo(ctx, fn, line, 2, format, a...)
 }
 
-func Successf(format string, a ...interface{}) {
+func Successf(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ExitfWithCallerInfo(fn, line, 0, format, a...)
+	ExitfWithCallerInfo(ctx, fn, line, 0, format, a...)
 }
 
-func Success() {
+func Success(ctx context.Context) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ExitfWithCallerInfo(fn, line, 0, "")
+	ExitfWithCallerInfo(ctx, fn, line, 0, "")
 }
 
-func Failuref(format string, a ...interface{}) {
+func Failuref(ctx context.Context, format string, a ...interface{}) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ExitfWithCallerInfo(fn, line, 1, format, a...)
+	ExitfWithCallerInfo(ctx, fn, line, 1, format, a...)
 }
 
-func Failure() {
+func Failure(ctx context.Context) {
 	_, fn, line, _ := runtime.Caller(1) // the file and line number of the caller
-	ExitfWithCallerInfo(fn, line, 1, "")
+	ExitfWithCallerInfo(ctx, fn, line, 1, "")
 }
 
 // create log file and directory provided by path if one does not exist
-// if no path is provided, use DEFAULT_LOG_FILE_DIR
-func CreateLogFile(path, version string, logs, retry, quiet, verbose, includeCLI, noCleanup bool) {
+// if no path is provided, use the configured log directory (DEFAULT_LOG_FILE_DIR by default)
+// entries are written in the configured log format (log_format)
+func CreateLogFile(ctx context.Context, path, version string, logs, retry, quiet, verbose, includeCLI, noCleanup bool) {
 	var err error
 
 	if verbose {
@@ -350,7 +388,7 @@ func CreateLogFile(path, version string, logs, retry, quiet, verbose, includeCLI
 	if !logs {
 		return
 	} else if len(path) == 0 {
-		path = DEFAULT_LOG_FILE_DIR
+		path = GetConfig().LogDir
 	}
 
 	// Create base log directory
@@ -386,9 +424,16 @@ func CreateLogFile(path, version string, logs, retry, quiet, verbose, includeCLI
 	logFile.SetLevel(logrus.DebugLevel)
 
 	// We want nanosecond precision in log file entries
-	logFile.SetFormatter(&logrus.TextFormatter{
-		TimestampFormat: time.RFC3339Nano,
-	})
+	if GetConfig().LogFormat == LogFormatJSON {
+		jsonLog = true
+		logFile.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		})
+	} else {
+		logFile.SetFormatter(&logrus.TextFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		})
+	}
 	logFile.SetOutput(f)
 	args := make([]string, 0, 5)
 	if retry {
@@ -406,11 +451,11 @@ func CreateLogFile(path, version string, logs, retry, quiet, verbose, includeCLI
 	if noCleanup {
 		args = append(args, "no-cleanup")
 	}
-	testLog = logFile.WithFields(logrus.Fields{"version": version, "args": strings.Join(args, ",")})
+	testLog = logFile.WithFields(logrus.Fields{"version": version, "args": Redact(strings.Join(args, ","))})
 	logFileDir = runSpecificDir
-	Infof("cmsdev starting")
+	Infof(ctx, "cmsdev starting")
 	for _, pkg := range RPMLIST {
-		Debugf("%s version: %s", pkg, GetPackageVersion(pkg))
+		Debugf(ctx, "%s version: %s", pkg, GetPackageVersion(ctx, pkg))
 	}
 	fmt.Printf("Starting main run, version: %s, log directory: %s\n", version, runSpecificDir)
 }
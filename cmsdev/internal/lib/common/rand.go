@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -24,6 +24,7 @@ package common
 import (
 	"fmt"
 	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -40,12 +41,32 @@ const TextChars = PunctuationChars + WhitespaceChars + AlnumChars
 
 var myRand *rand.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
 
+// A rand.Rand is not safe for concurrent use, and service tests may run in parallel
+var myRandLock sync.Mutex
+
+// Reseed the random number generator. Recording and replaying use the same seed, so
+// that the names the tests generate are the same in both.
+func SetRandomSeed(seed int64) {
+	myRandLock.Lock()
+	defer myRandLock.Unlock()
+	myRand = rand.New(rand.NewSource(seed))
+}
+
 // These next few functions are just wrappers for the corresponding math/rand
 // functions, only using the instance of rand we created and seeded above
 func Intn(n int) int {
+	myRandLock.Lock()
+	defer myRandLock.Unlock()
 	return myRand.Intn(n)
 }
 
+// Shuffle puts n elements in a random order, using swap to swap two of them
+func Shuffle(n int, swap func(i, j int)) {
+	myRandLock.Lock()
+	defer myRandLock.Unlock()
+	myRand.Shuffle(n, swap)
+}
+
 // Returns an integer in [min, max]
 func IntInRange(min, max int) int {
 	if min > max || min < 0 {
@@ -55,10 +76,14 @@ func IntInRange(min, max int) int {
 }
 
 func Float32() float32 {
+	myRandLock.Lock()
+	defer myRandLock.Unlock()
 	return myRand.Float32()
 }
 
 func Float64() float64 {
+	myRandLock.Lock()
+	defer myRandLock.Unlock()
 	return myRand.Float64()
 }
 
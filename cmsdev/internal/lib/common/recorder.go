@@ -0,0 +1,422 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * recorder.go
+ *
+ * Recording of the API requests and CLI commands made by a test run, and replaying
+ * them, so that the test logic can be run and debugged away from the system. Queries made
+ * through the Kubernetes API are not recorded; when replaying, there is no cluster unless a
+ * fake one is used (see ClusterAvailable and k8s.SetCluster). Credentials are redacted from
+ * the recordings, which are meant to be shared.
+ *
+ */
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	resty "gopkg.in/resty.v1"
+)
+
+// Name of the file in a recording directory which describes the recording
+const recordingManifestFile = "recording.json"
+
+// Placeholder for the temporary directory in recorded command arguments, since the
+// temporary directory is different for every run
+const tmpDirPlaceholder = "${CMSDEV_TMPDIR}"
+
+// Header used to make requests on behalf of a tenant
+const tenantHeader = "Cray-Tenant-Name"
+
+type recordingManifest struct {
+	CmsdevVersion string    `json:"cmsdev_version"`
+	Created       time.Time `json:"created"`
+	Seed          int64     `json:"seed"`
+}
+
+// A recorded HTTP request and its response. If the request failed without a response,
+// Error is set instead of the response fields. Credentials are redacted from every field,
+// and the Authorization header is not recorded.
+type httpExchange struct {
+	Method       string              `json:"method"`
+	Url          string              `json:"url"`
+	Tenant       string              `json:"tenant,omitempty"`
+	RequestBody  string              `json:"request_body,omitempty"`
+	Status       int                 `json:"status,omitempty"`
+	Headers      map[string][]string `json:"headers,omitempty"`
+	ResponseBody string              `json:"response_body,omitempty"`
+	Error        string              `json:"error,omitempty"`
+}
+
+// A recorded command and its result. The values of the environment variables are not
+// recorded, since they may include credentials, and credentials are redacted from the
+// other fields.
+type commandExchange struct {
+	Command  string   `json:"command"`
+	Args     []string `json:"args"`
+	EnvNames []string `json:"env_names,omitempty"`
+	Rc       int      `json:"rc"`
+	Stdout   string   `json:"stdout,omitempty"`
+	Stderr   string   `json:"stderr,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// One file in a recording directory
+type recordedExchange struct {
+	Seq     int              `json:"seq"`
+	Http    *httpExchange    `json:"http,omitempty"`
+	Command *commandExchange `json:"command,omitempty"`
+}
+
+// The recording directory, if recording or replaying
+var recordDir, replayDir string
+
+var recordLock sync.Mutex
+var recordSeq int
+
+// Recorded exchanges being replayed, by key. Each key has the exchanges in the order
+// they were recorded, and the index of the next one to replay.
+type replayQueue struct {
+	exchanges []*recordedExchange
+	next      int
+}
+
+var replayQueues map[string]*replayQueue
+
+func Recording() bool { return len(recordDir) > 0 }
+func Replaying() bool { return len(replayDir) > 0 }
+
+// StartRecording starts saving every API request made through Restful or RestfulTenant,
+// and every command run through RunName (or its variants), to the specified directory
+func StartRecording(ctx context.Context, dir, version string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("Unable to create recording directory '%s': %v", dir, err)
+	} else if entries, err := os.ReadDir(dir); err != nil {
+		return fmt.Errorf("Unable to read recording directory '%s': %v", dir, err)
+	} else if len(entries) > 0 {
+		return fmt.Errorf("Recording directory '%s' is not empty", dir)
+	}
+	manifest := recordingManifest{CmsdevVersion: version, Created: time.Now().UTC(), Seed: time.Now().UnixNano()}
+	if err := writeRecordingFile(filepath.Join(dir, recordingManifestFile), manifest); err != nil {
+		return err
+	}
+	SetRandomSeed(manifest.Seed)
+	recordDir = dir
+	resetHTTPClients()
+	Infof(ctx, "Recording API requests and CLI commands to directory '%s'", dir)
+	return nil
+}
+
+// StartReplay loads a recording made by StartRecording. After this, API requests and
+// commands are not actually made, but get the recorded responses and results.
+func StartReplay(ctx context.Context, dir string) error {
+	var manifest recordingManifest
+	if err := readRecordingFile(filepath.Join(dir, recordingManifestFile), &manifest); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("Unable to read recording directory '%s': %v", dir, err)
+	}
+	var exchanges []*recordedExchange
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == recordingManifestFile || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		exchange := new(recordedExchange)
+		if err := readRecordingFile(filepath.Join(dir, entry.Name()), exchange); err != nil {
+			return err
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	sort.Slice(exchanges, func(i, j int) bool { return exchanges[i].Seq < exchanges[j].Seq })
+
+	replayQueues = make(map[string]*replayQueue)
+	for _, exchange := range exchanges {
+		for _, key := range exchange.keys() {
+			if replayQueues[key] == nil {
+				replayQueues[key] = new(replayQueue)
+			}
+			replayQueues[key].exchanges = append(replayQueues[key].exchanges, exchange)
+		}
+	}
+	SetRandomSeed(manifest.Seed)
+	replayDir = dir
+	resetHTTPClients()
+	Infof(ctx, "Replaying %d API requests and CLI commands recorded by cmsdev %s at %s, from directory '%s'",
+		len(exchanges), manifest.CmsdevVersion, manifest.Created.Format(time.RFC3339), dir)
+	return nil
+}
+
+func writeRecordingFile(path string, data interface{}) error {
+	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Unable to encode recording file '%s': %v", path, err)
+	} else if err = os.WriteFile(path, jsonBytes, 0600); err != nil {
+		return fmt.Errorf("Unable to write recording file '%s': %v", path, err)
+	}
+	return nil
+}
+
+func readRecordingFile(path string, data interface{}) error {
+	jsonBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Unable to read recording file '%s': %v", path, err)
+	} else if err = json.Unmarshal(jsonBytes, data); err != nil {
+		return fmt.Errorf("Unable to decode recording file '%s': %v", path, err)
+	}
+	return nil
+}
+
+// Save an exchange in the recording directory
+func record(ctx context.Context, exchange *recordedExchange, kind string) {
+	recordLock.Lock()
+	recordSeq++
+	exchange.Seq = recordSeq
+	recordLock.Unlock()
+	exchange.redact()
+	path := filepath.Join(recordDir, fmt.Sprintf("%06d-%s.json", exchange.Seq, kind))
+	if err := writeRecordingFile(path, exchange); err != nil {
+		Warnf(ctx, "%v", err)
+	}
+}
+
+// Redact the credentials from every string field of the exchange (see Redact), and drop the
+// headers which carry them
+func (exchange *recordedExchange) redact() {
+	if h := exchange.Http; h != nil {
+		h.Url, h.Tenant, h.RequestBody = Redact(h.Url), Redact(h.Tenant), Redact(h.RequestBody)
+		h.ResponseBody, h.Error = Redact(h.ResponseBody), Redact(h.Error)
+		headers := make(map[string][]string, len(h.Headers))
+		for name, values := range h.Headers {
+			if http.CanonicalHeaderKey(name) == "Authorization" {
+				continue
+			}
+			redacted := make([]string, len(values))
+			for i, value := range values {
+				redacted[i] = Redact(value)
+			}
+			headers[name] = redacted
+		}
+		h.Headers = headers
+	}
+	if c := exchange.Command; c != nil {
+		c.Command = Redact(c.Command)
+		for i := range c.Args {
+			c.Args[i] = Redact(c.Args[i])
+		}
+		for i := range c.EnvNames {
+			c.EnvNames[i] = Redact(c.EnvNames[i])
+		}
+		c.Stdout, c.Stderr, c.Error = Redact(c.Stdout), Redact(c.Stderr), Redact(c.Error)
+	}
+}
+
+// The keys under which an exchange is replayed. An HTTP request is matched on its
+// method, URL, tenant and body, falling back to just the method, URL and tenant. A
+// command is matched on its name and arguments. These are redacted the same way for the
+// requests and commands being replayed as they were for the recording.
+func (exchange *recordedExchange) keys() []string {
+	if exchange.Http != nil {
+		base := fmt.Sprintf("http %s %s tenant=%s", exchange.Http.Method, exchange.Http.Url, exchange.Http.Tenant)
+		return []string{base + " body=" + exchange.Http.RequestBody, base}
+	}
+	return []string{fmt.Sprintf("command %s %q", exchange.Command.Command, exchange.Command.Args)}
+}
+
+// Return the next recorded exchange for the first of the keys which has any. Once all of
+// the exchanges for a key have been replayed, the last one is repeated, since polling
+// loops may make more requests than they did when the recording was made.
+func nextReplay(keys ...string) *recordedExchange {
+	recordLock.Lock()
+	defer recordLock.Unlock()
+	for _, key := range keys {
+		if queue, ok := replayQueues[key]; ok {
+			exchange := queue.exchanges[queue.next]
+			if queue.next < len(queue.exchanges)-1 {
+				queue.next++
+			}
+			return exchange
+		}
+	}
+	return nil
+}
+
+// UseRecordReplay sets up the client to record or replay its requests, if a recording
+// is being made or replayed. The shared API client is set up when it is built.
+func UseRecordReplay(client *resty.Client) {
+	if Replaying() {
+		client.SetTransport(replayTransport{})
+	} else if Recording() {
+		base := client.GetClient().Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		client.SetTransport(recordTransport{base: base})
+	}
+}
+
+// Read and restore the body of a request
+func requestBody(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return string(body), nil
+}
+
+type recordTransport struct {
+	base http.RoundTripper
+}
+
+func (t recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	exchange := &httpExchange{Method: req.Method, Url: req.URL.String(), Tenant: req.Header.Get(tenantHeader), RequestBody: body}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		exchange.Error = err.Error()
+		record(ctx, &recordedExchange{Http: exchange}, "http")
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	exchange.Status, exchange.Headers, exchange.ResponseBody = resp.StatusCode, resp.Header, string(respBody)
+	record(ctx, &recordedExchange{Http: exchange}, "http")
+	return resp, nil
+}
+
+type replayTransport struct{}
+
+func (t replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := &recordedExchange{Http: &httpExchange{Method: req.Method, Url: req.URL.String(), Tenant: req.Header.Get(tenantHeader), RequestBody: body}}
+	key.redact()
+	exchange := nextReplay(key.keys()...)
+	if exchange == nil {
+		return nil, fmt.Errorf("No recorded response for %s %s", req.Method, req.URL)
+	} else if len(exchange.Http.Error) > 0 {
+		return nil, fmt.Errorf("%s", exchange.Http.Error)
+	}
+	Debugf(ctx, "Replaying recorded response #%d for %s %s", exchange.Seq, req.Method, req.URL)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.Http.Status, http.StatusText(exchange.Http.Status)),
+		StatusCode:    exchange.Http.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header(exchange.Http.Headers).Clone(),
+		Body:          io.NopCloser(strings.NewReader(exchange.Http.ResponseBody)),
+		ContentLength: int64(len(exchange.Http.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// Replace the temporary directory in command arguments with a placeholder
+func normalizeCommandArgs(cmdArgs []string) []string {
+	normalized := make([]string, len(cmdArgs))
+	for i, arg := range cmdArgs {
+		if len(TmpDir) > 0 {
+			arg = strings.ReplaceAll(arg, TmpDir, tmpDirPlaceholder)
+		}
+		normalized[i] = arg
+	}
+	return normalized
+}
+
+func newCommandExchange(cmdEnv map[string]string, cmdName string, cmdArgs []string) *commandExchange {
+	exchange := &commandExchange{Command: cmdName, Args: normalizeCommandArgs(cmdArgs)}
+	for envName := range cmdEnv {
+		exchange.EnvNames = append(exchange.EnvNames, envName)
+	}
+	sort.Strings(exchange.EnvNames)
+	return exchange
+}
+
+// Record the result of a command
+func recordCommand(ctx context.Context, cmdEnv map[string]string, cmdName string, cmdArgs []string, cmdResult *CommandResult, err error) {
+	exchange := newCommandExchange(cmdEnv, cmdName, cmdArgs)
+	exchange.Rc, exchange.Stdout, exchange.Stderr = cmdResult.Rc, cmdResult.OutString(), cmdResult.ErrString()
+	if err != nil {
+		exchange.Error = err.Error()
+	}
+	record(ctx, &recordedExchange{Command: exchange}, "command")
+}
+
+// Return the recorded result of a command
+func replayCommand(ctx context.Context, cmdEnv map[string]string, cmdName string, cmdArgs []string) (cmdResult *CommandResult, err error) {
+	cmdResult = new(CommandResult)
+	if err = cmdResult.Init(ctx, cmdEnv, cmdName, cmdArgs...); err != nil {
+		return
+	}
+	cmdResult.CmdString = fmt.Sprintf("%s %s", cmdName, strings.Join(cmdArgs, " "))
+	key := &recordedExchange{Command: newCommandExchange(cmdEnv, cmdName, cmdArgs)}
+	key.redact()
+	exchange := nextReplay(key.keys()...)
+	if exchange == nil {
+		cmdResult.Rc = CmdRcCannotGet
+		err = fmt.Errorf("No recorded result for command: %s", cmdResult.CmdString)
+		return
+	}
+	Debugf(ctx, "Replaying recorded result #%d for command: %s", exchange.Seq, cmdResult.CmdString)
+	cmdResult.Ran = true
+	cmdResult.Rc = exchange.Command.Rc
+	cmdResult.OutBytes, cmdResult.ErrBytes = []byte(exchange.Command.Stdout), []byte(exchange.Command.Stderr)
+	if len(exchange.Command.Error) > 0 {
+		err = fmt.Errorf("%s", exchange.Command.Error)
+	}
+	if cmdResult.Rc > 0 {
+		cmdResult.CmdErr = fmt.Errorf("exit status %d", cmdResult.Rc)
+	} else if cmdResult.Rc == CmdRcCannotGet {
+		cmdResult.CmdErr = err
+	}
+	return
+}
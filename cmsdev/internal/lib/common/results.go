@@ -0,0 +1,180 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * results.go
+ *
+ * Recording of service test and subtest results, for use in test reports
+ *
+ */
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Result of a single subtest attempt
+type SubtestResult struct {
+	Name     string        `json:"name"`
+	Attempt  int           `json:"attempt"`
+	Passed   bool          `json:"passed"`
+	Skipped  bool          `json:"skipped"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"-"`
+	Errors   []string      `json:"errors,omitempty"`
+}
+
+// Result of a service test, including all of its subtests across all attempts
+type ServiceResult struct {
+	Name     string           `json:"name"`
+	Attempts int              `json:"attempts"`
+	Passed   bool             `json:"passed"`
+	Start    time.Time        `json:"start"`
+	Duration time.Duration    `json:"-"`
+	Errors   []string         `json:"errors,omitempty"`
+	Subtests []*SubtestResult `json:"subtests,omitempty"`
+
+	lock sync.Mutex
+}
+
+// A subtest in progress
+type Subtest struct {
+	result  *SubtestResult
+	service *ServiceResult
+}
+
+// StartServiceResult begins recording results for the specified service test in the
+// current run. Subtests started and errors logged in this run will be added to it until
+// EndServiceResult is called.
+func StartServiceResult(ctx context.Context, service string) *ServiceResult {
+	result := &ServiceResult{Name: service, Start: time.Now()}
+	rs := runStateOf(ctx)
+	rs.serviceResult = result
+	rs.subtests = nil
+	return result
+}
+
+func EndServiceResult(ctx context.Context, result *ServiceResult, passed bool) {
+	result.lock.Lock()
+	result.Passed = passed
+	result.Duration = time.Since(result.Start)
+	result.lock.Unlock()
+	rs := runStateOf(ctx)
+	if rs.serviceResult == result {
+		rs.serviceResult = nil
+		rs.subtests = nil
+	}
+}
+
+// Set the attempt number for subtests started after this point in the current run
+func SetTestAttempt(ctx context.Context, attempt int) {
+	rs := runStateOf(ctx)
+	rs.attempt = attempt
+	if rs.serviceResult != nil {
+		rs.serviceResult.lock.Lock()
+		rs.serviceResult.Attempts = attempt
+		rs.serviceResult.lock.Unlock()
+	}
+}
+
+// StartSubtest records the start of the named subtest. It is intended to be used at the
+// top of a test function with a named boolean return value:
+//
+//	defer common.StartSubtest("TestImageUndelete").End(&passed)
+//
+// Subtests started while another is in progress are recorded with the name of the outer
+// subtest as a prefix (e.g. TestImageCRUDOperation/TestImageCreate)
+func StartSubtest(ctx context.Context, name string) *Subtest {
+	rs := runStateOf(ctx)
+	if len(rs.subtests) > 0 {
+		name = rs.subtests[len(rs.subtests)-1].result.Name + "/" + name
+	}
+	attempt := rs.attempt
+	if attempt == 0 {
+		attempt = 1
+	}
+	subtest := &Subtest{
+		result:  &SubtestResult{Name: name, Attempt: attempt, Start: time.Now()},
+		service: rs.serviceResult,
+	}
+	rs.subtests = append(rs.subtests, subtest)
+	if subtest.service != nil {
+		subtest.service.lock.Lock()
+		subtest.service.Subtests = append(subtest.service.Subtests, subtest.result)
+		subtest.service.lock.Unlock()
+	}
+	Debugf(ctx, "Starting subtest %s", name)
+	return subtest
+}
+
+// SkipSubtest records that the named subtest was not run
+func SkipSubtest(ctx context.Context, name string) {
+	skipped := true
+	subtest := StartSubtest(ctx, name)
+	subtest.result.Skipped = true
+	subtest.End(ctx, &skipped)
+}
+
+// End records the result of the subtest
+func (subtest *Subtest) End(ctx context.Context, passed *bool) {
+	if subtest.service != nil {
+		subtest.service.lock.Lock()
+	}
+	subtest.result.Passed = *passed
+	subtest.result.Duration = time.Since(subtest.result.Start)
+	if subtest.service != nil {
+		subtest.service.lock.Unlock()
+	}
+
+	rs := runStateOf(ctx)
+	for i := len(rs.subtests) - 1; i >= 0; i-- {
+		if rs.subtests[i] == subtest {
+			rs.subtests = rs.subtests[:i]
+			break
+		}
+	}
+	if *passed {
+		Debugf(ctx, "Subtest %s passed (duration: %v)", subtest.result.Name, subtest.result.Duration)
+	} else {
+		Debugf(ctx, "Subtest %s FAILED (duration: %v)", subtest.result.Name, subtest.result.Duration)
+	}
+}
+
+// Record an error message against the open subtests (if any) and service test (if any)
+func (rs *runState) recordError(format string, a ...interface{}) {
+	if rs.serviceResult == nil || rs.cleaningUp {
+		return
+	}
+	msg := Redact(strings.TrimSpace(fmt.Sprintf(format, a...)))
+	rs.serviceResult.lock.Lock()
+	for _, subtest := range rs.subtests {
+		subtest.result.Errors = append(subtest.result.Errors, msg)
+	}
+	rs.serviceResult.Errors = append(rs.serviceResult.Errors, msg)
+	rs.serviceResult.lock.Unlock()
+}
@@ -0,0 +1,352 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * retry.go
+ *
+ * Retry policy for API requests, cray CLI commands and kubectl commands: which failures are
+ * transient, how long to wait before retrying them, and a record of the retries made
+ *
+ */
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	resty "gopkg.in/resty.v1"
+)
+
+// Classes of calls, each of which has its own retry policy
+const (
+	RetryClassAPI     = "api"
+	RetryClassToken   = "token"
+	RetryClassCLI     = "cli"
+	RetryClassKubectl = "kubectl"
+)
+
+// RetryPolicyFor returns the retry policy of the specified class
+func RetryPolicyFor(class string) RetryPolicy {
+	if policy, ok := GetConfig().Retries[class]; ok {
+		return policy
+	}
+	return defaultRetryPolicies[class]
+}
+
+// Returns how long to wait before the specified retry (numbered from 0)
+func (policy RetryPolicy) wait(retry int, retryAfter time.Duration) time.Duration {
+	maxWait := time.Duration(policy.MaxWaitSeconds * float64(time.Second))
+	wait := time.Duration(policy.WaitSeconds * math.Exp2(float64(retry)) * float64(time.Second))
+	if wait > maxWait {
+		wait = maxWait
+	}
+	// This does not use the cmsdev random number generator, so that it does not change the
+	// names that the tests generate
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(half+1))
+	}
+	if retryAfter > wait {
+		wait = retryAfter
+	}
+	if wait > maxWait {
+		wait = maxWait
+	}
+	return wait
+}
+
+// Why a call should be retried (empty if it should not be), and how long the server asked us
+// to wait (if it did)
+type retryReason struct {
+	reason     string
+	retryAfter time.Duration
+}
+
+// Retries made in this cmsdev run, by class and reason
+type retryCount struct {
+	retries   int
+	exhausted int
+}
+
+var retryCounts = map[[2]string]*retryCount{}
+var retryLock sync.Mutex
+
+// RetryStats summarizes the retries of one class of calls for one reason
+type RetryStats struct {
+	Class   string
+	Reason  string
+	Retries int
+	// Number of calls which still failed for this reason when their retries were used up
+	Exhausted int
+}
+
+// Record a retry (or a call which failed after its last retry) and log it, with the details in
+// separate fields of the log entry
+func logRetry(ctx context.Context, class, target, reason string, retry, maxRetries int, wait time.Duration) {
+	retryLock.Lock()
+	key := [2]string{class, reason}
+	if retryCounts[key] == nil {
+		retryCounts[key] = new(retryCount)
+	}
+	if retry > maxRetries {
+		retryCounts[key].exhausted++
+	} else {
+		retryCounts[key].retries++
+	}
+	retryLock.Unlock()
+
+	var msg string
+	if retry > maxRetries {
+		msg = fmt.Sprintf("%s: %s; giving up after %d retries", target, reason, maxRetries)
+	} else {
+		msg = fmt.Sprintf("%s: %s; retry %d/%d in %v", target, reason, retry, maxRetries, wait.Round(time.Millisecond))
+	}
+	if printInfo {
+		Printf(ctx, "%s\n", msg)
+	}
+	if entry := runStateOf(ctx).log(); entry != nil {
+		_, fn, line, _ := runtime.Caller(2)
+		entry.WithFields(logFields(ctx, fn, line)).WithFields(logrus.Fields{
+			"retry_class":        class,
+			"retry_target":       Redact(target),
+			"retry_reason":       reason,
+			"retry_attempt":      retry,
+			"retry_max":          maxRetries,
+			"retry_wait_seconds": wait.Seconds(),
+		}).Warn(Redact(msg))
+	}
+}
+
+// Make a call, and retry it for as long as check finds a reason to, the retries of the class
+// are not used up, and the runs have not been cancelled. No time is spent waiting when
+// replaying a recording.
+func withRetries(ctx context.Context, class, target string, noRetry bool, call func(), check func() retryReason) {
+	policy := RetryPolicyFor(class)
+	if noRetry {
+		policy.MaxRetries = 0
+	}
+	for retry := 1; ; retry++ {
+		call()
+		reason := check()
+		if len(reason.reason) == 0 || CallContext(ctx).Err() != nil {
+			return
+		} else if retry > policy.MaxRetries {
+			if policy.MaxRetries > 0 {
+				logRetry(ctx, class, target, reason.reason, retry, policy.MaxRetries, 0)
+			}
+			return
+		}
+		wait := policy.wait(retry-1, reason.retryAfter)
+		if Replaying() {
+			wait = 0
+		}
+		logRetry(ctx, class, target, reason.reason, retry, policy.MaxRetries, wait)
+		if !Sleep(ctx, wait) {
+			return
+		}
+	}
+}
+
+// Parse a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0
+	} else if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// Methods which can be repeated without changing the result if a request was processed but its
+// response was lost
+var idempotentMethods = map[string]bool{"GET": true, "HEAD": true, "PUT": true}
+
+// Classify the result of an HTTP request. A request which may not be repeated (such as a POST,
+// which could create a second record) is only retried when the failure shows that the server did
+// not process it: HTTP 429 or 503, or a refused connection. Certificate errors are not transient;
+// they are handled by retrying insecurely (see doRest).
+func httpRetryReason(idempotent bool, resp *resty.Response, err error) retryReason {
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case isCertificateError(err):
+			return retryReason{}
+		case errors.Is(err, syscall.ECONNREFUSED):
+			return retryReason{reason: "connection refused"}
+		case !idempotent:
+			return retryReason{}
+		case errors.Is(err, syscall.ECONNRESET):
+			return retryReason{reason: "connection reset"}
+		case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+			return retryReason{reason: "connection closed"}
+		case errors.As(err, &netErr) && netErr.Timeout():
+			return retryReason{reason: "timeout"}
+		}
+		return retryReason{}
+	} else if resp == nil {
+		return retryReason{}
+	}
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return retryReason{reason: fmt.Sprintf("HTTP %d", resp.StatusCode()), retryAfter: parseRetryAfter(resp.Header().Get("Retry-After"))}
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		if idempotent {
+			return retryReason{reason: fmt.Sprintf("HTTP %d", resp.StatusCode())}
+		}
+	}
+	return retryReason{}
+}
+
+// RetryHTTP makes an HTTP request with the retry policy of the specified class, for clients
+// other than the shared API client (which uses the api class). The request must be safe to
+// repeat, whatever its method (such as a Keycloak token request). The client should not retry
+// requests itself.
+func RetryHTTP(ctx context.Context, class, target string, request func() (*resty.Response, error)) (resp *resty.Response, err error) {
+	withRetries(ctx, class, target, false,
+		func() { resp, err = request() },
+		func() retryReason { return httpRetryReason(true, resp, err) })
+	return
+}
+
+// A failure of a command which shows that it failed because of a transient problem: its exit
+// code, and its error output (compared in lower case). If notProcessed is false, the failure does
+// not show that the command changed nothing, so it is only retried for read-only commands.
+type transientFailure struct {
+	rc           int
+	output       string
+	notProcessed bool
+}
+
+// Failures of commands which show that they failed because of a transient problem with the API
+// gateway or the Kubernetes API server. The cray CLI exits with 2 when it gets an HTTP error
+// response; only the responses which show that the request was not processed are retried, since
+// the command may create something. kubectl exits with 1 when it cannot reach the API server or
+// gets an error from it; apart from a refused connection, those errors are only retried for the
+// verbs which do not change anything (see readOnlyKubectlVerbs), like the HTTP errors of requests
+// which may not be repeated (see httpRetryReason).
+var transientCommandFailures = map[string][]transientFailure{
+	RetryClassCLI: {
+		{2, "429 too many requests", true}, {2, "503 service unavailable", true},
+	},
+	RetryClassKubectl: {
+		{1, "connection refused", true},
+		{1, "too many requests", false}, {1, "bad gateway", false}, {1, "service unavailable", false},
+		{1, "gateway timeout", false}, {1, "the server is currently unable to handle the request", false},
+		{1, "etcdserver: request timed out", false}, {1, "connection reset by peer", false},
+		{1, "i/o timeout", false}, {1, "tls handshake timeout", false},
+		{1, "http2: client connection lost", false}, {1, "unexpected eof", false},
+	},
+}
+
+// kubectl verbs which only read from the API server, so that repeating them is safe
+var readOnlyKubectlVerbs = map[string]bool{"get": true, "describe": true, "logs": true}
+
+// Returns true if the command of the specified retry class does not change anything
+func readOnlyCommand(class string, cmdArgs []string) bool {
+	return class == RetryClassKubectl && len(cmdArgs) > 0 && readOnlyKubectlVerbs[cmdArgs[0]]
+}
+
+// Classify the result of a command. Failures which do not show that a command was not processed
+// are only retried if it is read-only.
+func commandRetryReason(class string, readOnly bool, cmdResult *CommandResult) retryReason {
+	if cmdResult == nil || !cmdResult.Ran || cmdResult.Rc == 0 {
+		return retryReason{}
+	}
+	stderr := strings.ToLower(cmdResult.ErrString())
+	for _, failure := range transientCommandFailures[class] {
+		if (failure.notProcessed || readOnly) && cmdResult.Rc == failure.rc && strings.Contains(stderr, failure.output) {
+			return retryReason{reason: fmt.Sprintf("exit code %d, '%s'", cmdResult.Rc, failure.output)}
+		}
+	}
+	return retryReason{}
+}
+
+// RunNameWithRetryClass runs the command with the retry policy of the specified class (such as
+// RetryClassKubectl), retrying it if it fails because of a transient problem. An error is
+// returned if it still fails that way after its last retry.
+func RunNameWithRetryClass(ctx context.Context, class, cmdName string, cmdArgs ...string) (cmdResult *CommandResult, err error) {
+	var reason retryReason
+	readOnly := readOnlyCommand(class, cmdArgs)
+	withRetries(ctx, class, cmdName+" "+strings.Join(cmdArgs, " "), false,
+		func() { cmdResult, err = RunName(ctx, cmdName, cmdArgs...) },
+		func() retryReason {
+			reason = commandRetryReason(class, readOnly, cmdResult)
+			return reason
+		})
+	if err == nil && len(reason.reason) > 0 {
+		err = fmt.Errorf("Command failed after %d retries: %s", RetryPolicyFor(class).MaxRetries, reason.reason)
+	}
+	return
+}
+
+// RetrySummary returns the retries made so far, sorted by class and reason
+func RetrySummary() []RetryStats {
+	retryLock.Lock()
+	defer retryLock.Unlock()
+	summary := make([]RetryStats, 0, len(retryCounts))
+	for key, count := range retryCounts {
+		summary = append(summary, RetryStats{Class: key[0], Reason: key[1], Retries: count.retries, Exhausted: count.exhausted})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Class != summary[j].Class {
+			return summary[i].Class < summary[j].Class
+		}
+		return summary[i].Reason < summary[j].Reason
+	})
+	return summary
+}
+
+// PrintRetrySummary prints a table of the retries made so far, if there were any
+func PrintRetrySummary(ctx context.Context) {
+	summary := RetrySummary()
+	if len(summary) == 0 {
+		return
+	}
+	width := len("REASON")
+	for _, stats := range summary {
+		if len(stats.Reason) > width {
+			width = len(stats.Reason)
+		}
+	}
+	Infof(ctx, "Retries of transient failures:")
+	Infof(ctx, "%-8s %-*s %8s %10s", "CLASS", width, "REASON", "RETRIES", "GAVE UP")
+	for _, stats := range summary {
+		Infof(ctx, "%-8s %-*s %8d %10d", stats.Class, width, stats.Reason, stats.Retries, stats.Exhausted)
+	}
+}
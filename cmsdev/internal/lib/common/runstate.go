@@ -0,0 +1,261 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * runstate.go
+ *
+ * Per-run state (run tags, test service, artifact prefix, tenant), carried by the
+ * context of the run, and buffering of output for runs executing in parallel goroutines
+ *
+ */
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Thread-safe buffer, since subtests may write to their run's output from more than
+// one goroutine
+type lockedBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Bytes()
+}
+
+// State which is specific to a single run of tests. The main run uses mainRunState.
+// Runs started with RunBuffered get their own state. The state of a run is carried by its
+// context, which is passed explicitly to the goroutines that work on the run (see
+// RunBuffered and RunConcurrently).
+type runState struct {
+	// The context of the run, which carries this state
+	ctx context.Context
+
+	runTags            []string
+	runStartTimes      []time.Time
+	testService        string
+	artifactFilePrefix string
+	tenantName         string
+
+	// Results being recorded for the current service test, its open subtests, and
+	// the current attempt number
+	serviceResult *ServiceResult
+	subtests      []*Subtest
+	attempt       int
+
+	// True while the run is deleting leftover test resources, so that errors from that
+	// are not recorded against the test results
+	cleaningUp bool
+
+	// Arbitrary per-run values set by test packages
+	values     map[string]interface{}
+	valuesLock sync.Mutex
+
+	// For buffered runs, console output and log file entries are collected here and
+	// written out in a single block when the run ends
+	buffered  bool
+	outBuffer lockedBuffer
+	logBuffer lockedBuffer
+	testLog   *logrus.Entry
+}
+
+var mainRunState *runState
+
+// Cancels the context of the main run, and so the contexts of all runs (see CancelRuns)
+var cancelRuns context.CancelFunc
+
+// Key of the run state in the context of a run
+type runStateKey struct{}
+
+// Held while a buffered run writes its output, so that blocks do not interleave
+var flushLock sync.Mutex
+
+// Returns the state of a new run, whose context is derived from ctx
+func newRunState(ctx context.Context) *runState {
+	rs := &runState{
+		runTags:       []string{AlnumString(5)},
+		runStartTimes: []time.Time{time.Now()},
+		values:        map[string]interface{}{},
+	}
+	rs.ctx = context.WithValue(ctx, runStateKey{}, rs)
+	return rs
+}
+
+// Returns the state of the run which the context belongs to. A context which was not derived
+// from the context of a run (such as context.Background()) belongs to the main run.
+func runStateOf(ctx context.Context) *runState {
+	if rs, ok := ctx.Value(runStateKey{}).(*runState); ok {
+		return rs
+	}
+	return mainRunState
+}
+
+// MainContext returns the context of the main run. It carries the state of the run, and is
+// cancelled if cmsdev is interrupted (see CancelRuns). Commands pass it to the functions they
+// call, and the service tests pass on the context they are given (see registry.RunOptions),
+// so that their output and results go to their own runs.
+func MainContext() context.Context {
+	return mainRunState.ctx
+}
+
+// CallContext returns the context for API requests, commands and Kubernetes calls made with
+// the specified context. This is ctx itself, except while its run is deleting the resources
+// that tests created, which must go ahead even once the runs have been cancelled.
+func CallContext(ctx context.Context) context.Context {
+	if runStateOf(ctx).cleaningUp {
+		return context.WithoutCancel(ctx)
+	}
+	return ctx
+}
+
+// CancelRuns cancels the contexts of all runs, so that the tests stop: their API requests,
+// commands and Kubernetes calls fail, and they stop waiting and retrying. The resources
+// they created can still be deleted (see CallContext).
+func CancelRuns() {
+	cancelRuns()
+}
+
+// Cancelled returns true if the runs have been cancelled
+func Cancelled() bool {
+	return mainRunState.ctx.Err() != nil
+}
+
+// Sleep waits for the specified duration, or until the runs are cancelled. It returns false
+// if they were.
+func Sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-CallContext(ctx).Done():
+		return false
+	}
+}
+
+// Where console output for this run should be written
+func (rs *runState) stdout() io.Writer {
+	if rs.buffered {
+		return &rs.outBuffer
+	}
+	return os.Stdout
+}
+
+// The log entry for this run, or nil if logging is disabled
+func (rs *runState) log() *logrus.Entry {
+	if rs.buffered {
+		return rs.testLog
+	}
+	return testLog
+}
+
+// Write the buffered console output and log entries of this run
+func (rs *runState) flush() {
+	flushLock.Lock()
+	defer flushLock.Unlock()
+	if logFile != nil {
+		if _, err := logFile.Out.Write(rs.logBuffer.Bytes()); err != nil {
+			os.Stderr.WriteString("Error writing buffered log entries: " + err.Error() + "\n")
+		}
+	}
+	os.Stdout.Write(rs.outBuffer.Bytes())
+}
+
+// RunBuffered calls fn in the calling goroutine with a run of its own, whose context is
+// derived from ctx. The run keeps the run tag and tenant of the run which ctx belongs to.
+// Console output and log entries from the run are buffered and written as a single block once
+// fn returns (or panics), so that runs going on at the same time in other goroutines do not
+// interleave with it.
+func RunBuffered(ctx context.Context, fn func(ctx context.Context)) {
+	parent := runStateOf(ctx)
+	rs := &runState{
+		runTags:       []string{parent.runTags[0]},
+		runStartTimes: []time.Time{parent.runStartTimes[0]},
+		tenantName:    parent.tenantName,
+		values:        map[string]interface{}{},
+		buffered:      true,
+	}
+	rs.ctx = context.WithValue(ctx, runStateKey{}, rs)
+	if testLog != nil {
+		bufferLogger := logrus.New()
+		bufferLogger.SetLevel(logFile.GetLevel())
+		bufferLogger.SetFormatter(logFile.Formatter)
+		bufferLogger.SetOutput(&rs.logBuffer)
+		rs.testLog = bufferLogger.WithFields(testLog.Data)
+	}
+
+	defer rs.flush()
+	fn(rs.ctx)
+}
+
+// RunConcurrently calls each of the specified functions in a goroutine of its own, and waits
+// for them all to return. Each is passed ctx, and works on the run which ctx belongs to, so its
+// output goes wherever that run's does. The functions should not record errors or subtest
+// results (Errorf, StartSubtest), since those are not safe to update from more than one
+// goroutine; they should return what they find to the caller to check instead.
+func RunConcurrently(ctx context.Context, fns ...func(ctx context.Context)) {
+	var wg sync.WaitGroup
+
+	for _, fn := range fns {
+		wg.Add(1)
+		go func(fn func(ctx context.Context)) {
+			defer wg.Done()
+			fn(ctx)
+		}(fn)
+	}
+	wg.Wait()
+}
+
+// Set and get arbitrary values which are scoped to the run which ctx belongs to
+func SetRunValue(ctx context.Context, key string, value interface{}) {
+	rs := runStateOf(ctx)
+	rs.valuesLock.Lock()
+	defer rs.valuesLock.Unlock()
+	rs.values[key] = value
+}
+
+func GetRunValue(ctx context.Context, key string) (value interface{}, ok bool) {
+	rs := runStateOf(ctx)
+	rs.valuesLock.Lock()
+	defer rs.valuesLock.Unlock()
+	value, ok = rs.values[key]
+	return
+}
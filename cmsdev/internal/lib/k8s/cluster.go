@@ -0,0 +1,141 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * cluster.go
+ *
+ * The Kubernetes cluster queried by the tests. By default this is the cluster of the
+ * system (through client-go and kubectl), but a fake cluster can be used in its place.
+ *
+ */
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/kubernetes"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+// Cluster is the source of all of the Kubernetes information used by the tests
+type Cluster interface {
+	// Clientset returns the client for the Kubernetes API of the cluster
+	Clientset(ctx context.Context) (kubernetes.Interface, error)
+	// Tenants returns the names of the tenants defined on the cluster
+	Tenants(ctx context.Context) ([]string, error)
+	// RunCommandInContainer runs a command in the specified container, and returns its
+	// combined output
+	RunCommandInContainer(ctx context.Context, podName, namespace, containerName string, cmdStrings ...string) (string, error)
+}
+
+var cluster Cluster = clientGoCluster{}
+var clusterLock sync.RWMutex
+
+// SetCluster replaces the cluster that the k8s functions query. This is intended for
+// using a fake cluster (see NewFakeCluster) in place of the cluster of the system.
+func SetCluster(c Cluster) {
+	clusterLock.Lock()
+	defer clusterLock.Unlock()
+	cluster = c
+	_, isFake := c.(*FakeCluster)
+	common.SetFakeCluster(isFake)
+}
+
+// CurrentCluster returns the cluster that the k8s functions query
+func CurrentCluster() Cluster {
+	clusterLock.RLock()
+	defer clusterLock.RUnlock()
+	return cluster
+}
+
+// The cluster of the system. The API is accessed using the KUBECONFIG file, and
+// kubectl is used for the things which are not part of the core API. It is not queried while
+// a recording is being replayed, since the Kubernetes API queries are not recorded.
+type clientGoCluster struct{}
+
+var errReplaying = fmt.Errorf("The Kubernetes cluster is not queried while replaying a recording (use --fake-cluster)")
+
+func (clientGoCluster) Clientset(ctx context.Context) (kubernetes.Interface, error) {
+	if common.Replaying() {
+		return nil, errReplaying
+	}
+	config, err := getKubeConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+func (clientGoCluster) Tenants(ctx context.Context) (tenantList []string, err error) {
+	if common.Replaying() {
+		return nil, errReplaying
+	}
+	var cmdResult *common.CommandResult
+	cmdResult, err = runKubectl(ctx, "get", "tenants", "-n", "tenants", "-o", "custom-columns=:.metadata.name ", "--no-headers")
+	if err != nil {
+		return
+	}
+	// Trim whitespace
+	tenantListString := strings.TrimSpace(cmdResult.OutString())
+	if len(tenantListString) == 0 {
+		return
+	}
+	// Split by newline
+	tenantList = strings.Split(tenantListString, "\n")
+	return
+}
+
+func (clientGoCluster) RunCommandInContainer(ctx context.Context, podName, namespace, containerName string, cmdStrings ...string) (string, error) {
+	if common.Replaying() {
+		return "", errReplaying
+	}
+	k8sCmdList := [...]string{"exec", "-q", podName, "-n", namespace, "-c", containerName, "--stdin=false", "--"}
+	cmdList := append(k8sCmdList[:], cmdStrings...)
+	cmdResult, err := runKubectl(ctx, cmdList...)
+	if cmdResult == nil {
+		return "", err
+	}
+	return cmdResult.OutString(), err
+}
+
+// Runs kubectl with the specified arguments. This goes through common.RunName (or RunNameCombined
+// for exec), so that the command is recorded or replayed along with the others when that is requested.
+// Commands which fail because of a transient problem with the Kubernetes API server are retried
+// with the kubectl retry policy, except for exec, whose errors may come from the command it runs.
+// Returns an error if the command fails.
+func runKubectl(ctx context.Context, cmdArgs ...string) (cmdResult *common.CommandResult, err error) {
+	if len(cmdArgs) > 0 && cmdArgs[0] == "exec" {
+		// The output of the command run in the container is returned as a whole
+		cmdResult, err = common.RunNameCombined(ctx, "kubectl", cmdArgs...)
+	} else {
+		cmdResult, err = common.RunNameWithRetryClass(ctx, common.RetryClassKubectl, "kubectl", cmdArgs...)
+	}
+	if err == nil && cmdResult.Rc != 0 {
+		err = fmt.Errorf("kubectl command failed with return code %d", cmdResult.Rc)
+	}
+	return
+}
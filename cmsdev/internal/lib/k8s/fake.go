@@ -0,0 +1,174 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * fake.go
+ *
+ * A fake Kubernetes cluster, backed by the client-go fake clientset, so that the checks of
+ * the service pods and PVCs can be run against simulated cluster state
+ *
+ */
+
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	coreV1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+// FakeCluster is a Cluster whose API is served by the client-go fake clientset
+type FakeCluster struct {
+	clientset *fake.Clientset
+	// Names of the tenants defined on the cluster
+	TenantNames []string
+	// Called to run commands in containers. If not set, running commands fails.
+	Exec func(podName, namespace, containerName string, cmdStrings ...string) (string, error)
+}
+
+// NewFakeCluster returns a fake cluster containing the specified Kubernetes objects
+func NewFakeCluster(objects ...runtime.Object) *FakeCluster {
+	return &FakeCluster{clientset: fake.NewSimpleClientset(objects...)}
+}
+
+// FakePod returns a pod in the CMS namespace with the specified phase, whose containers (if it
+// is running) are all running, for adding to a fake cluster
+func FakePod(name string, phase coreV1.PodPhase) *coreV1.Pod {
+	pod := &coreV1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: common.NAMESPACE},
+		Status:     coreV1.PodStatus{Phase: phase},
+	}
+	if phase == coreV1.PodRunning {
+		pod.Status.ContainerStatuses = []coreV1.ContainerStatus{
+			{Name: "main", State: coreV1.ContainerState{Running: &coreV1.ContainerStateRunning{}}},
+		}
+	}
+	return pod
+}
+
+// LoadFakeCluster returns a fake cluster containing the Kubernetes objects in the specified
+// YAML (or JSON) file, such as the output of "kubectl get pods -A -o yaml". The file may
+// contain multiple documents and List objects. Tenant objects are not known to the fake
+// clientset, so their names are added to the tenants of the cluster instead.
+func LoadFakeCluster(path string) (*FakeCluster, error) {
+	var objects []runtime.Object
+	var tenants []string
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		} else if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		objects, tenants, err = decodeFakeObject(doc, objects, tenants)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	cluster := NewFakeCluster(objects...)
+	cluster.TenantNames = tenants
+	return cluster, nil
+}
+
+// UseFakeClusterFile makes the fake cluster in the specified file (see LoadFakeCluster) the
+// current cluster, for commands with a --fake-cluster option. Nothing is done if path is empty.
+func UseFakeClusterFile(path string) error {
+	if len(path) == 0 {
+		return nil
+	}
+	cluster, err := LoadFakeCluster(path)
+	if err != nil {
+		return err
+	}
+	SetCluster(cluster)
+	return nil
+}
+
+// Decodes a Kubernetes object (or the items of a List object), and appends it to the
+// objects (or, for a tenant, its name to the tenants)
+func decodeFakeObject(doc []byte, objects []runtime.Object, tenants []string) ([]runtime.Object, []string, error) {
+	var meta v1.PartialObjectMetadata
+
+	if err := yaml.Unmarshal(doc, &meta); err != nil {
+		return objects, tenants, err
+	} else if meta.Kind == "Tenant" {
+		return objects, append(tenants, meta.Name), nil
+	}
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(doc, nil, nil)
+	if err != nil {
+		return objects, tenants, err
+	}
+	list, isList := obj.(*coreV1.List)
+	if !isList {
+		return append(objects, obj), tenants, nil
+	}
+	for _, item := range list.Items {
+		objects, tenants, err = decodeFakeObject(item.Raw, objects, tenants)
+		if err != nil {
+			return objects, tenants, err
+		}
+	}
+	return objects, tenants, nil
+}
+
+// FakeClientset returns the fake clientset of the cluster, so that objects and reactors
+// can be added to it
+func (c *FakeCluster) FakeClientset() *fake.Clientset {
+	return c.clientset
+}
+
+func (c *FakeCluster) Clientset(ctx context.Context) (kubernetes.Interface, error) {
+	return c.clientset, nil
+}
+
+func (c *FakeCluster) Tenants(ctx context.Context) ([]string, error) {
+	return append([]string{}, c.TenantNames...), nil
+}
+
+func (c *FakeCluster) RunCommandInContainer(ctx context.Context, podName, namespace, containerName string, cmdStrings ...string) (string, error) {
+	if c.Exec == nil {
+		return "", fmt.Errorf("Cannot run commands in container %s of pod %s in namespace %s of the fake cluster",
+			containerName, podName, namespace)
+	}
+	return c.Exec(podName, namespace, containerName, cmdStrings...)
+}
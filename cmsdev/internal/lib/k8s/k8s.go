@@ -1,7 +1,7 @@
 //
 //  MIT License
 //
-//  (C) Copyright 2020-2025 Hewlett Packard Enterprise Development LP
+//  (C) Copyright 2020-2026 Hewlett Packard Enterprise Development LP
 //
 //  Permission is hereby granted, free of charge, to any person obtaining a
 //  copy of this software and associated documentation files (the "Software"),
@@ -27,8 +27,6 @@ package k8s
 import (
 	"bytes"
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -37,6 +35,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	resty "gopkg.in/resty.v1"
@@ -62,45 +61,41 @@ type ContainerEnvVar struct {
 
 var KubectlPath, VcsUser, VcsPass string
 var kubeConfig *rest.Config
+var kubeConfigLock sync.Mutex
 
 // Retry constants for k8s
 const MaxRetries = 45
 const RetryIntervalSeconds = 2
 
-func getKubeconfigEnvVar() (kubeconfigEnvVar string) {
+func getKubeconfigEnvVar(ctx context.Context) (kubeconfigEnvVar string) {
 	kubeconfigEnvVar = os.Getenv("KUBECONFIG")
 	if len(kubeconfigEnvVar) == 0 {
 		kubeconfigEnvVar = filepath.Join(os.Getenv("HOME"), ".kube", "config")
-		common.Debugf("KUBECONFIG env var not set. Using default = %s", kubeconfigEnvVar)
+		common.Debugf(ctx, "KUBECONFIG env var not set. Using default = %s", kubeconfigEnvVar)
 		return
 	}
-	common.Debugf("Found env var KUBECONFIG = %s", kubeconfigEnvVar)
+	common.Debugf(ctx, "Found env var KUBECONFIG = %s", kubeconfigEnvVar)
 	return
 }
 
-func getKubeConfig() (*rest.Config, error) {
+func getKubeConfig(ctx context.Context) (*rest.Config, error) {
 	var err error
+	kubeConfigLock.Lock()
+	defer kubeConfigLock.Unlock()
 	if kubeConfig == nil {
-		kubeConfig, err = clientcmd.BuildConfigFromFlags("", getKubeconfigEnvVar())
+		kubeConfig, err = clientcmd.BuildConfigFromFlags("", getKubeconfigEnvVar(ctx))
 	}
 	return kubeConfig, err
 }
 
-func GetClientset() (*kubernetes.Clientset, error) {
-	config, err := getKubeConfig()
-	if err != nil {
-		return nil, err
-	}
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, err
-	}
-	return clientset, err
+// Returns the client for the Kubernetes API of the current cluster (see CurrentCluster)
+func GetClientset(ctx context.Context) (kubernetes.Interface, error) {
+	return CurrentCluster().Clientset(ctx)
 }
 
-func GetKubectlPath() (string, error) {
+func GetKubectlPath(ctx context.Context) (string, error) {
 	if len(KubectlPath) == 0 {
-		common.Debugf("Trying to look up path of kubectl")
+		common.Debugf(ctx, "Trying to look up path of kubectl")
 		path, err := exec.LookPath("kubectl")
 		if err != nil {
 			return "", err
@@ -112,120 +107,90 @@ func GetKubectlPath() (string, error) {
 	return KubectlPath, nil
 }
 
-func GetTenants() (tenantList []string, err error) {
-	var path string
-	var cmdList = []string{"get", "tenants", "-n", "tenants", "-o", "custom-columns=:.metadata.name ", "--no-headers"}
-	path, err = GetKubectlPath()
-	if err != nil {
+func GetTenants(ctx context.Context) (tenantList []string, err error) {
+	if !common.ClusterAvailable() {
+		common.Infof(ctx, "Not getting tenants, because %s (no Kubernetes cluster)", common.NoClusterReason())
 		return
 	}
-	cmd := exec.Command(path, cmdList...)
-	common.Debugf("Running command: %s", cmd)
-	cmdOut, err := cmd.CombinedOutput()
+	tenantList, err = CurrentCluster().Tenants(ctx)
 	if err != nil {
 		return
 	}
-	// Trim whitespace
-	tenantListString := strings.TrimSpace(string(cmdOut))
-	if len(tenantListString) == 0 {
-		common.Infof("No tenants defined on the system")
+	if len(tenantList) == 0 {
+		common.Infof(ctx, "No tenants defined on the system")
 		return
 	}
-	// Split by newline
-	tenantList = strings.Split(tenantListString, "\n")
-	common.Infof("The following %d tenants are defined on the system: %s", len(tenantList), strings.Join(tenantList, ", "))
+	common.Infof(ctx, "The following %d tenants are defined on the system: %s", len(tenantList), strings.Join(tenantList, ", "))
 	return
 }
 
-func RunCommandInContainer(podName, namespace, containerName string, cmdStrings ...string) (string, error) {
-	k8sCmdList := [...]string{"exec", "-q", podName, "-n", namespace, "-c", containerName, "--stdin=false", "--"}
-	cmdList := append(k8sCmdList[:], cmdStrings...)
-	path, err := GetKubectlPath()
-	if err != nil {
-		return "", err
-	}
-	cmd := exec.Command(path, cmdList...)
-	common.Debugf("Running command: %s", cmd)
-	cmdOut, err := cmd.CombinedOutput()
-	if len(cmdOut) > 0 {
-		common.Debugf("Command output: %s", cmdOut)
-	} else {
-		common.Debugf("No command output")
-	}
-	return string(cmdOut), err
+func RunCommandInContainer(ctx context.Context, podName, namespace, containerName string, cmdStrings ...string) (string, error) {
+	return CurrentCluster().RunCommandInContainer(ctx, podName, namespace, containerName, cmdStrings...)
 }
 
-func GetVcsUsernamePassword() (vcsUsername, vcsPassword string, err error) {
+func GetVcsUsernamePassword(ctx context.Context) (vcsUsername, vcsPassword string, err error) {
 	if len(VcsUser) != 0 && len(VcsPass) != 0 {
-		common.Debugf("Using cached values of vcs user and password")
+		common.Debugf(ctx, "Using cached values of vcs user and password")
 		vcsUsername = VcsUser
 		vcsPassword = VcsPass
 		return
 	}
 
-	secret, err := GetSecret("services", "vcs-user-credentials")
+	secret, err := GetSecret(ctx, common.NAMESPACE, "vcs-user-credentials")
 	if err != nil {
 		return
 	}
 
-	vcsUsername, err = GetDataFieldFromSecret(secret, "vcs_username")
+	vcsUsername, err = GetDataFieldFromSecret(ctx, secret, "vcs_username")
 	if err != nil {
 		return
 	}
 
-	vcsPassword, err = GetDataFieldFromSecret(secret, "vcs_password")
+	vcsPassword, err = GetDataFieldFromSecret(ctx, secret, "vcs_password")
 	if err != nil {
 		return
 	}
+	common.RegisterSecret(vcsPassword)
 	VcsUser = vcsUsername
 	VcsPass = vcsPassword
 	return
 }
 
-func GetOauthClientSecret() (string, error) {
-	return GetSecretDataField("default", "admin-client-auth", "client-secret")
+func GetOauthClientSecret(ctx context.Context) (string, error) {
+	return GetSecretDataField(ctx, "default", "admin-client-auth", "client-secret")
 }
 
-func GetAccessJSON(params ...string) ([]byte, error) {
-	var err error
-	var clientSecret string = ""
-
-	if len(params) == 1 {
-		// check if param is valid otherwise discard
-		// add a regex here to check if string is valid
-		clientSecret = params[0]
-		re, _ := regexp.MatchString("^([0-9a-f]*-){4}[0-9a-f]*$", clientSecret)
-		if re != true {
-			return nil, errors.New("received invalid client-secret")
-		}
-	} else {
-		clientSecretByte, err := GetOauthClientSecret()
+// GetAccessJSON requests an access token from Keycloak with the client credentials grant, and
+// returns the JSON response. If clientSecret is empty, the secret of the admin client is read
+// from its Kubernetes secret.
+func GetAccessJSON(ctx context.Context, clientID, realm, clientSecret string) ([]byte, error) {
+	if len(clientSecret) == 0 {
+		var err error
+		clientSecret, err = GetOauthClientSecret(ctx)
 		if err != nil {
 			return nil, err
 		}
-		clientSecret = string(clientSecretByte)
+	}
+	common.RegisterSecret(clientSecret)
+	// Use the shared transport, so that Keycloak's certificate is verified like the API gateway's
+	transport, err := common.HTTPTransport()
+	if err != nil {
+		return nil, err
 	}
 	client := resty.New()
+	client.SetTransport(transport)
 	client.SetTimeout(common.API_TIMEOUT_SECONDS)
-	client.SetRetryCount(common.API_RETRY_COUNT)
-	client.SetRetryWaitTime(common.API_RETRY_WAIT_SECONDS * time.Second)
 	client.SetHeader("Content-Type", "application/json")
 	client.SetFormData(map[string]string{
 		"grant_type":    "client_credentials",
-		"client_id":     "admin-client",
+		"client_id":     clientID,
 		"client_secret": clientSecret,
 	})
 
-	// Add retry condition for HTTP 503 status code
-	client.AddRetryCondition(func(r *resty.Response) (bool, error) {
-		if r.StatusCode() == 503 {
-			fmt.Printf("Received HTTP code 503 from server, Waiting for: %d seconds before retry\n", common.API_RETRY_WAIT_SECONDS)
-			return true, nil
-		}
-		return false, nil
+	url := fmt.Sprintf("https://%s/keycloak/realms/%s/protocol/openid-connect/token", common.BASEHOST, realm)
+	resp, err := common.RetryHTTP(ctx, common.RetryClassToken, "POST "+url, func() (*resty.Response, error) {
+		return client.R().Post(url)
 	})
-
-	resp, err := client.R().Post("https://api-gw-service-nmn.local/keycloak/realms/shasta/protocol/openid-connect/token")
 	if err != nil {
 		return nil, err
 	} else if resp.StatusCode() != http.StatusOK {
@@ -234,23 +199,6 @@ func GetAccessJSON(params ...string) ([]byte, error) {
 	return resp.Body(), nil
 }
 
-func GetAccessToken(params ...string) (string, error) {
-	// TODO: clean this up. remove curl and replace with clientgo or a rest call
-	var cmdOut []byte
-	var err error
-	var jsonData map[string]interface{}
-
-	cmdOut, err = GetAccessJSON(params...)
-	if err != nil {
-		return "", err
-	}
-	common.Debugf("Parsing JSON object containing access token")
-	if err = json.Unmarshal(cmdOut, &jsonData); err != nil {
-		return "", err
-	}
-	return jsonData["access_token"].(string), nil
-}
-
 // Given a container, return a map of its environment variables
 func GetEnvVars(container coreV1.Container) (envVars []ContainerEnvVar) {
 	envVars = make([]ContainerEnvVar, 0, len(container.Env))
@@ -270,12 +218,12 @@ func GetContainers(pod coreV1.Pod) (containers []coreV1.Container) {
 }
 
 // Given a namespace and a cronjob name, verify that it exists
-func VerifyCronJobExists(namespace, name string) error {
-	clientset, err := GetClientset()
+func VerifyCronJobExists(ctx context.Context, namespace, name string) error {
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return err
 	}
-	allCronJobs, err := clientset.BatchV1().CronJobs(namespace).List(context.TODO(), v1.ListOptions{})
+	allCronJobs, err := clientset.BatchV1().CronJobs(namespace).List(common.CallContext(ctx), v1.ListOptions{})
 	if err != nil {
 		return err
 	}
@@ -288,14 +236,14 @@ func VerifyCronJobExists(namespace, name string) error {
 }
 
 // Given an optional regex, return an array of Nodes (whose name match the regex, if specified)
-func GetNodes(params ...string) ([]coreV1.Node, error) {
+func GetNodes(ctx context.Context, params ...string) ([]coreV1.Node, error) {
 	var nodes []coreV1.Node
 
-	clientset, err := GetClientset()
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return nodes, err
 	}
-	allNodes, err := clientset.CoreV1().Nodes().List(context.TODO(), v1.ListOptions{})
+	allNodes, err := clientset.CoreV1().Nodes().List(common.CallContext(ctx), v1.ListOptions{})
 	if err != nil {
 		return nodes, err
 	}
@@ -314,10 +262,10 @@ func GetNodes(params ...string) ([]coreV1.Node, error) {
 }
 
 // Given an optional regex, return an array of Node names
-func GetNodeNames(params ...string) ([]string, error) {
+func GetNodeNames(ctx context.Context, params ...string) ([]string, error) {
 	var names []string
 
-	nodes, err := GetNodes(params...)
+	nodes, err := GetNodes(ctx, params...)
 	if err != nil {
 		return names, err
 	}
@@ -327,8 +275,8 @@ func GetNodeNames(params ...string) ([]string, error) {
 	return names, err
 }
 
-func GetDataFieldFromSecret(secret *coreV1.Secret, field_name string) (string, error) {
-	common.Debugf("Retrieving '%s' data field from %s in namespace %s",
+func GetDataFieldFromSecret(ctx context.Context, secret *coreV1.Secret, field_name string) (string, error) {
+	common.Debugf(ctx, "Retrieving '%s' data field from %s in namespace %s",
 		field_name, secret.ObjectMeta.Name, secret.ObjectMeta.Namespace)
 	fieldBytes, keyFound := secret.Data[field_name]
 	if !keyFound {
@@ -341,44 +289,44 @@ func GetDataFieldFromSecret(secret *coreV1.Secret, field_name string) (string, e
 }
 
 // Given a namespace and a name, returns the matching secret
-func GetSecret(namespace, name string) (*coreV1.Secret, error) {
-	common.Debugf("Retrieving kubernetes secret %s in %s namespace", name, namespace)
-	clientset, err := GetClientset()
+func GetSecret(ctx context.Context, namespace, name string) (*coreV1.Secret, error) {
+	common.Debugf(ctx, "Retrieving kubernetes secret %s in %s namespace", name, namespace)
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return clientset.CoreV1().Secrets(namespace).Get(
-		context.TODO(),
+		common.CallContext(ctx),
 		name,
 		v1.GetOptions{},
 	)
 }
 
-func GetSecretDataField(namespace, name, field_name string) (string, error) {
-	k8sSecret, err := GetSecret(namespace, name)
+func GetSecretDataField(ctx context.Context, namespace, name, field_name string) (string, error) {
+	k8sSecret, err := GetSecret(ctx, namespace, name)
 	if err != nil {
 		return "", err
 	}
-	return GetDataFieldFromSecret(k8sSecret, field_name)
+	return GetDataFieldFromSecret(ctx, k8sSecret, field_name)
 }
 
 // Given a namespace and name, returns the matching configmap
-func GetConfigMap(namespace, name string) (cm coreV1.ConfigMap, err error) {
-	common.Debugf("Retrieving Kubernetes ConfigMap in namespace %s with name %s", namespace, name)
+func GetConfigMap(ctx context.Context, namespace, name string) (cm coreV1.ConfigMap, err error) {
+	common.Debugf(ctx, "Retrieving Kubernetes ConfigMap in namespace %s with name %s", namespace, name)
 
-	clientset, err := GetClientset()
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return
 	}
 
-	cmlist, err := clientset.CoreV1().ConfigMaps(namespace).List(context.TODO(), v1.ListOptions{})
+	cmlist, err := clientset.CoreV1().ConfigMaps(namespace).List(common.CallContext(ctx), v1.ListOptions{})
 	if err != nil {
 		return
 	}
 
 	for _, cm = range cmlist.Items {
 		if cm.ObjectMeta.Name == name {
-			common.Debugf("Found Kubernetes ConfigMap in namespace %s with name %s", namespace, name)
+			common.Debugf(ctx, "Found Kubernetes ConfigMap in namespace %s with name %s", namespace, name)
 			return
 		}
 	}
@@ -387,15 +335,15 @@ func GetConfigMap(namespace, name string) (cm coreV1.ConfigMap, err error) {
 }
 
 // Given a namespace, configmap name, and data field name, return the specified data field as a byte slice.
-func GetConfigMapDataField(namespace, cm_name, field_name string) (field_bytes []byte, err error) {
+func GetConfigMapDataField(ctx context.Context, namespace, cm_name, field_name string) (field_bytes []byte, err error) {
 	var cm coreV1.ConfigMap
 
-	cm, err = GetConfigMap(namespace, cm_name)
+	cm, err = GetConfigMap(ctx, namespace, cm_name)
 	if err != nil {
 		return
 	}
 
-	common.Debugf("Retrieve Data field '%s' from ConfigMap '%s' in namespace '%s'", field_name, cm_name, namespace)
+	common.Debugf(ctx, "Retrieve Data field '%s' from ConfigMap '%s' in namespace '%s'", field_name, cm_name, namespace)
 	dataField, keyFound := cm.Data[field_name]
 	if !keyFound {
 		err = fmt.Errorf("No field named '%s' found in Kubernetes ConfigMap %s in namespace %s", field_name, cm_name, namespace)
@@ -403,18 +351,18 @@ func GetConfigMapDataField(namespace, cm_name, field_name string) (field_bytes [
 	}
 
 	// Make sure we can convert the field to a byte slice
-	common.Debugf("Convert %s field to byte slice", field_name)
+	common.Debugf(ctx, "Convert %s field to byte slice", field_name)
 	field_bytes = []byte(dataField)
 	return
 }
 
 // Given a namespace and name, returns the matching service
-func GetService(namespace, name string) (service coreV1.Service, err error) {
-	clientset, err := GetClientset()
+func GetService(ctx context.Context, namespace, name string) (service coreV1.Service, err error) {
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return
 	}
-	services, err := clientset.CoreV1().Services(namespace).List(context.TODO(), v1.ListOptions{})
+	services, err := clientset.CoreV1().Services(namespace).List(common.CallContext(ctx), v1.ListOptions{})
 	if err != nil {
 		return
 	}
@@ -429,14 +377,14 @@ func GetService(namespace, name string) (service coreV1.Service, err error) {
 }
 
 // Given a namespace, and an optional regex, return an array of Pods (whose name match the regex, if specified)
-func GetPods(namespace string, params ...string) ([]coreV1.Pod, error) {
+func GetPods(ctx context.Context, namespace string, params ...string) ([]coreV1.Pod, error) {
 	var pods []coreV1.Pod
 
-	clientset, err := GetClientset()
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return pods, err
 	}
-	allPods, err := clientset.CoreV1().Pods(namespace).List(context.TODO(), v1.ListOptions{})
+	allPods, err := clientset.CoreV1().Pods(namespace).List(common.CallContext(ctx), v1.ListOptions{})
 	if err != nil {
 		return pods, err
 	}
@@ -455,8 +403,8 @@ func GetPods(namespace string, params ...string) ([]coreV1.Pod, error) {
 }
 
 // Given a namespace and the name of a pod, return its start time
-func GetPodStartTime(namespace, podName string) (nodeStart v1.Time, err error) {
-	pods, err := GetPods(namespace, podName)
+func GetPodStartTime(ctx context.Context, namespace, podName string) (nodeStart v1.Time, err error) {
+	pods, err := GetPods(ctx, namespace, podName)
 	if err != nil {
 		return
 	}
@@ -471,8 +419,8 @@ func GetPodStartTime(namespace, podName string) (nodeStart v1.Time, err error) {
 }
 
 // Given a namespace and the name of a pod, return the name of its node
-func GetPodNodeName(namespace, podName string) (nodeName string, err error) {
-	pods, err := GetPods(namespace, podName)
+func GetPodNodeName(ctx context.Context, namespace, podName string) (nodeName string, err error) {
+	pods, err := GetPods(ctx, namespace, podName)
 	if err != nil {
 		return
 	}
@@ -487,10 +435,10 @@ func GetPodNodeName(namespace, podName string) (nodeName string, err error) {
 }
 
 // Given a namespace, and an optional regex, return an array of Pod names
-func GetPodNames(namespace string, params ...string) ([]string, error) {
+func GetPodNames(ctx context.Context, namespace string, params ...string) ([]string, error) {
 	var names []string
 
-	pods, err := GetPods(namespace, params...)
+	pods, err := GetPods(ctx, namespace, params...)
 	if err != nil {
 		return names, err
 	}
@@ -500,29 +448,43 @@ func GetPodNames(namespace string, params ...string) ([]string, error) {
 	return names, err
 }
 
-// Given a namespace, and an optional regex, return an array of PVC Pod names
-func GetPVCNames(namespace string, params ...string) ([]string, error) {
-	var names []string
+// Given a namespace, and an optional regex, return an array of PVCs (whose name match the regex, if specified)
+func GetPVCs(ctx context.Context, namespace string, params ...string) ([]coreV1.PersistentVolumeClaim, error) {
+	var pvcs []coreV1.PersistentVolumeClaim
 
-	clientset, err := GetClientset()
+	clientset, err := GetClientset(ctx)
 	if err != nil {
-		return names, err
+		return pvcs, err
 	}
-	pvcs, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(context.TODO(), v1.ListOptions{})
+	allPvcs, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(common.CallContext(ctx), v1.ListOptions{})
 	if err != nil {
-		return names, err
+		return pvcs, err
 	}
-	for _, pvc := range pvcs.Items {
+	for _, pvc := range allPvcs.Items {
 		if len(params) > 0 {
 			match, _ := regexp.MatchString(params[0], pvc.GetName())
 			if match {
-				names = append(names, pvc.GetName())
+				pvcs = append(pvcs, pvc)
 			}
 			continue
 		} else {
-			names = append(names, pvc.GetName())
+			pvcs = append(pvcs, pvc)
 		}
 	}
+	return pvcs, err
+}
+
+// Given a namespace, and an optional regex, return an array of PVC names
+func GetPVCNames(ctx context.Context, namespace string, params ...string) ([]string, error) {
+	var names []string
+
+	pvcs, err := GetPVCs(ctx, namespace, params...)
+	if err != nil {
+		return names, err
+	}
+	for _, pvc := range pvcs {
+		names = append(names, pvc.GetName())
+	}
 	return names, err
 }
 
@@ -536,7 +498,7 @@ func GetPVCNames(namespace string, params ...string) ([]string, error) {
 // indicate that the true pod status cannot yet be determined (it may start up successfully, or it may be in CLBO);
 // the caller can choose to handle this accordingly (perhaps by waiting and retrying)
 // Otherwise (if all containers are running), it returns podStatus (Running) and nil error
-func determinePodStatus(namespace, podName string, pod *coreV1.Pod) (podStatus string, err error) {
+func determinePodStatus(ctx context.Context, namespace, podName string, pod *coreV1.Pod) (podStatus string, err error) {
 	podStatus = string(pod.Status.Phase)
 	if podStatus != "Running" {
 		return
@@ -544,7 +506,7 @@ func determinePodStatus(namespace, podName string, pod *coreV1.Pod) (podStatus s
 	allContainersRunning := true
 
 	for _, cs := range pod.Status.ContainerStatuses {
-		common.Debugf("Container %s status is %v", cs.Name, cs.State)
+		common.Debugf(ctx, "Container %s status is %v", cs.Name, cs.State)
 		if cs.State.Running != nil {
 			continue
 		} else if cs.State.Waiting != nil {
@@ -573,16 +535,16 @@ func determinePodStatus(namespace, podName string, pod *coreV1.Pod) (podStatus s
 }
 
 // returns phase for pods
-func GetPodStatus(namespace, podName string) (string, error) {
+func GetPodStatus(ctx context.Context, namespace, podName string) (string, error) {
 	var status string
-	clientset, err := GetClientset()
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return status, err
 	}
 
 	for retries := 0; retries < MaxRetries; retries++ {
 		pod, err := clientset.CoreV1().Pods(namespace).Get(
-			context.TODO(),
+			common.CallContext(ctx),
 			podName,
 			v1.GetOptions{},
 		)
@@ -590,34 +552,34 @@ func GetPodStatus(namespace, podName string) (string, error) {
 			return status, err
 		}
 
-		status, err := determinePodStatus(namespace, podName, pod)
+		status, err := determinePodStatus(ctx, namespace, podName, pod)
 		if err != nil {
 			return status, err
 		} else if status == "" {
 			// Allow retry loop to get the pod status again to make sure containers are running
-			common.Infof("Pod %s in namespace %s true status cannot be determined. Retrying in %d seconds...", podName, namespace, RetryIntervalSeconds)
-			time.Sleep(time.Duration(RetryIntervalSeconds) * time.Second)
+			common.Infof(ctx, "Pod %s in namespace %s true status cannot be determined. Retrying in %d seconds...", podName, namespace, RetryIntervalSeconds)
+			common.Sleep(ctx, time.Duration(RetryIntervalSeconds)*time.Second)
 			continue
 		} else if status != "Pending" {
 			return status, nil
 		}
 
-		common.Infof("Pod %s in namespace %s is in %s state. Retrying in %d seconds...", podName, namespace, status, RetryIntervalSeconds)
-		time.Sleep(time.Duration(RetryIntervalSeconds) * time.Second)
+		common.Infof(ctx, "Pod %s in namespace %s is in %s state. Retrying in %d seconds...", podName, namespace, status, RetryIntervalSeconds)
+		common.Sleep(ctx, time.Duration(RetryIntervalSeconds)*time.Second)
 	}
 
 	return status, fmt.Errorf("The pod %s and/or at least one of the containers in namespace %s is not in 'Running' state after %d retries", podName, namespace, MaxRetries)
 }
 
 // returns pod stats
-func GetPodStats(namespace, podName string) (stats *PodStats, err error) {
+func GetPodStats(ctx context.Context, namespace, podName string) (stats *PodStats, err error) {
 	stats = new(PodStats)
-	clientset, err := GetClientset()
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return
 	}
 	pod, err := clientset.CoreV1().Pods(namespace).Get(
-		context.TODO(),
+		common.CallContext(ctx),
 		podName,
 		v1.GetOptions{},
 	)
@@ -635,13 +597,13 @@ func GetPodStats(namespace, podName string) (stats *PodStats, err error) {
 }
 
 // given a pvc name, function returns its phase
-func GetPVCStatus(namespace, pvcName string) (status string, err error) {
-	clientset, err := GetClientset()
+func GetPVCStatus(ctx context.Context, namespace, pvcName string) (status string, err error) {
+	clientset, err := GetClientset(ctx)
 	if err != nil {
 		return
 	}
 	pvc, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(
-		context.TODO(),
+		common.CallContext(ctx),
 		pvcName,
 		v1.GetOptions{},
 	)
@@ -653,24 +615,12 @@ func GetPVCStatus(namespace, pvcName string) (status string, err error) {
 }
 
 // given a pod's name and container, returns service logs
-func GetPodLogs(namespace, podName string, containerName ...string) (string, error) {
-	clientset, err := GetClientset()
-	if err != nil {
-		return "", err
-	}
-	var req *rest.Request
-	if len(containerName) == 0 {
-		req = clientset.CoreV1().Pods(namespace).GetLogs(
-			podName,
-			&coreV1.PodLogOptions{},
-		)
-	} else {
-		req = clientset.CoreV1().Pods(namespace).GetLogs(
-			podName,
-			&coreV1.PodLogOptions{Container: containerName[0]},
-		)
+func GetPodLogs(ctx context.Context, namespace, podName string, containerName ...string) (string, error) {
+	options := &coreV1.PodLogOptions{}
+	if len(containerName) > 0 {
+		options.Container = containerName[0]
 	}
-	podLogs, err := req.Stream(context.TODO())
+	podLogs, err := StreamPodLogs(ctx, namespace, podName, options)
 	if err != nil {
 		return "", err
 	}
@@ -682,3 +632,113 @@ func GetPodLogs(namespace, podName string, containerName ...string) (string, err
 	}
 	return buf.String(), err
 }
+
+// Given a namespace, the name of a pod, and the log options (container, since, follow,
+// previous), returns a stream of the pod's logs, which the caller must close
+func StreamPodLogs(ctx context.Context, namespace, podName string, options *coreV1.PodLogOptions) (io.ReadCloser, error) {
+	clientset, err := GetClientset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.CoreV1().Pods(namespace).GetLogs(podName, options).Stream(common.CallContext(ctx))
+}
+
+// Given a namespace and the name of a deployment, return the number of replicas in its spec
+func GetDeploymentReplicas(ctx context.Context, namespace, name string) (replicas int, err error) {
+	clientset, err := GetClientset(ctx)
+	if err != nil {
+		return
+	}
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(common.CallContext(ctx), name, v1.GetOptions{})
+	if err != nil {
+		return
+	} else if deployment.Spec.Replicas == nil {
+		// Kubernetes defaults this to 1
+		return 1, nil
+	}
+	return int(*deployment.Spec.Replicas), nil
+}
+
+// Given a namespace and the name of a deployment, set the number of replicas in its spec
+func SetDeploymentReplicas(ctx context.Context, namespace, name string, replicas int) error {
+	common.Infof(ctx, "Scaling deployment %s in namespace %s to %d replicas", name, namespace, replicas)
+	clientset, err := GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(common.CallContext(ctx), name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	count := int32(replicas)
+	deployment.Spec.Replicas = &count
+	_, err = clientset.AppsV1().Deployments(namespace).Update(common.CallContext(ctx), deployment, v1.UpdateOptions{})
+	return err
+}
+
+// Given a namespace and the name of a deployment, return the value of the specified annotation,
+// and whether the deployment has it
+func GetDeploymentAnnotation(ctx context.Context, namespace, name, key string) (value string, found bool, err error) {
+	clientset, err := GetClientset(ctx)
+	if err != nil {
+		return
+	}
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(common.CallContext(ctx), name, v1.GetOptions{})
+	if err != nil {
+		return
+	}
+	value, found = deployment.Annotations[key]
+	return
+}
+
+// Given a namespace and the name of a deployment, set the specified annotation, or remove it if
+// value is empty
+func SetDeploymentAnnotation(ctx context.Context, namespace, name, key, value string) error {
+	clientset, err := GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(common.CallContext(ctx), name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if len(value) == 0 {
+		delete(deployment.Annotations, key)
+	} else {
+		if deployment.Annotations == nil {
+			deployment.Annotations = map[string]string{}
+		}
+		deployment.Annotations[key] = value
+	}
+	_, err = clientset.AppsV1().Deployments(namespace).Update(common.CallContext(ctx), deployment, v1.UpdateOptions{})
+	return err
+}
+
+// Given a namespace and the name of a deployment which is being scaled down to 0 replicas, wait
+// (up to the specified time) until none of its pods remain, including ones which are terminating
+func WaitForDeploymentScaledDown(ctx context.Context, namespace, name string, timeout time.Duration) error {
+	clientset, err := GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	stopTime := time.Now().Add(timeout)
+	for {
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(common.CallContext(ctx), name, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		selector := v1.FormatLabelSelector(deployment.Spec.Selector)
+		pods, err := clientset.CoreV1().Pods(namespace).List(common.CallContext(ctx), v1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return err
+		} else if deployment.Status.Replicas == 0 && len(pods.Items) == 0 {
+			common.Infof(ctx, "Deployment %s in namespace %s has scaled down, and all of its pods have terminated", name, namespace)
+			return nil
+		} else if time.Now().After(stopTime) {
+			return fmt.Errorf("Deployment %s in namespace %s did not scale down within %v (%d replicas, %d pods remain)",
+				name, namespace, timeout, deployment.Status.Replicas, len(pods.Items))
+		}
+		common.Infof(ctx, "Waiting for deployment %s in namespace %s to scale down (%d pods remain)", name, namespace, len(pods.Items))
+		common.Sleep(ctx, RetryIntervalSeconds*time.Second)
+	}
+}
@@ -0,0 +1,269 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * bos.go
+ *
+ * Mock BOS v2 endpoints
+ *
+ */
+
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const bosV2 = "/apis/bos/v2"
+
+var bosVersion = record{"major": "2", "minor": "0", "patch": "0"}
+
+func (s *Server) addBOSRoutes() {
+	s.handle("GET /apis/bos/{$}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, []record{bosVersion})
+	})
+	for _, path := range []string{bosV2, bosV2 + "/version"} {
+		s.handle("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, bosVersion)
+		})
+	}
+	s.handle("GET "+bosV2+"/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, record{"db_status": "ok", "api_status": "ok"})
+	})
+	s.handle("GET "+bosV2+"/options", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, record{"cleanup_completed_session_ttl": "7d", "polling_frequency": 15})
+	})
+
+	s.handle("GET "+bosV2+"/components", anyTenant(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		writeJSON(w, http.StatusOK, s.bosComponents.list(nil))
+	}))
+	s.handle("GET "+bosV2+"/components/{id}", anyTenant(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		getRecord(w, s.bosComponents, r.PathValue("id"), "Component")
+	}))
+
+	s.handle("GET "+bosV2+"/sessiontemplatetemplate", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, record{
+			"name":       "name-your-template",
+			"enable_cfs": true,
+			"cfs":        record{"configuration": "default-config"},
+			"boot_sets": record{"name_your_boot_set": record{
+				"arch":              "X86",
+				"kernel_parameters": "your-kernel-parameters",
+				"node_roles_groups": []string{"Compute"},
+				"path":              "your-ims-path",
+				"type":              "s3",
+				"etag":              "your_boot_image_etag",
+			}},
+		})
+	})
+	s.handle("GET "+bosV2+"/sessiontemplates", anyTenant(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		writeJSON(w, http.StatusOK, s.bosSessionTemplates.list(ownedBy("tenant", tenant)))
+	}))
+	s.handle("GET "+bosV2+"/sessiontemplates/{name}", anyTenant(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		getRecord(w, s.bosSessionTemplates, tenantKey(tenant, r.PathValue("name")), "Session template")
+	}))
+	s.handle("PUT "+bosV2+"/sessiontemplates/{name}", s.tenanted(s.putBOSSessionTemplate))
+	s.handle("PATCH "+bosV2+"/sessiontemplates/{name}", s.tenanted(s.patchBOSSessionTemplate))
+	s.handle("DELETE "+bosV2+"/sessiontemplates/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		deleteRecord(w, s.bosSessionTemplates, tenantKey(tenant, r.PathValue("name")), "Session template")
+	}))
+	s.handle("GET "+bosV2+"/sessiontemplatesvalid/{name}", anyTenant(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		if _, ok := s.bosSessionTemplates.get(tenantKey(tenant, r.PathValue("name"))); !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Session template '%s' does not exist", r.PathValue("name")))
+			return
+		}
+		writeJSON(w, http.StatusOK, "Valid")
+	}))
+
+	s.handle("GET "+bosV2+"/sessions", anyTenant(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		writeJSON(w, http.StatusOK, s.bosSessions.list(ownedBy("tenant", tenant)))
+	}))
+	s.handle("GET "+bosV2+"/sessions/{name}", anyTenant(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		getRecord(w, s.bosSessions, tenantKey(tenant, r.PathValue("name")), "Session")
+	}))
+	s.handle("GET "+bosV2+"/sessions/{name}/status", anyTenant(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		session, ok := s.bosSessions.get(tenantKey(tenant, r.PathValue("name")))
+		if !ok {
+			writeError(w, http.StatusNotFound, "Session does not exist")
+			return
+		}
+		writeJSON(w, http.StatusOK, bosSessionExtendedStatus(session))
+	}))
+	s.handle("POST "+bosV2+"/sessions", s.tenanted(s.createBOSSession))
+	s.handle("DELETE "+bosV2+"/sessions/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		deleteRecord(w, s.bosSessions, tenantKey(tenant, r.PathValue("name")), "Session")
+	}))
+}
+
+// Wraps a handler for an endpoint which does not check that the tenant exists. BOS only
+// checks the tenant on requests which make changes.
+func anyTenant(handler func(w http.ResponseWriter, r *http.Request, tenant string)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, r.Header.Get(tenantHeader))
+	}
+}
+
+// BOS records with the same name can belong to different tenants, so they are stored by
+// tenant and name
+func tenantKey(tenant, name string) string {
+	return tenant + "/" + name
+}
+
+// Returns a filter for the records that the tenant can list. Requests without a tenant
+// can list every record.
+func ownedBy(tenantField, tenant string) func(record) bool {
+	if len(tenant) == 0 {
+		return nil
+	}
+	return func(rec record) bool { return rec.str(tenantField) == tenant }
+}
+
+func getRecord(w http.ResponseWriter, c *collection, key, kind string) {
+	rec, ok := c.get(key)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s does not exist", kind))
+		return
+	}
+	writeJSON(w, http.StatusOK, rec)
+}
+
+func deleteRecord(w http.ResponseWriter, c *collection, key, kind string) {
+	if !c.remove(key) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s does not exist", kind))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) putBOSSessionTemplate(w http.ResponseWriter, r *http.Request, tenant string) {
+	template, ok := readRecord(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := template["boot_sets"].(map[string]interface{}); !ok {
+		writeError(w, http.StatusBadRequest, "Session template must have boot_sets")
+		return
+	}
+	template["name"] = r.PathValue("name")
+	template["tenant"] = tenant
+	s.bosSessionTemplates.put(tenantKey(tenant, r.PathValue("name")), template)
+	writeJSON(w, http.StatusOK, template)
+}
+
+func (s *Server) patchBOSSessionTemplate(w http.ResponseWriter, r *http.Request, tenant string) {
+	template, ok := s.bosSessionTemplates.get(tenantKey(tenant, r.PathValue("name")))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Session template does not exist")
+		return
+	}
+	update, ok := readRecord(w, r)
+	if !ok {
+		return
+	}
+	delete(update, "name")
+	delete(update, "tenant")
+	for field, value := range update {
+		template[field] = value
+	}
+	writeJSON(w, http.StatusOK, template)
+}
+
+func (s *Server) createBOSSession(w http.ResponseWriter, r *http.Request, tenant string) {
+	request, ok := readRecord(w, r)
+	if !ok {
+		return
+	}
+	name := request.str("name")
+	if len(name) == 0 {
+		name = newID()
+	}
+	operation := request.str("operation")
+	if operation != "boot" && operation != "reboot" && operation != "shutdown" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid operation: '%s'", operation))
+		return
+	}
+	templateName := request.str("template_name")
+	template, ok := s.bosSessionTemplates.get(tenantKey(tenant, templateName))
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Session template '%s' does not exist", templateName))
+		return
+	}
+	key := tenantKey(tenant, name)
+	if _, ok := s.bosSessions.get(key); ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Session '%s' already exists", name))
+		return
+	}
+	stage, _ := request["stage"].(bool)
+	includeDisabled, _ := request["include_disabled"].(bool)
+	session := record{
+		"name":             name,
+		"operation":        operation,
+		"template_name":    templateName,
+		"limit":            request.str("limit"),
+		"stage":            stage,
+		"components":       "",
+		"include_disabled": includeDisabled,
+		"tenant":           tenant,
+		"status":           record{"status": "pending", "start_time": timestamp()},
+	}
+	if node, ok := s.hsmComponents.get(request.str("limit")); ok && !stage {
+		s.bootNode(session, template, node.str("ID"))
+	}
+	s.bosSessions.put(key, session)
+	writeJSON(w, http.StatusCreated, session)
+}
+
+// The mock has no operator to run sessions, but a session limited to a single node which is
+// known to HSM completes as soon as it is created, as if the node booted successfully. If the
+// template enables CFS, its configuration becomes the desired configuration of the node.
+func (s *Server) bootNode(session, template record, xname string) {
+	if enableCFS, _ := template["enable_cfs"].(bool); enableCFS {
+		if component, ok := s.cfsComponents.get(xname); ok {
+			cfs, _ := template["cfs"].(map[string]interface{})
+			component["desired_config"] = record(cfs).str("configuration")
+		}
+	}
+	session["components"] = xname
+	session["status"] = record{"status": "complete", "start_time": timestamp(), "end_time": timestamp(), "error": nil}
+}
+
+// Returns the extended status of a session, with no errors, and every node succeeded if the
+// session is complete
+func bosSessionExtendedStatus(session record) record {
+	status, _ := session["status"].(record)
+	complete := status.str("status") == "complete"
+	percentSuccessful := 0
+	if complete {
+		percentSuccessful = 100
+	}
+	return record{
+		"status":             status.str("status"),
+		"phases":             record{"percent_complete": percentSuccessful},
+		"percent_successful": percentSuccessful,
+		"percent_failed":     0,
+		"percent_staged":     0,
+		"error_summary":      record{},
+		"timing":             record{"start_time": status["start_time"], "end_time": status["end_time"]},
+	}
+}
@@ -0,0 +1,479 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * cfs.go
+ *
+ * Mock CFS v2 and v3 endpoints
+ *
+ */
+
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const cfsV2 = "/apis/cfs/v2"
+const cfsV3 = "/apis/cfs/v3"
+
+var cfsVersion = record{"major": "1", "minor": "0", "patch": "0"}
+
+func (s *Server) addCFSRoutes() {
+	for _, path := range []string{"/apis/cfs/{$}", "/apis/cfs/versions", cfsV2, cfsV3} {
+		s.handle("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, cfsVersion)
+		})
+	}
+	s.handle("GET /apis/cfs/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, record{"db_status": "ok", "kafka_status": "ok"})
+	})
+	for _, path := range []string{cfsV2 + "/options", cfsV3 + "/options"} {
+		s.handle("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, s.cfsOptions)
+		})
+	}
+	s.handle("PATCH "+cfsV3+"/options", s.patchCFSOptions)
+
+	// v2 has no tenant support, and no paging
+	s.handle("GET "+cfsV2+"/components", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.cfsComponents.list(nil))
+	})
+	s.handle("GET "+cfsV2+"/components/{id}", func(w http.ResponseWriter, r *http.Request) {
+		getRecord(w, s.cfsComponents, r.PathValue("id"), "Component")
+	})
+	s.handle("GET "+cfsV2+"/sessions", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.cfsSessions.list(sessionFilter(r, "")))
+	})
+	s.handle("POST "+cfsV2+"/sessions", func(w http.ResponseWriter, r *http.Request) {
+		if session, ok := s.createCFSSession(w, r, "", false); ok {
+			writeJSON(w, http.StatusOK, session)
+		}
+	})
+	s.handle("DELETE "+cfsV2+"/sessions", func(w http.ResponseWriter, r *http.Request) {
+		for _, session := range s.cfsSessions.list(sessionFilter(r, "")) {
+			s.cfsSessions.remove(session.str("name"))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("GET "+cfsV2+"/sessions/{name}", func(w http.ResponseWriter, r *http.Request) {
+		getRecord(w, s.cfsSessions, r.PathValue("name"), "Session")
+	})
+	s.handle("DELETE "+cfsV2+"/sessions/{name}", func(w http.ResponseWriter, r *http.Request) {
+		deleteRecord(w, s.cfsSessions, r.PathValue("name"), "Session")
+	})
+	s.handle("GET "+cfsV2+"/configurations", func(w http.ResponseWriter, r *http.Request) {
+		configurations := []record{}
+		for _, configuration := range s.cfsConfigurations.list(nil) {
+			configurations = append(configurations, v2Configuration(configuration))
+		}
+		writeJSON(w, http.StatusOK, configurations)
+	})
+	s.handle("GET "+cfsV2+"/configurations/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if configuration, ok := s.cfsConfigurations.get(r.PathValue("name")); ok {
+			writeJSON(w, http.StatusOK, v2Configuration(configuration))
+			return
+		}
+		writeError(w, http.StatusNotFound, "Configuration does not exist")
+	})
+	s.handle("PUT "+cfsV2+"/configurations/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if configuration, ok := s.putCFSConfiguration(w, r, "", false); ok {
+			writeJSON(w, http.StatusOK, v2Configuration(configuration))
+		}
+	})
+	s.handle("DELETE "+cfsV2+"/configurations/{name}", func(w http.ResponseWriter, r *http.Request) {
+		deleteRecord(w, s.cfsConfigurations, r.PathValue("name"), "Configuration")
+	})
+
+	// v3 rejects unknown tenants, and pages its lists
+	s.handle("GET "+cfsV3+"/components", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		writePage(w, r, "components", s.cfsComponents.list(componentFilter(r)), "id")
+	}))
+	s.handle("GET "+cfsV3+"/components/{id}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		getRecord(w, s.cfsComponents, r.PathValue("id"), "Component")
+	}))
+	s.handle("PATCH "+cfsV3+"/components/{id}", s.tenanted(s.patchCFSComponent))
+	s.handle("GET "+cfsV3+"/sessions", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		writePage(w, r, "sessions", s.cfsSessions.list(sessionFilter(r, tenant)), "name")
+	}))
+	s.handle("POST "+cfsV3+"/sessions", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		if session, ok := s.createCFSSession(w, r, tenant, true); ok {
+			writeJSON(w, http.StatusCreated, session)
+		}
+	}))
+	s.handle("DELETE "+cfsV3+"/sessions", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		deleted := []string{}
+		for _, session := range s.cfsSessions.list(sessionFilter(r, tenant)) {
+			s.cfsSessions.remove(session.str("name"))
+			deleted = append(deleted, session.str("name"))
+		}
+		writeJSON(w, http.StatusOK, record{"session_ids": deleted})
+	}))
+	s.handle("GET "+cfsV3+"/sessions/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		getRecord(w, s.cfsSessions, r.PathValue("name"), "Session")
+	}))
+	s.handle("DELETE "+cfsV3+"/sessions/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		deleteRecord(w, s.cfsSessions, r.PathValue("name"), "Session")
+	}))
+	s.handle("GET "+cfsV3+"/configurations", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		writePage(w, r, "configurations", s.cfsConfigurations.list(ownedBy("tenant_name", tenant)), "name")
+	}))
+	s.handle("GET "+cfsV3+"/configurations/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		getRecord(w, s.cfsConfigurations, r.PathValue("name"), "Configuration")
+	}))
+	s.handle("PUT "+cfsV3+"/configurations/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		if configuration, ok := s.putCFSConfiguration(w, r, tenant, true); ok {
+			writeJSON(w, http.StatusOK, configuration)
+		}
+	}))
+	s.handle("DELETE "+cfsV3+"/configurations/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		if configuration, ok := s.cfsConfigurations.get(r.PathValue("name")); ok && !canModify(configuration, tenant) {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("Configuration is not owned by tenant '%s'", tenant))
+			return
+		}
+		deleteRecord(w, s.cfsConfigurations, r.PathValue("name"), "Configuration")
+	}))
+	s.handle("GET "+cfsV3+"/sources", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		writePage(w, r, "sources", s.cfsSources.list(nil), "name")
+	}))
+	s.handle("GET "+cfsV3+"/sources/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		getRecord(w, s.cfsSources, r.PathValue("name"), "Source")
+	}))
+	s.handle("POST "+cfsV3+"/sources", s.tenanted(s.createCFSSource))
+	s.handle("PATCH "+cfsV3+"/sources/{name}", s.tenanted(s.patchCFSSource))
+	s.handle("DELETE "+cfsV3+"/sources/{name}", s.tenanted(func(w http.ResponseWriter, r *http.Request, tenant string) {
+		deleteRecord(w, s.cfsSources, r.PathValue("name"), "Source")
+	}))
+}
+
+// Write one page of a CFS v3 list
+func writePage(w http.ResponseWriter, r *http.Request, listName string, recs []record, idField string) {
+	page, next, ok := pageRecords(w, r, recs, idField)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, record{listName: page, "next": next})
+}
+
+// Returns a filter for the sessions owned by the tenant (all of them, if there is no tenant)
+// which match the status and name_contains query parameters of the request
+func sessionFilter(r *http.Request, tenant string) func(record) bool {
+	owned := ownedBy("tenant_name", tenant)
+	status, nameContains := r.URL.Query().Get("status"), r.URL.Query().Get("name_contains")
+	return func(session record) bool {
+		if owned != nil && !owned(session) {
+			return false
+		} else if !strings.Contains(session.str("name"), nameContains) {
+			return false
+		}
+		return len(status) == 0 || sessionStatus(session) == status
+	}
+}
+
+// Returns the status of a session. The mock has no operator to run sessions, so only image
+// customization sessions (which complete as soon as they are created) leave the pending status.
+func sessionStatus(session record) string {
+	if status, ok := session["status"].(map[string]interface{}); ok {
+		if sessionStatus, ok := status["session"].(map[string]interface{}); ok {
+			value, _ := sessionStatus["status"].(string)
+			return value
+		}
+	}
+	return ""
+}
+
+// Create a session. The v2 request names its configuration with configurationName, and v3
+// with configuration_name. If the request is not valid, an error response is written and
+// false is returned.
+func (s *Server) createCFSSession(w http.ResponseWriter, r *http.Request, tenant string, v3 bool) (session record, ok bool) {
+	request, ok := readRecord(w, r)
+	if !ok {
+		return nil, false
+	}
+	name, configurationName := request.str("name"), request.str("configurationName")
+	if v3 {
+		configurationName = request.str("configuration_name")
+	}
+	if len(name) == 0 || len(configurationName) == 0 {
+		writeError(w, http.StatusBadRequest, "Session must have a name and configuration name")
+		return nil, false
+	} else if _, ok := s.cfsSessions.get(name); ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Session '%s' already exists", name))
+		return nil, false
+	} else if _, ok := s.cfsConfigurations.get(configurationName); !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Configuration '%s' does not exist", configurationName))
+		return nil, false
+	}
+	session = record{
+		"name":          name,
+		"configuration": map[string]interface{}{"name": configurationName},
+		"target":        request["target"],
+		"status":        map[string]interface{}{"session": map[string]interface{}{"status": "pending"}},
+		"tenant_name":   tenant,
+	}
+	if target, ok := request["target"].(map[string]interface{}); ok && target["definition"] == "image" {
+		session["status"] = s.customizeImages(target)
+	}
+	s.cfsSessions.put(name, session)
+	return session, true
+}
+
+// Complete an image customization session right away, by copying each source image in the
+// image map of its target to a result image. Returns the status of the completed session.
+func (s *Server) customizeImages(target map[string]interface{}) map[string]interface{} {
+	succeeded := "true"
+	artifacts := []interface{}{}
+	imageMap, _ := target["image_map"].([]interface{})
+	for _, rawMapping := range imageMap {
+		mapping, _ := rawMapping.(map[string]interface{})
+		sourceID := record(mapping).str("source_id")
+		source, ok := s.imsImages.get(sourceID)
+		if !ok {
+			succeeded = "false"
+			continue
+		}
+		resultID := newID()
+		s.imsImages.put(resultID, record{
+			"id":      resultID,
+			"name":    optionalString(mapping, "result_name", source.str("name")),
+			"arch":    source["arch"],
+			"created": timestamp(),
+			"link": map[string]interface{}{
+				"path": "s3://boot-images/" + resultID + "/manifest.json",
+				"etag": "d41d8cd98f00b204e9800998ecf8427e",
+				"type": "s3",
+			},
+			"metadata": map[string]interface{}{},
+		})
+		artifacts = append(artifacts, record{"image_id": sourceID, "result_id": resultID, "type": "ims_customized_image"})
+	}
+	return map[string]interface{}{
+		"artifacts": artifacts,
+		"session": map[string]interface{}{
+			"status":          "complete",
+			"succeeded":       succeeded,
+			"start_time":      timestamp(),
+			"completion_time": timestamp(),
+		},
+	}
+}
+
+// Returns a filter for the components which match the config_name query parameter of the
+// request, if it has one
+func componentFilter(r *http.Request) func(record) bool {
+	configName, ok := r.URL.Query()["config_name"]
+	if !ok {
+		return nil
+	}
+	return func(component record) bool { return component.str("desired_config") == configName[0] }
+}
+
+// Only the desired configuration and enabled fields of a component can be changed
+func (s *Server) patchCFSComponent(w http.ResponseWriter, r *http.Request, tenant string) {
+	component, ok := s.cfsComponents.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Component does not exist")
+		return
+	}
+	request, ok := readRecord(w, r)
+	if !ok {
+		return
+	}
+	for field := range request {
+		if field != "desired_config" && field != "enabled" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid field: '%s'", field))
+			return
+		}
+	}
+	for field, value := range request {
+		component[field] = value
+	}
+	writeJSON(w, http.StatusOK, component)
+}
+
+// Only default_page_size can be changed
+func (s *Server) patchCFSOptions(w http.ResponseWriter, r *http.Request) {
+	request, ok := readRecord(w, r)
+	if !ok {
+		return
+	}
+	for field, value := range request {
+		pageSize, isNumber := value.(float64)
+		if field != "default_page_size" || !isNumber || pageSize < 1 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid option: '%s'", field))
+			return
+		}
+		s.cfsOptions[field] = int(pageSize)
+	}
+	writeJSON(w, http.StatusOK, s.cfsOptions)
+}
+
+// Tenants can only modify the configurations which they own
+func canModify(configuration record, tenant string) bool {
+	return len(tenant) == 0 || configuration.str("tenant_name") == tenant
+}
+
+// Create or replace a configuration. Layers are stored in the v3 form (with clone_url rather
+// than cloneUrl). Tenants can only create configurations for themselves, but an admin can set
+// the tenant_name of a configuration to any tenant (v3 only). If the request is not valid, an error response
+// is written and false is returned.
+func (s *Server) putCFSConfiguration(w http.ResponseWriter, r *http.Request, tenant string, v3 bool) (configuration record, ok bool) {
+	request, ok := readRecord(w, r)
+	if !ok {
+		return nil, false
+	}
+	name := r.PathValue("name")
+	layers, ok := request["layers"].([]interface{})
+	if !ok || len(layers) == 0 {
+		writeError(w, http.StatusBadRequest, "Configuration must have at least one layer")
+		return nil, false
+	}
+	for _, rawLayer := range layers {
+		layer, ok := rawLayer.(map[string]interface{})
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Configuration layers must be objects")
+			return nil, false
+		}
+		if cloneURL, ok := layer["cloneUrl"]; ok {
+			layer["clone_url"] = cloneURL
+			delete(layer, "cloneUrl")
+		}
+	}
+
+	owner := tenant
+	if requestedOwner, ok := request["tenant_name"].(string); ok && v3 {
+		if len(tenant) > 0 && requestedOwner != tenant {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("Tenant '%s' cannot create configurations for another tenant", tenant))
+			return nil, false
+		}
+		owner = requestedOwner
+	}
+	if existing, ok := s.cfsConfigurations.get(name); ok && !canModify(existing, tenant) {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("Configuration is not owned by tenant '%s'", tenant))
+		return nil, false
+	}
+
+	configuration = record{
+		"name":         name,
+		"description":  request.str("description"),
+		"last_updated": timestamp(),
+		"layers":       layers,
+		"tenant_name":  owner,
+	}
+	s.cfsConfigurations.put(name, configuration)
+	return configuration, true
+}
+
+// Returns the v2 form of a configuration
+func v2Configuration(configuration record) record {
+	layers := []interface{}{}
+	for _, rawLayer := range configuration["layers"].([]interface{}) {
+		layer := record{}
+		for field, value := range rawLayer.(map[string]interface{}) {
+			if field == "clone_url" {
+				field = "cloneUrl"
+			}
+			layer[field] = value
+		}
+		layers = append(layers, layer)
+	}
+	return record{
+		"name":        configuration["name"],
+		"description": configuration["description"],
+		"lastUpdated": configuration["last_updated"],
+		"layers":      layers,
+	}
+}
+
+// The credentials of a source are kept in a Kubernetes secret, so they are not returned.
+// Only the name of the secret is.
+func sourceCredentials(name string, request record) (credentials record, ok bool) {
+	requested, ok := request["credentials"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	method, _ := requested["authentication_method"].(string)
+	if method != "password" {
+		return nil, false
+	}
+	return record{"authentication_method": method, "secret_name": "cfs-source-" + name}, true
+}
+
+func (s *Server) createCFSSource(w http.ResponseWriter, r *http.Request, tenant string) {
+	request, ok := readRecord(w, r)
+	if !ok {
+		return
+	}
+	name, cloneURL := request.str("name"), request.str("clone_url")
+	if len(name) == 0 || len(cloneURL) == 0 {
+		writeError(w, http.StatusBadRequest, "Source must have a name and clone_url")
+		return
+	} else if _, ok := s.cfsSources.get(name); ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Source '%s' already exists", name))
+		return
+	}
+	credentials, ok := sourceCredentials(name, request)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Source must have password credentials")
+		return
+	}
+	source := record{
+		"name":         name,
+		"description":  request.str("description"),
+		"clone_url":    cloneURL,
+		"credentials":  credentials,
+		"last_updated": timestamp(),
+	}
+	s.cfsSources.put(name, source)
+	writeJSON(w, http.StatusCreated, source)
+}
+
+func (s *Server) patchCFSSource(w http.ResponseWriter, r *http.Request, tenant string) {
+	name := r.PathValue("name")
+	source, ok := s.cfsSources.get(name)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Source does not exist")
+		return
+	}
+	request, ok := readRecord(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := request["credentials"]; ok {
+		credentials, ok := sourceCredentials(name, request)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Source must have password credentials")
+			return
+		}
+		source["credentials"] = credentials
+	}
+	for _, field := range []string{"clone_url", "description"} {
+		if value, ok := request[field].(string); ok {
+			source[field] = value
+		}
+	}
+	source["last_updated"] = timestamp()
+	writeJSON(w, http.StatusOK, source)
+}
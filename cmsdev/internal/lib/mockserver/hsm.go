@@ -0,0 +1,82 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * hsm.go
+ *
+ * Mock HSM v2 component state endpoints, which the barebones image boot test uses to find
+ * compute nodes
+ *
+ */
+
+package mockserver
+
+import (
+	"net/http"
+	"strings"
+)
+
+const hsmV2 = "/apis/smd/hsm/v2"
+
+func (s *Server) addHSMRoutes() {
+	s.handle("GET "+hsmV2+"/State/Components", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, record{"Components": s.hsmComponents.list(hsmComponentFilter(r))})
+	})
+	s.handle("GET "+hsmV2+"/State/Components/{xname}", func(w http.ResponseWriter, r *http.Request) {
+		getRecord(w, s.hsmComponents, r.PathValue("xname"), "Component")
+	})
+}
+
+// Returns a filter for the components which match the type, role, enabled, and arch query
+// parameters of the request. Each parameter may be given more than once, to match any of the
+// values, and values are not case sensitive.
+func hsmComponentFilter(r *http.Request) func(record) bool {
+	query := r.URL.Query()
+	return func(component record) bool {
+		for parameter, field := range map[string]string{"type": "Type", "role": "Role", "enabled": "Enabled", "arch": "Arch"} {
+			values, ok := query[parameter]
+			if !ok {
+				continue
+			}
+			matched := false
+			for _, value := range values {
+				if strings.EqualFold(value, hsmFieldString(component, field)) {
+					matched = true
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func hsmFieldString(component record, field string) string {
+	if enabled, ok := component[field].(bool); ok {
+		if enabled {
+			return "true"
+		}
+		return "false"
+	}
+	return component.str(field)
+}
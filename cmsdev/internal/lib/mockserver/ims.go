@@ -0,0 +1,254 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * ims.go
+ *
+ * Mock IMS v2 and v3 endpoints. IMS does not check tenants.
+ *
+ */
+
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// An IMS record type, and how to create and update its records
+type imsKind struct {
+	path    string
+	records *collection
+	create  func(request record) (rec record, err error)
+	// nil if the records cannot be updated
+	update func(rec, request record) error
+}
+
+func (s *Server) addIMSRoutes() {
+	kinds := []imsKind{
+		{path: "images", records: s.imsImages, create: newIMSImage, update: updateIMSImage},
+		{path: "recipes", records: s.imsRecipes, create: newIMSRecipe, update: updateIMSRecipe},
+		{path: "public-keys", records: s.imsPublicKeys, create: newIMSPublicKey},
+	}
+	// The unversioned endpoints are the latest version
+	for _, prefix := range []string{"/apis/ims", "/apis/ims/v2", "/apis/ims/v3"} {
+		for _, kind := range kinds {
+			s.addIMSKindRoutes(prefix, kind)
+		}
+		s.handle("GET "+prefix+"/jobs", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, s.imsJobs.list(nil))
+		})
+		s.handle("GET "+prefix+"/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+			getRecord(w, s.imsJobs, r.PathValue("id"), "Job")
+		})
+		s.handle("GET "+prefix+"/version", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, record{"version": "3.0.0"})
+		})
+		for _, probe := range []string{"live", "ready"} {
+			s.handle("GET "+prefix+"/healthz/"+probe, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, record{})
+			})
+		}
+	}
+}
+
+func (s *Server) addIMSKindRoutes(prefix string, kind imsKind) {
+	base := prefix + "/" + kind.path
+	deletedBase := prefix + "/deleted/" + kind.path
+
+	s.handle("GET "+base, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, kind.records.list(nil))
+	})
+	s.handle("POST "+base, func(w http.ResponseWriter, r *http.Request) {
+		request, ok := readRecord(w, r)
+		if !ok {
+			return
+		}
+		rec, err := kind.create(request)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		rec["id"] = newID()
+		rec["created"] = timestamp()
+		kind.records.put(rec.str("id"), rec)
+		writeJSON(w, http.StatusCreated, rec)
+	})
+	// Deleting records, individually or all at once, soft deletes them
+	s.handle("DELETE "+base, func(w http.ResponseWriter, r *http.Request) {
+		for _, rec := range kind.records.list(nil) {
+			kind.records.softDelete(rec.str("id"))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("GET "+base+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		getRecord(w, kind.records, r.PathValue("id"), "Record")
+	})
+	s.handle("DELETE "+base+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if !kind.records.softDelete(r.PathValue("id")) {
+			writeError(w, http.StatusNotFound, "Record does not exist")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if kind.update != nil {
+		s.handle("PATCH "+base+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+			rec, ok := kind.records.get(r.PathValue("id"))
+			if !ok {
+				writeError(w, http.StatusNotFound, "Record does not exist")
+				return
+			}
+			request, ok := readRecord(w, r)
+			if !ok {
+				return
+			}
+			if err := kind.update(rec, request); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			writeJSON(w, http.StatusOK, rec)
+		})
+	}
+
+	s.handle("GET "+deletedBase, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, kind.records.listDeleted())
+	})
+	s.handle("GET "+deletedBase+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		rec, ok := kind.records.getDeleted(r.PathValue("id"))
+		if !ok {
+			writeError(w, http.StatusNotFound, "Deleted record does not exist")
+			return
+		}
+		writeJSON(w, http.StatusOK, rec)
+	})
+	s.handle("PATCH "+deletedBase+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		request, ok := readRecord(w, r)
+		if !ok {
+			return
+		} else if operation := request.str("operation"); operation != "undelete" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid operation: '%s'", operation))
+			return
+		} else if !kind.records.undelete(r.PathValue("id")) {
+			writeError(w, http.StatusNotFound, "Deleted record does not exist")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle("DELETE "+deletedBase+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if !kind.records.removeDeleted(r.PathValue("id")) {
+			writeError(w, http.StatusNotFound, "Deleted record does not exist")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// Returns the value of an optional string field, or the default if the field is not set
+func optionalString(request record, field, defaultValue string) string {
+	if value := request.str(field); len(value) > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func newIMSImage(request record) (record, error) {
+	name := request.str("name")
+	if len(name) == 0 {
+		return nil, fmt.Errorf("Image must have a name")
+	}
+	image := record{
+		"name":     name,
+		"arch":     optionalString(request, "arch", "x86_64"),
+		"link":     request["link"],
+		"metadata": map[string]interface{}{},
+	}
+	// On create, metadata is a single key and value
+	if metadata, ok := request["metadata"].(map[string]interface{}); ok {
+		key, _ := metadata["key"].(string)
+		if len(key) == 0 {
+			return nil, fmt.Errorf("Image metadata must have a key")
+		}
+		image["metadata"] = map[string]interface{}{key: metadata["value"]}
+	}
+	return image, nil
+}
+
+func updateIMSImage(image, request record) error {
+	for _, field := range []string{"arch", "link"} {
+		if value, ok := request[field]; ok {
+			image[field] = value
+		}
+	}
+	// On update, metadata is an operation on a single key
+	if change, ok := request["metadata"].(map[string]interface{}); ok {
+		metadata, _ := image["metadata"].(map[string]interface{})
+		key, _ := change["key"].(string)
+		switch change["operation"] {
+		case "set":
+			metadata[key] = change["value"]
+		case "remove":
+			delete(metadata, key)
+		default:
+			return fmt.Errorf("Invalid metadata operation: '%v'", change["operation"])
+		}
+	}
+	return nil
+}
+
+func newIMSRecipe(request record) (record, error) {
+	name := request.str("name")
+	if len(name) == 0 {
+		return nil, fmt.Errorf("Recipe must have a name")
+	}
+	requireDKMS, _ := request["require_dkms"].(bool)
+	templateDictionary, _ := request["template_dictionary"].([]interface{})
+	if templateDictionary == nil {
+		templateDictionary = []interface{}{}
+	}
+	return record{
+		"name":                name,
+		"arch":                optionalString(request, "arch", "x86_64"),
+		"recipe_type":         request.str("recipe_type"),
+		"linux_distribution":  request.str("linux_distribution"),
+		"require_dkms":        requireDKMS,
+		"template_dictionary": templateDictionary,
+		"link":                request["link"],
+	}, nil
+}
+
+func updateIMSRecipe(recipe, request record) error {
+	for _, field := range []string{"arch", "link", "require_dkms", "template_dictionary"} {
+		if value, ok := request[field]; ok {
+			recipe[field] = value
+		}
+	}
+	return nil
+}
+
+func newIMSPublicKey(request record) (record, error) {
+	name, publicKey := request.str("name"), request.str("public_key")
+	if len(name) == 0 || len(publicKey) == 0 {
+		return nil, fmt.Errorf("Public key must have a name and public_key")
+	}
+	return record{"name": name, "public_key": publicKey}, nil
+}
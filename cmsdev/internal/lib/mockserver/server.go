@@ -0,0 +1,229 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * server.go
+ *
+ * In-memory implementations of the BOS, CFS, and IMS endpoints which cmsdev tests, and of the
+ * HSM endpoints which its tests use, so that the tests can be run without a system (see the
+ * mockserver command and the base_url setting)
+ *
+ */
+
+package mockserver
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+const tenantHeader = "Cray-Tenant-Name"
+const requestIDHeader = "X-Request-ID"
+
+// Server holds the records of the mock services. All requests are handled while holding
+// its lock, so handlers do not need to do any locking of their own.
+type Server struct {
+	lock    sync.Mutex
+	mux     *http.ServeMux
+	tenants map[string]bool
+
+	bosComponents, bosSessionTemplates, bosSessions           *collection
+	cfsComponents, cfsConfigurations, cfsSessions, cfsSources *collection
+	imsImages, imsRecipes, imsPublicKeys, imsJobs             *collection
+	hsmComponents                                             *collection
+
+	cfsOptions record
+}
+
+// New returns a mock server which knows the specified tenants. Requests made on behalf of
+// any other tenant are rejected by the services which check tenants, the way they are on
+// a system where that tenant does not exist.
+func New(tenants []string) *Server {
+	s := &Server{
+		mux:                 http.NewServeMux(),
+		tenants:             make(map[string]bool),
+		bosComponents:       newCollection(),
+		bosSessionTemplates: newCollection(),
+		bosSessions:         newCollection(),
+		cfsComponents:       newCollection(),
+		cfsConfigurations:   newCollection(),
+		cfsSessions:         newCollection(),
+		cfsSources:          newCollection(),
+		imsImages:           newCollection(),
+		imsRecipes:          newCollection(),
+		imsPublicKeys:       newCollection(),
+		imsJobs:             newCollection(),
+		hsmComponents:       newCollection(),
+		cfsOptions:          record{"default_playbook": "site.yml", "session_ttl": "7d", "default_page_size": 1000},
+	}
+	for _, tenant := range tenants {
+		s.tenants[tenant] = true
+	}
+	s.addBOSRoutes()
+	s.addCFSRoutes()
+	s.addIMSRoutes()
+	s.addHSMRoutes()
+	s.seed()
+	return s
+}
+
+// Handler returns the HTTP handler which serves the mock services
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe serves the mock services on the specified address until an error occurs
+func (s *Server) ListenAndServe(address string) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	return server.ListenAndServe()
+}
+
+// Records which the tests expect to find on a system, rather than creating themselves
+func (s *Server) seed() {
+	for xname, arch := range map[string]string{"x3000c0s17b1n0": "X86", "x3000c0s17b2n0": "ARM"} {
+		s.bosComponents.put(xname, record{"id": xname, "enabled": true, "status": map[string]interface{}{"phase": ""}})
+		s.cfsComponents.put(xname, record{"id": xname, "enabled": true, "configuration_status": "configured", "desired_config": ""})
+		s.hsmComponents.put(xname, record{"ID": xname, "Type": "Node", "Role": "Compute", "State": "Ready", "Enabled": true, "Arch": arch})
+	}
+	// These match the image IDs in the dummy product catalog data, which the BOS tests use
+	// when there is no product catalog to read (see prod_catalog_utils.go)
+	for id, arch := range map[string]string{
+		"8ceeac2a-221e-470d-ae49-18e648b96999": "aarch64",
+		"870d6bce-a626-48a2-98d3-4fe628e90999": "x86_64",
+	} {
+		s.imsImages.put(id, record{
+			"id":      id,
+			"name":    "compute-csm-1.7-7.1.37-" + arch,
+			"arch":    arch,
+			"created": timestamp(),
+			"link": map[string]interface{}{
+				"path": "s3://boot-images/" + id + "/manifest.json",
+				"etag": "d41d8cd98f00b204e9800998ecf8427e",
+				"type": "s3",
+			},
+			"metadata": map[string]interface{}{},
+		})
+	}
+}
+
+// Register a handler for the specified method and path pattern (see http.ServeMux). The
+// handler is called while holding the server lock, and each request is logged.
+func (s *Server) handle(pattern string, handler func(w http.ResponseWriter, r *http.Request)) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		lw := &loggingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		handler(lw, r)
+		request := r.Method + " " + r.URL.RequestURI()
+		if tenant := r.Header.Get(tenantHeader); len(tenant) > 0 {
+			request += fmt.Sprintf(" (tenant: %s)", tenant)
+		}
+		if requestID := r.Header.Get(requestIDHeader); len(requestID) > 0 {
+			request += fmt.Sprintf(" (request ID: %s)", requestID)
+		}
+		common.Infof(ctx, "%s: %d", request, lw.status)
+	})
+}
+
+// Records the response status, for logging
+type loggingResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (lw *loggingResponseWriter) WriteHeader(status int) {
+	lw.status = status
+	lw.ResponseWriter.WriteHeader(status)
+}
+
+// Returns the tenant which the request is made on behalf of ("" if none). If that tenant
+// is not known, a 400 response is written and false is returned.
+func (s *Server) requestTenant(w http.ResponseWriter, r *http.Request) (tenant string, ok bool) {
+	tenant = r.Header.Get(tenantHeader)
+	if len(tenant) > 0 && !s.tenants[tenant] {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Tenant '%s' does not exist", tenant))
+		return tenant, false
+	}
+	return tenant, true
+}
+
+// Wraps a handler for an endpoint which rejects requests made on behalf of unknown tenants
+func (s *Server) tenanted(handler func(w http.ResponseWriter, r *http.Request, tenant string)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if tenant, ok := s.requestTenant(w, r); ok {
+			handler(w, r, tenant)
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Write an error response, in the problem details form that the services use
+func writeError(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":   "about:blank",
+		"title":  http.StatusText(status),
+		"status": status,
+		"detail": detail,
+	})
+}
+
+// Decode the JSON object in the request body. If it is not valid, a 400 response is
+// written and false is returned.
+func readRecord(w http.ResponseWriter, r *http.Request) (body record, ok bool) {
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body == nil {
+		writeError(w, http.StatusBadRequest, "The request body must be a JSON object")
+		return nil, false
+	}
+	return body, true
+}
+
+// Returns a random UUID, for the records whose IDs are assigned by the service
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
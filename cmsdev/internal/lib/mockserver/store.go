@@ -0,0 +1,170 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * store.go
+ *
+ * In-memory record storage for the mock services
+ *
+ */
+
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// A JSON object, as stored and returned by the mock services
+type record map[string]interface{}
+
+// Returns the string value of the specified field, or "" if it is not a string
+func (rec record) str(field string) string {
+	value, _ := rec[field].(string)
+	return value
+}
+
+// The records of one kind. Records which are soft deleted (IMS only) are moved to the
+// deleted map, from which they can be restored or permanently deleted.
+type collection struct {
+	live    map[string]record
+	deleted map[string]record
+}
+
+func newCollection() *collection {
+	return &collection{live: make(map[string]record), deleted: make(map[string]record)}
+}
+
+func (c *collection) get(key string) (rec record, ok bool) {
+	rec, ok = c.live[key]
+	return
+}
+
+func (c *collection) put(key string, rec record) {
+	c.live[key] = rec
+}
+
+// Permanently deletes a live record, returning false if there is none
+func (c *collection) remove(key string) bool {
+	if _, ok := c.live[key]; !ok {
+		return false
+	}
+	delete(c.live, key)
+	return true
+}
+
+// Moves a live record to the deleted records, returning false if there is none
+func (c *collection) softDelete(key string) bool {
+	rec, ok := c.live[key]
+	if !ok {
+		return false
+	}
+	delete(c.live, key)
+	c.deleted[key] = rec
+	return true
+}
+
+func (c *collection) getDeleted(key string) (rec record, ok bool) {
+	rec, ok = c.deleted[key]
+	return
+}
+
+// Permanently deletes a deleted record, returning false if there is none
+func (c *collection) removeDeleted(key string) bool {
+	if _, ok := c.deleted[key]; !ok {
+		return false
+	}
+	delete(c.deleted, key)
+	return true
+}
+
+// Moves a deleted record back to the live records, returning false if there is none
+func (c *collection) undelete(key string) bool {
+	rec, ok := c.deleted[key]
+	if !ok {
+		return false
+	}
+	delete(c.deleted, key)
+	c.live[key] = rec
+	return true
+}
+
+// Returns the live records for which include returns true (all of them, if include is
+// nil), sorted by key
+func (c *collection) list(include func(record) bool) []record {
+	return sortedRecords(c.live, include)
+}
+
+func (c *collection) listDeleted() []record {
+	return sortedRecords(c.deleted, nil)
+}
+
+func sortedRecords(records map[string]record, include func(record) bool) []record {
+	keys := make([]string, 0, len(records))
+	for key, rec := range records {
+		if include == nil || include(rec) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	recs := make([]record, 0, len(keys))
+	for _, key := range keys {
+		recs = append(recs, records[key])
+	}
+	return recs
+}
+
+// Returns one page of the records (which must be sorted by idField), using the limit and
+// after query parameters the way CFS v3 does. next is nil on the last page, and otherwise
+// holds the parameters for requesting the next page. If the query parameters are not valid,
+// a 400 response is written and false is returned.
+func pageRecords(w http.ResponseWriter, r *http.Request, recs []record, idField string) (page []record, next map[string]interface{}, ok bool) {
+	query := r.URL.Query()
+	if after := query.Get("after"); len(after) > 0 {
+		start := len(recs)
+		for i, rec := range recs {
+			if rec.str(idField) > after {
+				start = i
+				break
+			}
+		}
+		recs = recs[start:]
+	}
+	limitString := query.Get("limit")
+	if len(limitString) == 0 {
+		return recs, nil, true
+	}
+	limit, err := strconv.Atoi(limitString)
+	if err != nil || limit < 1 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid limit: '%s'", limitString))
+		return nil, nil, false
+	}
+	if len(recs) <= limit {
+		return recs, nil, true
+	}
+	page = recs[:limit]
+	next = map[string]interface{}{"limit": limit, "after": page[limit-1].str(idField)}
+	return page, next, true
+}
@@ -0,0 +1,445 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * spec.go
+ *
+ * The OpenAPI specs of the services which cmsdev tests. The endpoint catalog is derived from
+ * them, and API responses are validated against them.
+ *
+ */
+
+package openapi
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The specs built into cmsdev, one file per service, named for the service
+//
+//go:embed specs/*.yaml
+var embeddedSpecs embed.FS
+
+// Spec is the OpenAPI spec of a service
+type Spec struct {
+	// The service name, from the name of the spec file
+	Service string
+	Title   string
+	Version string
+	// The path of the service on the API gateway (for example, /apis/bos), from the first
+	// server URL in the spec
+	BasePath   string
+	Operations []*Operation
+	// The whole spec, for resolving references
+	doc map[string]interface{}
+}
+
+// Operation is one method of one path in a spec
+type Operation struct {
+	Service string
+	Method  string
+	// The path template, relative to the base path of the service (for example,
+	// /v2/sessions/{session_id})
+	Path    string
+	Summary string
+	// The response status codes in the spec, in order ("default" and ranges like "4XX" last)
+	StatusCodes []string
+	responses   map[string]interface{}
+	spec        *Spec
+}
+
+var embedded = mustLoadEmbeddedSpecs()
+var specs = embedded
+
+// Specs returns the loaded specs, sorted by service
+func Specs() []*Spec {
+	return sortedSpecs(specs)
+}
+
+// EmbeddedSpecs returns the specs built into cmsdev, sorted by service, whether or not other
+// specs have been loaded in their place
+func EmbeddedSpecs() []*Spec {
+	return sortedSpecs(embedded)
+}
+
+func sortedSpecs(specs map[string]*Spec) []*Spec {
+	sorted := make([]*Spec, 0, len(specs))
+	for _, spec := range specs {
+		sorted = append(sorted, spec)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Service < sorted[j].Service })
+	return sorted
+}
+
+// The embedded specs are part of cmsdev, so an error in one is a bug
+func mustLoadEmbeddedSpecs() map[string]*Spec {
+	loaded := make(map[string]*Spec)
+	files, err := embeddedSpecs.ReadDir("specs")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range files {
+		data, err := embeddedSpecs.ReadFile("specs/" + file.Name())
+		if err != nil {
+			panic(err)
+		}
+		spec, err := parseSpec(serviceName(file.Name()), data)
+		if err != nil {
+			panic(fmt.Sprintf("embedded OpenAPI spec %s: %v", file.Name(), err))
+		}
+		loaded[spec.Service] = spec
+	}
+	return loaded
+}
+
+// LoadDir loads the specs (named <service>.yaml, <service>.yml, or <service>.json) in the
+// specified directory, in place of the embedded specs for those services. Services without
+// a spec file in the directory keep their embedded spec. It returns the services whose specs
+// were loaded. Use common.LoadOpenAPIDir, which also rebuilds the endpoint catalog.
+func LoadDir(dir string) (services []string, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read OpenAPI spec directory: %v", err)
+	}
+	loaded := make(map[string]*Spec)
+	for service, spec := range specs {
+		loaded[service] = spec
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read OpenAPI spec: %v", err)
+		}
+		spec, err := parseSpec(serviceName(entry.Name()), data)
+		if err != nil {
+			return nil, fmt.Errorf("OpenAPI spec %s: %v", path, err)
+		}
+		loaded[spec.Service] = spec
+		services = append(services, spec.Service)
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("No OpenAPI spec files (*.yaml, *.yml, or *.json) found in %s", dir)
+	}
+	specs = loaded
+	sort.Strings(services)
+	return services, nil
+}
+
+func serviceName(fileName string) string {
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName))
+}
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Parse a YAML or JSON OpenAPI 3 spec
+func parseSpec(service string, data []byte) (*Spec, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	doc, ok := jsonValue(raw).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a YAML or JSON object")
+	}
+	if version, _ := doc["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("openapi version is '%v'; only OpenAPI 3 is supported", doc["openapi"])
+	}
+	spec := &Spec{Service: service, doc: doc}
+	info, _ := doc["info"].(map[string]interface{})
+	spec.Title, _ = info["title"].(string)
+	spec.Version, _ = info["version"].(string)
+	if servers, _ := doc["servers"].([]interface{}); len(servers) > 0 {
+		server, _ := servers[0].(map[string]interface{})
+		serverURL, _ := server["url"].(string)
+		parsed, err := url.Parse(serverURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server URL '%s': %v", serverURL, err)
+		}
+		spec.BasePath = strings.TrimSuffix(parsed.Path, "/")
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no paths")
+	}
+	for path, rawItem := range paths {
+		item, ok := spec.resolve(rawItem).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("path %s: not an object", path)
+		}
+		for _, method := range httpMethods {
+			rawOperation, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			operation := &Operation{Service: service, Method: strings.ToUpper(method), Path: path, spec: spec}
+			operation.Summary, _ = rawOperation["summary"].(string)
+			operation.responses, _ = rawOperation["responses"].(map[string]interface{})
+			for code := range operation.responses {
+				operation.StatusCodes = append(operation.StatusCodes, code)
+			}
+			sort.Strings(operation.StatusCodes)
+			spec.Operations = append(spec.Operations, operation)
+		}
+	}
+	sort.Slice(spec.Operations, func(i, j int) bool {
+		a, b := spec.Operations[i], spec.Operations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+	return spec, nil
+}
+
+// Convert a value decoded from YAML to the form it would have if it were decoded from JSON,
+// so that the values in the spec compare equal to the values in responses
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = jsonValue(item)
+		}
+		return converted
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		return v
+	}
+}
+
+// Follow references within the spec (#/...) until reaching a value which is not one. An
+// unresolvable reference resolves to nil.
+func (spec *Spec) resolve(value interface{}) interface{} {
+	for depth := 0; depth < 32; depth++ {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return value
+		}
+		value = spec.lookup(ref)
+	}
+	return nil
+}
+
+// Returns the value at a reference within the spec, or nil if there is none
+func (spec *Spec) lookup(ref string) interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var value interface{} = spec.doc
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[token]
+	}
+	return value
+}
+
+// FindOperation returns the operation for a method and URL, or nil if the URL is not that of a
+// service with a spec, or the spec has no such operation. When several path templates match,
+// the one with the most fixed segments is used.
+func FindOperation(method, rawURL string) *Operation {
+	path := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		path = parsed.Path
+	}
+	var found *Operation
+	foundFixed := -1
+	for _, spec := range specs {
+		if len(spec.BasePath) > 0 && path != spec.BasePath && !strings.HasPrefix(path, spec.BasePath+"/") {
+			continue
+		}
+		relative := strings.TrimPrefix(path, spec.BasePath)
+		if len(relative) == 0 {
+			relative = "/"
+		}
+		for _, operation := range spec.Operations {
+			if operation.Method != method {
+				continue
+			}
+			if fixed, ok := matchPath(operation.Path, relative); ok && fixed > foundFixed {
+				found, foundFixed = operation, fixed
+			}
+		}
+	}
+	return found
+}
+
+// Match a path against a path template, returning the number of fixed (not parameter)
+// segments in the template if it matches
+func matchPath(template, path string) (fixed int, ok bool) {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return 0, false
+	}
+	for i, segment := range templateSegments {
+		if IsParameter(segment) {
+			if len(pathSegments[i]) == 0 {
+				return 0, false
+			}
+		} else if segment != pathSegments[i] {
+			return 0, false
+		} else {
+			fixed++
+		}
+	}
+	return fixed, true
+}
+
+// Endpoint returns the path template of the operation on the API gateway (for example,
+// /apis/bos/v2/sessions/{session_id})
+func (operation *Operation) Endpoint() string {
+	return operation.spec.BasePath + operation.Path
+}
+
+// IsParameter returns true if a path template segment is a parameter, like {session_id}
+func IsParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// Path templates on the API gateway which cmsdev calls on services that have no spec. HSM has
+// none, since cmsdev does not test it, but the barebones test uses it.
+var unspecifiedPaths = []string{
+	"/apis/smd/hsm/v2/State/Components",
+}
+
+// FixedSegments returns the segments of the paths in the loaded specs, and of the paths which
+// cmsdev calls on services that have no spec, which are not parameters
+func FixedSegments() map[string]bool {
+	paths := append([]string(nil), unspecifiedPaths...)
+	for _, spec := range Specs() {
+		for _, operation := range spec.Operations {
+			paths = append(paths, operation.Endpoint())
+		}
+	}
+	segments := map[string]bool{}
+	for _, path := range paths {
+		for _, segment := range strings.Split(path, "/") {
+			if len(segment) > 0 && !IsParameter(segment) {
+				segments[segment] = true
+			}
+		}
+	}
+	return segments
+}
+
+// Returns the response in the spec for a status code: the one for that code, or else the one
+// for its range (like 4XX), or else the default response. Returns nil if there is none.
+func (operation *Operation) response(status int) map[string]interface{} {
+	code := strconv.Itoa(status)
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx", "default"} {
+		if response, ok := operation.spec.resolve(operation.responses[key]).(map[string]interface{}); ok {
+			return response
+		}
+	}
+	return nil
+}
+
+// DocumentsStatus returns true if the spec documents a status code for the operation, either
+// explicitly, or by a range (like 4XX) or a default response
+func (operation *Operation) DocumentsStatus(status int) bool {
+	return operation.response(status) != nil
+}
+
+// Returns the schema of the JSON content of a response: that of application/json if it has
+// it, or else that of another JSON media type (like application/problem+json)
+func (operation *Operation) responseSchema(response map[string]interface{}) (schema interface{}, ok bool) {
+	content, _ := response["content"].(map[string]interface{})
+	if media, ok := content["application/json"].(map[string]interface{}); ok {
+		schema, ok = media["schema"]
+		return schema, ok
+	}
+	var mediaTypes []string
+	for mediaType := range content {
+		if strings.HasSuffix(mediaType, "json") {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		return nil, false
+	}
+	sort.Strings(mediaTypes)
+	media, _ := content[mediaTypes[0]].(map[string]interface{})
+	schema, ok = media["schema"]
+	return schema, ok
+}
+
+// ValidateResponse checks a response body against the schema in the spec for its status code.
+// Responses which the spec has no JSON schema for are not checked.
+func (operation *Operation) ValidateResponse(status int, body []byte) error {
+	response := operation.response(status)
+	if response == nil {
+		return nil
+	}
+	schema, ok := operation.responseSchema(response)
+	if !ok {
+		return nil
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return fmt.Errorf("response body is empty, but the %s OpenAPI spec has a schema for status %d of %s %s", operation.Service, status, operation.Method, operation.Path)
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("response body is not valid JSON: %v", err)
+	}
+	v := validator{spec: operation.spec}
+	v.validate("$", value, schema)
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("response does not match the %s OpenAPI spec for status %d of %s %s: %s",
+		operation.Service, status, operation.Method, operation.Path, strings.Join(v.violations, "; "))
+}
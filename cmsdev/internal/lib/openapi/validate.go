@@ -0,0 +1,241 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * validate.go
+ *
+ * Validation of JSON values against OpenAPI 3 schemas. This covers the schema keywords which
+ * the service specs use; others (such as format) are ignored.
+ *
+ */
+
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Only this many violations are reported for one response
+const maxViolations = 10
+
+type validator struct {
+	spec       *Spec
+	violations []string
+}
+
+func (v *validator) addViolation(path, format string, a ...interface{}) {
+	if len(v.violations) < maxViolations {
+		v.violations = append(v.violations, path+": "+fmt.Sprintf(format, a...))
+	} else if len(v.violations) == maxViolations {
+		v.violations = append(v.violations, "...")
+	}
+}
+
+// Returns the JSON type of a decoded JSON value
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// Returns true if a value matches a schema, without recording any violations
+func (v *validator) matches(value, schema interface{}) bool {
+	trial := validator{spec: v.spec}
+	trial.validate("", value, schema)
+	return len(trial.violations) == 0
+}
+
+// Validate a value against a schema, recording any violations. path is the JSON path of the
+// value within the response.
+func (v *validator) validate(path string, value, rawSchema interface{}) {
+	schema, ok := v.spec.resolve(rawSchema).(map[string]interface{})
+	if !ok {
+		// No schema (or an unresolvable reference) matches anything
+		return
+	}
+
+	// A nullable schema matches null, even if its allOf, anyOf, or oneOf schemas do not
+	if nullable, _ := schema["nullable"].(bool); value == nil && nullable {
+		return
+	} else if value == nil && schema["type"] != nil {
+		v.addViolation(path, "is null, but it is not nullable")
+		return
+	}
+
+	for _, subschema := range schemaList(schema["allOf"]) {
+		v.validate(path, value, subschema)
+	}
+	if anyOf := schemaList(schema["anyOf"]); len(anyOf) > 0 {
+		matched := false
+		for _, subschema := range anyOf {
+			if v.matches(value, subschema) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.addViolation(path, "does not match any of the anyOf schemas")
+		}
+	}
+	if oneOf := schemaList(schema["oneOf"]); len(oneOf) > 0 {
+		matched := 0
+		for _, subschema := range oneOf {
+			if v.matches(value, subschema) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.addViolation(path, "matches %d of the oneOf schemas, rather than exactly one", matched)
+		}
+	}
+
+	actualType := jsonType(value)
+	if expectedType, ok := schema["type"].(string); ok {
+		if actualType != expectedType && !(expectedType == "number" && actualType == "integer") {
+			v.addViolation(path, "expected %s, found %s", expectedType, actualType)
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.addViolation(path, "%v is not one of %v", value, enum)
+		}
+	}
+
+	switch actual := value.(type) {
+	case string:
+		v.validateString(path, actual, schema)
+	case float64:
+		v.validateNumber(path, actual, schema)
+	case []interface{}:
+		v.validateArray(path, actual, schema)
+	case map[string]interface{}:
+		v.validateObject(path, actual, schema)
+	}
+}
+
+// Returns the schemas in an allOf, anyOf, or oneOf list
+func schemaList(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+func (v *validator) validateString(path, value string, schema map[string]interface{}) {
+	length := float64(len([]rune(value)))
+	if minLength, ok := schema["minLength"].(float64); ok && length < minLength {
+		v.addViolation(path, "'%s' is shorter than %v characters", value, minLength)
+	}
+	if maxLength, ok := schema["maxLength"].(float64); ok && length > maxLength {
+		v.addViolation(path, "'%s' is longer than %v characters", value, maxLength)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(value) {
+			v.addViolation(path, "'%s' does not match the pattern %s", value, pattern)
+		}
+	}
+}
+
+func (v *validator) validateNumber(path string, value float64, schema map[string]interface{}) {
+	if minimum, ok := schema["minimum"].(float64); ok && value < minimum {
+		v.addViolation(path, "%v is less than the minimum of %v", value, minimum)
+	}
+	if maximum, ok := schema["maximum"].(float64); ok && value > maximum {
+		v.addViolation(path, "%v is greater than the maximum of %v", value, maximum)
+	}
+}
+
+func (v *validator) validateArray(path string, value []interface{}, schema map[string]interface{}) {
+	if minItems, ok := schema["minItems"].(float64); ok && float64(len(value)) < minItems {
+		v.addViolation(path, "has %d items, fewer than the minimum of %v", len(value), minItems)
+	}
+	if maxItems, ok := schema["maxItems"].(float64); ok && float64(len(value)) > maxItems {
+		v.addViolation(path, "has %d items, more than the maximum of %v", len(value), maxItems)
+	}
+	if items, ok := schema["items"]; ok {
+		for i, item := range value {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), item, items)
+		}
+	}
+}
+
+func (v *validator) validateObject(path string, value map[string]interface{}, schema map[string]interface{}) {
+	for _, rawName := range schemaList(schema["required"]) {
+		name, _ := rawName.(string)
+		if _, ok := value[name]; !ok {
+			v.addViolation(path, "required field '%s' is missing", name)
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fieldPath := path + "." + name
+		if strings.ContainsAny(name, ".[] ") {
+			fieldPath = fmt.Sprintf("%s[%q]", path, name)
+		}
+		if property, ok := properties[name]; ok {
+			v.validate(fieldPath, value[name], property)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.addViolation(path, "unexpected field '%s'", name)
+			}
+		case map[string]interface{}:
+			v.validate(fieldPath, value[name], additional)
+		}
+	}
+}
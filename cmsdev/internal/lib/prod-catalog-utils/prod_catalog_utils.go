@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,8 +28,10 @@
 package prod_catalog_utils
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"sync"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
@@ -55,19 +57,26 @@ type ProdCatalogEntry struct {
 	Configuration Configuration     `json:"configuration"`
 	Images        map[string]Image  `json:"images"`
 	Recipes       map[string]Recipe `json:"recipes"`
+	Version       string            `json:"-"` // the CSM version of this entry
 	Initialized   bool              `json:"-"` // internal use only to indicate if this struct has been initialized
 }
 
+// The CSM version of the dummy product catalog data
+const dummyCSMVersion = "1.7.0"
+
 var prodCatError error
 
+// Guards the cached product catalog data, since BOS and CFS tests may look it up concurrently
+var prodCatLock sync.Mutex
+
 // var LatestProdCatEntry map[interface{}]interface{}
 var LatestProdCatEntry ProdCatalogEntry
 
 // GetCsmProductCatalogData is used to fetch the CSM product catalog config map data from Kubernetes
 // It returns the config map data as a map[string]interface{} and error if any
 // The config map data contains the CSM versions and their corresponding configuration and image details
-func GetCsmProductCatalogData() (data map[string]interface{}, err error) {
-	resp, err := k8s.GetConfigMapDataField(common.NAMESPACE, common.CSMPRODCATALOGCMNAME, "csm")
+func GetCsmProductCatalogData(ctx context.Context) (data map[string]interface{}, err error) {
+	resp, err := k8s.GetConfigMapDataField(ctx, common.NAMESPACE, common.CSMPRODCATALOGCMNAME, "csm")
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +92,16 @@ func GetCsmProductCatalogData() (data map[string]interface{}, err error) {
 
 // Initialize the ProdCatalogEntry with dummy data
 func UseProdCatalogEntryDummyData() error {
+	entry, err := dummyProdCatalogEntry()
+	if err != nil {
+		return err
+	}
+	LatestProdCatEntry = entry
+	return nil
+}
+
+// Returns the dummy product catalog data, which matches the records seeded by the cmsdev mock server
+func dummyProdCatalogEntry() (ProdCatalogEntry, error) {
 	dummyData := map[interface{}]interface{}{
 		"configuration": map[interface{}]interface{}{
 			"clone_url":     "https://vcs.cmn.wasp.hpc.amslabs.hpecorp.net/vcs/cray/dummy-csm-config-management.git",
@@ -110,13 +129,12 @@ func UseProdCatalogEntryDummyData() error {
 	}
 	entry, err := MapToProdCatalogEntry(dummyData)
 	if err != nil {
-		return fmt.Errorf("Failed to convert dummy data: %v", err)
+		return ProdCatalogEntry{}, fmt.Errorf("Failed to convert dummy data: %v", err)
 	} else if !entry.Initialized {
-		return fmt.Errorf("Dummy data not properly initialized")
+		return ProdCatalogEntry{}, fmt.Errorf("Dummy data not properly initialized")
 	}
-
-	LatestProdCatEntry = entry
-	return nil
+	entry.Version = dummyCSMVersion
+	return entry, nil
 }
 
 // Helper function to convert map[interface{}]interface{} to ProdCatalogEntry
@@ -203,7 +221,7 @@ func MapToProdCatalogEntry(data map[interface{}]interface{}) (ProdCatalogEntry,
 // For testing purposes, both configuration and images must be present.
 // It sorts all available version keys, checks for the presence of both "configuration" and "images" fields,
 // and selects the highest version that meets these criteria. Returns the version string and an error if not found.
-func GetCSMLatestVersion(prodCatlogData map[string]interface{}) (version string, err error) {
+func GetCSMLatestVersion(ctx context.Context, prodCatlogData map[string]interface{}) (version string, err error) {
 	// Sort the keys in the JSON object to ensure consistent ordering
 	keys := make([]string, 0, len(prodCatlogData))
 	for key := range prodCatlogData {
@@ -225,7 +243,7 @@ func GetCSMLatestVersion(prodCatlogData map[string]interface{}) (version string,
 		}
 	}
 
-	common.Infof("Latest CSM version: %s", latestCSMVersion)
+	common.Infof(ctx, "Latest CSM version: %s", latestCSMVersion)
 	return latestCSMVersion, nil
 }
 
@@ -233,13 +251,13 @@ func GetCSMLatestVersion(prodCatlogData map[string]interface{}) (version string,
 // and caches it in LatestProdCatEntry. It retrieves the config map data from Kubernetes, determines the
 // latest valid CSM version, converts its data to a ProdCatalogEntry, and stores it for future use.
 // Returns an error if fetching or conversion fails.
-func GetLatestCSMProductCatalogEntry() error {
-	prodCatalogData, err := GetCsmProductCatalogData()
+func GetLatestCSMProductCatalogEntry(ctx context.Context) error {
+	prodCatalogData, err := GetCsmProductCatalogData(ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to get product catalog data: %v", err)
 	}
 
-	latestVersion, err := GetCSMLatestVersion(prodCatalogData)
+	latestVersion, err := GetCSMLatestVersion(ctx, prodCatalogData)
 	if err != nil {
 		return fmt.Errorf("Failed to get latest CSM version: %v", err)
 	}
@@ -254,6 +272,7 @@ func GetLatestCSMProductCatalogEntry() error {
 	} else if !entry.Initialized {
 		return fmt.Errorf("Programming logic error: No error raised parsing product catalog data, but it is not properly initialized")
 	}
+	entry.Version = latestVersion
 	LatestProdCatEntry = entry
 	return nil
 }
@@ -264,15 +283,25 @@ func GetLatestCSMProductCatalogEntry() error {
 // Otherwise, the function fetches and caches the latest entry from the product catalog config map.
 // Returns the latest ProdCatalogEntry and an error if fetching fails.
 // Caches the error in prodCatError to avoid repeated fetch attempts in future calls.
-func GetLatestProdCatEntry() (ProdCatalogEntry, error) {
+func GetLatestProdCatEntry(ctx context.Context) (ProdCatalogEntry, error) {
+	prodCatLock.Lock()
+	defer prodCatLock.Unlock()
 	if LatestProdCatEntry.Initialized {
-		common.Infof("Using cached product catalog data")
+		common.Infof(ctx, "Using cached product catalog data")
 		return LatestProdCatEntry, nil
 	}
 	if prodCatError != nil {
 		return ProdCatalogEntry{}, fmt.Errorf("Product catalog data unavailable due to previous failure: %v", prodCatError)
 	}
-	if err := GetLatestCSMProductCatalogEntry(); err != nil {
+	// Without a cluster there is no product catalog to read, which is expected rather than an error
+	if !common.ClusterAvailable() {
+		common.Infof(ctx, "Using dummy product catalog data, because %s (no Kubernetes cluster)", common.NoClusterReason())
+		if err := UseProdCatalogEntryDummyData(); err != nil {
+			prodCatError = err
+		}
+		return LatestProdCatEntry, prodCatError
+	}
+	if err := GetLatestCSMProductCatalogEntry(ctx); err != nil {
 		prodCatError = err
 		// On error, use dummy data for testing
 		errDummyData := UseProdCatalogEntryDummyData()
@@ -284,3 +313,30 @@ func GetLatestProdCatEntry() (ProdCatalogEntry, error) {
 	}
 	return LatestProdCatEntry, prodCatError
 }
+
+// GetProdCatEntry returns the ProdCatalogEntry for the specified CSM version. Unlike the latest
+// entry, it is not cached. Without a cluster, the dummy data is returned whatever the version.
+func GetProdCatEntry(ctx context.Context, version string) (ProdCatalogEntry, error) {
+	if !common.ClusterAvailable() {
+		common.Infof(ctx, "Using dummy product catalog data for CSM %s, because %s (no Kubernetes cluster)", version, common.NoClusterReason())
+		entry, err := dummyProdCatalogEntry()
+		entry.Version = version
+		return entry, err
+	}
+	prodCatalogData, err := GetCsmProductCatalogData(ctx)
+	if err != nil {
+		return ProdCatalogEntry{}, fmt.Errorf("Failed to get product catalog data: %v", err)
+	}
+	versionData, ok := prodCatalogData[version].(map[interface{}]interface{})
+	if !ok {
+		return ProdCatalogEntry{}, fmt.Errorf("No entry found for CSM version '%s' in the product catalog", version)
+	}
+	entry, err := MapToProdCatalogEntry(versionData)
+	if err != nil {
+		return ProdCatalogEntry{}, fmt.Errorf("Failed to convert data of CSM version '%s': %v", version, err)
+	} else if !entry.Initialized {
+		return ProdCatalogEntry{}, fmt.Errorf("Programming logic error: No error raised parsing product catalog data of CSM version '%s', but it is not properly initialized", version)
+	}
+	entry.Version = version
+	return entry, nil
+}
@@ -0,0 +1,52 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * leftovers.go
+ *
+ * Resources left behind by earlier test runs, found and deleted by the cleanup command
+ *
+ */
+
+package registry
+
+import "context"
+
+// Options passed to the leftover finder of a service test
+type CleanupOptions struct {
+	// The context of the run which is searching for the leftovers
+	Context context.Context
+	// Also find records which were created by tests and have since been soft deleted,
+	// so that they can be permanently deleted
+	PurgeDeleted bool
+}
+
+// A resource which was created by an earlier test run and not deleted. Tests only find
+// resources whose names match the names that they generate.
+type Leftover struct {
+	Kind string
+	Name string
+	// The tenant which owns the resource, if any. The resource is deleted as this tenant.
+	Tenant string
+	// Deletes the resource, returning true if it was deleted
+	Delete func() bool
+}
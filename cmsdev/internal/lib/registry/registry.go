@@ -0,0 +1,162 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * registry.go
+ *
+ * Registry of the service tests which cmsdev can run. Each test package registers
+ * its ServiceTest from an init function.
+ *
+ */
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Test timeout (in seconds) used for service tests which do not specify one
+// (test will not retry after this amount of time)
+const DefaultTimeout int64 = 120
+
+// Options passed to the run function of a service test
+type RunOptions struct {
+	// The context of the test's run, which carries the state of the run. The test passes it to
+	// the helpers it calls (including from goroutines it starts, see common.RunConcurrently),
+	// so that their output and results go to the test's run.
+	Context       context.Context
+	IncludeCLI    bool
+	IncludeTenant bool
+}
+
+// A service test which can be run by cmsdev
+type ServiceTest struct {
+	// Name used to select the test on the command line
+	Name string
+	// Other names which select the same test
+	Aliases []string
+	// One-line description, shown by "cmsdev test -l"
+	Description string
+	// Test timeout (in seconds) when retrying. If 0, DefaultTimeout is used.
+	DefaultTimeout int64
+	// Whether the test has CLI and tenant variants, which are enabled by
+	// the --include-cli and --include-tenant flags
+	SupportsCLI    bool
+	SupportsTenant bool
+	// Whether the test is only run when it is named, rather than as part of "all" (for
+	// example, because it disrupts the service while it runs)
+	ExplicitOnly bool
+	// Stable names of the subtests which the test may run, for use with the --only and
+	// --skip options (e.g. ims.signingkeys, vcs.clone)
+	Subtests []string
+	// Subtests (also listed in Subtests) which need the Kubernetes cluster, for example to check
+	// pods. They are skipped when there is no cluster (see common.ClusterAvailable).
+	ClusterSubtests []string
+	// Runs the test, returning true if it passed
+	Run func(opts RunOptions) bool
+	// Finds resources left behind by earlier runs of the test, in the order they should
+	// be deleted. Returns false if the search did not complete. Optional.
+	FindLeftovers func(opts CleanupOptions) (leftovers []Leftover, ok bool)
+}
+
+var serviceTests = map[string]*ServiceTest{}
+var aliases = map[string]string{}
+var registryLock sync.RWMutex
+
+// Register adds a service test to the registry. It panics if the name or any of
+// the aliases are already registered, since that is a programming error.
+func Register(test ServiceTest) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if len(test.Name) == 0 || test.Run == nil {
+		panic("service test registered without a name or run function")
+	}
+	for _, name := range append([]string{test.Name}, test.Aliases...) {
+		if _, ok := serviceTests[name]; ok {
+			panic(fmt.Sprintf("service test name '%s' registered more than once", name))
+		} else if _, ok := aliases[name]; ok {
+			panic(fmt.Sprintf("service test name '%s' registered more than once", name))
+		}
+	}
+	if test.DefaultTimeout == 0 {
+		test.DefaultTimeout = DefaultTimeout
+	}
+	serviceTests[test.Name] = &test
+	for _, alias := range test.Aliases {
+		aliases[alias] = test.Name
+	}
+}
+
+// Lookup returns the service test with the specified name or alias
+func Lookup(name string) (test *ServiceTest, ok bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	if test, ok = serviceTests[name]; ok {
+		return
+	}
+	if primaryName, isAlias := aliases[name]; isAlias {
+		test, ok = serviceTests[primaryName]
+	}
+	return
+}
+
+// IsAlias returns true if the specified name is an alias of another service test
+func IsAlias(name string) bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	_, ok := aliases[name]
+	return ok
+}
+
+// ServiceTests returns all registered service tests, sorted by name
+func ServiceTests() []*ServiceTest {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	tests := make([]*ServiceTest, 0, len(serviceTests))
+	for _, test := range serviceTests {
+		tests = append(tests, test)
+	}
+	sort.Slice(tests, func(i, j int) bool { return tests[i].Name < tests[j].Name })
+	return tests
+}
+
+// Names returns the sorted names of all registered service tests, optionally
+// including their aliases
+func Names(includeAliases bool) []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	names := make([]string, 0, len(serviceTests)+len(aliases))
+	for name := range serviceTests {
+		names = append(names, name)
+	}
+	if includeAliases {
+		for alias := range aliases {
+			names = append(names, alias)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,168 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * subtests.go
+ *
+ * Selection of the subtests to run, using the --only and --skip options
+ *
+ */
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+// Subtest selectors from the --only and --skip options. These are set once, before
+// any tests are run.
+var onlySelectors, skipSelectors []string
+
+// A selector matches a subtest if it is the full subtest name (ims.signingkeys), the last
+// part of it (signingkeys), or a leading group of it (ims.api matches ims.api.images)
+func selectorMatches(selector, subtestName string) bool {
+	return subtestName == selector ||
+		strings.HasSuffix(subtestName, "."+selector) ||
+		strings.HasPrefix(subtestName, selector+".")
+}
+
+// All subtest names declared by registered service tests
+func allSubtestNames() (names []string) {
+	for _, serviceTest := range ServiceTests() {
+		names = append(names, serviceTest.Subtests...)
+	}
+	return
+}
+
+// SetSubtestSelection validates and sets the --only and --skip subtest selectors.
+// Every selector must match at least one declared subtest.
+func SetSubtestSelection(only, skip []string) error {
+	names := allSubtestNames()
+	for _, selector := range append(append([]string{}, only...), skip...) {
+		found := false
+		for _, name := range names {
+			if selectorMatches(selector, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("'%s' does not match any subtest. Use 'cmsdev test -l --subtests' to list them", selector)
+		}
+	}
+	onlySelectors, skipSelectors = only, skip
+	return nil
+}
+
+// Returns true if the named subtest matches any --skip selector
+func subtestSkipped(subtestName string) bool {
+	for _, selector := range skipSelectors {
+		if selectorMatches(selector, subtestName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the named subtest needs the Kubernetes cluster
+func needsCluster(subtestName string) bool {
+	for _, serviceTest := range ServiceTests() {
+		for _, name := range serviceTest.ClusterSubtests {
+			if name == subtestName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Records a subtest which needs the Kubernetes cluster as skipped, if there is no cluster
+func skippedWithoutCluster(ctx context.Context, subtestName string) bool {
+	if common.ClusterAvailable() || !needsCluster(subtestName) {
+		return false
+	}
+	common.Infof(ctx, "Skipping subtest %s (needs the Kubernetes cluster, and %s)", subtestName, common.NoClusterReason())
+	common.SkipSubtest(ctx, subtestName)
+	return true
+}
+
+// SubtestSelected returns true if the named subtest should be run
+func SubtestSelected(subtestName string) bool {
+	if subtestSkipped(subtestName) {
+		return false
+	}
+	if len(onlySelectors) == 0 {
+		return true
+	}
+	for _, selector := range onlySelectors {
+		if selectorMatches(selector, subtestName) {
+			return true
+		}
+	}
+	return false
+}
+
+// AnySubtestSelected returns true if at least one of the named subtests should be run.
+// This is for setup steps which are shared by several subtests.
+func AnySubtestSelected(subtestNames ...string) bool {
+	for _, subtestName := range subtestNames {
+		if SubtestSelected(subtestName) {
+			return true
+		}
+	}
+	return false
+}
+
+// RunSubtest runs the named subtest, if it is selected, and returns its result.
+// A subtest which is not selected, or which needs the Kubernetes cluster when there is none,
+// is recorded as skipped and treated as passing.
+func RunSubtest(ctx context.Context, subtestName string, run func(ctx context.Context) bool) (passed bool) {
+	if !SubtestSelected(subtestName) {
+		common.Infof(ctx, "Skipping subtest %s (not selected)", subtestName)
+		common.SkipSubtest(ctx, subtestName)
+		return true
+	} else if skippedWithoutCluster(ctx, subtestName) {
+		return true
+	}
+	defer common.StartSubtest(ctx, subtestName).End(ctx, &passed)
+	return run(ctx)
+}
+
+// RunSubtestNeededBy is like RunSubtest, except that the named subtest is also run if any of
+// the dependent subtests are selected, because they rely on what it sets up. An explicit --skip
+// of the named subtest still takes precedence.
+func RunSubtestNeededBy(ctx context.Context, subtestName string, dependents []string, run func(ctx context.Context) bool) (passed bool) {
+	if SubtestSelected(subtestName) || subtestSkipped(subtestName) || !AnySubtestSelected(dependents...) {
+		return RunSubtest(ctx, subtestName, run)
+	}
+	if skippedWithoutCluster(ctx, subtestName) {
+		return true
+	}
+	common.Infof(ctx, "Running subtest %s (needed by %s)", subtestName, strings.Join(dependents, ", "))
+	defer common.StartSubtest(ctx, subtestName).End(ctx, &passed)
+	return run(ctx)
+}
@@ -0,0 +1,269 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+//
+/*
+ * report.go
+ *
+ * Machine-readable (JUnit XML and JSON) reports of service test results
+ *
+ */
+
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+// JSON report format
+
+type jsonSubtest struct {
+	Name            string    `json:"name"`
+	Attempt         int       `json:"attempt"`
+	Passed          bool      `json:"passed"`
+	Skipped         bool      `json:"skipped"`
+	Start           time.Time `json:"start"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Errors          []string  `json:"errors,omitempty"`
+}
+
+type jsonService struct {
+	Name            string        `json:"name"`
+	Passed          bool          `json:"passed"`
+	Attempts        int           `json:"attempts"`
+	Start           time.Time     `json:"start"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	Errors          []string      `json:"errors,omitempty"`
+	Subtests        []jsonSubtest `json:"subtests"`
+}
+
+type jsonLatency struct {
+	Method     string  `json:"method"`
+	Endpoint   string  `json:"endpoint"`
+	Count      int     `json:"count"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P95Seconds float64 `json:"p95_seconds"`
+	MaxSeconds float64 `json:"max_seconds"`
+}
+
+type jsonRetries struct {
+	Class     string `json:"class"`
+	Reason    string `json:"reason"`
+	Retries   int    `json:"retries"`
+	Exhausted int    `json:"exhausted"`
+}
+
+type jsonReport struct {
+	Version         string        `json:"version"`
+	Start           time.Time     `json:"start"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	Passed          bool          `json:"passed"`
+	Services        []jsonService `json:"services"`
+	Latency         []jsonLatency `json:"latency,omitempty"`
+	Retries         []jsonRetries `json:"retries,omitempty"`
+}
+
+// JUnit XML report format
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitTestCase struct {
+	ClassName  string          `xml:"classname,attr"`
+	Name       string          `xml:"name,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+	Skipped    *junitSkipped   `xml:"skipped,omitempty"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Time       string           `xml:"time,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+func junitFailureFromErrors(errors []string) *junitFailure {
+	failure := &junitFailure{Type: "failure", Message: "failed"}
+	if len(errors) > 0 {
+		failure.Message = errors[0]
+		failure.Contents = strings.Join(errors, "\n")
+	}
+	return failure
+}
+
+func writeFile(ctx context.Context, path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("Error writing report file '%s': %v", path, err)
+	}
+	common.Infof(ctx, "Test report written to '%s'", path)
+	return nil
+}
+
+// WriteJSON writes a JSON report of the specified service test results, API latency statistics
+// and retries of transient failures to the specified file
+func WriteJSON(ctx context.Context, path, version string, start time.Time, results []*common.ServiceResult, latency []common.LatencyStats, retries []common.RetryStats) error {
+	report := jsonReport{
+		Version:         version,
+		Start:           start,
+		DurationSeconds: time.Since(start).Seconds(),
+		Passed:          true,
+		Services:        make([]jsonService, 0, len(results)),
+	}
+	for _, result := range results {
+		service := jsonService{
+			Name:            result.Name,
+			Passed:          result.Passed,
+			Attempts:        result.Attempts,
+			Start:           result.Start,
+			DurationSeconds: result.Duration.Seconds(),
+			Errors:          result.Errors,
+			Subtests:        make([]jsonSubtest, 0, len(result.Subtests)),
+		}
+		for _, subtest := range result.Subtests {
+			service.Subtests = append(service.Subtests, jsonSubtest{
+				Name:            subtest.Name,
+				Attempt:         subtest.Attempt,
+				Passed:          subtest.Passed,
+				Skipped:         subtest.Skipped,
+				Start:           subtest.Start,
+				DurationSeconds: subtest.Duration.Seconds(),
+				Errors:          subtest.Errors,
+			})
+		}
+		if !result.Passed {
+			report.Passed = false
+		}
+		report.Services = append(report.Services, service)
+	}
+	for _, stats := range latency {
+		report.Latency = append(report.Latency, jsonLatency{
+			Method:     stats.Method,
+			Endpoint:   stats.Endpoint,
+			Count:      stats.Count,
+			P50Seconds: stats.P50.Seconds(),
+			P95Seconds: stats.P95.Seconds(),
+			MaxSeconds: stats.Max.Seconds(),
+		})
+	}
+	for _, stats := range retries {
+		report.Retries = append(report.Retries, jsonRetries{
+			Class:     stats.Class,
+			Reason:    stats.Reason,
+			Retries:   stats.Retries,
+			Exhausted: stats.Exhausted,
+		})
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(ctx, path, append(data, '\n'))
+}
+
+// WriteJUnit writes a JUnit XML report of the specified service test results to the specified file.
+// Each service is a test suite. It contains one test case for the overall service result, and one
+// for each subtest attempt.
+func WriteJUnit(ctx context.Context, path string, start time.Time, results []*common.ServiceResult) error {
+	suites := junitTestSuites{Name: "cmsdev", Time: seconds(time.Since(start))}
+	for _, result := range results {
+		suite := junitTestSuite{
+			Name:      result.Name,
+			Time:      seconds(result.Duration),
+			Timestamp: result.Start.Format(time.RFC3339),
+		}
+		overall := junitTestCase{
+			ClassName:  "cmsdev." + result.Name,
+			Name:       result.Name,
+			Time:       seconds(result.Duration),
+			Properties: []junitProperty{{Name: "attempts", Value: strconv.Itoa(result.Attempts)}},
+		}
+		if !result.Passed {
+			overall.Failure = junitFailureFromErrors(result.Errors)
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, overall)
+		for _, subtest := range result.Subtests {
+			testCase := junitTestCase{
+				ClassName:  "cmsdev." + result.Name,
+				Name:       subtest.Name,
+				Time:       seconds(subtest.Duration),
+				Properties: []junitProperty{{Name: "attempt", Value: strconv.Itoa(subtest.Attempt)}},
+			}
+			if subtest.Skipped {
+				testCase.Skipped = &junitSkipped{Message: "not selected"}
+				suite.Skipped++
+			} else if !subtest.Passed {
+				testCase.Failure = junitFailureFromErrors(subtest.Errors)
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suite.Tests = len(suite.TestCases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.TestSuites = append(suites.TestSuites, suite)
+	}
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(ctx, path, append([]byte(xml.Header), append(data, '\n')...))
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,69 +29,152 @@ package test
  */
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+
 	resty "gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/auth"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/openapi"
 )
 
-func GetAccessToken() string {
-	common.Debugf("Getting access token")
-	token, err := k8s.GetAccessToken()
+func GetAccessToken(ctx context.Context) string {
+	common.Debugf(ctx, "Getting access token")
+	token, err := auth.GetToken(ctx)
 	if err != nil {
-		common.Errorf("Error getting access token: %v", err)
+		common.Errorf(ctx, "Error getting access token: %v", err)
 		return ""
 	}
-	return token
+	return token.AccessToken
 }
 
-func GetAccessTokenParams() *common.Params {
-	token := GetAccessToken()
+func GetAccessTokenParams(ctx context.Context) *common.Params {
+	token := GetAccessToken(ctx)
 	if len(token) == 0 {
 		return nil
 	}
 	return &common.Params{Token: token}
 }
 
-func RestfulTestHeader(label string, testNum, testTotal int) {
-	common.VerbosePrintDivider()
-	common.Infof("%s test scenario #%d/%d", label, testNum, testTotal)
+func RestfulTestHeader(ctx context.Context, label string, testNum, testTotal int) {
+	common.VerbosePrintDivider(ctx)
+	common.Infof(ctx, "%s test scenario #%d/%d", label, testNum, testTotal)
 }
 
-func RestfulVerifyStatus(method, url string, params common.Params, ExpectedStatus int) (resp *resty.Response, err error) {
-	common.Infof("%s %s", method, url)
-	resp, err = common.Restful(method, url, params)
+func RestfulVerifyStatus(ctx context.Context, method, url string, params common.Params, ExpectedStatus int, options ...common.RequestOption) (resp *resty.Response, err error) {
+	common.Infof(ctx, "%s %s", method, url)
+	resp, err = common.Restful(ctx, method, url, params, options...)
 	if err != nil {
 		err = fmt.Errorf("%s %s failed: %v", method, url, err)
 		return
 	}
-	common.Debugf("resp=%v", resp)
-	common.PrettyPrintJSON(resp)
+	common.Debugf(ctx, "resp=%v", resp)
+	common.PrettyPrintJSON(ctx, resp)
 	if resp.StatusCode() != ExpectedStatus {
 		err = fmt.Errorf("%s %s: expected status code %d, got %d", method, url, ExpectedStatus, resp.StatusCode())
 		return
 	}
-	common.Infof("Received status code %d, as expected", resp.StatusCode())
+	common.Infof(ctx, "Received status code %d, as expected", resp.StatusCode())
+	if err = validateResponseSchema(ctx, method, url, resp); err != nil {
+		err = fmt.Errorf("%s %s: %v", method, url, err)
+	}
 	return
 }
 
-func TenantRestfulVerifyStatus(method, url, tenant string, params common.Params, ExpectedStatus int) (resp *resty.Response, err error) {
-	common.Infof("%s %s (tenant: %s)", method, url, tenant)
-	resp, err = common.RestfulTenant(method, url, tenant, params)
+func TenantRestfulVerifyStatus(ctx context.Context, method, url, tenant string, params common.Params, ExpectedStatus int, options ...common.RequestOption) (resp *resty.Response, err error) {
+	common.Infof(ctx, "%s %s (tenant: %s)", method, url, tenant)
+	resp, err = common.RestfulTenant(ctx, method, url, tenant, params, options...)
 	if err != nil {
 		err = fmt.Errorf("%s %s (tenant: %s) failed: %v", method, url, tenant, err)
 		return
 	}
-	common.Debugf("resp=%v", resp)
-	common.PrettyPrintJSON(resp)
+	common.Debugf(ctx, "resp=%v", resp)
+	common.PrettyPrintJSON(ctx, resp)
 	if resp.StatusCode() != ExpectedStatus {
 		err = fmt.Errorf("%s %s (tenant: %s): expected status code %d, got %d", method, url, tenant, ExpectedStatus, resp.StatusCode())
 		return
 	}
-	common.Infof("Received status code %d, as expected", resp.StatusCode())
+	common.Infof(ctx, "Received status code %d, as expected", resp.StatusCode())
+	if err = validateResponseSchema(ctx, method, url, resp); err != nil {
+		err = fmt.Errorf("%s %s (tenant: %s): %v", method, url, tenant, err)
+	}
 	return
 }
 
-func RestfulTestResultSummary(numFailed, testTotal int) {
-	common.Infof("%d passed, %d failed", testTotal-numFailed, numFailed)
+// Validate a response body against the schema for its status code in the OpenAPI spec of the
+// service, if the spec has the request's endpoint
+func validateResponseSchema(ctx context.Context, method, url string, resp *resty.Response) error {
+	operation := openapi.FindOperation(method, url)
+	if operation == nil {
+		common.Debugf(ctx, "No OpenAPI spec has %s %s; not validating the response", method, url)
+		return nil
+	}
+	if err := operation.ValidateResponse(resp.StatusCode(), resp.Body()); err != nil {
+		return err
+	}
+	common.Debugf(ctx, "Response matches the %s OpenAPI spec for %s %s", operation.Service, operation.Method, operation.Path)
+	return nil
+}
+
+// RegisterAPIDeleteCleanup registers a cleanup (see common.RegisterCleanup) for a resource created by
+// a test. The cleanup deletes the resource by making a DELETE request to each of the specified URLs in
+// turn. A 404 response counts as success, since it means that the resource does not exist.
+func RegisterAPIDeleteCleanup(ctx context.Context, kind, name string, urls ...string) {
+	common.RegisterCleanup(ctx, kind, name, func() bool {
+		return DeleteURLs(ctx, urls...)
+	})
+}
+
+// DeleteURLs makes a DELETE request to each of the specified URLs in turn, as the current tenant
+// (if any). It returns true if every request succeeded or found nothing to delete (status 404).
+func DeleteURLs(ctx context.Context, urls ...string) (ok bool) {
+	params := GetAccessTokenParams(ctx)
+	if params == nil {
+		return false
+	}
+	tenant := common.GetTenantName(ctx)
+	ok = true
+	for _, url := range urls {
+		var resp *resty.Response
+		var err error
+		if len(tenant) == 0 {
+			common.Infof(ctx, "DELETE %s", url)
+			resp, err = common.Restful(ctx, "DELETE", url, *params)
+		} else {
+			common.Infof(ctx, "DELETE %s (tenant: %s)", url, tenant)
+			resp, err = common.RestfulTenant(ctx, "DELETE", url, tenant, *params)
+		}
+		if err != nil {
+			common.Warnf(ctx, "DELETE %s failed: %v", url, err)
+			ok = false
+		} else if resp.StatusCode() == http.StatusNotFound {
+			common.Infof(ctx, "Received status code %d; it has already been deleted", resp.StatusCode())
+		} else if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+			common.Warnf(ctx, "DELETE %s: received status code %d", url, resp.StatusCode())
+			ok = false
+		} else {
+			common.Infof(ctx, "Received status code %d", resp.StatusCode())
+		}
+	}
+	return
+}
+
+// GetTenantsAndNone returns the empty tenant name (meaning no tenant) followed by the names of
+// the tenants defined on the system. This is used to find resources owned by any tenant. If
+// the tenants cannot be listed, it logs a warning and returns false, along with just the
+// empty tenant name.
+func GetTenantsAndNone(ctx context.Context) (tenants []string, ok bool) {
+	tenants = []string{""}
+	tenantList, err := k8s.GetTenants(ctx)
+	if err != nil {
+		common.Warnf(ctx, "Unable to list tenants: %v", err)
+		return tenants, false
+	}
+	return append(tenants, tenantList...), true
+}
+
+func RestfulTestResultSummary(ctx context.Context, numFailed, testTotal int) {
+	common.Infof(ctx, "%d passed, %d failed", testTotal-numFailed, numFailed)
 }
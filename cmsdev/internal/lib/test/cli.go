@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,19 +29,19 @@ package test
  */
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
 
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/auth"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
 )
 
 const cray_cli = "/usr/bin/cray"
 
 var CliAuthFile = ""
-var CliConfigFile = ""
 
 var ConfigErrorStrings = []string{
 	"Unable to connect to cray",
@@ -51,9 +51,10 @@ var ConfigErrorStrings = []string{
 	"cray init",
 }
 
+// The CLI uses the configured API gateway
 var cli_config_file_text = string(`
 [core]
-hostname = "https://api-gw-service-nmn.local"
+hostname = "%s"
 tenant = "%s"
 `)
 
@@ -62,62 +63,93 @@ tenant = "%s"
 // file with no tenant specified
 var cliConfigFilesByTenant = map[string]string{}
 
-// Using a global variable to track the return code of the CLI command execution
+// Guards CliAuthFile and cliConfigFilesByTenant, since service tests may run in parallel
+var cliFilesLock sync.Mutex
+
+// Using a run-scoped value to track the return code of the CLI command execution
 // This is used to simulate different return codes for testing purposes
 // The default value is 0, which indicates success
-var cliExecreturnCode = 0
+const cliExecReturnCodeKey = "cliExecReturnCode"
 
-func SetCliExecreturnCode(code int) {
-	cliExecreturnCode = code
+func SetCliExecreturnCode(ctx context.Context, code int) {
+	common.SetRunValue(ctx, cliExecReturnCodeKey, code)
 }
 
-func GetCliExecreturnCode() int {
-	return cliExecreturnCode
+func GetCliExecreturnCode(ctx context.Context) int {
+	if code, ok := common.GetRunValue(ctx, cliExecReturnCodeKey); ok {
+		return code.(int)
+	}
+	return 0
 }
 
-func GetAccessJSON() []byte {
-	common.Debugf("Getting access JSON object")
-	jobj, err := k8s.GetAccessJSON()
+// The access token written to CliAuthFile, so that the file is rewritten when the token is refreshed
+var cliAuthToken = ""
+
+// Get the access token in the format of a cray CLI credentials file
+func getAccessJSON(ctx context.Context) (token string, jobj []byte) {
+	common.Debugf(ctx, "Getting access JSON object")
+	accessToken, err := auth.GetToken(ctx)
 	if err != nil {
-		common.Error(err)
-		return nil
+		common.Error(ctx, err)
+		return
+	}
+	jobj, err = accessToken.CredentialsJSON()
+	if err != nil {
+		common.Error(ctx, err)
+		return
 	}
+	return accessToken.AccessToken, jobj
+}
+
+func GetAccessJSON(ctx context.Context) []byte {
+	_, jobj := getAccessJSON(ctx)
 	return jobj
 }
 
-func GetAccessFile() string {
-	if CliAuthFile != "" {
-		return CliAuthFile
-	}
-	jobj := GetAccessJSON()
+func GetAccessFile(ctx context.Context) string {
+	cliFilesLock.Lock()
+	defer cliFilesLock.Unlock()
+	token, jobj := getAccessJSON(ctx)
 	if jobj == nil {
 		return ""
+	} else if CliAuthFile != "" && token == cliAuthToken {
+		return CliAuthFile
 	}
-	CliAuthFile = common.TmpDir + "/cmsdev-cray-credentials-file.json"
-	common.Debugf("Writing credentials for CLI authentication to file: %s", CliAuthFile)
-	err := ioutil.WriteFile(CliAuthFile, jobj, 0644)
-	if err != nil {
-		common.Error(err)
+	authFile := common.TmpDir + "/cmsdev-cray-credentials-file.json"
+	common.Debugf(ctx, "Writing credentials for CLI authentication to file: %s", authFile)
+	// The new credentials are written to a temporary file in the same directory, which is then
+	// renamed over the old file, so that a CLI command running in another test never reads a
+	// partly written file. Only the user running cmsdev may read the credentials.
+	tmpFile := authFile + ".tmp"
+	if err := os.WriteFile(tmpFile, jobj, 0600); err != nil {
+		common.Error(ctx, err)
+		return ""
+	} else if err = os.Rename(tmpFile, authFile); err != nil {
+		common.Error(ctx, err)
+		os.Remove(tmpFile)
 		return ""
 	}
+	CliAuthFile, cliAuthToken = authFile, token
 	return CliAuthFile
 }
 
-func MakeConfigFile(tenant string) (filePath string, err error) {
+func MakeConfigFile(ctx context.Context, tenant string) (filePath string, err error) {
 	var file_contents string
 	var ok bool
 
+	cliFilesLock.Lock()
+	defer cliFilesLock.Unlock()
 	filePath, ok = cliConfigFilesByTenant[tenant]
 	if ok {
 		return
 	}
 	filePath = common.TmpDir + "/" + tenant + ".craycli.config"
 	if len(tenant) == 0 {
-		common.Debugf("Creating untenanted config file for Cray CLI: '%s'", filePath)
+		common.Debugf(ctx, "Creating untenanted config file for Cray CLI: '%s'", filePath)
 	} else {
-		common.Debugf("Creating config file for tenant '%s' for Cray CLI: '%s'", tenant, filePath)
+		common.Debugf(ctx, "Creating config file for tenant '%s' for Cray CLI: '%s'", tenant, filePath)
 	}
-	file_contents = fmt.Sprintf(cli_config_file_text, tenant)
+	file_contents = fmt.Sprintf(cli_config_file_text, common.BASEURL, tenant)
 	err = os.WriteFile(filePath, []byte(file_contents), 0600)
 	if err != nil {
 		err = fmt.Errorf("Error writing CLI configuration file '%s': %v", filePath, err)
@@ -127,21 +159,21 @@ func MakeConfigFile(tenant string) (filePath string, err error) {
 	return
 }
 
-func RunCLICommandJSON(baseCmdString string, cmdArgs ...string) []byte {
-	return TenantRunCLICommandJSON("", baseCmdString, cmdArgs...)
+func RunCLICommandJSON(ctx context.Context, baseCmdString string, cmdArgs ...string) []byte {
+	return TenantRunCLICommandJSON(ctx, "", baseCmdString, cmdArgs...)
 }
 
-func TenantRunCLICommandJSON(tenant, baseCmdString string, cmdArgs ...string) []byte {
+func TenantRunCLICommandJSON(ctx context.Context, tenant, baseCmdString string, cmdArgs ...string) []byte {
 	cmdList := append([]string{baseCmdString}, cmdArgs...)
 	cmdList = append(cmdList, "--format", "json")
-	return TenantRunCLICommand(tenant, cmdList...)
+	return TenantRunCLICommand(ctx, tenant, cmdList...)
 }
 
-func RunCLICommand(cmdList ...string) []byte {
-	return TenantRunCLICommand("", cmdList...)
+func RunCLICommand(ctx context.Context, cmdList ...string) []byte {
+	return TenantRunCLICommand(ctx, "", cmdList...)
 }
 
-func TenantRunCLICommand(tenant string, cmdList ...string) []byte {
+func TenantRunCLICommand(ctx context.Context, tenant string, cmdList ...string) []byte {
 	var cmdResult *common.CommandResult
 	var cmdStr, tenantText string
 	var err error
@@ -150,25 +182,25 @@ func TenantRunCLICommand(tenant string, cmdList ...string) []byte {
 		tenantText = fmt.Sprintf(" on behalf of tenant '%s'", tenant)
 	}
 
-	accessFile := GetAccessFile()
-	CliConfigFile, err = MakeConfigFile(tenant)
+	accessFile := GetAccessFile(ctx)
+	configFile, err := MakeConfigFile(ctx, tenant)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return nil
 	}
 	baseCmdStr := fmt.Sprintf("CRAY_CREDENTIALS=%s '%s'", accessFile, cray_cli)
 	for _, cliArg := range cmdList {
 		baseCmdStr = fmt.Sprintf("%s '%s'", baseCmdStr, cliArg)
 	}
-	cmdStr = "CRAY_CONFIG=" + CliConfigFile + " " + baseCmdStr
-	common.Debugf("Running command%s: %s", tenantText, cmdStr)
-	cmdResult, err = common.RunNameWithRetry("bash", "-c", cmdStr)
+	cmdStr = "CRAY_CONFIG=" + configFile + " " + baseCmdStr
+	common.Debugf(ctx, "Running command%s: %s", tenantText, cmdStr)
+	cmdResult, err = common.RunNameWithRetry(ctx, "bash", "-c", cmdStr)
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Error running CLI command%s (%s)", strings.Join(cmdList, " "), tenantText)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error running CLI command%s (%s)", strings.Join(cmdList, " "), tenantText)
 		return nil
-	} else if cmdResult.Rc != GetCliExecreturnCode() {
-		common.Errorf("CLI command%s (%s) failed with exit code %d", tenantText, strings.Join(cmdList, " "), cmdResult.Rc)
+	} else if cmdResult.Rc != GetCliExecreturnCode(ctx) {
+		common.Errorf(ctx, "CLI command%s (%s) failed with exit code %d", tenantText, strings.Join(cmdList, " "), cmdResult.Rc)
 		return nil
 	}
 	// Check for error code, return nil if there is an error
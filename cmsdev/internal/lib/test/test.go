@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,6 +29,7 @@ package test
  */
 
 import (
+	"context"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
 	"strings"
@@ -36,35 +37,35 @@ import (
 
 // given a service name and minimum and maximum counts, return a list
 // a negative value for a minimum or maximum count means it is not checked
-func GetPodNamesInNamespace(namespace, serviceName string, minExpectedCount, maxExpectedCount int) (podNames []string, passed bool) {
+func GetPodNamesInNamespace(ctx context.Context, namespace, serviceName string, minExpectedCount, maxExpectedCount int) (podNames []string, passed bool) {
 	var err error
 	passed = false
 	if minExpectedCount < 0 {
 		minExpectedCount = 0
 	} else if maxExpectedCount >= 0 && maxExpectedCount < minExpectedCount {
-		common.Errorf("Programming logic error: cms.GetPodNames: maxExpectedCount==%d < minExpectedCount==%d",
+		common.Errorf(ctx, "Programming logic error: cms.GetPodNames: maxExpectedCount==%d < minExpectedCount==%d",
 			maxExpectedCount, minExpectedCount,
 		)
 		return
 	}
-	podNames, err = k8s.GetPodNames(namespace, serviceName)
+	podNames, err = k8s.GetPodNames(ctx, namespace, serviceName)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	} else if minExpectedCount == maxExpectedCount {
 		if len(podNames) != minExpectedCount {
-			common.Errorf("Expected exactly %d %s pods in %s namespace but found %d: %s",
+			common.Errorf(ctx, "Expected exactly %d %s pods in %s namespace but found %d: %s",
 				minExpectedCount, serviceName, namespace, len(podNames), strings.Join(podNames, ", "),
 			)
 		} else {
 			passed = true
 		}
 	} else if len(podNames) < minExpectedCount {
-		common.Errorf("Expected at least %d %s pods in %s namespace but found %d: %s",
+		common.Errorf(ctx, "Expected at least %d %s pods in %s namespace but found %d: %s",
 			minExpectedCount, serviceName, namespace, len(podNames), strings.Join(podNames, ", "),
 		)
 	} else if maxExpectedCount > 0 && len(podNames) > maxExpectedCount {
-		common.Errorf("Expected at most %d %s pods in %s namespace but found %d: %s",
+		common.Errorf(ctx, "Expected at most %d %s pods in %s namespace but found %d: %s",
 			maxExpectedCount, serviceName, namespace, len(podNames), strings.Join(podNames, ", "),
 		)
 	} else {
@@ -73,26 +74,29 @@ func GetPodNamesInNamespace(namespace, serviceName string, minExpectedCount, max
 	return
 }
 
-func GetPodNamesByPrefixKey(pkey string, minExpectedCount, maxExpectedCount int) ([]string, bool) {
-	return GetPodNamesInNamespace(common.NAMESPACE, common.PodServiceNamePrefixes[pkey], minExpectedCount, maxExpectedCount)
+// Get the names of the pods for the specified PodServiceNamePrefixes key, verifying that their
+// number is within the configured expected pod count for that key
+func GetPodNamesByPrefixKey(ctx context.Context, pkey string) ([]string, bool) {
+	minExpectedCount, maxExpectedCount := common.ExpectedPodCount(ctx, pkey)
+	return GetPodNamesInNamespace(ctx, common.NAMESPACE, common.PodServiceNamePrefixes[pkey], minExpectedCount, maxExpectedCount)
 }
 
 // give a pod's name, check to see if it and its containers are running
 // Logs any errors encountered
 // Returns true if no errors, false otherwise
-func CheckPodStatsInNamespace(namespace, podName string, params ...string) (passed bool) {
+func CheckPodStatsInNamespace(ctx context.Context, namespace, podName string, params ...string) (passed bool) {
 	var err error
 	var stats *k8s.PodStats
 	passed = true
 
 	// get pod and container status details
-	common.Infof("checking pod status for %s in %s namespace; expecting Running", podName, namespace)
-	stats, err = k8s.GetPodStats(namespace, podName)
+	common.Infof(ctx, "checking pod status for %s in %s namespace; expecting Running", podName, namespace)
+	stats, err = k8s.GetPodStats(ctx, namespace, podName)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		passed = false
 	} else if stats.Phase != "Running" && stats.Phase != "Succeeded" {
-		common.Errorf("pod=%s, phase (%s) is neither Running or Succeeded", podName, stats.Phase)
+		common.Errorf(ctx, "pod=%s, phase (%s) is neither Running or Succeeded", podName, stats.Phase)
 		passed = false
 	} else if len(stats.ContainerStateWaitingReason) != 0 {
 		// check here if all pods are in working order
@@ -102,93 +106,93 @@ func CheckPodStatsInNamespace(namespace, podName string, params ...string) (pass
 			containerName, expectedContainerState = params[0], params[1]
 
 			// Check if targeted container does not exist or is not in the preferred state
-			common.Infof("Checking ContainerStateWaitingReason of container %s in pod %s (expecting %s)", containerName, podName,
+			common.Infof(ctx, "Checking ContainerStateWaitingReason of container %s in pod %s (expecting %s)", containerName, podName,
 				expectedContainerState)
 			containerState, ok = stats.ContainerStateWaitingReason[params[0]]
 			if !ok {
-				common.Errorf("Error checking ContainerStateWaitingReason of container %s in pod %s", containerName, podName)
+				common.Errorf(ctx, "Error checking ContainerStateWaitingReason of container %s in pod %s", containerName, podName)
 				passed = false
 			} else {
 				if containerState != expectedContainerState {
-					common.Errorf("Container %s in pod %s, has ContainerStateWaitingReason=%s but we expect %s", containerName,
+					common.Errorf(ctx, "Container %s in pod %s, has ContainerStateWaitingReason=%s but we expect %s", containerName,
 						podName, containerState, expectedContainerState)
 					passed = false
 				} else {
-					common.Infof("Container %s in pod %s has expected ContainerStateWaitingReason", containerName, podName)
+					common.Infof(ctx, "Container %s in pod %s has expected ContainerStateWaitingReason", containerName, podName)
 				}
 				delete(stats.ContainerStateWaitingReason, params[0])
 			}
 		} else if len(params) > 0 {
-			common.Errorf("PROGRAMMING LOGIC ERROR: Invalid # (%d) of params: %v", len(params), params)
+			common.Errorf(ctx, "PROGRAMMING LOGIC ERROR: Invalid # (%d) of params: %v", len(params), params)
 			passed = false
 		}
 
 		// checked each container status, the expected state for non targeted is the default 'Running'
 		expectedContainerState = "Running"
 		for containerName, containerState = range stats.ContainerStateWaitingReason {
-			common.Infof("Checking ContainerStateWaitingReason of container %s in pod %s (expecting %s)", containerName, podName,
+			common.Infof(ctx, "Checking ContainerStateWaitingReason of container %s in pod %s (expecting %s)", containerName, podName,
 				expectedContainerState)
 			if containerState != expectedContainerState {
-				common.Errorf("Container %s in pod %s, has ContainerStateWaitingReason=%s but we expect %s", containerName,
+				common.Errorf(ctx, "Container %s in pod %s, has ContainerStateWaitingReason=%s but we expect %s", containerName,
 					podName, containerState, expectedContainerState)
 				passed = false
 			} else {
-				common.Infof("Container %s in pod %s has expected ContainerStateWaitingReason", containerName, podName)
+				common.Infof(ctx, "Container %s in pod %s has expected ContainerStateWaitingReason", containerName, podName)
 			}
 		}
 	}
 	if passed {
-		common.VerboseOkayf("Pod status is as expected")
+		common.VerboseOkayf(ctx, "Pod status is as expected")
 	} else {
-		common.VerboseFailed()
+		common.VerboseFailed(ctx)
 	}
 	return
 }
 
 // Wrapper for CheckPodStatsInNamespace that assumes namespace = common.NAMESPACE
-func CheckPodStats(podName string, params ...string) bool {
-	return CheckPodStatsInNamespace(common.NAMESPACE, podName, params...)
+func CheckPodStats(ctx context.Context, podName string, params ...string) bool {
+	return CheckPodStatsInNamespace(ctx, common.NAMESPACE, podName, params...)
 }
 
 // give a pvc name, check to see if its status is Bound
 // Logs any errors encountered
 // Returns true if no errors, false otherwise
-func CheckPVCStatusInNamespace(namespace, pvcName string) (passed bool) {
+func CheckPVCStatusInNamespace(ctx context.Context, namespace, pvcName string) (passed bool) {
 	passed = false
-	common.Infof("checking pvc status for %s in namespace %s; expecting Bound", pvcName, namespace)
-	status, err := k8s.GetPVCStatus(namespace, pvcName)
+	common.Infof(ctx, "checking pvc status for %s in namespace %s; expecting Bound", pvcName, namespace)
+	status, err := k8s.GetPVCStatus(ctx, namespace, pvcName)
 	if err == nil {
 		if len(status) == 0 {
-			common.Errorf("pvc status length returned 0")
+			common.Errorf(ctx, "pvc status length returned 0")
 		} else if status != "Bound" {
-			common.Errorf("Expected Bound status for pvc=%s, found status=%s", pvcName, status)
+			common.Errorf(ctx, "Expected Bound status for pvc=%s, found status=%s", pvcName, status)
 		} else {
 			passed = true
-			common.VerboseOkayf("pvc status is as expected")
+			common.VerboseOkayf(ctx, "pvc status is as expected")
 			return
 		}
 	} else {
-		common.Error(err)
+		common.Error(ctx, err)
 	}
-	common.VerboseFailed()
+	common.VerboseFailed(ctx)
 	return
 }
 
-func CheckPVCStatus(pvcName string) bool {
-	return CheckPVCStatusInNamespace(common.NAMESPACE, pvcName)
+func CheckPVCStatus(ctx context.Context, pvcName string) bool {
+	return CheckPVCStatusInNamespace(ctx, common.NAMESPACE, pvcName)
 }
 
 // Given a list of pod names, calls CheckPodStats on each one of them.
-func CheckPodListStatsInNamespace(namespace string, podNames []string) (passed bool) {
+func CheckPodListStatsInNamespace(ctx context.Context, namespace string, podNames []string) (passed bool) {
 	passed = true
 	for _, podName := range podNames {
-		if !CheckPodStatsInNamespace(namespace, podName) {
+		if !CheckPodStatsInNamespace(ctx, namespace, podName) {
 			passed = false
 		}
 	}
 	return
 }
 
-func CheckPodListStats(podNames []string) bool {
-	return CheckPodListStatsInNamespace(common.NAMESPACE, podNames)
+func CheckPodListStats(ctx context.Context, podNames []string) bool {
+	return CheckPodListStatsInNamespace(ctx, common.NAMESPACE, podNames)
 }
@@ -0,0 +1,222 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * barebones.go
+ *
+ * Barebones image boot test. Customizes the CSM barebones compute image with CFS, then boots a
+ * compute node with it using BOS.
+ *
+ */
+
+package barebones
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	pcu "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/prod-catalog-utils"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/bos"
+)
+
+// Every resource created by the test is named with this prefix, followed by a timestamp
+const namePrefix = "csm-barebones-boot-test-"
+
+const defaultArch = "x86"
+const defaultPlaybook = "compute_nodes.yml"
+
+const helpURLBase = "https://github.com/Cray-HPE/docs-csm/blob"
+const helpURLPath = "troubleshooting/cms_barebones_image_boot.md"
+
+const bosSessionKind = "BOS session"
+const bosSessionTemplateKind = "BOS session template"
+const cfsDesiredConfigKind = "desired configuration of CFS component"
+const cfsConfigurationKind = "CFS configuration"
+const cfsSessionKind = "CFS session"
+const imsImageKind = "IMS image"
+
+// How an architecture (x86 or arm, in the test settings) is named by each service
+type archNames struct {
+	// HSM node Arch values. Nodes with the later values are only used if none have the first.
+	hsm []string
+	// IMS image arch, which also ends the names of the product catalog images
+	ims string
+	// BOS boot set arch
+	bos string
+}
+
+var architectures = map[string]archNames{
+	"x86": {hsm: []string{"X86", "UNKNOWN"}, ims: "x86_64", bos: "X86"},
+	"arm": {hsm: []string{"ARM"}, ims: "aarch64", bos: "ARM"},
+}
+
+func init() {
+	registry.Register(registry.ServiceTest{
+		Name:           "barebones",
+		Description:    "Barebones image boot: customizes the CSM barebones image and boots a compute node with it (reboots the node)",
+		DefaultTimeout: 3600,
+		ExplicitOnly:   true,
+		Run: func(opts registry.RunOptions) bool {
+			return newBarebonesTest(common.GetConfig().Barebones).run(opts.Context)
+		},
+		FindLeftovers: findBarebonesLeftovers,
+	})
+}
+
+// A compute node found in HSM
+type computeNode struct {
+	xname   string
+	arch    string
+	hsmArch string
+}
+
+// The state of one run of the test
+type barebonesTest struct {
+	common.BarebonesConfig
+	// The name of every resource created by the test
+	name    string
+	prodCat pcu.ProdCatalogEntry
+	node    computeNode
+	// The base image to customize, or the customized image if image_id is set
+	baseImage, image bos.ImsImage
+	// The CFS configuration for customizing the image and for the BOS session template, once
+	// it has been created (if cfs_configuration is not set)
+	configuration string
+}
+
+func newBarebonesTest(settings common.BarebonesConfig) *barebonesTest {
+	t := &barebonesTest{
+		BarebonesConfig: settings,
+		name:            namePrefix + time.Now().Format("20060102150405"),
+		configuration:   settings.CFSConfiguration,
+	}
+	if len(t.Playbook) == 0 {
+		t.Playbook = defaultPlaybook
+	}
+	return t
+}
+
+func (t *barebonesTest) run(ctx context.Context) (passed bool) {
+	common.Infof(ctx, "Barebones image boot test: resources created by the test are named %s", t.name)
+	defer func() {
+		if !passed {
+			common.Infof(ctx, "For troubleshooting information and manual steps, see %s", t.helpURL())
+		}
+	}()
+
+	if !t.checkSettings(ctx) || !t.loadProdCatEntry(ctx) {
+		return false
+	}
+	if len(t.node.xname) == 0 && !t.findComputeNode(ctx) {
+		return false
+	}
+	if len(t.ImageID) == 0 {
+		// Without a base image setting, the barebones image in the product catalog is customized
+		if len(t.BaseImageID) == 0 && !t.findBaseImage(ctx) {
+			return false
+		}
+		if !t.customizeImage(ctx) {
+			return false
+		}
+	}
+	if !t.bootNode(ctx) {
+		return false
+	}
+	common.Infof(ctx, "BOS session completed with no errors; compute node %s booted the %s image", t.node.xname, t.image.ImageID)
+
+	if t.KeepOnSuccess {
+		for _, resource := range common.KeepResources(ctx) {
+			common.Infof(ctx, "Not deleting %s, because keep_on_success is set", resource)
+		}
+	}
+	return true
+}
+
+// Make sure that the configuration, images and node named by the settings exist, and choose
+// the architecture from them if it is not set
+func (t *barebonesTest) checkSettings(ctx context.Context) bool {
+	if len(t.CFSConfiguration) > 0 && !cfsConfigurationExists(ctx, t.CFSConfiguration) {
+		return false
+	}
+	var ok bool
+	if len(t.BaseImageID) > 0 {
+		if t.baseImage, ok = getImage(ctx, t.BaseImageID); !ok {
+			return false
+		} else if t.Arch, ok = imageArch(ctx, t.baseImage); !ok {
+			return false
+		}
+		common.Infof(ctx, "Specified base IMS image %s has arch %s", t.BaseImageID, t.Arch)
+	}
+	if len(t.ImageID) > 0 {
+		if t.image, ok = getImage(ctx, t.ImageID); !ok {
+			return false
+		} else if t.Arch, ok = imageArch(ctx, t.image); !ok {
+			return false
+		}
+		common.Infof(ctx, "Specified customized IMS image %s has arch %s", t.ImageID, t.Arch)
+	}
+	if len(t.Xname) > 0 {
+		if t.node, ok = getComputeNode(ctx, t.Xname); !ok {
+			return false
+		}
+		common.Infof(ctx, "Specified compute node %s has arch %s", t.node.xname, t.node.arch)
+		if len(t.Arch) > 0 && t.Arch != t.node.arch {
+			common.Errorf(ctx, "Conflicting architectures: compute node %s is %s, but the specified image is %s", t.node.xname, t.node.arch, t.Arch)
+			return false
+		}
+		t.Arch = t.node.arch
+	}
+	if len(t.Arch) == 0 {
+		common.Infof(ctx, "No architecture, node, or image specified; using the default arch: %s", defaultArch)
+		t.Arch = defaultArch
+	}
+	return true
+}
+
+// Look up the CSM entry in the Cray Product Catalog, for the specified CSM version or the latest one
+func (t *barebonesTest) loadProdCatEntry(ctx context.Context) bool {
+	var err error
+	if len(t.CSMVersion) > 0 {
+		t.prodCat, err = pcu.GetProdCatEntry(ctx, t.CSMVersion)
+	} else {
+		t.prodCat, err = pcu.GetLatestProdCatEntry(ctx)
+	}
+	if err != nil {
+		common.Errorf(ctx, "Unable to get the CSM entry in the Cray Product Catalog: %v", err)
+		return false
+	}
+	common.Infof(ctx, "Using CSM %s from the Cray Product Catalog", t.prodCat.Version)
+	return true
+}
+
+// The troubleshooting documentation is for the CSM release being tested, once it is known
+func (t *barebonesTest) helpURL() string {
+	majorMinor := regexp.MustCompile(`^([1-9][0-9]*[.][0-9]+)([.].*)?$`).FindStringSubmatch(t.prodCat.Version)
+	if majorMinor == nil {
+		return fmt.Sprintf("%s/main/%s", helpURLBase, helpURLPath)
+	}
+	return fmt.Sprintf("%s/release/%s/%s", helpURLBase, majorMinor[1], helpURLPath)
+}
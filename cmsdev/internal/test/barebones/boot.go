@@ -0,0 +1,124 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * boot.go
+ *
+ * Booting the compute node with the customized image, for the barebones image boot test
+ *
+ */
+
+package barebones
+
+import (
+	"context"
+	"fmt"
+
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/bos"
+)
+
+// Create a BOS session template for the customized image, and reboot the node with it
+func (t *barebonesTest) bootNode(ctx context.Context) bool {
+	if len(t.image.Link.S3_Etag) == 0 {
+		common.Errorf(ctx, "IMS image %s has no S3 etag in its link field: %+v", t.image.ImageID, t.image.Link)
+		return false
+	}
+	if !t.getConfiguration(ctx) {
+		return false
+	}
+	params := test.GetAccessTokenParams(ctx)
+	if params == nil {
+		return false
+	}
+	client := bosclient.NewClient(*params)
+	// Look up the URLs to delete the session template and session with before creating them
+	templateURL, err := bosclient.SessionTemplateURL(t.name)
+	if err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	sessionURL, err := bosclient.SessionURL(t.name)
+	if err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+
+	template, ok := bos.BuildBOSSessionTemplate(ctx, t.configuration, true, architectures[t.Arch].bos, t.image.ImageID)
+	if !ok {
+		return false
+	}
+	common.Infof(ctx, "Creating BOS session template %s for IMS image %s", t.name, t.image.ImageID)
+	if _, err := client.PutSessionTemplate(ctx, t.name, template); err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	test.RegisterAPIDeleteCleanup(ctx, bosSessionTemplateKind, t.name, templateURL)
+
+	common.Infof(ctx, "Creating BOS session %s to reboot node %s", t.name, t.node.xname)
+	session := bosclient.SessionCreate{
+		Name:         t.name,
+		TemplateName: t.name,
+		Limit:        t.node.xname,
+		Operation:    "reboot",
+	}
+	if _, err := client.CreateSession(ctx, session); err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	test.RegisterAPIDeleteCleanup(ctx, bosSessionKind, t.name, sessionURL)
+
+	return waitForSession(ctx, bosSessionKind, t.name, func() (sessionStatus, bool) {
+		return getBOSSessionStatus(ctx, client, t.name)
+	})
+}
+
+// Look up a BOS session and its extended status, and return its status
+func getBOSSessionStatus(ctx context.Context, client bosclient.Client, name string) (status sessionStatus, ok bool) {
+	session, err := client.GetSession(ctx, name)
+	if err != nil {
+		common.Error(ctx, err)
+		return status, false
+	}
+	extendedStatus, err := client.GetSessionStatus(ctx, name)
+	if err != nil {
+		common.Error(ctx, err)
+		return status, false
+	}
+	errors := extendedStatus.Errors()
+
+	status.status = session.Status.Status
+	sessionError := session.Status.Error
+	status.details = []string{
+		fmt.Sprintf("error=%q", sessionError),
+		fmt.Sprintf("error_summary=%v", errors),
+		fmt.Sprintf("percent_failed=%.2f", extendedStatus.PercentFailed),
+	}
+	if len(sessionError) > 0 {
+		status.failure = "error: " + sessionError
+	} else if extendedStatus.PercentFailed != 0 {
+		status.failure = fmt.Sprintf("percent_failed is %.2f, with errors: %v", extendedStatus.PercentFailed, errors)
+	}
+	return status, true
+}
@@ -0,0 +1,147 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * hsm.go
+ *
+ * Choosing the compute node booted by the barebones image boot test, from HSM
+ *
+ */
+
+package barebones
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// The fields of an HSM component's state which the test uses
+type hsmComponent struct {
+	ID      string `json:"ID"`
+	Type    string `json:"Type"`
+	Role    string `json:"Role"`
+	Enabled bool   `json:"Enabled"`
+	Arch    string `json:"Arch"`
+}
+
+func hsmComponentsURL() string {
+	return common.BASEURL + "/apis/smd/hsm/v2/State/Components"
+}
+
+// Returns the compute node for an HSM component, or false if its arch is not one the test supports
+func nodeFromComponent(ctx context.Context, component hsmComponent) (node computeNode, ok bool) {
+	for arch, names := range architectures {
+		if common.StringInArray(component.Arch, names.hsm) {
+			return computeNode{xname: component.ID, arch: arch, hsmArch: component.Arch}, true
+		}
+	}
+	common.Errorf(ctx, "Node %s has unsupported arch in HSM: '%s'", component.ID, component.Arch)
+	return computeNode{}, false
+}
+
+// Look up the specified node in HSM, which must be an enabled compute node
+func getComputeNode(ctx context.Context, xname string) (node computeNode, ok bool) {
+	params := test.GetAccessTokenParams(ctx)
+	if params == nil {
+		return computeNode{}, false
+	}
+	common.Infof(ctx, "Querying HSM for the state of node %s", xname)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", hsmComponentsURL()+"/"+xname, *params, http.StatusOK)
+	if err != nil {
+		common.Error(ctx, err)
+		return computeNode{}, false
+	}
+	var component hsmComponent
+	if err := json.Unmarshal(resp.Body(), &component); err != nil {
+		common.Errorf(ctx, "Unable to decode the HSM state of node %s: %v", xname, err)
+		return computeNode{}, false
+	}
+	ok = true
+	if component.Type != "Node" {
+		common.Errorf(ctx, "Node %s should have Type 'Node' in HSM, but it is '%s'", xname, component.Type)
+		ok = false
+	}
+	if component.Role != "Compute" {
+		common.Errorf(ctx, "Node %s should have Role 'Compute' in HSM, but it is '%s'", xname, component.Role)
+		ok = false
+	}
+	if !component.Enabled {
+		common.Errorf(ctx, "Node %s is not enabled in HSM", xname)
+		ok = false
+	}
+	if !ok {
+		return computeNode{}, false
+	}
+	return nodeFromComponent(ctx, component)
+}
+
+// Choose an enabled compute node with the test's architecture. For backwards compatibility, x86
+// includes nodes with UNKNOWN arch in HSM, but those are only chosen if there are no others.
+func (t *barebonesTest) findComputeNode(ctx context.Context) bool {
+	params := test.GetAccessTokenParams(ctx)
+	if params == nil {
+		return false
+	}
+	common.Infof(ctx, "Querying HSM to find an enabled compute node with %s arch", t.Arch)
+	query := url.Values{
+		"type":    {"Node"},
+		"role":    {"Compute"},
+		"enabled": {"true"},
+		"arch":    architectures[t.Arch].hsm,
+	}
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", hsmComponentsURL()+"?"+query.Encode(), *params, http.StatusOK)
+	if err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	var components struct {
+		Components []hsmComponent `json:"Components"`
+	}
+	if err := json.Unmarshal(resp.Body(), &components); err != nil {
+		common.Errorf(ctx, "Unable to decode the HSM component states: %v", err)
+		return false
+	}
+	for _, hsmArch := range architectures[t.Arch].hsm {
+		for _, component := range components.Components {
+			if component.Arch != hsmArch {
+				continue
+			}
+			if hsmArch != architectures[t.Arch].hsm[0] {
+				common.Warnf(ctx, "The only suitable enabled compute node found (%s) has %s arch in HSM", component.ID, hsmArch)
+			}
+			node, ok := nodeFromComponent(ctx, component)
+			if !ok {
+				return false
+			}
+			t.node = node
+			common.Infof(ctx, "Found compute node %s with arch %s", t.node.xname, t.node.arch)
+			return true
+		}
+	}
+	common.Errorf(ctx, "No enabled compute nodes found in HSM with %s arch", t.Arch)
+	return false
+}
@@ -0,0 +1,274 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * images.go
+ *
+ * IMS images and image customization for the barebones image boot test
+ *
+ */
+
+package barebones
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/apiclient"
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/bos"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/test/cfs"
+)
+
+// Returns a CFS v3 client
+func cfsClient(ctx context.Context) (client cfsclient.Client, ok bool) {
+	params := test.GetAccessTokenParams(ctx)
+	if params == nil {
+		return client, false
+	}
+	return cfsclient.NewClient(*params), true
+}
+
+// IMS v2 is used to delete images, because it can also delete their S3 artifacts
+func imsImageDeleteURL(imageID string) string {
+	return common.BASEURL + "/apis/ims/v2/images/" + imageID + "?cascade=True"
+}
+
+// Look up an image in IMS
+func getImage(ctx context.Context, imageID string) (image bos.ImsImage, ok bool) {
+	common.Infof(ctx, "Looking up IMS image %s", imageID)
+	if image, ok = bos.GetImageRecord(ctx, imageID); !ok {
+		common.Errorf(ctx, "Unable to get IMS image %s", imageID)
+	}
+	return
+}
+
+// Returns the test architecture (x86 or arm) of an IMS image
+func imageArch(ctx context.Context, image bos.ImsImage) (arch string, ok bool) {
+	for arch, names := range architectures {
+		if image.Arch == names.ims {
+			return arch, true
+		}
+	}
+	common.Errorf(ctx, "IMS image %s has unsupported arch: '%s'", image.ImageID, image.Arch)
+	return "", false
+}
+
+// Make sure that the specified CFS configuration exists
+func cfsConfigurationExists(ctx context.Context, name string) bool {
+	client, ok := cfsClient(ctx)
+	if !ok {
+		return false
+	}
+	if _, err := client.GetConfiguration(ctx, name); apiclient.IsNotFound(err) {
+		common.Errorf(ctx, "Specified CFS configuration %s does not exist", name)
+		return false
+	} else if err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	common.Infof(ctx, "Specified CFS configuration %s exists", name)
+	return true
+}
+
+// Find the barebones compute image for the test architecture in the product catalog, and make
+// sure that IMS agrees about its architecture
+func (t *barebonesTest) findBaseImage(ctx context.Context) bool {
+	namePattern := regexp.MustCompile("^compute-.+-" + regexp.QuoteMeta(architectures[t.Arch].ims) + "$")
+	var names []string
+	for name := range t.prodCat.Images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !namePattern.MatchString(name) {
+			common.Debugf(ctx, "Skipping product catalog image '%s'", name)
+			continue
+		}
+		common.Infof(ctx, "Found barebones image in the product catalog: %s (%s)", name, t.prodCat.Images[name].ID)
+		var ok bool
+		if t.baseImage, ok = getImage(ctx, t.prodCat.Images[name].ID); !ok {
+			return false
+		}
+		if arch, ok := imageArch(ctx, t.baseImage); !ok {
+			return false
+		} else if arch != t.Arch {
+			common.Errorf(ctx, "IMS image %s from the product catalog should have %s arch, but it has %s arch", t.baseImage.ImageID, t.Arch, arch)
+			return false
+		}
+		return true
+	}
+	common.Errorf(ctx, "No barebones %s compute image found in the Cray Product Catalog for CSM %s", t.Arch, t.prodCat.Version)
+	return false
+}
+
+// Returns the layer of the CFS configuration created by the test. Unless they are specified in
+// the settings, the repository and commit are the CSM ones from the product catalog, with the
+// clone URL changed to go through the API gateway.
+func (t *barebonesTest) configurationLayer(ctx context.Context) (layer cfs.CsmProductCatalogConfiguration, ok bool) {
+	layer.Clone_url = t.VCSURL
+	if len(layer.Clone_url) == 0 {
+		cloneURL := t.prodCat.Configuration.CloneURL
+		vcsIndex := strings.Index(cloneURL, "/vcs/")
+		if vcsIndex < 0 {
+			common.Errorf(ctx, "/vcs/ not found in the clone URL in the Cray Product Catalog: '%s'", cloneURL)
+			return layer, false
+		}
+		layer.Clone_url = common.BASEURL + cloneURL[vcsIndex:]
+	}
+	layer.Commit = t.GitCommit
+	if len(layer.Commit) == 0 {
+		layer.Commit = t.prodCat.Configuration.Commit
+	}
+	if len(layer.Commit) == 0 {
+		common.Errorf(ctx, "No commit found in the Cray Product Catalog for CSM %s", t.prodCat.Version)
+		return layer, false
+	}
+	return layer, true
+}
+
+// Create the CFS configuration used for customizing the image and booting the node, unless one
+// is specified in the settings or was already created
+func (t *barebonesTest) getConfiguration(ctx context.Context) bool {
+	if len(t.configuration) > 0 {
+		return true
+	}
+	layer, ok := t.configurationLayer(ctx)
+	if !ok {
+		return false
+	}
+	payload := cfs.GetCFGConfigurationPayloadForLayer(ctx, layer, t.Playbook, false)
+	common.Infof(ctx, "Creating CFS configuration %s", t.name)
+	if _, ok = cfs.CreateUpdateCFSConfigurationRecordAPI(ctx, t.name, "v3", payload, http.StatusOK); !ok {
+		return false
+	}
+	t.configuration = t.name
+
+	// Booting the node with BOS sets the configuration as the desired configuration of its CFS
+	// component, which must be undone before the configuration can be deleted. Cleanups run in
+	// the reverse order of registration, so this one runs just before the configuration is deleted.
+	xname := t.node.xname
+	common.RegisterCleanup(ctx, cfsDesiredConfigKind, xname, func() bool {
+		return resetDesiredConfig(ctx, xname, t.name)
+	})
+	return true
+}
+
+// Clear the desired configuration of a CFS component, if it is the specified configuration
+func resetDesiredConfig(ctx context.Context, xname, configuration string) bool {
+	client, ok := cfsClient(ctx)
+	if !ok {
+		return false
+	}
+	component, err := client.GetComponent(ctx, xname)
+	if apiclient.IsNotFound(err) {
+		common.Infof(ctx, "There is no CFS component for %s", xname)
+		return true
+	} else if err != nil {
+		common.Error(ctx, err)
+		return false
+	} else if component.DesiredConfig != configuration {
+		common.Infof(ctx, "The desired configuration of CFS component %s is '%s'; not changing it", xname, component.DesiredConfig)
+		return true
+	}
+	noConfig := ""
+	if _, err = client.UpdateComponent(ctx, xname, cfsclient.ComponentUpdate{DesiredConfig: &noConfig}); err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	common.Infof(ctx, "Cleared the desired configuration of CFS component %s", xname)
+	return true
+}
+
+// Customize the base image with a CFS session, and look up the resulting image in IMS
+func (t *barebonesTest) customizeImage(ctx context.Context) bool {
+	if !t.getConfiguration(ctx) {
+		return false
+	}
+	client, ok := cfsClient(ctx)
+	if !ok {
+		return false
+	}
+	// Look up the URL to delete the session with before creating it
+	sessionURL, err := cfsclient.SessionURL(t.name)
+	if err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	common.Infof(ctx, "Creating CFS session %s to customize IMS image %s with CFS configuration %s", t.name, t.baseImage.ImageID, t.configuration)
+	_, err = client.CreateSession(ctx, cfsclient.SessionCreate{
+		Name:              t.name,
+		ConfigurationName: t.configuration,
+		Target: &cfsclient.Target{
+			Definition: "image",
+			Groups:     []cfsclient.TargetGroup{{Name: "Compute", Members: []string{t.baseImage.ImageID}}},
+			ImageMap:   []cfsclient.ImageMapping{{SourceID: t.baseImage.ImageID, ResultName: t.name}},
+		},
+	})
+	if err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	test.RegisterAPIDeleteCleanup(ctx, cfsSessionKind, t.name, sessionURL)
+
+	var session cfsclient.Session
+	if !waitForSession(ctx, cfsSessionKind, t.name, func() (sessionStatus, bool) {
+		return getCFSSessionStatus(ctx, client, t.name, &session)
+	}) {
+		return false
+	}
+
+	artifacts := session.Status.Artifacts
+	if len(artifacts) != 1 {
+		common.Errorf(ctx, "CFS session %s should have exactly one artifact, but it has %d: %v", t.name, len(artifacts), artifacts)
+		return false
+	}
+	resultID := artifacts[0].ResultID
+	if len(resultID) == 0 {
+		common.Errorf(ctx, "CFS session %s artifact has no result_id: %v", t.name, artifacts[0])
+		return false
+	}
+	test.RegisterAPIDeleteCleanup(ctx, imsImageKind, resultID, imsImageDeleteURL(resultID))
+	common.Infof(ctx, "CFS session %s created customized IMS image %s", t.name, resultID)
+	t.image, ok = getImage(ctx, resultID)
+	return ok
+}
+
+// Look up a CFS session, and return its status
+func getCFSSessionStatus(ctx context.Context, client cfsclient.Client, name string, session *cfsclient.Session) (status sessionStatus, ok bool) {
+	var err error
+	if *session, err = client.GetSession(ctx, name); err != nil {
+		common.Error(ctx, err)
+		return status, false
+	}
+	status.status = session.Status.Session.Status
+	status.details = []string{"succeeded=" + session.Status.Session.Succeeded}
+	if status.status == "complete" && session.Status.Session.Succeeded != "true" {
+		status.failure = "succeeded is '" + session.Status.Session.Succeeded + "'"
+	}
+	return status, true
+}
@@ -0,0 +1,208 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * leftovers.go
+ *
+ * Finding the resources left behind by earlier runs of the barebones image boot test
+ *
+ */
+
+package barebones
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+var resourceNamePattern = regexp.MustCompile("^" + regexp.QuoteMeta(namePrefix) + "[0-9]{14}$")
+
+// The name and ID of a listed IMS record
+type record struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// Returns a BOS client, for listing the BOS sessions and session templates
+func bosClient(ctx context.Context) (client bosclient.Client, ok bool) {
+	params := test.GetAccessTokenParams(ctx)
+	if params == nil {
+		return client, false
+	}
+	return bosclient.NewClient(*params), true
+}
+
+// List the records returned by an IMS request
+func listRecords(ctx context.Context, listURL string) (records []record, ok bool) {
+	params := test.GetAccessTokenParams(ctx)
+	if params == nil {
+		return nil, false
+	}
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", listURL, *params, http.StatusOK)
+	if err != nil {
+		common.Warnf(ctx, "%v", err)
+		return nil, false
+	}
+	if err = json.Unmarshal(resp.Body(), &records); err != nil {
+		common.Warnf(ctx, "Unable to decode the response to GET %s: %v", listURL, err)
+		return nil, false
+	}
+	return records, true
+}
+
+// Before a leftover configuration can be deleted, it must not be the desired configuration
+// of any CFS component
+func deleteConfiguration(ctx context.Context, name string) bool {
+	client, ok := cfsClient(ctx)
+	if !ok {
+		return false
+	}
+	query := url.Values{}
+	query.Set("config_name", name)
+	components := client.Components(ctx, query)
+	for components.Next() {
+		if !resetDesiredConfig(ctx, components.ID(), name) {
+			return false
+		}
+	}
+	if err := components.Err(); err != nil {
+		common.Warnf(ctx, "%v", err)
+		return false
+	}
+	configurationURL, err := cfsclient.ConfigurationURL(name)
+	if err != nil {
+		common.Warnf(ctx, "%v", err)
+		return false
+	}
+	return test.DeleteURLs(ctx, configurationURL)
+}
+
+// Find the resources created by the test, in the reverse order of their creation: BOS sessions,
+// BOS session templates, IMS images, CFS sessions, and CFS configurations
+func findBarebonesLeftovers(opts registry.CleanupOptions) (leftovers []registry.Leftover, ok bool) {
+	ctx := opts.Context
+	ok = true
+	if client, listed := bosClient(ctx); !listed {
+		ok = false
+	} else {
+		if sessions, err := client.ListSessions(ctx); err != nil {
+			common.Warnf(ctx, "%v", err)
+			ok = false
+		} else {
+			for _, session := range sessions {
+				if resourceNamePattern.MatchString(session.Name) {
+					sessionURL, err := bosclient.SessionURL(session.Name)
+					if err != nil {
+						common.Warnf(ctx, "%v", err)
+						ok = false
+						continue
+					}
+					leftovers = append(leftovers, registry.Leftover{
+						Kind: bosSessionKind, Name: session.Name,
+						Delete: func() bool { return test.DeleteURLs(ctx, sessionURL) },
+					})
+				}
+			}
+		}
+		if templates, err := client.ListSessionTemplates(ctx); err != nil {
+			common.Warnf(ctx, "%v", err)
+			ok = false
+		} else {
+			for _, template := range templates {
+				if resourceNamePattern.MatchString(template.Name) {
+					templateURL, err := bosclient.SessionTemplateURL(template.Name)
+					if err != nil {
+						common.Warnf(ctx, "%v", err)
+						ok = false
+						continue
+					}
+					leftovers = append(leftovers, registry.Leftover{
+						Kind: bosSessionTemplateKind, Name: template.Name,
+						Delete: func() bool { return test.DeleteURLs(ctx, templateURL) },
+					})
+				}
+			}
+		}
+	}
+	if images, listed := listRecords(ctx, common.BASEURL+"/apis/ims/v3/images"); !listed {
+		ok = false
+	} else {
+		for _, image := range images {
+			if resourceNamePattern.MatchString(image.Name) {
+				imageURL := imsImageDeleteURL(image.ID)
+				leftovers = append(leftovers, registry.Leftover{
+					Kind: imsImageKind, Name: image.Name + " (" + image.ID + ")",
+					Delete: func() bool { return test.DeleteURLs(ctx, imageURL) },
+				})
+			}
+		}
+	}
+	client, listed := cfsClient(ctx)
+	if !listed {
+		return leftovers, false
+	}
+	query := url.Values{}
+	query.Set("name_contains", namePrefix)
+	if sessions, err := client.ListSessions(ctx, query); err != nil {
+		common.Warnf(ctx, "%v", err)
+		ok = false
+	} else {
+		for _, session := range sessions {
+			if resourceNamePattern.MatchString(session.Name) {
+				sessionURL, err := cfsclient.SessionURL(session.Name)
+				if err != nil {
+					common.Warnf(ctx, "%v", err)
+					ok = false
+					continue
+				}
+				leftovers = append(leftovers, registry.Leftover{
+					Kind: cfsSessionKind, Name: session.Name,
+					Delete: func() bool { return test.DeleteURLs(ctx, sessionURL) },
+				})
+			}
+		}
+	}
+	if configurations, err := client.ListConfigurations(ctx, nil); err != nil {
+		common.Warnf(ctx, "%v", err)
+		ok = false
+	} else {
+		for _, configuration := range configurations {
+			if resourceNamePattern.MatchString(configuration.Name) {
+				name := configuration.Name
+				leftovers = append(leftovers, registry.Leftover{
+					Kind: cfsConfigurationKind, Name: name,
+					Delete: func() bool { return deleteConfiguration(ctx, name) },
+				})
+			}
+		}
+	}
+	return
+}
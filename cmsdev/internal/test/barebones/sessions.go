@@ -0,0 +1,112 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * sessions.go
+ *
+ * Waiting for the CFS and BOS sessions of the barebones image boot test to complete
+ *
+ */
+
+package barebones
+
+import (
+	"context"
+	"time"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+)
+
+const sessionPollInterval = 10 * time.Second
+
+// A session which has not left the pending status by then is considered stuck
+const sessionStartTimeout = 10 * time.Minute
+
+const sessionTimeout = 30 * time.Minute
+
+// The status of a CFS or BOS session
+type sessionStatus struct {
+	// The status field of the session (pending, running, or complete)
+	status string
+	// Other status fields, as "name=value", whose changes are logged
+	details []string
+	// If the session is complete, why it failed (empty if it succeeded)
+	failure string
+}
+
+func (s sessionStatus) started() bool {
+	return len(s.status) > 0 && s.status != "pending"
+}
+
+func (s sessionStatus) completed() bool {
+	return s.status == "complete"
+}
+
+// Log the status fields which differ from the previous status
+func (s sessionStatus) logChanges(ctx context.Context, kind, name string, previous *sessionStatus) {
+	if previous == nil || s.status != previous.status {
+		common.Infof(ctx, "%s %s status is '%s'", kind, name, s.status)
+	}
+	for i, detail := range s.details {
+		if previous == nil || i >= len(previous.details) || detail != previous.details[i] {
+			common.Infof(ctx, "%s %s %s", kind, name, detail)
+		}
+	}
+}
+
+// Poll the status of a session until it completes, and return true if it succeeded. getStatus
+// returns false if the status could not be found (which it logs).
+func waitForSession(ctx context.Context, kind, name string, getStatus func() (sessionStatus, bool)) bool {
+	common.Infof(ctx, "Waiting for %s %s to complete", kind, name)
+	startTime := time.Now()
+	var previous *sessionStatus
+	started := false
+	for {
+		status, ok := getStatus()
+		if !ok {
+			return false
+		}
+		status.logChanges(ctx, kind, name, previous)
+		previous = &status
+		if status.completed() {
+			if len(status.failure) > 0 {
+				common.Errorf(ctx, "%s %s completed but was not successful: %s", kind, name, status.failure)
+				return false
+			}
+			common.Infof(ctx, "%s %s completed successfully", kind, name)
+			return true
+		}
+		started = started || status.started()
+		elapsed := time.Since(startTime)
+		if elapsed > sessionTimeout {
+			common.Errorf(ctx, "%s %s has not completed even after %v", kind, name, sessionTimeout)
+			return false
+		} else if !started && elapsed > sessionStartTimeout {
+			common.Errorf(ctx, "%s %s is not running or complete even after %v", kind, name, sessionStartTimeout)
+			return false
+		}
+		if !common.Sleep(ctx, sessionPollInterval) {
+			common.Errorf(ctx, "Interrupted while waiting for %s %s to complete", kind, name)
+			return false
+		}
+	}
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,10 +29,12 @@ package bos
  */
 
 import (
+	"context"
 	"strings"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
@@ -42,29 +44,105 @@ const defaultTenantName = "cmsdev-tenant"
 
 // Return a random tenant from the list
 // If the list is empty, return defaultTenantName
-func getAnyTenant(tenantList []string) string {
+func getAnyTenant(ctx context.Context, tenantList []string) string {
 	if len(tenantList) > 0 {
 		if tenantName, err := common.GetRandomStringFromList(tenantList); err == nil {
-			common.Debugf("Randomly selected tenant name '%s'", tenantName)
+			common.Debugf(ctx, "Randomly selected tenant name '%s'", tenantName)
 			return tenantName
 		}
 	}
-	common.Debugf("Returning default tenant name '%s'", defaultTenantName)
+	common.Debugf(ctx, "Returning default tenant name '%s'", defaultTenantName)
 	return defaultTenantName
 }
 
-func IsBOSRunning(includeCLI, includeTenant bool) (passed bool) {
+// Subtest names, for use with the --only and --skip options
+var bosAPISubtests = []string{"bos.api.version", "bos.api.healthz", "bos.api.components", "bos.api.options",
+	"bos.api.sessiontemplates", "bos.api.sessions"}
+var bosCLISubtests = []string{"bos.cli.version", "bos.cli.healthz", "bos.cli.components", "bos.cli.options",
+	"bos.cli.sessiontemplates", "bos.cli.sessions"}
+
+func init() {
+	registry.Register(registry.ServiceTest{
+		Name:            "bos",
+		Description:     "Boot Orchestration Service: pods, API, session template and session CRUD",
+		DefaultTimeout:  300,
+		SupportsCLI:     true,
+		SupportsTenant:  true,
+		Subtests:        append(append([]string{"bos.pods"}, bosAPISubtests...), bosCLISubtests...),
+		ClusterSubtests: []string{"bos.pods"},
+		Run: func(opts registry.RunOptions) bool {
+			return IsBOSRunning(opts.Context, opts.IncludeCLI, opts.IncludeTenant)
+		},
+		FindLeftovers: findBOSLeftovers,
+	})
+}
+
+func IsBOSRunning(ctx context.Context, includeCLI, includeTenant bool) (passed bool) {
 	var err error
 	var tenantList []string
+	var ok bool
 	artifactsCollected := false
+	var podNames []string
+	passed = true
+
+	if !registry.RunSubtest(ctx, "bos.pods", func(ctx context.Context) bool { podNames, ok = checkBOSPods(ctx); return ok }) {
+		passed = false
+	}
+
+	if !passed {
+		common.ArtifactsKubernetes(ctx)
+		if len(podNames) > 0 {
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, podNames)
+		}
+		artifactsCollected = true
+	}
+
+	runAPI := registry.AnySubtestSelected(bosAPISubtests...)
+	runCLI := includeCLI && registry.AnySubtestSelected(bosCLISubtests...)
+	if runAPI || runCLI {
+		// Get list of defined tenants on the system (if any)
+		tenantList, err = k8s.GetTenants(ctx)
+		if err != nil {
+			common.VerboseFailedf(ctx, "%s", err.Error())
+			passed = false
+			// Set tenantList to an empty list -- some tenant tests can still run even if no tenants are known
+			tenantList = []string{}
+		}
+	}
+
+	// Defined in bos_api.go
+	if runAPI && !apiTests(ctx, tenantList, includeTenant) {
+		passed = false
+	}
+
+	// CLI tests will be run only if requested using the include-cli flag
+	if runCLI {
+		// Defined in bos_cli.go
+		if !cliTests(ctx, tenantList, includeTenant) {
+			passed = false
+		}
+	}
+
+	if !passed && !artifactsCollected {
+		common.ArtifactsKubernetes(ctx)
+		if len(podNames) > 0 {
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, podNames)
+		}
+	}
+
+	return
+}
+
+// Check the status of the BOS pods. Returns the pod names and true if they all look okay.
+func checkBOSPods(ctx context.Context) (podNames []string, passed bool) {
 	passed = true
 
-	// Look for at least 3 bos pods, although we know there are more.
-	podNames, ok := test.GetPodNamesByPrefixKey("bos", 3, -1)
+	// By default, look for at least 3 bos pods, although we know there are more.
+	podNames, ok := test.GetPodNamesByPrefixKey(ctx, "bos")
 	if !ok {
 		passed = false
 	}
-	common.Infof("Found %d bos pods", len(podNames))
+	common.Infof(ctx, "Found %d bos pods", len(podNames))
 	// The BOS pod zoo has become rather unruly. Parts of this test were
 	// passing more by accident than anything else. Given that, this
 	// section of the check has been simplified.
@@ -75,14 +153,14 @@ func IsBOSRunning(includeCLI, includeTenant bool) (passed bool) {
 	numMigrationPodsSucceeded := 0
 	numMigrationPodsNotSucceeded := 0
 	for _, podName := range podNames {
-		common.Infof("Getting pod status for %s", podName)
-		status, err := k8s.GetPodStatus(common.NAMESPACE, podName)
+		common.Infof(ctx, "Getting pod status for %s", podName)
+		status, err := k8s.GetPodStatus(ctx, common.NAMESPACE, podName)
 		if err != nil {
-			common.VerboseFailedf(err.Error())
+			common.VerboseFailedf(ctx, "%s", err.Error())
 			passed = false
 			continue
 		} else {
-			common.Infof("Pod %s status is %s", podName, status)
+			common.Infof(ctx, "Pod %s status is %s", podName, status)
 		}
 
 		if strings.HasPrefix(podName, "cray-bos-migration-") {
@@ -92,53 +170,16 @@ func IsBOSRunning(includeCLI, includeTenant bool) (passed bool) {
 				numMigrationPodsNotSucceeded += 1
 			}
 		} else if status == "Succeeded" {
-			common.Warnf("Pod %s has status %s", podName, status)
+			common.Warnf(ctx, "Pod %s has status %s", podName, status)
 		} else if status != "Running" {
-			common.VerboseFailedf("Pod %s has status %s, but we expect it to be Running", podName, status)
+			common.VerboseFailedf(ctx, "Pod %s has status %s, but we expect it to be Running", podName, status)
 			passed = false
 		}
 	}
 
 	if numMigrationPodsNotSucceeded > 0 && numMigrationPodsSucceeded == 0 {
-		common.VerboseFailedf("There are %d cray-bos-migration pods, and none of them have status Succeeded", numMigrationPodsNotSucceeded)
-		passed = false
-	}
-
-	if !passed {
-		common.ArtifactsKubernetes()
-		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
-		}
-		artifactsCollected = true
-	}
-
-	// Get list of defined tenants on the system (if any)
-	tenantList, err = k8s.GetTenants()
-	if err != nil {
-		common.VerboseFailedf(err.Error())
+		common.VerboseFailedf(ctx, "There are %d cray-bos-migration pods, and none of them have status Succeeded", numMigrationPodsNotSucceeded)
 		passed = false
-		// Set tenantList to an empty list -- some tenant tests can still run even if no tenants are known
-		tenantList = []string{}
-	}
-
-	// Defined in bos_api.go
-	if !apiTests(tenantList, includeTenant) {
-		passed = false
-	}
-
-	// CLI tests will be run only if requested using the include-cli flag
-	if includeCLI {
-		// Defined in bos_cli.go
-		if !cliTests(tenantList, includeTenant) {
-			passed = false
-		}
-	}
-
-	if !passed && !artifactsCollected {
-		common.ArtifactsKubernetes()
-		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
-		}
 	}
 
 	return
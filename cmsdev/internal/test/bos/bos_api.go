@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2022-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2022-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,98 +29,96 @@ package bos
  */
 
 import (
-	resty "gopkg.in/resty.v1"
-	"net/http"
+	"context"
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-// BOS base URLs and URIs
-const bosBaseUrl = common.BASEURL + "/apis/bos"
-const bosV2BaseUri = "/v2"
+// Kinds of BOS resources, for the cleanup registry
+const bosSessionKind = "BOS session"
+const bosSessionTemplateKind = "BOS session template"
 
-// A wrapper for the common test.RestfulVerifyStatus function that converts a BOS URI into the full URL before
-// calling that function
-func bosRestfulVerifyStatus(method, uri string, params *common.Params, ExpectedStatus int) (*resty.Response, error) {
-	return test.RestfulVerifyStatus(method, bosBaseUrl+uri, *params, ExpectedStatus)
-}
-
-func bosTenantRestfulVerifyStatus(method, uri, tenant string, params *common.Params, ExpectedStatus int) (*resty.Response, error) {
-	return test.TenantRestfulVerifyStatus(method, bosBaseUrl+uri, tenant, *params, ExpectedStatus)
-}
-
-// Given a BOS URI, do a GET request to it. Verify that the response has 200 status code and returns a dictionary (aka string map) object.
-// Return true if all of that worked fine. Otherwise, log an appropriate error and return false.
-func basicGetUriVerifyStringMapTest(uri string, params *common.Params) bool {
-	common.Infof("GET %s test scenario", uri)
-	resp, err := bosRestfulVerifyStatus("GET", uri, params, http.StatusOK)
+func registerBOSSessionCleanup(ctx context.Context, sessionName string) {
+	sessionURL, err := bosclient.SessionURL(sessionName)
 	if err != nil {
-		common.Error(err)
-		return false
+		common.Failuref(ctx, "%v", err)
 	}
+	test.RegisterAPIDeleteCleanup(ctx, bosSessionKind, sessionName, sessionURL)
+}
 
-	// Validate that object can be decoded into a string map at least
-	_, err = common.DecodeJSONIntoStringMap(resp.Body())
+func registerBOSSessionTemplateCleanup(ctx context.Context, templateName string) {
+	templateURL, err := bosclient.SessionTemplateURL(templateName)
 	if err != nil {
-		common.Error(err)
-		return false
+		common.Failuref(ctx, "%v", err)
 	}
-	return true
+	test.RegisterAPIDeleteCleanup(ctx, bosSessionTemplateKind, templateName, templateURL)
 }
 
-func basicTenantGetUriVerifyStringMapTest(uri, tenant string, params *common.Params) bool {
-	common.Infof("GET %s (tenant: %s) test scenario", uri, tenant)
-	resp, err := bosTenantRestfulVerifyStatus("GET", uri, tenant, params, http.StatusOK)
-	if err != nil {
-		common.Error(err)
-		return false
+// Returns the path of a BOS endpoint in the version of the API which the client uses (like
+// /v2/sessions), for log messages
+func endpointPath(ctx context.Context, name string) string {
+	return "/" + bosclient.APIVersion + serviceEndpoint(ctx, "bos", name).Uri
+}
+
+// Logs the start of a BOS API test scenario, noting the tenant of the client (if any)
+func apiScenario(ctx context.Context, client bosclient.Client, method, uri string) {
+	if len(client.Tenant()) == 0 {
+		common.Infof(ctx, "%s %s test scenario", method, uri)
+	} else {
+		common.Infof(ctx, "%s %s (tenant: %s) test scenario", method, uri, client.Tenant())
 	}
+}
 
-	// Validate that object can be decoded into a string map at least
-	_, err = common.DecodeJSONIntoStringMap(resp.Body())
+// Returns true if err is nil. Otherwise logs it as an error and returns false.
+func checkNoError(ctx context.Context, err error) bool {
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 	return true
 }
 
 // Run all of the BOS API subtests. Return true if they all pass, false otherwise.
-func apiTests(tenantList []string, includeTenant bool) (passed bool) {
+func apiTests(ctx context.Context, tenantList []string, includeTenant bool) (passed bool) {
 	passed = true
 
-	params := test.GetAccessTokenParams()
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return false
 	}
+	client := bosclient.NewClient(*params)
 
 	// Defined in bos_version.go
-	if !versionTestsAPI(params, tenantList) {
+	if !registry.RunSubtest(ctx, "bos.api.version", func(ctx context.Context) bool { return versionTestsAPI(ctx, client, tenantList) }) {
 		passed = false
 	}
 
 	// Defined in bos_healthz.go
-	if !healthzTestsAPI(params, tenantList) {
+	if !registry.RunSubtest(ctx, "bos.api.healthz", func(ctx context.Context) bool { return healthzTestsAPI(ctx, client, tenantList) }) {
 		passed = false
 	}
 
 	// Defined in bos_components.go
-	if !componentsTestsAPI(params, tenantList) {
+	if !registry.RunSubtest(ctx, "bos.api.components", func(ctx context.Context) bool { return componentsTestsAPI(ctx, client, tenantList) }) {
 		passed = false
 	}
 
 	// Defined in bos_options.go
-	if !optionsTestsAPI(params) {
+	if !registry.RunSubtest(ctx, "bos.api.options", func(ctx context.Context) bool { return optionsTestsAPI(ctx, client) }) {
 		passed = false
 	}
 
 	// Defined in bos_sessiontemplate.go
-	if !sessionTemplatesTestsAPI(params, tenantList, includeTenant) {
+	if !registry.RunSubtest(ctx, "bos.api.sessiontemplates", func(ctx context.Context) bool {
+		return sessionTemplatesTestsAPI(ctx, client, tenantList, includeTenant)
+	}) {
 		passed = false
 	}
 
 	// Defined in bos_session.go
-	if !sessionsTestsAPI(params, tenantList, includeTenant) {
+	if !registry.RunSubtest(ctx, "bos.api.sessions", func(ctx context.Context) bool { return sessionsTestsAPI(ctx, client, tenantList, includeTenant) }) {
 		passed = false
 	}
 
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2021-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2021-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,176 +29,103 @@ package bos
  */
 
 import (
+	"context"
 	"strings"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
 // Wrapper function for test.RunCLICommandJSON. Prepends "bos" to a CLI command list and then runs it.
-func runBosCLI(cmdArgs ...string) []byte {
-	common.Infof("Testing BOS CLI (%s)", strings.Join(cmdArgs, " "))
-	return test.RunCLICommandJSON("bos", cmdArgs...)
+func runBosCLI(ctx context.Context, cmdArgs ...string) []byte {
+	common.Infof(ctx, "Testing BOS CLI (%s)", strings.Join(cmdArgs, " "))
+	return test.RunCLICommandJSON(ctx, "bos", cmdArgs...)
 }
 
 // Same but for TenantRunCLICommandJSON
 // If tenant is empty string it means no tenant
-func runTenantBosCLI(tenant string, cmdArgs ...string) []byte {
+func runTenantBosCLI(ctx context.Context, tenant string, cmdArgs ...string) []byte {
 	if len(tenant) == 0 {
-		return runBosCLI(cmdArgs...)
+		return runBosCLI(ctx, cmdArgs...)
 	}
-	common.Infof("Testing BOS CLI (%s) on behalf of tenant '%s'", strings.Join(cmdArgs, " "), tenant)
-	return test.TenantRunCLICommandJSON(tenant, "bos", cmdArgs...)
+	common.Infof(ctx, "Testing BOS CLI (%s) on behalf of tenant '%s'", strings.Join(cmdArgs, " "), tenant)
+	return test.TenantRunCLICommandJSON(ctx, tenant, "bos", cmdArgs...)
 }
 
 // Wrapper function for runBosCLI. Append "list" to a CLI command list and then runs it.
-func runBosCLIList(cmdArgs ...string) []byte {
+func runBosCLIList(ctx context.Context, cmdArgs ...string) []byte {
 	newCmdArgs := append(cmdArgs, "list")
-	return runBosCLI(newCmdArgs...)
+	return runBosCLI(ctx, newCmdArgs...)
 }
 
 // Same but for runTenantBosCLI
 // If tenant is empty string it means no tenant
-func runTenantBosCLIList(tenant string, cmdArgs ...string) []byte {
+func runTenantBosCLIList(ctx context.Context, tenant string, cmdArgs ...string) []byte {
 	newCmdArgs := append(cmdArgs, "list")
-	return runTenantBosCLI(tenant, newCmdArgs...)
+	return runTenantBosCLI(ctx, tenant, newCmdArgs...)
 }
 
 // Wrapper function for runBosCLI. Append "describe <target>" to a CLI command list and then runs it.
-func runBosCLIDescribe(target string, cmdArgs ...string) []byte {
+func runBosCLIDescribe(ctx context.Context, target string, cmdArgs ...string) []byte {
 	newCmdArgs := append(cmdArgs, "describe", target)
-	return runBosCLI(newCmdArgs...)
+	return runBosCLI(ctx, newCmdArgs...)
 }
 
 // Same but for runTenantBosCLI
 // If tenant is empty string it means no tenant
-func runTenantBosCLIDescribe(tenant, target string, cmdArgs ...string) []byte {
+func runTenantBosCLIDescribe(ctx context.Context, tenant, target string, cmdArgs ...string) []byte {
 	newCmdArgs := append(cmdArgs, "describe", target)
-	return runTenantBosCLI(tenant, newCmdArgs...)
-}
-
-// Runs a BOS CLI list with the specified arguments
-// If tenant is empty string it means no tenant
-// Parses the result to convert it to a list of dictionaries with string keys
-// Returns the result and a boolean indicating whether or not this was successful (true == no errors)
-func bosTenantListCli(tenant string, cmdArgs ...string) (dictList []map[string]interface{}, passed bool) {
-	var err error
-
-	passed = false
-	cmdOut := runTenantBosCLIList(tenant, cmdArgs...)
-	if cmdOut == nil {
-		return
-	}
-
-	// Decode JSON into a list of string maps
-	dictList, err = common.DecodeJSONIntoStringMapList(cmdOut)
-	if err != nil {
-		common.Error(err)
-		return
-	}
-	passed = true
-	return
-}
-
-func bosListCli(cmdArgs ...string) (dictList []map[string]interface{}, passed bool) {
-	return bosTenantListCli("", cmdArgs...)
-}
-
-// Runs a BOS CLI describe on the specified target with the specified arguments
-// If tenant is empty string it means no tenant
-// Parses the result to convert it to a dictionary with string keys
-// Returns the result and a boolean indicating whether or not this was successful (true == no errors)
-func bosTenantDescribeCli(tenant, target string, cmdArgs ...string) (dict map[string]interface{}, passed bool) {
-	var err error
-
-	passed = false
-	cmdOut := runTenantBosCLIDescribe(tenant, target, cmdArgs...)
-	if cmdOut == nil {
-		return
-	}
-
-	// Decode JSON into a list of string maps
-	dict, err = common.DecodeJSONIntoStringMap(cmdOut)
-	if err != nil {
-		common.Error(err)
-		return
-	}
-	passed = true
-	return
+	return runTenantBosCLI(ctx, tenant, newCmdArgs...)
 }
 
 // Given a BOS CLI command prefix, run that CLI command with "list" appended to the end.
-// Verify that the command succeeded and returns a dictionary (aka string map) object.
+// Verify that the command succeeded and that its output can be parsed by the specified function.
 // Return true if all of that worked fine. Otherwise, log an appropriate error and return false.
 // If tenant is empty string it means no tenant
-func basicTenantCLIListVerifyStringMapTest(tenant string, cmdArgs ...string) bool {
-	cmdOut := runTenantBosCLIList(tenant, cmdArgs...)
+func tenantCLIListParseTest(ctx context.Context, tenant string, parse func(context.Context, []byte) error, cmdArgs ...string) bool {
+	cmdOut := runTenantBosCLIList(ctx, tenant, cmdArgs...)
 	if cmdOut == nil {
 		return false
 	}
-
-	// Validate that object can be decoded into a string map at least
-	_, err := common.DecodeJSONIntoStringMap(cmdOut)
-	if err != nil {
-		common.Error(err)
-		return false
-	}
-	return true
+	return checkNoError(ctx, parse(ctx, cmdOut))
 }
 
-func basicCLIListVerifyStringMapTest(cmdArgs ...string) bool {
-	return basicTenantCLIListVerifyStringMapTest("", cmdArgs...)
-}
-
-// Given a BOS CLI command prefix and a target name, run that CLI command with "describe <target>" appended to the end.
-// Verify that the command succeeded and returns a dictionary (aka string map) object.
-// Return true if all of that worked fine. Otherwise, log an appropriate error and return false.
-func basicCLIDescribeVerifyStringMapTest(target string, cmdArgs ...string) bool {
-	cmdOut := runBosCLIDescribe(target, cmdArgs...)
-	if cmdOut == nil {
-		return false
-	}
-
-	// Validate that object can be decoded into a string map at least
-	_, err := common.DecodeJSONIntoStringMap(cmdOut)
-	if err != nil {
-		common.Error(err)
-		return false
-	}
-	return true
+func cliListParseTest(ctx context.Context, parse func(context.Context, []byte) error, cmdArgs ...string) bool {
+	return tenantCLIListParseTest(ctx, "", parse, cmdArgs...)
 }
 
 // Run all of the BOS CLI subtests. Return true if they all pass, false otherwise.
-func cliTests(tenantList []string, includeTenant bool) (passed bool) {
+func cliTests(ctx context.Context, tenantList []string, includeTenant bool) (passed bool) {
 	passed = true
 
 	// Defined in bos_version.go
-	if !versionTestsCLI(tenantList) {
+	if !registry.RunSubtest(ctx, "bos.cli.version", func(ctx context.Context) bool { return versionTestsCLI(ctx, tenantList) }) {
 		passed = false
 	}
 
 	// Defined in bos_healthz.go
-	if !healthzTestsCLI(tenantList) {
+	if !registry.RunSubtest(ctx, "bos.cli.healthz", func(ctx context.Context) bool { return healthzTestsCLI(ctx, tenantList) }) {
 		passed = false
 	}
 
 	// Defined in bos_components.go
-	if !componentsTestsCLI(tenantList) {
+	if !registry.RunSubtest(ctx, "bos.cli.components", func(ctx context.Context) bool { return componentsTestsCLI(ctx, tenantList) }) {
 		passed = false
 	}
 
 	// Defined in bos_options.go
-	if !optionsTestsCLI() {
+	if !registry.RunSubtest(ctx, "bos.cli.options", func(ctx context.Context) bool { return optionsTestsCLI(ctx) }) {
 		passed = false
 	}
 
 	// Defined in bos_sessiontemplate.go
-	if !sessionTemplatesTestsCLI(tenantList, includeTenant) {
+	if !registry.RunSubtest(ctx, "bos.cli.sessiontemplates", func(ctx context.Context) bool { return sessionTemplatesTestsCLI(ctx, tenantList, includeTenant) }) {
 		passed = false
 	}
 
 	// Defined in bos_session.go
-	if !sessionsTestsCLI(tenantList, includeTenant) {
+	if !registry.RunSubtest(ctx, "bos.cli.sessions", func(ctx context.Context) bool { return sessionsTestsCLI(ctx, tenantList, includeTenant) }) {
 		passed = false
 	}
 
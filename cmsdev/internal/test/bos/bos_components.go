@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2022-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2022-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,137 +29,109 @@ package bos
  */
 
 import (
+	"context"
 	"fmt"
-	resty "gopkg.in/resty.v1"
-	"net/http"
+
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
 // components is new for BOS v2
-const bosV2ComponentsUri = bosV2BaseUri + "/components"
-
 const bosV2ComponentsCLI = "components"
 const bosDefaultComponentsCLI = bosV2ComponentsCLI
 
-// The componentsTestsURI and componentsTestsCLICommand functions define the API and CLI versions of the BOS components subtests.
+// The componentsTestsClient and componentsTestsCLICommand functions define the API and CLI versions of the BOS components subtests.
 // They both do the same thing:
 // 1. List all components
-// 2. Verify that this succeeds and returns something of the right general form
-// 3. If the list returned is empty, then the subtest is over. Otherwise, select the first element of the list and extract the "id" field
+// 2. Verify that this succeeds and returns a list of components
+// 3. If the list returned is empty, then the subtest is over. Otherwise, select the first element of the list
 // 4. Do a GET/describe on that particular component
-// 5. Verify that this succeeds and returns something of the right general form
+// 5. Verify that this succeeds and returns the same component
 
-func componentsTestsAPI(params *common.Params, tenantList []string) (passed bool) {
+func componentsTestsAPI(ctx context.Context, client bosclient.Client, tenantList []string) (passed bool) {
 	// Test with no tenant specified in the query.
-	passed = componentsTestsURI(bosV2ComponentsUri, params, "")
+	passed = componentsTestsClient(ctx, client)
 
 	if len(tenantList) == 0 {
-		common.Infof("Skipping tenanted components tests, because no tenants are defined on the system")
+		common.Infof(ctx, "Skipping tenanted components tests, because no tenants are defined on the system")
 		return
 	}
 
-	if !componentsTestsURI(bosV2ComponentsUri, params, getAnyTenant(tenantList)) {
+	if !componentsTestsClient(ctx, client.WithTenant(getAnyTenant(ctx, tenantList))) {
 		passed = false
 	}
 
 	return
 }
 
-func componentsTestsCLI(tenantList []string) (passed bool) {
+func componentsTestsCLI(ctx context.Context, tenantList []string) (passed bool) {
 	passed = true
 
 	// v2 (untenanted)
-	if !componentsTestsCLICommand("", "v2", bosV2ComponentsCLI) {
+	if !componentsTestsCLICommand(ctx, "", "v2", bosV2ComponentsCLI) {
 		passed = false
 	}
 
 	// default (v2) (untenated)
-	if !componentsTestsCLICommand("", bosDefaultComponentsCLI) {
+	if !componentsTestsCLICommand(ctx, "", bosDefaultComponentsCLI) {
 		passed = false
 	}
 
 	if len(tenantList) == 0 {
-		common.Infof("Skipping tenanted components tests, because no tenants are defined on the system")
+		common.Infof(ctx, "Skipping tenanted components tests, because no tenants are defined on the system")
 		return
 	}
 
 	// v2
-	if !componentsTestsCLICommand(getAnyTenant(tenantList), "v2", bosV2ComponentsCLI) {
+	if !componentsTestsCLICommand(ctx, getAnyTenant(ctx, tenantList), "v2", bosV2ComponentsCLI) {
 		passed = false
 	}
 
 	// default (v2)
-	if !componentsTestsCLICommand(getAnyTenant(tenantList), bosDefaultComponentsCLI) {
+	if !componentsTestsCLICommand(ctx, getAnyTenant(ctx, tenantList), bosDefaultComponentsCLI) {
 		passed = false
 	}
 
 	return
 }
 
-func getFirstComponentId(listCmdOut []byte) (string, error) {
-	return common.GetStringFieldFromFirstItem("id", listCmdOut)
-}
-
-func ValidateComponentId(mapCmdOut []byte, expectedId string) bool {
-	err := common.ValidateStringFieldValue("BOS component", "id", expectedId, mapCmdOut)
-	if err != nil {
-		common.Error(err)
+// Verifies that a component has the expected ID, and logs its state
+func ValidateComponent(ctx context.Context, component bosclient.Component, expectedId string) bool {
+	if component.ID != expectedId {
+		common.Errorf(ctx, "BOS component ID '%s' does not match expected ID '%s'", component.ID, expectedId)
 		return false
 	}
+	common.Infof(ctx, "BOS component '%s' enabled: %t, phase: '%s', status: '%s'", component.ID, component.Enabled,
+		component.Status.Phase, component.Status.Status)
 	return true
 }
 
 // See comment earler in file for a description of this function
-func componentsTestsURI(uri string, params *common.Params, tenantName string) (passed bool) {
-	var resp *resty.Response
-	var err error
-
+func componentsTestsClient(ctx context.Context, client bosclient.Client) (passed bool) {
 	// test #1, list components
-	if len(tenantName) == 0 {
-		common.Infof("GET %s test scenario", uri)
-		resp, err = bosRestfulVerifyStatus("GET", uri, params, http.StatusOK)
-	} else {
-		common.Infof("GET %s (tenant: %s) test scenario", uri, tenantName)
-		resp, err = bosTenantRestfulVerifyStatus("GET", uri, tenantName, params, http.StatusOK)
-	}
-	if err != nil {
-		common.Error(err)
+	apiScenario(ctx, client, "GET", endpointPath(ctx, "components"))
+	components, err := client.ListComponents(ctx)
+	if !checkNoError(ctx, err) {
 		return false
-	}
-
-	// use results from previous test, grab the first component ID
-	componentId, err := getFirstComponentId(resp.Body())
-	if err != nil {
-		common.Error(err)
-		return false
-	} else if len(componentId) == 0 {
-		common.Infof("skipping test GET %s/{component_id}", uri)
-		common.Infof("results from previous test is []")
+	} else if len(components) == 0 {
+		common.Infof(ctx, "skipping test GET %s/{component_id}", endpointPath(ctx, "components"))
+		common.Infof(ctx, "results from previous test is []")
 		return true
 	}
 
-	// a component id is available
+	// use results from previous test, grab the first component ID
 	// test #2 describe component
-	uri += "/" + componentId
-	if len(tenantName) == 0 {
-		common.Infof("GET %s test scenario", uri)
-		resp, err = bosRestfulVerifyStatus("GET", uri, params, http.StatusOK)
-	} else {
-		common.Infof("GET %s (tenant: %s) test scenario", uri, tenantName)
-		resp, err = bosTenantRestfulVerifyStatus("GET", uri, tenantName, params, http.StatusOK)
-	}
-	if err != nil {
-		common.Error(err)
-		return false
-	} else if !ValidateComponentId(resp.Body(), componentId) {
+	componentId := components[0].ID
+	apiScenario(ctx, client, "GET", endpointPath(ctx, "components")+"/"+componentId)
+	component, err := client.GetComponent(ctx, componentId)
+	if !checkNoError(ctx, err) {
 		return false
 	}
-
-	return true
+	return ValidateComponent(ctx, component, componentId)
 }
 
 // See comment earler in file for a description of this function
-func componentsTestsCLICommand(tenantName string, cmdArgs ...string) bool {
+func componentsTestsCLICommand(ctx context.Context, tenantName string, cmdArgs ...string) bool {
 	var tenantText string
 
 	if len(tenantName) > 0 {
@@ -167,29 +139,28 @@ func componentsTestsCLICommand(tenantName string, cmdArgs ...string) bool {
 	}
 
 	// test #1, list components
-	cmdOut := runTenantBosCLIList(tenantName, cmdArgs...)
+	cmdOut := runTenantBosCLIList(ctx, tenantName, cmdArgs...)
 	if cmdOut == nil {
 		return false
 	}
-
-	// use results from previous test, grab the first component
-	componentId, err := getFirstComponentId(cmdOut)
-	if err != nil {
-		common.Error(err)
+	components, err := bosclient.ParseComponents(cmdOut)
+	if !checkNoError(ctx, err) {
 		return false
-	} else if len(componentId) == 0 {
-		common.Infof("skipping test CLI describe component {component_id}%s because result from previous test is []", tenantText)
+	} else if len(components) == 0 {
+		common.Infof(ctx, "skipping test CLI describe component {component_id}%s because result from previous test is []", tenantText)
 		return true
 	}
 
-	// a component_id is available
+	// use results from previous test, grab the first component
 	// test #2 describe component
-	cmdOut = runTenantBosCLIDescribe(tenantName, componentId, cmdArgs...)
+	componentId := components[0].ID
+	cmdOut = runTenantBosCLIDescribe(ctx, tenantName, componentId, cmdArgs...)
 	if cmdOut == nil {
 		return false
-	} else if !ValidateComponentId(cmdOut, componentId) {
+	}
+	component, err := bosclient.ParseComponent(cmdOut)
+	if !checkNoError(ctx, err) {
 		return false
 	}
-
-	return true
+	return ValidateComponent(ctx, component, componentId)
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -22,6 +22,7 @@
 package bos
 
 import (
+	"context"
 	"sync"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
@@ -61,42 +62,6 @@ type ImsImage struct {
 	Link    ImageLink `json:"link"`
 }
 
-type BootSet struct {
-	Arch              string   `json:"arch"`
-	Etag              string   `json:"etag"`
-	Kernel_parameters string   `json:"kernel_parameters"`
-	Node_roles_groups []string `json:"node_roles_groups"`
-	Path              string   `json:"path"`
-	Type              string   `json:"type"`
-}
-
-type BOSTemplateBootSet struct {
-	Compute BootSet `json:"compute"`
-}
-
-type BOSCfs struct {
-	Configuration string `json:"configuration"`
-}
-
-type BOSSessionTemplate struct {
-	Enable_cfs bool               `json:"enable_cfs"`
-	Name       string             `json:"name"`
-	Tenant     string             `json:"tenant"`
-	Boot_sets  BOSTemplateBootSet `json:"boot_sets"`
-	Cfs        BOSCfs             `json:"cfs"`
-}
-
-type BOSSession struct {
-	Components       string `json:"components"`
-	Include_disabled bool   `json:"include_disabled"`
-	Limit            string `json:"limit"`
-	Name             string `json:"name"`
-	Operation        string `json:"operation"`
-	Stage            bool   `json:"stage"`
-	Template_name    string `json:"template_name"`
-	Tenant           string `json:"tenant"`
-}
-
 type BOSSessionTemplateInventory struct {
 	TemplateNameList []string `json:"template_name_list"`
 }
@@ -116,5 +81,13 @@ func GetBOSSessionTemplateInventoryInstance() *BOSSessionTemplateInventory {
 	return instance
 }
 
-// CMS service endpoints
-var endpoints map[string]map[string]*common.Endpoint = common.GetEndpoints()
+// Returns a CMS service endpoint. These are derived from the OpenAPI specs, which can be loaded
+// after the package is initialized (see --openapi-dir), so they are looked up when needed.
+// Loaded specs are checked for the endpoints the tests use, so one should never be missing.
+func serviceEndpoint(ctx context.Context, service, name string) *common.Endpoint {
+	endpoint, err := common.GetEndpoint(service, name)
+	if err != nil {
+		common.Failuref(ctx, "%v", err)
+	}
+	return endpoint
+}
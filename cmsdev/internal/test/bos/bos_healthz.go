@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2022-2024 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2022-2024, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,55 +29,81 @@ package bos
  */
 
 import (
+	"context"
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-const bosV2HealthzUri = bosV2BaseUri + "/healthz"
-
 const bosV2HealthzCLI = "healthz"
 const bosDefaultHealthzCLI = bosV2HealthzCLI
 
-func healthzTestsAPI(params *common.Params, tenantList []string) (passed bool) {
+func logHealthz(ctx context.Context, healthz bosclient.Healthz) {
+	common.Infof(ctx, "BOS API status: %s, database status: %s", healthz.APIStatus, healthz.DBStatus)
+}
+
+// Returns true if the health status was retrieved without error, logging it
+func checkHealthz(ctx context.Context, healthz bosclient.Healthz, err error) bool {
+	if !checkNoError(ctx, err) {
+		return false
+	}
+	logHealthz(ctx, healthz)
+	return true
+}
+
+func parseHealthzOutput(ctx context.Context, cmdOut []byte) error {
+	healthz, err := bosclient.ParseHealthz(cmdOut)
+	if err == nil {
+		logHealthz(ctx, healthz)
+	}
+	return err
+}
+
+func healthzTestsAPI(ctx context.Context, client bosclient.Client, tenantList []string) (passed bool) {
 	passed = true
 
-	// Just do a GET of the options endpoint and make sure that the response has
-	// 200 status and a dictionary object
+	// Just do a GET of the healthz endpoint and make sure that the response has
+	// 200 status and a health status object
 
 	// v2 endpoint
-	if !basicGetUriVerifyStringMapTest(bosV2HealthzUri, params) {
+	apiScenario(ctx, client, "GET", endpointPath(ctx, "healthz"))
+	healthz, err := client.Healthz(ctx)
+	if !checkHealthz(ctx, healthz, err) {
 		passed = false
 	}
 
 	// v2 endpoint as random tenant (BOS does not verify if tenant exists for GET operations)
-	if !basicTenantGetUriVerifyStringMapTest(bosV2HealthzUri, getAnyTenant(tenantList), params) {
+	tenantClient := client.WithTenant(getAnyTenant(ctx, tenantList))
+	apiScenario(ctx, tenantClient, "GET", endpointPath(ctx, "healthz"))
+	healthz, err = tenantClient.Healthz(ctx)
+	if !checkHealthz(ctx, healthz, err) {
 		passed = false
 	}
 
 	return
 }
 
-func healthzTestsCLI(tenantList []string) (passed bool) {
+func healthzTestsCLI(ctx context.Context, tenantList []string) (passed bool) {
 	passed = true
 
-	// Make sure that "healthz list" CLI command succeeds and returns a dictionary object.
+	// Make sure that "healthz list" CLI command succeeds and returns a health status object.
 
 	// "v2 healthz list"
-	if !basicCLIListVerifyStringMapTest("v2", bosV2HealthzCLI) {
+	if !cliListParseTest(ctx, parseHealthzOutput, "v2", bosV2HealthzCLI) {
 		passed = false
 	}
 
 	// "v2 healthz list" as random tenant (BOS does not verify if tenant exists for GET operations)
-	if !basicTenantCLIListVerifyStringMapTest(getAnyTenant(tenantList), "v2", bosV2HealthzCLI) {
+	if !tenantCLIListParseTest(ctx, getAnyTenant(ctx, tenantList), parseHealthzOutput, "v2", bosV2HealthzCLI) {
 		passed = false
 	}
 
 	// "healthz list"
-	if !basicCLIListVerifyStringMapTest(bosDefaultHealthzCLI) {
+	if !cliListParseTest(ctx, parseHealthzOutput, bosDefaultHealthzCLI) {
 		passed = false
 	}
 
 	// "healthz list" as random tenant (BOS does not verify if tenant exists for GET operations)
-	if !basicTenantCLIListVerifyStringMapTest(getAnyTenant(tenantList), bosDefaultHealthzCLI) {
+	if !tenantCLIListParseTest(ctx, getAnyTenant(ctx, tenantList), parseHealthzOutput, bosDefaultHealthzCLI) {
 		passed = false
 	}
 
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2022-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2022-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,39 +29,52 @@ package bos
  */
 
 import (
+	"context"
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
 // Options are new in BOS v2
-const bosV2OptionsUri = bosV2BaseUri + "/options"
-
 const bosV2OptionsCLI = "options"
 const bosDefaultOptionsCLI = bosV2OptionsCLI
 
-func optionsTestsAPI(params *common.Params) (passed bool) {
-	passed = true
+func logOptions(ctx context.Context, options bosclient.Options) {
+	common.Infof(ctx, "BOS logging level: %s, polling frequency: %ds, completed session TTL: %s",
+		options.LoggingLevel, options.PollingFrequency, options.CleanupCompletedSessionTTL)
+}
 
-	// Just do a GET of the options endpoint and make sure that the response has
-	// 200 status and a dictionary object
-	if !basicGetUriVerifyStringMapTest(bosV2OptionsUri, params) {
-		passed = false
+func parseOptionsOutput(ctx context.Context, cmdOut []byte) error {
+	options, err := bosclient.ParseOptions(cmdOut)
+	if err == nil {
+		logOptions(ctx, options)
 	}
+	return err
+}
 
-	return
+func optionsTestsAPI(ctx context.Context, client bosclient.Client) (passed bool) {
+	// Just do a GET of the options endpoint and make sure that the response has
+	// 200 status and an options object
+	apiScenario(ctx, client, "GET", endpointPath(ctx, "options"))
+	options, err := client.Options(ctx)
+	if !checkNoError(ctx, err) {
+		return false
+	}
+	logOptions(ctx, options)
+	return true
 }
 
-func optionsTestsCLI() (passed bool) {
+func optionsTestsCLI(ctx context.Context) (passed bool) {
 	passed = true
 
-	// Make sure that "options list" CLI command succeeds and returns a dictionary object.
+	// Make sure that "options list" CLI command succeeds and returns an options object.
 
 	// "v2 options list"
-	if !basicCLIListVerifyStringMapTest("v2", bosV2OptionsCLI) {
+	if !cliListParseTest(ctx, parseOptionsOutput, "v2", bosV2OptionsCLI) {
 		passed = false
 	}
 
 	// "options list"
-	if !basicCLIListVerifyStringMapTest(bosDefaultOptionsCLI) {
+	if !cliListParseTest(ctx, parseOptionsOutput, bosDefaultOptionsCLI) {
 		passed = false
 	}
 
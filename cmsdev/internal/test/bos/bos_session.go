@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,19 +29,14 @@ package bos
  */
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"net/http"
-	"reflect"
 
-	resty "gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/apiclient"
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-const bosV2SessionsUri = bosV2BaseUri + "/sessions"
-
 const bosV2SessionsCLI = "sessions"
 const bosDefaultSessionsCLI = bosV2SessionsCLI
 
@@ -67,186 +62,89 @@ func (sessionData v2SessionData) String() string {
 
 // Compares two sessionData objects. Returns true if both fields match, false otherwise. If false,
 // appropriate errors are also logged noting the discrepancies
-func (sessionData v2SessionData) HasExpectedValues(expectedSessionData v2SessionData) (passed bool) {
+func (sessionData v2SessionData) HasExpectedValues(ctx context.Context, expectedSessionData v2SessionData) (passed bool) {
 	// Let's be optimistic and assume that they will match
 	passed = true
 
 	// Validate that name fields match
 	if sessionData.Name != expectedSessionData.Name {
-		common.Errorf("BOS session name '%s' does not match expected name '%s'", sessionData.Name, expectedSessionData.Name)
+		common.Errorf(ctx, "BOS session name '%s' does not match expected name '%s'", sessionData.Name, expectedSessionData.Name)
 		passed = false
 	} else {
-		common.Debugf("BOS session name '%s' matches expected value", sessionData.Name)
+		common.Debugf(ctx, "BOS session name '%s' matches expected value", sessionData.Name)
 	}
 
 	// Validate the tenant name
 	if sessionData.Tenant == expectedSessionData.Tenant {
 		if len(sessionData.Tenant) == 0 {
-			common.Debugf("Session does not belong to a tenant, which matches expectations")
+			common.Debugf(ctx, "Session does not belong to a tenant, which matches expectations")
 		} else {
-			common.Debugf("Session belongs to the expected tenant")
+			common.Debugf(ctx, "Session belongs to the expected tenant")
 		}
 		return
 	}
 	passed = false
 	if len(sessionData.Tenant) == 0 {
-		common.Errorf("Session does not belong to a tenant, but it should belong to '%s'", expectedSessionData.Tenant)
-	} else {
-		common.Errorf("Session belongs to tenant '%s', but it should not belong to any tenant", sessionData.Tenant)
-	}
-	return
-}
-
-// Takes as input a mapping from strings to arbitrary objects.
-// Parses it to extract the values of the 'name' and 'tenant' fields (although the
-// latter is allowed to be absent). Validates that 'name' (and 'tenant', if present and non-null)
-// have string values and that 'name' is non-0 length. Returns a v2SessionData object
-// populated from those fields. Returns an error with any problems encountered.
-func parseV2SessionData(sessionDict map[string]interface{}) (sessionData v2SessionData, totalErr error) {
-	var err error
-	var fieldFound, fieldIsString bool
-	var sessionTenantField interface{}
-
-	common.Debugf("Getting name of session")
-	sessionData.Name, err = common.GetStringFieldFromMapObject("name", sessionDict)
-	if err != nil {
-		err = fmt.Errorf("%w; Error getting 'name' field of BOS session", err)
-	} else if len(sessionData.Name) == 0 {
-		err = fmt.Errorf("BOS session has a 0-length name")
+		common.Errorf(ctx, "Session does not belong to a tenant, but it should belong to '%s'", expectedSessionData.Tenant)
 	} else {
-		common.Debugf("Name of session is '%s'", sessionData.Name)
-	}
-	totalErr = errors.Join(totalErr, err)
-
-	common.Debugf("Checking for 'tenant' field of session '%s'", sessionData.Name)
-	sessionTenantField, fieldFound = sessionDict["tenant"]
-	if !fieldFound {
-		// This session has no tenant field, which is equivalent to a 0-length string tenant field
-		sessionData.Tenant = ""
-		common.Debugf("Session '%s' has no 'tenant' field", sessionData.Name)
-		return
-	}
-	if sessionTenantField == nil {
-		// This session has null-value tenant field, which is equivalent to a 0-length string tenant field
-		sessionData.Tenant = ""
-		common.Debugf("Session '%s' has null 'tenant' field", sessionData.Name)
-		return
-	}
-
-	// If it is present and non-null, it should be a string value
-	sessionData.Tenant, fieldIsString = sessionTenantField.(string)
-	if !fieldIsString {
-		// tenant field has non-string value
-		err = fmt.Errorf("Session '%s' has a non-null 'tenant' field but its value is type %s, not string",
-			sessionData.Name, reflect.TypeOf(sessionTenantField).String())
-		totalErr = errors.Join(totalErr, err)
-	}
-	common.Debugf("Session: %s", sessionData.String())
-	return
-}
-
-// Performs an API query to get a particular BOS v2 session (possibly belonging to a tenant)
-// Parses the result to convert it to a dictionary with string keys
-// Returns the result and error (if any)
-func getV2SessionApi(params *common.Params, sessionData v2SessionData) (sessionDict map[string]interface{}, err error) {
-	var resp *resty.Response
-	uri := bosV2SessionsUri + "/" + sessionData.Name
-	if len(sessionData.Tenant) == 0 {
-		common.Infof("GET %s test scenario", uri)
-		resp, err = bosRestfulVerifyStatus("GET", uri, params, http.StatusOK)
-	} else {
-		common.Infof("GET %s (tenant: %s) test scenario", uri, sessionData.Tenant)
-		resp, err = bosTenantRestfulVerifyStatus("GET", uri, sessionData.Tenant, params, http.StatusOK)
-	}
-	if err != nil {
-		return
+		common.Errorf(ctx, "Session belongs to tenant '%s', but it should not belong to any tenant", sessionData.Tenant)
 	}
-	// Decode JSON into a string map
-	sessionDict, err = common.DecodeJSONIntoStringMap(resp.Body())
 	return
 }
 
-// Performs an API query to list BOS v2 sessions (possibly with a tenant specified)
-// Parses the result to convert it to a list of dictionaries with string keys
-// Returns the result and error (if any)
-func listV2SessionsApi(params *common.Params, tenantName string) (dictList []map[string]interface{}, err error) {
-	var resp *resty.Response
-	if len(tenantName) == 0 {
-		common.Infof("GET %s test scenario", bosV2SessionsUri)
-		resp, err = bosRestfulVerifyStatus("GET", bosV2SessionsUri, params, http.StatusOK)
-	} else {
-		common.Infof("GET %s (tenant: %s) test scenario", bosV2SessionsUri, tenantName)
-		resp, err = bosTenantRestfulVerifyStatus("GET", bosV2SessionsUri, tenantName, params, http.StatusOK)
-	}
-	if err != nil {
-		return
-	}
-	// Decode JSON into a list of string maps
-	dictList, err = common.DecodeJSONIntoStringMapList(resp.Body())
-	return
+// Returns a v2SessionData object identifying the specified session
+func sessionDataOf(session bosclient.Session) v2SessionData {
+	return v2SessionData{Name: session.Name, Tenant: session.Tenant}
 }
 
-// Get a particular BOS v2 session (possibly belonging to a tenant) using getV2SessionApi,
-// parses that dictionary into a v2SessionData struct.
+// Get a particular BOS v2 session (possibly belonging to a tenant) using the BOS client.
 // Validates that it matches the expected session name and (if any) tenant name.
-// Returns that struct and error (if any)
-func getV2SessionDataApi(params *common.Params, sessionData v2SessionData) (sessionDataFromApi v2SessionData, err error) {
-	var sessionDict map[string]interface{}
-
-	sessionDict, err = getV2SessionApi(params, sessionData)
-	if err != nil {
-		return
-	}
-	sessionDataFromApi, err = parseV2SessionData(sessionDict)
+// Returns the identifying data of the session and error (if any)
+func getV2SessionDataApi(ctx context.Context, client bosclient.Client, sessionData v2SessionData) (sessionDataFromApi v2SessionData, err error) {
+	client = client.WithTenant(sessionData.Tenant)
+	apiScenario(ctx, client, "GET", endpointPath(ctx, "sessions")+"/"+sessionData.Name)
+	session, err := client.GetSession(ctx, sessionData.Name)
 	if err != nil {
 		return
 	}
-	if !sessionDataFromApi.HasExpectedValues(sessionData) {
+	sessionDataFromApi = sessionDataOf(session)
+	if !sessionDataFromApi.HasExpectedValues(ctx, sessionData) {
 		err = fmt.Errorf("Session returned by API query (%s) does not match session requested (%s)",
 			sessionDataFromApi.String(), sessionData.String())
 	}
 	return
 }
 
-// Describe a particular BOS v2 session (possibly belonging to a tenant) using bosTenantDescribeCli,
-// parse that dictionary into a v2SessionData struct.
+// Describe a particular BOS v2 session (possibly belonging to a tenant) using the CLI.
 // Validates that it matches the expected session name and (if any) tenant name.
-// Returns that struct and a boolean indicating pass/fail
-func describeV2SessionDataCli(sessionData v2SessionData, cmdArgs ...string) (sessionDataFromCli v2SessionData, passed bool) {
-	var sessionDict map[string]interface{}
-	var err error
-	var ok bool
-
-	passed = false
-	sessionDict, ok = bosTenantDescribeCli(sessionData.Tenant, sessionData.Name, cmdArgs...)
-	if !ok {
+// Returns the identifying data of the session and a boolean indicating pass/fail
+func describeV2SessionDataCli(ctx context.Context, sessionData v2SessionData, cmdArgs ...string) (sessionDataFromCli v2SessionData, passed bool) {
+	cmdOut := runTenantBosCLIDescribe(ctx, sessionData.Tenant, sessionData.Name, cmdArgs...)
+	if cmdOut == nil {
 		return
 	}
-	sessionDataFromCli, err = parseV2SessionData(sessionDict)
+	session, err := bosclient.ParseSession(cmdOut)
 	if err != nil {
+		common.Error(ctx, err)
 		return
 	}
-	if !sessionDataFromCli.HasExpectedValues(sessionData) {
-		common.Errorf("Session returned by CLI command (%s) does not match session requested (%s)",
+	sessionDataFromCli = sessionDataOf(session)
+	if !sessionDataFromCli.HasExpectedValues(ctx, sessionData) {
+		common.Errorf(ctx, "Session returned by CLI command (%s) does not match session requested (%s)",
 			sessionDataFromCli.String(), sessionData.String())
-	} else {
-		passed = true
+		return
 	}
+	passed = true
 	return
 }
 
-// Parses list of string-key dictionaries into a list of v2SessionData structs.
+// Converts a list of sessions into a list of v2SessionData structs.
 // If a tenant was specified, validate that every session belongs to that tenant.
 // Returns that list of structs and error (if any)
-func dictListToSessionDataList(dictList []map[string]interface{}, tenantName string) (sessionDataList []v2SessionData, err error) {
-	var sessionData v2SessionData
-	sessionDataList = make([]v2SessionData, 0, len(dictList))
-	for sessionIndex, sessionDict := range dictList {
-		sessionData, err = parseV2SessionData(sessionDict)
-		if err != nil {
-			err = fmt.Errorf("%w; Error parsing session #%d in list", err, sessionIndex)
-			return
-		}
+func sessionsToSessionDataList(sessions []bosclient.Session, tenantName string) (sessionDataList []v2SessionData, err error) {
+	sessionDataList = make([]v2SessionData, 0, len(sessions))
+	for sessionIndex, session := range sessions {
+		sessionData := sessionDataOf(session)
 		// If a tenant was specified, validate that session belongs to the expected tenant
 		if len(tenantName) > 0 && sessionData.Tenant != tenantName {
 			err = fmt.Errorf("Session #%d in the list (%s) does not belong to expected tenant '%s'",
@@ -258,207 +156,181 @@ func dictListToSessionDataList(dictList []map[string]interface{}, tenantName str
 	return
 }
 
-// Gets a list of V2 session dictionary objects using listV2SessionsApi,
-// converts that list using dictListToSessionDataList. Returns resulting list and error (if any)
-func listV2SessionDataApi(params *common.Params, tenantName string) (sessionDataList []v2SessionData, err error) {
-	var dictList []map[string]interface{}
-
-	dictList, err = listV2SessionsApi(params, tenantName)
+// Lists the sessions (of the specified tenant, if any) using the BOS client, and
+// converts that list using sessionsToSessionDataList. Returns resulting list and error (if any)
+func listV2SessionDataApi(ctx context.Context, client bosclient.Client, tenantName string) (sessionDataList []v2SessionData, err error) {
+	client = client.WithTenant(tenantName)
+	apiScenario(ctx, client, "GET", endpointPath(ctx, "sessions"))
+	sessions, err := client.ListSessions(ctx)
 	if err != nil {
 		return
 	}
-	sessionDataList, err = dictListToSessionDataList(dictList, tenantName)
+	sessionDataList, err = sessionsToSessionDataList(sessions, tenantName)
 	return
 }
 
-// Gets a list of V2 session dictionary objects using bosListCli,
-// converts that list using dictListToSessionDataList. Returns resulting list and boolean
+// Lists the sessions (of the specified tenant, if any) using the CLI, and
+// converts that list using sessionsToSessionDataList. Returns resulting list and boolean
 // value indicating whether the function passed or failed (an error will have been logged in the case
 // of failure)
-func listV2SessionDataCli(tenantName string, cmdArgs ...string) (sessionDataList []v2SessionData, passed bool) {
-	var dictList []map[string]interface{}
-	var err error
-
-	dictList, passed = bosTenantListCli(tenantName, cmdArgs...)
-	if !passed {
+func listV2SessionDataCli(ctx context.Context, tenantName string, cmdArgs ...string) (sessionDataList []v2SessionData, passed bool) {
+	cmdOut := runTenantBosCLIList(ctx, tenantName, cmdArgs...)
+	if cmdOut == nil {
 		return
 	}
-	sessionDataList, err = dictListToSessionDataList(dictList, tenantName)
+	sessions, err := bosclient.ParseSessions(cmdOut)
+	if err == nil {
+		sessionDataList, err = sessionsToSessionDataList(sessions, tenantName)
+	}
 	if err != nil {
-		common.Error(err)
-		passed = false
+		common.Error(ctx, err)
+		return
 	}
+	passed = true
 	return
 }
 
-func CreateBOSSessionPayload(sessionName, templateName string, staged bool, operation string, arch string, imageId string) (payload string, ok bool) {
+// Creates a session template with an image of the specified architecture, and returns the request
+// to create a BOS session from it
+func CreateBOSSessionPayload(ctx context.Context, sessionName, templateName string, staged bool, operation string, arch string, imageId string) (session bosclient.SessionCreate, ok bool) {
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
 
-	payload, ok = GetCreateBOSSessionTemplatePayload(cfgName, false, arch, imageId)
+	template, ok := BuildBOSSessionTemplate(ctx, cfgName, false, arch, imageId)
 	if !ok {
-		return "", false
+		return
 	}
-	common.Debugf("BOS Session create - Session template payload: %s", payload)
 	// Create BOS session template
-	sessionTemplateRecord, ok := CreateUpdateBOSSessiontemplatesAPI(payload, templateName, "PUT")
+	sessionTemplateRecord, ok := CreateBOSSessionTemplateAPI(ctx, template, templateName)
 	if !ok {
-		common.Errorf("Failed to create session template")
-		return "", false
-	}
-	paylaod := map[string]interface{}{
-		"name":          sessionName,
-		"operation":     operation,
-		"template_name": sessionTemplateRecord.Name,
-		"limit":         "fakexname",
-		"stage":         staged,
+		common.Errorf(ctx, "Failed to create session template")
+		return
 	}
-
-	payloadJson, err := json.Marshal(paylaod)
-	if err != nil {
-		common.Errorf("Failed to marshal payload: %v", err)
-		return "", false
+	session = bosclient.SessionCreate{
+		Name:         sessionName,
+		Operation:    operation,
+		TemplateName: sessionTemplateRecord.Name,
+		Limit:        "fakexname",
+		Stage:        staged,
 	}
-	return string(payloadJson), true
+	return
 }
 
-func CreateBOSSessionAPI(sessionPayload string) (sessionRecord BOSSession, ok bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return BOSSession{}, false
+func CreateBOSSessionAPI(ctx context.Context, session bosclient.SessionCreate) (sessionRecord bosclient.Session, ok bool) {
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
 	}
-
-	params.JsonStr = sessionPayload
-
-	url := common.BASEURL + endpoints["bos"]["sessions"].Url +
-		"/" + endpoints["bos"]["sessions"].Version +
-		endpoints["bos"]["sessions"].Uri
-
-	resp, err := VerifyRestStatusWithTenant("POST", url, *params, http.StatusCreated)
-	if err != nil {
-		common.Errorf("Failed to create staged session: %v", err)
-		return BOSSession{}, false
+	sessionRecord, err := client.CreateSession(ctx, session)
+	if !checkTenantResult(ctx, err) {
+		common.Errorf(ctx, "Failed to create session '%s'", session.Name)
+		return bosclient.Session{}, false
 	}
-	if err := json.Unmarshal(resp.Body(), &sessionRecord); err != nil {
-		common.Errorf("Failed to unmarshal staged session response: %v", err)
-		return BOSSession{}, false
+	if err == nil {
+		registerBOSSessionCleanup(ctx, sessionRecord.Name)
 	}
-	ok = true
-	return
+	return sessionRecord, true
 }
 
-func DeleteBOSSessionAPI(sessionName string) (passed bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
+func DeleteBOSSessionAPI(ctx context.Context, sessionName string) (passed bool) {
+	client, ok := tenantClient(ctx)
+	if !ok {
 		return false
 	}
-
-	url := common.BASEURL + endpoints["bos"]["sessions"].Url +
-		"/" + endpoints["bos"]["sessions"].Version +
-		endpoints["bos"]["sessions"].Uri +
-		"/" + sessionName
-
-	_, err := VerifyRestStatusWithTenant("DELETE", url, *params, http.StatusNoContent)
-	if err != nil {
-		common.Errorf("Failed to delete staged session: %v", err)
+	err := client.DeleteSession(ctx, sessionName)
+	if !checkTenantResult(ctx, err) {
+		common.Errorf(ctx, "Failed to delete session '%s'", sessionName)
 		return false
 	}
-	passed = true
-	return
-}
-
-func GetAllBOSSessionsAPI() (sessionList []BOSSession, ok bool) {
-	common.Infof("Getting all sessions")
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return nil, false
+	if err == nil {
+		common.ResourceDeleted(ctx, bosSessionKind, sessionName)
 	}
+	return true
+}
 
-	url := common.BASEURL + endpoints["bos"]["sessions"].Url +
-		"/" + endpoints["bos"]["sessions"].Version +
-		endpoints["bos"]["sessions"].Uri
-
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, http.StatusOK)
-	if err != nil {
-		common.Errorf("Failed to get all staged sessions: %v", err)
-		return nil, false
+func GetAllBOSSessionsAPI(ctx context.Context) (sessionList []bosclient.Session, ok bool) {
+	common.Infof(ctx, "Getting all sessions")
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
 	}
-	if err := json.Unmarshal(resp.Body(), &sessionList); err != nil {
-		common.Errorf("Failed to unmarshal staged session response: %v", err)
+	sessionList, err := client.ListSessions(ctx)
+	if !checkTenantResult(ctx, err) {
+		common.Errorf(ctx, "Failed to list sessions")
 		return nil, false
 	}
-	ok = true
-	return
+	return sessionList, true
 }
 
-func GetBOSSessionAPI(sessionName string, httpStatus int) (sessionRecord BOSSession, ok bool) {
-	common.Infof("Getting BOS session '%s'", sessionName)
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return BOSSession{}, false
+func GetBOSSessionAPI(ctx context.Context, sessionName string) (sessionRecord bosclient.Session, ok bool) {
+	common.Infof(ctx, "Getting BOS session '%s'", sessionName)
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
 	}
+	sessionRecord, err := client.GetSession(ctx, sessionName)
+	if !checkTenantResult(ctx, err) {
+		common.Errorf(ctx, "Failed to get session '%s'", sessionName)
+		return bosclient.Session{}, false
+	}
+	return sessionRecord, true
+}
 
-	url := common.BASEURL + endpoints["bos"]["sessions"].Url +
-		"/" + endpoints["bos"]["sessions"].Version +
-		endpoints["bos"]["sessions"].Uri +
-		"/" + sessionName
-
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, httpStatus)
-	if err != nil {
-		common.Errorf("Failed to get staged session: %v", err)
-		return BOSSession{}, false
+// Verifies that getting the specified session fails with status 404
+func BOSSessionDeletedAPI(ctx context.Context, sessionName string) bool {
+	common.Infof(ctx, "Verifying that BOS session '%s' does not exist", sessionName)
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return false
 	}
-	if err := json.Unmarshal(resp.Body(), &sessionRecord); err != nil {
-		common.Errorf("Failed to unmarshal staged session response: %v", err)
-		return BOSSession{}, false
+	_, err := client.GetSession(ctx, sessionName)
+	if apiclient.IsNotFound(err) {
+		common.Infof(ctx, "BOS session '%s' was not found, as expected", sessionName)
+		return true
 	}
-	ok = true
-	return
+	checkNoError(ctx, err)
+	return false
 }
 
-func BOSSessionExists(sessionName string, sessionList []BOSSession) (ok bool) {
+func BOSSessionExists(ctx context.Context, sessionName string, sessionList []bosclient.Session) (ok bool) {
 	for _, session := range sessionList {
 		if session.Name == sessionName {
 			return true
 		}
 	}
-	common.Infof("BOS session '%s' not found in list of sessions", sessionName)
+	common.Infof(ctx, "BOS session '%s' not found in list of sessions", sessionName)
 	return false
 }
 
-func VerifyBOSSession(sessionRecord BOSSession, sessionPayload string) (ok bool) {
-	// Unmarshal the payoad into a map
-	var payloadMap map[string]interface{}
-	if err := json.Unmarshal([]byte(sessionPayload), &payloadMap); err != nil {
-		common.Errorf("Failed to unmarshal session payload: %v", err)
-		return false
-	}
+// Verifies that a session has the values it was created with
+func VerifyBOSSession(ctx context.Context, sessionRecord bosclient.Session, expected bosclient.SessionCreate) (ok bool) {
 	// Verify the session name
-	if sessionRecord.Name != payloadMap["name"] {
-		common.Errorf("Session name '%s' does not match expected name '%s'", sessionRecord.Name, payloadMap["name"])
+	if sessionRecord.Name != expected.Name {
+		common.Errorf(ctx, "Session name '%s' does not match expected name '%s'", sessionRecord.Name, expected.Name)
 		return false
 	}
 	// Verify the operation
-	if sessionRecord.Operation != payloadMap["operation"] {
-		common.Errorf("Session operation '%s' does not match expected operation '%s'", sessionRecord.Operation, payloadMap["operation"])
+	if sessionRecord.Operation != expected.Operation {
+		common.Errorf(ctx, "Session operation '%s' does not match expected operation '%s'", sessionRecord.Operation, expected.Operation)
 		return false
 	}
 	// Verify the template name
-	if sessionRecord.Template_name != payloadMap["template_name"] {
-		common.Errorf("Session template name '%s' does not match expected template name '%s'", sessionRecord.Template_name, payloadMap["template_name"])
+	if sessionRecord.TemplateName != expected.TemplateName {
+		common.Errorf(ctx, "Session template name '%s' does not match expected template name '%s'", sessionRecord.TemplateName, expected.TemplateName)
 		return false
 	}
 	// Verify the stage field
-	if sessionRecord.Stage != payloadMap["stage"] {
-		common.Errorf("Session stage '%t' does not match expected stage '%t'", sessionRecord.Stage, payloadMap["stage"])
+	if sessionRecord.Stage != expected.Stage {
+		common.Errorf(ctx, "Session stage '%t' does not match expected stage '%t'", sessionRecord.Stage, expected.Stage)
 		return false
 	}
 	// Verify the limit field
-	if sessionRecord.Limit != payloadMap["limit"] {
-		common.Errorf("Session limit '%s' does not match expected limit '%s'", sessionRecord.Limit, payloadMap["limit"])
+	if sessionRecord.Limit != expected.Limit {
+		common.Errorf(ctx, "Session limit '%s' does not match expected limit '%s'", sessionRecord.Limit, expected.Limit)
+		return false
+	}
+	// Verify the tenant field
+	if sessionRecord.Tenant != common.GetTenantName(ctx) {
+		common.Errorf(ctx, "Session tenant '%s' does not match expected tenant '%s'", sessionRecord.Tenant, common.GetTenantName(ctx))
 		return false
 	}
 	return true
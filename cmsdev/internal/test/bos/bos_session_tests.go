@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,8 +29,10 @@ package bos
  */
 
 import (
+	"context"
 	"strings"
 
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
@@ -38,58 +40,58 @@ import (
 // define the API and CLI versions of the BOS v2 session subtests.
 // They all do essentially the same thing
 // 1. List all sessions
-// 2. Verify that this succeeds and returns something of the right general form (this should be a list of dictionary objects)
-// 3. If the list returned is empty, then the subtest is over. Otherwise, select the first element of the list and extract the "name" field of that element
+// 2. Verify that this succeeds and returns a list of sessions
+// 3. If the list returned is empty, then the subtest is over. Otherwise, select the first element of the list
 // 4. Do a GET/describe on that particular session
-// 5. Verify that this succeeds and returns something of the right general form. Also verify that it has the expected name
+// 5. Verify that this succeeds and returns a session. Also verify that it has the expected name
 
-func sessionsTestsAPI(params *common.Params, tenantList []string, includeTenant bool) (passed bool) {
+func sessionsTestsAPI(ctx context.Context, client bosclient.Client, tenantList []string, includeTenant bool) (passed bool) {
 	passed = true
 
 	// v2 sessions
-	if !sessionsV2TestsURI(params, tenantList) {
+	if !sessionsV2TestsURI(ctx, client, tenantList) {
 		passed = false
 	}
 
 	// Tenant tests will be run only if requested using the include-tenant flag
 	if includeTenant {
-		if !TestBOSSessionsCRUDOperationsUsingTenants() {
+		if !TestBOSSessionsCRUDOperationsUsingTenants(ctx) {
 			passed = false
 		}
 		return
 	}
 
 	// BOS sessions CRUD operations tests
-	if !TestBOSSessionsCRUDOperations() {
+	if !TestBOSSessionsCRUDOperations(ctx) {
 		passed = false
 	}
 
 	return
 }
 
-func sessionsTestsCLI(tenantList []string, includeTenant bool) (passed bool) {
+func sessionsTestsCLI(ctx context.Context, tenantList []string, includeTenant bool) (passed bool) {
 	passed = true
 
 	// v2 sessions
-	if !sessionsV2TestsCLICommand(tenantList, "v2", bosV2SessionsCLI) {
+	if !sessionsV2TestsCLICommand(ctx, tenantList, "v2", bosV2SessionsCLI) {
 		passed = false
 	}
 
 	// default (v2) sessions
-	if !sessionsV2TestsCLICommand(tenantList, bosDefaultSessionsCLI) {
+	if !sessionsV2TestsCLICommand(ctx, tenantList, bosDefaultSessionsCLI) {
 		passed = false
 	}
 
 	// Tenant tests will be run only if requested using the include-tenant flag
 	if includeTenant {
-		if !TestSessionsCRUDOperationsWithTenantUsingCLI() {
+		if !TestSessionsCRUDOperationsWithTenantUsingCLI(ctx) {
 			passed = false
 		}
 		return
 	}
 
 	// BOS sessions CRUD operations tests
-	if !TestSessionsCRUDOperationsUsingCLI() {
+	if !TestSessionsCRUDOperationsUsingCLI(ctx) {
 		passed = false
 	}
 
@@ -106,10 +108,9 @@ func sessionsTestsCLI(tenantList []string, includeTenant bool) (passed bool) {
 // The v2 API version of this function has had further improvements made in order to test good-path multitenancy
 // queries. Specifically, this function now does the following (all using API calls)
 // 1. API query to list all BOS v2 sessions (no tenant name specified)
-// 2. Parses the result, verifying that it is a JSON list, and verifying that each item in that list:
-//    a. Is a JSON dictionary
-//    b. Has a "name" field which maps to a non-0-length string
-//    c. Either has no "tenant" field or has a "tenant" field that maps to a (possibly 0-length) string
+// 2. Parses the result, verifying that it is a list of sessions, and verifying that each item in that list:
+//    a. Has a non-0-length name
+//    b. Either has no tenant or has a (possibly 0-length) tenant name
 // 3. If the list is empty, then pick a random tenant name (possibly not one which exists on the system)
 //    and issue a query for all BOS sessions belonging to that tenant. Verify that the resulting list is empty.
 // 4. If the list from #1 is not empty, but no sessions belong to a tenant, then do #3.
@@ -128,38 +129,38 @@ func sessionsTestsCLI(tenantList []string, includeTenant bool) (passed bool) {
 // the BOS health check in the case that certain failures are seen. Eventually the test could be improved to automatically
 // retry the relevant checks a limited number of times, to reduce the likelihood of false failures.
 
-func sessionsV2TestsURI(params *common.Params, tenantList []string) bool {
+func sessionsV2TestsURI(ctx context.Context, client bosclient.Client, tenantList []string) bool {
 	var tenantedSessionData, untenantedSessionData v2SessionData
 	var tenantedSessionCount int
 	var err error
 	var sessionDataList []v2SessionData
 
 	// test #1, list sessions with no tenant specified
-	sessionDataList, err = listV2SessionDataApi(params, "")
+	sessionDataList, err = listV2SessionDataApi(ctx, client, "")
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 	if len(sessionDataList) == 0 {
-		common.Infof("skipping test GET %s/{session_id} because result from previous test is []", bosV2SessionsUri)
+		common.Infof(ctx, "skipping test GET %s/{session_id} because result from previous test is []", endpointPath(ctx, "sessions"))
 
 		// However, we can still try to list all of the sessions with a tenant name specified.
 		// Since no sessions were found from the un-tenanted query, we expect none to be found once a tenant
 		// is specified
-		tenant := getAnyTenant(tenantList)
-		sessionDataList, err = listV2SessionDataApi(params, tenant)
+		tenant := getAnyTenant(ctx, tenantList)
+		sessionDataList, err = listV2SessionDataApi(ctx, client, tenant)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			return false
 		}
 
 		// Validate that this list is empty
 		if len(sessionDataList) > 0 {
-			common.Errorf("Listing of all sessions empty, but found %d when listing sessions for tenant '%s'",
+			common.Errorf(ctx, "Listing of all sessions empty, but found %d when listing sessions for tenant '%s'",
 				len(sessionDataList), tenant)
 			return false
 		}
-		common.Infof("Session list is still empty, as expected")
+		common.Infof(ctx, "Session list is still empty, as expected")
 		return true
 	}
 
@@ -168,14 +169,14 @@ func sessionsV2TestsURI(params *common.Params, tenantList []string) bool {
 	// * One session that belongs to a tenant (tenantedSessionData), and a count (tenantedSessionCount)
 	//   of how many sessions in total have that same tenant
 	for sessionIndex, sessionData := range sessionDataList {
-		common.Debugf("Parsing session #%d in the session list (%s)", sessionIndex, sessionData.String())
+		common.Debugf(ctx, "Parsing session #%d in the session list (%s)", sessionIndex, sessionData.String())
 		if len(sessionData.Tenant) == 0 {
 			// This session has no tenant
 			if untenantedSessionData.IsNil() {
 				// This is the first session we have encountered that has no tenant field,
 				// so take note of it
 				untenantedSessionData = sessionData
-				common.Infof("Found BOS v2 session #%d (%s)", sessionIndex, untenantedSessionData.String())
+				common.Infof(ctx, "Found BOS v2 session #%d (%s)", sessionIndex, untenantedSessionData.String())
 			}
 			continue
 		}
@@ -185,7 +186,7 @@ func sessionsV2TestsURI(params *common.Params, tenantList []string) bool {
 			// and note that we have found 1 session belonging to this tenant so far
 			tenantedSessionData = sessionData
 			tenantedSessionCount = 1
-			common.Infof("Found BOS v2 session #%d (%s)", sessionIndex, tenantedSessionData.String())
+			common.Infof(ctx, "Found BOS v2 session #%d (%s)", sessionIndex, tenantedSessionData.String())
 			continue
 		}
 		// We have already found a session belonging to a tenant. If it is the tenant we found first,
@@ -198,55 +199,55 @@ func sessionsV2TestsURI(params *common.Params, tenantList []string) bool {
 	passed := true
 
 	if untenantedSessionData.IsNil() {
-		common.Infof("skipping test GET %s/{session_id} with no tenant specified, because all BOS v2 sessions are owned by tenants",
-			bosV2SessionsUri)
+		common.Infof(ctx, "skipping test GET %s/{session_id} with no tenant specified, because all BOS v2 sessions are owned by tenants",
+			endpointPath(ctx, "sessions"))
 	} else {
 		// test: describe session using the untenanted session name we found earlier
-		_, err = getV2SessionDataApi(params, untenantedSessionData)
+		_, err = getV2SessionDataApi(ctx, client, untenantedSessionData)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			passed = false
 		}
 	}
 
 	if tenantedSessionData.IsNil() {
-		common.Infof("No BOS v2 sessions found belonging to any tenants")
+		common.Infof(ctx, "No BOS v2 sessions found belonging to any tenants")
 
-		tenant := getAnyTenant(tenantList)
-		sessionDataList, err = listV2SessionDataApi(params, tenant)
+		tenant := getAnyTenant(ctx, tenantList)
+		sessionDataList, err = listV2SessionDataApi(ctx, client, tenant)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			passed = false
 		} else {
 			// Validate that this list is empty
 			if len(sessionDataList) > 0 {
-				common.Errorf("Listing all sessions found none owned by tenants, but found %d when listing sessions for tenant '%s'",
+				common.Errorf(ctx, "Listing all sessions found none owned by tenants, but found %d when listing sessions for tenant '%s'",
 					len(sessionDataList), tenant)
 				passed = false
 			}
-			common.Infof("List of sessions belonging to tenant '%s' is empty, as expected", tenant)
+			common.Infof(ctx, "List of sessions belonging to tenant '%s' is empty, as expected", tenant)
 		}
 		return passed
 	}
-	common.Infof("Counted %d BOS v2 sessions belonging to tenant '%s'", tenantedSessionCount, tenantedSessionData.Tenant)
-	sessionDataList, err = listV2SessionDataApi(params, tenantedSessionData.Tenant)
+	common.Infof(ctx, "Counted %d BOS v2 sessions belonging to tenant '%s'", tenantedSessionCount, tenantedSessionData.Tenant)
+	sessionDataList, err = listV2SessionDataApi(ctx, client, tenantedSessionData.Tenant)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		passed = false
 	} else {
 		// Validate that this list is expected length
 		if len(sessionDataList) != tenantedSessionCount {
-			common.Errorf("Listing all sessions found %d owned by tenant '%s', but found %d when listing sessions for that tenant",
+			common.Errorf(ctx, "Listing all sessions found %d owned by tenant '%s', but found %d when listing sessions for that tenant",
 				tenantedSessionCount, tenantedSessionData.Tenant, len(sessionDataList))
 			passed = false
 		}
-		common.Infof("List of sessions belonging to tenant '%s' is the expected length", tenantedSessionData.Tenant)
+		common.Infof(ctx, "List of sessions belonging to tenant '%s' is the expected length", tenantedSessionData.Tenant)
 	}
 
 	// test: describe session using the session name we found owned by a tenant in the earlier loop
-	_, err = getV2SessionDataApi(params, tenantedSessionData)
+	_, err = getV2SessionDataApi(ctx, client, tenantedSessionData)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 
@@ -255,36 +256,36 @@ func sessionsV2TestsURI(params *common.Params, tenantList []string) bool {
 
 // v2 sessions CLI tests
 // See comment at the top of the file for a description of this function
-func sessionsV2TestsCLICommand(tenantList []string, cmdArgs ...string) bool {
+func sessionsV2TestsCLICommand(ctx context.Context, tenantList []string, cmdArgs ...string) bool {
 	var tenantedSessionData, untenantedSessionData v2SessionData
 	var tenantedSessionCount int
 	var ok, passed bool
 	var sessionDataList []v2SessionData
 
 	// test #1, list sessions with no tenant specified
-	sessionDataList, ok = listV2SessionDataCli("", cmdArgs...)
+	sessionDataList, ok = listV2SessionDataCli(ctx, "", cmdArgs...)
 	if !ok {
 		return false
 	}
 	if len(sessionDataList) == 0 {
-		common.Infof("skipping test CLI %s describe {session_id} because result from previous test is []", strings.Join(cmdArgs, " "))
+		common.Infof(ctx, "skipping test CLI %s describe {session_id} because result from previous test is []", strings.Join(cmdArgs, " "))
 
 		// However, we can still try to list all of the sessions with a tenant name specified.
 		// Since no sessions were found from the un-tenanted query, we expect none to be found once a tenant
 		// is specified
-		tenant := getAnyTenant(tenantList)
-		sessionDataList, ok = listV2SessionDataCli("", cmdArgs...)
+		tenant := getAnyTenant(ctx, tenantList)
+		sessionDataList, ok = listV2SessionDataCli(ctx, "", cmdArgs...)
 		if !ok {
 			return false
 		}
 
 		// Validate that this list is empty
 		if len(sessionDataList) > 0 {
-			common.Errorf("Listing of all sessions empty, but found %d when listing sessions for tenant '%s'",
+			common.Errorf(ctx, "Listing of all sessions empty, but found %d when listing sessions for tenant '%s'",
 				len(sessionDataList), tenant)
 			return false
 		}
-		common.Infof("Session list is still empty, as expected")
+		common.Infof(ctx, "Session list is still empty, as expected")
 		return true
 	}
 
@@ -293,14 +294,14 @@ func sessionsV2TestsCLICommand(tenantList []string, cmdArgs ...string) bool {
 	// * One session that belongs to a tenant (tenantedSessionData), and a count (tenantedSessionCount)
 	//   of how many sessions in total have that same tenant
 	for sessionIndex, sessionData := range sessionDataList {
-		common.Debugf("Parsing session #%d in the session list (%s)", sessionIndex, sessionData.String())
+		common.Debugf(ctx, "Parsing session #%d in the session list (%s)", sessionIndex, sessionData.String())
 		if len(sessionData.Tenant) == 0 {
 			// This session has no tenant
 			if untenantedSessionData.IsNil() {
 				// This is the first session we have encountered that has no tenant field,
 				// so take note of it
 				untenantedSessionData = sessionData
-				common.Infof("Found BOS v2 session #%d (%s)", sessionIndex, untenantedSessionData.String())
+				common.Infof(ctx, "Found BOS v2 session #%d (%s)", sessionIndex, untenantedSessionData.String())
 			}
 			continue
 		}
@@ -310,7 +311,7 @@ func sessionsV2TestsCLICommand(tenantList []string, cmdArgs ...string) bool {
 			// and note that we have found 1 session belonging to this tenant so far
 			tenantedSessionData = sessionData
 			tenantedSessionCount = 1
-			common.Infof("Found BOS v2 session #%d (%s)", sessionIndex, tenantedSessionData.String())
+			common.Infof(ctx, "Found BOS v2 session #%d (%s)", sessionIndex, tenantedSessionData.String())
 			continue
 		}
 		// We have already found a session belonging to a tenant. If it is the tenant we found first,
@@ -323,47 +324,47 @@ func sessionsV2TestsCLICommand(tenantList []string, cmdArgs ...string) bool {
 	passed = true
 
 	if untenantedSessionData.IsNil() {
-		common.Infof("skipping test CLI %s describe {session_id} with no tenant specified, because all BOS v2 sessions are owned by tenants", strings.Join(cmdArgs, " "))
+		common.Infof(ctx, "skipping test CLI %s describe {session_id} with no tenant specified, because all BOS v2 sessions are owned by tenants", strings.Join(cmdArgs, " "))
 	} else {
 		// test: describe session using the untenanted session name we found earlier
-		_, ok = describeV2SessionDataCli(untenantedSessionData, cmdArgs...)
+		_, ok = describeV2SessionDataCli(ctx, untenantedSessionData, cmdArgs...)
 		passed = passed && ok
 	}
 
 	if tenantedSessionData.IsNil() {
-		common.Infof("No BOS v2 sessions found belonging to any tenants")
+		common.Infof(ctx, "No BOS v2 sessions found belonging to any tenants")
 
-		tenant := getAnyTenant(tenantList)
-		sessionDataList, ok = listV2SessionDataCli(tenant, cmdArgs...)
+		tenant := getAnyTenant(ctx, tenantList)
+		sessionDataList, ok = listV2SessionDataCli(ctx, tenant, cmdArgs...)
 		if !ok {
 			passed = false
 		} else {
 			// Validate that this list is empty
 			if len(sessionDataList) > 0 {
-				common.Errorf("Listing all sessions found none owned by tenants, but found %d when listing sessions for tenant '%s'",
+				common.Errorf(ctx, "Listing all sessions found none owned by tenants, but found %d when listing sessions for tenant '%s'",
 					len(sessionDataList), tenant)
 				passed = false
 			}
-			common.Infof("List of sessions belonging to tenant '%s' is empty, as expected", tenant)
+			common.Infof(ctx, "List of sessions belonging to tenant '%s' is empty, as expected", tenant)
 		}
 		return passed
 	}
-	common.Infof("Counted %d BOS v2 sessions belonging to tenant '%s'", tenantedSessionCount, tenantedSessionData.Tenant)
-	sessionDataList, ok = listV2SessionDataCli(tenantedSessionData.Tenant, cmdArgs...)
+	common.Infof(ctx, "Counted %d BOS v2 sessions belonging to tenant '%s'", tenantedSessionCount, tenantedSessionData.Tenant)
+	sessionDataList, ok = listV2SessionDataCli(ctx, tenantedSessionData.Tenant, cmdArgs...)
 	if !ok {
 		passed = false
 	} else {
 		// Validate that this list is expected length
 		if len(sessionDataList) != tenantedSessionCount {
-			common.Errorf("Listing all sessions found %d owned by tenant '%s', but found %d when listing sessions for that tenant",
+			common.Errorf(ctx, "Listing all sessions found %d owned by tenant '%s', but found %d when listing sessions for that tenant",
 				tenantedSessionCount, tenantedSessionData.Tenant, len(sessionDataList))
 			passed = false
 		}
-		common.Infof("List of sessions belonging to tenant '%s' is the expected length", tenantedSessionData.Tenant)
+		common.Infof(ctx, "List of sessions belonging to tenant '%s' is the expected length", tenantedSessionData.Tenant)
 	}
 
 	// test: describe session using the session name we found owned by a tenant in the earlier loop
-	_, ok = describeV2SessionDataCli(tenantedSessionData, cmdArgs...)
+	_, ok = describeV2SessionDataCli(ctx, tenantedSessionData, cmdArgs...)
 	passed = passed && ok
 
 	return passed
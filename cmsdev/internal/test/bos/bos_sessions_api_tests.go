@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,69 +29,73 @@
 package bos
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func TestBOSSessionsCRUDOperationsUsingTenants() (passed bool) {
-	passed = TestBOSSessionsCRUDOperations()
+func TestBOSSessionsCRUDOperationsUsingTenants(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestBOSSessionsCRUDOperationsUsingTenants").End(ctx, &passed)
+	passed = TestBOSSessionsCRUDOperations(ctx)
 	tenantList := []string{}
 	dummyTenantName := common.GetDummyTenantName()
 	tenantList = append(tenantList, dummyTenantName)
 	// Running the tests with tenants
-	tenantName := GetTenantFromList()
+	tenantName := GetTenantFromList(ctx)
 	if len(tenantName) != 0 {
 		tenantList = append(tenantList, tenantName)
 	}
 
 	for _, tenant := range tenantList {
 		// Set the tenant name for the tests
-		common.SetTenantName(tenant)
-		passed = passed && TestBOSSessionsCRUDOperations()
+		common.SetTenantName(ctx, tenant)
+		passed = passed && TestBOSSessionsCRUDOperations(ctx)
 		// Unsetting the tenant name after tests
-		common.SetTenantName("")
+		common.SetTenantName(ctx, "")
 	}
 	return passed
 }
 
-func TestBOSSessionsCRUDOperations() (passed bool) {
+func TestBOSSessionsCRUDOperations(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestBOSSessionsCRUDOperations").End(ctx, &passed)
 	passed = true
 	var testRan bool
-	if len(common.GetTenantName()) != 0 {
-		common.PrintLog(fmt.Sprintf("Running BOS session tests with tenant: %s", common.GetTenantName()))
+	if len(common.GetTenantName(ctx)) != 0 {
+		common.PrintLog(ctx, fmt.Sprintf("Running BOS session tests with tenant: %s", common.GetTenantName(ctx)))
 	} else {
-		common.PrintLog("Running BOS session tests without tenant")
+		common.PrintLog(ctx, "Running BOS session tests without tenant")
 	}
 
 	// Range over archMap to create session templates with different architectures
-	for arch := range archMap {
-		imageId, err := GetLatestImageIdFromCsmProductCatalog(arch)
+	for _, arch := range archKeys() {
+		imageId, err := GetLatestImageIdFromCsmProductCatalog(ctx, arch)
 		if err != nil {
-			common.Infof("Unable to get latest image id for architecture %s", archMap[arch])
-			common.Warnf("Skipping BOS session tests for architecture %s", archMap[arch])
+			common.Infof(ctx, "Unable to get latest image id for architecture %s", archMap[arch])
+			common.Warnf(ctx, "Skipping BOS session tests for architecture %s", archMap[arch])
 			continue
 		}
 		testRan = true
-		common.PrintLog(fmt.Sprintf("Running BOS session tests for arch %s", archMap[arch]))
+		common.PrintLog(ctx, fmt.Sprintf("Running BOS session tests for arch %s", archMap[arch]))
 		// Ruuning test suite for both staged and non-staged sessions
 		for _, staged := range []bool{true, false} {
-			sessionRecord, success := TestBOSSessionsCreate(staged, arch, imageId)
+			sessionRecord, success := TestBOSSessionsCreate(ctx, staged, arch, imageId)
 			if !success {
-				common.Errorf("BOS Session creation failed for imageId %s and arch %s", imageId, archMap[arch])
-				common.Warnf("Skipping rest of the BOS session tests for imageId %s and architecture %s", imageId, archMap[arch])
+				common.Errorf(ctx, "BOS Session creation failed for imageId %s and arch %s", imageId, archMap[arch])
+				common.Warnf(ctx, "Skipping rest of the BOS session tests for imageId %s and architecture %s", imageId, archMap[arch])
 				passed = false
 				continue
 			}
 
 			if len(sessionRecord.Name) != 0 {
-				passed = TestBOSSessionsDelete(sessionRecord.Name) &&
-					TestBOSSessionsGetAll() && passed
+				passed = TestBOSSessionsDelete(ctx, sessionRecord.Name) &&
+					TestBOSSessionsGetAll(ctx) && passed
 				// Deleting session template as part of cleanup
 				sessionTemplateInventory := GetBOSSessionTemplateInventoryInstance()
-				if !DeleteBOSSessionTemplatesAPI(sessionTemplateInventory.TemplateNameList[0]) {
-					common.Errorf("Unable to delete BOS session template '%s'", sessionTemplateInventory.TemplateNameList[0])
+				if !DeleteBOSSessionTemplatesAPI(ctx, sessionTemplateInventory.TemplateNameList[0]) {
+					common.Errorf(ctx, "Unable to delete BOS session template '%s'", sessionTemplateInventory.TemplateNameList[0])
 				}
 				// Remove the template from inventory
 				if len(sessionTemplateInventory.TemplateNameList) > 0 {
@@ -103,8 +107,8 @@ func TestBOSSessionsCRUDOperations() (passed bool) {
 	}
 
 	if !testRan {
-		common.Infof("No image found for supported architecture")
-		common.Warnf("Skipping BOS session tests")
+		common.Infof(ctx, "No image found for supported architecture")
+		common.Warnf(ctx, "Skipping BOS session tests")
 		// No image found for supported architecture, skip the tests
 		// return true to indicate that the tests were skipped successfully
 		return true
@@ -113,95 +117,96 @@ func TestBOSSessionsCRUDOperations() (passed bool) {
 	return passed
 }
 
-func TestBOSSessionsCreate(staged bool, arch string, imageId string) (sessionRecord BOSSession, passed bool) {
+func TestBOSSessionsCreate(ctx context.Context, staged bool, arch string, imageId string) (sessionRecord bosclient.Session, passed bool) {
+	defer common.StartSubtest(ctx, "TestBOSSessionsCreate").End(ctx, &passed)
 	sessionName := "BOS_Session_" + string(common.GetRandomString(10))
 	templateName := "BOS_SessionTemplate_" + string(common.GetRandomString(10))
-	common.PrintLog(fmt.Sprintf("Creating BOS session %s with staged=%t", sessionName, staged))
+	common.PrintLog(ctx, fmt.Sprintf("Creating BOS session %s with staged=%t", sessionName, staged))
 
 	// Create session payload
-	sessionPayload, success := CreateBOSSessionPayload(sessionName, templateName, staged, "reboot", arch, imageId)
+	sessionPayload, success := CreateBOSSessionPayload(ctx, sessionName, templateName, staged, "reboot", arch, imageId)
 	if !success {
-		return BOSSession{}, false
+		return bosclient.Session{}, false
 	}
 
-	common.Debugf("Create BOS session payload: %s", sessionPayload)
 	// Create BOS session
-	sessionRecord, success = CreateBOSSessionAPI(sessionPayload)
+	sessionRecord, success = CreateBOSSessionAPI(ctx, sessionPayload)
 	if !success {
-		return BOSSession{}, false
+		return bosclient.Session{}, false
 	}
 
-	if GetExpectedHTTPStatusCode() != http.StatusOK {
-		return BOSSession{}, true // If creation was expected to fail (e.g. using a dummy tenant), skip verification of created resource
+	if GetExpectedHTTPStatusCode(ctx) != http.StatusOK {
+		return bosclient.Session{}, true // If creation was expected to fail (e.g. using a dummy tenant), skip verification of created resource
 	}
 
 	// Verify the created session
-	if !VerifyBOSSession(sessionRecord, sessionPayload) {
-		common.Errorf("Verify failed for BOS session '%s'", sessionRecord.Name)
-		return BOSSession{}, false
+	if !VerifyBOSSession(ctx, sessionRecord, sessionPayload) {
+		common.Errorf(ctx, "Verify failed for BOS session '%s'", sessionRecord.Name)
+		return bosclient.Session{}, false
 	}
 
 	// Get the BOS session
-	_, success = GetBOSSessionAPI(sessionRecord.Name, http.StatusOK)
+	_, success = GetBOSSessionAPI(ctx, sessionRecord.Name)
 	if !success {
-		common.Errorf("Failed to get BOS session '%s'", sessionRecord.Name)
-		return BOSSession{}, false
+		common.Errorf(ctx, "Failed to get BOS session '%s'", sessionRecord.Name)
+		return bosclient.Session{}, false
 	}
 
 	// Check if BOS session is in the list of all sessions
-	sessionList, success := GetAllBOSSessionsAPI()
+	sessionList, success := GetAllBOSSessionsAPI(ctx)
 	if !success {
-		return BOSSession{}, false
+		return bosclient.Session{}, false
 	}
 
-	if !BOSSessionExists(sessionRecord.Name, sessionList) {
-		common.Errorf("BOS session '%s' not found in the list of all sessions", sessionRecord.Name)
-		return BOSSession{}, false
+	if !BOSSessionExists(ctx, sessionRecord.Name, sessionList) {
+		common.Errorf(ctx, "BOS session '%s' not found in the list of all sessions", sessionRecord.Name)
+		return bosclient.Session{}, false
 	}
 	// Add the session template to inventory for cleanup
 	sessionTemplateInventory := GetBOSSessionTemplateInventoryInstance()
 	sessionTemplateInventory.TemplateNameList = append(sessionTemplateInventory.TemplateNameList, templateName)
 
-	common.Infof("BOS session %s created successfully", sessionRecord.Name)
+	common.Infof(ctx, "BOS session %s created successfully", sessionRecord.Name)
 	return sessionRecord, true
 }
 
-func TestBOSSessionsDelete(sessionName string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Deleting BOS session '%s'", sessionName))
+func TestBOSSessionsDelete(ctx context.Context, sessionName string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestBOSSessionsDelete").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting BOS session '%s'", sessionName))
 	// Delete BOS session
-	if !DeleteBOSSessionAPI(sessionName) {
+	if !DeleteBOSSessionAPI(ctx, sessionName) {
 		return false
 	}
 
 	// Check if BOS session is deleted
-	_, success := GetBOSSessionAPI(sessionName, http.StatusNotFound)
-	if !success {
-		common.Errorf("BOS session '%s' still exists", sessionName)
+	if !BOSSessionDeletedAPI(ctx, sessionName) {
+		common.Errorf(ctx, "BOS session '%s' still exists", sessionName)
 		return false
 	}
 
 	// Check if BOS session is deleted from the list of all sessions
-	sessionList, success := GetAllBOSSessionsAPI()
+	sessionList, success := GetAllBOSSessionsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if BOSSessionExists(sessionName, sessionList) {
-		common.Errorf("BOS session '%s' not deleted, found in the list of all sessions", sessionName)
+	if BOSSessionExists(ctx, sessionName, sessionList) {
+		common.Errorf(ctx, "BOS session '%s' not deleted, found in the list of all sessions", sessionName)
 		return false
 	}
 
-	common.Infof("Deleted BOS session '%s' successfully", sessionName)
+	common.Infof(ctx, "Deleted BOS session '%s' successfully", sessionName)
 	return true
 }
 
-func TestBOSSessionsGetAll() (passed bool) {
+func TestBOSSessionsGetAll(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestBOSSessionsGetAll").End(ctx, &passed)
 	// Get all BOS sessions
-	common.PrintLog("Getting all BOS sessions")
-	sessionList, success := GetAllBOSSessionsAPI()
+	common.PrintLog(ctx, "Getting all BOS sessions")
+	sessionList, success := GetAllBOSSessionsAPI(ctx)
 	if !success {
 		return false
 	}
-	common.Infof("Found %d BOS sessions", len(sessionList))
+	common.Infof(ctx, "Found %d BOS sessions", len(sessionList))
 	return true
 }
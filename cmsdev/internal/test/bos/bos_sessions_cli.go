@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,58 +29,64 @@
 package bos
 
 import (
-	"encoding/json"
+	"context"
 	"strconv"
 
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func CreateBOSSessionCLI(staged bool, sessionName, templateName, cliVersion string) (sessionRecord BOSSession, ok bool) {
-	common.Infof("Creating session template %s in BOS via CLI", sessionName)
-	if cmdOut := RunVersionedBOSCommand(cliVersion, "sessions", "create", "--name", sessionName, "--stage", strconv.FormatBool(staged),
+func CreateBOSSessionCLI(ctx context.Context, staged bool, sessionName, templateName, cliVersion string) (sessionRecord bosclient.Session, ok bool) {
+	common.Infof(ctx, "Creating session template %s in BOS via CLI", sessionName)
+	if cmdOut := RunVersionedBOSCommand(ctx, cliVersion, "sessions", "create", "--name", sessionName, "--stage", strconv.FormatBool(staged),
 		"--template-name", templateName, "--operation", "reboot", "--limit", "fakexname"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &sessionRecord); err == nil {
+		registerBOSSessionCleanup(ctx, sessionName)
+		var err error
+		if sessionRecord, err = bosclient.ParseSession(cmdOut); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	// If the tenant is a dummy tenant, we expect the command to fail
-	if common.IsDummyTenant(common.GetTenantName()) {
-		return BOSSession{}, true
+	if common.IsDummyTenant(common.GetTenantName(ctx)) {
+		return bosclient.Session{}, true
 	}
 
 	return
 }
 
-func GetBOSSessionRecordCLI(sessionName, cliVersion string) (sessionRecord BOSSession, ok bool) {
-	common.Infof("Getting session %s in BOS via CLI", sessionName)
-	if cmdOut := RunVersionedBOSCommand(cliVersion, "sessions", "describe", sessionName); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &sessionRecord); err == nil {
+func GetBOSSessionRecordCLI(ctx context.Context, sessionName, cliVersion string) (sessionRecord bosclient.Session, ok bool) {
+	common.Infof(ctx, "Getting session %s in BOS via CLI", sessionName)
+	if cmdOut := RunVersionedBOSCommand(ctx, cliVersion, "sessions", "describe", sessionName); cmdOut != nil {
+		var err error
+		if sessionRecord, err = bosclient.ParseSession(cmdOut); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func GetBOSSessionRecordsCLI(cliVersion string) (sessionRecords []BOSSession, ok bool) {
-	common.Infof("Getting all sessions in BOS via CLI")
-	if cmdOut := RunVersionedBOSCommand(cliVersion, "sessions", "list"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &sessionRecords); err == nil {
+func GetBOSSessionRecordsCLI(ctx context.Context, cliVersion string) (sessionRecords []bosclient.Session, ok bool) {
+	common.Infof(ctx, "Getting all sessions in BOS via CLI")
+	if cmdOut := RunVersionedBOSCommand(ctx, cliVersion, "sessions", "list"); cmdOut != nil {
+		var err error
+		if sessionRecords, err = bosclient.ParseSessions(cmdOut); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func DeleteBOSSessionCLI(sessionName, cliVersion string) (ok bool) {
-	common.Infof("Deleting session %s in BOS via CLI", sessionName)
-	return RunVersionedBOSCommand(cliVersion, "sessions", "delete", sessionName) != nil
+func DeleteBOSSessionCLI(ctx context.Context, sessionName, cliVersion string) (ok bool) {
+	common.Infof(ctx, "Deleting session %s in BOS via CLI", sessionName)
+	if RunVersionedBOSCommand(ctx, cliVersion, "sessions", "delete", sessionName) == nil {
+		return false
+	}
+	common.ResourceDeleted(ctx, bosSessionKind, sessionName)
+	return true
 }
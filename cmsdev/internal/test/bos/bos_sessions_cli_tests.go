@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,71 +29,74 @@
 package bos
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
 
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func TestSessionsCRUDOperationsWithTenantUsingCLI() (passed bool) {
-	passed = TestSessionsCRUDOperationsUsingCLI()
+func TestSessionsCRUDOperationsWithTenantUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionsCRUDOperationsWithTenantUsingCLI").End(ctx, &passed)
+	passed = TestSessionsCRUDOperationsUsingCLI(ctx)
 	tenantList := []string{}
 	dummyTenantName := common.GetDummyTenantName()
 	tenantList = append(tenantList, dummyTenantName)
 	// Running the tests with tenants
-	tenantName := GetTenantFromList()
+	tenantName := GetTenantFromList(ctx)
 	if len(tenantName) != 0 {
 		tenantList = append(tenantList, tenantName)
 	}
 
 	for _, tenant := range tenantList {
 		// Set the tenant name for the tests
-		common.SetTenantName(tenant)
-		passed = passed && TestSessionsCRUDOperationsUsingCLI()
+		common.SetTenantName(ctx, tenant)
+		passed = passed && TestSessionsCRUDOperationsUsingCLI(ctx)
 		// Unsetting the tenant name after tests
-		common.SetTenantName("")
+		common.SetTenantName(ctx, "")
 	}
 	return passed
 }
 
-func TestSessionsCRUDOperationsUsingCLI() (passed bool) {
+func TestSessionsCRUDOperationsUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionsCRUDOperationsUsingCLI").End(ctx, &passed)
 	passed = true
 	var testRan bool
-	if len(common.GetTenantName()) != 0 {
-		common.PrintLog(fmt.Sprintf("Running BOS session CLI tests with tenant: %s", common.GetTenantName()))
+	if len(common.GetTenantName(ctx)) != 0 {
+		common.PrintLog(ctx, fmt.Sprintf("Running BOS session CLI tests with tenant: %s", common.GetTenantName(ctx)))
 	} else {
-		common.PrintLog("Running BOS session CLI tests without Tenant")
+		common.PrintLog(ctx, "Running BOS session CLI tests without Tenant")
 	}
 
 	// Range over archMap to create session templates with different architectures
-	for arch := range archMap {
-		imageId, err := GetLatestImageIdFromCsmProductCatalog(arch)
+	for _, arch := range archKeys() {
+		imageId, err := GetLatestImageIdFromCsmProductCatalog(ctx, arch)
 		if err != nil {
-			common.Infof("Unable to get latest image id for architecture %s", archMap[arch])
-			common.Warnf("Skipping BOS session tests for architecture %s", archMap[arch])
+			common.Infof(ctx, "Unable to get latest image id for architecture %s", archMap[arch])
+			common.Warnf(ctx, "Skipping BOS session tests for architecture %s", archMap[arch])
 			continue
 		}
 		testRan = true
 		for cliVersion := range bosCliVersions {
-			common.PrintLog(fmt.Sprintf("Running BOS session CLI tests with version %s", cliVersion))
+			common.PrintLog(ctx, fmt.Sprintf("Running BOS session CLI tests with version %s", cliVersion))
 			// Ruuning test suite for both staged and non-staged sessions
 			for _, staged := range []bool{true, false} {
-				sessionRecord, success := TestCLIBOSSessionsCreate(staged, arch, imageId, bosCliVersions[cliVersion])
+				sessionRecord, success := TestCLIBOSSessionsCreate(ctx, staged, arch, imageId, bosCliVersions[cliVersion])
 				if !success {
-					common.Errorf("BOS Session creation failed for imageId %s and arch %s", imageId, archMap[arch])
-					common.Warnf("Skipping rest of the BOS session tests for imageId %s and architecture %s", imageId, archMap[arch])
+					common.Errorf(ctx, "BOS Session creation failed for imageId %s and arch %s", imageId, archMap[arch])
+					common.Warnf(ctx, "Skipping rest of the BOS session tests for imageId %s and architecture %s", imageId, archMap[arch])
 					passed = false
 					continue
 				}
 				if len(sessionRecord.Name) != 0 {
-					passed = TestCLIBOSSessionsDelete(sessionRecord.Name, bosCliVersions[cliVersion]) &&
-						TestCLiBOSSessionsGetAll(bosCliVersions[cliVersion]) && passed
+					passed = TestCLIBOSSessionsDelete(ctx, sessionRecord.Name, bosCliVersions[cliVersion]) &&
+						TestCLiBOSSessionsGetAll(ctx, bosCliVersions[cliVersion]) && passed
 					// Deleting session template as part of cleanup
 					sessionTemplateInventory := GetBOSSessionTemplateInventoryInstance()
-					if !DeleteBOSSessionTemplatesAPI(sessionTemplateInventory.TemplateNameList[0]) {
-						common.Errorf("Unable to delete BOS session template '%s'", sessionTemplateInventory.TemplateNameList[0])
+					if !DeleteBOSSessionTemplatesAPI(ctx, sessionTemplateInventory.TemplateNameList[0]) {
+						common.Errorf(ctx, "Unable to delete BOS session template '%s'", sessionTemplateInventory.TemplateNameList[0])
 					}
 					// Remove the template from inventory
 					if len(sessionTemplateInventory.TemplateNameList) > 0 {
@@ -105,8 +108,8 @@ func TestSessionsCRUDOperationsUsingCLI() (passed bool) {
 	}
 
 	if !testRan {
-		common.Infof("No image found for supported architecture")
-		common.Warnf("Skipping BOS session tests")
+		common.Infof(ctx, "No image found for supported architecture")
+		common.Warnf(ctx, "Skipping BOS session tests")
 		// No image found for supported architecture, skip the tests
 		// return true to indicate that the tests were skipped successfully
 		return true
@@ -115,138 +118,134 @@ func TestSessionsCRUDOperationsUsingCLI() (passed bool) {
 	return passed
 }
 
-func TestCLIBOSSessionsCreate(staged bool, arch, imageId, cliVersion string) (sessionRecord BOSSession, passed bool) {
+func TestCLIBOSSessionsCreate(ctx context.Context, staged bool, arch, imageId, cliVersion string) (sessionRecord bosclient.Session, passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIBOSSessionsCreate").End(ctx, &passed)
 	// Create a session using the CLI
 	sessionName := "BOS_Session_" + string(common.GetRandomString(10))
-	common.PrintLog(fmt.Sprintf("Creating BOS session %s with staged=%t and arch %s", sessionName, staged, archMap[arch]))
+	common.PrintLog(ctx, fmt.Sprintf("Creating BOS session %s with staged=%t and arch %s", sessionName, staged, archMap[arch]))
 
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
 	templateName := "BOS_SessionTemplate_" + string(common.GetRandomString(10))
 
 	// Create Bos session template payload needed for session creation
-	fileName, _, success := GetCreateBOSSessionTemplatePayloadCLI(cfgName, true, arch, imageId)
+	fileName, _, success := GetCreateBOSSessionTemplatePayloadCLI(ctx, cfgName, true, arch, imageId)
 	if !success {
-		return BOSSession{}, false
+		return bosclient.Session{}, false
 	}
 
-	if common.IsDummyTenant(common.GetTenantName()) {
+	if common.IsDummyTenant(common.GetTenantName(ctx)) {
 		// Set execution code to 2 to indiacate that we expect the command to fail
-		test.SetCliExecreturnCode(2)
+		test.SetCliExecreturnCode(ctx, 2)
 	}
 
 	// Create BOS session template using the payload
-	sessionTemplateRecord, success := CreateBOSSessionTemplatesCLI(templateName, fileName, cfgName, cliVersion)
+	sessionTemplateRecord, success := CreateBOSSessionTemplatesCLI(ctx, templateName, fileName, cfgName, cliVersion)
 	if !success {
-		common.Errorf("Session template creation failed for imageId %s and arch %s", imageId, archMap[arch])
-		return BOSSession{}, false
+		common.Errorf(ctx, "Session template creation failed for imageId %s and arch %s", imageId, archMap[arch])
+		return bosclient.Session{}, false
 	}
 
 	// remove the session template file after creation
 	if err := os.Remove(fileName); err != nil {
-		common.Errorf("Unable to remove session template file %s: %v", fileName, err)
+		common.Errorf(ctx, "Unable to remove session template file %s: %v", fileName, err)
 	}
 
-	sessionRecord, success = CreateBOSSessionCLI(staged, sessionName, sessionTemplateRecord.Name, cliVersion)
+	sessionRecord, success = CreateBOSSessionCLI(ctx, staged, sessionName, sessionTemplateRecord.Name, cliVersion)
 	if !success {
-		common.Errorf("Session creation failed for imageId %s and arch %s", imageId, archMap[arch])
-		return BOSSession{}, false
+		common.Errorf(ctx, "Session creation failed for imageId %s and arch %s", imageId, archMap[arch])
+		return bosclient.Session{}, false
 	}
 
-	if test.GetCliExecreturnCode() != 0 {
-		test.SetCliExecreturnCode(0)
-		return BOSSession{}, true // If creation was expected to fail (e.g. using a dummy tenant), skip verification of created resource
+	if test.GetCliExecreturnCode(ctx) != 0 {
+		test.SetCliExecreturnCode(ctx, 0)
+		return bosclient.Session{}, true // If creation was expected to fail (e.g. using a dummy tenant), skip verification of created resource
 	}
 
 	// Creating expected session record for verification
-	expectedBOSSession := BOSSession{
-		Name:          sessionName,
-		Template_name: sessionTemplateRecord.Name,
-		Stage:         staged,
-		Operation:     "reboot",
-		Limit:         "fakexname",
-	}
-
-	// Marshalling the expected session record to JSON format
-	expectedBOSSessionJson, err := json.Marshal(expectedBOSSession)
-	if err != nil {
-		common.Errorf("Error marshalling expected session record: %v", err)
-		return BOSSession{}, false
+	expectedBOSSession := bosclient.SessionCreate{
+		Name:         sessionName,
+		TemplateName: sessionTemplateRecord.Name,
+		Stage:        staged,
+		Operation:    "reboot",
+		Limit:        "fakexname",
 	}
 
 	// Verify the created session
-	if !VerifyBOSSession(sessionRecord, string(expectedBOSSessionJson)) {
-		common.Errorf("Verify failed for BOS session '%s'", sessionRecord.Name)
-		return BOSSession{}, false
+	if !VerifyBOSSession(ctx, sessionRecord, expectedBOSSession) {
+		common.Errorf(ctx, "Verify failed for BOS session '%s'", sessionRecord.Name)
+		return bosclient.Session{}, false
 	}
 
 	// Get the BOS session
-	_, success = GetBOSSessionRecordCLI(sessionRecord.Name, cliVersion)
+	_, success = GetBOSSessionRecordCLI(ctx, sessionRecord.Name, cliVersion)
 	if !success {
-		return BOSSession{}, false
+		return bosclient.Session{}, false
 	}
 
 	// Verify session in list of sessions
-	sessionList, success := GetBOSSessionRecordsCLI(cliVersion)
+	sessionList, success := GetBOSSessionRecordsCLI(ctx, cliVersion)
 
 	if !success {
-		return BOSSession{}, false
+		return bosclient.Session{}, false
 	}
 
-	if !BOSSessionExists(sessionRecord.Name, sessionList) {
-		common.Errorf("BOS session '%s' not found in the list of all sessions", sessionRecord.Name)
-		return BOSSession{}, false
+	if !BOSSessionExists(ctx, sessionRecord.Name, sessionList) {
+		common.Errorf(ctx, "BOS session '%s' not found in the list of all sessions", sessionRecord.Name)
+		return bosclient.Session{}, false
 	}
 
 	// Add the session template to inventory for cleanup
 	sessionTemplateInventory := GetBOSSessionTemplateInventoryInstance()
 	sessionTemplateInventory.TemplateNameList = append(sessionTemplateInventory.TemplateNameList, templateName)
 
-	common.Infof("BOS session %s created successfully", sessionRecord.Name)
+	common.Infof(ctx, "BOS session %s created successfully", sessionRecord.Name)
 	return sessionRecord, true
 }
 
-func TestCLIBOSSessionsDelete(sessionName, cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Deleting BOS session '%s'", sessionName))
+func TestCLIBOSSessionsDelete(ctx context.Context, sessionName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIBOSSessionsDelete").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting BOS session '%s'", sessionName))
 	// Delete BOS session
-	if !DeleteBOSSessionCLI(sessionName, cliVersion) {
+	if !DeleteBOSSessionCLI(ctx, sessionName, cliVersion) {
 		return false
 	}
 
 	// Set CLI execution return code to 2. Since the session is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 	// Check if BOS session is deleted
-	_, success := GetBOSSessionRecordCLI(sessionName, cliVersion)
+	_, success := GetBOSSessionRecordCLI(ctx, sessionName, cliVersion)
 	if success {
-		common.Errorf("BOS session '%s' still exists", sessionName)
+		common.Errorf(ctx, "BOS session '%s' still exists", sessionName)
 		return false
 	}
 
 	// Set CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// Check if BOS session is deleted from the list of all sessions
-	sessionList, success := GetBOSSessionRecordsCLI(cliVersion)
+	sessionList, success := GetBOSSessionRecordsCLI(ctx, cliVersion)
 	if !success {
 		return false
 	}
 
-	if BOSSessionExists(sessionName, sessionList) {
-		common.Errorf("BOS session '%s' not deleted, found in the list of all sessions", sessionName)
+	if BOSSessionExists(ctx, sessionName, sessionList) {
+		common.Errorf(ctx, "BOS session '%s' not deleted, found in the list of all sessions", sessionName)
 		return false
 	}
 
-	common.Infof("Deleted BOS session '%s' successfully", sessionName)
+	common.Infof(ctx, "Deleted BOS session '%s' successfully", sessionName)
 	return true
 }
 
-func TestCLiBOSSessionsGetAll(cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Getting all BOS sessions"))
+func TestCLiBOSSessionsGetAll(ctx context.Context, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLiBOSSessionsGetAll").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Getting all BOS sessions"))
 	// Get all BOS sessions using the CLI
-	sessionList, success := GetBOSSessionRecordsCLI(cliVersion)
+	sessionList, success := GetBOSSessionRecordsCLI(ctx, cliVersion)
 	if !success {
 		return false
 	}
 
-	common.Infof("Found %d BOS sessions", len(sessionList))
+	common.Infof(ctx, "Found %d BOS sessions", len(sessionList))
 	return true
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,69 +29,73 @@
 package bos
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func TestSessionTemplatesCRUDOperationsUsingTenants() (passed bool) {
-	passed = TestSessionTemplatesCRUDOperations()
+func TestSessionTemplatesCRUDOperationsUsingTenants(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionTemplatesCRUDOperationsUsingTenants").End(ctx, &passed)
+	passed = TestSessionTemplatesCRUDOperations(ctx)
 	tenantList := []string{}
 	dummyTenantName := common.GetDummyTenantName()
 	tenantList = append(tenantList, dummyTenantName)
 	// Running the tests with tenants
-	tenantName := GetTenantFromList()
+	tenantName := GetTenantFromList(ctx)
 	if len(tenantName) != 0 {
 		tenantList = append(tenantList, tenantName)
 	}
 
 	for _, tenant := range tenantList {
 		// Set the tenant name for the tests
-		common.SetTenantName(tenant)
-		passed = passed && TestSessionTemplatesCRUDOperations()
+		common.SetTenantName(ctx, tenant)
+		passed = passed && TestSessionTemplatesCRUDOperations(ctx)
 		// Unsetting the tenant name after tests
-		common.SetTenantName("")
+		common.SetTenantName(ctx, "")
 	}
 	return passed
 }
 
-func TestSessionTemplatesCRUDOperations() (passed bool) {
+func TestSessionTemplatesCRUDOperations(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionTemplatesCRUDOperations").End(ctx, &passed)
 	passed = true
 	var testRan bool
-	if len(common.GetTenantName()) != 0 {
-		common.PrintLog(fmt.Sprintf("Running BOS session template tests with tenant: %s", common.GetTenantName()))
+	if len(common.GetTenantName(ctx)) != 0 {
+		common.PrintLog(ctx, fmt.Sprintf("Running BOS session template tests with tenant: %s", common.GetTenantName(ctx)))
 	} else {
-		common.PrintLog("Running BOS session template tests without Tenant")
+		common.PrintLog(ctx, "Running BOS session template tests without Tenant")
 	}
 	// Range over archMap to create session templates with different architectures
-	for arch := range archMap {
-		imageId, err := GetLatestImageIdFromCsmProductCatalog(arch)
+	for _, arch := range archKeys() {
+		imageId, err := GetLatestImageIdFromCsmProductCatalog(ctx, arch)
 		if err != nil {
-			common.Infof("Unable to get latest image id for architecture %s", archMap[arch])
-			common.Warnf("Skipping BOS session template tests for architecture %s", archMap[arch])
+			common.Infof(ctx, "Unable to get latest image id for architecture %s", archMap[arch])
+			common.Warnf(ctx, "Skipping BOS session template tests for architecture %s", archMap[arch])
 			continue
 		}
 		testRan = true
-		common.PrintLog(fmt.Sprintf("Running BOS session template tests for arch %s", archMap[arch]))
-		sessionTemplateRecord, ok := TestSessionTemplatesCreate(arch, imageId)
+		common.PrintLog(ctx, fmt.Sprintf("Running BOS session template tests for arch %s", archMap[arch]))
+		sessionTemplateRecord, ok := TestSessionTemplatesCreate(ctx, arch, imageId)
 		if !ok {
-			common.Errorf("Session template creation failed for imageId %s and arch %s", imageId, archMap[arch])
-			common.Warnf("Skipping rest of the BOS session template tests for imageId %s and architecture %s", imageId, archMap[arch])
+			common.Errorf(ctx, "Session template creation failed for imageId %s and arch %s", imageId, archMap[arch])
+			common.Warnf(ctx, "Skipping rest of the BOS session template tests for imageId %s and architecture %s", imageId, archMap[arch])
 			passed = false
 			continue
 		}
 
 		if len(sessionTemplateRecord.Name) != 0 {
-			passed = TestSessionTemplatesUpdate(sessionTemplateRecord.Name, imageId) &&
-				TestSessionTemplatesDelete(sessionTemplateRecord.Name) &&
-				TestSessionTemplatesGetAll() && passed
+			passed = TestSessionTemplatesUpdate(ctx, sessionTemplateRecord.Name, imageId) &&
+				TestSessionTemplatesDelete(ctx, sessionTemplateRecord.Name) &&
+				TestSessionTemplatesGetAll(ctx) && passed
 		}
 	}
 
 	if !testRan {
-		common.Infof("No image found for supported architecture")
-		common.Warnf("Skipping BOS session template tests")
+		common.Infof(ctx, "No image found for supported architecture")
+		common.Warnf(ctx, "Skipping BOS session template tests")
 		// No image found for supported architecture, skip the tests
 		// return true to indicate that the tests were skipped successfully
 		return true
@@ -100,147 +104,148 @@ func TestSessionTemplatesCRUDOperations() (passed bool) {
 	return passed
 }
 
-func TestSessionTemplatesCreate(imageArch string, imageId string) (sessionTemplateRecord BOSSessionTemplate, passed bool) {
+func TestSessionTemplatesCreate(ctx context.Context, imageArch string, imageId string) (sessionTemplateRecord bosclient.SessionTemplate, passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionTemplatesCreate").End(ctx, &passed)
 	templateName := "BOS_SessionTemplate_" + string(common.GetRandomString(10))
-	common.PrintLog(fmt.Sprintf("Creating BOS session template %s with image ID %s and  arch %s", templateName, imageId, imageArch))
+	common.PrintLog(ctx, fmt.Sprintf("Creating BOS session template %s with image ID %s and  arch %s", templateName, imageId, imageArch))
 
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
 
 	// create sessiontemplates payload
-	payload, success := GetCreateBOSSessionTemplatePayload(cfgName, false, imageArch, imageId)
+	template, success := BuildBOSSessionTemplate(ctx, cfgName, false, imageArch, imageId)
 	if !success {
-		return BOSSessionTemplate{}, false
+		return bosclient.SessionTemplate{}, false
 	}
-	common.Debugf("BOS Session template create payload: %s", payload)
 	// Create session template
-	sessionTemplateRecord, success = CreateUpdateBOSSessiontemplatesAPI(payload, templateName, "PUT")
+	sessionTemplateRecord, success = CreateBOSSessionTemplateAPI(ctx, template, templateName)
 	if !success {
-		return BOSSessionTemplate{}, false
+		return bosclient.SessionTemplate{}, false
 	}
 
-	if GetExpectedHTTPStatusCode() != http.StatusOK {
-		return BOSSessionTemplate{}, true // If creation was expected to fail (e.g. using a dummy tenant), skip verification of created resource
+	if GetExpectedHTTPStatusCode(ctx) != http.StatusOK {
+		return bosclient.SessionTemplate{}, true // If creation was expected to fail (e.g. using a dummy tenant), skip verification of created resource
 	}
 
 	// Verify sessiontemplate
-	if !VerifyBOSSessionTemplate(sessionTemplateRecord, payload, templateName) {
-		common.Errorf("Session template %s verification failed", sessionTemplateRecord.Name)
-		return BOSSessionTemplate{}, false
+	if !VerifyBOSSessionTemplate(ctx, sessionTemplateRecord, template, templateName) {
+		common.Errorf(ctx, "Session template %s verification failed", sessionTemplateRecord.Name)
+		return bosclient.SessionTemplate{}, false
 	}
 
 	// Get the created session template
-	_, success = GetBOSSessionTemplatesAPI(sessionTemplateRecord.Name, http.StatusOK)
+	_, success = GetBOSSessionTemplatesAPI(ctx, sessionTemplateRecord.Name)
 	if !success {
-		common.Errorf("Unable to get BOS session template %s", sessionTemplateRecord.Name)
-		return BOSSessionTemplate{}, false
+		common.Errorf(ctx, "Unable to get BOS session template %s", sessionTemplateRecord.Name)
+		return bosclient.SessionTemplate{}, false
 	}
 
 	// verify session template in list of session templates
-	sessionTemplateRecords, success := GetAllBOSSessionTemplatesAPI()
+	sessionTemplateRecords, success := GetAllBOSSessionTemplatesAPI(ctx)
 	if !success {
-		common.Errorf("Unable to get all session templates")
-		return BOSSessionTemplate{}, false
+		common.Errorf(ctx, "Unable to get all session templates")
+		return bosclient.SessionTemplate{}, false
 	}
 
-	if !BOSSessionTemplateExists(sessionTemplateRecord.Name, sessionTemplateRecords) {
-		common.Errorf("BOS session template %s not found in list of session templates", sessionTemplateRecord.Name)
-		return BOSSessionTemplate{}, false
+	if !BOSSessionTemplateExists(ctx, sessionTemplateRecord.Name, sessionTemplateRecords) {
+		common.Errorf(ctx, "BOS session template %s not found in list of session templates", sessionTemplateRecord.Name)
+		return bosclient.SessionTemplate{}, false
 	}
 
 	// Validate session template
-	if !ValidateBOSSessionTemplateAPI(sessionTemplateRecord.Name) {
-		return BOSSessionTemplate{}, false
+	if !ValidateBOSSessionTemplateAPI(ctx, sessionTemplateRecord.Name) {
+		return bosclient.SessionTemplate{}, false
 	}
 
-	common.Infof("Session template %s created successfully", sessionTemplateRecord.Name)
+	common.Infof(ctx, "Session template %s created successfully", sessionTemplateRecord.Name)
 	return sessionTemplateRecord, true
 
 }
 
-func TestSessionTemplatesUpdate(templateName string, imageId string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Updating session template %s", templateName))
+func TestSessionTemplatesUpdate(ctx context.Context, templateName string, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionTemplatesUpdate").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Updating session template %s", templateName))
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
 
-	sessionTemplate, success := GetBOSSessionTemplatesAPI(templateName, http.StatusOK)
+	sessionTemplate, success := GetBOSSessionTemplatesAPI(ctx, templateName)
 	if !success {
-		common.Errorf("Unable to get session template %s", templateName)
+		common.Errorf(ctx, "Unable to get session template %s", templateName)
 		return false
 	}
 
-	payload, success := GetCreateBOSSessionTemplatePayload(cfgName, true, sessionTemplate.Boot_sets.Compute.Arch, imageId)
+	template, success := BuildBOSSessionTemplate(ctx, cfgName, true, templateArch(sessionTemplate), imageId)
 	if !success {
 		return false
 	}
-	common.Debugf("Session template update payload: %s", payload)
-	sessionTemplateRecord, success := CreateUpdateBOSSessiontemplatesAPI(payload, templateName, "PATCH")
+	sessionTemplateRecord, success := UpdateBOSSessionTemplateAPI(ctx, template, templateName)
 	if !success {
-		common.Errorf("Session template %s update failed", templateName)
+		common.Errorf(ctx, "Session template %s update failed", templateName)
 		return false
 	}
 
 	// Verify sessiontemplate
-	if !VerifyBOSSessionTemplate(sessionTemplateRecord, payload, templateName) {
-		common.Errorf("Session template %s verification failed", sessionTemplateRecord.Name)
+	if !VerifyBOSSessionTemplate(ctx, sessionTemplateRecord, template, templateName) {
+		common.Errorf(ctx, "Session template %s verification failed", sessionTemplateRecord.Name)
 		return false
 	}
 
 	// Get the created session template
-	_, success = GetBOSSessionTemplatesAPI(sessionTemplateRecord.Name, http.StatusOK)
+	_, success = GetBOSSessionTemplatesAPI(ctx, sessionTemplateRecord.Name)
 	if !success {
-		common.Errorf("Unable to get BOS session template %s", sessionTemplateRecord.Name)
+		common.Errorf(ctx, "Unable to get BOS session template %s", sessionTemplateRecord.Name)
 		return false
 	}
 
 	// Validate session template
-	if !ValidateBOSSessionTemplateAPI(sessionTemplateRecord.Name) {
+	if !ValidateBOSSessionTemplateAPI(ctx, sessionTemplateRecord.Name) {
 		return false
 	}
 
-	common.Infof("Session template %s updated successfully", templateName)
+	common.Infof(ctx, "Session template %s updated successfully", templateName)
 	return true
 
 }
 
-func TestSessionTemplatesDelete(templateName string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Deleting session template %s", templateName))
+func TestSessionTemplatesDelete(ctx context.Context, templateName string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionTemplatesDelete").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting session template %s", templateName))
 	// Delete session template
-	if !DeleteBOSSessionTemplatesAPI(templateName) {
-		common.Errorf("Unable to delete sessiontemplate %s", templateName)
+	if !DeleteBOSSessionTemplatesAPI(ctx, templateName) {
+		common.Errorf(ctx, "Unable to delete sessiontemplate %s", templateName)
 		return false
 	}
 
 	// Get the deleted session template
-	_, success := GetBOSSessionTemplatesAPI(templateName, http.StatusNotFound)
-	if !success {
-		common.Errorf("BOS sessiontemplate %s was not deleted", templateName)
+	if !BOSSessionTemplateDeletedAPI(ctx, templateName) {
+		common.Errorf(ctx, "BOS sessiontemplate %s was not deleted", templateName)
 		return false
 	}
 
 	// verify session template in list of session templates
-	sessionTemplateRecords, success := GetAllBOSSessionTemplatesAPI()
+	sessionTemplateRecords, success := GetAllBOSSessionTemplatesAPI(ctx)
 	if !success {
-		common.Errorf("Unable to get all session templates")
+		common.Errorf(ctx, "Unable to get all session templates")
 		return false
 	}
 
-	if BOSSessionTemplateExists(templateName, sessionTemplateRecords) {
-		common.Errorf("BOS session template %s not deleted, found in list of session templates", templateName)
+	if BOSSessionTemplateExists(ctx, templateName, sessionTemplateRecords) {
+		common.Errorf(ctx, "BOS session template %s not deleted, found in list of session templates", templateName)
 		return false
 	}
 
-	common.Infof("Deleted sessiontemplate %s successfully", templateName)
+	common.Infof(ctx, "Deleted sessiontemplate %s successfully", templateName)
 	return true
 }
 
-func TestSessionTemplatesGetAll() (passed bool) {
-	common.PrintLog("Getting all session templates")
+func TestSessionTemplatesGetAll(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionTemplatesGetAll").End(ctx, &passed)
+	common.PrintLog(ctx, "Getting all session templates")
 	// Get all session templates
-	sessionTemplateRecords, success := GetAllBOSSessionTemplatesAPI()
+	sessionTemplateRecords, success := GetAllBOSSessionTemplatesAPI(ctx)
 	if !success {
-		common.Errorf("Unable to get all session templates")
+		common.Errorf(ctx, "Unable to get all session templates")
 		return false
 	}
 
-	common.Infof("Found %d BOS sessiontemplates", len(sessionTemplateRecords))
+	common.Infof(ctx, "Found %d BOS sessiontemplates", len(sessionTemplateRecords))
 	return true
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,92 +29,113 @@ package bos
  */
 
 import (
+	"context"
 	"strings"
 
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
 // The sessionTemplatesTestsURI and sessionTemplatesTestsCLICommand functions define the API and CLI versions of the BOS session template subtests.
 // They both basically do the same thing.
 // 1. List all session templates
-// 2. Verify that this succeeds and returns something of the right general form
-// 3. If the list returned is empty, then the subtest is over. Otherwise, select the first element of the list and extract the "name" field
+// 2. Verify that this succeeds and returns a list of session templates
+// 3. If the list returned is empty, then the subtest is over. Otherwise, select the first element of the list
 // 4. Do a GET/describe on that particular session template
-// 5. Verify that this succeeds and returns something of the right general form
+// 5. Verify that this succeeds and returns the same session template
 
-func sessionTemplatesTestsAPI(params *common.Params, tenantList []string, includeTenant bool) (passed bool) {
+// Logs the boot sets of the session template template
+func logSessionTemplateTemplate(ctx context.Context, template bosclient.SessionTemplate) {
+	for _, bootSetName := range template.BootSetNames() {
+		bootSet := template.BootSets[bootSetName]
+		common.Infof(ctx, "BOS session template template has boot set '%s' (arch: '%s', type: '%s')", bootSetName, bootSet.Arch, bootSet.Type)
+	}
+}
+
+func parseSessionTemplateTemplateOutput(ctx context.Context, cmdOut []byte) error {
+	template, err := bosclient.ParseSessionTemplateTemplate(cmdOut)
+	if err == nil {
+		logSessionTemplateTemplate(ctx, template)
+	}
+	return err
+}
+
+func sessionTemplatesTestsAPI(ctx context.Context, client bosclient.Client, tenantList []string, includeTenant bool) (passed bool) {
 	passed = true
 
 	// session template template API tests
 	// Just do a GET of the sessiontemplatetemplate endpoint and make sure that the response has
-	// 200 status and a dictionary object
+	// 200 status and a session template with at least one boot set
 
 	// v2
-	if !basicGetUriVerifyStringMapTest(bosV2SessionTemplateTemplateUri, params) {
+	apiScenario(ctx, client, "GET", endpointPath(ctx, "sessiontemplatetemplate"))
+	if template, err := client.SessionTemplateTemplate(ctx); checkNoError(ctx, err) {
+		logSessionTemplateTemplate(ctx, template)
+	} else {
 		passed = false
 	}
 
 	// session template API tests
 
 	// v2
-	if !v2SessionTemplatesTestsURI(params, tenantList) {
+	if !v2SessionTemplatesTestsURI(ctx, client, tenantList) {
 		passed = false
 	}
 
 	// Tenant tests will be run only if requested using the include-tenant flag
 	if includeTenant {
-		if !TestSessionTemplatesCRUDOperationsUsingTenants() {
+		if !TestSessionTemplatesCRUDOperationsUsingTenants(ctx) {
 			passed = false
 		}
 		return
 	}
 	// session templates CRUD operations tests
-	if !TestSessionTemplatesCRUDOperations() {
+	if !TestSessionTemplatesCRUDOperations(ctx) {
 		passed = false
 	}
 
 	return
 }
 
-func sessionTemplatesTestsCLI(tenantList []string, includeTenant bool) (passed bool) {
+func sessionTemplatesTestsCLI(ctx context.Context, tenantList []string, includeTenant bool) (passed bool) {
 	passed = true
 
 	// session template template CLI tests
-	// Make sure that "sessiontemplatetemplate list" CLI command succeeds and returns a dictionary object.
+	// Make sure that "sessiontemplatetemplate list" CLI command succeeds and returns a session template.
 
 	// v2 sessiontemplatetemplate list
-	if !basicCLIListVerifyStringMapTest("v2", bosV2SessionTemplateTemplateCLI) {
+	if !cliListParseTest(ctx, parseSessionTemplateTemplateOutput, "v2", bosV2SessionTemplateTemplateCLI) {
 		passed = false
 	}
 
 	// sessiontemplatetemplate list
-	if !basicCLIListVerifyStringMapTest(bosDefaultSessionTemplateTemplateCLI) {
+	if !cliListParseTest(ctx, parseSessionTemplateTemplateOutput, bosDefaultSessionTemplateTemplateCLI) {
 		passed = false
 	}
 
 	// session template CLI tests
 
 	// v2 sessiontemplates
-	if !v2SessionTemplatesTestsCLICommand(tenantList, "v2", bosV2SessionTemplatesCLI) {
+	if !v2SessionTemplatesTestsCLICommand(ctx, tenantList, "v2", bosV2SessionTemplatesCLI) {
 		passed = false
 	}
 
 	// sessiontemplates
-	if !v2SessionTemplatesTestsCLICommand(tenantList, bosDefaultSessionTemplatesCLI) {
+	if !v2SessionTemplatesTestsCLICommand(ctx, tenantList, bosDefaultSessionTemplatesCLI) {
 		passed = false
 	}
 
 	// Tenant tests will be run only if requested using the include-tenant flag
 	if includeTenant {
 		// sessiontemplates CRUD operations tests
-		if !TestSessionTemplatesCRUDOperationsWithTenantUsingCLI() {
+		if !TestSessionTemplatesCRUDOperationsWithTenantUsingCLI(ctx) {
 			passed = false
 		}
 		return
 	}
 
 	// sessiontemplates CRUD operations tests
-	if !TestSessionTemplatesCRUDOperationsUsingCLI() {
+	if !TestSessionTemplatesCRUDOperationsUsingCLI(ctx) {
 		passed = false
 	}
 
@@ -127,10 +148,9 @@ func sessionTemplatesTestsCLI(tenantList []string, includeTenant bool) (passed b
 // The v2 API version of this function has had further improvements made in order to test good-path multitenancy
 // queries. Specifically, this function now does the following (all using API calls)
 // 1. API query to list all BOS v2 session templates (no tenant name specified)
-// 2. Parses the result, verifying that it is a JSON list, and verifying that each item in that list:
-//    a. Is a JSON dictionary
-//    b. Has a "name" field which maps to a non-0-length string
-//    c. Either has no "tenant" field or has a "tenant" field that maps to a (possibly 0-length) string
+// 2. Parses the result, verifying that it is a list of session templates, and verifying that each item in that list:
+//    a. Has a non-0-length name
+//    b. Either has no tenant or has a (possibly 0-length) tenant name
 // 3. If the list is empty, then pick a random tenant name (possibly not one which exists on the system)
 //    and issue a query for all BOS session templates belonging to that tenant. Verify that the resulting list is empty.
 // 4. If the list from #1 is not empty, but no session templates belong to a tenant, then do #3.
@@ -147,38 +167,38 @@ func sessionTemplatesTestsCLI(tenantList []string, includeTenant bool) (passed b
 // the BOS health check in the case that certain failures are seen. Eventually the test could be improved to automatically
 // retry the relevant checks a limited number of times, to reduce the likelihood of false failures.
 
-func v2SessionTemplatesTestsURI(params *common.Params, tenantList []string) bool {
+func v2SessionTemplatesTestsURI(ctx context.Context, client bosclient.Client, tenantList []string) bool {
 	var tenantedTemplateData, untenantedTemplateData v2TemplateData
 	var tenantedTemplateCount int
 	var err error
 	var templateDataList []v2TemplateData
 
 	// test #1, list templates
-	templateDataList, err = listV2TemplateDataApi(params, "")
+	templateDataList, err = listV2TemplateDataApi(ctx, client, "")
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 	if len(templateDataList) == 0 {
-		common.Infof("skipping test GET %s/{session_template_id} because result from previous test is []", bosV2SessionTemplatesUri)
+		common.Infof(ctx, "skipping test GET %s/{session_template_id} because result from previous test is []", endpointPath(ctx, "sessiontemplates"))
 
 		// However, we can still try to list all of the session templates with a tenant name specified.
 		// Since no session templates were found from the un-tenanted query, we expect none to be found once a tenant
 		// is specified
-		tenant := getAnyTenant(tenantList)
-		templateDataList, err = listV2TemplateDataApi(params, tenant)
+		tenant := getAnyTenant(ctx, tenantList)
+		templateDataList, err = listV2TemplateDataApi(ctx, client, tenant)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			return false
 		}
 
 		// Validate that this list is empty
 		if len(templateDataList) > 0 {
-			common.Errorf("Listing of all session templates empty, but found %d when listing session templates for tenant '%s'",
+			common.Errorf(ctx, "Listing of all session templates empty, but found %d when listing session templates for tenant '%s'",
 				len(templateDataList), tenant)
 			return false
 		}
-		common.Infof("Session template list is still empty, as expected")
+		common.Infof(ctx, "Session template list is still empty, as expected")
 		return true
 	}
 
@@ -187,14 +207,14 @@ func v2SessionTemplatesTestsURI(params *common.Params, tenantList []string) bool
 	// * One session template that belongs to a tenant (tenantedTemplateData), and a count (tenantedTemplateCount)
 	//   of how many session templates in total have that same tenant
 	for sessionTemplateIndex, TemplateData := range templateDataList {
-		common.Debugf("Parsing session #%d in the session list (%s)", sessionTemplateIndex, TemplateData.String())
+		common.Debugf(ctx, "Parsing session #%d in the session list (%s)", sessionTemplateIndex, TemplateData.String())
 		if len(TemplateData.Tenant) == 0 {
 			// This session template has no tenant
 			if untenantedTemplateData.IsNil() {
 				// This is the first session template we have encountered that has no tenant field,
 				// so take note of it
 				untenantedTemplateData = TemplateData
-				common.Infof("Found BOS v2 session template #%d (%s)", sessionTemplateIndex, untenantedTemplateData.String())
+				common.Infof(ctx, "Found BOS v2 session template #%d (%s)", sessionTemplateIndex, untenantedTemplateData.String())
 			}
 			continue
 		}
@@ -204,7 +224,7 @@ func v2SessionTemplatesTestsURI(params *common.Params, tenantList []string) bool
 			// and note that we have found 1 session template belonging to this tenant so far
 			tenantedTemplateData = TemplateData
 			tenantedTemplateCount = 1
-			common.Infof("Found BOS v2 session template #%d (%s)", sessionTemplateIndex, tenantedTemplateData.String())
+			common.Infof(ctx, "Found BOS v2 session template #%d (%s)", sessionTemplateIndex, tenantedTemplateData.String())
 			continue
 		}
 		// We have already found a session template belonging to a tenant. If it is the tenant we found first,
@@ -217,55 +237,55 @@ func v2SessionTemplatesTestsURI(params *common.Params, tenantList []string) bool
 	passed := true
 
 	if untenantedTemplateData.IsNil() {
-		common.Infof("skipping test GET %s/{session_template_id} with no tenant specified, because all BOS v2 session templates are owned by tenants",
-			bosV2SessionTemplatesUri)
+		common.Infof(ctx, "skipping test GET %s/{session_template_id} with no tenant specified, because all BOS v2 session templates are owned by tenants",
+			endpointPath(ctx, "sessiontemplates"))
 	} else {
 		// test: describe session template using the untenanted session template name we found earlier
-		_, err = getV2TemplateDataApi(params, untenantedTemplateData)
+		_, err = getV2TemplateDataApi(ctx, client, untenantedTemplateData)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			passed = false
 		}
 	}
 
 	if tenantedTemplateData.IsNil() {
-		common.Infof("No BOS v2 session templates found belonging to any tenants")
+		common.Infof(ctx, "No BOS v2 session templates found belonging to any tenants")
 
-		tenant := getAnyTenant(tenantList)
-		templateDataList, err = listV2TemplateDataApi(params, tenant)
+		tenant := getAnyTenant(ctx, tenantList)
+		templateDataList, err = listV2TemplateDataApi(ctx, client, tenant)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			passed = false
 		} else {
 			// Validate that this list is empty
 			if len(templateDataList) > 0 {
-				common.Errorf("Listing all session templates found none owned by tenants, but found %d when listing session templates for tenant '%s'",
+				common.Errorf(ctx, "Listing all session templates found none owned by tenants, but found %d when listing session templates for tenant '%s'",
 					len(templateDataList), tenant)
 				passed = false
 			}
-			common.Infof("List of session templates belonging to tenant '%s' is empty, as expected", tenant)
+			common.Infof(ctx, "List of session templates belonging to tenant '%s' is empty, as expected", tenant)
 		}
 		return passed
 	}
-	common.Infof("Counted %d BOS v2 session templates belonging to tenant '%s'", tenantedTemplateCount, tenantedTemplateData.Tenant)
-	templateDataList, err = listV2TemplateDataApi(params, tenantedTemplateData.Tenant)
+	common.Infof(ctx, "Counted %d BOS v2 session templates belonging to tenant '%s'", tenantedTemplateCount, tenantedTemplateData.Tenant)
+	templateDataList, err = listV2TemplateDataApi(ctx, client, tenantedTemplateData.Tenant)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		passed = false
 	} else {
 		// Validate that this list is expected length
 		if len(templateDataList) != tenantedTemplateCount {
-			common.Errorf("Listing all session templates found %d owned by tenant '%s', but found %d when listing session templates for that tenant",
+			common.Errorf(ctx, "Listing all session templates found %d owned by tenant '%s', but found %d when listing session templates for that tenant",
 				tenantedTemplateCount, tenantedTemplateData.Tenant, len(templateDataList))
 			passed = false
 		}
-		common.Infof("List of session templates belonging to tenant '%s' is the expected length", tenantedTemplateData.Tenant)
+		common.Infof(ctx, "List of session templates belonging to tenant '%s' is the expected length", tenantedTemplateData.Tenant)
 	}
 
 	// test: describe session template using the session template name we found owned by a tenant in the earlier loop
-	_, err = getV2TemplateDataApi(params, tenantedTemplateData)
+	_, err = getV2TemplateDataApi(ctx, client, tenantedTemplateData)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 
@@ -274,36 +294,36 @@ func v2SessionTemplatesTestsURI(params *common.Params, tenantList []string) bool
 
 // v2 session templates CLI tests
 // See comment earlier in the file for a description of this function
-func v2SessionTemplatesTestsCLICommand(tenantList []string, cmdArgs ...string) bool {
+func v2SessionTemplatesTestsCLICommand(ctx context.Context, tenantList []string, cmdArgs ...string) bool {
 	var tenantedTemplateData, untenantedTemplateData v2TemplateData
 	var tenantedTemplateCount int
 	var ok, passed bool
 	var templateDataList []v2TemplateData
 
 	// test #1, list templates with no tenant specified
-	templateDataList, ok = listTemplateDataCli("", cmdArgs...)
+	templateDataList, ok = listTemplateDataCli(ctx, "", cmdArgs...)
 	if !ok {
 		return false
 	}
 	if len(templateDataList) == 0 {
-		common.Infof("skipping test CLI %s describe {template_id} because result from previous test is []", strings.Join(cmdArgs, " "))
+		common.Infof(ctx, "skipping test CLI %s describe {template_id} because result from previous test is []", strings.Join(cmdArgs, " "))
 
 		// However, we can still try to list all of the session templates with a tenant name specified.
 		// Since no session templates were found from the un-tenanted query, we expect none to be found once a tenant
 		// is specified
-		tenant := getAnyTenant(tenantList)
-		templateDataList, ok = listTemplateDataCli(tenant, cmdArgs...)
+		tenant := getAnyTenant(ctx, tenantList)
+		templateDataList, ok = listTemplateDataCli(ctx, tenant, cmdArgs...)
 		if !ok {
 			return false
 		}
 
 		// Validate that this list is empty
 		if len(templateDataList) > 0 {
-			common.Errorf("Listing of all session templates empty, but found %d when listing session templates for tenant '%s'",
+			common.Errorf(ctx, "Listing of all session templates empty, but found %d when listing session templates for tenant '%s'",
 				len(templateDataList), tenant)
 			return false
 		}
-		common.Infof("Session template list is still empty, as expected")
+		common.Infof(ctx, "Session template list is still empty, as expected")
 		return true
 	}
 
@@ -312,14 +332,14 @@ func v2SessionTemplatesTestsCLICommand(tenantList []string, cmdArgs ...string) b
 	// * One session template that belongs to a tenant (tenantedTemplateData), and a count (tenantedTemplateCount)
 	//   of how many session templates in total have that same tenant
 	for sessionTemplateIndex, TemplateData := range templateDataList {
-		common.Debugf("Parsing session #%d in the session list (%s)", sessionTemplateIndex, TemplateData.String())
+		common.Debugf(ctx, "Parsing session #%d in the session list (%s)", sessionTemplateIndex, TemplateData.String())
 		if len(TemplateData.Tenant) == 0 {
 			// This session template has no tenant
 			if untenantedTemplateData.IsNil() {
 				// This is the first session template we have encountered that has no tenant field,
 				// so take note of it
 				untenantedTemplateData = TemplateData
-				common.Infof("Found BOS v2 session template #%d (%s)", sessionTemplateIndex, untenantedTemplateData.String())
+				common.Infof(ctx, "Found BOS v2 session template #%d (%s)", sessionTemplateIndex, untenantedTemplateData.String())
 			}
 			continue
 		}
@@ -329,7 +349,7 @@ func v2SessionTemplatesTestsCLICommand(tenantList []string, cmdArgs ...string) b
 			// and note that we have found 1 session template belonging to this tenant so far
 			tenantedTemplateData = TemplateData
 			tenantedTemplateCount = 1
-			common.Infof("Found BOS v2 session template #%d (%s)", sessionTemplateIndex, tenantedTemplateData.String())
+			common.Infof(ctx, "Found BOS v2 session template #%d (%s)", sessionTemplateIndex, tenantedTemplateData.String())
 			continue
 		}
 		// We have already found a session template belonging to a tenant. If it is the tenant we found first,
@@ -342,48 +362,48 @@ func v2SessionTemplatesTestsCLICommand(tenantList []string, cmdArgs ...string) b
 	passed = true
 
 	if untenantedTemplateData.IsNil() {
-		common.Infof("skipping test CLI %s describe {template_id} with no tenant specified, because all BOS v2 session templates are owned by tenants",
+		common.Infof(ctx, "skipping test CLI %s describe {template_id} with no tenant specified, because all BOS v2 session templates are owned by tenants",
 			strings.Join(cmdArgs, " "))
 	} else {
 		// test: describe session template using the untenanted session template name we found earlier
-		_, ok = describeV2TemplateDataCli(untenantedTemplateData, cmdArgs...)
+		_, ok = describeV2TemplateDataCli(ctx, untenantedTemplateData, cmdArgs...)
 		passed = passed && ok
 	}
 
 	if tenantedTemplateData.IsNil() {
-		common.Infof("No BOS v2 session templates found belonging to any tenants")
+		common.Infof(ctx, "No BOS v2 session templates found belonging to any tenants")
 
-		tenant := getAnyTenant(tenantList)
-		templateDataList, ok = listTemplateDataCli(tenant, cmdArgs...)
+		tenant := getAnyTenant(ctx, tenantList)
+		templateDataList, ok = listTemplateDataCli(ctx, tenant, cmdArgs...)
 		if !ok {
 			passed = false
 		} else {
 			// Validate that this list is empty
 			if len(templateDataList) > 0 {
-				common.Errorf("Listing all session templates found none owned by tenants, but found %d when listing session templates for tenant '%s'",
+				common.Errorf(ctx, "Listing all session templates found none owned by tenants, but found %d when listing session templates for tenant '%s'",
 					len(templateDataList), tenant)
 				passed = false
 			}
-			common.Infof("List of session templates belonging to tenant '%s' is empty, as expected", tenant)
+			common.Infof(ctx, "List of session templates belonging to tenant '%s' is empty, as expected", tenant)
 		}
 		return passed
 	}
-	common.Infof("Counted %d BOS v2 session templates belonging to tenant '%s'", tenantedTemplateCount, tenantedTemplateData.Tenant)
-	templateDataList, ok = listTemplateDataCli(tenantedTemplateData.Tenant, cmdArgs...)
+	common.Infof(ctx, "Counted %d BOS v2 session templates belonging to tenant '%s'", tenantedTemplateCount, tenantedTemplateData.Tenant)
+	templateDataList, ok = listTemplateDataCli(ctx, tenantedTemplateData.Tenant, cmdArgs...)
 	if !ok {
 		passed = false
 	} else {
 		// Validate that this list is expected length
 		if len(templateDataList) != tenantedTemplateCount {
-			common.Errorf("Listing all session templates found %d owned by tenant '%s', but found %d when listing session templates for that tenant",
+			common.Errorf(ctx, "Listing all session templates found %d owned by tenant '%s', but found %d when listing session templates for that tenant",
 				tenantedTemplateCount, tenantedTemplateData.Tenant, len(templateDataList))
 			passed = false
 		}
-		common.Infof("List of session templates belonging to tenant '%s' is the expected length", tenantedTemplateData.Tenant)
+		common.Infof(ctx, "List of session templates belonging to tenant '%s' is the expected length", tenantedTemplateData.Tenant)
 	}
 
 	// test: describe session template using the session template name we found owned by a tenant in the earlier loop
-	_, ok = describeV2TemplateDataCli(tenantedTemplateData, cmdArgs...)
+	_, ok = describeV2TemplateDataCli(ctx, tenantedTemplateData, cmdArgs...)
 	passed = passed && ok
 
 	return passed
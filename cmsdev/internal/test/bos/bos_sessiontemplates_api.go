@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,12 +28,16 @@
 package bos
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
+	"sort"
 	"strings"
 
-	resty "gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/apiclient"
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
 	pcu "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/prod-catalog-utils"
@@ -53,18 +57,35 @@ var archMap = map[string]string{
 	"ARM": "aarch64",
 }
 
-func GetLatestImageIdFromCsmProductCatalog(arch string) (string, error) {
-	latestCSMData, err := pcu.GetLatestProdCatEntry()
+// The keys of archMap, sorted so that the tests handle the architectures in the same order
+// every time (which a replayed recording relies on)
+func archKeys() []string {
+	keys := make([]string, 0, len(archMap))
+	for key := range archMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func GetLatestImageIdFromCsmProductCatalog(ctx context.Context, arch string) (string, error) {
+	latestCSMData, err := pcu.GetLatestProdCatEntry(ctx)
 	if err != nil {
 		return "", err
 	}
 
 	csmImages := latestCSMData.Images
 	if len(csmImages) != 0 {
-		common.Infof("CSM images: %v", csmImages)
+		common.Infof(ctx, "CSM images: %v", csmImages)
+		keys := make([]string, 0, len(csmImages))
 		for key := range csmImages {
+			keys = append(keys, key)
+		}
+		// Sorted, so that the same image is found every time
+		sort.Strings(keys)
+		for _, key := range keys {
 			if strings.Contains(key, archMap[arch]) && csmImages[key].ID != "" {
-				common.Debugf("Found image ID for architecture %s: %s", archMap[arch], csmImages[key])
+				common.Debugf(ctx, "Found image ID for architecture %s: %s", archMap[arch], csmImages[key])
 				return csmImages[key].ID, nil
 			}
 		}
@@ -72,23 +93,23 @@ func GetLatestImageIdFromCsmProductCatalog(arch string) (string, error) {
 	return "", fmt.Errorf("no image found in the latest CSM data")
 }
 
-func GetImageRecord(imageID string) (imagerecord ImsImage, ok bool) {
-	params := test.GetAccessTokenParams()
+func GetImageRecord(ctx context.Context, imageID string) (imagerecord ImsImage, ok bool) {
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
+		common.Error(ctx, fmt.Errorf("Unable to get access token params"))
 		return ImsImage{}, false
 	}
 
-	url := common.BASEURL + endpoints["ims"]["images"].Url + endpoints["ims"]["images"].Uri + "/" + imageID
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	url := serviceEndpoint(ctx, "ims", "images").URL("") + "/" + imageID
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return ImsImage{}, false
 	}
 
 	// Unmarshal the response into the ImsImage struct
 	if err := json.Unmarshal(resp.Body(), &imagerecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return ImsImage{}, false
 	}
 
@@ -96,10 +117,11 @@ func GetImageRecord(imageID string) (imagerecord ImsImage, ok bool) {
 	return
 }
 
-func GetCreateBOSSessionTemplatePayload(cfsConfigName string, enableCFS bool, arch string, imageId string) (bosSessionTemplatePayload string, ok bool) {
-	imageRecord, ok := GetImageRecord(imageId)
+// Builds a session template with a single compute boot set, which boots the specified image
+func BuildBOSSessionTemplate(ctx context.Context, cfsConfigName string, enableCFS bool, arch string, imageId string) (template bosclient.SessionTemplate, ok bool) {
+	imageRecord, ok := GetImageRecord(ctx, imageId)
 	if !ok {
-		return "", false
+		return
 	}
 	kernelParameters :=
 		"console=ttyS0,115200 bad_page=panic crashkernel=512M hugepagelist=2m-2g " +
@@ -110,247 +132,249 @@ func GetCreateBOSSessionTemplatePayload(cfsConfigName string, enableCFS bool, ar
 			fmt.Sprintf("root=live:s3://boot-images/%s/rootfs ", imageRecord.ImageID) +
 			fmt.Sprintf("nmd_data=url=s3://boot-images/%s/rootfs,etag=%s", imageRecord.ImageID, imageRecord.Link.S3_Etag)
 
-	computeSet := map[string]interface{}{
-		"etag":              imageRecord.Link.S3_Etag,
-		"kernel_parameters": kernelParameters,
-		"node_roles_groups": []string{"Compute"},
-		"path":              imageRecord.Link.S3_Path,
-		"type":              "s3",
-		"arch":              arch,
-	}
-
-	cfsConfig := map[string]string{
-		"configuration": cfsConfigName,
-	}
-
-	bosParams := map[string]interface{}{
-		"cfs":        cfsConfig,
-		"enable_cfs": enableCFS,
-		"boot_sets": map[string]interface{}{
-			"compute": computeSet,
+	template = bosclient.SessionTemplate{
+		EnableCFS: &enableCFS,
+		CFS:       &bosclient.CFSParameters{Configuration: cfsConfigName},
+		BootSets: map[string]bosclient.BootSet{
+			"compute": {
+				Etag:             imageRecord.Link.S3_Etag,
+				KernelParameters: kernelParameters,
+				NodeRolesGroups:  []string{"Compute"},
+				Path:             imageRecord.Link.S3_Path,
+				Type:             "s3",
+				Arch:             arch,
+			},
 		},
 	}
+	return template, true
+}
 
-	jsonPayload, err := json.Marshal(bosParams)
-	if err != nil {
-		common.Error(err)
-		return "", false
+// Returns the architecture of the first boot set (by name) of a session template
+func templateArch(template bosclient.SessionTemplate) string {
+	for _, bootSetName := range template.BootSetNames() {
+		return template.BootSets[bootSetName].Arch
 	}
-
-	return string(jsonPayload), true
+	return ""
 }
 
-func CreateUpdateBOSSessiontemplatesAPI(bosSessionTemplatePayload string, sessionTemplateName string, method string) (bosSessionTemplate BOSSessionTemplate, ok bool) {
-	params := test.GetAccessTokenParams()
+// Returns a BOS client which makes its requests as the current tenant (if any)
+func tenantClient(ctx context.Context) (client bosclient.Client, ok bool) {
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return BOSSessionTemplate{}, false
+		common.Errorf(ctx, "Unable to get access token params")
+		return
 	}
+	return bosclient.NewClient(*params).WithTenant(common.GetTenantName(ctx)), true
+}
 
-	// Create the BOS session template payload
-	if method == "PUT" {
-		// Create session template payload
-		params.JsonStr = bosSessionTemplatePayload
+// Checks the error (if any) from a request made as the current tenant. Requests made as the dummy
+// tenant are expected to be rejected with status 400, and all others to succeed. Returns true if
+// the result was as expected. Otherwise, logs an appropriate error and returns false.
+func checkTenantResult(ctx context.Context, err error) bool {
+	tenantName := common.GetTenantName(ctx)
+	if !common.IsDummyTenant(tenantName) {
+		return checkNoError(ctx, err)
+	}
+	if apiclient.IsStatus(err, http.StatusBadRequest) {
+		common.Infof(ctx, "Request as dummy tenant '%s' was rejected, as expected", tenantName)
+		return true
+	} else if err == nil {
+		common.Errorf(ctx, "Request as dummy tenant '%s' succeeded, but it should have been rejected", tenantName)
 	} else {
-		// Update session template payload
-		params.JsonStrArray = []byte(bosSessionTemplatePayload)
+		common.Error(ctx, err)
 	}
+	return false
+}
 
-	url := common.BASEURL + endpoints["bos"]["sessiontemplates"].Url +
-		"/" + endpoints["bos"]["sessiontemplates"].Version +
-		endpoints["bos"]["sessiontemplates"].Uri + "/" + sessionTemplateName
-
-	resp, err := VerifyRestStatusWithTenant(method, url, *params, http.StatusOK)
-	if err != nil {
-		common.Error(err)
-		return BOSSessionTemplate{}, false
+// Creates the named session template, registering it for cleanup. Returns the template as BOS
+// stored it (or an empty template, if it was created as the dummy tenant and rejected, as expected)
+func CreateBOSSessionTemplateAPI(ctx context.Context, template bosclient.SessionTemplate, sessionTemplateName string) (sessionTemplate bosclient.SessionTemplate, ok bool) {
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
 	}
-
-	// Decode the response body into the BOSSessionTemplate struct
-	if err := json.Unmarshal(resp.Body(), &bosSessionTemplate); err != nil {
-		common.Error(err)
-		return BOSSessionTemplate{}, false
+	sessionTemplate, err := client.PutSessionTemplate(ctx, sessionTemplateName, template)
+	if !checkTenantResult(ctx, err) {
+		return bosclient.SessionTemplate{}, false
 	}
-
-	ok = true
-	return
+	if err == nil {
+		registerBOSSessionTemplateCleanup(ctx, sessionTemplateName)
+	}
+	return sessionTemplate, true
 }
 
-func DeleteBOSSessionTemplatesAPI(sessionTemplateName string) (ok bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return false
+// Updates the named session template with the fields which are set in template
+func UpdateBOSSessionTemplateAPI(ctx context.Context, template bosclient.SessionTemplate, sessionTemplateName string) (sessionTemplate bosclient.SessionTemplate, ok bool) {
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
 	}
-
-	url := common.BASEURL + endpoints["bos"]["sessiontemplates"].Url +
-		"/" + endpoints["bos"]["sessiontemplates"].Version +
-		endpoints["bos"]["sessiontemplates"].Uri + "/" + sessionTemplateName
-	_, err := VerifyRestStatusWithTenant("DELETE", url, *params, http.StatusNoContent)
-	if err != nil {
-		common.Error(err)
-		return false
+	sessionTemplate, err := client.PatchSessionTemplate(ctx, sessionTemplateName, template)
+	if !checkTenantResult(ctx, err) {
+		return bosclient.SessionTemplate{}, false
 	}
-
-	ok = true
-	return
+	return sessionTemplate, true
 }
 
-func ValidateBOSSessionTemplateAPI(templateName string) (ok bool) {
-	common.Infof("Validating BOS sessiontemplate '%s'", templateName)
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Errorf("Unable to get access token params")
-		return false
+func DeleteBOSSessionTemplatesAPI(ctx context.Context, sessionTemplateName string) (ok bool) {
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
 	}
-
-	url := common.BASEURL + endpoints["bos"]["sessiontemplatesvalid"].Url +
-		"/" + endpoints["bos"]["sessiontemplatesvalid"].Version +
-		endpoints["bos"]["sessiontemplatesvalid"].Uri + "/" + templateName
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, http.StatusOK)
-	if err != nil {
-		common.Error(err)
+	err := client.DeleteSessionTemplate(ctx, sessionTemplateName)
+	if !checkTenantResult(ctx, err) {
 		return false
 	}
-
-	if strings.Contains(string(resp.Body()), "Valid") {
-		common.Infof("Session template %s is valid", templateName)
-		ok = true
-	} else {
-		common.Errorf("Session template %s is not valid", templateName)
-		ok = false
+	if err == nil {
+		common.ResourceDeleted(ctx, bosSessionTemplateKind, sessionTemplateName)
 	}
-
-	return
-
+	return true
 }
 
-func GetBOSSessionTemplatesAPI(templateName string, httpStatus int) (bosSessionTemplate BOSSessionTemplate, ok bool) {
-	common.Infof("Getting BOS sessiontemplate '%s'", templateName)
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Errorf("Unable to get access token params")
-		return BOSSessionTemplate{}, false
+func ValidateBOSSessionTemplateAPI(ctx context.Context, templateName string) (ok bool) {
+	common.Infof(ctx, "Validating BOS sessiontemplate '%s'", templateName)
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
 	}
-
-	url := common.BASEURL + endpoints["bos"]["sessiontemplates"].Url +
-		"/" + endpoints["bos"]["sessiontemplates"].Version +
-		endpoints["bos"]["sessiontemplates"].Uri + "/" + templateName
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, httpStatus)
-	if err != nil {
-		common.Error(err)
-		return BOSSessionTemplate{}, false
+	message, err := client.ValidateSessionTemplate(ctx, templateName)
+	if !checkTenantResult(ctx, err) {
+		return false
+	} else if err != nil {
+		return true
 	}
 
-	// Decode the response body into the BOSSessionTemplate struct
-	if err := json.Unmarshal(resp.Body(), &bosSessionTemplate); err != nil {
-		common.Error(err)
-		return BOSSessionTemplate{}, false
+	if strings.Contains(message, "Valid") {
+		common.Infof(ctx, "Session template %s is valid", templateName)
+		return true
 	}
-
-	ok = true
-	return
+	common.Errorf(ctx, "Session template %s is not valid: %s", templateName, message)
+	return false
 }
 
-func GetAllBOSSessionTemplatesAPI() (bosSessionTemplates []BOSSessionTemplate, ok bool) {
-	common.Infof("Getting all BOS sessiontemplates")
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Errorf("Unable to get access token params")
-		return []BOSSessionTemplate{}, false
+func GetBOSSessionTemplatesAPI(ctx context.Context, templateName string) (sessionTemplate bosclient.SessionTemplate, ok bool) {
+	common.Infof(ctx, "Getting BOS sessiontemplate '%s'", templateName)
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
 	}
-
-	url := common.BASEURL + endpoints["bos"]["sessiontemplates"].Url +
-		"/" + endpoints["bos"]["sessiontemplates"].Version +
-		endpoints["bos"]["sessiontemplates"].Uri
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, http.StatusOK)
-	if err != nil {
-		common.Error(err)
-		return []BOSSessionTemplate{}, false
+	sessionTemplate, err := client.GetSessionTemplate(ctx, templateName)
+	if !checkTenantResult(ctx, err) {
+		return bosclient.SessionTemplate{}, false
 	}
+	return sessionTemplate, true
+}
 
-	// Decode the response body into the BOSSessionTemplate struct
-	if err := json.Unmarshal(resp.Body(), &bosSessionTemplates); err != nil {
-		common.Error(err)
-		return []BOSSessionTemplate{}, false
+// Verifies that getting the specified session template fails with status 404
+func BOSSessionTemplateDeletedAPI(ctx context.Context, templateName string) bool {
+	common.Infof(ctx, "Verifying that BOS sessiontemplate '%s' does not exist", templateName)
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return false
 	}
+	_, err := client.GetSessionTemplate(ctx, templateName)
+	if apiclient.IsNotFound(err) {
+		common.Infof(ctx, "BOS sessiontemplate '%s' was not found, as expected", templateName)
+		return true
+	}
+	checkNoError(ctx, err)
+	return false
+}
 
-	ok = true
-	return
+func GetAllBOSSessionTemplatesAPI(ctx context.Context) (sessionTemplates []bosclient.SessionTemplate, ok bool) {
+	common.Infof(ctx, "Getting all BOS sessiontemplates")
+	client, ok := tenantClient(ctx)
+	if !ok {
+		return
+	}
+	sessionTemplates, err := client.ListSessionTemplates(ctx)
+	if !checkTenantResult(ctx, err) {
+		return nil, false
+	}
+	return sessionTemplates, true
 }
 
-func BOSSessionTemplateExists(sessionTemplateName string, templateList []BOSSessionTemplate) (ok bool) {
+func BOSSessionTemplateExists(ctx context.Context, sessionTemplateName string, templateList []bosclient.SessionTemplate) (ok bool) {
 	for _, template := range templateList {
 		if template.Name == sessionTemplateName {
 			return true
 		}
 	}
-	common.Infof("Sessiontemplate %s was not found in the list of sessiontemplates", sessionTemplateName)
+	common.Infof(ctx, "Sessiontemplate %s was not found in the list of sessiontemplates", sessionTemplateName)
 	return false
 }
 
-func VerifyBOSSessionTemplate(sessionTemplate BOSSessionTemplate, sessionTemplatePayload string, templateName string) (ok bool) {
-	// Unmarshal the session template payload into a BOSSessionTemplate struct
-	var expectedSessionTemplate BOSSessionTemplate
-	if err := json.Unmarshal([]byte(sessionTemplatePayload), &expectedSessionTemplate); err != nil {
-		common.Errorf("Error unmarshaling session template payload: %v", err)
-		return false
-	}
-
+// Verifies that a session template has the expected name and the values it was created or
+// updated with: whether CFS is enabled, the CFS configuration, and every expected boot set
+func VerifyBOSSessionTemplate(ctx context.Context, sessionTemplate, expectedSessionTemplate bosclient.SessionTemplate, templateName string) (ok bool) {
 	if sessionTemplate.Name != templateName {
-		common.Errorf("Session template name does not match. Expected: %s, Got: %s", templateName, sessionTemplate.Name)
+		common.Errorf(ctx, "Session template name does not match. Expected: %s, Got: %s", templateName, sessionTemplate.Name)
 		return false
 	}
 
-	if sessionTemplate.Enable_cfs != expectedSessionTemplate.Enable_cfs {
-		common.Errorf("Enable CFS does not match. Expected: %v, Got: %v", expectedSessionTemplate.Enable_cfs, sessionTemplate.Enable_cfs)
+	if sessionTemplate.CFSEnabled() != expectedSessionTemplate.CFSEnabled() {
+		common.Errorf(ctx, "Enable CFS does not match. Expected: %v, Got: %v", expectedSessionTemplate.CFSEnabled(), sessionTemplate.CFSEnabled())
 		return false
 	}
 
-	if sessionTemplate.Boot_sets.Compute.Kernel_parameters != expectedSessionTemplate.Boot_sets.Compute.Kernel_parameters {
-		common.Errorf("Kernel parameters do not match. Expected: %s, Got: %s", expectedSessionTemplate.Boot_sets.Compute.Kernel_parameters, sessionTemplate.Boot_sets.Compute.Kernel_parameters)
-		return false
+	if expectedSessionTemplate.CFS != nil {
+		var configuration string
+		if sessionTemplate.CFS != nil {
+			configuration = sessionTemplate.CFS.Configuration
+		}
+		if configuration != expectedSessionTemplate.CFS.Configuration {
+			common.Errorf(ctx, "CFS configuration does not match. Expected: %s, Got: %s", expectedSessionTemplate.CFS.Configuration, configuration)
+			return false
+		}
 	}
 
-	if sessionTemplate.Boot_sets.Compute.Etag != expectedSessionTemplate.Boot_sets.Compute.Etag {
-		common.Errorf("Etag does not match. Expected: %s, Got: %s", expectedSessionTemplate.Boot_sets.Compute.Etag, sessionTemplate.Boot_sets.Compute.Etag)
-		return false
+	for _, bootSetName := range expectedSessionTemplate.BootSetNames() {
+		expected := expectedSessionTemplate.BootSets[bootSetName]
+		bootSet, found := sessionTemplate.BootSets[bootSetName]
+		if !found {
+			common.Errorf(ctx, "Session template has no boot set '%s'", bootSetName)
+			return false
+		}
+		if !verifyBootSet(ctx, bootSetName, bootSet, expected) {
+			return false
+		}
 	}
 
-	if sessionTemplate.Boot_sets.Compute.Path != expectedSessionTemplate.Boot_sets.Compute.Path {
-		common.Errorf("Path does not match. Expected: %s, Got: %s", expectedSessionTemplate.Boot_sets.Compute.Path, sessionTemplate.Boot_sets.Compute.Path)
-		return false
-	}
+	return true
+}
 
-	if sessionTemplate.Boot_sets.Compute.Type != expectedSessionTemplate.Boot_sets.Compute.Type {
-		common.Errorf("Type does not match. Expected: %s, Got: %s", expectedSessionTemplate.Boot_sets.Compute.Type, sessionTemplate.Boot_sets.Compute.Type)
-		return false
+// Verifies that a boot set has the expected values
+func verifyBootSet(ctx context.Context, bootSetName string, bootSet, expected bosclient.BootSet) bool {
+	fields := []struct {
+		name             string
+		actual, expected string
+	}{
+		{"kernel parameters", bootSet.KernelParameters, expected.KernelParameters},
+		{"etag", bootSet.Etag, expected.Etag},
+		{"path", bootSet.Path, expected.Path},
+		{"type", bootSet.Type, expected.Type},
+		{"arch", bootSet.Arch, expected.Arch},
+	}
+	for _, field := range fields {
+		if field.actual != field.expected {
+			common.Errorf(ctx, "Boot set '%s' %s does not match. Expected: %s, Got: %s", bootSetName, field.name, field.expected, field.actual)
+			return false
+		}
 	}
 
-	if sessionTemplate.Boot_sets.Compute.Node_roles_groups[0] != expectedSessionTemplate.Boot_sets.Compute.Node_roles_groups[0] {
-		common.Errorf("Node roles groups do not match. Expected: %s, Got: %s", expectedSessionTemplate.Boot_sets.Compute.Node_roles_groups[0], sessionTemplate.Boot_sets.Compute.Node_roles_groups[0])
+	actualGroups := append([]string{}, bootSet.NodeRolesGroups...)
+	expectedGroups := append([]string{}, expected.NodeRolesGroups...)
+	sort.Strings(actualGroups)
+	sort.Strings(expectedGroups)
+	if !reflect.DeepEqual(actualGroups, expectedGroups) {
+		common.Errorf(ctx, "Boot set '%s' node roles groups do not match. Expected: %v, Got: %v", bootSetName, expectedGroups, actualGroups)
 		return false
 	}
-
 	return true
 }
 
-func VerifyRestStatusWithTenant(method, uri string, params common.Params, expectedStatus int) (*resty.Response, error) {
-	tenantName := common.GetTenantName()
-	// Checking if Tenant name is set or not
-	if len(tenantName) == 0 {
-		return test.RestfulVerifyStatus(method, uri, params, expectedStatus)
-	} else {
-		if common.IsDummyTenant(tenantName) {
-			// If the tenant name is a dummy tenant, we can skip the verification
-			return test.TenantRestfulVerifyStatus(method, uri, tenantName, params, http.StatusBadRequest)
-		}
-		return test.TenantRestfulVerifyStatus(method, uri, tenantName, params, expectedStatus)
-	}
-}
-
-func GetExpectedHTTPStatusCode() int {
-	tenantName := common.GetTenantName()
+func GetExpectedHTTPStatusCode(ctx context.Context) int {
+	tenantName := common.GetTenantName(ctx)
 	if common.IsDummyTenant(tenantName) {
 		// If the tenant name is a dummy tenant, the expected response is StatusBadRequest
 		return http.StatusBadRequest
@@ -358,19 +382,19 @@ func GetExpectedHTTPStatusCode() int {
 	return http.StatusOK
 }
 
-func GetTenantFromList() string {
-	tenantList, err := k8s.GetTenants()
+func GetTenantFromList(ctx context.Context) string {
+	tenantList, err := k8s.GetTenants(ctx)
 	if err != nil {
-		common.Errorf("Error getting tenant list: %s", err.Error())
+		common.Errorf(ctx, "Error getting tenant list: %s", err.Error())
 		return ""
 	}
 
 	// Set the tenant name to be used in the tests
 	tenantName, err := common.GetRandomStringFromList(tenantList)
 	if err != nil {
-		common.Debugf("Unable to get random tenant from list: %s", err.Error())
+		common.Debugf(ctx, "Unable to get random tenant from list: %s", err.Error())
 		return ""
 	}
-	common.Infof("Using tenant: %s", tenantName)
+	common.Infof(ctx, "Using tenant: %s", tenantName)
 	return tenantName
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,11 +29,13 @@
 package bos
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
@@ -43,111 +45,113 @@ var bosCliVersions = map[string]string{
 	"v2":      "v2",
 }
 
-func RunVersionedBOSCommand(cliVersion string, cmdArgs ...string) []byte {
+func RunVersionedBOSCommand(ctx context.Context, cliVersion string, cmdArgs ...string) []byte {
 	if cliVersion == "" {
-		return runTenantBosCLI(common.GetTenantName(), cmdArgs...)
+		return runTenantBosCLI(ctx, common.GetTenantName(ctx), cmdArgs...)
 	}
 	newArgs := append([]string{cliVersion}, cmdArgs...)
-	return runTenantBosCLI(common.GetTenantName(), newArgs...)
+	return runTenantBosCLI(ctx, common.GetTenantName(ctx), newArgs...)
 }
 
-func GetCreateBOSSessionTemplatePayloadCLI(cfsConfigName string, enableCFS bool, arch string, imageId string) (fileName string, payload string, ok bool) {
+// Builds a session template (see BuildBOSSessionTemplate) and writes it to a file, for use with
+// the CLI. Returns the file name and the template.
+func GetCreateBOSSessionTemplatePayloadCLI(ctx context.Context, cfsConfigName string, enableCFS bool, arch string, imageId string) (fileName string, template bosclient.SessionTemplate, ok bool) {
 	fileName = "bos_sessiontemplate_create_payload"
-	payload, success := GetCreateBOSSessionTemplatePayload(cfsConfigName, enableCFS, arch, imageId)
+	template, success := BuildBOSSessionTemplate(ctx, cfsConfigName, enableCFS, arch, imageId)
 	if !success {
-		return "", "", false
+		return "", bosclient.SessionTemplate{}, false
+	}
+	payload, err := json.Marshal(template)
+	if err != nil {
+		common.Error(ctx, err)
+		return "", bosclient.SessionTemplate{}, false
 	}
 
 	dir, err := os.Getwd()
 	if err != nil {
-		common.Errorf("Error getting current directory: %v\n", err)
+		common.Errorf(ctx, "Error getting current directory: %v\n", err)
 		return
 	}
 	fileName = fmt.Sprintf("%s/%s_%s.json", dir, fileName, arch)
 
 	// Write the formatted JSON payload to the file
-	err = os.WriteFile(fileName, []byte(payload), 0644)
+	err = os.WriteFile(fileName, payload, 0644)
 	if err != nil {
-		common.Errorf("Unable to write payload to file %s: %v", fileName, err)
-		return "", "", false
+		common.Errorf(ctx, "Unable to write payload to file %s: %v", fileName, err)
+		return "", bosclient.SessionTemplate{}, false
 	}
 
-	return fileName, payload, true
+	return fileName, template, true
 }
 
-func UpdateBOSSessionTemplatesCLI(templateName, filename, cfgName, cliVersion string) (sessionTemplateRecord BOSSessionTemplate, passed bool) {
-	common.Infof("Updating session template %s in BOS via CLI", templateName)
-	if cmdOut := RunVersionedBOSCommand(cliVersion, "sessiontemplates", "update", templateName,
-		"--file", filename); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &sessionTemplateRecord); err == nil {
+// Runs a BOS CLI command and parses its output as a session template
+func runBOSSessionTemplateCLI(ctx context.Context, cliVersion string, cmdArgs ...string) (sessionTemplateRecord bosclient.SessionTemplate, passed bool) {
+	if cmdOut := RunVersionedBOSCommand(ctx, cliVersion, cmdArgs...); cmdOut != nil {
+		var err error
+		if sessionTemplateRecord, err = bosclient.ParseSessionTemplate(cmdOut); err == nil {
 			passed = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func CreateBOSSessionTemplatesCLI(templateName, filename, cfgName, cliVersion string) (sessionTemplateRecord BOSSessionTemplate, passed bool) {
-	common.Infof("Creating session template %s in BOS via CLI", templateName)
-	if cmdOut := RunVersionedBOSCommand(cliVersion, "sessiontemplates", "create", templateName,
-		"--file", filename); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &sessionTemplateRecord); err == nil {
-			passed = true
-		} else {
-			common.Error(err)
-		}
+func UpdateBOSSessionTemplatesCLI(ctx context.Context, templateName, filename, cfgName, cliVersion string) (sessionTemplateRecord bosclient.SessionTemplate, passed bool) {
+	common.Infof(ctx, "Updating session template %s in BOS via CLI", templateName)
+	return runBOSSessionTemplateCLI(ctx, cliVersion, "sessiontemplates", "update", templateName, "--file", filename)
+}
+
+func CreateBOSSessionTemplatesCLI(ctx context.Context, templateName, filename, cfgName, cliVersion string) (sessionTemplateRecord bosclient.SessionTemplate, passed bool) {
+	common.Infof(ctx, "Creating session template %s in BOS via CLI", templateName)
+	sessionTemplateRecord, passed = runBOSSessionTemplateCLI(ctx, cliVersion, "sessiontemplates", "create", templateName, "--file", filename)
+	if passed {
+		registerBOSSessionTemplateCleanup(ctx, templateName)
 	}
 	// if the tenant is a dummy tenant, we expect the command to fail
-	if common.IsDummyTenant(common.GetTenantName()) {
-		return BOSSessionTemplate{}, true
+	if common.IsDummyTenant(common.GetTenantName(ctx)) {
+		return bosclient.SessionTemplate{}, true
 	}
 	return
 }
 
-func GetBOSSessiontemplatesCLI(templateName, cliVersion string) (sessionTemplateRecord BOSSessionTemplate, passed bool) {
-	common.Infof("Getting BOS session template %s via CLI", templateName)
-	if cmdOut := RunVersionedBOSCommand(cliVersion, "sessiontemplates", "describe", templateName); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &sessionTemplateRecord); err == nil {
-			passed = true
-		} else {
-			common.Error(err)
-		}
-	}
-	return
+func GetBOSSessiontemplatesCLI(ctx context.Context, templateName, cliVersion string) (sessionTemplateRecord bosclient.SessionTemplate, passed bool) {
+	common.Infof(ctx, "Getting BOS session template %s via CLI", templateName)
+	return runBOSSessionTemplateCLI(ctx, cliVersion, "sessiontemplates", "describe", templateName)
 }
 
-func GetBOSSessiontemplatesListCLI(cliVersion string) (sessionTemplateRecords []BOSSessionTemplate, passed bool) {
-	common.Infof("Getting all BOS session templates via CLI")
-	if cmdOut := RunVersionedBOSCommand(cliVersion, "sessiontemplates", "list"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &sessionTemplateRecords); err == nil {
+func GetBOSSessiontemplatesListCLI(ctx context.Context, cliVersion string) (sessionTemplateRecords []bosclient.SessionTemplate, passed bool) {
+	common.Infof(ctx, "Getting all BOS session templates via CLI")
+	if cmdOut := RunVersionedBOSCommand(ctx, cliVersion, "sessiontemplates", "list"); cmdOut != nil {
+		var err error
+		if sessionTemplateRecords, err = bosclient.ParseSessionTemplates(cmdOut); err == nil {
 			passed = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func DeleteBOSSessionTemplatesCLI(templateName, cliVersion string) (passed bool) {
-	common.Infof("Deleting session template %s in BOS via CLI", templateName)
-	return RunVersionedBOSCommand(cliVersion, "sessiontemplates", "delete", templateName) != nil
+func DeleteBOSSessionTemplatesCLI(ctx context.Context, templateName, cliVersion string) (passed bool) {
+	common.Infof(ctx, "Deleting session template %s in BOS via CLI", templateName)
+	if RunVersionedBOSCommand(ctx, cliVersion, "sessiontemplates", "delete", templateName) == nil {
+		return false
+	}
+	common.ResourceDeleted(ctx, bosSessionTemplateKind, templateName)
+	return true
 }
 
-func ValidateBOSSessionTemplateCLI(templateName, cliVersion string) (passed bool) {
-	common.Infof("Validating BOS session template %s via CLI", templateName)
-	if cmdOut := RunVersionedBOSCommand(cliVersion, "sessiontemplatesvalid", "describe", templateName); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+func ValidateBOSSessionTemplateCLI(ctx context.Context, templateName, cliVersion string) (passed bool) {
+	common.Infof(ctx, "Validating BOS session template %s via CLI", templateName)
+	if cmdOut := RunVersionedBOSCommand(ctx, cliVersion, "sessiontemplatesvalid", "describe", templateName); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
 		output := string(cmdOut)
 		if strings.Contains(output, "Valid") {
-			common.Infof("Session template %s is valid", templateName)
+			common.Infof(ctx, "Session template %s is valid", templateName)
 			passed = true
 		} else {
-			common.Errorf("Session template %s is not valid", templateName)
+			common.Errorf(ctx, "Session template %s is not valid", templateName)
 			passed = false
 		}
 	}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,74 +29,78 @@
 package bos
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func TestSessionTemplatesCRUDOperationsWithTenantUsingCLI() (passed bool) {
-	passed = TestSessionTemplatesCRUDOperationsUsingCLI()
+func TestSessionTemplatesCRUDOperationsWithTenantUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionTemplatesCRUDOperationsWithTenantUsingCLI").End(ctx, &passed)
+	passed = TestSessionTemplatesCRUDOperationsUsingCLI(ctx)
 	tenantList := []string{}
 	dummyTenantName := common.GetDummyTenantName()
 	tenantList = append(tenantList, dummyTenantName)
 	// Running the tests with tenants
-	tenantName := GetTenantFromList()
+	tenantName := GetTenantFromList(ctx)
 	if len(tenantName) != 0 {
 		tenantList = append(tenantList, tenantName)
 	}
 
 	for _, tenant := range tenantList {
 		// Set the tenant name for the tests
-		common.SetTenantName(tenant)
-		passed = passed && TestSessionTemplatesCRUDOperationsUsingCLI()
+		common.SetTenantName(ctx, tenant)
+		passed = passed && TestSessionTemplatesCRUDOperationsUsingCLI(ctx)
 		// Unsetting the tenant name after tests
-		common.SetTenantName("")
+		common.SetTenantName(ctx, "")
 	}
 	return passed
 }
 
-func TestSessionTemplatesCRUDOperationsUsingCLI() (passed bool) {
+func TestSessionTemplatesCRUDOperationsUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestSessionTemplatesCRUDOperationsUsingCLI").End(ctx, &passed)
 	passed = true
 	var testRan bool
-	if len(common.GetTenantName()) != 0 {
-		common.PrintLog(fmt.Sprintf("Running BOS session template tests with tenant: %s", common.GetTenantName()))
+	if len(common.GetTenantName(ctx)) != 0 {
+		common.PrintLog(ctx, fmt.Sprintf("Running BOS session template tests with tenant: %s", common.GetTenantName(ctx)))
 	} else {
-		common.PrintLog("Running BOS session template tests without Tenant")
+		common.PrintLog(ctx, "Running BOS session template tests without Tenant")
 	}
 
 	// Range over archMap to create session templates with different architectures
-	for arch := range archMap {
-		imageId, err := GetLatestImageIdFromCsmProductCatalog(arch)
+	for _, arch := range archKeys() {
+		imageId, err := GetLatestImageIdFromCsmProductCatalog(ctx, arch)
 		if err != nil {
-			common.Infof("Unable to get latest image id for architecture %s", archMap[arch])
-			common.Warnf("Skipping BOS session template CLI tests for architecture %s", archMap[arch])
+			common.Infof(ctx, "Unable to get latest image id for architecture %s", archMap[arch])
+			common.Warnf(ctx, "Skipping BOS session template CLI tests for architecture %s", archMap[arch])
 			continue
 		}
 		testRan = true
 		for cliVersion := range bosCliVersions {
-			common.PrintLog(fmt.Sprintf("Running BOS session template CLI tests with version %s", cliVersion))
-			sessionTemplateRecord, ok := TestCLISessionTemplatesCreate(arch, imageId, bosCliVersions[cliVersion])
+			common.PrintLog(ctx, fmt.Sprintf("Running BOS session template CLI tests with version %s", cliVersion))
+			sessionTemplateRecord, ok := TestCLISessionTemplatesCreate(ctx, arch, imageId, bosCliVersions[cliVersion])
 			if !ok {
-				common.Errorf("Session template creation failed for imageId %s and arch %s", imageId, archMap[arch])
-				common.Warnf("Skipping rest of the BOS session template CLI tests for imageId %s and architecture %s", imageId, archMap[arch])
+				common.Errorf(ctx, "Session template creation failed for imageId %s and arch %s", imageId, archMap[arch])
+				common.Warnf(ctx, "Skipping rest of the BOS session template CLI tests for imageId %s and architecture %s", imageId, archMap[arch])
 				passed = false
 				continue
 			}
 
 			if len(sessionTemplateRecord.Name) != 0 {
-				common.Infof("Session template created successfully with name %s", sessionTemplateRecord.Name)
-				passed = TestCLISessionTemplatesUpdate(sessionTemplateRecord.Name, imageId, bosCliVersions[cliVersion]) &&
-					TestCLISessionTemplatesDelete(sessionTemplateRecord.Name, bosCliVersions[cliVersion]) &&
-					TestCLISessionTemplatesGetAll(sessionTemplateRecord.Name, bosCliVersions[cliVersion])
+				common.Infof(ctx, "Session template created successfully with name %s", sessionTemplateRecord.Name)
+				passed = TestCLISessionTemplatesUpdate(ctx, sessionTemplateRecord.Name, imageId, bosCliVersions[cliVersion]) &&
+					TestCLISessionTemplatesDelete(ctx, sessionTemplateRecord.Name, bosCliVersions[cliVersion]) &&
+					TestCLISessionTemplatesGetAll(ctx, sessionTemplateRecord.Name, bosCliVersions[cliVersion])
 			}
 		}
 
 	}
 	if !testRan {
-		common.Infof("No image found for supported architecture")
-		common.Warnf("Skipping BOS session template CLI tests")
+		common.Infof(ctx, "No image found for supported architecture")
+		common.Warnf(ctx, "Skipping BOS session template CLI tests")
 		// No image found for supported architecture, skip the tests
 		// return true to indicate that the tests were skipped successfully
 		return true
@@ -104,183 +108,187 @@ func TestSessionTemplatesCRUDOperationsUsingCLI() (passed bool) {
 	return passed
 }
 
-func TestCLISessionTemplatesCreate(arch, imageId, cliVersion string) (sessionTemplateRecord BOSSessionTemplate, passed bool) {
+func TestCLISessionTemplatesCreate(ctx context.Context, arch, imageId, cliVersion string) (sessionTemplateRecord bosclient.SessionTemplate, passed bool) {
+	defer common.StartSubtest(ctx, "TestCLISessionTemplatesCreate").End(ctx, &passed)
 	// Create a session template using the CLI
 	templateName := "BOS_SessionTemplate_" + string(common.GetRandomString(10))
-	common.PrintLog(fmt.Sprintf("Creating BOS session template %s with image ID %s and  arch %s", templateName, imageId, arch))
+	common.PrintLog(ctx, fmt.Sprintf("Creating BOS session template %s with image ID %s and  arch %s", templateName, imageId, arch))
 
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
 	// create sessiontemplates payload
-	fileName, payload, success := GetCreateBOSSessionTemplatePayloadCLI(cfgName, false, arch, imageId)
+	fileName, template, success := GetCreateBOSSessionTemplatePayloadCLI(ctx, cfgName, false, arch, imageId)
 	if !success {
-		return BOSSessionTemplate{}, false
+		return bosclient.SessionTemplate{}, false
 	}
 
 	// If the tenant is a dummy tenant, we expect the command to fail
-	if common.IsDummyTenant(common.GetTenantName()) {
+	if common.IsDummyTenant(common.GetTenantName(ctx)) {
 		// Set execution code to 2 to indicate that the session template creation is not supported for dummy tenants
-		test.SetCliExecreturnCode(2)
+		test.SetCliExecreturnCode(ctx, 2)
 	}
 
-	sessionTemplateRecord, success = CreateBOSSessionTemplatesCLI(templateName, fileName, cfgName, cliVersion)
+	sessionTemplateRecord, success = CreateBOSSessionTemplatesCLI(ctx, templateName, fileName, cfgName, cliVersion)
 	if !success {
-		return BOSSessionTemplate{}, false
+		return bosclient.SessionTemplate{}, false
 	}
 
 	// Remove the created session template file
 	if err := os.Remove(fileName); err != nil {
-		common.Errorf("Unable to remove file %s: %v", fileName, err)
+		common.Errorf(ctx, "Unable to remove file %s: %v", fileName, err)
 	}
 
 	// If creation was expected to fail (e.g. using a dummy tenant), skip verification of created resource
-	if test.GetCliExecreturnCode() != 0 {
-		test.SetCliExecreturnCode(0)
+	if test.GetCliExecreturnCode(ctx) != 0 {
+		test.SetCliExecreturnCode(ctx, 0)
 		return sessionTemplateRecord, true
 	}
 
 	// Verify sessiontemplate
-	if !VerifyBOSSessionTemplate(sessionTemplateRecord, payload, templateName) {
-		common.Errorf("BOS session template %s verification failed", sessionTemplateRecord.Name)
-		return BOSSessionTemplate{}, false
+	if !VerifyBOSSessionTemplate(ctx, sessionTemplateRecord, template, templateName) {
+		common.Errorf(ctx, "BOS session template %s verification failed", sessionTemplateRecord.Name)
+		return bosclient.SessionTemplate{}, false
 	}
 
 	// Get the created session template
-	_, success = GetBOSSessiontemplatesCLI(sessionTemplateRecord.Name, cliVersion)
+	_, success = GetBOSSessiontemplatesCLI(ctx, sessionTemplateRecord.Name, cliVersion)
 	if !success {
-		common.Errorf("Unable to get BOS session template %s", sessionTemplateRecord.Name)
-		return BOSSessionTemplate{}, false
+		common.Errorf(ctx, "Unable to get BOS session template %s", sessionTemplateRecord.Name)
+		return bosclient.SessionTemplate{}, false
 	}
 
 	//verify session template in list of session templates
-	sessionTemplateRecords, success := GetBOSSessiontemplatesListCLI(cliVersion)
+	sessionTemplateRecords, success := GetBOSSessiontemplatesListCLI(ctx, cliVersion)
 	if !success {
-		return BOSSessionTemplate{}, false
+		return bosclient.SessionTemplate{}, false
 	}
 
-	if !BOSSessionTemplateExists(sessionTemplateRecord.Name, sessionTemplateRecords) {
-		common.Errorf("BOS session template %s not found in list of session templates", sessionTemplateRecord.Name)
-		return BOSSessionTemplate{}, false
+	if !BOSSessionTemplateExists(ctx, sessionTemplateRecord.Name, sessionTemplateRecords) {
+		common.Errorf(ctx, "BOS session template %s not found in list of session templates", sessionTemplateRecord.Name)
+		return bosclient.SessionTemplate{}, false
 	}
 
 	// Validate session template
-	if !ValidateBOSSessionTemplateCLI(sessionTemplateRecord.Name, cliVersion) {
-		return BOSSessionTemplate{}, false
+	if !ValidateBOSSessionTemplateCLI(ctx, sessionTemplateRecord.Name, cliVersion) {
+		return bosclient.SessionTemplate{}, false
 	}
 
 	return sessionTemplateRecord, true
 }
 
-func TestCLISessionTemplatesUpdate(templateName, imageId, cliVersion string) (passed bool) {
+func TestCLISessionTemplatesUpdate(ctx context.Context, templateName, imageId, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLISessionTemplatesUpdate").End(ctx, &passed)
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
 	// Update the session template using the CLI
-	common.PrintLog(fmt.Sprintf("Updating BOS session template %s with CFS config %s", templateName, cfgName))
+	common.PrintLog(ctx, fmt.Sprintf("Updating BOS session template %s with CFS config %s", templateName, cfgName))
 
 	// Get the existing session template
-	sessionTemplate, success := GetBOSSessiontemplatesCLI(templateName, cliVersion)
+	sessionTemplate, success := GetBOSSessiontemplatesCLI(ctx, templateName, cliVersion)
 	if !success {
-		common.Errorf("Unable to get BOS session template %s", templateName)
+		common.Errorf(ctx, "Unable to get BOS session template %s", templateName)
 		return false
 	}
 
 	// create sessiontemplates payload
-	fileName, payload, success := GetCreateBOSSessionTemplatePayloadCLI(cfgName, true, sessionTemplate.Boot_sets.Compute.Arch, imageId)
+	fileName, template, success := GetCreateBOSSessionTemplatePayloadCLI(ctx, cfgName, true, templateArch(sessionTemplate), imageId)
 	if !success {
 		return false
 	}
 
-	sessionTemplateRecord, success := UpdateBOSSessionTemplatesCLI(templateName, fileName, cfgName, cliVersion)
+	sessionTemplateRecord, success := UpdateBOSSessionTemplatesCLI(ctx, templateName, fileName, cfgName, cliVersion)
 	if !success {
 		return false
 	}
 
 	// Remove the created session template file
 	if err := os.Remove(fileName); err != nil {
-		common.Errorf("Unable to remove file %s: %v", fileName, err)
+		common.Errorf(ctx, "Unable to remove file %s: %v", fileName, err)
 	}
 
 	// Verify sessiontemplate
-	if !VerifyBOSSessionTemplate(sessionTemplateRecord, payload, templateName) {
-		common.Errorf("BOS session template %s verification failed", sessionTemplateRecord.Name)
+	if !VerifyBOSSessionTemplate(ctx, sessionTemplateRecord, template, templateName) {
+		common.Errorf(ctx, "BOS session template %s verification failed", sessionTemplateRecord.Name)
 		return false
 	}
 
 	// Get the updated session template
-	_, success = GetBOSSessiontemplatesCLI(sessionTemplateRecord.Name, cliVersion)
+	_, success = GetBOSSessiontemplatesCLI(ctx, sessionTemplateRecord.Name, cliVersion)
 	if !success {
-		common.Errorf("Unable to get BOS session template %s", sessionTemplateRecord.Name)
+		common.Errorf(ctx, "Unable to get BOS session template %s", sessionTemplateRecord.Name)
 		return false
 	}
 
 	//verify session template in list of session templates
-	sessionTemplateRecords, success := GetBOSSessiontemplatesListCLI(cliVersion)
+	sessionTemplateRecords, success := GetBOSSessiontemplatesListCLI(ctx, cliVersion)
 	if !success {
 		return false
 	}
 
-	if !BOSSessionTemplateExists(sessionTemplateRecord.Name, sessionTemplateRecords) {
-		common.Errorf("BOS session template %s not found in list of session templates", sessionTemplateRecord.Name)
+	if !BOSSessionTemplateExists(ctx, sessionTemplateRecord.Name, sessionTemplateRecords) {
+		common.Errorf(ctx, "BOS session template %s not found in list of session templates", sessionTemplateRecord.Name)
 		return false
 	}
 
 	// Validate session template
-	if !ValidateBOSSessionTemplateCLI(sessionTemplateRecord.Name, cliVersion) {
+	if !ValidateBOSSessionTemplateCLI(ctx, sessionTemplateRecord.Name, cliVersion) {
 		return false
 	}
 
-	common.Infof("BOS Session template %s updated successfully", sessionTemplateRecord.Name)
+	common.Infof(ctx, "BOS Session template %s updated successfully", sessionTemplateRecord.Name)
 
 	return true
 }
 
-func TestCLISessionTemplatesDelete(templateName, cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Deleting BOS session template %s", templateName))
+func TestCLISessionTemplatesDelete(ctx context.Context, templateName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLISessionTemplatesDelete").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting BOS session template %s", templateName))
 
 	// Delete the session template using the CLI
-	if !DeleteBOSSessionTemplatesCLI(templateName, cliVersion) {
-		common.Errorf("Unable to delete BOS session template %s", templateName)
+	if !DeleteBOSSessionTemplatesCLI(ctx, templateName, cliVersion) {
+		common.Errorf(ctx, "Unable to delete BOS session template %s", templateName)
 		return false
 	}
 
 	// Set CLI execution return code to 2. Since the session template is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 
 	// Get the deleted session template
-	_, success := GetBOSSessiontemplatesCLI(templateName, cliVersion)
+	_, success := GetBOSSessiontemplatesCLI(ctx, templateName, cliVersion)
 	if success {
-		common.Errorf("BOS session template %s was not deleted", templateName)
+		common.Errorf(ctx, "BOS session template %s was not deleted", templateName)
 		return false
 	}
 
 	// Set CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// Verify that the session template is deleted
-	sessionTemplateRecords, success := GetBOSSessiontemplatesListCLI(cliVersion)
+	sessionTemplateRecords, success := GetBOSSessiontemplatesListCLI(ctx, cliVersion)
 	if !success {
 		return false
 	}
 
-	if BOSSessionTemplateExists(templateName, sessionTemplateRecords) {
-		common.Errorf("BOS session template %s not deleted, found in list of session templates", templateName)
+	if BOSSessionTemplateExists(ctx, templateName, sessionTemplateRecords) {
+		common.Errorf(ctx, "BOS session template %s not deleted, found in list of session templates", templateName)
 		return false
 	}
 
-	common.Infof("BOS Session template %s deleted successfully", templateName)
+	common.Infof(ctx, "BOS Session template %s deleted successfully", templateName)
 
 	return true
 }
 
-func TestCLISessionTemplatesGetAll(templateName, cliVersion string) (passed bool) {
-	common.PrintLog("Getting all BOS session templates")
+func TestCLISessionTemplatesGetAll(ctx context.Context, templateName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLISessionTemplatesGetAll").End(ctx, &passed)
+	common.PrintLog(ctx, "Getting all BOS session templates")
 
 	// Get all session templates using the CLI
-	sessionTemplateRecords, success := GetBOSSessiontemplatesListCLI(cliVersion)
+	sessionTemplateRecords, success := GetBOSSessiontemplatesListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Unable to get all session templates")
+		common.Errorf(ctx, "Unable to get all session templates")
 		return false
 	}
 
-	common.Infof("Found %d BOS session templates", len(sessionTemplateRecords))
+	common.Infof(ctx, "Found %d BOS session templates", len(sessionTemplateRecords))
 
 	return true
 }
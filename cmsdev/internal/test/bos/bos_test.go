@@ -0,0 +1,83 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package bos
+
+/*
+ * bos_test.go
+ *
+ * Checks of the BOS pods against simulated cluster state
+ *
+ */
+
+import (
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+)
+
+// Makes a fake cluster with the specified objects the current cluster, until the test ends
+func useFakeCluster(t *testing.T, objects ...runtime.Object) {
+	previous := k8s.CurrentCluster()
+	k8s.SetCluster(k8s.NewFakeCluster(objects...))
+	t.Cleanup(func() { k8s.SetCluster(previous) })
+}
+
+func TestCheckBOSPods(t *testing.T) {
+	ctx := common.MainContext()
+	running := []runtime.Object{
+		k8s.FakePod("cray-bos-5d8c7b6f9-abcde", coreV1.PodRunning),
+		k8s.FakePod("cray-bos-operator-discovery-7f9c8d-fghij", coreV1.PodRunning),
+		k8s.FakePod("cray-bos-operator-status-6b7d9c-klmno", coreV1.PodRunning),
+	}
+	// A running pod whose container keeps restarting
+	crashLooping := k8s.FakePod("cray-bos-operator-status-6b7d9c-klmno", coreV1.PodRunning)
+	crashLooping.Status.ContainerStatuses[0].State = coreV1.ContainerState{
+		Waiting: &coreV1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+	}
+	tests := []struct {
+		name   string
+		pods   []runtime.Object
+		passed bool
+	}{
+		{"all running", running, true},
+		{"migration pod succeeded", append(running[:3:3], k8s.FakePod("cray-bos-migration-1-pqrst", coreV1.PodSucceeded)), true},
+		{"cray-bos-migration pod failed", append(running[:3:3], k8s.FakePod("cray-bos-migration-1-pqrst", coreV1.PodFailed)), false},
+		{"one of the migration pods succeeded", append(running[:3:3],
+			k8s.FakePod("cray-bos-migration-1-pqrst", coreV1.PodFailed),
+			k8s.FakePod("cray-bos-migration-2-uvwxy", coreV1.PodSucceeded)), true},
+		{"operator pod failed", append(running[:2:2], k8s.FakePod("cray-bos-operator-status-6b7d9c-klmno", coreV1.PodFailed)), false},
+		{"container crash looping", append(running[:2:2], crashLooping), false},
+		{"completed pod", append(running[:3:3], k8s.FakePod("cray-bos-operator-power-on-3c4d5e-zabcd", coreV1.PodSucceeded)), true},
+		{"too few pods", running[:2], false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			useFakeCluster(t, tc.pods...)
+			if _, passed := checkBOSPods(ctx); passed != tc.passed {
+				t.Errorf("checkBOSPods() passed = %v, expected %v", passed, tc.passed)
+			}
+		})
+	}
+}
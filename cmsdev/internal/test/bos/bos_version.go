@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2024 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2024, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,96 +29,105 @@ package bos
  */
 
 import (
-	"net/http"
+	"context"
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-// This endpoint returns a list of BOS versions
-const bosBaseUri = "/"
-
-const bosV2VersionUri = bosV2BaseUri + "/version"
-
 const bosV2VersionCLI = "version"
 const bosDefaultVersionCLI = bosV2VersionCLI
 
-func versionTestsAPI(params *common.Params, tenantList []string) (passed bool) {
-	passed = true
-
-	// / endpoint
-	// Verify that a GET to this endpoint returns status 200 and a list of dictionary objects
-	if !versionListTestAPI(params) {
-		passed = false
+// Returns true if the version was retrieved without error, logging it
+func checkVersion(ctx context.Context, version bosclient.Version, err error) bool {
+	if !checkNoError(ctx, err) {
+		return false
 	}
+	common.Infof(ctx, "BOS version is %s", version)
+	return true
+}
 
-	// For the remaining endpoints:
-	// Do a GET of the version endpoint and make sure that the response has
-	// 200 status and a dictionary object
-
-	// /v2 endpoint
-	if !basicGetUriVerifyStringMapTest(bosV2BaseUri, params) {
-		passed = false
+func parseVersionOutput(ctx context.Context, cmdOut []byte) error {
+	version, err := bosclient.ParseVersion(cmdOut)
+	if err == nil {
+		common.Infof(ctx, "BOS version is %s", version)
 	}
+	return err
+}
 
-	// v2 endpoint as random tenant (BOS does not verify that tenant exists on GET requests)
-	if !basicTenantGetUriVerifyStringMapTest(bosV2BaseUri, getAnyTenant(tenantList), params) {
-		passed = false
-	}
+func versionTestsAPI(ctx context.Context, client bosclient.Client, tenantList []string) (passed bool) {
+	passed = true
 
-	// /v2/version endpoint
-	if !basicGetUriVerifyStringMapTest(bosV2VersionUri, params) {
+	// / endpoint
+	// Verify that a GET to this endpoint returns status 200 and a list of versions
+	if !versionListTestAPI(ctx, client) {
 		passed = false
 	}
 
-	// /v2/version endpoint as random tenant (BOS does not verify that tenant exists on GET requests)
-	if !basicTenantGetUriVerifyStringMapTest(bosV2VersionUri, getAnyTenant(tenantList), params) {
-		passed = false
+	// For the remaining endpoints:
+	// Do a GET of the version endpoint and make sure that the response has
+	// 200 status and a version object. Do this both without a tenant and as a random
+	// tenant (BOS does not verify that tenant exists on GET requests)
+	for _, tenantClient := range []bosclient.Client{client, client.WithTenant(getAnyTenant(ctx, tenantList))} {
+		// /v2 endpoint
+		apiScenario(ctx, tenantClient, "GET", "/"+bosclient.APIVersion)
+		version, err := tenantClient.V2(ctx)
+		if !checkVersion(ctx, version, err) {
+			passed = false
+		}
+
+		// /v2/version endpoint
+		apiScenario(ctx, tenantClient, "GET", endpointPath(ctx, "version"))
+		version, err = tenantClient.Version(ctx)
+		if !checkVersion(ctx, version, err) {
+			passed = false
+		}
 	}
 
 	return
 }
 
-func versionTestsCLI(tenantList []string) (passed bool) {
+func versionTestsCLI(ctx context.Context, tenantList []string) (passed bool) {
 	passed = true
 
-	// Make sure that "version list" CLI command succeeds and returns a dictionary object.
+	// Make sure that "version list" CLI command succeeds and returns a version object.
 
 	// /v2 endpoint - "cray bos v2 list"
-	if !basicCLIListVerifyStringMapTest("v2") {
+	if !cliListParseTest(ctx, parseVersionOutput, "v2") {
 		passed = false
 	}
 
 	// /v2 endpoint - "cray bos v2 list" as random tenant (BOS does not verify that tenant exists on GET requests)
-	if !basicTenantCLIListVerifyStringMapTest(getAnyTenant(tenantList), "v2") {
+	if !tenantCLIListParseTest(ctx, getAnyTenant(ctx, tenantList), parseVersionOutput, "v2") {
 		passed = false
 	}
 
 	// v2 version list - "cray bos v2 version list"
-	if !basicCLIListVerifyStringMapTest("v2", bosV2VersionCLI) {
+	if !cliListParseTest(ctx, parseVersionOutput, "v2", bosV2VersionCLI) {
 		passed = false
 	}
 
 	// v2 version list - "cray bos v2 version list" as random tenant (BOS does not verify that tenant exists on GET requests)
-	if !basicTenantCLIListVerifyStringMapTest(getAnyTenant(tenantList), "v2", bosV2VersionCLI) {
+	if !tenantCLIListParseTest(ctx, getAnyTenant(ctx, tenantList), parseVersionOutput, "v2", bosV2VersionCLI) {
 		passed = false
 	}
 
 	// version list - "cray bos version list"
-	if !basicCLIListVerifyStringMapTest(bosDefaultVersionCLI) {
+	if !cliListParseTest(ctx, parseVersionOutput, bosDefaultVersionCLI) {
 		passed = false
 	}
 
 	// version list - "cray bos version list" as random tenant (BOS does not verify that tenant exists on GET requests)
-	if !basicTenantCLIListVerifyStringMapTest(getAnyTenant(tenantList), bosDefaultVersionCLI) {
+	if !tenantCLIListParseTest(ctx, getAnyTenant(ctx, tenantList), parseVersionOutput, bosDefaultVersionCLI) {
 		passed = false
 	}
 
 	// default endpoint - "cray bos list"
-	if !basicCLIListVerifyStringMapTest() {
+	if !cliListParseTest(ctx, parseVersionOutput) {
 		passed = false
 	}
 
 	// default endpoint - "cray bos list" as random tenant (BOS does not verify that tenant exists on GET requests)
-	if !basicTenantCLIListVerifyStringMapTest(getAnyTenant(tenantList)) {
+	if !tenantCLIListParseTest(ctx, getAnyTenant(ctx, tenantList), parseVersionOutput) {
 		passed = false
 	}
 
@@ -126,19 +135,15 @@ func versionTestsCLI(tenantList []string) (passed bool) {
 }
 
 // There is no corresponding CLI test for the / endpoint
-func versionListTestAPI(params *common.Params) bool {
-	common.VerbosePrintDivider()
-	common.Infof("GET %s test scenario", bosBaseUri)
-	resp, err := bosRestfulVerifyStatus("GET", bosBaseUri, params, http.StatusOK)
-	if err != nil {
-		common.Error(err)
+func versionListTestAPI(ctx context.Context, client bosclient.Client) bool {
+	common.VerbosePrintDivider(ctx)
+	apiScenario(ctx, client, "GET", "/")
+	versions, err := client.Versions(ctx)
+	if !checkNoError(ctx, err) {
 		return false
 	}
-	// Validate that object can be decoded into a list of string maps at least
-	_, err = common.DecodeJSONIntoStringMapList(resp.Body())
-	if err != nil {
-		common.Error(err)
-		return false
+	for _, version := range versions {
+		common.Infof(ctx, "BOS API version %s is available", version)
 	}
 	return true
 }
@@ -0,0 +1,94 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * leftovers.go
+ *
+ * Finding BOS session templates and sessions left behind by earlier test runs
+ *
+ */
+
+package bos
+
+import (
+	"regexp"
+
+	bosclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/bos"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// The names generated by the BOS tests for the sessions and session templates they create
+var bosSessionNamePattern = regexp.MustCompile(`^BOS_Session_[a-z]{10}$`)
+var bosSessionTemplateNamePattern = regexp.MustCompile(`^BOS_SessionTemplate_[a-z]{10}$`)
+
+// Find the BOS sessions and session templates created by cmsdev, for every tenant. Sessions
+// are listed first, since they refer to the templates.
+func findBOSLeftovers(opts registry.CleanupOptions) (leftovers []registry.Leftover, ok bool) {
+	ctx := opts.Context
+	var templates []registry.Leftover
+
+	tenants, ok := test.GetTenantsAndNone(ctx)
+	defer common.SetTenantName(ctx, "")
+	for _, tenant := range tenants {
+		common.SetTenantName(ctx, tenant)
+		if sessionList, listed := GetAllBOSSessionsAPI(ctx); !listed {
+			ok = false
+		} else {
+			for _, session := range sessionList {
+				if session.Tenant == tenant && bosSessionNamePattern.MatchString(session.Name) {
+					url, err := bosclient.SessionURL(session.Name)
+					if err != nil {
+						common.Warnf(ctx, "%v", err)
+						ok = false
+						continue
+					}
+					leftovers = append(leftovers, registry.Leftover{
+						Kind: bosSessionKind, Name: session.Name, Tenant: tenant,
+						Delete: func() bool { return test.DeleteURLs(ctx, url) },
+					})
+				}
+			}
+		}
+		if templateList, listed := GetAllBOSSessionTemplatesAPI(ctx); !listed {
+			ok = false
+		} else {
+			for _, template := range templateList {
+				if template.Tenant == tenant && bosSessionTemplateNamePattern.MatchString(template.Name) {
+					url, err := bosclient.SessionTemplateURL(template.Name)
+					if err != nil {
+						common.Warnf(ctx, "%v", err)
+						ok = false
+						continue
+					}
+					templates = append(templates, registry.Leftover{
+						Kind: bosSessionTemplateKind, Name: template.Name, Tenant: tenant,
+						Delete: func() bool { return test.DeleteURLs(ctx, url) },
+					})
+				}
+			}
+		}
+	}
+	leftovers = append(leftovers, templates...)
+	return
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,11 +29,13 @@ package cfs
  */
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
@@ -47,96 +49,72 @@ func SetProdCatOk(ok bool) {
 	prodCatOk = ok
 }
 
-func IsCFSRunning(includeCLI, includeTenant bool) (passed bool) {
+// Subtest names, for use with the --only and --skip options
+var cfsAPISubtests = []string{"cfs.api.endpoints", "cfs.api.configurations", "cfs.api.sources"}
+var cfsCLISubtests = []string{"cfs.cli.endpoints", "cfs.cli.configurations", "cfs.cli.sources"}
+
+func init() {
+	registry.Register(registry.ServiceTest{
+		Name:            "cfs",
+		Description:     "Configuration Framework Service: pods, API, configuration and source CRUD",
+		DefaultTimeout:  300,
+		SupportsCLI:     true,
+		SupportsTenant:  true,
+		Subtests:        append(append([]string{"cfs.pods"}, cfsAPISubtests...), cfsCLISubtests...),
+		ClusterSubtests: []string{"cfs.pods"},
+		Run: func(opts registry.RunOptions) bool {
+			return IsCFSRunning(opts.Context, opts.IncludeCLI, opts.IncludeTenant)
+		},
+		FindLeftovers: findCFSLeftovers,
+	})
+}
+
+func IsCFSRunning(ctx context.Context, includeCLI, includeTenant bool) (passed bool) {
+	var podNames []string
+	var ok bool
 	passed = true
-	// 2 pods minimum since we expect both an api and operator pod
-	podNames, ok := test.GetPodNamesByPrefixKey("cfs", 2, -1)
-	if !ok {
-		passed = false
-	}
-	common.Infof("Found %d cfs pods", len(podNames))
-	apiPodName, operatorPodName := "", ""
-	for _, podName := range podNames {
-		// CFS is running if there is operator and api pod
-		// we can ignore the state of cfs-jobs or cfs-db pods
-		re := regexp.MustCompile(common.PodServiceNamePrefixes["cfsServices"])
-		if re.MatchString(podName) {
-			if strings.HasPrefix(podName, common.PodServiceNamePrefixes["cfs-api"]) {
-				apiPodName = podName
-				common.Infof("Found apiPod=%s", podName)
-			} else if strings.HasPrefix(podName, common.PodServiceNamePrefixes["cfs-operator"]) {
-				operatorPodName = podName
-				common.Infof("Found operatorPod=%s", podName)
-			}
-		}
-		if re.MatchString(podName) {
-			common.Infof("checking pod status for %s expecting %s", podName, "Running")
-		} else {
-			common.Infof("checking pod status for %s expecting %s", podName, "N/A")
-		}
-		status, err := k8s.GetPodStatus(common.NAMESPACE, podName)
-		if err != nil {
-			common.VerboseFailedf(err.Error())
-			passed = false
-			continue
-		}
-		common.Infof("Pod status is %s", status)
-		if re.MatchString(podName) {
-			if status == "Succeeded" {
-				common.Warnf("Pod %s has status %s", podName, status)
-			} else if status != "Running" {
-				common.VerboseFailedf("expected status=Running, found status=%s for podName=%s", status, podName)
-				passed = false
-			} else {
-				common.VerboseOkay()
-			}
-		}
-	}
-	if len(apiPodName) == 0 {
-		common.Errorf("No apiPod found")
-		passed = false
-	}
-	if len(operatorPodName) == 0 {
-		common.Errorf("No operatorPod found")
+
+	if !registry.RunSubtest(ctx, "cfs.pods", func(ctx context.Context) bool { podNames, ok = checkCFSPods(ctx); return ok }) {
 		passed = false
 	}
-	if !testCFSAPI() {
+
+	if !registry.RunSubtest(ctx, "cfs.api.endpoints", testCFSAPI) {
 		passed = false
 	}
 
 	// Tenant tests will be run only if requested using the include-tenant flag
 	if includeTenant {
-		if !TestCFSConfigurationsCRUDOperationWithTenantsUsingAPIVersions() {
+		if !registry.RunSubtest(ctx, "cfs.api.configurations", TestCFSConfigurationsCRUDOperationWithTenantsUsingAPIVersions) {
 			passed = false
 		}
 	} else {
-		if !TestCFSConfigurationsCRUDOperationUsingAPIVersions() {
+		if !registry.RunSubtest(ctx, "cfs.api.configurations", TestCFSConfigurationsCRUDOperationUsingAPIVersions) {
 			passed = false
 		}
 	}
 
-	if !TestCFSSourcesCRUDOperation() {
+	if !registry.RunSubtest(ctx, "cfs.api.sources", TestCFSSourcesCRUDOperation) {
 		passed = false
 	}
 
 	// CLI tests will be run only if requested using the include-cli flag
 	if includeCLI {
-		if !testCFSCLI() {
+		if !registry.RunSubtest(ctx, "cfs.cli.endpoints", testCFSCLI) {
 			passed = false
 		}
 
 		// Tenant tests will be run only if requested using the include-tenant flag
 		if includeTenant {
-			if !TestCFSConfigurationsCRUDOperationWithTenantsUsingCLI() {
+			if !registry.RunSubtest(ctx, "cfs.cli.configurations", TestCFSConfigurationsCRUDOperationWithTenantsUsingCLI) {
 				passed = false
 			}
 		} else {
-			if !TestCFSConfigurationsCRUDOperationUsingCLI() {
+			if !registry.RunSubtest(ctx, "cfs.cli.configurations", TestCFSConfigurationsCRUDOperationUsingCLI) {
 				passed = false
 			}
 		}
 
-		if !TestCFSSourcesCRUDOperationUsingCLI() {
+		if !registry.RunSubtest(ctx, "cfs.cli.sources", TestCFSSourcesCRUDOperationUsingCLI) {
 			passed = false
 		}
 	}
@@ -147,10 +125,67 @@ func IsCFSRunning(includeCLI, includeTenant bool) (passed bool) {
 	passed = passed && prodCatOk
 
 	if !passed {
-		common.ArtifactsKubernetes()
+		common.ArtifactsKubernetes(ctx)
 		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, podNames)
 		}
 	}
 	return
 }
+
+// Check the status of the CFS pods. Returns the pod names and true if they all look okay.
+func checkCFSPods(ctx context.Context) (podNames []string, passed bool) {
+	passed = true
+	// 2 pods minimum by default, since we expect both an api and operator pod
+	podNames, ok := test.GetPodNamesByPrefixKey(ctx, "cfs")
+	if !ok {
+		passed = false
+	}
+	common.Infof(ctx, "Found %d cfs pods", len(podNames))
+	apiPodName, operatorPodName := "", ""
+	for _, podName := range podNames {
+		// CFS is running if there is operator and api pod
+		// we can ignore the state of cfs-jobs or cfs-db pods
+		re := regexp.MustCompile(common.PodServiceNamePrefixes["cfsServices"])
+		if re.MatchString(podName) {
+			if strings.HasPrefix(podName, common.PodServiceNamePrefixes["cfs-api"]) {
+				apiPodName = podName
+				common.Infof(ctx, "Found apiPod=%s", podName)
+			} else if strings.HasPrefix(podName, common.PodServiceNamePrefixes["cfs-operator"]) {
+				operatorPodName = podName
+				common.Infof(ctx, "Found operatorPod=%s", podName)
+			}
+		}
+		if re.MatchString(podName) {
+			common.Infof(ctx, "checking pod status for %s expecting %s", podName, "Running")
+		} else {
+			common.Infof(ctx, "checking pod status for %s expecting %s", podName, "N/A")
+		}
+		status, err := k8s.GetPodStatus(ctx, common.NAMESPACE, podName)
+		if err != nil {
+			common.VerboseFailedf(ctx, "%s", err.Error())
+			passed = false
+			continue
+		}
+		common.Infof(ctx, "Pod status is %s", status)
+		if re.MatchString(podName) {
+			if status == "Succeeded" {
+				common.Warnf(ctx, "Pod %s has status %s", podName, status)
+			} else if status != "Running" {
+				common.VerboseFailedf(ctx, "expected status=Running, found status=%s for podName=%s", status, podName)
+				passed = false
+			} else {
+				common.VerboseOkay(ctx)
+			}
+		}
+	}
+	if len(apiPodName) == 0 {
+		common.Errorf(ctx, "No apiPod found")
+		passed = false
+	}
+	if len(operatorPodName) == 0 {
+		common.Errorf(ctx, "No operatorPod found")
+		passed = false
+	}
+	return
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2024 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2024, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -27,48 +27,86 @@ package cfs
  * CFS API definitions
  *
  */
-
 import (
+	"context"
 	"fmt"
-	"net/http"
 
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-const cfsBaseUrl = common.BASEURL + "/apis/cfs"
-const cfsHealthzUrl = cfsBaseUrl + "/healthz"
-const cfsMaxVersion = 3
-const cfsMinVersion = 2
-
-var cfsVersionUrls = []string{cfsBaseUrl + "/", cfsBaseUrl + "/versions", cfsBaseUrl + "/v2", cfsBaseUrl + "/v3"}
-
 type cfsEndpoint struct {
 	Name     string // This must equal what you need to specify in the URI string
-	IdField  string
 	Versions []int
+	// Returns an iterator over the objects listed by a PageFunc, in the specified CFS version
+	iterate func(version int, pages cfsclient.PageFunc) cfsclient.Iterator
+	// Gets an object with the API, and returns its ID
+	get func(ctx context.Context, client cfsclient.Client, id string) (string, error)
+	// Decodes an object from CLI output, and returns its ID
+	parse func(version int, data []byte) (string, error)
 }
 
 var cfsEndpoints = []cfsEndpoint{
 	{
 		Name:     "components",
-		IdField:  "id",
 		Versions: []int{2, 3},
+		iterate: func(version int, pages cfsclient.PageFunc) cfsclient.Iterator {
+			return cfsclient.NewComponentIterator(version, nil, pages)
+		},
+		get: func(ctx context.Context, client cfsclient.Client, id string) (string, error) {
+			component, err := client.GetComponent(ctx, id)
+			return component.ID, err
+		},
+		parse: func(version int, data []byte) (string, error) {
+			component, err := cfsclient.ParseComponent(version, data)
+			return component.ID, err
+		},
 	},
 	{
 		Name:     "configurations",
-		IdField:  "name",
 		Versions: []int{2, 3},
+		iterate: func(version int, pages cfsclient.PageFunc) cfsclient.Iterator {
+			return cfsclient.NewConfigurationIterator(version, nil, pages)
+		},
+		get: func(ctx context.Context, client cfsclient.Client, name string) (string, error) {
+			configuration, err := client.GetConfiguration(ctx, name)
+			return configuration.Name, err
+		},
+		parse: func(version int, data []byte) (string, error) {
+			configuration, err := cfsclient.ParseConfiguration(version, data)
+			return configuration.Name, err
+		},
 	},
 	{
 		Name:     "sessions",
-		IdField:  "name",
 		Versions: []int{2, 3},
+		iterate: func(version int, pages cfsclient.PageFunc) cfsclient.Iterator {
+			return cfsclient.NewSessionIterator(version, nil, pages)
+		},
+		get: func(ctx context.Context, client cfsclient.Client, name string) (string, error) {
+			session, err := client.GetSession(ctx, name)
+			return session.Name, err
+		},
+		parse: func(version int, data []byte) (string, error) {
+			session, err := cfsclient.ParseSession(version, data)
+			return session.Name, err
+		},
 	},
 	{
 		Name:     "sources",
-		IdField:  "name",
 		Versions: []int{3},
+		iterate: func(version int, pages cfsclient.PageFunc) cfsclient.Iterator {
+			return cfsclient.NewSourceIterator(nil, pages)
+		},
+		get: func(ctx context.Context, client cfsclient.Client, name string) (string, error) {
+			source, err := client.GetSource(ctx, name)
+			return source.Name, err
+		},
+		parse: func(version int, data []byte) (string, error) {
+			source, err := cfsclient.ParseSource(data)
+			return source.Name, err
+		},
 	},
 }
 
@@ -96,351 +134,255 @@ func (endpoint cfsEndpoint) InVersion(version int) bool {
 	return false
 }
 
-func (endpoint cfsEndpoint) Url(version int) string {
-	return fmt.Sprintf("%s/v%d/%s", cfsBaseUrl, version, endpoint.Name)
-}
-
-func (endpoint cfsEndpoint) RunCliCommand(version int, cmdArgs ...string) []byte {
+func (endpoint cfsEndpoint) RunCliCommand(ctx context.Context, version int, cmdArgs ...string) []byte {
 	cmdPrefix := []string{fmt.Sprintf("v%d", version), endpoint.Name}
-	return test.RunCLICommandJSON("cfs", append(cmdPrefix, cmdArgs...)...)
+	return test.RunCLICommandJSON(ctx, "cfs", append(cmdPrefix, cmdArgs...)...)
 }
 
-func (endpoint cfsEndpoint) TestApi(params *common.Params) (passed bool) {
+func (endpoint cfsEndpoint) TestApi(ctx context.Context, client cfsclient.Client) (passed bool) {
 	passed = true
-	var itemsList []interface{}
-	common.Infof("API: Testing CFS %s endpoint", endpoint.Name)
-	version := cfsMaxVersion + 1
+	common.Infof(ctx, "API: Testing CFS %s endpoint", endpoint.Name)
 	multiplePages := false
-	for version > cfsMinVersion {
-		version -= 1
-		if endpoint.skipTest(version, multiplePages) {
+	for version := cfsclient.MaxVersion; version >= cfsclient.MinVersion; version-- {
+		if endpoint.skipTest(ctx, version, multiplePages) {
 			continue
 		}
-		common.Infof("API: Listing CFS %s using v%d endpoint", endpoint.Name, version)
-		url := endpoint.Url(version)
-		resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
-		if err != nil {
-			common.Error(err)
+		common.Infof(ctx, "API: Listing CFS %s using v%d endpoint", endpoint.Name, version)
+		client := client.WithVersion(version)
+		ids, pages, ok := endpoint.listIds(ctx, endpoint.iterate(version, client.Pages(ctx, endpoint.Name)))
+		if !ok {
 			passed = false
 			continue
 		}
+		multiplePages = pages > 1
 
-		common.Debugf("API: Parsing response from CFS")
-		// Paging was implemented in CFS v3
-		if version < 3 {
-			itemsList, err = endpoint.parseUnpagedListResponse(resp.Body())
-			if err != nil {
-				common.Error(err)
-				passed = false
-				continue
+		if len(ids) == 0 {
+			common.Infof(ctx, "API: v%d %s list returned an empty list -- skipping API test to get individual item", version, endpoint.Name)
+			continue
+		}
+
+		// Now try to get the first and last items listed directly
+		for _, id := range firstAndLast(ids) {
+			common.Infof(ctx, "API: Getting CFS %s %s using v%d endpoint", endpoint.Name, id, version)
+			idFieldValue, err := endpoint.get(ctx, client, id)
+			if err == nil {
+				err = endpoint.checkId(idFieldValue, id)
 			}
-		} else {
-			itemsList, multiplePages, err = endpoint.parsePagedListResponse(resp.Body())
 			if err != nil {
-				common.Error(err)
+				common.Error(ctx, err)
 				passed = false
-				continue
 			}
 		}
-
-		if len(itemsList) == 0 {
-			common.Infof("API: GET %s returned an empty list -- skipping API test to get individual item", url)
-			continue
-		}
-
-		// The list has entries, so let's get the ID field of the
-		// first entry. Then we can do a GET/describe on that object
-		idFieldValue, err := endpoint.getFirstId(itemsList)
-		if err != nil {
-			common.Error(err)
-			passed = false
-			continue
-		}
-
-		// Now try to get this item directly
-		common.Infof("API: Getting CFS %s %s using v%d endpoint", endpoint.Name, idFieldValue, version)
-		url += "/" + idFieldValue
-		resp, err = test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
-		if err != nil {
-			common.Error(err)
-			passed = false
-			continue
-		}
-
-		// Validate that we find the expected ID field value
-		err = endpoint.checkIDField(resp.Body(), idFieldValue)
-		if err != nil {
-			common.Error(err)
-			passed = false
-		}
 	}
 	return
 }
 
-func (endpoint cfsEndpoint) TestCli() (passed bool) {
+func (endpoint cfsEndpoint) TestCli(ctx context.Context) (passed bool) {
 	passed = true
-	var itemsList []interface{}
-	var err error
-	common.Infof("CLI: Testing CFS %s endpoint", endpoint.Name)
-	version := cfsMaxVersion + 1
+	common.Infof(ctx, "CLI: Testing CFS %s endpoint", endpoint.Name)
 	multiplePages := false
-	for version > cfsMinVersion {
-		version -= 1
-		if endpoint.skipTest(version, multiplePages) {
+	for version := cfsclient.MaxVersion; version >= cfsclient.MinVersion; version-- {
+		if endpoint.skipTest(ctx, version, multiplePages) {
 			continue
 		}
-		common.Infof("CLI: Listing CFS %s using v%d endpoint", endpoint.Name, version)
-		cmdOut := endpoint.RunCliCommand(version, "list")
-		if cmdOut == nil {
+		common.Infof(ctx, "CLI: Listing CFS %s using v%d endpoint", endpoint.Name, version)
+		ids, pages, ok := endpoint.listIds(ctx, endpoint.iterate(version, cliPages(ctx, fmt.Sprintf("v%d", version), endpoint.Name)))
+		if !ok {
 			passed = false
 			continue
 		}
+		multiplePages = pages > 1
 
-		common.Debugf("API: Parsing response from CFS")
-		// Paging was implemented in CFS v3
-		if version < 3 {
-			itemsList, err = endpoint.parseUnpagedListResponse(cmdOut)
-			if err != nil {
-				common.Error(err)
+		if len(ids) == 0 {
+			common.Infof(ctx, "CLI: v%d %s list returned an empty list -- skipping CLI test to get individual item", version, endpoint.Name)
+			continue
+		}
+
+		// Now try to get the first and last items listed directly
+		for _, id := range firstAndLast(ids) {
+			common.Infof(ctx, "CLI: v%d %s describe %s", version, endpoint.Name, id)
+			cmdOut := endpoint.RunCliCommand(ctx, version, "describe", id)
+			if cmdOut == nil {
 				passed = false
 				continue
 			}
-		} else {
-			itemsList, multiplePages, err = endpoint.parsePagedListResponse(cmdOut)
+			idFieldValue, err := endpoint.parse(version, cmdOut)
+			if err == nil {
+				err = endpoint.checkId(idFieldValue, id)
+			}
 			if err != nil {
-				common.Error(err)
+				common.Error(ctx, err)
 				passed = false
-				continue
 			}
 		}
-
-		if len(itemsList) == 0 {
-			common.Infof("CLI: v%d %s list returned an empty list -- skipping CLI test to get individual item", version, endpoint.Name)
-			continue
-		}
-
-		// The list has entries, so let's get the ID field of the
-		// first entry. Then we can do a GET/describe on that object
-		idFieldValue, err := endpoint.getFirstId(itemsList)
-		if err != nil {
-			common.Error(err)
-			passed = false
-			continue
-		}
-
-		// Now try to get this item directly
-		common.Infof("CLI: v%d %s describe %s", version, endpoint.Name, idFieldValue)
-		cmdOut = endpoint.RunCliCommand(version, "describe", idFieldValue)
-		if cmdOut == nil {
-			passed = false
-			continue
-		}
-
-		// Validate that we find the expected ID field value
-		err = endpoint.checkIDField(cmdOut, idFieldValue)
-		if err != nil {
-			common.Error(err)
-			passed = false
-		}
 	}
 	return
 }
 
-func (endpoint cfsEndpoint) skipTest(version int, multiplePages bool) bool {
-	// Return True if the test of this CFS endpoint/version combo should be skipped
+func (endpoint cfsEndpoint) skipTest(ctx context.Context, version int, multiplePages bool) bool {
+	// Return True if the test of this CFS endpoint/version combo should be skipped.
+	// CFS v2 does not page its lists, and fails to list more items than fit in one v3 page.
 	if !endpoint.InVersion(version) {
-		common.Debugf("%s endpoint is not supported in CFS v%d; skipping", endpoint.Name, version)
+		common.Debugf(ctx, "%s endpoint is not supported in CFS v%d; skipping", endpoint.Name, version)
 		return true
 	} else if (version == 2) && multiplePages {
-		common.Infof("Too many %s in CFS to test v2 endpoint with current default page size; skipping", endpoint.Name)
+		common.Infof(ctx, "Too many %s in CFS to test v2 endpoint with current default page size; skipping", endpoint.Name)
 		return true
 	}
 	return false
 }
 
-func (endpoint cfsEndpoint) parseUnpagedListResponse(responseBytes []byte) ([]interface{}, error) {
-	// Paging was implemented in CFS v3, so if this is an earlier version, the response is a simple list
-	return common.DecodeJSONIntoList(responseBytes)
-}
-
-func (endpoint cfsEndpoint) parsePagedListResponse(responseBytes []byte) (itemsList []interface{}, multiplePages bool, err error) {
-	var mapObject map[string]interface{}
-	// Our response should be a map object with two fields -- endpoint.Name and "next"
-	// endpoint.Name should map to a list
-	// next should either be nil or map to a string map
-	mapObject, err = common.DecodeJSONIntoStringMap(responseBytes)
-	if err != nil {
-		return
-	}
-
-	nextRawValue, ok := mapObject["next"]
-	if !ok {
-		err = fmt.Errorf("Response is missing expected 'next' field")
-		return
-	}
-
-	itemsListRawValue, ok := mapObject[endpoint.Name]
-	if !ok {
-		err = fmt.Errorf("Response is missing expected '%s' field", endpoint.Name)
-		return
-	}
-
-	itemsList, ok = itemsListRawValue.([]interface{})
-	if !ok {
-		err = fmt.Errorf("Response field '%s' should map to a list but does not", endpoint.Name)
-		return
+// listIds lists every item with the iterator, and returns their IDs and the number of pages
+// they were listed in. An item which is listed more than once (such as on two pages) is an error.
+func (endpoint cfsEndpoint) listIds(ctx context.Context, it cfsclient.Iterator) (ids []string, pages int, ok bool) {
+	listed := make(map[string]bool)
+	for it.Next() {
+		if listed[it.ID()] {
+			common.Errorf(ctx, "CFS %s list has %s more than once", endpoint.Name, it.ID())
+			return ids, it.Pages(), false
+		}
+		listed[it.ID()] = true
+		ids = append(ids, it.ID())
 	}
-
-	if nextRawValue == nil {
-		common.Debugf("Response field 'next' is nil, so there is only one page of items")
-		multiplePages = false
-	} else if _, ok = nextRawValue.(map[string]interface{}); ok {
-		common.Debugf("Response field 'next' is not nil, so there is more than one page of items")
-		multiplePages = true
-	} else {
-		err = fmt.Errorf("Response field 'next' should map to None or a dictionary, but does not")
+	if err := it.Err(); err != nil {
+		common.Error(ctx, err)
+		return ids, it.Pages(), false
 	}
-
-	return
+	common.Infof(ctx, "Listed %d CFS %s in %d page(s)", len(ids), endpoint.Name, it.Pages())
+	return ids, it.Pages(), true
 }
 
-func (endpoint cfsEndpoint) getFirstId(itemsList []interface{}) (idFieldValue string, err error) {
-	// This function should only be called after it has been verified that the
-	// list is not empty. The list has entries, so let's get the ID field of the
-	// first entry. Then we can do a GET/describe on that object
-	firstListEntry, ok := itemsList[0].(map[string]interface{})
-	if !ok {
-		err = fmt.Errorf("First item in list is not a dictionary, but should be")
-		return
-	}
-
-	idFieldRawValue, ok := firstListEntry[endpoint.IdField]
-	if !ok {
-		err = fmt.Errorf("First item in list is missing required '%s' field", endpoint.IdField)
-		return
+// Returns the first and last IDs in a list, or the only one if it has one entry
+func firstAndLast(ids []string) []string {
+	if len(ids) == 1 {
+		return ids
 	}
+	return []string{ids[0], ids[len(ids)-1]}
+}
 
-	idFieldValue, ok = idFieldRawValue.(string)
-	if !ok {
-		err = fmt.Errorf("In first item listed, '%s' field does not map to a string, but it should", endpoint.IdField)
-	} else if len(idFieldValue) == 0 {
-		err = fmt.Errorf("In first item listed, '%s' field maps to a 0-length string, but it should have non-0 length", endpoint.IdField)
+func (endpoint cfsEndpoint) checkId(idFieldValue, expectedIdValue string) error {
+	if idFieldValue != expectedIdValue {
+		// The endpoint names are plural ending in s -- this makes it singular
+		return fmt.Errorf("CFS %s has ID '%s', expected '%s'", endpoint.Name[:len(endpoint.Name)-1], idFieldValue, expectedIdValue)
 	}
-	return
+	return nil
 }
 
-func (endpoint cfsEndpoint) checkIDField(mapCmdOut []byte, expectedIdValue string) error {
-	// The endpoint names are plural ending in s -- this makes it singular
-	objectName := "CFS " + endpoint.Name[:len(endpoint.Name)-1]
-	return common.ValidateStringFieldValue(objectName, endpoint.IdField, expectedIdValue, mapCmdOut)
+// validCLIOutput returns true if a CLI command succeeded, and its output can be decoded by parse
+func validCLIOutput(ctx context.Context, cmdOut []byte, parse func([]byte) error) bool {
+	if cmdOut == nil {
+		return false
+	} else if err := parse(cmdOut); err != nil {
+		common.Error(ctx, err)
+		return false
+	}
+	return true
 }
 
-func validJSONStringMap(jsonBytes []byte) bool {
-	_, err := common.DecodeJSONIntoStringMap(jsonBytes)
+// checkVersion logs the CFS version returned by a version endpoint, or the error getting it
+func checkVersion(ctx context.Context, endpoint string, version cfsclient.Version, err error) bool {
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
+	common.Infof(ctx, "API: CFS version from %s is %s", endpoint, version)
 	return true
 }
 
-func testCFSAPI() (passed bool) {
+func testCFSAPI(ctx context.Context) (passed bool) {
 	passed = false
-	common.Infof("Checking CFS API endpoints")
-	params := test.GetAccessTokenParams()
+	common.Infof(ctx, "Checking CFS API endpoints")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 	passed = true
+	client := cfsclient.NewClient(*params)
 
-	common.Infof("API: Checking CFS service health")
-	resp, err := test.RestfulVerifyStatus("GET", cfsHealthzUrl, *params, http.StatusOK)
-	if err != nil {
-		common.Error(err)
-		passed = false
-	} else if !validJSONStringMap(resp.Body()) {
+	common.Infof(ctx, "API: Checking CFS service health")
+	if healthz, err := client.Healthz(ctx); err != nil {
+		common.Error(ctx, err)
 		passed = false
+	} else {
+		common.Infof(ctx, "API: CFS db_status is '%s', kafka_status is '%s'", healthz.DBStatus, healthz.KafkaStatus)
 	}
 
-	common.Infof("API: Checking CFS version endpoints")
-	for _, url := range cfsVersionUrls {
-		resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
-		if err != nil {
-			common.Error(err)
-			passed = false
-		} else if !validJSONStringMap(resp.Body()) {
-			passed = false
-		}
+	common.Infof(ctx, "API: Checking CFS version endpoints")
+	version, err := client.Root(ctx)
+	passed = checkVersion(ctx, "/", version, err) && passed
+	version, err = client.Versions(ctx)
+	passed = checkVersion(ctx, "/versions", version, err) && passed
+	for v := cfsclient.MinVersion; v <= cfsclient.MaxVersion; v++ {
+		version, err = client.WithVersion(v).Version(ctx)
+		passed = checkVersion(ctx, fmt.Sprintf("/v%d", v), version, err) && passed
 	}
 
-	common.Infof("API: Checking CFS option endpoints")
-	version := cfsMinVersion
-	for version <= cfsMaxVersion {
-		url := fmt.Sprintf("%s/v%d/options", cfsBaseUrl, version)
-		resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
-		if err != nil {
-			common.Error(err)
-			passed = false
-		} else if !validJSONStringMap(resp.Body()) {
+	common.Infof(ctx, "API: Checking CFS option endpoints")
+	for v := cfsclient.MinVersion; v <= cfsclient.MaxVersion; v++ {
+		if options, err := client.WithVersion(v).Options(ctx); err != nil {
+			common.Error(ctx, err)
 			passed = false
+		} else {
+			common.Infof(ctx, "API: CFS v%d default_playbook is '%s', session_ttl is '%s'", v, options.DefaultPlaybook, options.SessionTTL)
 		}
-		version += 1
 	}
 
 	for _, endpoint := range cfsEndpoints {
-		if !endpoint.TestApi(params) {
+		if !endpoint.TestApi(ctx, client) {
 			passed = false
 		}
 	}
 	return
 }
 
-func testCFSCLI() (passed bool) {
+func testCFSCLI(ctx context.Context) (passed bool) {
 	passed = true
+	parseVersion := func(data []byte) error { _, err := cfsclient.ParseVersion(data); return err }
 
 	// cray cfs healthz list
-	common.Infof("CLI: Checking CFS service health")
-	cmdOut := test.RunCLICommandJSON("cfs", "healthz", "list")
-	if cmdOut == nil || !validJSONStringMap(cmdOut) {
+	common.Infof(ctx, "CLI: Checking CFS service health")
+	cmdOut := test.RunCLICommandJSON(ctx, "cfs", "healthz", "list")
+	if !validCLIOutput(ctx, cmdOut, func(data []byte) error { _, err := cfsclient.ParseHealthz(data); return err }) {
 		passed = false
 	}
 
-	common.Infof("CLI: Checking CFS version endpoints")
+	common.Infof(ctx, "CLI: Checking CFS version endpoints")
 	// cray cfs list
-	cmdOut = test.RunCLICommandJSON("cfs", "list")
-	if cmdOut == nil || !validJSONStringMap(cmdOut) {
+	cmdOut = test.RunCLICommandJSON(ctx, "cfs", "list")
+	if !validCLIOutput(ctx, cmdOut, parseVersion) {
 		passed = false
 	}
 
 	// cray cfs versions list
-	cmdOut = test.RunCLICommandJSON("cfs", "versions", "list")
-	if cmdOut == nil || !validJSONStringMap(cmdOut) {
+	cmdOut = test.RunCLICommandJSON(ctx, "cfs", "versions", "list")
+	if !validCLIOutput(ctx, cmdOut, parseVersion) {
 		passed = false
 	}
 
 	// cray cfs v# list
-	version := cfsMinVersion
-	for version <= cfsMaxVersion {
-		cmdOut = test.RunCLICommandJSON("cfs", fmt.Sprintf("v%d", version), "list")
-		if cmdOut == nil || !validJSONStringMap(cmdOut) {
+	version := cfsclient.MinVersion
+	for version <= cfsclient.MaxVersion {
+		cmdOut = test.RunCLICommandJSON(ctx, "cfs", fmt.Sprintf("v%d", version), "list")
+		if !validCLIOutput(ctx, cmdOut, parseVersion) {
 			passed = false
 		}
 		version += 1
 	}
 
 	// cray cfs v# options list
-	common.Infof("API: Checking CFS option endpoints")
-	version = cfsMinVersion
-	for version <= cfsMaxVersion {
-		cmdOut = test.RunCLICommandJSON("cfs", fmt.Sprintf("v%d", version), "options", "list")
-		if cmdOut == nil || !validJSONStringMap(cmdOut) {
+	common.Infof(ctx, "CLI: Checking CFS option endpoints")
+	version = cfsclient.MinVersion
+	for version <= cfsclient.MaxVersion {
+		cmdOut = test.RunCLICommandJSON(ctx, "cfs", fmt.Sprintf("v%d", version), "options", "list")
+		optionsVersion := version
+		if !validCLIOutput(ctx, cmdOut, func(data []byte) error { _, err := cfsclient.ParseOptions(optionsVersion, data); return err }) {
 			passed = false
 		}
 		version += 1
 	}
 
 	for _, endpoint := range cfsEndpoints {
-		if !endpoint.TestCli() {
+		if !endpoint.TestCli(ctx) {
 			passed = false
 		}
 	}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,10 +29,12 @@
 package cfs
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"net/http"
 
-	resty "gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/apiclient"
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
 	pcu "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/prod-catalog-utils"
@@ -41,12 +43,12 @@ import (
 
 // GetProdCatalogConfigData fetches the CSM product catalog configuration layer data from the product catalog config map
 // It returns the CsmProductCatalogConfiguration struct and error if any
-func GetProdCatalogConfigData() (cfsConfigLayerData CsmProductCatalogConfiguration, err error) {
-	latestCSMData, err := pcu.GetLatestProdCatEntry()
+func GetProdCatalogConfigData(ctx context.Context) (cfsConfigLayerData CsmProductCatalogConfiguration, err error) {
+	latestCSMData, err := pcu.GetLatestProdCatEntry(ctx)
 	if err != nil {
 		// This means that there was an error accessing the actual product catalog data
 		SetProdCatOk(false)
-		common.Errorf("Unable to get latest CSM product catalog entry: %v", err)
+		common.Errorf(ctx, "Unable to get latest CSM product catalog entry: %v", err)
 		if !latestCSMData.Initialized {
 			// This means that for some reason, fake data was unable to be generated
 			// to use in place of the actual product catalog data. In this case, the
@@ -56,7 +58,7 @@ func GetProdCatalogConfigData() (cfsConfigLayerData CsmProductCatalogConfigurati
 		// This means that it was able to provide fake data for the test
 		// We've set the prodCatOk variable, which will ensure the CFS test fails.
 		// We can use the fake data to proceed with this test
-		common.Infof("Using fake data in place of the actual product catalog data")
+		common.Infof(ctx, "Using fake data in place of the actual product catalog data")
 	}
 
 	if latestCSMData.Configuration.CloneURL == "" || latestCSMData.Configuration.Commit == "" {
@@ -70,211 +72,150 @@ func GetProdCatalogConfigData() (cfsConfigLayerData CsmProductCatalogConfigurati
 }
 
 // GetCreateCFGConfigurationPayload returns the payload for creating a CFS configuration
-func GetCreateCFGConfigurationPayload(apiVersion string, addTenant bool) (payload string, ok bool) {
-	common.Infof("Getting product catalog configuration layer data")
-	configData, err := GetProdCatalogConfigData()
+func GetCreateCFGConfigurationPayload(ctx context.Context, addTenant bool) (payload cfsclient.Configuration, ok bool) {
+	common.Infof(ctx, "Getting product catalog configuration layer data")
+	configData, err := GetProdCatalogConfigData(ctx)
 	if err != nil {
-		return "", false
+		return cfsclient.Configuration{}, false
 	}
+	return GetCFGConfigurationPayloadForLayer(ctx, configData, DEFAULT_PLAYBOOK, addTenant), true
+}
 
-	cfgLayerName := "Configuration_Layer_" + string(common.GetRandomString(10))
-
-	// Create the CFS configuration payload
-	cfsPayload := map[string]interface{}{
-		"layers": []map[string]string{
+// GetCFGConfigurationPayloadForLayer returns the payload for creating a CFS configuration with a
+// single layer, which runs the specified playbook from the repository and commit in layerData
+func GetCFGConfigurationPayloadForLayer(ctx context.Context, layerData CsmProductCatalogConfiguration, playbook string, addTenant bool) (payload cfsclient.Configuration) {
+	payload = cfsclient.Configuration{
+		Layers: []cfsclient.Layer{
 			{
-				"commit":   configData.Commit,
-				"playbook": DEFAULT_PLAYBOOK,
-				"name":     cfgLayerName,
+				Name:     "Configuration_Layer_" + string(common.GetRandomString(10)),
+				CloneURL: layerData.Clone_url,
+				Commit:   layerData.Commit,
+				Playbook: playbook,
 			},
 		},
 	}
 
 	// add the tenant in the payload body if the configuration is created by admin
 	if addTenant {
-		cfsPayload["tenant_name"] = common.GetTenantName()
+		payload.TenantName = common.GetTenantName(ctx)
 	}
+	return
+}
 
-	// Setting the clone_url in the payload based on the API version
-	// As per API spec For v3, use "clone_url" key, for others, use "cloneUrl" key
-	layers, ok := cfsPayload["layers"].([]map[string]string)
-	if !ok {
-		common.Error(fmt.Errorf("failed to type-assert layers as []map[string]string"))
-		return "", false
+// versionNumber returns the number of a CFS API or CLI version ("v2" or "v3"). The CLI
+// commands which do not specify a version use CFS v2.
+func versionNumber(version string) int {
+	if version == "v3" {
+		return 3
 	}
+	return 2
+}
 
-	if apiVersion == "v3" {
-		layers[0]["clone_url"] = configData.Clone_url
-	} else {
-		layers[0]["cloneUrl"] = configData.Clone_url
+// cfsClient returns a client for the specified CFS API version, which makes its requests as
+// the current tenant (if any)
+func cfsClient(ctx context.Context, apiVersion string) (client cfsclient.Client, ok bool) {
+	params := test.GetAccessTokenParams(ctx)
+	if params == nil {
+		common.Error(ctx, fmt.Errorf("Unable to get access token params"))
+		return client, false
 	}
+	return cfsclient.NewClient(*params).WithVersion(versionNumber(apiVersion)).WithTenant(common.GetTenantName(ctx)), true
+}
 
-	jsonPayload, err := json.Marshal(cfsPayload)
-	if err != nil {
-		common.Error(err)
-		return "", false
+// expectStatus checks the result of a CFS request which was expected to get a response with
+// expectedStatus, where successStatus is the status of a successful response. It returns true
+// if the request succeeded and was expected to, or if it failed with the expected status.
+func expectStatus(ctx context.Context, err error, expectedStatus, successStatus int) bool {
+	if expectedStatus == successStatus {
+		if err != nil {
+			common.Error(ctx, err)
+			return false
+		}
+		return true
+	} else if err == nil {
+		common.Errorf(ctx, "Expected status code %d, but got %d", expectedStatus, successStatus)
+		return false
+	} else if !apiclient.IsStatus(err, expectedStatus) {
+		common.Error(ctx, err)
+		return false
 	}
-
-	common.Infof("CFS configuration payload: %s", string(jsonPayload))
-	return string(jsonPayload), true
+	common.Infof(ctx, "Received status code %d, as expected", expectedStatus)
+	return true
 }
 
 // CreateUpdateCFSConfigurationRecordAPI creates or updates a CFS configuration record using the provided payload
 // It takes cfs config name, apiVersion, payload, and expected http status code as parameters
-// It returns true and CFSConfiguration struct with data for following cases:
+// It returns true and the configuration with data for following cases:
 // - If the create/update operation is successful and expectedHttpStatus matches the actual http status code
-// It returns true and empty CFSConfiguration struct for following cases:
+// It returns true and an empty configuration for following cases:
 // - If the create/update operation is not successful and expectedHttpStatus matches the actual http status code
-// It returns false and empty CFSConfiguration struct for following cases:
+// It returns false and an empty configuration for following cases:
 // - If the create/update operation is not successful and expectedHttpStatus does not match the actual http status code
 // - If there is an error in getting access token params
-// - If there is an error in unmarshalling the response body into CFSConfiguration struct
-func CreateUpdateCFSConfigurationRecordAPI(cfgName, apiVersion, payload string, httpStatus int) (cfsConfig CFSConfiguration, ok bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return CFSConfiguration{}, false
+// - If there is an error in decoding the response body
+func CreateUpdateCFSConfigurationRecordAPI(ctx context.Context, cfgName, apiVersion string, payload cfsclient.Configuration, httpStatus int) (cfsConfig cfsclient.Configuration, ok bool) {
+	client, ok := cfsClient(ctx, apiVersion)
+	if !ok {
+		return cfsclient.Configuration{}, false
 	}
 
-	// Set the payload for the request
-	params.JsonStr = payload
-	url := constructCFSURL("configurations", apiVersion) + "/" + cfgName
-	resp, err := VerifyRestStatusWithTenant("PUT", url, *params, httpStatus)
-
-	if err != nil {
-		common.Error(err)
-		return CFSConfiguration{}, false
+	if jsonPayload, err := cfsclient.EncodeConfiguration(client.APIVersion(), payload); err == nil {
+		common.Infof(ctx, "CFS configuration payload: %s", string(jsonPayload))
 	}
-
-	// Decoding the response body into the CFSConfiguration struct
-	if err := json.Unmarshal(resp.Body(), &cfsConfig); err != nil {
-		common.Error(err)
-		return CFSConfiguration{}, false
+	cfsConfig, err := client.PutConfiguration(ctx, cfgName, payload)
+	if !expectStatus(ctx, err, httpStatus, http.StatusOK) {
+		return cfsclient.Configuration{}, false
+	} else if err == nil {
+		registerCFSConfigurationCleanup(ctx, cfgName)
 	}
-
-	ok = true
-	return
+	return cfsConfig, true
 }
 
 // GetCFSConfigurationRecordAPI retrieves a CFS configuration record by name
 // It takes cfs config name, apiVersion, and expected http status code as parameters
-// It returns true and CFSConfiguration struct with data for following cases:
+// It returns true and the configuration with data for following cases:
 // - If the get operation is successful and expectedHttpStatus matches the actual http status code
-// It returns true and empty CFSConfiguration struct for following cases:
+// It returns true and an empty configuration for following cases:
 // - If the get operation is not successful and expectedHttpStatus matches the actual http status code
-// It returns false and empty CFSConfiguration struct for following cases:
-// - If the get operation is not successful and expectedHttpStatus does not match the actual http status code
-// - If there is an error in getting access token params
-// - If there is an error in unmarshalling the response body into CFSConfiguration struct
-func GetCFSConfigurationRecordAPI(cfgName, apiVersion string, httpStatus int) (cfsConfig CFSConfiguration, ok bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return CFSConfiguration{}, false
-	}
-
-	url := constructCFSURL("configurations", apiVersion) + "/" + cfgName
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, httpStatus)
-
-	if err != nil {
-		common.Error(err)
-		return CFSConfiguration{}, false
-	}
-
-	// Decoding the response body into the CFSConfiguration struct
-	if err := json.Unmarshal(resp.Body(), &cfsConfig); err != nil {
-		common.Error(err)
-		return CFSConfiguration{}, false
-	}
-
-	ok = true
-	return
-}
-
-// GetCFSConfigurationsListAPIV2 retrieves the list of CFS configurations for API version v2
-// It takes apiVersion and expected http status code as parameters
-// It returns true and a slice of CFSConfiguration structs with data for following cases:
-// - If the get operation is successful and expectedHttpStatus matches the actual http status code
-// It returns false and empty slice of CFSConfiguration structs for following cases:
+// It returns false and an empty configuration for following cases:
 // - If the get operation is not successful and expectedHttpStatus does not match the actual http status code
 // - If there is an error in getting access token params
-// - If there is an error in unmarshalling the response body into slice of CFSConfiguration structs
-func GetCFSConfigurationsListAPIV2(apiVersion string, expectedHttpStatus int) (cfsConfigurations []CFSConfiguration, ok bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return []CFSConfiguration{}, false
-	}
-
-	url := constructCFSURL("configurations", apiVersion)
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, expectedHttpStatus)
-
-	if err != nil {
-		common.Error(err)
-		return []CFSConfiguration{}, false
+// - If there is an error in decoding the response body
+func GetCFSConfigurationRecordAPI(ctx context.Context, cfgName, apiVersion string, httpStatus int) (cfsConfig cfsclient.Configuration, ok bool) {
+	client, ok := cfsClient(ctx, apiVersion)
+	if !ok {
+		return cfsclient.Configuration{}, false
 	}
 
-	// Decoding the response body into the CFSConfiguration struct
-	if err := json.Unmarshal(resp.Body(), &cfsConfigurations); err != nil {
-		common.Error(err)
-		return []CFSConfiguration{}, false
+	cfsConfig, err := client.GetConfiguration(ctx, cfgName)
+	if !expectStatus(ctx, err, httpStatus, http.StatusOK) {
+		return cfsclient.Configuration{}, false
 	}
-
-	ok = true
-	return
+	return cfsConfig, true
 }
 
-// GetCFSConfigurationsListAPI retrieves the list of CFS configurations for API version v3
+// GetCFSConfigurationsListAPI retrieves the list of CFS configurations. With v3, every page of the
+// list is retrieved.
 // It takes apiVersion and expected http status code as parameters
-// It returns true and CFSConfigurationsList struct with data for following cases:
+// It returns true and the configurations for following cases:
 // - If the get operation is successful and expectedHttpStatus matches the actual http status code
-// It returns false and empty CFSConfigurationsList struct for following cases:
+// It returns true and an empty list for following cases:
+// - If the get operation is not successful and expectedHttpStatus matches the actual http status code
+// It returns false and an empty list for following cases:
 // - If the get operation is not successful and expectedHttpStatus does not match the actual http status code
 // - If there is an error in getting access token params
-// - If there is an error in unmarshalling the response body into CFSConfigurationsList struct
-func GetCFSConfigurationsListAPI(apiVersion string, expectedHttpStatus int) (cfsConfigurations CFSConfigurationsList, ok bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return CFSConfigurationsList{}, false
-	}
-
-	url := constructCFSURL("configurations", apiVersion)
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, expectedHttpStatus)
-
-	if err != nil {
-		common.Error(err)
-		return CFSConfigurationsList{}, false
-	}
-
-	// Decoding the response body into the CFSConfiguration struct
-	if err := json.Unmarshal(resp.Body(), &cfsConfigurations); err != nil {
-		common.Error(err)
-		return CFSConfigurationsList{}, false
+// - If there is an error in decoding a response body
+func GetCFSConfigurationsListAPI(ctx context.Context, apiVersion string, expectedHttpStatus int) (cfsConfigurations []cfsclient.Configuration, ok bool) {
+	client, ok := cfsClient(ctx, apiVersion)
+	if !ok {
+		return []cfsclient.Configuration{}, false
 	}
 
-	ok = true
-	return
-}
-
-// GetAPIBasedCFSConfigurationRecordList gets the list of CFS configurations based on the API version
-// It takes apiVersion and expected http status code as parameters
-func GetAPIBasedCFSConfigurationRecordList(apiVersion string, expectedHttpStatus int) (cfsConfig []CFSConfiguration, ok bool) {
-	// Get the CFS configurations list based on the API version. The response will be different for v2 and v3.
-	// For v3, the response will be a list of CFSConfigurationsList, while for v2, it will be a CFSConfigurations struct.
-	if apiVersion == "v3" {
-		cfsConfigV3, ok := GetCFSConfigurationsListAPI(apiVersion, expectedHttpStatus)
-		if !ok {
-			return []CFSConfiguration{}, false
-		}
-		cfsConfig = cfsConfigV3.Configurations
-	} else {
-		cfsConfig, ok = GetCFSConfigurationsListAPIV2(apiVersion, expectedHttpStatus)
-		if !ok {
-			return []CFSConfiguration{}, false
-		}
+	cfsConfigurations, err := client.ListConfigurations(ctx, nil)
+	if !expectStatus(ctx, err, expectedHttpStatus, http.StatusOK) {
+		return []cfsclient.Configuration{}, false
 	}
-	return cfsConfig, true
+	return cfsConfigurations, true
 }
 
 // DeleteCFSConfigurationRecordAPI deletes a CFS configuration record by name
@@ -282,147 +223,112 @@ func GetAPIBasedCFSConfigurationRecordList(apiVersion string, expectedHttpStatus
 // It returns true if the actual http status code from the API delete call matches the
 // expected http status code (whether or not the API call was successful). It returns
 // false otherwise.
-func DeleteCFSConfigurationRecordAPI(cfgName, apiVersion string, httpStatus int) (ok bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
+func DeleteCFSConfigurationRecordAPI(ctx context.Context, cfgName, apiVersion string, httpStatus int) (ok bool) {
+	client, ok := cfsClient(ctx, apiVersion)
+	if !ok {
 		return false
 	}
 
-	url := constructCFSURL("configurations", apiVersion) + "/" + cfgName
-	_, err := VerifyRestStatusWithTenant("DELETE", url, *params, httpStatus)
-
-	if err != nil {
-		common.Error(err)
+	err := client.DeleteConfiguration(ctx, cfgName)
+	if !expectStatus(ctx, err, httpStatus, http.StatusNoContent) {
 		return false
+	} else if err == nil {
+		common.ResourceDeleted(ctx, cfsConfigurationKind, cfgName)
 	}
-
-	ok = true
-	return
+	return true
 }
 
 // CFSConfigurationExists checks if a CFS configuration with the given name exists in the list of configurations
-func CFSConfigurationExists(cfsConfigurations []CFSConfiguration, cfgName string) (ok bool) {
+func CFSConfigurationExists(ctx context.Context, cfsConfigurations []cfsclient.Configuration, cfgName string) (ok bool) {
 	for _, cfsConfig := range cfsConfigurations {
 		if cfsConfig.Name == cfgName {
-			common.Infof("CFS configuration %s was found in the list of configurations", cfgName)
+			common.Infof(ctx, "CFS configuration %s was found in the list of configurations", cfgName)
 			return true
 		}
 	}
-	common.Infof("CFS configuration %s was not found in the list of configurations", cfgName)
+	common.Infof(ctx, "CFS configuration %s was not found in the list of configurations", cfgName)
 	return false
 }
 
 // VerifyCFSConfigurationRecord verifies that the CFS configuration record matches the payload used for create/update operation
-func VerifyCFSConfigurationRecord(cfsConfig CFSConfiguration, cfsPayload, cfgName, apiVersion string) (ok bool) {
+func VerifyCFSConfigurationRecord(ctx context.Context, cfsConfig, cfsPayload cfsclient.Configuration, cfgName string) (ok bool) {
 	ok = true
-	// Verify the CFS configuration record
-	var cfsConfigPayload map[string]interface{}
-	var cfsConfiglayerMap map[string]string
-
-	// Decode the cfs configuration payload into a map[string]interface{}
-	if err := json.Unmarshal([]byte(cfsPayload), &cfsConfigPayload); err != nil {
-		common.Error(err)
-		return false
-	}
-
-	cfsConfigPayloadLayerInterface := cfsConfigPayload["layers"].([]interface{})
-	cfsConfigPayloadLayerInt := cfsConfigPayloadLayerInterface[0].(map[string]interface{})
-
-	// Convert map[string]interface{} to map[string]string
-	cfsConfigPayloadLayerMap := make(map[string]string)
-	for key, value := range cfsConfigPayloadLayerInt {
-		if strValue, ok := value.(string); ok {
-			cfsConfigPayloadLayerMap[key] = strValue
-		} else {
-			common.Warnf("Skipping non-string value for key %s", key)
-		}
-	}
-
-	//Extract cfs configuration layer data from the cfs configuration record
-
-	if len(cfsConfig.Layers) > 0 {
-		// Convert the first layer of cfsConfig to a map[string]string
-		cfsConfiglayerMap = map[string]string{
-			"commit":   cfsConfig.Layers[0].Commit,
-			"playbook": cfsConfig.Layers[0].Playbook,
-			"name":     cfsConfig.Layers[0].Name,
-		}
-
-		if apiVersion == "v3" {
-			cfsConfiglayerMap["clone_url"] = cfsConfig.Layers[0].Clone_url
-		} else {
-			cfsConfiglayerMap["cloneUrl"] = cfsConfig.Layers[0].CloneURL
-		}
-	}
-
-	// Verify the cfs configuration layer data
-	passed := common.CompareMaps(cfsConfigPayloadLayerMap, cfsConfiglayerMap)
-	if !passed {
+	if len(cfsConfig.Layers) != len(cfsPayload.Layers) {
+		common.Errorf(ctx, "CFS configuration layer count mismatch: expected %d, got %d", len(cfsPayload.Layers), len(cfsConfig.Layers))
 		ok = false
-		common.Errorf("CFS configuration layer data mismatch: expected %v, got %v", cfsConfigPayloadLayerMap, cfsConfiglayerMap)
+	} else {
+		// Verify the cfs configuration layer data
+		for i, expected := range cfsPayload.Layers {
+			actual := cfsConfig.Layers[i]
+			if actual.Name != expected.Name || actual.CloneURL != expected.CloneURL || actual.Commit != expected.Commit || actual.Playbook != expected.Playbook {
+				common.Errorf(ctx, "CFS configuration layer data mismatch: expected %+v, got %+v", expected, actual)
+				ok = false
+			}
+		}
 	}
 
 	if cfsConfig.Name != cfgName {
-		common.Errorf("CFS configuration name mismatch: expected %s, got %s", cfgName, cfsConfig.Name)
+		common.Errorf(ctx, "CFS configuration name mismatch: expected %s, got %s", cfgName, cfsConfig.Name)
 		ok = false
 	}
 
 	return
 }
 
-// constructCFSURL constructs the CFS API URL based on the endpoint and API version
-func constructCFSURL(endpoint, apiVersion string) string {
-	base := common.BASEURL + endpoints["cfs"][endpoint].Url
-	if apiVersion != "" {
-		return base + "/" + apiVersion + endpoints["cfs"][endpoint].Uri
+// Kinds of CFS resources, for the cleanup registry
+const cfsConfigurationKind = "CFS configuration"
+const cfsSourceKind = "CFS source"
+
+// Register cleanups for CFS resources created by tests. These are deleted using the v3 API,
+// regardless of how they were created.
+func registerCFSConfigurationCleanup(ctx context.Context, cfgName string) {
+	cfgURL, err := cfsclient.ConfigurationURL(cfgName)
+	if err != nil {
+		common.Failuref(ctx, "%v", err)
 	}
-	return base + endpoints["cfs"][endpoint].Uri
+	test.RegisterAPIDeleteCleanup(ctx, cfsConfigurationKind, cfgName, cfgURL)
 }
 
-// VerifyRestStatusWithTenant is a wrapper function that checks if a tenant name is set and calls the appropriate function
-func VerifyRestStatusWithTenant(method, uri string, params common.Params, expectedStatus int) (*resty.Response, error) {
-	tenantName := common.GetTenantName()
-	// Checking if Tenant name is set or not
-	if len(tenantName) == 0 {
-		return test.RestfulVerifyStatus(method, uri, params, expectedStatus)
-	} else {
-		return test.TenantRestfulVerifyStatus(method, uri, tenantName, params, expectedStatus)
+func registerCFSSourceCleanup(ctx context.Context, sourceName string) {
+	sourceURL, err := cfsclient.SourceURL(sourceName)
+	if err != nil {
+		common.Failuref(ctx, "%v", err)
 	}
+	test.RegisterAPIDeleteCleanup(ctx, cfsSourceKind, sourceName, sourceURL)
 }
 
 // GetTenantFromList gets a random tenant name from the list of tenants
-func GetTenantFromList() string {
-	tenantList, err := k8s.GetTenants()
+func GetTenantFromList(ctx context.Context) string {
+	tenantList, err := k8s.GetTenants(ctx)
 	if err != nil {
-		common.Errorf("Error getting tenant list: %s", err.Error())
+		common.Errorf(ctx, "Error getting tenant list: %s", err.Error())
 		return ""
 	}
 
 	// Set the tenant name to be used in the tests
 	tenantName, err := common.GetRandomStringFromList(tenantList)
 	if err != nil {
-		common.Debugf("Unable to get random tenant from list: %s", err.Error())
+		common.Debugf(ctx, "Unable to get random tenant from list: %s", err.Error())
 		return ""
 	}
-	common.Infof("Using tenant: %s", tenantName)
+	common.Infof(ctx, "Using tenant: %s", tenantName)
 	return tenantName
 }
 
 // GetAnotherTenantFromList gets a random tenant name from the list of tenants excluding the current tenant
-func GetAnotherTenantFromList(currentTenant string) string {
-	tenantList, err := k8s.GetTenants()
+func GetAnotherTenantFromList(ctx context.Context, currentTenant string) string {
+	tenantList, err := k8s.GetTenants(ctx)
 	if err != nil {
-		common.Errorf("Error getting tenant list: %s", err.Error())
+		common.Errorf(ctx, "Error getting tenant list: %s", err.Error())
 		return ""
 	}
 
 	// Set the tenant name to be used in the tests
 	tenantName, err := common.GetRandomStringFromListExcept(tenantList, currentTenant)
 	if err != nil {
-		common.Debugf("Unable to get random tenant from list excluding %s: %s", currentTenant, err.Error())
+		common.Debugf(ctx, "Unable to get random tenant from list excluding %s: %s", currentTenant, err.Error())
 		return ""
 	}
-	common.Infof("Using another tenant: %s", tenantName)
+	common.Infof(ctx, "Using another tenant: %s", tenantName)
 	return tenantName
 }
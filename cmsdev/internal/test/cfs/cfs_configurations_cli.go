@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,125 +29,134 @@
 package cfs
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
 	"strings"
 
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func RunVersionedCFSCommand(cliVersion string, cmdArgs ...string) []byte {
-	common.Infof("Testing CFS CLI (%s %s)", cliVersion, strings.Join(cmdArgs, " "))
+func RunVersionedCFSCommand(ctx context.Context, cliVersion string, cmdArgs ...string) []byte {
+	common.Infof(ctx, "Testing CFS CLI (%s %s)", cliVersion, strings.Join(cmdArgs, " "))
 	if cliVersion == "" {
-		return test.TenantRunCLICommandJSON(common.GetTenantName(), "cfs", cmdArgs...)
+		return test.TenantRunCLICommandJSON(ctx, common.GetTenantName(ctx), "cfs", cmdArgs...)
 	}
 	newArgs := append([]string{cliVersion}, cmdArgs...)
-	return test.TenantRunCLICommandJSON(common.GetTenantName(), "cfs", newArgs...)
+	return test.TenantRunCLICommandJSON(ctx, common.GetTenantName(ctx), "cfs", newArgs...)
 }
 
-func CreateCFSConfigurationFile(cfgName, cliVersion string, addTenant bool) (fileName string, payload string, ok bool) {
+// cliPages returns a PageFunc which lists a CFS collection (such as "configurations") with the
+// CLI. The query parameters for the next page of a CFS v3 list are passed as options: after as
+// --after, and so on.
+func cliPages(ctx context.Context, cliVersion, collection string) cfsclient.PageFunc {
+	return func(query url.Values) ([]byte, error) {
+		cmdArgs := []string{collection, "list"}
+		keys := make([]string, 0, len(query))
+		for key := range query {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			cmdArgs = append(cmdArgs, "--"+strings.ReplaceAll(key, "_", "-"), query.Get(key))
+		}
+		if cmdOut := RunVersionedCFSCommand(ctx, cliVersion, cmdArgs...); cmdOut != nil {
+			return cmdOut, nil
+		}
+		return nil, fmt.Errorf("Unable to list CFS %s via CLI", collection)
+	}
+}
+
+func CreateCFSConfigurationFile(ctx context.Context, cfgName, cliVersion string, addTenant bool) (fileName string, payload cfsclient.Configuration, ok bool) {
 	fileName = "cfs_configuration"
-	payload, success := GetCreateCFGConfigurationPayload(cliVersion, addTenant)
+	payload, success := GetCreateCFGConfigurationPayload(ctx, addTenant)
 	if !success {
-		return "", "", false
+		return "", cfsclient.Configuration{}, false
 	}
 
+	// The layers in the file have clone_url or cloneUrl, depending on the CLI version
+	jsonPayload, err := cfsclient.EncodeConfiguration(versionNumber(cliVersion), payload)
+	if err != nil {
+		common.Error(ctx, err)
+		return "", cfsclient.Configuration{}, false
+	}
+	common.Infof(ctx, "CFS configuration payload: %s", string(jsonPayload))
+
 	dir, err := os.Getwd()
 	if err != nil {
-		common.Errorf("Error getting current directory: %v\n", err)
+		common.Errorf(ctx, "Error getting current directory: %v\n", err)
 		return
 	}
 	fileName = fmt.Sprintf("%s/%s.json", dir, fileName)
 
 	// Write the formatted JSON payload to the file
-	err = os.WriteFile(fileName, []byte(payload), 0644)
+	err = os.WriteFile(fileName, jsonPayload, 0644)
 	if err != nil {
-		common.Errorf("Unable to write payload to file %s: %v", fileName, err)
-		return "", "", false
+		common.Errorf(ctx, "Unable to write payload to file %s: %v", fileName, err)
+		return "", cfsclient.Configuration{}, false
 	}
 
 	return fileName, payload, true
 }
 
-func CreateUpdateCFSConfigurationCLI(cfgName, fileName, cliVersion string) (cfsConfig CFSConfiguration, passed bool) {
-	common.Infof("Creating configuration %s in CFS via CLI", cfgName)
-	if cmdOut := RunVersionedCFSCommand(cliVersion, "configurations", "update", cfgName,
+func CreateUpdateCFSConfigurationCLI(ctx context.Context, cfgName, fileName, cliVersion string) (cfsConfig cfsclient.Configuration, passed bool) {
+	common.Infof(ctx, "Creating configuration %s in CFS via CLI", cfgName)
+	if cmdOut := RunVersionedCFSCommand(ctx, cliVersion, "configurations", "update", cfgName,
 		"--file", fileName); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &cfsConfig); err == nil {
+		registerCFSConfigurationCleanup(ctx, cfgName)
+		common.Infof(ctx, "Decoding JSON in command output")
+		var err error
+		if cfsConfig, err = cfsclient.ParseConfiguration(versionNumber(cliVersion), cmdOut); err == nil {
 			passed = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	// if the tenant is a dummy tenant, we expect the command to fail
-	if common.IsDummyTenant(common.GetTenantName()) {
-		return CFSConfiguration{}, true
+	if common.IsDummyTenant(common.GetTenantName(ctx)) {
+		return cfsclient.Configuration{}, true
 	}
 	return
 }
 
-func GetCFSConfigurationRecordCLI(cfgName, cliVersion string) (cfsConfig CFSConfiguration, passed bool) {
-	common.Infof("Getting configuration %s in CFS via CLI", cfgName)
-	if cmdOut := RunVersionedCFSCommand(cliVersion, "configurations", "describe", cfgName); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &cfsConfig); err == nil {
+func GetCFSConfigurationRecordCLI(ctx context.Context, cfgName, cliVersion string) (cfsConfig cfsclient.Configuration, passed bool) {
+	common.Infof(ctx, "Getting configuration %s in CFS via CLI", cfgName)
+	if cmdOut := RunVersionedCFSCommand(ctx, cliVersion, "configurations", "describe", cfgName); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
+		var err error
+		if cfsConfig, err = cfsclient.ParseConfiguration(versionNumber(cliVersion), cmdOut); err == nil {
 			passed = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func GetCFSConfigurationsListCLI(cliVersion string) (cfsConfigList CFSConfigurationsList, passed bool) {
-	common.Infof("Getting CFS configurations list via CLI using cli version %s", cliVersion)
-	if cmdOut := RunVersionedCFSCommand(cliVersion, "configurations", "list"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &cfsConfigList); err == nil {
-			passed = true
-		} else {
-			common.Error(err)
-		}
+// GetCFSConfigurationsListCLI gets the list of CFS configurations via CLI. With v3, every page of
+// the list is listed.
+func GetCFSConfigurationsListCLI(ctx context.Context, cliVersion string) (cfsConfigurations []cfsclient.Configuration, passed bool) {
+	common.Infof(ctx, "Getting CFS configurations list via CLI using cli version %s", cliVersion)
+	it := cfsclient.NewConfigurationIterator(versionNumber(cliVersion), nil, cliPages(ctx, cliVersion, "configurations"))
+	for it.Next() {
+		cfsConfigurations = append(cfsConfigurations, it.Configuration())
 	}
-	return
-}
-
-func GetCFSConfigurationsListCLIV2(cliVersion string) (cfsConfigList []CFSConfiguration, passed bool) {
-	common.Infof("Getting CFS configurations list via CLI using cli version %s", cliVersion)
-	if cmdOut := RunVersionedCFSCommand(cliVersion, "configurations", "list"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &cfsConfigList); err == nil {
-			passed = true
-		} else {
-			common.Error(err)
-		}
-	}
-	return
-}
-
-func GetCLIVersionBasedCFSConfigurationRecordList(cliVersion string) (cfsConfigurations []CFSConfiguration, passed bool) {
-	// Get the CFS configurations list based on the API version. The response will be different for v2 and v3.
-	// For v3, the response will be a list of CFSConfigurationsList, while for v2, it will be a CFSConfigurations struct.
-	if cliVersion == "v3" {
-		cfsConfigV3, ok := GetCFSConfigurationsListCLI(cliVersion)
-		if !ok {
-			return []CFSConfiguration{}, false
-		}
-		cfsConfigurations = cfsConfigV3.Configurations
-	} else {
-		cfsConfigV2, ok := GetCFSConfigurationsListCLIV2(cliVersion)
-		if !ok {
-			return []CFSConfiguration{}, false
-		}
-		cfsConfigurations = cfsConfigV2
+	if err := it.Err(); err != nil {
+		common.Error(ctx, err)
+		return []cfsclient.Configuration{}, false
 	}
 	return cfsConfigurations, true
 }
 
-func DeleteCFSConfigurationRecordCLI(cfgName, cliVersion string) (passed bool) {
-	common.Infof("Deleting configuration %s in CFS via CLI", cfgName)
-	return RunVersionedCFSCommand(cliVersion, "configurations", "delete", cfgName) != nil
+func DeleteCFSConfigurationRecordCLI(ctx context.Context, cfgName, cliVersion string) (passed bool) {
+	common.Infof(ctx, "Deleting configuration %s in CFS via CLI", cfgName)
+	if RunVersionedCFSCommand(ctx, cliVersion, "configurations", "delete", cfgName) == nil {
+		return false
+	}
+	common.ResourceDeleted(ctx, cfsConfigurationKind, cfgName)
+	return true
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,11 +28,7 @@
  */
 package cfs
 
-import (
-	"net/http"
-
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
-)
+import "net/http"
 
 var DEFAULT_PLAYBOOK = "compute_nodes.yml"
 var EXPECTED_CFS_CREATE_HTTP_STATUS = http.StatusOK
@@ -43,23 +39,6 @@ var EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS = http.StatusBadRequest
 var EXPECTED_CFS_NOT_FOUND_HTTP_STATUS = http.StatusNotFound
 var EXPECTED_CFS_FORBIDDEN_HTTP_STATUS = http.StatusForbidden
 
-type CfsLayer struct {
-	Clone_url string `json:"clone_url,omitempty"`
-	CloneURL  string `json:"cloneUrl,omitempty"`
-	Commit    string `json:"commit"`
-	Name      string `json:"name"`
-	Playbook  string `json:"playbook"`
-}
-
-type CFSConfiguration struct {
-	Layers []CfsLayer `json:"layers"`
-	Name   string     `json:"name"`
-}
-
-type CFSConfigurationsList struct {
-	Configurations []CFSConfiguration `json:"configurations"`
-}
-
 type CsmProductCatalogConfiguration struct {
 	Clone_url     string `json:"clone_url"`
 	Commit        string `json:"commit"`
@@ -67,21 +46,3 @@ type CsmProductCatalogConfiguration struct {
 	Import_Date   string `json:"import_date"`
 	Ssh_url       string `json:"ssh_url"`
 }
-
-type CFSSourceCredentials struct {
-	Authentication_method string `json:"authentication_method"`
-	Secret_name           string `json:"secret_name"`
-}
-
-type CFSSources struct {
-	Clone_url   string               `json:"clone_url"`
-	Name        string               `json:"name"`
-	Credentials CFSSourceCredentials `json:"credentials"`
-}
-
-type CFSSourcesList struct {
-	Sources []CFSSources `json:"sources"`
-}
-
-// CMS service endpoints
-var endpoints map[string]map[string]*common.Endpoint = common.GetEndpoints()
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,195 +29,138 @@
 package cfs
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
 	"net/http"
 
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
-	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-var firstCloneURL = "https://api-gw-service-nmn.local/vcs/cray/csm-config-management.git"
-var secondCloneURL = "https://api-gw-service-nmn.local/vcs/cray/csm-product-catalog.git"
+// Clone URLs of the sources, in VCS behind the configured API gateway
+func firstCloneURL() string  { return common.BASEURL + "/vcs/cray/csm-config-management.git" }
+func secondCloneURL() string { return common.BASEURL + "/vcs/cray/csm-product-catalog.git" }
 
-func CreateCFSSourceRecordAPI(sourceName string) (cfsSourceRecord CFSSources, passed bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return CFSSources{}, false
+func CreateCFSSourceRecordAPI(ctx context.Context, sourceName string) (cfsSourceRecord cfsclient.Source, passed bool) {
+	client, ok := cfsClient(ctx, "v3")
+	if !ok {
+		return cfsclient.Source{}, false
 	}
 
 	// Create CFS source payload
-	payload := map[string]interface{}{
-		"name":      sourceName,
-		"clone_url": firstCloneURL,
-		"credentials": map[string]string{
-			"username":              "testuser",
-			"password":              "testpassword",
-			"authentication_method": "password",
+	payload := cfsclient.Source{
+		Name:     sourceName,
+		CloneURL: firstCloneURL(),
+		Credentials: &cfsclient.SourceCredentials{
+			Username:             "testuser",
+			Password:             "testpassword",
+			AuthenticationMethod: "password",
 		},
 	}
+	common.Infof(ctx, "Creating CFS source %s with clone_url %s", sourceName, firstCloneURL())
 
-	jsonPayload, err := json.Marshal(payload)
+	cfsSourceRecord, err := client.CreateSource(ctx, payload)
 	if err != nil {
-		common.Error(err)
-		return CFSSources{}, false
-	}
-
-	params.JsonStr = string(jsonPayload)
-	common.Infof("CFS configuration payload: %s", string(jsonPayload))
-
-	url := common.BASEURL + endpoints["cfs"]["sources"].Url + "/" + endpoints["cfs"]["sources"].Version + endpoints["cfs"]["sources"].Uri
-	resp, err := VerifyRestStatusWithTenant("POST", url, *params, http.StatusCreated)
-
-	if err != nil {
-		common.Error(err)
-		return CFSSources{}, false
-	}
-
-	// Decoding the response body into the CFSConfiguration struct
-	if err := json.Unmarshal(resp.Body(), &cfsSourceRecord); err != nil {
-		common.Error(err)
-		return CFSSources{}, false
+		common.Error(ctx, err)
+		return cfsclient.Source{}, false
 	}
+	registerCFSSourceCleanup(ctx, sourceName)
 
 	passed = true
 	return
 }
 
-func UpdateCFSSourceRecordAPI(sourceName string) (cfsSourceRecord CFSSources, passed bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return CFSSources{}, false
+func UpdateCFSSourceRecordAPI(ctx context.Context, sourceName string) (cfsSourceRecord cfsclient.Source, passed bool) {
+	client, ok := cfsClient(ctx, "v3")
+	if !ok {
+		return cfsclient.Source{}, false
 	}
 
-	// Create CFS source payload
-	payload := map[string]string{
-		"clone_url": secondCloneURL,
-	}
-
-	jsonPayload, err := json.Marshal(payload)
+	// Update only the clone_url of the source
+	common.Infof(ctx, "Updating CFS source %s with clone_url %s", sourceName, secondCloneURL())
+	cfsSourceRecord, err := client.UpdateSource(ctx, sourceName, cfsclient.Source{CloneURL: secondCloneURL()})
 	if err != nil {
-		common.Error(err)
-		return CFSSources{}, false
-	}
-
-	params.JsonStrArray = jsonPayload
-	common.Infof("CFS configuration payload: %s", string(jsonPayload))
-
-	url := common.BASEURL + endpoints["cfs"]["sources"].Url + "/" + endpoints["cfs"]["sources"].Version + endpoints["cfs"]["sources"].Uri + "/" + sourceName
-	resp, err := VerifyRestStatusWithTenant("PATCH", url, *params, http.StatusOK)
-
-	if err != nil {
-		common.Error(err)
-		return CFSSources{}, false
-	}
-
-	// Decoding the response body into the CFSConfiguration struct
-	if err := json.Unmarshal(resp.Body(), &cfsSourceRecord); err != nil {
-		common.Error(err)
-		return CFSSources{}, false
+		common.Error(ctx, err)
+		return cfsclient.Source{}, false
 	}
 
 	passed = true
 	return
 }
 
-func DeleteCFSSourceRecordAPI(sourceName string) (passed bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
+func DeleteCFSSourceRecordAPI(ctx context.Context, sourceName string) (passed bool) {
+	client, ok := cfsClient(ctx, "v3")
+	if !ok {
 		return false
 	}
 
-	url := common.BASEURL + endpoints["cfs"]["sources"].Url + "/" + endpoints["cfs"]["sources"].Version + endpoints["cfs"]["sources"].Uri + "/" + sourceName
-	_, err := VerifyRestStatusWithTenant("DELETE", url, *params, http.StatusNoContent)
-
-	if err != nil {
-		common.Error(err)
+	if err := client.DeleteSource(ctx, sourceName); err != nil {
+		common.Error(ctx, err)
 		return false
 	}
 
+	common.ResourceDeleted(ctx, cfsSourceKind, sourceName)
 	return true
 }
 
-func GetCFSSourceRecordAPI(sourceName string, httpStatus int) (cfsSourceRecord CFSSources, passed bool) {
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return CFSSources{}, false
+func GetCFSSourceRecordAPI(ctx context.Context, sourceName string, httpStatus int) (cfsSourceRecord cfsclient.Source, passed bool) {
+	client, ok := cfsClient(ctx, "v3")
+	if !ok {
+		return cfsclient.Source{}, false
 	}
 
-	url := common.BASEURL + endpoints["cfs"]["sources"].Url + "/" + endpoints["cfs"]["sources"].Version + endpoints["cfs"]["sources"].Uri + "/" + sourceName
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, httpStatus)
-
-	if err != nil {
-		common.Error(err)
-		return CFSSources{}, false
-	}
-
-	// Decoding the response body into the CFSConfiguration struct
-	if err := json.Unmarshal(resp.Body(), &cfsSourceRecord); err != nil {
-		common.Error(err)
-		return CFSSources{}, false
+	cfsSourceRecord, err := client.GetSource(ctx, sourceName)
+	if !expectStatus(ctx, err, httpStatus, http.StatusOK) {
+		return cfsclient.Source{}, false
 	}
 
 	passed = true
 	return
 }
 
-func GetCFSSourcesListAPI() (cfsSources []CFSSources, passed bool) {
-	var cfsSourcesList CFSSourcesList
-	params := test.GetAccessTokenParams()
-	if params == nil {
-		common.Error(fmt.Errorf("Unable to get access token params"))
-		return []CFSSources{}, false
+// GetCFSSourcesListAPI gets every CFS source, from every page of the list
+func GetCFSSourcesListAPI(ctx context.Context) (cfsSources []cfsclient.Source, passed bool) {
+	client, ok := cfsClient(ctx, "v3")
+	if !ok {
+		return []cfsclient.Source{}, false
 	}
 
-	url := common.BASEURL + endpoints["cfs"]["sources"].Url + "/" + endpoints["cfs"]["sources"].Version + endpoints["cfs"]["sources"].Uri
-	resp, err := VerifyRestStatusWithTenant("GET", url, *params, http.StatusOK)
-
+	cfsSources, err := client.ListSources(ctx, nil)
 	if err != nil {
-		common.Error(err)
-		return []CFSSources{}, false
+		common.Error(ctx, err)
+		return []cfsclient.Source{}, false
 	}
 
-	// Decoding the response body into the CFSConfiguration struct
-	if err := json.Unmarshal(resp.Body(), &cfsSourcesList); err != nil {
-		common.Error(err)
-		return []CFSSources{}, false
-	}
-
-	cfsSources = cfsSourcesList.Sources
 	passed = true
 	return
 }
 
-func CFSSourceExists(cfsSourcesList []CFSSources, sourceName string) (passed bool) {
+func CFSSourceExists(ctx context.Context, cfsSourcesList []cfsclient.Source, sourceName string) (passed bool) {
 	passed = false
 	for _, source := range cfsSourcesList {
 		if source.Name == sourceName {
-			common.Infof("CFS source %s found in the list", sourceName)
+			common.Infof(ctx, "CFS source %s found in the list", sourceName)
 			return true
 		}
 	}
-	common.Infof("CFS source %s not found in the list", sourceName)
+	common.Infof(ctx, "CFS source %s not found in the list", sourceName)
 	return false
 }
 
-func VerifyCFSSourceRecord(cfsSourceRecord CFSSources, sourceName, cloneUrl string) (passed bool) {
+func VerifyCFSSourceRecord(ctx context.Context, cfsSourceRecord cfsclient.Source, sourceName, cloneUrl string) (passed bool) {
 	passed = true
 	if cfsSourceRecord.Name != sourceName {
-		common.Errorf("CFS source name mismatch: expected %s, found %s", sourceName, cfsSourceRecord.Name)
+		common.Errorf(ctx, "CFS source name mismatch: expected %s, found %s", sourceName, cfsSourceRecord.Name)
 		passed = false
 	}
-	if cfsSourceRecord.Clone_url != cloneUrl {
-		common.Errorf("CFS source clone_url mismatch: expected %s, found %s", cloneUrl, cfsSourceRecord.Clone_url)
+	if cfsSourceRecord.CloneURL != cloneUrl {
+		common.Errorf(ctx, "CFS source clone_url mismatch: expected %s, found %s", cloneUrl, cfsSourceRecord.CloneURL)
 		passed = false
 	}
-	if cfsSourceRecord.Credentials.Authentication_method != "password" {
-		common.Errorf("CFS source authentication method mismatch: expected password, found %s", cfsSourceRecord.Credentials.Authentication_method)
+	if cfsSourceRecord.Credentials == nil {
+		common.Errorf(ctx, "CFS source has no credentials")
+		passed = false
+	} else if cfsSourceRecord.Credentials.AuthenticationMethod != "password" {
+		common.Errorf(ctx, "CFS source authentication method mismatch: expected password, found %s", cfsSourceRecord.Credentials.AuthenticationMethod)
 		passed = false
 	}
 	return passed
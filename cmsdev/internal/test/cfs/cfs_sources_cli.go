@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,67 +29,75 @@
 package cfs
 
 import (
-	"encoding/json"
-
+	"context"
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func CreateCFSSourceRecordCLI(sourceName, cloneURL, cliVersion string) (cfsSourceRecord CFSSources, passed bool) {
-	common.Infof("Creating source %s in CFS via CLI", sourceName)
-	if cmdOut := RunVersionedCFSCommand(cliVersion, "sources", "create", "--name", sourceName,
+func CreateCFSSourceRecordCLI(ctx context.Context, sourceName, cloneURL, cliVersion string) (cfsSourceRecord cfsclient.Source, passed bool) {
+	common.Infof(ctx, "Creating source %s in CFS via CLI", sourceName)
+	if cmdOut := RunVersionedCFSCommand(ctx, cliVersion, "sources", "create", "--name", sourceName,
 		"--clone-url", cloneURL, "--credentials-username", "user", "--credentials-password", "pass"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &cfsSourceRecord); err == nil {
+		registerCFSSourceCleanup(ctx, sourceName)
+		common.Infof(ctx, "Decoding JSON in command output")
+		var err error
+		if cfsSourceRecord, err = cfsclient.ParseSource(cmdOut); err == nil {
 			passed = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func GetCFSSourceRecordCLI(sourceName, cliVersion string) (cfsSourceRecord CFSSources, passed bool) {
-	common.Infof("Getting source %s in CFS via CLI", sourceName)
-	if cmdOut := RunVersionedCFSCommand(cliVersion, "sources", "describe", sourceName); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &cfsSourceRecord); err == nil {
+func GetCFSSourceRecordCLI(ctx context.Context, sourceName, cliVersion string) (cfsSourceRecord cfsclient.Source, passed bool) {
+	common.Infof(ctx, "Getting source %s in CFS via CLI", sourceName)
+	if cmdOut := RunVersionedCFSCommand(ctx, cliVersion, "sources", "describe", sourceName); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
+		var err error
+		if cfsSourceRecord, err = cfsclient.ParseSource(cmdOut); err == nil {
 			passed = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func GetCFSSourcesListCLI(cliVersion string) (cfsSourceRecords []CFSSources, passed bool) {
-	var cfsSourcesList CFSSourcesList
-	common.Infof("Getting all source in CFS via CLI")
-	if cmdOut := RunVersionedCFSCommand(cliVersion, "sources", "list"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &cfsSourcesList); err == nil {
-			passed = true
-		} else {
-			common.Error(err)
-		}
+// GetCFSSourcesListCLI gets every CFS source via CLI, from every page of the list
+func GetCFSSourcesListCLI(ctx context.Context, cliVersion string) (cfsSourceRecords []cfsclient.Source, passed bool) {
+	common.Infof(ctx, "Getting all source in CFS via CLI")
+	it := cfsclient.NewSourceIterator(nil, cliPages(ctx, cliVersion, "sources"))
+	for it.Next() {
+		cfsSourceRecords = append(cfsSourceRecords, it.Source())
+	}
+	if err := it.Err(); err != nil {
+		common.Error(ctx, err)
+		return nil, false
 	}
-	return cfsSourcesList.Sources, passed
+	return cfsSourceRecords, true
 }
 
-func UpdateCFSSourceRecordCLI(sourceName, cloneURL, cliVersion string) (cfsSourceRecord CFSSources, passed bool) {
-	common.Infof("Updating source %s in CFS via CLI", sourceName)
-	if cmdOut := RunVersionedCFSCommand(cliVersion, "sources", "update", sourceName,
+func UpdateCFSSourceRecordCLI(ctx context.Context, sourceName, cloneURL, cliVersion string) (cfsSourceRecord cfsclient.Source, passed bool) {
+	common.Infof(ctx, "Updating source %s in CFS via CLI", sourceName)
+	if cmdOut := RunVersionedCFSCommand(ctx, cliVersion, "sources", "update", sourceName,
 		"--clone-url", cloneURL); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
-		if err := json.Unmarshal(cmdOut, &cfsSourceRecord); err == nil {
+		common.Infof(ctx, "Decoding JSON in command output")
+		var err error
+		if cfsSourceRecord, err = cfsclient.ParseSource(cmdOut); err == nil {
 			passed = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func DeleteCFSSourceRecordCLI(sourceName, cliVersion string) (passed bool) {
-	common.Infof("Deleting source %s in CFS via CLI", sourceName)
-	return RunVersionedCFSCommand(cliVersion, "sources", "delete", sourceName) != nil
+func DeleteCFSSourceRecordCLI(ctx context.Context, sourceName, cliVersion string) (passed bool) {
+	common.Infof(ctx, "Deleting source %s in CFS via CLI", sourceName)
+	if RunVersionedCFSCommand(ctx, cliVersion, "sources", "delete", sourceName) == nil {
+		return false
+	}
+	common.ResourceDeleted(ctx, cfsSourceKind, sourceName)
+	return true
 }
@@ -0,0 +1,106 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * leftovers.go
+ *
+ * Finding CFS configurations and sources left behind by earlier test runs
+ *
+ */
+
+package cfs
+
+import (
+	"net/http"
+	"regexp"
+
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// The names generated by the CFS and BOS tests for the configurations and sources they create
+var cfsConfigurationNamePattern = regexp.MustCompile(`^CFS_Configuration_[a-z]{10}$`)
+var cfsSourceNamePattern = regexp.MustCompile(`^CFS_Source_[a-z]{10}$`)
+
+// Find the CFS configurations (for every tenant) and sources created by cmsdev
+func findCFSLeftovers(opts registry.CleanupOptions) (leftovers []registry.Leftover, ok bool) {
+	ctx := opts.Context
+	// Configuration names are unique across tenants. A configuration which is listed both
+	// without a tenant and for its tenant is recorded as belonging to that tenant.
+	cfgIndex := make(map[string]int)
+
+	tenants, ok := test.GetTenantsAndNone(ctx)
+	defer common.SetTenantName(ctx, "")
+	for _, tenant := range tenants {
+		common.SetTenantName(ctx, tenant)
+		cfgList, listed := GetCFSConfigurationsListAPI(ctx, "v3", http.StatusOK)
+		if !listed {
+			ok = false
+			continue
+		}
+		for _, cfg := range cfgList {
+			if !cfsConfigurationNamePattern.MatchString(cfg.Name) {
+				continue
+			}
+			url, err := cfsclient.ConfigurationURL(cfg.Name)
+			if err != nil {
+				common.Warnf(ctx, "%v", err)
+				ok = false
+				continue
+			}
+			leftover := registry.Leftover{
+				Kind: cfsConfigurationKind, Name: cfg.Name, Tenant: tenant,
+				Delete: func() bool { return test.DeleteURLs(ctx, url) },
+			}
+			if i, found := cfgIndex[cfg.Name]; found {
+				leftovers[i] = leftover
+			} else {
+				cfgIndex[cfg.Name] = len(leftovers)
+				leftovers = append(leftovers, leftover)
+			}
+		}
+	}
+
+	// Sources do not belong to tenants
+	common.SetTenantName(ctx, "")
+	if sourceList, listed := GetCFSSourcesListAPI(ctx); !listed {
+		ok = false
+	} else {
+		for _, source := range sourceList {
+			if cfsSourceNamePattern.MatchString(source.Name) {
+				url, err := cfsclient.SourceURL(source.Name)
+				if err != nil {
+					common.Warnf(ctx, "%v", err)
+					ok = false
+					continue
+				}
+				leftovers = append(leftovers, registry.Leftover{
+					Kind: cfsSourceKind, Name: source.Name,
+					Delete: func() bool { return test.DeleteURLs(ctx, url) },
+				})
+			}
+		}
+	}
+	return
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,38 +29,42 @@
 package cfs
 
 import (
+	"context"
 	"fmt"
 
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func TestCFSConfigurationsCRUDOperationWithTenantsUsingAPIVersions() (passed bool) {
-	passed = TestCFSConfigurationsCRUDOperationUsingAPIVersions()
+func TestCFSConfigurationsCRUDOperationWithTenantsUsingAPIVersions(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationsCRUDOperationWithTenantsUsingAPIVersions").End(ctx, &passed)
+	passed = TestCFSConfigurationsCRUDOperationUsingAPIVersions(ctx)
 	tenantList := []string{}
 	dummyTenant := common.GetDummyTenantName()
 	tenantList = append(tenantList, dummyTenant)
 	// Get an actual tenant
-	tenantName := GetTenantFromList()
+	tenantName := GetTenantFromList(ctx)
 	if len(tenantName) > 0 {
 		tenantList = append(tenantList, tenantName)
 	}
 	for _, tenant := range tenantList {
-		common.SetTenantName(tenant)
-		result := TestCFSConfigurationsCRUDOperationUsingAPIVersions()
+		common.SetTenantName(ctx, tenant)
+		result := TestCFSConfigurationsCRUDOperationUsingAPIVersions(ctx)
 		passed = passed && result
-		common.SetTenantName("")
+		common.SetTenantName(ctx, "")
 	}
 	return passed
 
 }
 
-func TestCFSConfigurationsCRUDOperationUsingAPIVersions() (passed bool) {
+func TestCFSConfigurationsCRUDOperationUsingAPIVersions(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationsCRUDOperationUsingAPIVersions").End(ctx, &passed)
 	passed = true
 	// Get supported API versions for configurations endpoints
 	for _, apiVersion := range GetSupportAPIVersions("configurations") {
-		if common.GetTenantName() == "" || apiVersion != "v2" {
-			common.PrintLog(fmt.Sprintf("Testing CFS configurations CRUD operations using API version: %s", apiVersion))
-			result := TestCFSConfigurationsCRUDOperation(apiVersion)
+		if common.GetTenantName(ctx) == "" || apiVersion != "v2" {
+			common.PrintLog(ctx, fmt.Sprintf("Testing CFS configurations CRUD operations using API version: %s", apiVersion))
+			result := TestCFSConfigurationsCRUDOperation(ctx, apiVersion)
 			passed = passed && result
 		}
 	}
@@ -68,59 +72,60 @@ func TestCFSConfigurationsCRUDOperationUsingAPIVersions() (passed bool) {
 	return passed
 }
 
-func TestCFSConfigurationsCRUDOperation(apiVersion string) (passed bool) {
+func TestCFSConfigurationsCRUDOperation(ctx context.Context, apiVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationsCRUDOperation").End(ctx, &passed)
 	passed = true
 	expectedCreateHttpStatus := EXPECTED_CFS_CREATE_HTTP_STATUS
-	tenantName := common.GetTenantName()
+	tenantName := common.GetTenantName(ctx)
 	if len(tenantName) != 0 {
-		common.PrintLog(fmt.Sprintf("Running CFS configurations tests with tenant: %s", tenantName))
+		common.PrintLog(ctx, fmt.Sprintf("Running CFS configurations tests with tenant: %s", tenantName))
 		if common.IsDummyTenant(tenantName) {
 			expectedCreateHttpStatus = EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS
 		}
 	} else {
-		common.PrintLog("Running CFS configurations tests without tenant")
+		common.PrintLog(ctx, "Running CFS configurations tests without tenant")
 	}
-	cfsConfigurationRecord, success := TestCFSConfigurationCreate(apiVersion, expectedCreateHttpStatus)
+	cfsConfigurationRecord, success := TestCFSConfigurationCreate(ctx, apiVersion, expectedCreateHttpStatus)
 	if !success {
 		return false
 	}
 
 	// Test Update, Delete, and Get with dummy tenant after creating with admin
 	if len(cfsConfigurationRecord.Name) == 0 && apiVersion != "v2" {
-		result := TestCFSConfigurationsCRUDOperationWithDummyTenant(apiVersion)
+		result := TestCFSConfigurationsCRUDOperationWithDummyTenant(ctx, apiVersion)
 		passed = passed && result
 	}
 
-	currentTenant := common.GetTenantName()
+	currentTenant := common.GetTenantName(ctx)
 	if len(cfsConfigurationRecord.Name) != 0 && !common.IsDummyTenant(currentTenant) && currentTenant != "" {
-		NewTenant := GetAnotherTenantFromList(currentTenant)
+		NewTenant := GetAnotherTenantFromList(ctx, currentTenant)
 		if len(NewTenant) != 0 {
 			// Verify that the system admin is able to create a configuration of the same name, but belonging to tenant B
-			createdWithAdmin := TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(apiVersion, cfsConfigurationRecord.Name, NewTenant, EXPECTED_CFS_CREATE_HTTP_STATUS)
+			createdWithAdmin := TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(ctx, apiVersion, cfsConfigurationRecord.Name, NewTenant, EXPECTED_CFS_CREATE_HTTP_STATUS)
 			// Verify that the system admin is able to create a configuration of the same name, but that belongs to no tenant
-			createdWithNoTenant := TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(apiVersion, cfsConfigurationRecord.Name, "", EXPECTED_CFS_CREATE_HTTP_STATUS)
+			createdWithNoTenant := TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(ctx, apiVersion, cfsConfigurationRecord.Name, "", EXPECTED_CFS_CREATE_HTTP_STATUS)
 			// Verify that the system admin is able to create a configuration of the same name, but belonging to tenant A
-			createdWithOldTenant := TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(apiVersion, cfsConfigurationRecord.Name, currentTenant, EXPECTED_CFS_CREATE_HTTP_STATUS)
+			createdWithOldTenant := TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(ctx, apiVersion, cfsConfigurationRecord.Name, currentTenant, EXPECTED_CFS_CREATE_HTTP_STATUS)
 			passed = passed && createdWithAdmin && createdWithNoTenant && createdWithOldTenant
 			// Seeting the tenant back to the original tenant
-			common.SetTenantName(currentTenant)
+			common.SetTenantName(ctx, currentTenant)
 		}
 	}
 
 	// Testing CFS configuration CRUD operation that does not belong to the same tenant
-	if len(cfsConfigurationRecord.Name) != 0 && !common.IsDummyTenant(common.GetTenantName()) && apiVersion != "v2" {
-		notCreatedWithTenant := TestCFSConfigurationCreateWithSameNameDifferentTenant(apiVersion, cfsConfigurationRecord.Name, EXPECTED_CFS_FORBIDDEN_HTTP_STATUS)
-		notUpdatedWithTenant := TestCFSConfigurationUpdatewithDifferentTenant(apiVersion, cfsConfigurationRecord.Name, EXPECTED_CFS_FORBIDDEN_HTTP_STATUS)
-		notDeletedWithTenant := TestCFSConfigurationDeleteUsingDifferentTenant(apiVersion, cfsConfigurationRecord.Name, EXPECTED_CFS_FORBIDDEN_HTTP_STATUS)
+	if len(cfsConfigurationRecord.Name) != 0 && !common.IsDummyTenant(common.GetTenantName(ctx)) && apiVersion != "v2" {
+		notCreatedWithTenant := TestCFSConfigurationCreateWithSameNameDifferentTenant(ctx, apiVersion, cfsConfigurationRecord.Name, EXPECTED_CFS_FORBIDDEN_HTTP_STATUS)
+		notUpdatedWithTenant := TestCFSConfigurationUpdatewithDifferentTenant(ctx, apiVersion, cfsConfigurationRecord.Name, EXPECTED_CFS_FORBIDDEN_HTTP_STATUS)
+		notDeletedWithTenant := TestCFSConfigurationDeleteUsingDifferentTenant(ctx, apiVersion, cfsConfigurationRecord.Name, EXPECTED_CFS_FORBIDDEN_HTTP_STATUS)
 		passed = passed && notCreatedWithTenant && notUpdatedWithTenant && notDeletedWithTenant
 	}
 
 	if len(cfsConfigurationRecord.Name) != 0 {
-		updated := TestCFSConfigurationUpdate(cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_UPDATE_HTTP_STATUS)
+		updated := TestCFSConfigurationUpdate(ctx, cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_UPDATE_HTTP_STATUS)
 
-		deleted := TestCFSConfigurationDelete(cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_DELETE_HTTP_STATUS)
+		deleted := TestCFSConfigurationDelete(ctx, cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_DELETE_HTTP_STATUS)
 
-		getAll := TestCFSConfigurationGetAll(apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
+		getAll := TestCFSConfigurationGetAll(ctx, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
 
 		return passed && updated && deleted && getAll
 	}
@@ -131,53 +136,54 @@ func TestCFSConfigurationsCRUDOperation(apiVersion string) (passed bool) {
 // after creating the configuration with admin (no tenant).
 // It takes apiVersion as parameter and returns true if all tests pass otherwise false.
 // Note: This test is not run for v2 as v2 does not support tenant in the payload.
-func TestCFSConfigurationsCRUDOperationWithDummyTenant(apiVersion string) (passed bool) {
+func TestCFSConfigurationsCRUDOperationWithDummyTenant(ctx context.Context, apiVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationsCRUDOperationWithDummyTenant").End(ctx, &passed)
 	passed = true
-	existingTenant := common.GetTenantName()
+	existingTenant := common.GetTenantName(ctx)
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
-	common.PrintLog(fmt.Sprintf("Running CFS configurations update, Delete tests with dummy tenant: %s after creating with Admin.", common.GetTenantName()))
+	common.PrintLog(ctx, fmt.Sprintf("Running CFS configurations update, Delete tests with dummy tenant: %s after creating with Admin.", common.GetTenantName(ctx)))
 
 	// get CFS configuration payload with tenane_name set in the payload
-	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(apiVersion, true)
+	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(ctx, true)
 	if !success {
-		common.Infof("Unable to get CFS configuration payload, skippinhg the test.")
+		common.Infof(ctx, "Unable to get CFS configuration payload, skippinhg the test.")
 		return true
 	}
 
 	// Unsetting the tenant name to create a configuration that belongs to the new tenant using admin
-	common.SetTenantName("")
+	common.SetTenantName(ctx, "")
 
 	// Attempt to create CFS configuration with the same name in a different tenant
-	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationRecordAPI(cfgName, apiVersion, cfsConfigurationPayload, EXPECTED_CFS_CREATE_HTTP_STATUS)
+	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, cfsConfigurationPayload, EXPECTED_CFS_CREATE_HTTP_STATUS)
 	if !success {
-		common.Errorf("CFS configuration %s not successfully created with Admin: %s, skipping other tests.", cfgName, common.GetTenantName())
+		common.Errorf(ctx, "CFS configuration %s not successfully created with Admin: %s, skipping other tests.", cfgName, common.GetTenantName(ctx))
 		return false
 	}
 
-	common.SetTenantName(existingTenant)
+	common.SetTenantName(ctx, existingTenant)
 
 	// Run CRUD operations with dummy tenant
-	common.PrintLog(fmt.Sprintf("Running GET CFS configuration: %s test", cfsConfigurationRecord.Name))
-	_, getFailed := GetCFSConfigurationRecordAPI(cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS)
+	common.PrintLog(ctx, fmt.Sprintf("Running GET CFS configuration: %s test", cfsConfigurationRecord.Name))
+	_, getFailed := GetCFSConfigurationRecordAPI(ctx, cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS)
 
-	getAllFailed := TestCFSConfigurationGetAll(apiVersion, EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS)
+	getAllFailed := TestCFSConfigurationGetAll(ctx, apiVersion, EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS)
 
-	notUpdated := TestCFSConfigurationUpdate(cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS)
+	notUpdated := TestCFSConfigurationUpdate(ctx, cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS)
 
-	notDeleted := TestCFSConfigurationDelete(cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS)
+	notDeleted := TestCFSConfigurationDelete(ctx, cfsConfigurationRecord.Name, apiVersion, EXPECTED_CFS_BAD_REQUEST_HTTP_STATUS)
 
 	// Delete the configuration using admin
-	common.SetTenantName("")
-	common.Infof("Deleting CFS configuration %s using Admin.", cfsConfigurationRecord.Name)
-	success = DeleteCFSConfigurationRecordAPI(cfgName, apiVersion, EXPECTED_CFS_DELETE_HTTP_STATUS)
+	common.SetTenantName(ctx, "")
+	common.Infof(ctx, "Deleting CFS configuration %s using Admin.", cfsConfigurationRecord.Name)
+	success = DeleteCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, EXPECTED_CFS_DELETE_HTTP_STATUS)
 	if !success {
-		common.Infof("CFS configuration %s not successfully deleted with Admin: %s", cfgName)
+		common.Infof(ctx, "CFS configuration %s not successfully deleted with Admin", cfgName)
 	}
 
 	passed = passed && notUpdated && notDeleted && getFailed && getAllFailed && success
 
 	// Setting the tenant back to the original tenant
-	common.SetTenantName(existingTenant)
+	common.SetTenantName(ctx, existingTenant)
 
 	return
 }
@@ -190,62 +196,63 @@ func TestCFSConfigurationsCRUDOperationWithDummyTenant(apiVersion string) (passe
 //   - The created configuration can be retrieved using GET operation
 //   - The created configuration is present in the list of configurations
 //   - The created configuration matches the payload used for create operation
-func TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(apiVersion, cfgName, newTenant string, expectedHttpStatus int) (success bool) {
-	common.PrintLog(fmt.Sprintf("Admin Creating CFS configuration with same name %s and different tenant.", cfgName))
+func TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(ctx context.Context, apiVersion, cfgName, newTenant string, expectedHttpStatus int) (success bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant").End(ctx, &success)
+	common.PrintLog(ctx, fmt.Sprintf("Admin Creating CFS configuration with same name %s and different tenant.", cfgName))
 	var addTenant bool
 
-	currentTenant := common.GetTenantName()
+	currentTenant := common.GetTenantName(ctx)
 
 	// Set the tenant name to the new tenant so that it is used for creation of paylaod
 	if len(newTenant) != 0 {
-		common.SetTenantName(newTenant)
+		common.SetTenantName(ctx, newTenant)
 		addTenant = true
 	} else {
 		addTenant = false
 	}
 
 	// get CFS configuration payload with tenane_name set in the payload
-	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(apiVersion, addTenant)
+	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(ctx, addTenant)
 	if !success {
 		return false
 	}
 
-	common.Infof("Admin Creating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Admin Creating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
 
 	// Unsetting the tenant name to create a configuration that belongs to the new tenant using admin
-	common.SetTenantName("")
+	common.SetTenantName(ctx, "")
 	// Attempt to create CFS configuration with the same name in a different tenant
-	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationRecordAPI(cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
+	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
 	if !success {
 		return false
 	}
 
 	// Set the new tenant for GET call to work
-	common.SetTenantName(newTenant)
+	common.SetTenantName(ctx, newTenant)
 	// verify Cfs configuration record
-	_, success = GetCFSConfigurationRecordAPI(cfgName, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
+	_, success = GetCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
 	if !success {
-		common.Errorf("Unable to get CFS configuration record: %s", cfgName)
+		common.Errorf(ctx, "Unable to get CFS configuration record: %s", cfgName)
 		return false
 	}
 
 	// Verify cfs configurations in the list of configurations
-	cfsConfigurations, success := GetAPIBasedCFSConfigurationRecordList(apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
+	cfsConfigurations, success := GetCFSConfigurationsListAPI(ctx, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
 	if !success {
-		common.Errorf("Unable to get CFS configurations list")
+		common.Errorf(ctx, "Unable to get CFS configurations list")
 		return false
 	}
 
-	if !CFSConfigurationExists(cfsConfigurations, cfgName) {
-		common.Errorf("CFS configuration %s was not found in the list of CFS configurations", cfgName)
+	if !CFSConfigurationExists(ctx, cfsConfigurations, cfgName) {
+		common.Errorf(ctx, "CFS configuration %s was not found in the list of CFS configurations", cfgName)
 		return false
 	}
 
 	// Verify the CFS configuration record
-	if !VerifyCFSConfigurationRecord(cfsConfigurationRecord, cfsConfigurationPayload, cfgName, apiVersion) {
+	if !VerifyCFSConfigurationRecord(ctx, cfsConfigurationRecord, cfsConfigurationPayload, cfgName) {
 		return false
 	}
-	common.Infof("Admin successfully updated CFS configuration %s tenant %s -> tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Admin successfully updated CFS configuration %s tenant %s -> tenant %s", cfgName, currentTenant, newTenant)
 	return true
 }
 
@@ -254,37 +261,38 @@ func TestCFSConfigurationCreateByAdminWithSameNameDifferentTenant(apiVersion, cf
 // it returns true for following cases otherwise false:
 // - If the create operation is performed using non-owner tenant and create is not successful and
 // expectedHttpStatus matches the actual http status code
-func TestCFSConfigurationCreateWithSameNameDifferentTenant(apiVersion, cfgName string, expectedHttpStatus int) (success bool) {
+func TestCFSConfigurationCreateWithSameNameDifferentTenant(ctx context.Context, apiVersion, cfgName string, expectedHttpStatus int) (success bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationCreateWithSameNameDifferentTenant").End(ctx, &success)
 
-	common.PrintLog(fmt.Sprintf("Creating CFS configuration with same name %s and different tenant", cfgName))
+	common.PrintLog(ctx, fmt.Sprintf("Creating CFS configuration with same name %s and different tenant", cfgName))
 
 	// get CFS configuration payload
-	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(apiVersion, false)
+	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(ctx, false)
 	if !success {
 		return false
 	}
 
-	currentTenant := common.GetTenantName()
-	newTenant := GetAnotherTenantFromList(currentTenant)
+	currentTenant := common.GetTenantName(ctx)
+	newTenant := GetAnotherTenantFromList(ctx, currentTenant)
 
 	if len(newTenant) == 0 {
-		common.Warnf("No other tenant found to test CFS configuration creation with same name, skipping the test.")
+		common.Warnf(ctx, "No other tenant found to test CFS configuration creation with same name, skipping the test.")
 		return true
 	}
 
-	common.Infof("Creating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Creating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
 
-	common.SetTenantName(newTenant)
+	common.SetTenantName(ctx, newTenant)
 	// Attempt to create CFS configuration with the same name in a different tenant
-	_, success = CreateUpdateCFSConfigurationRecordAPI(cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
+	_, success = CreateUpdateCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
 	// Reset tenant name to the original tenant
-	common.SetTenantName(currentTenant)
+	common.SetTenantName(ctx, currentTenant)
 
 	if !success {
-		common.Errorf("Create CFS configuration successful with same name %s for a different tenant: %s", cfgName, newTenant)
+		common.Errorf(ctx, "Create CFS configuration successful with same name %s for a different tenant: %s", cfgName, newTenant)
 		return false
 	}
-	common.Infof("Unable to create CFS configuration with same name %s for a different tenant: %s", cfgName, newTenant)
+	common.Infof(ctx, "Unable to create CFS configuration with same name %s for a different tenant: %s", cfgName, newTenant)
 	return true
 }
 
@@ -298,21 +306,22 @@ func TestCFSConfigurationCreateWithSameNameDifferentTenant(apiVersion, cfgName s
 //
 // - If the create operation is performed using dummy or non-owner tenant and create is not successful
 // and expectedHttpStatus matches the actual http status code
-func TestCFSConfigurationCreate(apiVersion string, expectedHttpStatus int) (cfsConfigurationRecord CFSConfiguration, success bool) {
+func TestCFSConfigurationCreate(ctx context.Context, apiVersion string, expectedHttpStatus int) (cfsConfigurationRecord cfsclient.Configuration, success bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationCreate").End(ctx, &success)
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
 
-	common.PrintLog(fmt.Sprintf("Creating CFS configuration: %s", cfgName))
+	common.PrintLog(ctx, fmt.Sprintf("Creating CFS configuration: %s", cfgName))
 
 	// get CFS configuration payload
-	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(apiVersion, false)
+	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(ctx, false)
 	if !success {
-		return CFSConfiguration{}, false
+		return cfsclient.Configuration{}, false
 	}
 
 	// create CFS configuration
-	cfsConfigurationRecord, success = CreateUpdateCFSConfigurationRecordAPI(cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
+	cfsConfigurationRecord, success = CreateUpdateCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
 	if !success {
-		return CFSConfiguration{}, false
+		return cfsclient.Configuration{}, false
 	}
 
 	// We need to determine if we should continue on to verify the configuration record,
@@ -328,34 +337,34 @@ func TestCFSConfigurationCreate(apiVersion string, expectedHttpStatus int) (cfsC
 	// range, and we reached this point, then we know the API call must have failed.
 	if expectedHttpStatus > 299 || expectedHttpStatus < 200 {
 		// This means the API call failed, so we should not verify the response
-		common.Infof("CFS configuration %s not successfully created with dummy tenant: %s", cfgName, common.GetTenantName())
-		return CFSConfiguration{}, true
+		common.Infof(ctx, "CFS configuration %s not successfully created with dummy tenant: %s", cfgName, common.GetTenantName(ctx))
+		return cfsclient.Configuration{}, true
 	}
 
 	// verify Cfs configuration record
-	_, success = GetCFSConfigurationRecordAPI(cfgName, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
+	_, success = GetCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
 	if !success {
-		common.Errorf("Unable to get CFS configuration record: %s", cfgName)
-		return CFSConfiguration{}, false
+		common.Errorf(ctx, "Unable to get CFS configuration record: %s", cfgName)
+		return cfsclient.Configuration{}, false
 	}
 
 	// Verify cfs configurations in the list of configurations
-	cfsConfigurations, success := GetAPIBasedCFSConfigurationRecordList(apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
+	cfsConfigurations, success := GetCFSConfigurationsListAPI(ctx, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
 	if !success {
-		common.Errorf("Unable to get CFS configurations list")
-		return CFSConfiguration{}, false
+		common.Errorf(ctx, "Unable to get CFS configurations list")
+		return cfsclient.Configuration{}, false
 	}
 
-	if !CFSConfigurationExists(cfsConfigurations, cfgName) {
-		common.Errorf("CFS configuration %s was not found in the list of cfs configurations", cfgName)
-		return CFSConfiguration{}, false
+	if !CFSConfigurationExists(ctx, cfsConfigurations, cfgName) {
+		common.Errorf(ctx, "CFS configuration %s was not found in the list of cfs configurations", cfgName)
+		return cfsclient.Configuration{}, false
 	}
 
 	// Verify the CFS configuration record
-	if !VerifyCFSConfigurationRecord(cfsConfigurationRecord, cfsConfigurationPayload, cfgName, apiVersion) {
-		return CFSConfiguration{}, false
+	if !VerifyCFSConfigurationRecord(ctx, cfsConfigurationRecord, cfsConfigurationPayload, cfgName) {
+		return cfsclient.Configuration{}, false
 	}
-	common.Infof("CFS configuration record created successfully: %s", cfsConfigurationRecord.Name)
+	common.Infof(ctx, "CFS configuration record created successfully: %s", cfsConfigurationRecord.Name)
 
 	return cfsConfigurationRecord, true
 }
@@ -366,36 +375,37 @@ func TestCFSConfigurationCreate(apiVersion string, expectedHttpStatus int) (cfsC
 // - If the update operation is performed using Non owner tenant and update is not successful and
 // expectedHttpStatus matches the actual http status code
 // - If new tenant is not found to perform the test
-func TestCFSConfigurationUpdatewithDifferentTenant(apiVersion, cfgName string, expectedHttpStatus int) (success bool) {
-	common.PrintLog(fmt.Sprintf("Updating CFS configuration %s with a non owner tenant.", cfgName))
+func TestCFSConfigurationUpdatewithDifferentTenant(ctx context.Context, apiVersion, cfgName string, expectedHttpStatus int) (success bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationUpdatewithDifferentTenant").End(ctx, &success)
+	common.PrintLog(ctx, fmt.Sprintf("Updating CFS configuration %s with a non owner tenant.", cfgName))
 
 	// get CFS configuration payload
-	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(apiVersion, false)
+	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(ctx, false)
 	if !success {
 		return false
 	}
 
-	currentTenant := common.GetTenantName()
-	newTenant := GetAnotherTenantFromList(currentTenant)
+	currentTenant := common.GetTenantName(ctx)
+	newTenant := GetAnotherTenantFromList(ctx, currentTenant)
 
 	if len(newTenant) == 0 {
-		common.Warnf("No other tenant found to test CFS configuration update with same name, skipping the test.")
+		common.Warnf(ctx, "No other tenant found to test CFS configuration update with same name, skipping the test.")
 		return true
 	}
 
-	common.Infof("Updating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Updating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
 
-	common.SetTenantName(newTenant)
+	common.SetTenantName(ctx, newTenant)
 	// Attempt to update CFS configuration with the same name in a different tenant
-	_, success = CreateUpdateCFSConfigurationRecordAPI(cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
+	_, success = CreateUpdateCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
 	// Reset tenant name to the original tenant
-	common.SetTenantName(currentTenant)
+	common.SetTenantName(ctx, currentTenant)
 
 	if !success {
-		common.Errorf("Successfully updated CFS configuration %s using new tenant: %s", cfgName, newTenant)
+		common.Errorf(ctx, "Successfully updated CFS configuration %s using new tenant: %s", cfgName, newTenant)
 		return false
 	}
-	common.Infof("CFS configuration %s not updated using new tenant: %s", cfgName, newTenant)
+	common.Infof(ctx, "CFS configuration %s not updated using new tenant: %s", cfgName, newTenant)
 
 	return true
 }
@@ -410,16 +420,17 @@ func TestCFSConfigurationUpdatewithDifferentTenant(apiVersion, cfgName string, e
 //
 // - If the update operation is performed using dummy or non-owner tenant and update is not successful and
 // expectedHttpStatus matches the actual http status code
-func TestCFSConfigurationUpdate(cfgName, apiVersion string, expectedHttpStatus int) (success bool) {
-	common.PrintLog(fmt.Sprintf("Updating CFS configuration: %s", cfgName))
+func TestCFSConfigurationUpdate(ctx context.Context, cfgName, apiVersion string, expectedHttpStatus int) (success bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationUpdate").End(ctx, &success)
+	common.PrintLog(ctx, fmt.Sprintf("Updating CFS configuration: %s", cfgName))
 	// get CFS configuration payload
-	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(apiVersion, false)
+	cfsConfigurationPayload, success := GetCreateCFGConfigurationPayload(ctx, false)
 	if !success {
 		return false
 	}
 
 	// Update CFS configuration record
-	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationRecordAPI(cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
+	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, cfsConfigurationPayload, expectedHttpStatus)
 	if !success {
 		return false
 	}
@@ -437,30 +448,30 @@ func TestCFSConfigurationUpdate(cfgName, apiVersion string, expectedHttpStatus i
 	// range, and we reached this point, then we know the API call must have failed.
 	if expectedHttpStatus > 299 || expectedHttpStatus < 200 {
 		// This means the API call failed, so we should not verify the response
-		common.Infof("CFS configuration %s not successfully updated with dummy tenant: %s", cfgName, common.GetTenantName())
+		common.Infof(ctx, "CFS configuration %s not successfully updated with dummy tenant: %s", cfgName, common.GetTenantName(ctx))
 		return true
 	}
 
 	// Verify the CFS configuration record
-	_, success = GetCFSConfigurationRecordAPI(cfgName, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
+	_, success = GetCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
 	if !success {
 		return false
 	}
 
 	// Verify cfs configurations in the list of configurations
-	cfsConfigurations, success := GetAPIBasedCFSConfigurationRecordList(apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
+	cfsConfigurations, success := GetCFSConfigurationsListAPI(ctx, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
 	if !success {
 		return false
 	}
 
-	if !CFSConfigurationExists(cfsConfigurations, cfgName) {
+	if !CFSConfigurationExists(ctx, cfsConfigurations, cfgName) {
 		return false
 	}
 
-	if !VerifyCFSConfigurationRecord(cfsConfigurationRecord, cfsConfigurationPayload, cfgName, apiVersion) {
+	if !VerifyCFSConfigurationRecord(ctx, cfsConfigurationRecord, cfsConfigurationPayload, cfgName) {
 		return false
 	}
-	common.Infof("CFS configuration record updated successfully: %s", cfsConfigurationRecord.Name)
+	common.Infof(ctx, "CFS configuration record updated successfully: %s", cfsConfigurationRecord.Name)
 
 	return true
 }
@@ -471,31 +482,32 @@ func TestCFSConfigurationUpdate(cfgName, apiVersion string, expectedHttpStatus i
 // - If the delete operation is performed using non-owner tenant and delete is not successful and
 // expectedHttpStatus matches the actual http status code
 // - If new tenant is not found to perform the test
-func TestCFSConfigurationDeleteUsingDifferentTenant(apiVersion, cfgName string, expectedHttpStatus int) (success bool) {
-	common.PrintLog(fmt.Sprintf("Deleting CFS configuration %s using a different tenant", cfgName))
+func TestCFSConfigurationDeleteUsingDifferentTenant(ctx context.Context, apiVersion, cfgName string, expectedHttpStatus int) (success bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationDeleteUsingDifferentTenant").End(ctx, &success)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting CFS configuration %s using a different tenant", cfgName))
 
 	// Get another tenant
-	currentTenant := common.GetTenantName()
-	newTenant := GetAnotherTenantFromList(currentTenant)
+	currentTenant := common.GetTenantName(ctx)
+	newTenant := GetAnotherTenantFromList(ctx, currentTenant)
 
 	if len(newTenant) == 0 {
-		common.Warnf("No other tenant found to test CFS configuration deletion with same name, skipping the test.")
+		common.Warnf(ctx, "No other tenant found to test CFS configuration deletion with same name, skipping the test.")
 		return true
 	}
 
-	common.Infof("Deleting CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Deleting CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
 
-	common.SetTenantName(newTenant)
+	common.SetTenantName(ctx, newTenant)
 	// Attempt to delete CFS configuration with the same name in a different tenant
-	success = DeleteCFSConfigurationRecordAPI(cfgName, apiVersion, expectedHttpStatus)
+	success = DeleteCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, expectedHttpStatus)
 	// Reset tenant name to the original tenant
-	common.SetTenantName(currentTenant)
+	common.SetTenantName(ctx, currentTenant)
 
 	if !success {
-		common.Errorf("Successfully deleted CFS configuration %s using new tenant: %s", cfgName, newTenant)
+		common.Errorf(ctx, "Successfully deleted CFS configuration %s using new tenant: %s", cfgName, newTenant)
 		return false
 	}
-	common.Infof("CFS configuration %s not deleted using new tenant: %s", cfgName, newTenant)
+	common.Infof(ctx, "CFS configuration %s not deleted using new tenant: %s", cfgName, newTenant)
 
 	return true
 }
@@ -510,10 +522,11 @@ func TestCFSConfigurationDeleteUsingDifferentTenant(apiVersion, cfgName string,
 //
 // - If the delete operation is performed using dummy or non-owner tenant and delete is not successful and
 // expectedHttpStatus matches the actual http status code
-func TestCFSConfigurationDelete(cfgName string, apiVersion string, expectedHttpStatus int) (success bool) {
-	common.PrintLog(fmt.Sprintf("Deleting CFS configuration: %s", cfgName))
+func TestCFSConfigurationDelete(ctx context.Context, cfgName string, apiVersion string, expectedHttpStatus int) (success bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationDelete").End(ctx, &success)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting CFS configuration: %s", cfgName))
 	// Delete CFS configuration record
-	success = DeleteCFSConfigurationRecordAPI(cfgName, apiVersion, expectedHttpStatus)
+	success = DeleteCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, expectedHttpStatus)
 	if !success {
 		return false
 	}
@@ -532,41 +545,42 @@ func TestCFSConfigurationDelete(cfgName string, apiVersion string, expectedHttpS
 	// have failed.
 	if expectedHttpStatus > 299 || expectedHttpStatus < 200 {
 		// This means the API call failed, so we should not verify the delete
-		common.Infof("CFS configuration %s not successfully created with dummy tenant: %s", cfgName, common.GetTenantName())
+		common.Infof(ctx, "CFS configuration %s not successfully created with dummy tenant: %s", cfgName, common.GetTenantName(ctx))
 		return true
 	}
 
 	// Verify CFS configuration record is deleted
-	_, success = GetCFSConfigurationRecordAPI(cfgName, apiVersion, EXPECTED_CFS_NOT_FOUND_HTTP_STATUS)
+	_, success = GetCFSConfigurationRecordAPI(ctx, cfgName, apiVersion, EXPECTED_CFS_NOT_FOUND_HTTP_STATUS)
 	if !success {
 		return false
 	}
 
 	// Verify cfs configurations is not in the list of configurations
-	cfsConfigurations, success := GetAPIBasedCFSConfigurationRecordList(apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
+	cfsConfigurations, success := GetCFSConfigurationsListAPI(ctx, apiVersion, EXPECTED_CFS_GET_HTTP_STATUS)
 	if !success {
 		return false
 	}
 
-	if CFSConfigurationExists(cfsConfigurations, cfgName) {
+	if CFSConfigurationExists(ctx, cfsConfigurations, cfgName) {
 		return false
 	}
-	common.Infof("CFS configuration %s deleted successfully", cfgName)
+	common.Infof(ctx, "CFS configuration %s deleted successfully", cfgName)
 
 	return true
 }
 
 // TestCFSConfigurationGetAll gets all existing CFS configurations using the given apiVersion and expected http status code.
 // It returns true if the GET ALL API call's status code matches the expected http status code, otherwise false.
-func TestCFSConfigurationGetAll(apiVersion string, expectedHttpStatus int) (success bool) {
-	common.PrintLog("Getting all CFS configurations")
+func TestCFSConfigurationGetAll(ctx context.Context, apiVersion string, expectedHttpStatus int) (success bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationGetAll").End(ctx, &success)
+	common.PrintLog(ctx, "Getting all CFS configurations")
 	// Get CFS configurations list
-	cfsConfigurations, success := GetAPIBasedCFSConfigurationRecordList(apiVersion, expectedHttpStatus)
+	cfsConfigurations, success := GetCFSConfigurationsListAPI(ctx, apiVersion, expectedHttpStatus)
 	if !success {
 		return false
 	}
 
-	common.Infof("Found %d CFS configurations", len(cfsConfigurations))
+	common.Infof(ctx, "Found %d CFS configurations", len(cfsConfigurations))
 
 	return true
 }
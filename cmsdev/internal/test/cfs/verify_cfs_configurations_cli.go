@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,132 +29,138 @@
 package cfs
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func TestCFSConfigurationsCRUDOperationWithTenantsUsingCLI() (passed bool) {
-	passed = TestCFSConfigurationsCRUDOperationUsingCLI()
+func TestCFSConfigurationsCRUDOperationWithTenantsUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationsCRUDOperationWithTenantsUsingCLI").End(ctx, &passed)
+	passed = TestCFSConfigurationsCRUDOperationUsingCLI(ctx)
 	tenantList := []string{}
 	dummyTenant := common.GetDummyTenantName()
 	tenantList = append(tenantList, dummyTenant)
 	// Get an actual tenant
-	tenantName := GetTenantFromList()
+	tenantName := GetTenantFromList(ctx)
 	if len(tenantName) > 0 {
 		tenantList = append(tenantList, tenantName)
 	}
 	for _, tenant := range tenantList {
-		common.SetTenantName(tenant)
-		result := TestCFSConfigurationsCRUDOperationUsingCLI()
+		common.SetTenantName(ctx, tenant)
+		result := TestCFSConfigurationsCRUDOperationUsingCLI(ctx)
 		passed = passed && result
-		common.SetTenantName("")
+		common.SetTenantName(ctx, "")
 	}
 	return passed
 }
 
-func TestCFSConfigurationsCRUDOperationUsingCLI() (passed bool) {
+func TestCFSConfigurationsCRUDOperationUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationsCRUDOperationUsingCLI").End(ctx, &passed)
 	passed = true
 
-	if len(common.GetTenantName()) != 0 {
-		common.PrintLog(fmt.Sprintf("Running CFS configurations CLI tests with tenant: %s", common.GetTenantName()))
+	if len(common.GetTenantName(ctx)) != 0 {
+		common.PrintLog(ctx, fmt.Sprintf("Running CFS configurations CLI tests with tenant: %s", common.GetTenantName(ctx)))
 	} else {
-		common.PrintLog("Running CFS configurations CLI tests without tenant")
+		common.PrintLog(ctx, "Running CFS configurations CLI tests without tenant")
 	}
 
 	// Get supported API versions for configurations endpoints
 	for _, cliVersion := range GetSupportAPIVersions("configurations") {
-		if common.GetTenantName() == "" || cliVersion != "v2" {
-			common.PrintLog(fmt.Sprintf("Testing CFS configurations CRUD operations using CLI and version: %s", cliVersion))
-			result := TestCFSConfigurationsCRUDOperationCLI(cliVersion)
+		if common.GetTenantName(ctx) == "" || cliVersion != "v2" {
+			common.PrintLog(ctx, fmt.Sprintf("Testing CFS configurations CRUD operations using CLI and version: %s", cliVersion))
+			result := TestCFSConfigurationsCRUDOperationCLI(ctx, cliVersion)
 			passed = passed && result
 		}
 	}
 
-	if common.GetTenantName() == "" {
+	if common.GetTenantName(ctx) == "" {
 		// Test default CLI version which is v2
-		common.PrintLog("Testing CFS configurations CRUD operations using default CLI version")
-		result := TestCFSConfigurationsCRUDOperationCLI("")
+		common.PrintLog(ctx, "Testing CFS configurations CRUD operations using default CLI version")
+		result := TestCFSConfigurationsCRUDOperationCLI(ctx, "")
 		passed = passed && result
 	}
 
 	return passed
 }
 
-func TestCFSConfigurationsCRUDOperationCLI(cliVersion string) (passed bool) {
+func TestCFSConfigurationsCRUDOperationCLI(ctx context.Context, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSConfigurationsCRUDOperationCLI").End(ctx, &passed)
 	passed = true
 	// Create a CFS configuration using CLI
-	cfsConfigurationRecord, success := TestCLICFSConfigurationCreate(cliVersion)
+	cfsConfigurationRecord, success := TestCLICFSConfigurationCreate(ctx, cliVersion)
 	if !success {
 		return false
 	}
 
-	currentTenant := common.GetTenantName()
+	currentTenant := common.GetTenantName(ctx)
 	if len(cfsConfigurationRecord.Name) != 0 && !common.IsDummyTenant(currentTenant) && currentTenant != "" {
-		NewTenant := GetAnotherTenantFromList(currentTenant)
+		NewTenant := GetAnotherTenantFromList(ctx, currentTenant)
 		if len(NewTenant) != 0 {
 			// Verify that the system admin is able to create a configuration of the same name, but belonging to tenant B
-			createdWithAdmin := TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(cfsConfigurationRecord.Name, cliVersion, NewTenant)
+			createdWithAdmin := TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(ctx, cfsConfigurationRecord.Name, cliVersion, NewTenant)
 			// Verify that the system admin is able to create a configuration of the same name, but that belongs to no tenant
-			createdWithNoTenant := TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(cfsConfigurationRecord.Name, cliVersion, "")
+			createdWithNoTenant := TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(ctx, cfsConfigurationRecord.Name, cliVersion, "")
 			// Verify that the system admin is able to create a configuration of the same name, but belonging to tenant A
-			createdWithOldTenant := TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(cfsConfigurationRecord.Name, cliVersion, currentTenant)
+			createdWithOldTenant := TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(ctx, cfsConfigurationRecord.Name, cliVersion, currentTenant)
 			passed = passed && createdWithAdmin && createdWithNoTenant && createdWithOldTenant
 			// Seeting the tenant back to the original tenant
-			common.SetTenantName(currentTenant)
+			common.SetTenantName(ctx, currentTenant)
 		}
 	}
 
-	if len(cfsConfigurationRecord.Name) != 0 && !common.IsDummyTenant(common.GetTenantName()) && cliVersion == "v3" {
-		createdWithTenant := TestCLICFSConfigurationCreateWithSameNameDifferentTenant(cfsConfigurationRecord.Name, cliVersion)
-		updatedWithTenant := TestCLICFSConfigurationUpdateWithDifferentTenant(cfsConfigurationRecord.Name, cliVersion)
-		deletedWithTenant := TestCLICFSConfigurationDeleteWithDifferentTenant(cfsConfigurationRecord.Name, cliVersion)
+	if len(cfsConfigurationRecord.Name) != 0 && !common.IsDummyTenant(common.GetTenantName(ctx)) && cliVersion == "v3" {
+		createdWithTenant := TestCLICFSConfigurationCreateWithSameNameDifferentTenant(ctx, cfsConfigurationRecord.Name, cliVersion)
+		updatedWithTenant := TestCLICFSConfigurationUpdateWithDifferentTenant(ctx, cfsConfigurationRecord.Name, cliVersion)
+		deletedWithTenant := TestCLICFSConfigurationDeleteWithDifferentTenant(ctx, cfsConfigurationRecord.Name, cliVersion)
 		passed = createdWithTenant && updatedWithTenant && deletedWithTenant
 	}
 
 	if len(cfsConfigurationRecord.Name) != 0 {
 		// Update the CFS configuration using CLI
-		updated := TestCLICFSConfigurationUpdate(cfsConfigurationRecord.Name, cliVersion)
+		updated := TestCLICFSConfigurationUpdate(ctx, cfsConfigurationRecord.Name, cliVersion)
 
 		// Delete the CFS configuration using CLI
-		deleted := TestCLICFSConfigurationDelete(cfsConfigurationRecord.Name, cliVersion)
+		deleted := TestCLICFSConfigurationDelete(ctx, cfsConfigurationRecord.Name, cliVersion)
 
 		// Get all CFS configurations using CLI
-		getAll := TestCLICFSConfigurationGetAll(cliVersion)
+		getAll := TestCLICFSConfigurationGetAll(ctx, cliVersion)
 
 		return passed && updated && deleted && getAll
 	}
 	return true
 }
 
-func TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(cfgName, cliVersion, newTenant string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Admin Creating CFS configuration with same name %s and different tenant.", cfgName))
-	currentTenant := common.GetTenantName()
+func TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(ctx context.Context, cfgName, cliVersion, newTenant string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Admin Creating CFS configuration with same name %s and different tenant.", cfgName))
+	currentTenant := common.GetTenantName(ctx)
 	var addTenant bool
 
 	// Set the tenant name to the new tenant so that it is used for creation of paylaod
 	if len(newTenant) != 0 {
-		common.SetTenantName(newTenant)
+		common.SetTenantName(ctx, newTenant)
 		addTenant = true
 	} else {
 		addTenant = false
 	}
 
-	common.Infof("Creating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Creating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
 
 	// Create CFS configuration payload
-	fileName, payload, success := CreateCFSConfigurationFile(cfgName, cliVersion, addTenant)
+	fileName, payload, success := CreateCFSConfigurationFile(ctx, cfgName, cliVersion, addTenant)
 	if !success {
 		return false
 	}
 
 	// Unsetting the tenant name to create a configuration that belongs to the new tenant using admin
-	common.SetTenantName("")
+	common.SetTenantName(ctx, "")
 
 	// Create a CFS configuration using CLI
-	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationCLI(cfgName, fileName, cliVersion)
+	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationCLI(ctx, cfgName, fileName, cliVersion)
 
 	if !success {
 		return false
@@ -162,323 +168,330 @@ func TestCLICFSConfigurationCreateByAdminWithSameNameDifferentTenant(cfgName, cl
 
 	// Remove the created configuration file
 	if err := os.Remove(fileName); err != nil {
-		common.Errorf("Unable to remove file %s: %v", fileName, err)
+		common.Errorf(ctx, "Unable to remove file %s: %v", fileName, err)
 	}
 
 	// Set the new tenant for GET call to work
-	common.SetTenantName(newTenant)
+	common.SetTenantName(ctx, newTenant)
 
 	// Verify CFS configuration record using CLI
-	_, success = GetCFSConfigurationRecordCLI(cfgName, cliVersion)
+	_, success = GetCFSConfigurationRecordCLI(ctx, cfgName, cliVersion)
 	if !success {
 		return false
 	}
 
 	// Verify CFS configurations in the list of configurations using CLI
-	cfsConfigurations, success := GetCLIVersionBasedCFSConfigurationRecordList(cliVersion)
+	cfsConfigurations, success := GetCFSConfigurationsListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Unable to get CFS configurations list using CLI")
+		common.Errorf(ctx, "Unable to get CFS configurations list using CLI")
 		return false
 	}
 
-	if !CFSConfigurationExists(cfsConfigurations, cfgName) {
-		common.Errorf("CFS configuration %s was not found in the list of CFS configurations", cfgName)
+	if !CFSConfigurationExists(ctx, cfsConfigurations, cfgName) {
+		common.Errorf(ctx, "CFS configuration %s was not found in the list of CFS configurations", cfgName)
 		return false
 	}
 
 	// Verify the CFS configuration record
-	if !VerifyCFSConfigurationRecord(cfsConfigurationRecord, payload, cfgName, cliVersion) {
+	if !VerifyCFSConfigurationRecord(ctx, cfsConfigurationRecord, payload, cfgName) {
 		return false
 	}
 
-	common.Infof("Admin successfully updated CFS configuration %s tenant %s -> tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Admin successfully updated CFS configuration %s tenant %s -> tenant %s", cfgName, currentTenant, newTenant)
 	return true
 }
 
-func TestCLICFSConfigurationCreateWithSameNameDifferentTenant(cfgName, cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Creating CFS configuration with same name and different tenant: %s", cfgName))
-	currentTenant := common.GetTenantName()
-	newTenant := GetAnotherTenantFromList(currentTenant)
+func TestCLICFSConfigurationCreateWithSameNameDifferentTenant(ctx context.Context, cfgName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSConfigurationCreateWithSameNameDifferentTenant").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Creating CFS configuration with same name and different tenant: %s", cfgName))
+	currentTenant := common.GetTenantName(ctx)
+	newTenant := GetAnotherTenantFromList(ctx, currentTenant)
 
 	if len(newTenant) == 0 {
-		common.Warnf("No other tenant found to test CFS configuration creation with same name, skipping the test.")
+		common.Warnf(ctx, "No other tenant found to test CFS configuration creation with same name, skipping the test.")
 		return true
 	}
 
-	common.SetTenantName(newTenant)
+	common.SetTenantName(ctx, newTenant)
 
-	common.Infof("Creating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Creating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
 
 	// Create CFS configuration payload
-	fileName, _, success := CreateCFSConfigurationFile(cfgName, cliVersion, false)
+	fileName, _, success := CreateCFSConfigurationFile(ctx, cfgName, cliVersion, false)
 	if !success {
 		return false
 	}
 	// Set the CLI execution return code to 2, since creating the configuration with the same name with different tenant is expected to fail
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 	// Create a CFS configuration using CLI
-	_, success = CreateUpdateCFSConfigurationCLI(cfgName, fileName, cliVersion)
+	_, success = CreateUpdateCFSConfigurationCLI(ctx, cfgName, fileName, cliVersion)
 
 	// Set the CLi execution code back to 0
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 	// Reset tenant name to the original tenant
-	common.SetTenantName(currentTenant)
+	common.SetTenantName(ctx, currentTenant)
 
 	if success {
-		common.Errorf("Created CFS configuration %s using CLI with different tenant %s.", cfgName, newTenant)
+		common.Errorf(ctx, "Created CFS configuration %s using CLI with different tenant %s.", cfgName, newTenant)
 		return false
 	}
 
 	// Remove the created configuration file
 	if err := os.Remove(fileName); err != nil {
-		common.Errorf("Unable to remove file %s: %v", fileName, err)
+		common.Errorf(ctx, "Unable to remove file %s: %v", fileName, err)
 	}
 
 	passed = true
-	common.Infof("Unable to create CFS configuration with same name %s for a different tenant: %s", cfgName, newTenant)
+	common.Infof(ctx, "Unable to create CFS configuration with same name %s for a different tenant: %s", cfgName, newTenant)
 	return
 }
 
-func TestCLICFSConfigurationCreate(cliVersion string) (cfsConfigurationRecord CFSConfiguration, passed bool) {
+func TestCLICFSConfigurationCreate(ctx context.Context, cliVersion string) (cfsConfigurationRecord cfsclient.Configuration, passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSConfigurationCreate").End(ctx, &passed)
 	cfgName := "CFS_Configuration_" + string(common.GetRandomString(10))
 
-	common.PrintLog(fmt.Sprintf("Creating CFS configuration: %s", cfgName))
+	common.PrintLog(ctx, fmt.Sprintf("Creating CFS configuration: %s", cfgName))
 
 	// Get CFS configuration payload
-	fileName, payload, success := CreateCFSConfigurationFile(cfgName, cliVersion, false)
+	fileName, payload, success := CreateCFSConfigurationFile(ctx, cfgName, cliVersion, false)
 	if !success {
-		return CFSConfiguration{}, false
+		return cfsclient.Configuration{}, false
 	}
 
-	common.Infof("Creating CFS configuration with file: %s", fileName)
+	common.Infof(ctx, "Creating CFS configuration with file: %s", fileName)
 
-	if common.IsDummyTenant(common.GetTenantName()) {
+	if common.IsDummyTenant(common.GetTenantName(ctx)) {
 		// Set execution return code to 2, since dummy tenant is used
-		test.SetCliExecreturnCode(2)
+		test.SetCliExecreturnCode(ctx, 2)
 	}
 
 	// Create a CFS configuration using CLI
-	cfsConfigurationRecord, success = CreateUpdateCFSConfigurationCLI(cfgName, fileName, cliVersion)
+	cfsConfigurationRecord, success = CreateUpdateCFSConfigurationCLI(ctx, cfgName, fileName, cliVersion)
 	if !success {
-		common.Errorf("Failed to create CFS configuration using CLI")
+		common.Errorf(ctx, "Failed to create CFS configuration using CLI")
 		// set the CLI execution return code to 0 if code execution reaches here and CLI execution return code was set to 2
-		test.SetCliExecreturnCode(0)
-		return CFSConfiguration{}, false
+		test.SetCliExecreturnCode(ctx, 0)
+		return cfsclient.Configuration{}, false
 	}
 
 	// Remove the created configuration file
 	if err := os.Remove(fileName); err != nil {
-		common.Errorf("Unable to remove file %s: %v", fileName, err)
+		common.Errorf(ctx, "Unable to remove file %s: %v", fileName, err)
 	}
 
-	if test.GetCliExecreturnCode() != 0 {
-		common.Infof("CFS configuration %s not successfully created with dummy tenant: %s", cfgName, common.GetTenantName())
-		test.SetCliExecreturnCode(0)
-		return CFSConfiguration{}, true // if the tenant is dummy, we skip the verification as creation is expected to fail
+	if test.GetCliExecreturnCode(ctx) != 0 {
+		common.Infof(ctx, "CFS configuration %s not successfully created with dummy tenant: %s", cfgName, common.GetTenantName(ctx))
+		test.SetCliExecreturnCode(ctx, 0)
+		return cfsclient.Configuration{}, true // if the tenant is dummy, we skip the verification as creation is expected to fail
 	}
 
 	// Verify CFS configuration record using CLI
-	_, success = GetCFSConfigurationRecordCLI(cfgName, cliVersion)
+	_, success = GetCFSConfigurationRecordCLI(ctx, cfgName, cliVersion)
 	if !success {
-		return CFSConfiguration{}, false
+		return cfsclient.Configuration{}, false
 	}
 
 	// Verify CFS configurations in the list of configurations using CLI
-	cfsConfigurations, success := GetCLIVersionBasedCFSConfigurationRecordList(cliVersion)
+	cfsConfigurations, success := GetCFSConfigurationsListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Unable to get CFS configurations list using CLI")
-		return CFSConfiguration{}, false
+		common.Errorf(ctx, "Unable to get CFS configurations list using CLI")
+		return cfsclient.Configuration{}, false
 	}
 
-	if !CFSConfigurationExists(cfsConfigurations, cfgName) {
-		common.Errorf("CFS configuration %s was not found in the list of cfs configurations", cfgName)
-		return CFSConfiguration{}, false
+	if !CFSConfigurationExists(ctx, cfsConfigurations, cfgName) {
+		common.Errorf(ctx, "CFS configuration %s was not found in the list of cfs configurations", cfgName)
+		return cfsclient.Configuration{}, false
 	}
 
 	// Verify the CFS configuration record
-	if !VerifyCFSConfigurationRecord(cfsConfigurationRecord, payload, cfgName, cliVersion) {
-		return CFSConfiguration{}, false
+	if !VerifyCFSConfigurationRecord(ctx, cfsConfigurationRecord, payload, cfgName) {
+		return cfsclient.Configuration{}, false
 	}
 
-	common.Infof("CFS configuration created successfully: %s", cfgName)
+	common.Infof(ctx, "CFS configuration created successfully: %s", cfgName)
 	return cfsConfigurationRecord, true
 }
 
-func TestCLICFSConfigurationUpdateWithDifferentTenant(cfgName, cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Updating CFS configuration %s with different tenant.", cfgName))
-	currentTenant := common.GetTenantName()
-	newTenant := GetAnotherTenantFromList(currentTenant)
+func TestCLICFSConfigurationUpdateWithDifferentTenant(ctx context.Context, cfgName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSConfigurationUpdateWithDifferentTenant").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Updating CFS configuration %s with different tenant.", cfgName))
+	currentTenant := common.GetTenantName(ctx)
+	newTenant := GetAnotherTenantFromList(ctx, currentTenant)
 
 	if len(newTenant) == 0 {
-		common.Warnf("No other tenant found to test CFS configuration update with same name, skipping the test.")
+		common.Warnf(ctx, "No other tenant found to test CFS configuration update with same name, skipping the test.")
 		return true
 	}
 
-	common.SetTenantName(newTenant)
+	common.SetTenantName(ctx, newTenant)
 
-	common.Infof("Updating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Updating CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
 
 	// Get CFS configuration payload
-	fileName, _, success := CreateCFSConfigurationFile(cfgName, cliVersion, false)
+	fileName, _, success := CreateCFSConfigurationFile(ctx, cfgName, cliVersion, false)
 	if !success {
 		return false
 	}
 	// Set the CLI execution return code to 2, since updating the configuration with different tenant is expected to fail
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 	// Update a CFS configuration using CLI
-	_, success = CreateUpdateCFSConfigurationCLI(cfgName, fileName, cliVersion)
+	_, success = CreateUpdateCFSConfigurationCLI(ctx, cfgName, fileName, cliVersion)
 
 	// Set the CLi execution code back to 0
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 	// Reset tenant name to the original tenant
-	common.SetTenantName(currentTenant)
+	common.SetTenantName(ctx, currentTenant)
 
 	if success {
-		common.Errorf("Updated CFS configuration %s using CLI with different tenant %s.", cfgName, newTenant)
+		common.Errorf(ctx, "Updated CFS configuration %s using CLI with different tenant %s.", cfgName, newTenant)
 		return false
 	}
 
 	// Remove the created configuration file
 	if err := os.Remove(fileName); err != nil {
-		common.Errorf("Unable to remove file %s: %v", fileName, err)
+		common.Errorf(ctx, "Unable to remove file %s: %v", fileName, err)
 	}
 
 	passed = true
-	common.Infof("CFS configuration %s not updated using new tenant: %s", cfgName, newTenant)
+	common.Infof(ctx, "CFS configuration %s not updated using new tenant: %s", cfgName, newTenant)
 	return
 }
 
-func TestCLICFSConfigurationUpdate(cfgName, cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Updating CFS configuration: %s", cfgName))
+func TestCLICFSConfigurationUpdate(ctx context.Context, cfgName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSConfigurationUpdate").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Updating CFS configuration: %s", cfgName))
 
 	// Get CFS configuration payload
-	fileName, payload, success := CreateCFSConfigurationFile(cfgName, cliVersion, false)
+	fileName, payload, success := CreateCFSConfigurationFile(ctx, cfgName, cliVersion, false)
 	if !success {
 		return false
 	}
 
 	// Update the CFS configuration using CLI
-	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationCLI(cfgName, fileName, cliVersion)
+	cfsConfigurationRecord, success := CreateUpdateCFSConfigurationCLI(ctx, cfgName, fileName, cliVersion)
 	if !success {
-		common.Errorf("Failed to update CFS configuration using CLI")
+		common.Errorf(ctx, "Failed to update CFS configuration using CLI")
 		return false
 	}
 
 	// Remove the created configuration file
 	if err := os.Remove(fileName); err != nil {
-		common.Errorf("Unable to remove file %s: %v", fileName, err)
+		common.Errorf(ctx, "Unable to remove file %s: %v", fileName, err)
 	}
 
 	// Verify CFS configuration record using CLI
-	_, success = GetCFSConfigurationRecordCLI(cfgName, cliVersion)
+	_, success = GetCFSConfigurationRecordCLI(ctx, cfgName, cliVersion)
 	if !success {
 		return false
 	}
 
 	// Verify the CFS configuration record
-	if !VerifyCFSConfigurationRecord(cfsConfigurationRecord, payload, cfgName, cliVersion) {
+	if !VerifyCFSConfigurationRecord(ctx, cfsConfigurationRecord, payload, cfgName) {
 		return false
 	}
 
 	// Verify CFS configurations in the list of configurations using CLI
-	cfsConfigurations, success := GetCLIVersionBasedCFSConfigurationRecordList(cliVersion)
+	cfsConfigurations, success := GetCFSConfigurationsListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Unable to get CFS configurations list using CLI")
+		common.Errorf(ctx, "Unable to get CFS configurations list using CLI")
 		return false
 	}
 
-	if !CFSConfigurationExists(cfsConfigurations, cfgName) {
-		common.Errorf("CFS configuration %s was not found in the list of cfs configurations", cfgName)
+	if !CFSConfigurationExists(ctx, cfsConfigurations, cfgName) {
+		common.Errorf(ctx, "CFS configuration %s was not found in the list of cfs configurations", cfgName)
 		return false
 	}
 
-	common.Infof("CFS configuration updated successfully: %s", cfgName)
+	common.Infof(ctx, "CFS configuration updated successfully: %s", cfgName)
 	return true
 }
 
-func TestCLICFSConfigurationDeleteWithDifferentTenant(cfgName, cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Deleting CFS configuration %s with different tenant.", cfgName))
-	currentTenant := common.GetTenantName()
-	newTenant := GetAnotherTenantFromList(currentTenant)
+func TestCLICFSConfigurationDeleteWithDifferentTenant(ctx context.Context, cfgName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSConfigurationDeleteWithDifferentTenant").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting CFS configuration %s with different tenant.", cfgName))
+	currentTenant := common.GetTenantName(ctx)
+	newTenant := GetAnotherTenantFromList(ctx, currentTenant)
 
 	if len(newTenant) == 0 {
-		common.Warnf("No other tenant found to test CFS configuration deletion with same name, skipping the test.")
+		common.Warnf(ctx, "No other tenant found to test CFS configuration deletion with same name, skipping the test.")
 		return true
 	}
 
-	common.SetTenantName(newTenant)
+	common.SetTenantName(ctx, newTenant)
 
-	common.Infof("Deleting CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
+	common.Infof(ctx, "Deleting CFS configuration %s belonging to tenant %s using new tenant %s", cfgName, currentTenant, newTenant)
 
 	// Set the CLI execution return code to 2, since deleting the configuration with different tenant is expected to fail
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 	// Delete a CFS configuration using CLI
-	success := DeleteCFSConfigurationRecordCLI(cfgName, cliVersion)
+	success := DeleteCFSConfigurationRecordCLI(ctx, cfgName, cliVersion)
 
 	// Set the CLi execution code back to 0
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 	// Reset tenant name to the original tenant
-	common.SetTenantName(currentTenant)
+	common.SetTenantName(ctx, currentTenant)
 
 	if success {
-		common.Errorf("Deleted CFS configuration %s using CLI with different tenant %s.", cfgName, newTenant)
+		common.Errorf(ctx, "Deleted CFS configuration %s using CLI with different tenant %s.", cfgName, newTenant)
 		return false
 	}
 
 	passed = true
-	common.Infof("CFS configuration %s not deleted using new tenant: %s", cfgName, newTenant)
+	common.Infof(ctx, "CFS configuration %s not deleted using new tenant: %s", cfgName, newTenant)
 	return
 }
 
-func TestCLICFSConfigurationDelete(cfgName, cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Deleting CFS configuration: %s", cfgName))
+func TestCLICFSConfigurationDelete(ctx context.Context, cfgName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSConfigurationDelete").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting CFS configuration: %s", cfgName))
 
 	// Delete the CFS configuration using CLI
 
-	success := DeleteCFSConfigurationRecordCLI(cfgName, cliVersion)
+	success := DeleteCFSConfigurationRecordCLI(ctx, cfgName, cliVersion)
 	if !success {
-		common.Errorf("Failed to delete CFS configuration using CLI")
+		common.Errorf(ctx, "Failed to delete CFS configuration using CLI")
 		return false
 	}
 
 	// Set CLI execution return code to 2. Since cfs configuration is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 	// Verify CFS configuration record using CLI
-	_, success = GetCFSConfigurationRecordCLI(cfgName, cliVersion)
+	_, success = GetCFSConfigurationRecordCLI(ctx, cfgName, cliVersion)
 	if success {
-		common.Errorf("CFS configuration %s was not deleted successfully", cfgName)
+		common.Errorf(ctx, "CFS configuration %s was not deleted successfully", cfgName)
 		return false
 	}
 
 	// Set CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// Verify CFS configurations in the list of configurations using CLI
-	cfsConfigurations, success := GetCLIVersionBasedCFSConfigurationRecordList(cliVersion)
+	cfsConfigurations, success := GetCFSConfigurationsListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Unable to get CFS configurations list using CLI")
+		common.Errorf(ctx, "Unable to get CFS configurations list using CLI")
 		return false
 	}
 
-	if CFSConfigurationExists(cfsConfigurations, cfgName) {
-		common.Errorf("CFS configuration %s was found in the list of cfs configurations", cfgName)
+	if CFSConfigurationExists(ctx, cfsConfigurations, cfgName) {
+		common.Errorf(ctx, "CFS configuration %s was found in the list of cfs configurations", cfgName)
 		return false
 	}
 
-	common.Infof("CFS configuration deleted successfully: %s", cfgName)
+	common.Infof(ctx, "CFS configuration deleted successfully: %s", cfgName)
 	return true
 }
 
-func TestCLICFSConfigurationGetAll(cliVersion string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Getting all CFS configurations"))
+func TestCLICFSConfigurationGetAll(ctx context.Context, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSConfigurationGetAll").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Getting all CFS configurations"))
 
 	// Get all CFS configurations using CLI
-	cfsConfigurations, success := GetCLIVersionBasedCFSConfigurationRecordList(cliVersion)
+	cfsConfigurations, success := GetCFSConfigurationsListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Unable to get CFS configurations list using CLI")
+		common.Errorf(ctx, "Unable to get CFS configurations list using CLI")
 		return false
 	}
 
-	common.Infof("Found %d CFS configurations", len(cfsConfigurations))
+	common.Infof(ctx, "Found %d CFS configurations", len(cfsConfigurations))
 	return true
 }
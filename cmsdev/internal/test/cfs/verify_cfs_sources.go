@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,26 +29,29 @@
 package cfs
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func TestCFSSourcesCRUDOperation() (passed bool) {
+func TestCFSSourcesCRUDOperation(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSSourcesCRUDOperation").End(ctx, &passed)
 	passed = true
 
-	cfsSourceRecord, success := TestCFSSourceCreate()
+	cfsSourceRecord, success := TestCFSSourceCreate(ctx)
 	if !success {
 		return false
 	}
 
 	if len(cfsSourceRecord.Name) != 0 {
-		updated := TestCFSSourceUpdate(cfsSourceRecord.Name)
+		updated := TestCFSSourceUpdate(ctx, cfsSourceRecord.Name)
 
-		deleted := TestCFSSourceDelete(cfsSourceRecord.Name)
+		deleted := TestCFSSourceDelete(ctx, cfsSourceRecord.Name)
 
-		getAll := TestCFSSourceGetAll()
+		getAll := TestCFSSourceGetAll(ctx)
 
 		passed = passed && updated && deleted && getAll
 	}
@@ -56,123 +59,127 @@ func TestCFSSourcesCRUDOperation() (passed bool) {
 	return passed
 }
 
-func TestCFSSourceCreate() (cfsSourceRecord CFSSources, passed bool) {
+func TestCFSSourceCreate(ctx context.Context) (cfsSourceRecord cfsclient.Source, passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSSourceCreate").End(ctx, &passed)
 	sourceName := "CFS_Source_" + string(common.GetRandomString(10))
-	common.PrintLog(fmt.Sprintf("Creating CFS source: %s", sourceName))
+	common.PrintLog(ctx, fmt.Sprintf("Creating CFS source: %s", sourceName))
 
 	// Create a new CFS source record
-	cfsSourceRecord, success := CreateCFSSourceRecordAPI(sourceName)
+	cfsSourceRecord, success := CreateCFSSourceRecordAPI(ctx, sourceName)
 	if !success {
-		return CFSSources{}, false
+		return cfsclient.Source{}, false
 	}
 
 	// Get the CFS source record
-	_, success = GetCFSSourceRecordAPI(sourceName, http.StatusOK)
+	_, success = GetCFSSourceRecordAPI(ctx, sourceName, http.StatusOK)
 	if !success {
-		common.Errorf("Failed to get CFS source record: %s", sourceName)
-		return CFSSources{}, false
+		common.Errorf(ctx, "Failed to get CFS source record: %s", sourceName)
+		return cfsclient.Source{}, false
 	}
 
 	// verify CFS source is in the list of sources
-	cfsSourceList, success := GetCFSSourcesListAPI()
+	cfsSourceList, success := GetCFSSourcesListAPI(ctx)
 	if !success {
-		common.Errorf("Failed to get CFS sources list")
-		return CFSSources{}, false
+		common.Errorf(ctx, "Failed to get CFS sources list")
+		return cfsclient.Source{}, false
 	}
 
 	// Check if the source is in the list
-	if !CFSSourceExists(cfsSourceList, sourceName) {
-		return CFSSources{}, false
+	if !CFSSourceExists(ctx, cfsSourceList, sourceName) {
+		return cfsclient.Source{}, false
 	}
 
 	// verify the source record
-	if !VerifyCFSSourceRecord(cfsSourceRecord, sourceName, firstCloneURL) {
-		return CFSSources{}, false
+	if !VerifyCFSSourceRecord(ctx, cfsSourceRecord, sourceName, firstCloneURL()) {
+		return cfsclient.Source{}, false
 	}
 
-	common.Infof("CFS source %s created successfully", sourceName)
+	common.Infof(ctx, "CFS source %s created successfully", sourceName)
 	return cfsSourceRecord, true
 }
 
-func TestCFSSourceUpdate(sourceName string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Updating CFS source: %s", sourceName))
+func TestCFSSourceUpdate(ctx context.Context, sourceName string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSSourceUpdate").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Updating CFS source: %s", sourceName))
 
 	// Update the CFS source record
-	cfsSourceRecord, success := UpdateCFSSourceRecordAPI(sourceName)
+	cfsSourceRecord, success := UpdateCFSSourceRecordAPI(ctx, sourceName)
 	if !success {
 		return false
 	}
 
 	// Get the CFS source record
-	_, success = GetCFSSourceRecordAPI(sourceName, http.StatusOK)
+	_, success = GetCFSSourceRecordAPI(ctx, sourceName, http.StatusOK)
 	if !success {
-		common.Errorf("Failed to get CFS source record: %s", sourceName)
+		common.Errorf(ctx, "Failed to get CFS source record: %s", sourceName)
 		return false
 	}
 
 	// verify CFS source is in the list of sources
-	cfsSourceList, success := GetCFSSourcesListAPI()
+	cfsSourceList, success := GetCFSSourcesListAPI(ctx)
 	if !success {
-		common.Errorf("Failed to get CFS sources list")
+		common.Errorf(ctx, "Failed to get CFS sources list")
 		return false
 	}
 
 	// Check if the source is in the list
-	if !CFSSourceExists(cfsSourceList, sourceName) {
+	if !CFSSourceExists(ctx, cfsSourceList, sourceName) {
 		return false
 	}
 
 	// Verify the CFS source record
-	if !VerifyCFSSourceRecord(cfsSourceRecord, sourceName, secondCloneURL) {
+	if !VerifyCFSSourceRecord(ctx, cfsSourceRecord, sourceName, secondCloneURL()) {
 		return false
 	}
 
-	common.Infof("CFS source %s updated successfully", sourceName)
+	common.Infof(ctx, "CFS source %s updated successfully", sourceName)
 	return true
 }
 
-func TestCFSSourceDelete(sourceName string) (passed bool) {
-	common.PrintLog(fmt.Sprintf("Deleting CFS source: %s", sourceName))
+func TestCFSSourceDelete(ctx context.Context, sourceName string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSSourceDelete").End(ctx, &passed)
+	common.PrintLog(ctx, fmt.Sprintf("Deleting CFS source: %s", sourceName))
 
 	// Delete the CFS source record
-	success := DeleteCFSSourceRecordAPI(sourceName)
+	success := DeleteCFSSourceRecordAPI(ctx, sourceName)
 	if !success {
 		return false
 	}
 
 	// Verify the CFS source record is deleted
-	_, success = GetCFSSourceRecordAPI(sourceName, http.StatusNotFound)
+	_, success = GetCFSSourceRecordAPI(ctx, sourceName, http.StatusNotFound)
 	if !success {
 		return false
 	}
 
 	// verify CFS source is not in the list of sources
-	cfsSourceList, success := GetCFSSourcesListAPI()
+	cfsSourceList, success := GetCFSSourcesListAPI(ctx)
 	if !success {
 		return false
 	}
 
 	// Check if the source is in the list
-	if CFSSourceExists(cfsSourceList, sourceName) {
+	if CFSSourceExists(ctx, cfsSourceList, sourceName) {
 		return false
 	}
 
-	common.Infof("CFS source %s deleted successfully", sourceName)
+	common.Infof(ctx, "CFS source %s deleted successfully", sourceName)
 	return true
 }
 
-func TestCFSSourceGetAll() (passed bool) {
-	common.PrintLog("Getting all CFS sources")
+func TestCFSSourceGetAll(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSSourceGetAll").End(ctx, &passed)
+	common.PrintLog(ctx, "Getting all CFS sources")
 	// Get CFS sources list
-	cfsSources, success := GetCFSSourcesListAPI()
+	cfsSources, success := GetCFSSourcesListAPI(ctx)
 	if !success {
 		return false
 	}
 
 	for _, source := range cfsSources {
-		common.Infof("CFS source: %s", source.Name)
+		common.Infof(ctx, "CFS source: %s", source.Name)
 	}
 
-	common.Infof("Found %d CFS sources", len(cfsSources))
+	common.Infof(ctx, "Found %d CFS sources", len(cfsSources))
 	return true
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,158 +29,165 @@
 package cfs
 
 import (
+	"context"
+	cfsclient "stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cfs"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func TestCFSSourcesCRUDOperationUsingCLI() (passed bool) {
+func TestCFSSourcesCRUDOperationUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCFSSourcesCRUDOperationUsingCLI").End(ctx, &passed)
 	passed = true
 
 	// Create a CFS configuration using CLI
-	cfsConfigurationRecord, success := TestCLICFSSourcesCreate("v3")
+	cfsConfigurationRecord, success := TestCLICFSSourcesCreate(ctx, "v3")
 	if !success {
 		return false
 	}
 
 	if len(cfsConfigurationRecord.Name) != 0 {
 		// Update the CFS configuration using CLI
-		updated := TestCLICFSSourcesUpdate(cfsConfigurationRecord.Name, "v3")
+		updated := TestCLICFSSourcesUpdate(ctx, cfsConfigurationRecord.Name, "v3")
 
 		// Delete the CFS configuration using CLI
-		deleted := TestCLICFSSourcesDelete(cfsConfigurationRecord.Name, "v3")
+		deleted := TestCLICFSSourcesDelete(ctx, cfsConfigurationRecord.Name, "v3")
 
 		// Get all CFS configurations using CLI
-		getAll := TestCLICFSSourcesGetAll("v3")
+		getAll := TestCLICFSSourcesGetAll(ctx, "v3")
 		return passed && updated && deleted && getAll
 	}
 	return passed
 }
 
-func TestCLICFSSourcesCreate(cliVersion string) (cfsSourceRecord CFSSources, passed bool) {
+func TestCLICFSSourcesCreate(ctx context.Context, cliVersion string) (cfsSourceRecord cfsclient.Source, passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSSourcesCreate").End(ctx, &passed)
 	passed = true
 	sourceName := "CFS_Source_" + string(common.GetRandomString(10))
-	common.PrintLog("Creating CFS source using CLI: " + sourceName)
+	common.PrintLog(ctx, "Creating CFS source using CLI: "+sourceName)
 
 	// Create a new CFS source record
-	cfsSourceRecord, success := CreateCFSSourceRecordCLI(sourceName, firstCloneURL, cliVersion)
+	cfsSourceRecord, success := CreateCFSSourceRecordCLI(ctx, sourceName, firstCloneURL(), cliVersion)
 	if !success {
-		return CFSSources{}, false
+		return cfsclient.Source{}, false
 	}
 
 	// Get the CFS source record
-	_, success = GetCFSSourceRecordCLI(sourceName, cliVersion)
+	_, success = GetCFSSourceRecordCLI(ctx, sourceName, cliVersion)
 	if !success {
-		common.Errorf("Failed to get CFS source record: %s", sourceName)
-		return CFSSources{}, false
+		common.Errorf(ctx, "Failed to get CFS source record: %s", sourceName)
+		return cfsclient.Source{}, false
 	}
 
 	// verify CFS source is in the list of sources
-	cfsSourceList, success := GetCFSSourcesListCLI(cliVersion)
+	cfsSourceList, success := GetCFSSourcesListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Failed to get CFS sources list")
-		return CFSSources{}, false
+		common.Errorf(ctx, "Failed to get CFS sources list")
+		return cfsclient.Source{}, false
 	}
 
 	// Check if the source is in the list
-	if !CFSSourceExists(cfsSourceList, sourceName) {
-		return CFSSources{}, false
+	if !CFSSourceExists(ctx, cfsSourceList, sourceName) {
+		return cfsclient.Source{}, false
 	}
 
 	// verify the source record
-	if !VerifyCFSSourceRecord(cfsSourceRecord, sourceName, firstCloneURL) {
-		return CFSSources{}, false
+	if !VerifyCFSSourceRecord(ctx, cfsSourceRecord, sourceName, firstCloneURL()) {
+		return cfsclient.Source{}, false
 	}
 
-	common.Infof("CFS source %s created successfully", sourceName)
+	common.Infof(ctx, "CFS source %s created successfully", sourceName)
 	return cfsSourceRecord, true
 }
 
-func TestCLICFSSourcesUpdate(sourceName, cliVersion string) (passed bool) {
-	common.PrintLog("Updating CFS source using CLI: " + sourceName)
+func TestCLICFSSourcesUpdate(ctx context.Context, sourceName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSSourcesUpdate").End(ctx, &passed)
+	common.PrintLog(ctx, "Updating CFS source using CLI: "+sourceName)
 
 	// Update the CFS source record
-	cfsSourceRecord, success := UpdateCFSSourceRecordCLI(sourceName, secondCloneURL, cliVersion)
+	cfsSourceRecord, success := UpdateCFSSourceRecordCLI(ctx, sourceName, secondCloneURL(), cliVersion)
 	if !success {
 		return false
 	}
 
 	// Get the CFS source record
-	_, success = GetCFSSourceRecordCLI(sourceName, cliVersion)
+	_, success = GetCFSSourceRecordCLI(ctx, sourceName, cliVersion)
 	if !success {
-		common.Errorf("Failed to get CFS source record: %s", sourceName)
+		common.Errorf(ctx, "Failed to get CFS source record: %s", sourceName)
 		return false
 	}
 
 	// verify the source record
-	if !VerifyCFSSourceRecord(cfsSourceRecord, sourceName, secondCloneURL) {
+	if !VerifyCFSSourceRecord(ctx, cfsSourceRecord, sourceName, secondCloneURL()) {
 		return false
 	}
 
 	// verify CFS source is in the list of sources
-	cfsSourceList, success := GetCFSSourcesListCLI(cliVersion)
+	cfsSourceList, success := GetCFSSourcesListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Failed to get CFS sources list")
+		common.Errorf(ctx, "Failed to get CFS sources list")
 		return false
 	}
 
 	// Check if the source is in the list
-	if !CFSSourceExists(cfsSourceList, sourceName) {
+	if !CFSSourceExists(ctx, cfsSourceList, sourceName) {
 		return false
 	}
 
-	common.Infof("CFS source %s updated successfully", sourceName)
+	common.Infof(ctx, "CFS source %s updated successfully", sourceName)
 	return true
 }
 
-func TestCLICFSSourcesDelete(sourceName, cliVersion string) (passed bool) {
-	common.PrintLog("Deleting CFS source using CLI: " + sourceName)
+func TestCLICFSSourcesDelete(ctx context.Context, sourceName, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSSourcesDelete").End(ctx, &passed)
+	common.PrintLog(ctx, "Deleting CFS source using CLI: "+sourceName)
 
 	// Delete the CFS source record
-	success := DeleteCFSSourceRecordCLI(sourceName, cliVersion)
+	success := DeleteCFSSourceRecordCLI(ctx, sourceName, cliVersion)
 	if !success {
 		return false
 	}
 
 	// Set CLI execution return code to 2. Since cfs configuration is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 
 	// Get the CFS source record
-	_, success = GetCFSSourceRecordCLI(sourceName, cliVersion)
+	_, success = GetCFSSourceRecordCLI(ctx, sourceName, cliVersion)
 	if success {
-		common.Errorf("CFS source %s was not deleted successfully", sourceName)
+		common.Errorf(ctx, "CFS source %s was not deleted successfully", sourceName)
 		return false
 	}
 
 	// Set CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// verify CFS source is not in the list of sources
-	cfsSourceList, success := GetCFSSourcesListCLI(cliVersion)
+	cfsSourceList, success := GetCFSSourcesListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Failed to get CFS sources list")
+		common.Errorf(ctx, "Failed to get CFS sources list")
 		return false
 	}
 
 	// Check if the source is in the list
-	if CFSSourceExists(cfsSourceList, sourceName) {
-		common.Errorf("CFS source %s was found in the list of cfs sources", sourceName)
+	if CFSSourceExists(ctx, cfsSourceList, sourceName) {
+		common.Errorf(ctx, "CFS source %s was found in the list of cfs sources", sourceName)
 		return false
 	}
 
-	common.Infof("CFS source %s deleted successfully", sourceName)
+	common.Infof(ctx, "CFS source %s deleted successfully", sourceName)
 	return true
 }
 
-func TestCLICFSSourcesGetAll(cliVersion string) (passed bool) {
-	common.PrintLog("Getting all CFS sources using CLI")
+func TestCLICFSSourcesGetAll(ctx context.Context, cliVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLICFSSourcesGetAll").End(ctx, &passed)
+	common.PrintLog(ctx, "Getting all CFS sources using CLI")
 
 	// Get all CFS sources using CLI
-	cfsSourceList, success := GetCFSSourcesListCLI(cliVersion)
+	cfsSourceList, success := GetCFSSourcesListCLI(ctx, cliVersion)
 	if !success {
-		common.Errorf("Unable to get CFS sources list using CLI")
+		common.Errorf(ctx, "Unable to get CFS sources list using CLI")
 		return false
 	}
 
-	common.Infof("Found %d CFS sources", len(cfsSourceList))
+	common.Infof(ctx, "Found %d CFS sources", len(cfsSourceList))
 	return true
 }
@@ -0,0 +1,130 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * cfs_sessions_rc.go
+ *
+ * CFS sessions race condition test. Creates pending CFS sessions, then checks how CFS
+ * handles concurrent requests to delete and get them.
+ *
+ */
+
+package cfs_sessions_rc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+)
+
+// The CFS operator is scaled down while the test runs, so that the sessions it creates stay pending
+const cfsOperatorDeployment = "cray-cfs-operator"
+const operatorScaleDownTimeout = 5 * time.Minute
+
+// The annotation of the CFS operator deployment which holds its replica count while the test
+// has it scaled down
+const operatorReplicasAnnotation = "cmsdev.cray.com/cfs-sessions-rc-replicas"
+
+// The replica count which the CFS operator is restored to if it was left scaled down with no
+// saved replica count (the default in its Helm chart)
+const defaultOperatorReplicas = 1
+
+const cfsSessionKind = "CFS session"
+const cfsConfigurationKind = "CFS configuration"
+const cfsOptionKind = "CFS option"
+const deploymentKind = "Kubernetes deployment"
+
+// Subtest names, for use with the --only and --skip options
+const setupSubtest = "cfs-sessions-rc.setup"
+
+var raceSubtests = []string{
+	"cfs-sessions-rc.single-delete",
+	"cfs-sessions-rc.single-delete-single-get",
+	"cfs-sessions-rc.single-delete-multi-get",
+	"cfs-sessions-rc.multi-delete",
+	"cfs-sessions-rc.multi-delete-single-get",
+	"cfs-sessions-rc.multi-delete-multi-get",
+}
+
+func init() {
+	registry.Register(registry.ServiceTest{
+		Name:           "cfs-sessions-rc",
+		Description:    "CFS sessions race conditions: concurrent session deletes and gets (scales down the CFS operator)",
+		DefaultTimeout: 900,
+		ExplicitOnly:   true,
+		Subtests:       append([]string{setupSubtest}, raceSubtests...),
+		Run: func(opts registry.RunOptions) bool {
+			return newRaceTest(opts.Context, common.GetConfig().CFSSessionsRC).run()
+		},
+		FindLeftovers: findCFSSessionsRCLeftovers,
+	})
+}
+
+// The state of one run of the test
+type raceTest struct {
+	common.CFSSessionsRCConfig
+	// The context of the test's run, for the goroutines making concurrent requests
+	ctx context.Context
+	// The page size used when listing sessions with CFS v3
+	pageSize int
+}
+
+func newRaceTest(ctx context.Context, settings common.CFSSessionsRCConfig) *raceTest {
+	t := &raceTest{CFSSessionsRCConfig: settings, ctx: ctx, pageSize: settings.PageSize}
+	if t.pageSize == 0 {
+		t.pageSize = 10 * t.MaxSessions
+	}
+	return t
+}
+
+func (t *raceTest) run() (passed bool) {
+	common.Infof(t.ctx, "CFS sessions race condition test: CFS %s, session name prefix '%s', up to %d sessions",
+		t.CFSVersion, t.NamePrefix, t.MaxSessions)
+	if !registry.RunSubtestNeededBy(t.ctx, setupSubtest, raceSubtests, func(context.Context) bool { return t.setup() }) {
+		// Without the setup, the sessions would not stay pending
+		return false
+	}
+
+	passed = true
+	subtests := []func(context.Context) bool{
+		func(context.Context) bool { return t.runSubtest(false, noGets) },
+		func(context.Context) bool { return t.runSubtest(false, singleGets) },
+		func(context.Context) bool { return t.runSubtest(false, multiGets) },
+		func(context.Context) bool { return t.runSubtest(true, noGets) },
+		func(context.Context) bool { return t.runSubtest(true, singleGets) },
+		func(context.Context) bool { return t.runSubtest(true, multiGets) },
+	}
+	for i, subtest := range subtests {
+		if !registry.RunSubtest(t.ctx, raceSubtests[i], subtest) {
+			passed = false
+		}
+	}
+	return
+}
+
+// The URL of the CFS sessions collection, for the CFS version being tested
+func (t *raceTest) sessionsURL() string {
+	return fmt.Sprintf("%s/apis/cfs/%s/sessions", common.BASEURL, t.CFSVersion)
+}
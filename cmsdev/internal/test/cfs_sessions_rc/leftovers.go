@@ -0,0 +1,126 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * leftovers.go
+ *
+ * Finding CFS sessions and configurations left behind by earlier runs of the CFS sessions
+ * race condition test
+ *
+ */
+
+package cfs_sessions_rc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// Find the pending sessions and the configuration created by the test, using the name prefix
+// from the current settings, and the CFS operator if it was left scaled down. Sessions are listed
+// first, since they use the configuration, and the operator last, so that the sessions are gone
+// before it would start them.
+func findCFSSessionsRCLeftovers(opts registry.CleanupOptions) (leftovers []registry.Leftover, ok bool) {
+	ctx := opts.Context
+	operator, ok := findOperatorLeftover(ctx)
+	if operator != nil {
+		defer func() { leftovers = append(leftovers, *operator) }()
+	}
+
+	settings := common.GetConfig().CFSSessionsRC
+	settings.CFSVersion = "v3"
+	t := newRaceTest(ctx, settings)
+	params := test.GetAccessTokenParams(ctx)
+	if params == nil {
+		return nil, false
+	}
+
+	sessionNamePattern := regexp.MustCompile("^" + regexp.QuoteMeta(t.NamePrefix) + "[0-9]+$")
+	sessions, status, err := t.listSessions(common.Restful, *params)
+	if err != nil || status != http.StatusOK {
+		common.Warnf(ctx, "Unable to list CFS sessions (status code %d): %v", status, err)
+		return nil, false
+	}
+	for _, session := range sessions {
+		if name, isSession := sessionName(session); isSession && sessionNamePattern.MatchString(name) {
+			url := t.sessionsURL() + "/" + name
+			leftovers = append(leftovers, registry.Leftover{
+				Kind: cfsSessionKind, Name: name,
+				Delete: func() bool { return test.DeleteURLs(ctx, url) },
+			})
+		}
+	}
+
+	name := t.NamePrefix + "config"
+	url := common.BASEURL + "/apis/cfs/v3/configurations/" + name
+	resp, err := common.Restful(ctx, "GET", url, *params)
+	if err != nil {
+		common.Warnf(ctx, "GET %s failed: %v", url, err)
+		return leftovers, false
+	} else if resp.StatusCode() == http.StatusOK {
+		leftovers = append(leftovers, registry.Leftover{
+			Kind: cfsConfigurationKind, Name: name,
+			Delete: func() bool { return test.DeleteURLs(ctx, url) },
+		})
+	} else if resp.StatusCode() != http.StatusNotFound {
+		common.Warnf(ctx, "GET %s: received status code %d", url, resp.StatusCode())
+		return leftovers, false
+	}
+	return leftovers, ok
+}
+
+// Returns the CFS operator as a leftover if it has 0 replicas and a replica count was saved
+// when the test scaled it down, or if there is no saved count (in which case it is restored to
+// the default). Returns nil if it is not scaled down, or there is no Kubernetes cluster.
+func findOperatorLeftover(ctx context.Context) (leftover *registry.Leftover, ok bool) {
+	if !common.ClusterAvailable() {
+		return nil, true
+	}
+	replicas, err := k8s.GetDeploymentReplicas(ctx, common.NAMESPACE, cfsOperatorDeployment)
+	if err != nil {
+		common.Warnf(ctx, "Unable to get the replica count of deployment %s: %v", cfsOperatorDeployment, err)
+		return nil, false
+	} else if replicas > 0 {
+		return nil, true
+	}
+	saved, found, err := savedOperatorReplicas(ctx)
+	if err != nil {
+		common.Warnf(ctx, "%v", err)
+		return nil, false
+	} else if !found {
+		common.Warnf(ctx, "Deployment %s has 0 replicas, and no replica count was saved when it was scaled down; it will be restored to %d",
+			cfsOperatorDeployment, defaultOperatorReplicas)
+		saved = defaultOperatorReplicas
+	}
+	return &registry.Leftover{
+		Kind:   deploymentKind,
+		Name:   fmt.Sprintf("%s (scaled down from %d replicas)", cfsOperatorDeployment, saved),
+		Delete: func() bool { return restoreOperator(ctx, saved) },
+	}, true
+}
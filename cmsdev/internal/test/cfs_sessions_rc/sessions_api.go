@@ -0,0 +1,245 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * sessions_api.go
+ *
+ * CFS session and configuration requests made by the CFS sessions race condition test
+ *
+ */
+
+package cfs_sessions_rc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"gopkg.in/resty.v1"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// The layer of the configuration which the test creates, if there is none it can use. The
+// sessions are never run, so it does not matter that the repository does not exist.
+var dummyLayer = map[string]string{
+	"clone_url": "https://dummy-server-nmn.local/vcs/cray/example-repo.git",
+	"commit":    "43ecfa8236bed625b54325ebb70916f599999999",
+	"playbook":  "compute_nodes.yml",
+	"name":      "compute",
+}
+
+// common.Restful, or common.RestfulNoRetry for the concurrent requests being tested
+type restfulFunc func(ctx context.Context, method, url string, params common.Params, options ...common.RequestOption) (*resty.Response, error)
+
+// The query parameters which select the pending sessions created by the test
+func (t *raceTest) sessionQuery() url.Values {
+	query := url.Values{}
+	query.Set("status", "pending")
+	query.Set("name_contains", t.NamePrefix)
+	return query
+}
+
+// List the pending sessions created by the test, following the next links of CFS v3 to get
+// every page. Each session is returned as decoded from JSON, so that the caller can check
+// that it is an object. If a request fails, the sessions listed so far are returned along
+// with the status code (0 if there was no response) or error.
+func (t *raceTest) listSessions(restful restfulFunc, params common.Params) (sessions []interface{}, status int, err error) {
+	query := t.sessionQuery()
+	if t.CFSVersion == "v3" {
+		query.Set("limit", strconv.Itoa(t.pageSize))
+	}
+	for {
+		listURL := t.sessionsURL() + "?" + query.Encode()
+		common.Debugf(t.ctx, "GET %s", listURL)
+		resp, err := restful(t.ctx, "GET", listURL, params)
+		if err != nil {
+			return sessions, 0, fmt.Errorf("GET %s failed: %v", listURL, err)
+		} else if resp.StatusCode() != http.StatusOK {
+			return sessions, resp.StatusCode(), nil
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(resp.Body()))
+		decoder.UseNumber()
+		if t.CFSVersion == "v2" {
+			var page []interface{}
+			if err = decoder.Decode(&page); err != nil {
+				return sessions, resp.StatusCode(), fmt.Errorf("Unable to decode CFS sessions list: %v", err)
+			}
+			return page, resp.StatusCode(), nil
+		}
+		var page struct {
+			Sessions []interface{}          `json:"sessions"`
+			Next     map[string]interface{} `json:"next"`
+		}
+		if err = decoder.Decode(&page); err != nil {
+			return sessions, resp.StatusCode(), fmt.Errorf("Unable to decode CFS sessions list: %v", err)
+		}
+		sessions = append(sessions, page.Sessions...)
+		if len(page.Next) == 0 {
+			return sessions, resp.StatusCode(), nil
+		}
+		// The next link holds the query parameters for the next page
+		for key, value := range page.Next {
+			if value != nil {
+				query.Set(key, fmt.Sprint(value))
+			}
+		}
+	}
+}
+
+// Returns the name of a session, as decoded from JSON, and whether it is an object with a name
+func sessionName(session interface{}) (name string, ok bool) {
+	object, ok := session.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok = object["name"].(string)
+	return
+}
+
+// Delete all of the pending sessions created by the test, with one request
+func (t *raceTest) deleteSessions(params common.Params) bool {
+	deleteURL := t.sessionsURL() + "?" + t.sessionQuery().Encode()
+	common.Infof(t.ctx, "DELETE %s", deleteURL)
+	resp, err := common.Restful(t.ctx, "DELETE", deleteURL, params)
+	if err != nil {
+		common.Errorf(t.ctx, "DELETE %s failed: %v", deleteURL, err)
+		return false
+	} else if resp.StatusCode() == http.StatusBadRequest {
+		// CFS responds this way when there is nothing to delete
+		common.Infof(t.ctx, "Received status code %d; there are no sessions to delete", resp.StatusCode())
+		return true
+	} else if resp.StatusCode() != t.multiDeleteStatus() {
+		common.Errorf(t.ctx, "DELETE %s: expected status code %d, got %d", deleteURL, t.multiDeleteStatus(), resp.StatusCode())
+		return false
+	}
+	common.Infof(t.ctx, "Received status code %d, as expected", resp.StatusCode())
+	return true
+}
+
+// The status code of a successful request to delete multiple sessions
+func (t *raceTest) multiDeleteStatus() int {
+	if t.CFSVersion == "v2" {
+		return http.StatusNoContent
+	}
+	return http.StatusOK
+}
+
+// Returns the name of a configuration for the sessions to use. The first configuration
+// listed is used; if there are none, one is created, and true is returned to show that it
+// should be deleted afterwards.
+func findOrCreateConfiguration(ctx context.Context, params common.Params, namePrefix string) (name string, created, ok bool) {
+	configurationsURL := common.BASEURL + "/apis/cfs/v3/configurations"
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", configurationsURL+"?limit=1", params, http.StatusOK)
+	if err != nil {
+		common.Error(ctx, err)
+		return
+	}
+	var list struct {
+		Configurations []struct {
+			Name string `json:"name"`
+		} `json:"configurations"`
+	}
+	if err = json.Unmarshal(resp.Body(), &list); err != nil {
+		common.Errorf(ctx, "Unable to decode CFS configurations list: %v", err)
+		return
+	} else if len(list.Configurations) > 0 {
+		common.Infof(ctx, "Using existing CFS configuration %s", list.Configurations[0].Name)
+		return list.Configurations[0].Name, false, true
+	}
+
+	name = namePrefix + "config"
+	configurationURL := configurationsURL + "/" + name
+	payload, err := json.Marshal(map[string]interface{}{"layers": []map[string]string{dummyLayer}})
+	if err != nil {
+		common.Errorf(ctx, "Unable to encode CFS configuration: %v", err)
+		return
+	}
+	params.JsonStr = string(payload)
+	if _, err = test.RestfulVerifyStatus(ctx, "PUT", configurationURL, params, http.StatusOK); err != nil {
+		common.Error(ctx, err)
+		return
+	}
+	test.RegisterAPIDeleteCleanup(ctx, cfsConfigurationKind, name, configurationURL)
+	return name, true, true
+}
+
+// Create count sessions, named with the name prefix followed by a number, and check that
+// exactly those sessions are listed as pending
+func (t *raceTest) createSessions(params common.Params, configurationName string, count int) (names []string, ok bool) {
+	expectedStatus := http.StatusCreated
+	configurationField := "configuration_name"
+	if t.CFSVersion == "v2" {
+		expectedStatus = http.StatusOK
+		configurationField = "configurationName"
+	}
+	target := map[string]interface{}{
+		"definition": "spec",
+		"groups":     []map[string]interface{}{{"name": "Compute", "members": []string{"fakexname"}}},
+	}
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("%s%d", t.NamePrefix, i)
+		payload, err := json.Marshal(map[string]interface{}{"name": name, configurationField: configurationName, "target": target})
+		if err != nil {
+			common.Errorf(t.ctx, "Unable to encode CFS session: %v", err)
+			return
+		}
+		params.JsonStrArray = payload
+		if _, err = test.RestfulVerifyStatus(t.ctx, "POST", t.sessionsURL(), params, expectedStatus); err != nil {
+			common.Error(t.ctx, err)
+			return
+		}
+		names = append(names, name)
+	}
+
+	sessions, status, err := t.listSessions(common.Restful, params)
+	if err != nil {
+		common.Error(t.ctx, err)
+		return
+	} else if status != http.StatusOK {
+		common.Errorf(t.ctx, "Listing CFS sessions: expected status code %d, got %d", http.StatusOK, status)
+		return
+	}
+	listed := make(map[string]bool, len(sessions))
+	for _, session := range sessions {
+		if name, isSession := sessionName(session); isSession {
+			listed[name] = true
+		}
+	}
+	if len(listed) != len(names) || len(sessions) != len(names) {
+		common.Errorf(t.ctx, "Created %d sessions, but %d pending sessions are listed", len(names), len(sessions))
+		return
+	}
+	for _, name := range names {
+		if !listed[name] {
+			common.Errorf(t.ctx, "Created session %s is not listed as pending", name)
+			return
+		}
+	}
+	common.Infof(t.ctx, "Created %d sessions, which are all pending", len(names))
+	return names, true
+}
@@ -0,0 +1,221 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * setup.go
+ *
+ * Setup of the CFS sessions race condition test, and its restoration of what it changed
+ *
+ */
+
+package cfs_sessions_rc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// The CFS default_page_size option, when it has not been set
+const defaultCFSPageSize = 1000
+
+// Make sure that session lists are not too long for CFS, delete (or fail on) sessions left
+// behind by earlier runs, and scale down the CFS operator so that new sessions stay pending.
+// What is changed is restored by the cleanups registered here, when the test attempt ends.
+func (t *raceTest) setup() bool {
+	params := test.GetAccessTokenParams(t.ctx)
+	if params == nil {
+		return false
+	}
+	if t.CFSVersion == "v2" && !t.setV2PageSize(*params) {
+		return false
+	}
+	if !t.deletePreviousSessions(*params) {
+		return false
+	}
+	return t.scaleDownOperator()
+}
+
+func cfsOptionsURL() string {
+	return common.BASEURL + "/apis/cfs/v3/options"
+}
+
+// CFS v2 does not page session lists. It fails a list request which would return more
+// sessions than the default_page_size option (which can only be set through CFS v3), so
+// the option is raised if needed.
+func (t *raceTest) setV2PageSize(params common.Params) bool {
+	resp, err := test.RestfulVerifyStatus(t.ctx, "GET", cfsOptionsURL(), params, http.StatusOK)
+	if err != nil {
+		common.Error(t.ctx, err)
+		return false
+	}
+	var options struct {
+		DefaultPageSize *int `json:"default_page_size"`
+	}
+	if err := json.Unmarshal(resp.Body(), &options); err != nil {
+		common.Errorf(t.ctx, "Unable to decode CFS options: %v", err)
+		return false
+	}
+	current := defaultCFSPageSize
+	if options.DefaultPageSize != nil {
+		current = *options.DefaultPageSize
+	}
+
+	// A page size from the settings must be at least max_sessions (see validateCFSSessionsRC)
+	if t.PageSize > 0 {
+		t.pageSize = t.PageSize
+	} else {
+		t.pageSize = max(10*t.MaxSessions, current)
+	}
+	if t.pageSize == current {
+		common.Infof(t.ctx, "CFS default_page_size is already %d", current)
+		return true
+	}
+	if err := setCFSPageSize(t.ctx, params, t.pageSize); err != nil {
+		common.Error(t.ctx, err)
+		return false
+	}
+	common.RegisterCleanup(t.ctx, cfsOptionKind, "default_page_size", func() bool {
+		params := test.GetAccessTokenParams(t.ctx)
+		if params == nil {
+			return false
+		}
+		if err := setCFSPageSize(t.ctx, *params, current); err != nil {
+			common.Warnf(t.ctx, "%v", err)
+			return false
+		}
+		return true
+	})
+	return true
+}
+
+func setCFSPageSize(ctx context.Context, params common.Params, pageSize int) (err error) {
+	common.Infof(ctx, "Setting CFS default_page_size to %d", pageSize)
+	params.JsonStrArray, err = json.Marshal(map[string]int{"default_page_size": pageSize})
+	if err != nil {
+		return fmt.Errorf("Unable to encode CFS options: %v", err)
+	}
+	_, err = test.RestfulVerifyStatus(ctx, "PATCH", cfsOptionsURL(), params, http.StatusOK)
+	return
+}
+
+// Sessions left behind by an earlier run would spoil the checks of which sessions are listed
+// and deleted, so they must be deleted first. Unless delete_previous_sessions is set, that is
+// left to the user.
+func (t *raceTest) deletePreviousSessions(params common.Params) bool {
+	common.Infof(t.ctx, "Checking for pending CFS sessions with '%s' in their names", t.NamePrefix)
+	sessions, status, err := t.listSessions(common.Restful, params)
+	if err != nil {
+		common.Error(t.ctx, err)
+		return false
+	} else if status != http.StatusOK {
+		common.Errorf(t.ctx, "Listing CFS sessions: expected status code %d, got %d", http.StatusOK, status)
+		return false
+	} else if len(sessions) == 0 {
+		common.Infof(t.ctx, "No CFS sessions found")
+		return true
+	} else if !t.DeletePreviousSessions {
+		common.Errorf(t.ctx, "Found %d pending CFS sessions with '%s' in their names, from an earlier run. Delete them, "+
+			"use a different name_prefix, or set delete_previous_sessions.", len(sessions), t.NamePrefix)
+		return false
+	}
+	return t.deleteSessions(params)
+}
+
+// Scale the CFS operator down to 0 replicas, and wait for its pods to go away. Its replica count
+// is saved in an annotation of the deployment until it is restored, so that it can be restored
+// if the run ends without doing so (see findCFSSessionsRCLeftovers). There is no operator to scale
+// when the test is run against the cmsdev mock server.
+func (t *raceTest) scaleDownOperator() bool {
+	if !common.ClusterAvailable() {
+		common.Infof(t.ctx, "No Kubernetes cluster; not scaling down %s", cfsOperatorDeployment)
+		return true
+	}
+	replicas, err := k8s.GetDeploymentReplicas(t.ctx, common.NAMESPACE, cfsOperatorDeployment)
+	if err != nil {
+		common.Errorf(t.ctx, "Unable to get the replica count of deployment %s: %v", cfsOperatorDeployment, err)
+		return false
+	}
+	common.Infof(t.ctx, "Deployment %s has %d replicas", cfsOperatorDeployment, replicas)
+	saved, found, err := savedOperatorReplicas(t.ctx)
+	if err != nil {
+		common.Error(t.ctx, err)
+		return false
+	} else if replicas == 0 && found {
+		common.Infof(t.ctx, "Deployment %s was left scaled down by an earlier run, from %d replicas", cfsOperatorDeployment, saved)
+		replicas = saved
+	} else if replicas > 0 {
+		if err = k8s.SetDeploymentAnnotation(t.ctx, common.NAMESPACE, cfsOperatorDeployment, operatorReplicasAnnotation, strconv.Itoa(replicas)); err != nil {
+			common.Errorf(t.ctx, "Unable to save the replica count of deployment %s: %v", cfsOperatorDeployment, err)
+			return false
+		}
+		if err = k8s.SetDeploymentReplicas(t.ctx, common.NAMESPACE, cfsOperatorDeployment, 0); err != nil {
+			common.Errorf(t.ctx, "Unable to scale down deployment %s: %v", cfsOperatorDeployment, err)
+			return false
+		}
+	}
+	if replicas > 0 {
+		common.RegisterCleanup(t.ctx, deploymentKind, cfsOperatorDeployment, func() bool {
+			return restoreOperator(t.ctx, replicas)
+		})
+	}
+	if err = k8s.WaitForDeploymentScaledDown(t.ctx, common.NAMESPACE, cfsOperatorDeployment, operatorScaleDownTimeout); err != nil {
+		common.Error(t.ctx, err)
+		return false
+	}
+	return true
+}
+
+// Returns the replica count of the CFS operator which was saved when it was scaled down, and
+// whether there is one
+func savedOperatorReplicas(ctx context.Context) (replicas int, found bool, err error) {
+	value, found, err := k8s.GetDeploymentAnnotation(ctx, common.NAMESPACE, cfsOperatorDeployment, operatorReplicasAnnotation)
+	if err != nil {
+		err = fmt.Errorf("Unable to get the annotations of deployment %s: %v", cfsOperatorDeployment, err)
+		return
+	} else if !found {
+		return
+	}
+	if replicas, err = strconv.Atoi(value); err != nil || replicas < 1 {
+		err = fmt.Errorf("Deployment %s has an invalid %s annotation: '%s'", cfsOperatorDeployment, operatorReplicasAnnotation, value)
+	}
+	return
+}
+
+// Scale the CFS operator back up to the specified replica count, and remove the saved count
+func restoreOperator(ctx context.Context, replicas int) bool {
+	if err := k8s.SetDeploymentReplicas(ctx, common.NAMESPACE, cfsOperatorDeployment, replicas); err != nil {
+		common.Warnf(ctx, "Unable to restore deployment %s to %d replicas: %v", cfsOperatorDeployment, replicas, err)
+		return false
+	}
+	if err := k8s.SetDeploymentAnnotation(ctx, common.NAMESPACE, cfsOperatorDeployment, operatorReplicasAnnotation, ""); err != nil {
+		common.Warnf(ctx, "Unable to remove the %s annotation from deployment %s: %v", operatorReplicasAnnotation, cfsOperatorDeployment, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,351 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * subtests.go
+ *
+ * The race condition subtests: concurrent requests to delete the sessions created by the
+ * test, along with concurrent requests to get them, and the checks of the responses
+ *
+ */
+
+package cfs_sessions_rc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// The requests to get sessions which are made while the sessions are being deleted
+type getKind int
+
+const (
+	noGets     getKind = iota
+	singleGets         // GET each session by name
+	multiGets          // List the sessions
+)
+
+// The response to one of the concurrent requests. status is 0 if there was no response.
+type response struct {
+	status int
+	err    error
+	body   []byte
+	// For requests which list sessions, every session listed
+	sessions []interface{}
+}
+
+// Make a request without retrying it, for use by a worker goroutine
+func request(ctx context.Context, method, url string, params common.Params) response {
+	common.Debugf(ctx, "%s %s", method, url)
+	resp, err := common.RestfulNoRetry(ctx, method, url, params)
+	if err != nil {
+		return response{err: fmt.Errorf("%s %s failed: %v", method, url, err)}
+	}
+	return response{status: resp.StatusCode(), body: resp.Body()}
+}
+
+// Run one subtest. With multiDelete, max_sessions sessions are created, and each delete
+// request deletes all of them. Otherwise one session is created, and each delete request
+// deletes it by name.
+func (t *raceTest) runSubtest(multiDelete bool, gets getKind) (passed bool) {
+	params := test.GetAccessTokenParams(t.ctx)
+	if params == nil {
+		return false
+	}
+	configurationName, created, ok := findOrCreateConfiguration(t.ctx, *params, t.NamePrefix)
+	if !ok {
+		return false
+	} else if created {
+		defer func() {
+			if test.DeleteURLs(t.ctx, common.BASEURL+"/apis/cfs/v3/configurations/"+configurationName) {
+				common.ResourceDeleted(t.ctx, cfsConfigurationKind, configurationName)
+			}
+		}()
+	}
+	// Deferred after the configuration, so that the sessions which use it are deleted first
+	sessionsName := t.NamePrefix + "*"
+	common.RegisterCleanup(t.ctx, cfsSessionKind, sessionsName, t.cleanupSessions)
+	defer func() {
+		if t.cleanupSessions() {
+			common.ResourceDeleted(t.ctx, cfsSessionKind, sessionsName)
+		}
+	}()
+
+	count := 1
+	if multiDelete {
+		count = t.MaxSessions
+	}
+	names, ok := t.createSessions(*params, configurationName, count)
+	if !ok {
+		return false
+	}
+
+	var lock sync.Mutex
+	var deletes, getResponses []response
+	record := func(responses *[]response, r response) {
+		lock.Lock()
+		defer lock.Unlock()
+		*responses = append(*responses, r)
+	}
+	var workers []func(context.Context)
+	deleteURL, deleteRequests := t.sessionsURL()+"/"+names[0], t.MaxSingleDeleteRequests
+	if multiDelete {
+		deleteURL, deleteRequests = t.sessionsURL()+"?"+t.sessionQuery().Encode(), t.MaxMultiDeleteRequests
+	}
+	for i := 0; i < deleteRequests; i++ {
+		workers = append(workers, func(ctx context.Context) { record(&deletes, request(ctx, "DELETE", deleteURL, *params)) })
+	}
+	switch gets {
+	case singleGets:
+		// With one session, every worker gets it. Otherwise the sessions are shared out among
+		// the workers, and each worker gets its sessions in turn.
+		workerCount := min(t.MaxSingleGetRequests, len(names))
+		if !multiDelete {
+			workerCount = t.MaxSingleGetRequests
+		}
+		for i := 0; i < workerCount; i++ {
+			first := i % len(names)
+			workers = append(workers, func(ctx context.Context) {
+				for j := first; j < len(names); j += workerCount {
+					record(&getResponses, request(ctx, "GET", t.sessionsURL()+"/"+names[j], *params))
+				}
+			})
+		}
+	case multiGets:
+		for i := 0; i < t.MaxMultiGetRequests; i++ {
+			workers = append(workers, func(context.Context) {
+				sessions, status, err := t.listSessions(common.RestfulNoRetry, *params)
+				record(&getResponses, response{status: status, err: err, sessions: sessions})
+			})
+		}
+	}
+	// Start the requests in a random order, so that the deletes are not always first
+	common.Shuffle(len(workers), func(i, j int) { workers[i], workers[j] = workers[j], workers[i] })
+	common.Infof(t.ctx, "Making %d concurrent requests", len(workers))
+	common.RunConcurrently(t.ctx, workers...)
+
+	if multiDelete {
+		passed = t.checkMultiDeletes(*params, names, deletes)
+	} else {
+		passed = t.checkSingleDeletes(*params, names[0], deletes)
+	}
+	switch gets {
+	case singleGets:
+		passed = checkSingleGets(t.ctx, names, getResponses) && passed
+	case multiGets:
+		passed = checkMultiGets(t.ctx, names, getResponses) && passed
+	}
+	return
+}
+
+// Delete any pending sessions created by the test which remain. Returns true if there are
+// none left.
+func (t *raceTest) cleanupSessions() bool {
+	params := test.GetAccessTokenParams(t.ctx)
+	if params == nil {
+		return false
+	}
+	sessions, status, err := t.listSessions(common.Restful, *params)
+	if err != nil || status != http.StatusOK {
+		common.Warnf(t.ctx, "Unable to list CFS sessions (status code %d): %v", status, err)
+		return false
+	} else if len(sessions) == 0 {
+		return true
+	}
+	return test.DeleteURLs(t.ctx, t.sessionsURL()+"?"+t.sessionQuery().Encode())
+}
+
+// Exactly one of the requests to delete the session should have succeeded, and the others
+// should have found nothing to delete
+func (t *raceTest) checkSingleDeletes(params common.Params, name string, deletes []response) (passed bool) {
+	passed = true
+	deleted := 0
+	for _, r := range deletes {
+		if r.err != nil {
+			common.Error(t.ctx, r.err)
+			passed = false
+		} else if r.status == http.StatusNoContent {
+			deleted++
+		} else if r.status != http.StatusNotFound {
+			common.Errorf(t.ctx, "Deleting session %s: expected status code %d or %d, got %d",
+				name, http.StatusNoContent, http.StatusNotFound, r.status)
+			passed = false
+		}
+	}
+	if deleted != 1 {
+		common.Errorf(t.ctx, "Expected exactly 1 of the %d requests to delete session %s to succeed, but %d did",
+			len(deletes), name, deleted)
+		passed = false
+	}
+	if _, err := test.RestfulVerifyStatus(t.ctx, "GET", t.sessionsURL()+"/"+name, params, http.StatusNotFound); err != nil {
+		common.Errorf(t.ctx, "Session %s still exists after being deleted: %v", name, err)
+		passed = false
+	}
+	if passed {
+		common.Infof(t.ctx, "Session %s was deleted once, by 1 of %d requests", name, len(deletes))
+	}
+	return
+}
+
+// Every request to delete the sessions should have succeeded, and none of the sessions should
+// remain. With CFS v3, the responses list the sessions deleted, and together they should name
+// each session exactly once.
+func (t *raceTest) checkMultiDeletes(params common.Params, names []string, deletes []response) (passed bool) {
+	passed = true
+	var deletedNames []string
+	for _, r := range deletes {
+		if r.err != nil {
+			common.Error(t.ctx, r.err)
+			passed = false
+		} else if r.status == http.StatusBadRequest {
+			// CFS responds this way when there is nothing to delete
+			common.Infof(t.ctx, "A request to delete sessions found nothing to delete (status code %d)", r.status)
+		} else if r.status != t.multiDeleteStatus() {
+			common.Errorf(t.ctx, "Deleting sessions: expected status code %d, got %d", t.multiDeleteStatus(), r.status)
+			passed = false
+		} else if t.CFSVersion == "v3" {
+			var deleted struct {
+				SessionIDs []string `json:"session_ids"`
+			}
+			if err := json.Unmarshal(r.body, &deleted); err != nil {
+				common.Errorf(t.ctx, "Unable to decode response to deleting sessions: %v", err)
+				passed = false
+				continue
+			}
+			common.Infof(t.ctx, "Deleted sessions: %v", deleted.SessionIDs)
+			deletedNames = append(deletedNames, deleted.SessionIDs...)
+		}
+	}
+
+	sessions, status, err := t.listSessions(common.Restful, params)
+	if err != nil {
+		common.Error(t.ctx, err)
+		passed = false
+	} else if status != http.StatusOK {
+		common.Errorf(t.ctx, "Listing CFS sessions: expected status code %d, got %d", http.StatusOK, status)
+		passed = false
+	} else if len(sessions) > 0 {
+		common.Errorf(t.ctx, "%d sessions remain after being deleted", len(sessions))
+		passed = false
+	}
+
+	if t.CFSVersion == "v3" {
+		created := make(map[string]bool, len(names))
+		for _, name := range names {
+			created[name] = true
+		}
+		seen := make(map[string]bool, len(deletedNames))
+		for _, name := range deletedNames {
+			if !created[name] {
+				common.Errorf(t.ctx, "Session %s was reported as deleted, but was not created by the test", name)
+				passed = false
+			} else if seen[name] {
+				common.Errorf(t.ctx, "Session %s was reported as deleted by more than one request", name)
+				passed = false
+			}
+			seen[name] = true
+		}
+		if len(seen) != len(names) {
+			common.Errorf(t.ctx, "%d sessions were reported as deleted, but %d were created", len(seen), len(names))
+			passed = false
+		}
+	}
+	if passed {
+		common.Infof(t.ctx, "All %d sessions were deleted by %d requests", len(names), len(deletes))
+	}
+	return
+}
+
+// Every request to get a session should have either found it or not, and any session
+// found should be one created by the test
+func checkSingleGets(ctx context.Context, names []string, gets []response) (passed bool) {
+	passed = true
+	found, notFound := 0, 0
+	for _, r := range gets {
+		if r.err != nil {
+			common.Error(ctx, r.err)
+			passed = false
+			continue
+		} else if r.status == http.StatusNotFound {
+			notFound++
+			continue
+		} else if r.status != http.StatusOK {
+			common.Errorf(ctx, "Getting a session: expected status code %d or %d, got %d", http.StatusOK, http.StatusNotFound, r.status)
+			passed = false
+			continue
+		}
+		found++
+		var session interface{}
+		if err := json.Unmarshal(r.body, &session); err != nil {
+			common.Errorf(ctx, "Unable to decode session: %v", err)
+			passed = false
+		} else if !checkListedSession(ctx, names, session) {
+			passed = false
+		}
+	}
+	common.Infof(ctx, "Of %d requests to get a session, %d found it and %d did not", len(gets), found, notFound)
+	return
+}
+
+// Every request to list the sessions should have succeeded, and listed only sessions
+// created by the test (though any of them may have been deleted already)
+func checkMultiGets(ctx context.Context, names []string, gets []response) (passed bool) {
+	passed = true
+	for _, r := range gets {
+		if r.err != nil {
+			common.Error(ctx, r.err)
+			passed = false
+			continue
+		} else if r.status != http.StatusOK {
+			common.Errorf(ctx, "Listing CFS sessions: expected status code %d, got %d", http.StatusOK, r.status)
+			passed = false
+			continue
+		}
+		common.Infof(ctx, "A request to list the sessions listed %d of them", len(r.sessions))
+		for _, session := range r.sessions {
+			if !checkListedSession(ctx, names, session) {
+				passed = false
+			}
+		}
+	}
+	return
+}
+
+// Check that a session returned by CFS is an object, which names one of the sessions created
+// by the test
+func checkListedSession(ctx context.Context, names []string, session interface{}) bool {
+	name, ok := sessionName(session)
+	if !ok {
+		common.Errorf(ctx, "Expected a session object with a name, found: %v", session)
+		return false
+	} else if !common.StringInArray(name, names) {
+		common.Errorf(ctx, "Session %s is not one of the sessions created by the test", name)
+		return false
+	}
+	return true
+}
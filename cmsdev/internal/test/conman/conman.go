@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,11 +29,13 @@ package conman
  */
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
@@ -44,35 +46,60 @@ var allPvcNames = []string{
 
 var allPodNames = []string{}
 
-func IsConmanRunning() (passed bool) {
+// Subtest names, for use with the --only and --skip options
+var conmanSubtests = []string{"conman.pvcs", "conman.console-data", "conman.console-node", "conman.console-operator"}
+
+func init() {
+	registry.Register(registry.ServiceTest{
+		Name:           "conman",
+		Description:    "Console services: console-data, console-node and console-operator pods and PVCs",
+		DefaultTimeout: 300,
+		Subtests:       conmanSubtests,
+		// Every subtest checks the pods and PVCs
+		ClusterSubtests: conmanSubtests,
+		Run: func(opts registry.RunOptions) bool {
+			return IsConmanRunning(opts.Context)
+		},
+	})
+}
+
+func IsConmanRunning(ctx context.Context) (passed bool) {
 	passed = true
 
 	// check conman pvc status
-	for _, pvcName := range allPvcNames {
-		if !test.CheckPVCStatus(pvcName) {
-			passed = false
-		}
+	if !registry.RunSubtest(ctx, "conman.pvcs", verifyConsolePVCs) {
+		passed = false
 	}
 
-	if !verifyConsoleDataPods() {
+	if !registry.RunSubtest(ctx, "conman.console-data", verifyConsoleDataPods) {
 		passed = false
 	}
 
-	if !verifyConsoleNodePods() {
+	if !registry.RunSubtest(ctx, "conman.console-node", verifyConsoleNodePods) {
 		passed = false
 	}
 
-	if !verifyConsoleOperatorPods() {
+	if !registry.RunSubtest(ctx, "conman.console-operator", verifyConsoleOperatorPods) {
 		passed = false
 	}
 
 	if !passed {
-		common.ArtifactsKubernetes()
+		common.ArtifactsKubernetes(ctx)
 		if len(allPodNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, allPodNames)
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, allPodNames)
 		}
 		if len(allPvcNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, allPvcNames)
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, allPvcNames)
+		}
+	}
+	return
+}
+
+func verifyConsolePVCs(ctx context.Context) (passed bool) {
+	passed = true
+	for _, pvcName := range allPvcNames {
+		if !test.CheckPVCStatus(ctx, pvcName) {
+			passed = false
 		}
 	}
 	return
@@ -87,15 +114,15 @@ func IsConmanRunning() (passed bool) {
 //      - exactly 1 cray-console-operator pod
 // All should be in Running state except for wait-for-postgres, which sould be Succeeded
 
-func verifyConsoleDataPods() (passed bool) {
+func verifyConsoleDataPods(ctx context.Context) (passed bool) {
 	passed = true
-	// We expect 4 or more of these (one main pod, 3 postgres pods, and possibly 1+ wait-for-postgres pods)
-	podNames, ok := test.GetPodNamesByPrefixKey("console-data", 4, -1)
+	// By default we expect 4 or more of these (one main pod, 3 postgres pods, and possibly 1+ wait-for-postgres pods)
+	podNames, ok := test.GetPodNamesByPrefixKey(ctx, "console-data")
 	if !ok {
 		passed = false
 	}
 	allPodNames = append(allPodNames, podNames...)
-	common.Infof("Found %d console-data pods: %s", len(podNames), strings.Join(podNames, ", "))
+	common.Infof(ctx, "Found %d console-data pods: %s", len(podNames), strings.Join(podNames, ", "))
 	postgresRe := regexp.MustCompile("^cray-console-data-postgres-[0-9][0-9]*$")
 	waitForPostgresRe := regexp.MustCompile("^cray-console-data-wait-for-postgres-[0-9][0-9]*-")
 	mainPodCount := 0
@@ -108,51 +135,51 @@ func verifyConsoleDataPods() (passed bool) {
 			postgresPodCount += 1
 			// There should be a corresponding pvc
 			pvcName := "pgdata-" + podName
-			common.Infof("There should be a corresponding pvc with name %s", pvcName)
+			common.Infof(ctx, "There should be a corresponding pvc with name %s", pvcName)
 			allPvcNames = append(allPvcNames, pvcName)
-			if !test.CheckPVCStatus(pvcName) {
+			if !test.CheckPVCStatus(ctx, pvcName) {
 				passed = false
 			}
 		} else {
 			mainPodCount += 1
 		}
-		common.Infof("checking pod status for %s expecting %s", podName, expectedStatus)
-		status, err := k8s.GetPodStatus(common.NAMESPACE, podName)
+		common.Infof(ctx, "checking pod status for %s expecting %s", podName, expectedStatus)
+		status, err := k8s.GetPodStatus(ctx, common.NAMESPACE, podName)
 		if err != nil {
-			common.VerboseFailedf(err.Error())
+			common.VerboseFailedf(ctx, "%s", err.Error())
 			passed = false
 			continue
 		}
-		common.Infof("Pod status is %s", status)
+		common.Infof(ctx, "Pod status is %s", status)
 		if status == "Succeeded" && expectedStatus != "Succeeded" {
-			common.Warnf("Pod %s has status %s", podName, status)
+			common.Warnf(ctx, "Pod %s has status %s", podName, status)
 		} else if status != expectedStatus {
-			common.VerboseFailedf("expected status=%s, found status=%s for podName=%s", expectedStatus, status, podName)
+			common.VerboseFailedf(ctx, "expected status=%s, found status=%s for podName=%s", expectedStatus, status, podName)
 			passed = false
 		} else {
-			common.VerboseOkay()
+			common.VerboseOkay(ctx)
 		}
 	}
 	if mainPodCount != 1 {
-		common.VerboseFailedf("Expected exactly 1 main console-data pod but found %d", mainPodCount)
+		common.VerboseFailedf(ctx, "Expected exactly 1 main console-data pod but found %d", mainPodCount)
 		passed = false
 	}
 	if postgresPodCount < 1 || postgresPodCount > 3 {
-		common.VerboseFailedf("Expected between 1 and 3 console-data-postgres pods but found %d", postgresPodCount)
+		common.VerboseFailedf(ctx, "Expected between 1 and 3 console-data-postgres pods but found %d", postgresPodCount)
 		passed = false
 	}
 	return
 }
 
-func verifyConsoleNodePods() (passed bool) {
+func verifyConsoleNodePods(ctx context.Context) (passed bool) {
 	passed = true
-	// We expect at least 2 of these
-	podNames, ok := test.GetPodNamesByPrefixKey("console-node", 2, -1)
+	// By default we expect at least 2 of these
+	podNames, ok := test.GetPodNamesByPrefixKey(ctx, "console-node")
 	if !ok {
 		passed = false
 	}
 	allPodNames = append(allPodNames, podNames...)
-	common.Infof("Found %d console-node pods: %s", len(podNames), strings.Join(podNames, ", "))
+	common.Infof(ctx, "Found %d console-node pods: %s", len(podNames), strings.Join(podNames, ", "))
 	consoleNodeRe := regexp.MustCompile("^cray-console-node-[0-9][0-9]*$")
 	mainPodCount := 0
 	for _, podName := range podNames {
@@ -160,59 +187,59 @@ func verifyConsoleNodePods() (passed bool) {
 		if consoleNodeRe.MatchString(podName) {
 			mainPodCount += 1
 		} else {
-			common.VerboseFailedf("Unexpected pod name format for console-nod pod: %s", podName)
+			common.VerboseFailedf(ctx, "Unexpected pod name format for console-nod pod: %s", podName)
 			passed = false
 		}
-		common.Infof("checking pod status for %s expecting %s", podName, expectedStatus)
-		status, err := k8s.GetPodStatus(common.NAMESPACE, podName)
+		common.Infof(ctx, "checking pod status for %s expecting %s", podName, expectedStatus)
+		status, err := k8s.GetPodStatus(ctx, common.NAMESPACE, podName)
 		if err != nil {
-			common.VerboseFailedf(err.Error())
+			common.VerboseFailedf(ctx, "%s", err.Error())
 			passed = false
 			continue
 		}
-		common.Infof("Pod status is %s", status)
+		common.Infof(ctx, "Pod status is %s", status)
 		if status == "Succeeded" {
-			common.Warnf("Pod %s has status %s", podName, status)
+			common.Warnf(ctx, "Pod %s has status %s", podName, status)
 		} else if status != expectedStatus {
-			common.VerboseFailedf("expected status=%s, found status=%s for podName=%s", expectedStatus, status, podName)
+			common.VerboseFailedf(ctx, "expected status=%s, found status=%s for podName=%s", expectedStatus, status, podName)
 			passed = false
 		} else {
-			common.VerboseOkay()
+			common.VerboseOkay(ctx)
 		}
 	}
 	if mainPodCount < 2 {
-		common.VerboseFailedf("Expected at least 2 main console-node pods but found %d", mainPodCount)
+		common.VerboseFailedf(ctx, "Expected at least 2 main console-node pods but found %d", mainPodCount)
 		passed = false
 	}
 	return
 }
 
-func verifyConsoleOperatorPods() (passed bool) {
+func verifyConsoleOperatorPods(ctx context.Context) (passed bool) {
 	passed = true
-	// We expect exactly one of these
-	podNames, ok := test.GetPodNamesByPrefixKey("console-operator", 1, 1)
+	// By default we expect exactly one of these
+	podNames, ok := test.GetPodNamesByPrefixKey(ctx, "console-operator")
 	if !ok {
 		passed = false
 	}
 	allPodNames = append(allPodNames, podNames...)
-	common.Infof("Found %d console-operator pods: %s", len(podNames), strings.Join(podNames, ", "))
+	common.Infof(ctx, "Found %d console-operator pods: %s", len(podNames), strings.Join(podNames, ", "))
 	for _, podName := range podNames {
 		expectedStatus := "Running"
-		common.Infof("checking pod status for %s expecting %s", podName, expectedStatus)
-		status, err := k8s.GetPodStatus(common.NAMESPACE, podName)
+		common.Infof(ctx, "checking pod status for %s expecting %s", podName, expectedStatus)
+		status, err := k8s.GetPodStatus(ctx, common.NAMESPACE, podName)
 		if err != nil {
-			common.VerboseFailedf(err.Error())
+			common.VerboseFailedf(ctx, "%s", err.Error())
 			passed = false
 			continue
 		}
-		common.Infof("Pod status is %s", status)
+		common.Infof(ctx, "Pod status is %s", status)
 		if status == "Succeeded" {
-			common.Warnf("Pod %s has status %s", podName, status)
+			common.Warnf(ctx, "Pod %s has status %s", podName, status)
 		} else if status != expectedStatus {
-			common.VerboseFailedf("expected status=%s, found status=%s for podName=%s", expectedStatus, status, podName)
+			common.VerboseFailedf(ctx, "expected status=%s, found status=%s for podName=%s", expectedStatus, status, podName)
 			passed = false
 		} else {
-			common.VerboseOkay()
+			common.VerboseOkay(ctx)
 		}
 	}
 	// No need to keep or check a count like for the other two types, because there is only the one type of console-operator-
@@ -0,0 +1,147 @@
+// MIT License
+//
+// (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+package conman
+
+/*
+ * conman_test.go
+ *
+ * Checks of the console-data pods against simulated cluster state
+ *
+ */
+
+import (
+	"testing"
+
+	coreV1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+)
+
+// Returns a PVC in the CMS namespace with the specified phase
+func fakePVC(name string, phase coreV1.PersistentVolumeClaimPhase) *coreV1.PersistentVolumeClaim {
+	return &coreV1.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: common.NAMESPACE},
+		Status:     coreV1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+// Makes the specified cluster the current cluster, and clears the names collected by earlier
+// checks, until the test ends
+func useCluster(t *testing.T, cluster k8s.Cluster) {
+	previous := k8s.CurrentCluster()
+	previousPodNames, previousPvcNames := allPodNames, allPvcNames
+	k8s.SetCluster(cluster)
+	allPodNames, allPvcNames = []string{}, []string{}
+	t.Cleanup(func() {
+		k8s.SetCluster(previous)
+		allPodNames, allPvcNames = previousPodNames, previousPvcNames
+	})
+}
+
+func TestVerifyConsoleDataPods(t *testing.T) {
+	ctx := common.MainContext()
+	healthy := []runtime.Object{
+		k8s.FakePod("cray-console-data-6d8f9c7b5-x2k4m", coreV1.PodRunning),
+		k8s.FakePod("cray-console-data-postgres-0", coreV1.PodRunning),
+		k8s.FakePod("cray-console-data-postgres-1", coreV1.PodRunning),
+		k8s.FakePod("cray-console-data-postgres-2", coreV1.PodRunning),
+		fakePVC("pgdata-cray-console-data-postgres-0", coreV1.ClaimBound),
+		fakePVC("pgdata-cray-console-data-postgres-1", coreV1.ClaimBound),
+		fakePVC("pgdata-cray-console-data-postgres-2", coreV1.ClaimBound),
+	}
+	// healthy without the PVC of the last postgres pod
+	withoutLastPVC := healthy[:6:6]
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		passed  bool
+	}{
+		{"healthy", healthy, true},
+		{"wait-for-postgres pod succeeded", append(healthy[:7:7],
+			k8s.FakePod("cray-console-data-wait-for-postgres-1-q7r8s", coreV1.PodSucceeded)), true},
+		{"wait-for-postgres pod failed", append(healthy[:7:7],
+			k8s.FakePod("cray-console-data-wait-for-postgres-1-q7r8s", coreV1.PodFailed)), false},
+		{"postgres PVC missing", withoutLastPVC, false},
+		{"postgres PVC not bound", append(withoutLastPVC,
+			fakePVC("pgdata-cray-console-data-postgres-2", coreV1.ClaimPending)), false},
+		{"two main pods", append(healthy[:7:7], k8s.FakePod("cray-console-data-6d8f9c7b5-y3l5n", coreV1.PodRunning)), false},
+		{"postgres pod failed", append(healthy[:3:3],
+			k8s.FakePod("cray-console-data-postgres-2", coreV1.PodFailed),
+			k8s.FakePod("cray-console-data-wait-for-postgres-1-q7r8s", coreV1.PodSucceeded),
+			fakePVC("pgdata-cray-console-data-postgres-0", coreV1.ClaimBound),
+			fakePVC("pgdata-cray-console-data-postgres-1", coreV1.ClaimBound),
+			fakePVC("pgdata-cray-console-data-postgres-2", coreV1.ClaimBound)), false},
+		{"too few pods", append(healthy[:2:2], healthy[4]), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			useCluster(t, k8s.NewFakeCluster(tc.objects...))
+			if passed := verifyConsoleDataPods(ctx); passed != tc.passed {
+				t.Errorf("verifyConsoleDataPods() passed = %v, expected %v", passed, tc.passed)
+			}
+		})
+	}
+}
+
+func TestVerifyConsoleDataPodsFromFile(t *testing.T) {
+	ctx := common.MainContext()
+	cluster, err := k8s.LoadFakeCluster("testdata/console-data.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useCluster(t, cluster)
+	if !verifyConsoleDataPods(ctx) {
+		t.Error("verifyConsoleDataPods() failed for the pods in testdata/console-data.yaml")
+	}
+	if len(allPvcNames) != 3 {
+		t.Errorf("Expected the 3 postgres PVCs to be recorded, found %v", allPvcNames)
+	}
+}
+
+func TestVerifyConsoleNodePods(t *testing.T) {
+	ctx := common.MainContext()
+	tests := []struct {
+		name   string
+		pods   []runtime.Object
+		passed bool
+	}{
+		{"two running", []runtime.Object{
+			k8s.FakePod("cray-console-node-0", coreV1.PodRunning),
+			k8s.FakePod("cray-console-node-1", coreV1.PodRunning)}, true},
+		{"only one", []runtime.Object{
+			k8s.FakePod("cray-console-node-0", coreV1.PodRunning)}, false},
+		{"unexpected name", []runtime.Object{
+			k8s.FakePod("cray-console-node-0", coreV1.PodRunning),
+			k8s.FakePod("cray-console-node-1", coreV1.PodRunning),
+			k8s.FakePod("cray-console-node-debug", coreV1.PodRunning)}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			useCluster(t, k8s.NewFakeCluster(tc.pods...))
+			if passed := verifyConsoleNodePods(ctx); passed != tc.passed {
+				t.Errorf("verifyConsoleNodePods() passed = %v, expected %v", passed, tc.passed)
+			}
+		})
+	}
+}
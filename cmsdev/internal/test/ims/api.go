@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2021-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2021-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,6 +29,7 @@ package ims
  */
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -36,27 +37,46 @@ import (
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
+// Kinds of IMS resources, for the cleanup registry
+const imsImageKind = "IMS image"
+const imsPublicKeyKind = "IMS public key"
+const imsRecipeKind = "IMS recipe"
+
+// The URLs of an IMS record, and of the record once it has been soft deleted
+func imsRecordUrls(ctx context.Context, endpoint, id string) (liveUrl, deletedUrl string) {
+	liveUrl = constructIMSURL(ctx, endpoint, "") + "/" + id
+	deletedUrl = constructIMSURL(ctx, "deleted_"+endpoint, "") + "/" + id
+	return
+}
+
+// Register a cleanup for an IMS record created by a test. The record is soft deleted and then
+// permanently deleted, so that it does not linger in the deleted records either. A 404 from
+// either request means that step is already done.
+func registerIMSCleanup(ctx context.Context, kind, endpoint, id string) {
+	liveUrl, deletedUrl := imsRecordUrls(ctx, endpoint, id)
+	test.RegisterAPIDeleteCleanup(ctx, kind, id, liveUrl, deletedUrl)
+}
+
 // Return specific job record in IMS via API
-func getIMSJobRecordAPI(jobId string) (jobRecord IMSJobRecord, ok bool) {
-	var baseurl string = common.BASEURL
+func getIMSJobRecordAPI(ctx context.Context, jobId string) (jobRecord IMSJobRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting job record %s in IMS via API", jobId)
-	params := test.GetAccessTokenParams()
+	common.Infof(ctx, "Getting job record %s in IMS via API", jobId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
-	url := baseurl + endpoints["ims"]["jobs"].Url + "/" + jobId
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	url := serviceEndpoint(ctx, "ims", "jobs").FullURL() + "/" + jobId
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	// Extract job record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &jobRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -65,26 +85,25 @@ func getIMSJobRecordAPI(jobId string) (jobRecord IMSJobRecord, ok bool) {
 }
 
 // Return a list of all job records in IMS via API
-func getIMSJobRecordsAPI() (recordList []IMSJobRecord, ok bool) {
-	var baseurl string = common.BASEURL
+func getIMSJobRecordsAPI(ctx context.Context) (recordList []IMSJobRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting list of all job records in IMS via API")
-	params := test.GetAccessTokenParams()
+	common.Infof(ctx, "Getting list of all job records in IMS via API")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
-	url := baseurl + endpoints["ims"]["jobs"].Url
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	url := serviceEndpoint(ctx, "ims", "jobs").FullURL()
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	// Extract list of job records from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -93,78 +112,75 @@ func getIMSJobRecordsAPI() (recordList []IMSJobRecord, ok bool) {
 }
 
 // Check IMS liveness probe. Returns True if live, False otherwise
-func checkIMSLivenessProbe() bool {
-	var baseurl string = common.BASEURL
+func checkIMSLivenessProbe(ctx context.Context) bool {
 
-	common.Infof("Checking IMS Liveness Probe")
-	params := test.GetAccessTokenParams()
+	common.Infof(ctx, "Checking IMS Liveness Probe")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return false
 	}
-	url := baseurl + endpoints["ims"]["live"].Url
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	url := serviceEndpoint(ctx, "ims", "live").FullURL()
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 	// Validate that we can decode the response into a string map
 	_, err = common.DecodeJSONIntoStringMap(resp.Body())
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 	return true
 }
 
 // Check IMS readiness probe. Returns True if ready, False otherwise
-func checkIMSReadinessProbe() bool {
-	var baseurl string = common.BASEURL
+func checkIMSReadinessProbe(ctx context.Context) bool {
 
-	common.Infof("Checking IMS Readiness Probe")
-	params := test.GetAccessTokenParams()
+	common.Infof(ctx, "Checking IMS Readiness Probe")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return false
 	}
-	url := baseurl + endpoints["ims"]["ready"].Url
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	url := serviceEndpoint(ctx, "ims", "ready").FullURL()
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 	// Validate that we can decode the response into a string map
 	_, err = common.DecodeJSONIntoStringMap(resp.Body())
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 	return true
 }
 
 // Return IMS version
-func getIMSVersion() (ver string, ok bool) {
-	var baseurl string = common.BASEURL
+func getIMSVersion(ctx context.Context) (ver string, ok bool) {
 	ok = false
 
-	common.Infof("Getting IMS version")
-	params := test.GetAccessTokenParams()
+	common.Infof(ctx, "Getting IMS version")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
-	url := baseurl + endpoints["ims"]["version"].Url
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	url := serviceEndpoint(ctx, "ims", "version").FullURL()
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	// Extract version record from response
 	var record IMSVersionRecord
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &record); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	} else if len(record.Version) == 0 {
-		common.Errorf("IMS version string is empty")
+		common.Errorf(ctx, "IMS version string is empty")
 		return
 	}
 	ok = true
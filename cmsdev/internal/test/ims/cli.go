@@ -29,29 +29,30 @@ package ims
  */
 
 import (
+	"context"
 	"encoding/json"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func runCLICommand(cmdArgs ...string) []byte {
-	return test.RunCLICommandJSON("ims", cmdArgs...)
+func runCLICommand(ctx context.Context, cmdArgs ...string) []byte {
+	return test.RunCLICommandJSON(ctx, "ims", cmdArgs...)
 }
 
 // Return specific image record in IMS via CLI
-func getIMSImageRecordCLI(imageId string) (imageRecord IMSImageRecord, ok bool) {
+func getIMSImageRecordCLI(ctx context.Context, imageId string) (imageRecord IMSImageRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting image record %s in IMS via CLI", imageId)
-	cmdOut := runCLICommand("images", "describe", imageId)
+	common.Infof(ctx, "Getting image record %s in IMS via CLI", imageId)
+	cmdOut := runCLICommand(ctx, "images", "describe", imageId)
 	if cmdOut == nil {
 		return
 	}
 
 	// Extract image record from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	if err := json.Unmarshal(cmdOut, &imageRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -59,19 +60,19 @@ func getIMSImageRecordCLI(imageId string) (imageRecord IMSImageRecord, ok bool)
 }
 
 // Return a list of all image records in IMS via CLI
-func getIMSImageRecordsCLI() (recordList []IMSImageRecord, ok bool) {
+func getIMSImageRecordsCLI(ctx context.Context) (recordList []IMSImageRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting list of all image records in IMS via CLI")
-	cmdOut := runCLICommand("images", "list")
+	common.Infof(ctx, "Getting list of all image records in IMS via CLI")
+	cmdOut := runCLICommand(ctx, "images", "list")
 	if cmdOut == nil {
 		return
 	}
 
 	// Extract list of image records from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	if err := json.Unmarshal(cmdOut, &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -79,19 +80,19 @@ func getIMSImageRecordsCLI() (recordList []IMSImageRecord, ok bool) {
 }
 
 // Return specific job record in IMS via CLI
-func getIMSJobRecordCLI(jobId string) (jobRecord IMSJobRecord, ok bool) {
+func getIMSJobRecordCLI(ctx context.Context, jobId string) (jobRecord IMSJobRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting job record %s in IMS via CLI", jobId)
-	cmdOut := runCLICommand("jobs", "describe", jobId)
+	common.Infof(ctx, "Getting job record %s in IMS via CLI", jobId)
+	cmdOut := runCLICommand(ctx, "jobs", "describe", jobId)
 	if cmdOut == nil {
 		return
 	}
 
 	// Extract job records from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	if err := json.Unmarshal(cmdOut, &jobRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -100,19 +101,19 @@ func getIMSJobRecordCLI(jobId string) (jobRecord IMSJobRecord, ok bool) {
 }
 
 // Return a list of all job records in IMS via CLI
-func getIMSJobRecordsCLI() (recordList []IMSJobRecord, ok bool) {
+func getIMSJobRecordsCLI(ctx context.Context) (recordList []IMSJobRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting list of all job records in IMS via CLI")
-	cmdOut := runCLICommand("jobs", "list")
+	common.Infof(ctx, "Getting list of all job records in IMS via CLI")
+	cmdOut := runCLICommand(ctx, "jobs", "list")
 	if cmdOut == nil {
 		return
 	}
 
 	// Extract list of job records from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	if err := json.Unmarshal(cmdOut, &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -121,19 +122,19 @@ func getIMSJobRecordsCLI() (recordList []IMSJobRecord, ok bool) {
 }
 
 // Return specific public key record in IMS via CLI
-func getIMSPublicKeyRecordCLI(pkeyId string) (pkeyRecord IMSPublicKeyRecord, ok bool) {
+func getIMSPublicKeyRecordCLI(ctx context.Context, pkeyId string) (pkeyRecord IMSPublicKeyRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting public key record %s in IMS via CLI", pkeyId)
-	cmdOut := runCLICommand("public-keys", "describe", pkeyId)
+	common.Infof(ctx, "Getting public key record %s in IMS via CLI", pkeyId)
+	cmdOut := runCLICommand(ctx, "public-keys", "describe", pkeyId)
 	if cmdOut == nil {
 		return
 	}
 
 	// Extract public key record from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	if err := json.Unmarshal(cmdOut, &pkeyRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -142,19 +143,19 @@ func getIMSPublicKeyRecordCLI(pkeyId string) (pkeyRecord IMSPublicKeyRecord, ok
 }
 
 // Return a list of all public key records in IMS via CLI
-func getIMSPublicKeyRecordsCLI() (recordList []IMSPublicKeyRecord, ok bool) {
+func getIMSPublicKeyRecordsCLI(ctx context.Context) (recordList []IMSPublicKeyRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting list of all public key records in IMS via CLI")
-	cmdOut := runCLICommand("public-keys", "list")
+	common.Infof(ctx, "Getting list of all public key records in IMS via CLI")
+	cmdOut := runCLICommand(ctx, "public-keys", "list")
 	if cmdOut == nil {
 		return
 	}
 
 	// Extract list of public key records from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	if err := json.Unmarshal(cmdOut, &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -163,17 +164,17 @@ func getIMSPublicKeyRecordsCLI() (recordList []IMSPublicKeyRecord, ok bool) {
 }
 
 // Return a specific recipe record in IMS via CLI
-func getIMSRecipeRecordCLI(recipeId string) (recipeRecord IMSRecipeRecord, ok bool) {
+func getIMSRecipeRecordCLI(ctx context.Context, recipeId string) (recipeRecord IMSRecipeRecord, ok bool) {
 	ok = false
 
-	common.Infof("Describing recipe record %s in IMS via CLI", recipeId)
-	cmdOut := runCLICommand("recipes", "describe", recipeId)
+	common.Infof(ctx, "Describing recipe record %s in IMS via CLI", recipeId)
+	cmdOut := runCLICommand(ctx, "recipes", "describe", recipeId)
 	if cmdOut == nil {
 		return
 	}
 
 	// Extract recipe record from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	if err := json.Unmarshal(cmdOut, &recipeRecord); err != nil {
 		return
 	}
@@ -182,20 +183,20 @@ func getIMSRecipeRecordCLI(recipeId string) (recipeRecord IMSRecipeRecord, ok bo
 }
 
 // Return a list of all recipe records in IMS via CLI
-func getIMSRecipeRecordsCLI() (recordList []IMSRecipeRecord, ok bool) {
+func getIMSRecipeRecordsCLI(ctx context.Context) (recordList []IMSRecipeRecord, ok bool) {
 	ok = false
 
-	common.Infof("Getting list of all recipe records in IMS via CLI")
-	cmdOut := runCLICommand("recipes", "list")
+	common.Infof(ctx, "Getting list of all recipe records in IMS via CLI")
+	cmdOut := runCLICommand(ctx, "recipes", "list")
 	if cmdOut == nil {
 		return
 	}
 
 	// Extract list of recipe records from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	err := json.Unmarshal(cmdOut, &recordList)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2021-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2021-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,6 +29,7 @@ package ims
  */
 
 import (
+	"context"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
@@ -84,5 +85,13 @@ var pvcNames = []string{
 	"cray-ims-data-claim",
 }
 
-// CMS service endpoints
-var endpoints map[string]map[string]*common.Endpoint = common.GetEndpoints()
+// Returns a CMS service endpoint. These are derived from the OpenAPI specs, which can be loaded
+// after the package is initialized (see --openapi-dir), so they are looked up when needed.
+// Loaded specs are checked for the endpoints the tests use, so one should never be missing.
+func serviceEndpoint(ctx context.Context, service, name string) *common.Endpoint {
+	endpoint, err := common.GetEndpoint(service, name)
+	if err != nil {
+		common.Failuref(ctx, "%v", err)
+	}
+	return endpoint
+}
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,6 +29,7 @@ package ims
  */
 
 import (
+	"context"
 	"os"
 	"regexp"
 	"strings"
@@ -37,38 +38,39 @@ import (
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/cms"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func IsIMSRunning(includeCLI bool) (passed bool) {
-	var ok, found, artifactsCollected bool
+func init() {
+	registry.Register(registry.ServiceTest{
+		Name:        "ims",
+		Description: "Image Management Service: pods, S3, API, image, recipe and public key CRUD, signing keys",
+		SupportsCLI: true,
+		Subtests: []string{"ims.pods", "ims.recipe-pods", "ims.s3", "ims.api.recipes", "ims.api.probes",
+			"ims.api.version", "ims.api.images", "ims.api.jobs", "ims.api.public-keys", "ims.cli.images",
+			"ims.cli.jobs", "ims.cli.public-keys", "ims.cli.recipes", "ims.signingkeys"},
+		ClusterSubtests: []string{"ims.pods", "ims.recipe-pods", "ims.s3", "ims.signingkeys"},
+		Run: func(opts registry.RunOptions) bool {
+			return IsIMSRunning(opts.Context, opts.IncludeCLI)
+		},
+		FindLeftovers: findIMSLeftovers,
+	})
+}
+
+func IsIMSRunning(ctx context.Context, includeCLI bool) (passed bool) {
 	var expectedRecipes []Recipe
+	var podNames []string
+	var ok, artifactsCollected bool
 	passed = true
 	artifactsCollected = false
-	// check service pod status
-	podNames, ok := test.GetPodNamesByPrefixKey("ims", 1, 1)
-	if !ok {
-		passed = false
-	}
-	common.Infof("Found %d ims pods", len(podNames))
-	if !test.CheckPodListStats(podNames) {
-		passed = false
-	}
 
-	for _, pvcName := range pvcNames {
-		if !test.CheckPVCStatus(pvcName) {
-			passed = false
-		}
+	if !registry.RunSubtest(ctx, "ims.pods", func(ctx context.Context) bool { podNames, ok = checkIMSPods(ctx); return ok }) {
+		passed = false
 	}
 
 	if !passed {
-		common.ArtifactsKubernetes()
-		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
-		}
-		if len(pvcNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, pvcNames)
-		}
+		collectIMSArtifacts(ctx, podNames)
 		artifactsCollected = true
 	}
 
@@ -78,224 +80,279 @@ func IsIMSRunning(includeCLI bool) (passed bool) {
 	// If set, IMS_RECIPE_DISTRO specifies the expected distro of the default IMS recipe to verify.
 	defaultRecipeName := os.Getenv("IMS_RECIPE_NAME")
 	if len(defaultRecipeName) > 0 {
-		common.Infof("IMS_RECIPE_NAME set to \"%s\"", defaultRecipeName)
+		common.Infof(ctx, "IMS_RECIPE_NAME set to \"%s\"", defaultRecipeName)
 
 		defaultRecipeDistro := os.Getenv("IMS_RECIPE_DISTRO")
 		if len(defaultRecipeDistro) > 0 {
-			common.Infof("IMS_RECIPE_DISTRO set to \"%s\"", defaultRecipeDistro)
+			common.Infof(ctx, "IMS_RECIPE_DISTRO set to \"%s\"", defaultRecipeDistro)
 			expectedRecipes = append(expectedRecipes, Recipe{Name: defaultRecipeName, Distro: defaultRecipeDistro})
 		} else {
 			// Default to RECIPE_DISTRO_DEFAULT
-			common.Infof("IMS_RECIPE_DISTRO not set. Default IMS recipe distro = \"%s\"", RECIPE_DISTRO_DEFAULT)
+			common.Infof(ctx, "IMS_RECIPE_DISTRO not set. Default IMS recipe distro = \"%s\"", RECIPE_DISTRO_DEFAULT)
 			expectedRecipes = append(expectedRecipes, Recipe{Name: defaultRecipeName, Distro: RECIPE_DISTRO_DEFAULT})
 		}
 	} else {
-		common.Infof("IMS_RECIPE_NAME not set. Skipping default recipe checks.")
+		common.Infof(ctx, "IMS_RECIPE_NAME not set. Skipping default recipe checks.")
 	}
 
-	// Verify any default ims recipe pods are Succeeded
-	common.Infof("Getting list of cray-init-recipe pods")
-	pods, err := k8s.GetPods(common.NAMESPACE, "cray-init-recipe")
-	if err != nil {
-		common.Error(err)
+	if !registry.RunSubtest(ctx, "ims.recipe-pods", func(ctx context.Context) bool { return checkRecipePods(ctx, expectedRecipes) }) {
 		passed = false
-	} else if len(pods) > 0 {
-		for _, pod := range pods {
-			podName := pod.GetName()
-			podPhase := pod.Status.Phase
-			if len(pod.Status.Message) > 0 {
-				common.Infof("Found pod %s with phase %s (message: %s)", podName, podPhase, pod.Status.Message)
-			} else {
-				common.Infof("Found pod %s with phase %s", podName, podPhase)
-			}
-			if podPhase == "Succeeded" {
-				continue
-			}
-			// Check if this is one of our default pods
-			matchingRecipes := findMatchingRecipes(podName, expectedRecipes)
-			if len(matchingRecipes) == 0 {
-				common.Warnf("Pod %s has not Succeeded (phase=%s), but it is not for one of our default recipes", podName, podPhase)
-				continue
-			}
-			recFromPod, okay := getRecipeEnvVars(pod)
-			if !okay {
-				passed = false
-				continue
-			}
-			found = false
-			podRecName := recFromPod.Name
-			podRecDistro := recFromPod.Distro
-			for _, matchingRec := range matchingRecipes {
-				if podRecName == matchingRec.Name && podRecDistro == matchingRec.Distro {
-					found = true
-				} else if podRecName == matchingRec.Name {
-					common.Warnf("Recipe name (%s) in this pod (%s) matches a default recipe, but with different distro (pod %s, default %s)", podRecName, podName, podRecDistro, matchingRec.Distro)
-				}
-			}
-			if found {
-				common.Errorf("Default recipe pod %s should have phase Succeeded but has phase=%s", podName, podPhase)
-				passed = false
-			} else {
-				common.Warnf("Pod %s is not for a default recipe (pod recipe=%s distro=%s), so we only warn that its phase %s != Succeeded", podName, podRecName, podRecDistro, podPhase)
-			}
-		}
-	} else {
-		// We don't consider this a failure because as long as the recipes exist in
-		// IMS, it is okay if the pod isn't there
-		common.Warnf("No cray-init-recipe pods found")
 	}
 
 	if !passed && !artifactsCollected {
-		common.ArtifactsKubernetes()
-		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
-		}
-		if len(pvcNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, pvcNames)
-		}
+		collectIMSArtifacts(ctx, podNames)
 		artifactsCollected = true
 	}
 
 	// Verify S3 (from an IMS perspective)
-	if !verifyS3(len(expectedRecipes)) {
+	if !registry.RunSubtest(ctx, "ims.s3", func(ctx context.Context) bool { return verifyS3(ctx, len(expectedRecipes)) }) {
 		passed = false
 	}
 
 	// Verify recipe CRUD operations via API
-	if !TestRecipeCRUDOperationUsingAPIVersions() {
+	if !registry.RunSubtest(ctx, "ims.api.recipes", TestRecipeCRUDOperationUsingAPIVersions) {
 		passed = false
 	}
 
 	// Do a few basic API and CLI tests
-	if !checkIMSLivenessProbe() {
-		passed = false
-	}
-	if !checkIMSReadinessProbe() {
+	if !registry.RunSubtest(ctx, "ims.api.probes", checkIMSProbes) {
 		passed = false
 	}
-	ver, ok := getIMSVersion()
-	if !ok {
+	if !registry.RunSubtest(ctx, "ims.api.version", checkIMSVersion) {
 		passed = false
-	} else {
-		common.Infof("IMS version is reported to be %s", ver)
 	}
 
 	// Verify that we can perform CRUD operation on image via API
-	if !TestImageCRUDOperationUsingAPIVersions() {
+	if !registry.RunSubtest(ctx, "ims.api.images", TestImageCRUDOperationUsingAPIVersions) {
 		passed = false
 	}
 
-	imsJobList, ok := getIMSJobRecordsAPI()
-	if !ok {
+	if !registry.RunSubtest(ctx, "ims.api.jobs", checkIMSJobsAPI) {
 		passed = false
-	} else {
-		common.Infof("Found %d IMS job records via API", len(imsJobList))
-		if len(imsJobList) > 0 {
-			if imsJobId := imsJobList[0].Id; len(imsJobId) == 0 {
-				common.Errorf("First IMS job record in list has 0-length ID field")
-				passed = false
-			} else if _, getOk := getIMSJobRecordAPI(imsJobId); !getOk {
-				passed = false
-			}
-		}
 	}
 
 	// Verify that we can perform CRUD operation on public key via API
-	if !TestPublicKeyCRUDOperationUsingAPIVersions() {
+	if !registry.RunSubtest(ctx, "ims.api.public-keys", TestPublicKeyCRUDOperationUsingAPIVersions) {
 		passed = false
 	}
 
 	// CLI tests will be run only if requested using the include-cli flag
 	if includeCLI {
-		if !TestImageCRUDOperationUsingCLI() {
+		if !registry.RunSubtest(ctx, "ims.cli.images", TestImageCRUDOperationUsingCLI) {
 			passed = false
 		}
 
-		imsJobList, ok = getIMSJobRecordsCLI()
-		if !ok {
+		if !registry.RunSubtest(ctx, "ims.cli.jobs", checkIMSJobsCLI) {
 			passed = false
-		} else {
-			common.Infof("Found %d IMS job records via CLI", len(imsJobList))
-			if len(imsJobList) > 0 {
-				if imsJobId := imsJobList[0].Id; len(imsJobId) == 0 {
-					common.Errorf("First IMS job record in list has 0-length ID field")
-					passed = false
-				} else if _, getOk := getIMSJobRecordCLI(imsJobId); !getOk {
-					passed = false
-				}
-			}
 		}
 
 		// Verify that we can perform CRUD operation on public key via CLI
-		if !TestPublicKeyCRUDOperationUsingCLI() {
+		if !registry.RunSubtest(ctx, "ims.cli.public-keys", TestPublicKeyCRUDOperationUsingCLI) {
 			passed = false
 		}
 
 		// Verify that we can perform CRUD operation on recipes via CLI
-		if !TestRecipeCRUDOperationUsingCLI() {
+		if !registry.RunSubtest(ctx, "ims.cli.recipes", TestRecipeCRUDOperationUsingCLI) {
 			passed = false
 		}
 	}
 
-	if !signingkeysTest() {
+	if !registry.RunSubtest(ctx, "ims.signingkeys", signingkeysTest) {
 		passed = false
 	}
 
 	if !passed && !artifactsCollected {
-		common.ArtifactsKubernetes()
-		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
+		collectIMSArtifacts(ctx, podNames)
+	}
+
+	return
+}
+
+func collectIMSArtifacts(ctx context.Context, podNames []string) {
+	common.ArtifactsKubernetes(ctx)
+	if len(podNames) > 0 {
+		common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, podNames)
+	}
+	if len(pvcNames) > 0 {
+		common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, pvcNames)
+	}
+}
+
+// Check the status of the IMS pod and PVCs. Returns the pod names and true if they all look okay.
+func checkIMSPods(ctx context.Context) (podNames []string, passed bool) {
+	passed = true
+	// check service pod status
+	podNames, ok := test.GetPodNamesByPrefixKey(ctx, "ims")
+	if !ok {
+		passed = false
+	}
+	common.Infof(ctx, "Found %d ims pods", len(podNames))
+	if !test.CheckPodListStats(ctx, podNames) {
+		passed = false
+	}
+
+	for _, pvcName := range pvcNames {
+		if !test.CheckPVCStatus(ctx, pvcName) {
+			passed = false
 		}
-		if len(pvcNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, pvcNames)
+	}
+	return
+}
+
+// Verify that the cray-init-recipe pods for any of the expected recipes have Succeeded
+func checkRecipePods(ctx context.Context, expectedRecipes []Recipe) (passed bool) {
+	var found bool
+	passed = true
+
+	common.Infof(ctx, "Getting list of cray-init-recipe pods")
+	pods, err := k8s.GetPods(ctx, common.NAMESPACE, "cray-init-recipe")
+	if err != nil {
+		common.Error(ctx, err)
+		passed = false
+	} else if len(pods) > 0 {
+		for _, pod := range pods {
+			podName := pod.GetName()
+			podPhase := pod.Status.Phase
+			if len(pod.Status.Message) > 0 {
+				common.Infof(ctx, "Found pod %s with phase %s (message: %s)", podName, podPhase, pod.Status.Message)
+			} else {
+				common.Infof(ctx, "Found pod %s with phase %s", podName, podPhase)
+			}
+			if podPhase == "Succeeded" {
+				continue
+			}
+			// Check if this is one of our default pods
+			matchingRecipes := findMatchingRecipes(podName, expectedRecipes)
+			if len(matchingRecipes) == 0 {
+				common.Warnf(ctx, "Pod %s has not Succeeded (phase=%s), but it is not for one of our default recipes", podName, podPhase)
+				continue
+			}
+			recFromPod, okay := getRecipeEnvVars(ctx, pod)
+			if !okay {
+				passed = false
+				continue
+			}
+			found = false
+			podRecName := recFromPod.Name
+			podRecDistro := recFromPod.Distro
+			for _, matchingRec := range matchingRecipes {
+				if podRecName == matchingRec.Name && podRecDistro == matchingRec.Distro {
+					found = true
+				} else if podRecName == matchingRec.Name {
+					common.Warnf(ctx, "Recipe name (%s) in this pod (%s) matches a default recipe, but with different distro (pod %s, default %s)", podRecName, podName, podRecDistro, matchingRec.Distro)
+				}
+			}
+			if found {
+				common.Errorf(ctx, "Default recipe pod %s should have phase Succeeded but has phase=%s", podName, podPhase)
+				passed = false
+			} else {
+				common.Warnf(ctx, "Pod %s is not for a default recipe (pod recipe=%s distro=%s), so we only warn that its phase %s != Succeeded", podName, podRecName, podRecDistro, podPhase)
+			}
 		}
-		artifactsCollected = true
+	} else {
+		// We don't consider this a failure because as long as the recipes exist in
+		// IMS, it is okay if the pod isn't there
+		common.Warnf(ctx, "No cray-init-recipe pods found")
 	}
 
 	return
 }
 
-func verifyDefaultRecipes(expectedRecipes []Recipe, imsRecipeList []IMSRecipeRecord) (passed bool) {
+func checkIMSProbes(ctx context.Context) (passed bool) {
+	passed = true
+	if !checkIMSLivenessProbe(ctx) {
+		passed = false
+	}
+	if !checkIMSReadinessProbe(ctx) {
+		passed = false
+	}
+	return
+}
+
+func checkIMSVersion(ctx context.Context) bool {
+	ver, ok := getIMSVersion(ctx)
+	if !ok {
+		return false
+	}
+	common.Infof(ctx, "IMS version is reported to be %s", ver)
+	return true
+}
+
+func checkIMSJobsAPI(ctx context.Context) bool {
+	imsJobList, ok := getIMSJobRecordsAPI(ctx)
+	if !ok {
+		return false
+	}
+	common.Infof(ctx, "Found %d IMS job records via API", len(imsJobList))
+	if len(imsJobList) > 0 {
+		if imsJobId := imsJobList[0].Id; len(imsJobId) == 0 {
+			common.Errorf(ctx, "First IMS job record in list has 0-length ID field")
+			return false
+		} else if _, getOk := getIMSJobRecordAPI(ctx, imsJobId); !getOk {
+			return false
+		}
+	}
+	return true
+}
+
+func checkIMSJobsCLI(ctx context.Context) bool {
+	imsJobList, ok := getIMSJobRecordsCLI(ctx)
+	if !ok {
+		return false
+	}
+	common.Infof(ctx, "Found %d IMS job records via CLI", len(imsJobList))
+	if len(imsJobList) > 0 {
+		if imsJobId := imsJobList[0].Id; len(imsJobId) == 0 {
+			common.Errorf(ctx, "First IMS job record in list has 0-length ID field")
+			return false
+		} else if _, getOk := getIMSJobRecordCLI(ctx, imsJobId); !getOk {
+			return false
+		}
+	}
+	return true
+}
+
+func verifyDefaultRecipes(ctx context.Context, expectedRecipes []Recipe, imsRecipeList []IMSRecipeRecord) (passed bool) {
 	passed = true
-	common.Infof("Verifying that all expected IMS recipes are available")
+	common.Infof(ctx, "Verifying that all expected IMS recipes are available")
 	for _, expectedRecipe := range expectedRecipes {
 		defaultRecName := expectedRecipe.Name
 		defaultRecDistro := expectedRecipe.Distro
 		imsid := ""
-		common.Infof("Checking for recipe %s, distro %s", defaultRecName, defaultRecDistro)
+		common.Infof(ctx, "Checking for recipe %s, distro %s", defaultRecName, defaultRecDistro)
 		for _, irec := range imsRecipeList {
 			if irec.Name == defaultRecName {
 				if irec.Linux_distribution == defaultRecDistro {
-					common.Infof("Found this recipe in IMS with id %s", irec.Id)
+					common.Infof(ctx, "Found this recipe in IMS with id %s", irec.Id)
 					if len(imsid) > 0 {
-						common.Warnf("Multiple IMS recipe records found matching recipe name %s and distro %s (IMS ids %s, %s)", defaultRecName, defaultRecDistro, imsid, irec.Id)
+						common.Warnf(ctx, "Multiple IMS recipe records found matching recipe name %s and distro %s (IMS ids %s, %s)", defaultRecName, defaultRecDistro, imsid, irec.Id)
 					} else {
 						imsid = irec.Id
 					}
 					if irec.Link == nil {
-						common.Errorf("Recipe link should not be null, but it is (IMS id %s)", irec.Id)
+						common.Errorf(ctx, "Recipe link should not be null, but it is (IMS id %s)", irec.Id)
 						passed = false
 					}
 				} else {
-					common.Warnf("Found an IMS record (id %s) for default recipe %s, but different distro (IMS %s, default %s)", irec.Id, defaultRecName, irec.Linux_distribution, defaultRecDistro)
+					common.Warnf(ctx, "Found an IMS record (id %s) for default recipe %s, but different distro (IMS %s, default %s)", irec.Id, defaultRecName, irec.Linux_distribution, defaultRecDistro)
 				}
 			}
 		}
 		if len(imsid) == 0 {
-			common.Errorf("No IMS recipe record found with name %s and distro %s", defaultRecName, defaultRecDistro)
+			common.Errorf(ctx, "No IMS recipe record found with name %s and distro %s", defaultRecName, defaultRecDistro)
 			passed = false
 		}
 	}
 	return
 }
 
-func verifyS3(numExpectedRecipes int) bool {
-	bucketList := cms.GetBuckets()
+func verifyS3(ctx context.Context, numExpectedRecipes int) (passed bool) {
+	bucketList := cms.GetBuckets(ctx)
 	if bucketList == nil {
 		return false
 	}
 
-	common.Infof("Found the following S3 buckets: %s", strings.Join(bucketList, " "))
+	common.Infof(ctx, "Found the following S3 buckets: %s", strings.Join(bucketList, " "))
 	imsBucketsFound := 0
 	for _, bucketName := range bucketList {
 		if bucketName == "ims" {
@@ -303,31 +360,31 @@ func verifyS3(numExpectedRecipes int) bool {
 		}
 	}
 	if imsBucketsFound == 0 {
-		common.Errorf("No S3 bucket named 'ims' found")
+		common.Errorf(ctx, "No S3 bucket named 'ims' found")
 		return false
 	} else if imsBucketsFound > 1 {
-		common.Errorf("%d S3 buckets named 'ims' found, but there should be exactly 1", imsBucketsFound)
+		common.Errorf(ctx, "%d S3 buckets named 'ims' found, but there should be exactly 1", imsBucketsFound)
 		return false
 	}
 
-	common.Infof("S3 bucket named 'ims' found")
-	artifactList := cms.GetArtifactsInBucket("ims")
+	common.Infof(ctx, "S3 bucket named 'ims' found")
+	artifactList := cms.GetArtifactsInBucket(ctx, "ims")
 	if artifactList == nil {
 		return false
 	}
 
 	if len(artifactList) < numExpectedRecipes {
-		common.InfoOverridef("Found %d IMS S3 artifact(s):", len(artifactList))
+		common.InfoOverridef(ctx, "Found %d IMS S3 artifact(s):", len(artifactList))
 		for _, s3Artifact := range artifactList {
-			common.InfoOverridef("Key=%s, Etag: %s, Modified: %s", s3Artifact.Key, s3Artifact.ETag, s3Artifact.LastModified)
+			common.InfoOverridef(ctx, "Key=%s, Etag: %s, Modified: %s", s3Artifact.Key, s3Artifact.ETag, s3Artifact.LastModified)
 		}
-		common.Errorf("# S3 IMS artifacts (%d) should be >= # of default IMS recipes (%d)", len(artifactList), numExpectedRecipes)
+		common.Errorf(ctx, "# S3 IMS artifacts (%d) should be >= # of default IMS recipes (%d)", len(artifactList), numExpectedRecipes)
 		return false
 	}
 
-	common.Infof("Found %d IMS S3 artifact(s)", len(artifactList))
+	common.Infof(ctx, "Found %d IMS S3 artifact(s)", len(artifactList))
 	for _, s3Artifact := range artifactList {
-		common.Infof("Key=%s, Etag: %s, Modified: %s", s3Artifact.Key, s3Artifact.ETag, s3Artifact.LastModified)
+		common.Infof(ctx, "Key=%s, Etag: %s, Modified: %s", s3Artifact.Key, s3Artifact.ETag, s3Artifact.LastModified)
 	}
 	return true
 }
@@ -349,7 +406,7 @@ func findMatchingRecipes(podName string, expectedRecipes []Recipe) (matchingReci
 // Checks pod for init-ims container, then checks that container for the
 // RECIPE_NAME and RECIPE_LINUX_DISTRIBUTION environment variables.
 // Returns a Recipe object with those variables if found. Otherwise returns nil.
-func getRecipeEnvVars(pod coreV1.Pod) (recipe Recipe, okay bool) {
+func getRecipeEnvVars(ctx context.Context, pod coreV1.Pod) (recipe Recipe, okay bool) {
 	var foundContainer bool
 	var recipeName, recipeDistro string
 
@@ -362,22 +419,22 @@ func getRecipeEnvVars(pod coreV1.Pod) (recipe Recipe, okay bool) {
 		if container.Name != "init-ims" {
 			continue
 		} else if foundContainer {
-			common.Errorf("Multiple init-ims containers found in pod %s", podName)
+			common.Errorf(ctx, "Multiple init-ims containers found in pod %s", podName)
 			okay = false
 			break
 		} else {
-			common.Infof("Found init-ims container in %s", podName)
+			common.Infof(ctx, "Found init-ims container in %s", podName)
 			foundContainer = true
 		}
 		for _, evar := range k8s.GetEnvVars(container) {
 			if evar.Name == "RECIPE_NAME" {
 				if len(evar.Value) == 0 {
-					common.Errorf("Pod %s: RECIPE_NAME variable in init-ims container exists but is blank", podName)
+					common.Errorf(ctx, "Pod %s: RECIPE_NAME variable in init-ims container exists but is blank", podName)
 					okay = false
 				} else {
-					common.Infof("Pod %s: Found RECIPE_NAME variable set to %s", podName, evar.Value)
+					common.Infof(ctx, "Pod %s: Found RECIPE_NAME variable set to %s", podName, evar.Value)
 					if len(recipeName) > 0 {
-						common.Errorf("Pod %s: RECIPE_NAME variable in init-ims container is set multiple times (%s, %s)", podName, recipeName, evar.Value)
+						common.Errorf(ctx, "Pod %s: RECIPE_NAME variable in init-ims container is set multiple times (%s, %s)", podName, recipeName, evar.Value)
 						okay = false
 					} else {
 						recipeName = evar.Value
@@ -385,12 +442,12 @@ func getRecipeEnvVars(pod coreV1.Pod) (recipe Recipe, okay bool) {
 				}
 			} else if evar.Name == "RECIPE_LINUX_DISTRIBUTION" {
 				if len(evar.Value) == 0 {
-					common.Errorf("Pod %s: RECIPE_LINUX_DISTRIBUTION variable in init-ims container exists but is blank", podName)
+					common.Errorf(ctx, "Pod %s: RECIPE_LINUX_DISTRIBUTION variable in init-ims container exists but is blank", podName)
 					okay = false
 				} else {
-					common.Infof("Pod %s: Found RECIPE_LINUX_DISTRIBUTION variable in init-ims container set to %s", podName, evar.Value)
+					common.Infof(ctx, "Pod %s: Found RECIPE_LINUX_DISTRIBUTION variable in init-ims container set to %s", podName, evar.Value)
 					if len(recipeDistro) > 0 {
-						common.Errorf("Pod %s: RECIPE_LINUX_DISTRIBUTION variable in init-ims container is set multiple times (%s, %s)", podName, recipeDistro, evar.Value)
+						common.Errorf(ctx, "Pod %s: RECIPE_LINUX_DISTRIBUTION variable in init-ims container is set multiple times (%s, %s)", podName, recipeDistro, evar.Value)
 						okay = false
 					} else {
 						recipeDistro = evar.Value
@@ -399,16 +456,16 @@ func getRecipeEnvVars(pod coreV1.Pod) (recipe Recipe, okay bool) {
 			}
 		}
 		if len(recipeName) == 0 {
-			common.Errorf("Pod %s: RECIPE_NAME variable not found in init-ims container", podName)
+			common.Errorf(ctx, "Pod %s: RECIPE_NAME variable not found in init-ims container", podName)
 			okay = false
 		}
 		if len(recipeDistro) == 0 {
-			common.Errorf("Pod %s: RECIPE_LINUX_DISTRIBUTION variable not found in init-ims container", podName)
+			common.Errorf(ctx, "Pod %s: RECIPE_LINUX_DISTRIBUTION variable not found in init-ims container", podName)
 			okay = false
 		}
 	}
 	if !foundContainer {
-		common.Errorf("No init-ims container found in pod %s", podName)
+		common.Errorf(ctx, "No init-ims container found in pod %s", podName)
 		okay = false
 	} else if okay {
 		recipe.Name = recipeName
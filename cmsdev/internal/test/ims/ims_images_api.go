@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,17 +28,17 @@ package ims
  *
  */
 import (
+	"context"
 	"encoding/json"
 	"net/http"
-	"strings"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func CreateIMSImageRecordAPI(imageName string, metadata map[string]string) (imageRecord IMSImageRecord, ok bool) {
-	common.Infof("Creating image %s in IMS via API with metadata %v", imageName, metadata)
-	params := test.GetAccessTokenParams()
+func CreateIMSImageRecordAPI(ctx context.Context, imageName string, metadata map[string]string) (imageRecord IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Creating image %s in IMS via API with metadata %v", imageName, metadata)
+	params := test.GetAccessTokenParams(ctx)
 	var url string
 	if params == nil {
 		return
@@ -51,32 +51,33 @@ func CreateIMSImageRecordAPI(imageName string, metadata map[string]string) (imag
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	params.JsonStr = string(jsonPayload)
-	apiVersion := common.GetIMSAPIVersion()
-	url = constructIMSURL("images", apiVersion)
-	resp, err := test.RestfulVerifyStatus("POST", url, *params, http.StatusCreated)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url = constructIMSURL(ctx, "images", apiVersion)
+	resp, err := test.RestfulVerifyStatus(ctx, "POST", url, *params, http.StatusCreated)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &imageRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
+	registerIMSCleanup(ctx, imsImageKind, "images", imageRecord.Id)
 
 	ok = true
 	return
 }
 
-func UpdateIMSImageRecordAPI(imageId string, arch string, metadata map[string]string) (imageRecord IMSImageRecord, ok bool) {
-	common.Infof("Updating image %s with arch %s and metadata %v", imageId, arch, metadata)
-	params := test.GetAccessTokenParams()
+func UpdateIMSImageRecordAPI(ctx context.Context, imageId string, arch string, metadata map[string]string) (imageRecord IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Updating image %s with arch %s and metadata %v", imageId, arch, metadata)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
@@ -89,22 +90,22 @@ func UpdateIMSImageRecordAPI(imageId string, arch string, metadata map[string]st
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	params.JsonStrArray = jsonPayload
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("images", apiVersion) + "/" + imageId
-	resp, err := test.RestfulVerifyStatus("PATCH", url, *params, http.StatusOK)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "images", apiVersion) + "/" + imageId
+	resp, err := test.RestfulVerifyStatus(ctx, "PATCH", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &imageRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
@@ -112,19 +113,19 @@ func UpdateIMSImageRecordAPI(imageId string, arch string, metadata map[string]st
 	return
 }
 
-func DeleteIMSImageRecordAPI(imageId string) (ok bool) {
-	common.Infof("Soft deleting image %s", imageId)
-	params := test.GetAccessTokenParams()
+func DeleteIMSImageRecordAPI(ctx context.Context, imageId string) (ok bool) {
+	common.Infof(ctx, "Soft deleting image %s", imageId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("images", apiVersion) + "/" + imageId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "images", apiVersion) + "/" + imageId
 	// setting the payload
-	_, err := test.RestfulVerifyStatus("DELETE", url, *params, http.StatusNoContent)
+	_, err := test.RestfulVerifyStatus(ctx, "DELETE", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
@@ -132,9 +133,9 @@ func DeleteIMSImageRecordAPI(imageId string) (ok bool) {
 	return
 }
 
-func UndeleteIMSImageRecordAPI(imageId string) (ok bool) {
-	common.Infof("Restoring image %s", imageId)
-	params := test.GetAccessTokenParams()
+func UndeleteIMSImageRecordAPI(ctx context.Context, imageId string) (ok bool) {
+	common.Infof(ctx, "Restoring image %s", imageId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
@@ -145,19 +146,16 @@ func UndeleteIMSImageRecordAPI(imageId string) (ok bool) {
 	}
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	params.JsonStrArray = jsonPayload
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("images", apiVersion)
-	// getting the base uri needed for undelete
-	uri := strings.Split(baseURL, "/images")
-	url := uri[0] + "/deleted/images" + "/" + imageId
-	_, err = test.RestfulVerifyStatus("PATCH", url, *params, http.StatusNoContent)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_images", apiVersion) + "/" + imageId
+	_, err = test.RestfulVerifyStatus(ctx, "PATCH", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
@@ -165,49 +163,44 @@ func UndeleteIMSImageRecordAPI(imageId string) (ok bool) {
 	return
 }
 
-func PermanentDeleteIMSImageRecordAPI(imageId string) (ok bool) {
-	common.Infof("Permanently deleting image %s", imageId)
-	params := test.GetAccessTokenParams()
+func PermanentDeleteIMSImageRecordAPI(ctx context.Context, imageId string) (ok bool) {
+	common.Infof(ctx, "Permanently deleting image %s", imageId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("images", apiVersion)
-	// getting the base uri needed for Permanent delete
-	uri := strings.Split(baseURL, "/images")
-	url := uri[0] + "/deleted/images" + "/" + imageId
-	_, err := test.RestfulVerifyStatus("DELETE", url, *params, http.StatusNoContent)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_images", apiVersion) + "/" + imageId
+	_, err := test.RestfulVerifyStatus(ctx, "DELETE", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
+	common.ResourceDeleted(ctx, imsImageKind, imageId)
 
 	ok = true
 	return
 }
 
-func GetDeletedIMSImageRecordAPI(imageId string, httpStatus int) (imageRecord IMSImageRecord, ok bool) {
-	common.Infof("Getting deleted image record %s in IMS via API", imageId)
-	params := test.GetAccessTokenParams()
+func GetDeletedIMSImageRecordAPI(ctx context.Context, imageId string, httpStatus int) (imageRecord IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Getting deleted image record %s in IMS via API", imageId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return IMSImageRecord{}, false
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("images", apiVersion)
-	// getting the base uri needed for getting deleted image record
-	uri := strings.Split(baseURL, "/images")
-	url := uri[0] + "/deleted/images" + "/" + imageId
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, httpStatus)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_images", apiVersion) + "/" + imageId
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, httpStatus)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSImageRecord{}, false
 	}
 	// Extract image record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &imageRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSImageRecord{}, false
 	}
 
@@ -215,27 +208,24 @@ func GetDeletedIMSImageRecordAPI(imageId string, httpStatus int) (imageRecord IM
 	return
 }
 
-func GetDeletedIMSImageRecordsAPI() (recordList []IMSImageRecord, ok bool) {
-	common.Infof("Getting list of all deleted image records in IMS via API")
-	params := test.GetAccessTokenParams()
+func GetDeletedIMSImageRecordsAPI(ctx context.Context) (recordList []IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Getting list of all deleted image records in IMS via API")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return []IMSImageRecord{}, false
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("images", apiVersion)
-	// getting the base uri needed for getting deleted image records
-	uri := strings.Split(baseURL, "/images")
-	url := uri[0] + "/deleted/images"
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_images", apiVersion)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return []IMSImageRecord{}, false
 	}
 	// Extract list of image records from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return []IMSImageRecord{}, false
 	}
 	ok = true
@@ -244,25 +234,25 @@ func GetDeletedIMSImageRecordsAPI() (recordList []IMSImageRecord, ok bool) {
 }
 
 // Return specific image record in IMS via API
-func GetIMSImageRecordAPI(imageId string, httpStatus int) (imageRecord IMSImageRecord, ok bool) {
-	common.Infof("Getting image record %s in IMS via API", imageId)
-	params := test.GetAccessTokenParams()
+func GetIMSImageRecordAPI(ctx context.Context, imageId string, httpStatus int) (imageRecord IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Getting image record %s in IMS via API", imageId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return IMSImageRecord{}, false
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("images", apiVersion) + "/" + imageId
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, httpStatus)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "images", apiVersion) + "/" + imageId
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, httpStatus)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSImageRecord{}, false
 	}
 
 	// Extract image record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &imageRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSImageRecord{}, false
 	}
 	ok = true
@@ -271,25 +261,25 @@ func GetIMSImageRecordAPI(imageId string, httpStatus int) (imageRecord IMSImageR
 }
 
 // Return a list of all image records in IMS via API
-func GetIMSImageRecordsAPI() (recordList []IMSImageRecord, ok bool) {
-	common.Infof("Getting list of all image records in IMS via API")
-	params := test.GetAccessTokenParams()
+func GetIMSImageRecordsAPI(ctx context.Context) (recordList []IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Getting list of all image records in IMS via API")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return []IMSImageRecord{}, false
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("images", apiVersion)
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "images", apiVersion)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return []IMSImageRecord{}, false
 	}
 
 	// Extract list of image records from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return []IMSImageRecord{}, false
 	}
 	ok = true
@@ -297,45 +287,43 @@ func GetIMSImageRecordsAPI() (recordList []IMSImageRecord, ok bool) {
 	return
 }
 
-func ImageRecordExists(imageId string, recordList []IMSImageRecord) (ok bool) {
+func ImageRecordExists(ctx context.Context, imageId string, recordList []IMSImageRecord) (ok bool) {
 	for _, imageRecord := range recordList {
 		if imageRecord.Id == imageId {
 			return true
 		}
 	}
-	common.Infof("Image %s was not found in the list of images", imageId)
+	common.Infof(ctx, "Image %s was not found in the list of images", imageId)
 	return false
 }
 
-func constructIMSURL(endpoint, apiVersion string) string {
-	base := common.BASEURL + endpoints["ims"][endpoint].Url
-	if apiVersion != "" {
-		return base + "/" + apiVersion + endpoints["ims"][endpoint].Uri
-	}
-	return base + endpoints["ims"][endpoint].Uri
+// The URL of an IMS endpoint from the catalog in the specified API version ("" for the path
+// without a version)
+func constructIMSURL(ctx context.Context, endpoint, apiVersion string) string {
+	return serviceEndpoint(ctx, "ims", endpoint).URL(apiVersion)
 }
 
-func VerifyIMSImageRecord(imageRecord IMSImageRecord, expectedImageRecord IMSImageRecord) (ok bool) {
+func VerifyIMSImageRecord(ctx context.Context, imageRecord IMSImageRecord, expectedImageRecord IMSImageRecord) (ok bool) {
 	// Verify the image record is not empty
 	ok = true
 	if imageRecord.Id != expectedImageRecord.Id {
-		common.Errorf("Image ID %s does not match expected ID %s", imageRecord.Id, expectedImageRecord.Id)
+		common.Errorf(ctx, "Image ID %s does not match expected ID %s", imageRecord.Id, expectedImageRecord.Id)
 		ok = false
 	}
 
 	// Verify the image record is in the list of images
 	if imageRecord.Name != expectedImageRecord.Name {
-		common.Errorf("Expected image name %s, got %s", expectedImageRecord.Name, imageRecord.Name)
+		common.Errorf(ctx, "Expected image name %s, got %s", expectedImageRecord.Name, imageRecord.Name)
 		ok = false
 	}
 
-	if !common.CompareMaps(imageRecord.Metadata, expectedImageRecord.Metadata) {
-		common.Errorf("Expected metadata %v, got %v", expectedImageRecord.Metadata, imageRecord.Metadata)
+	if !common.CompareMaps(ctx, imageRecord.Metadata, expectedImageRecord.Metadata) {
+		common.Errorf(ctx, "Expected metadata %v, got %v", expectedImageRecord.Metadata, imageRecord.Metadata)
 		ok = false
 	}
 
 	if imageRecord.Arch != expectedImageRecord.Arch {
-		common.Errorf("Expected image arch %s, got %s", expectedImageRecord.Arch, imageRecord.Arch)
+		common.Errorf(ctx, "Expected image arch %s, got %s", expectedImageRecord.Arch, imageRecord.Arch)
 		ok = false
 	}
 
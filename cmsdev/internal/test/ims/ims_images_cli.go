@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,76 +28,82 @@ package ims
  *
  */
 import (
+	"context"
 	"encoding/json"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func GetDeletedIMSImageRecordCLI(imageId string) (imageRecord IMSImageRecord, ok bool) {
-	common.Infof("Getting deleted image record %s in IMS via CLI", imageId)
-	if cmdOut := runCLICommand("deleted", "images", "describe", imageId); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+func GetDeletedIMSImageRecordCLI(ctx context.Context, imageId string) (imageRecord IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Getting deleted image record %s in IMS via CLI", imageId)
+	if cmdOut := runCLICommand(ctx, "deleted", "images", "describe", imageId); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &imageRecord); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func GetDeletedIMSImageRecordsCLI() (imageRecords []IMSImageRecord, ok bool) {
-	common.Infof("Getting all deleted image records in IMS via CLI")
-	if cmdOut := runCLICommand("deleted", "images", "list"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+func GetDeletedIMSImageRecordsCLI(ctx context.Context) (imageRecords []IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Getting all deleted image records in IMS via CLI")
+	if cmdOut := runCLICommand(ctx, "deleted", "images", "list"); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &imageRecords); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func CreateIMSImageRecordCLI(imageName string) (imageRecord IMSImageRecord, ok bool) {
-	common.Infof("Creating image %s in IMS via CLI", imageName)
-	if cmdOut := runCLICommand("images", "create", "--name", imageName,
+func CreateIMSImageRecordCLI(ctx context.Context, imageName string) (imageRecord IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Creating image %s in IMS via CLI", imageName)
+	if cmdOut := runCLICommand(ctx, "images", "create", "--name", imageName,
 		"--metadata-key", "name", "--metadata-value", imageName); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &imageRecord); err == nil {
+			registerIMSCleanup(ctx, imsImageKind, "images", imageRecord.Id)
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func UpdateIMSImageRecordCLI(imageId string, arch string) (imageRecord IMSImageRecord, ok bool) {
-	common.Infof("Updating image %s with arch %s", imageId, arch)
-	if cmdOut := runCLICommand("images", "update", imageId, "--arch", arch, "--metadata-operation", "set",
+func UpdateIMSImageRecordCLI(ctx context.Context, imageId string, arch string) (imageRecord IMSImageRecord, ok bool) {
+	common.Infof(ctx, "Updating image %s with arch %s", imageId, arch)
+	if cmdOut := runCLICommand(ctx, "images", "update", imageId, "--arch", arch, "--metadata-operation", "set",
 		"--metadata-key", "project", "--metadata-value", "csm"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &imageRecord); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func DeleteIMSImageRecordCLI(imageId string) (ok bool) {
-	common.Infof("Soft deleting image %s", imageId)
-	return runCLICommand("images", "delete", imageId) != nil
+func DeleteIMSImageRecordCLI(ctx context.Context, imageId string) (ok bool) {
+	common.Infof(ctx, "Soft deleting image %s", imageId)
+	return runCLICommand(ctx, "images", "delete", imageId) != nil
 }
 
-func UndeleteIMSImageRecordCLI(imageId string) (ok bool) {
-	common.Infof("Restoring image %s", imageId)
-	return runCLICommand("deleted", "images", "update", imageId, "--operation", "undelete") != nil
+func UndeleteIMSImageRecordCLI(ctx context.Context, imageId string) (ok bool) {
+	common.Infof(ctx, "Restoring image %s", imageId)
+	return runCLICommand(ctx, "deleted", "images", "update", imageId, "--operation", "undelete") != nil
 }
 
-func PermanentDeleteIMSImageRecordCLI(imageId string) (ok bool) {
-	common.Infof("Permanently deleting image %s", imageId)
-	return runCLICommand("deleted", "images", "delete", imageId) != nil
+func PermanentDeleteIMSImageRecordCLI(ctx context.Context, imageId string) (ok bool) {
+	common.Infof(ctx, "Permanently deleting image %s", imageId)
+	if runCLICommand(ctx, "deleted", "images", "delete", imageId) == nil {
+		return false
+	}
+	common.ResourceDeleted(ctx, imsImageKind, imageId)
+	return true
 }
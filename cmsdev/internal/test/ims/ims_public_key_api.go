@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,37 +28,34 @@ package ims
  *
  */
 import (
+	"context"
 	"encoding/json"
 	"net/http"
-	"strings"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func GetDeletedIMSPublicKeyRecordAPI(publicKeyId string, httpStatus int) (publicKeyRecord IMSPublicKeyRecord, ok bool) {
-	common.Infof("Getting public key record %s in IMS via API", publicKeyId)
-	params := test.GetAccessTokenParams()
+func GetDeletedIMSPublicKeyRecordAPI(ctx context.Context, publicKeyId string, httpStatus int) (publicKeyRecord IMSPublicKeyRecord, ok bool) {
+	common.Infof(ctx, "Getting public key record %s in IMS via API", publicKeyId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("public_keys", apiVersion)
-	// getting the base uri needed for getting all deleted public key records
-	uri := strings.Split(baseURL, "/public-keys")
-	url := uri[0] + "/deleted/public-keys" + "/" + publicKeyId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_public_keys", apiVersion) + "/" + publicKeyId
 
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, httpStatus)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, httpStatus)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSPublicKeyRecord{}, false
 	}
 
 	// Extract public key record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &publicKeyRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSPublicKeyRecord{}, false
 	}
 	ok = true
@@ -66,29 +63,26 @@ func GetDeletedIMSPublicKeyRecordAPI(publicKeyId string, httpStatus int) (public
 	return
 }
 
-func GetDeletedIMSPublicKeyRecordsAPI() (recordList []IMSPublicKeyRecord, ok bool) {
-	common.Infof("Getting list of all deleted public key records in IMS via API")
-	params := test.GetAccessTokenParams()
+func GetDeletedIMSPublicKeyRecordsAPI(ctx context.Context) (recordList []IMSPublicKeyRecord, ok bool) {
+	common.Infof(ctx, "Getting list of all deleted public key records in IMS via API")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("public_keys", apiVersion)
-	// getting the base uri needed for getting all deleted public key records
-	uri := strings.Split(baseURL, "/public-keys")
-	url := uri[0] + "/deleted/public-keys"
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_public_keys", apiVersion)
 
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	// Extract list of public key records from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -96,19 +90,19 @@ func GetDeletedIMSPublicKeyRecordsAPI() (recordList []IMSPublicKeyRecord, ok boo
 	return
 }
 
-func DeleteIMSPublicKeyRecordAPI(publicKeyId string) (ok bool) {
-	common.Infof("Soft deleting public key record %s in IMS via API", publicKeyId)
-	params := test.GetAccessTokenParams()
+func DeleteIMSPublicKeyRecordAPI(ctx context.Context, publicKeyId string) (ok bool) {
+	common.Infof(ctx, "Soft deleting public key record %s in IMS via API", publicKeyId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("public_keys", apiVersion) + "/" + publicKeyId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "public_keys", apiVersion) + "/" + publicKeyId
 
-	_, err := test.RestfulVerifyStatus("DELETE", url, *params, http.StatusNoContent)
+	_, err := test.RestfulVerifyStatus(ctx, "DELETE", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
@@ -116,9 +110,9 @@ func DeleteIMSPublicKeyRecordAPI(publicKeyId string) (ok bool) {
 	return
 }
 
-func UndeleteIMSPublicKeyRecordAPI(publicKeyId string) (ok bool) {
-	common.Infof("Restoring public key record %s in IMS via API", publicKeyId)
-	params := test.GetAccessTokenParams()
+func UndeleteIMSPublicKeyRecordAPI(ctx context.Context, publicKeyId string) (ok bool) {
+	common.Infof(ctx, "Restoring public key record %s in IMS via API", publicKeyId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
@@ -130,20 +124,17 @@ func UndeleteIMSPublicKeyRecordAPI(publicKeyId string) (ok bool) {
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	params.JsonStrArray = jsonPayload
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("public_keys", apiVersion)
-	// getting the base uri needed for restoring deleted public key records
-	uri := strings.Split(baseURL, "/public-keys")
-	url := uri[0] + "/deleted/public-keys" + "/" + publicKeyId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_public_keys", apiVersion) + "/" + publicKeyId
 
-	_, err = test.RestfulVerifyStatus("PATCH", url, *params, http.StatusNoContent)
+	_, err = test.RestfulVerifyStatus(ctx, "PATCH", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
@@ -151,39 +142,37 @@ func UndeleteIMSPublicKeyRecordAPI(publicKeyId string) (ok bool) {
 	return
 }
 
-func PermanentDeleteIMSPublicKeyRecordAPI(publicKeyId string) (ok bool) {
-	common.Infof("Permanently deleting public key record %s in IMS via API", publicKeyId)
-	params := test.GetAccessTokenParams()
+func PermanentDeleteIMSPublicKeyRecordAPI(ctx context.Context, publicKeyId string) (ok bool) {
+	common.Infof(ctx, "Permanently deleting public key record %s in IMS via API", publicKeyId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("public_keys", apiVersion)
-	// getting the base uri needed for getting all deleted public key records
-	uri := strings.Split(baseURL, "/public-keys")
-	url := uri[0] + "/deleted/public-keys" + "/" + publicKeyId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_public_keys", apiVersion) + "/" + publicKeyId
 
-	_, err := test.RestfulVerifyStatus("DELETE", url, *params, http.StatusNoContent)
+	_, err := test.RestfulVerifyStatus(ctx, "DELETE", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
+	common.ResourceDeleted(ctx, imsPublicKeyKind, publicKeyId)
 
 	ok = true
 	return
 }
 
-func CreateIMSPublicKeyRecordAPI(publicKeyName string) (publicKeyRecord IMSPublicKeyRecord, ok bool) {
-	common.Infof("Creating public key record %s in IMS via API", publicKeyName)
-	params := test.GetAccessTokenParams()
+func CreateIMSPublicKeyRecordAPI(ctx context.Context, publicKeyName string) (publicKeyRecord IMSPublicKeyRecord, ok bool) {
+	common.Infof(ctx, "Creating public key record %s in IMS via API", publicKeyName)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	sshPublicKey, err := common.GetSSHPublicKey()
+	sshPublicKey, err := common.GetSSHPublicKey(ctx)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
@@ -195,53 +184,54 @@ func CreateIMSPublicKeyRecordAPI(publicKeyName string) (publicKeyRecord IMSPubli
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	params.JsonStr = string(jsonPayload)
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("public_keys", apiVersion)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "public_keys", apiVersion)
 
-	resp, err := test.RestfulVerifyStatus("POST", url, *params, http.StatusCreated)
+	resp, err := test.RestfulVerifyStatus(ctx, "POST", url, *params, http.StatusCreated)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	// Extract public key record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &publicKeyRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
+	registerIMSCleanup(ctx, imsPublicKeyKind, "public_keys", publicKeyRecord.Id)
 	ok = true
 
 	return
 }
 
 // Return specific public key record in IMS via API
-func GetIMSPublicKeyRecordAPI(pkeyId string, httpStatus int) (pkeyRecord IMSPublicKeyRecord, ok bool) {
-	common.Infof("Getting public key record %s in IMS via API", pkeyId)
-	params := test.GetAccessTokenParams()
+func GetIMSPublicKeyRecordAPI(ctx context.Context, pkeyId string, httpStatus int) (pkeyRecord IMSPublicKeyRecord, ok bool) {
+	common.Infof(ctx, "Getting public key record %s in IMS via API", pkeyId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return IMSPublicKeyRecord{}, false
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("public_keys", apiVersion) + "/" + pkeyId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "public_keys", apiVersion) + "/" + pkeyId
 
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, httpStatus)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, httpStatus)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSPublicKeyRecord{}, false
 	}
 
 	// Extract public key record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &pkeyRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSPublicKeyRecord{}, false
 	}
 	ok = true
@@ -250,26 +240,26 @@ func GetIMSPublicKeyRecordAPI(pkeyId string, httpStatus int) (pkeyRecord IMSPubl
 }
 
 // Return a list of all public key records in IMS via API
-func GetIMSPublicKeyRecordsAPI() (recordList []IMSPublicKeyRecord, ok bool) {
-	common.Infof("Getting list of all public key records in IMS via API")
-	params := test.GetAccessTokenParams()
+func GetIMSPublicKeyRecordsAPI(ctx context.Context) (recordList []IMSPublicKeyRecord, ok bool) {
+	common.Infof(ctx, "Getting list of all public key records in IMS via API")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("public_keys", apiVersion)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "public_keys", apiVersion)
 
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	// Extract list of public key records from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -277,12 +267,12 @@ func GetIMSPublicKeyRecordsAPI() (recordList []IMSPublicKeyRecord, ok bool) {
 	return
 }
 
-func PublicKeyRecordExists(publicKeyId string, publicKeyRecords []IMSPublicKeyRecord) (exists bool) {
+func PublicKeyRecordExists(ctx context.Context, publicKeyId string, publicKeyRecords []IMSPublicKeyRecord) (exists bool) {
 	for _, publicKeyRecord := range publicKeyRecords {
 		if publicKeyRecord.Id == publicKeyId {
 			return true
 		}
 	}
-	common.Infof("Public Key %s was not found in the list of public keys", publicKeyId)
+	common.Infof(ctx, "Public Key %s was not found in the list of public keys", publicKeyId)
 	return false
 }
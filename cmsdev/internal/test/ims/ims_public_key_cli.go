@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,70 +28,76 @@ package ims
  *
  */
 import (
+	"context"
 	"encoding/json"
 	"os"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func CreateIMSPublicKeyRecordCLI(publicKeyName string) (publicKeyRecord IMSPublicKeyRecord, ok bool) {
-	common.Infof("Creating public key %s in IMS via CLI", publicKeyName)
+func CreateIMSPublicKeyRecordCLI(ctx context.Context, publicKeyName string) (publicKeyRecord IMSPublicKeyRecord, ok bool) {
+	common.Infof(ctx, "Creating public key %s in IMS via CLI", publicKeyName)
 	// Get the home directory of the current user
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	filePath := homeDir + "/.ssh/id_rsa.pub"
-	if cmdOut := runCLICommand("public-keys", "create", "--name", publicKeyName, "--public-key", filePath); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+	if cmdOut := runCLICommand(ctx, "public-keys", "create", "--name", publicKeyName, "--public-key", filePath); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &publicKeyRecord); err == nil {
+			registerIMSCleanup(ctx, imsPublicKeyKind, "public_keys", publicKeyRecord.Id)
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func GetDeletedIMSPublicKeyRecordCLI(publicKeyId string) (publicKeyRecord IMSPublicKeyRecord, ok bool) {
-	common.Infof("Getting deleted public key record %s in IMS via CLI", publicKeyId)
-	if cmdOut := runCLICommand("deleted", "public-keys", "describe", publicKeyId); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+func GetDeletedIMSPublicKeyRecordCLI(ctx context.Context, publicKeyId string) (publicKeyRecord IMSPublicKeyRecord, ok bool) {
+	common.Infof(ctx, "Getting deleted public key record %s in IMS via CLI", publicKeyId)
+	if cmdOut := runCLICommand(ctx, "deleted", "public-keys", "describe", publicKeyId); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &publicKeyRecord); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func GetDeletedIMSPublicKeyRecordCLIs() (publicKeyRecords []IMSPublicKeyRecord, ok bool) {
-	common.Infof("Getting list of all deleted public key records in IMS via CLI")
-	if cmdOut := runCLICommand("deleted", "public-keys", "list"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+func GetDeletedIMSPublicKeyRecordCLIs(ctx context.Context) (publicKeyRecords []IMSPublicKeyRecord, ok bool) {
+	common.Infof(ctx, "Getting list of all deleted public key records in IMS via CLI")
+	if cmdOut := runCLICommand(ctx, "deleted", "public-keys", "list"); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &publicKeyRecords); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func DeleteIMSPublicKeyRecordCLI(publicKeyId string) (ok bool) {
-	common.Infof("Deleting public key %s in IMS via CLI", publicKeyId)
-	return runCLICommand("public-keys", "delete", publicKeyId) != nil
+func DeleteIMSPublicKeyRecordCLI(ctx context.Context, publicKeyId string) (ok bool) {
+	common.Infof(ctx, "Deleting public key %s in IMS via CLI", publicKeyId)
+	return runCLICommand(ctx, "public-keys", "delete", publicKeyId) != nil
 }
 
-func UndeleteIMSPublicKeyRecordCLI(publicKeyId string) (ok bool) {
-	common.Infof("Restoring public key %s in IMS via CLI", publicKeyId)
-	return runCLICommand("deleted", "public-keys", "update", publicKeyId, "--operation", "undelete") != nil
+func UndeleteIMSPublicKeyRecordCLI(ctx context.Context, publicKeyId string) (ok bool) {
+	common.Infof(ctx, "Restoring public key %s in IMS via CLI", publicKeyId)
+	return runCLICommand(ctx, "deleted", "public-keys", "update", publicKeyId, "--operation", "undelete") != nil
 }
 
-func PermanentDeleteIMSPublicKeyRecordCLI(publicKeyId string) (ok bool) {
-	common.Infof("Hard deleting public key %s in IMS via CLI", publicKeyId)
-	return runCLICommand("deleted", "public-keys", "delete", publicKeyId) != nil
+func PermanentDeleteIMSPublicKeyRecordCLI(ctx context.Context, publicKeyId string) (ok bool) {
+	common.Infof(ctx, "Hard deleting public key %s in IMS via CLI", publicKeyId)
+	if runCLICommand(ctx, "deleted", "public-keys", "delete", publicKeyId) == nil {
+		return false
+	}
+	common.ResourceDeleted(ctx, imsPublicKeyKind, publicKeyId)
+	return true
 }
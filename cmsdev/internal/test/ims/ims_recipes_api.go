@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,17 +28,17 @@ package ims
  *
  */
 import (
+	"context"
 	"encoding/json"
 	"net/http"
-	"strings"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func CreateIMSRecipeRecordAPI(recipeName string, templateDict []map[string]string, requireDKMS bool) (recipeRecord IMSRecipeRecord, ok bool) {
-	common.Infof("Creating recipe %s with templates %v in IMS via API", recipeName, templateDict)
-	params := test.GetAccessTokenParams()
+func CreateIMSRecipeRecordAPI(ctx context.Context, recipeName string, templateDict []map[string]string, requireDKMS bool) (recipeRecord IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Creating recipe %s with templates %v in IMS via API", recipeName, templateDict)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
@@ -52,32 +52,33 @@ func CreateIMSRecipeRecordAPI(recipeName string, templateDict []map[string]strin
 	}
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	// setting the payload
 	params.JsonStr = string(jsonPayload)
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("recipes", apiVersion)
-	resp, err := test.RestfulVerifyStatus("POST", url, *params, http.StatusCreated)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "recipes", apiVersion)
+	resp, err := test.RestfulVerifyStatus(ctx, "POST", url, *params, http.StatusCreated)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	// Extract recipe record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recipeRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
+	registerIMSCleanup(ctx, imsRecipeKind, "recipes", recipeRecord.Id)
 	ok = true
 	return
 }
 
-func UpdateIMSRecipeRecordAPI(recipeId string, arch string, templateDict []map[string]string) (recipeRecord IMSRecipeRecord, ok bool) {
-	common.Infof("Updating recipe %s with arch %s and templates %v", recipeId, arch, templateDict)
-	params := test.GetAccessTokenParams()
+func UpdateIMSRecipeRecordAPI(ctx context.Context, recipeId string, arch string, templateDict []map[string]string) (recipeRecord IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Updating recipe %s with arch %s and templates %v", recipeId, arch, templateDict)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
@@ -88,108 +89,102 @@ func UpdateIMSRecipeRecordAPI(recipeId string, arch string, templateDict []map[s
 	}
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	params.JsonStrArray = jsonPayload
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("recipes", apiVersion) + "/" + recipeId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "recipes", apiVersion) + "/" + recipeId
 
-	resp, err := test.RestfulVerifyStatus("PATCH", url, *params, http.StatusOK)
+	resp, err := test.RestfulVerifyStatus(ctx, "PATCH", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	// Extract recipe record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recipeRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
 	return
 }
 
-func DeleteIMSRecipeRecordAPI(recipeId string) (ok bool) {
-	common.Infof("Soft deleting recipe %s", recipeId)
-	params := test.GetAccessTokenParams()
+func DeleteIMSRecipeRecordAPI(ctx context.Context, recipeId string) (ok bool) {
+	common.Infof(ctx, "Soft deleting recipe %s", recipeId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("recipes", apiVersion) + "/" + recipeId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "recipes", apiVersion) + "/" + recipeId
 
-	_, err := test.RestfulVerifyStatus("DELETE", url, *params, http.StatusNoContent)
+	_, err := test.RestfulVerifyStatus(ctx, "DELETE", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
 	return
 }
 
-func GetDeletedIMSRecipeRecordAPI(recipeId string, httpStatus int) (recipeRecord IMSRecipeRecord, ok bool) {
-	common.Infof("Getting deleted recipe record %s in IMS via API", recipeId)
-	params := test.GetAccessTokenParams()
+func GetDeletedIMSRecipeRecordAPI(ctx context.Context, recipeId string, httpStatus int) (recipeRecord IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Getting deleted recipe record %s in IMS via API", recipeId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return IMSRecipeRecord{}, false
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("recipes", apiVersion)
-	// getting the base uri needed for getting deleted recipe record
-	uri := strings.Split(baseURL, "/recipes")
-	url := uri[0] + "/deleted/recipes" + "/" + recipeId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_recipes", apiVersion) + "/" + recipeId
 
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, httpStatus)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, httpStatus)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSRecipeRecord{}, false
 	}
 	// Extract recipe record from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recipeRecord); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return IMSRecipeRecord{}, false
 	}
 	ok = true
 	return
 }
 
-func GetDeletedIMSRecipeRecordsAPI() (recordList []IMSRecipeRecord, ok bool) {
-	common.Infof("Getting list of all deleted recipe records in IMS via API")
-	params := test.GetAccessTokenParams()
+func GetDeletedIMSRecipeRecordsAPI(ctx context.Context) (recordList []IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Getting list of all deleted recipe records in IMS via API")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("recipes", apiVersion)
-	// getting the base uri needed for getting all deleted recipe record
-	uri := strings.Split(baseURL, "/recipes")
-	url := uri[0] + "/deleted/recipes"
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_recipes", apiVersion)
 
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	// Extract list of recipe records from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
 	return
 }
 
-func UndeleteIMSRecipeRecordAPI(recipeId string) (ok bool) {
-	common.Infof("Restoring recipe %s", recipeId)
-	params := test.GetAccessTokenParams()
+func UndeleteIMSRecipeRecordAPI(ctx context.Context, recipeId string) (ok bool) {
+	common.Infof(ctx, "Restoring recipe %s", recipeId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
@@ -199,66 +194,61 @@ func UndeleteIMSRecipeRecordAPI(recipeId string) (ok bool) {
 	}
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	params.JsonStrArray = jsonPayload
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("recipes", apiVersion)
-	// getting the base uri needed for restoring deleted recipe record
-	uri := strings.Split(baseURL, "/recipes")
-	url := uri[0] + "/deleted/recipes" + "/" + recipeId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_recipes", apiVersion) + "/" + recipeId
 
-	_, err = test.RestfulVerifyStatus("PATCH", url, *params, http.StatusNoContent)
+	_, err = test.RestfulVerifyStatus(ctx, "PATCH", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
 	return
 }
 
-func PermanentDeleteIMSRecipeRecordAPI(recipeId string) (ok bool) {
-	common.Infof("Permanently deleting recipe %s", recipeId)
-	params := test.GetAccessTokenParams()
+func PermanentDeleteIMSRecipeRecordAPI(ctx context.Context, recipeId string) (ok bool) {
+	common.Infof(ctx, "Permanently deleting recipe %s", recipeId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	baseURL := constructIMSURL("recipes", apiVersion)
-	// getting the base uri needed for getting deleted recipe record
-	uri := strings.Split(baseURL, "/recipes")
-	url := uri[0] + "/deleted/recipes" + "/" + recipeId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "deleted_recipes", apiVersion) + "/" + recipeId
 
-	_, err := test.RestfulVerifyStatus("DELETE", url, *params, http.StatusNoContent)
+	_, err := test.RestfulVerifyStatus(ctx, "DELETE", url, *params, http.StatusNoContent)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
+	common.ResourceDeleted(ctx, imsRecipeKind, recipeId)
 	ok = true
 	return
 }
 
 // Return a specific recipe record in IMS via API
-func GetIMSRecipeRecordAPI(recipeId string, httpStatus int) (recipeRecord IMSRecipeRecord, ok bool) {
-	common.Infof("Getting recipe record %s in IMS via API", recipeId)
-	params := test.GetAccessTokenParams()
+func GetIMSRecipeRecordAPI(ctx context.Context, recipeId string, httpStatus int) (recipeRecord IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Getting recipe record %s in IMS via API", recipeId)
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return IMSRecipeRecord{}, false
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("recipes", apiVersion) + "/" + recipeId
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "recipes", apiVersion) + "/" + recipeId
 
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, httpStatus)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, httpStatus)
 	if err != nil {
 		return IMSRecipeRecord{}, false
 	}
 
 	// Extract recipe record from command output
-	common.Infof("Decoding JSON in command output")
+	common.Infof(ctx, "Decoding JSON in command output")
 	err = json.Unmarshal(resp.Body(), &recipeRecord)
 	if err != nil {
 		return IMSRecipeRecord{}, false
@@ -268,26 +258,26 @@ func GetIMSRecipeRecordAPI(recipeId string, httpStatus int) (recipeRecord IMSRec
 }
 
 // Return a list of all recipe records in IMS via API
-func GetIMSRecipeRecordsAPI() (recordList []IMSRecipeRecord, ok bool) {
-	common.Infof("Getting list of all recipe records in IMS via API")
-	params := test.GetAccessTokenParams()
+func GetIMSRecipeRecordsAPI(ctx context.Context) (recordList []IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Getting list of all recipe records in IMS via API")
+	params := test.GetAccessTokenParams(ctx)
 	if params == nil {
 		return
 	}
 
-	apiVersion := common.GetIMSAPIVersion()
-	url := constructIMSURL("recipes", apiVersion)
+	apiVersion := common.GetIMSAPIVersion(ctx)
+	url := constructIMSURL(ctx, "recipes", apiVersion)
 
-	resp, err := test.RestfulVerifyStatus("GET", url, *params, http.StatusOK)
+	resp, err := test.RestfulVerifyStatus(ctx, "GET", url, *params, http.StatusOK)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	// Extract list of recipe records from response
-	common.Infof("Decoding JSON in response body")
+	common.Infof(ctx, "Decoding JSON in response body")
 	if err := json.Unmarshal(resp.Body(), &recordList); err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ok = true
@@ -295,45 +285,45 @@ func GetIMSRecipeRecordsAPI() (recordList []IMSRecipeRecord, ok bool) {
 	return
 }
 
-func RecipeRecordExists(recipeId string, recipeRecords []IMSRecipeRecord) (exists bool) {
+func RecipeRecordExists(ctx context.Context, recipeId string, recipeRecords []IMSRecipeRecord) (exists bool) {
 	for _, recipeRecord := range recipeRecords {
 		if recipeRecord.Id == recipeId {
 			return true
 		}
 	}
-	common.Infof("Recipe %s was not found in the list of recipes", recipeId)
+	common.Infof(ctx, "Recipe %s was not found in the list of recipes", recipeId)
 	return false
 }
 
-func VerifyIMSRecipeRecord(recipeRecord IMSRecipeRecord, existingRecipeRecord IMSRecipeRecord) (ok bool) {
+func VerifyIMSRecipeRecord(ctx context.Context, recipeRecord IMSRecipeRecord, existingRecipeRecord IMSRecipeRecord) (ok bool) {
 	ok = true
 	if recipeRecord.Name != existingRecipeRecord.Name {
-		common.Errorf("Recipe name %s does not match expected name %s", recipeRecord.Name, existingRecipeRecord.Name)
+		common.Errorf(ctx, "Recipe name %s does not match expected name %s", recipeRecord.Name, existingRecipeRecord.Name)
 		ok = false
 	}
 
 	if recipeRecord.Linux_distribution != existingRecipeRecord.Linux_distribution {
-		common.Errorf("Recipe linux distribution %s does not match expected linux distribution %s", recipeRecord.Linux_distribution, existingRecipeRecord.Linux_distribution)
+		common.Errorf(ctx, "Recipe linux distribution %s does not match expected linux distribution %s", recipeRecord.Linux_distribution, existingRecipeRecord.Linux_distribution)
 		ok = false
 	}
 
 	if recipeRecord.Arch != existingRecipeRecord.Arch {
-		common.Errorf("Recipe arch %s does not match expected arch %s", recipeRecord.Arch, existingRecipeRecord.Arch)
+		common.Errorf(ctx, "Recipe arch %s does not match expected arch %s", recipeRecord.Arch, existingRecipeRecord.Arch)
 		ok = false
 	}
 
 	if recipeRecord.Recipe_type != existingRecipeRecord.Recipe_type {
-		common.Errorf("Recipe type %s does not match expected recipe type %s", recipeRecord.Recipe_type, existingRecipeRecord.Recipe_type)
+		common.Errorf(ctx, "Recipe type %s does not match expected recipe type %s", recipeRecord.Recipe_type, existingRecipeRecord.Recipe_type)
 		ok = false
 	}
 
 	if recipeRecord.Require_dkms != existingRecipeRecord.Require_dkms {
-		common.Errorf("Recipe require_dkms %t does not match expected require_dkms %t", recipeRecord.Require_dkms, existingRecipeRecord.Require_dkms)
+		common.Errorf(ctx, "Recipe require_dkms %t does not match expected require_dkms %t", recipeRecord.Require_dkms, existingRecipeRecord.Require_dkms)
 		ok = false
 	}
 
-	if !common.CompareSlicesOfMaps(recipeRecord.Template_dictionary, existingRecipeRecord.Template_dictionary) {
-		common.Errorf("Template dictionary %v does not match expected template dictionary %v", recipeRecord.Template_dictionary, existingRecipeRecord.Template_dictionary)
+	if !common.CompareSlicesOfMaps(ctx, recipeRecord.Template_dictionary, existingRecipeRecord.Template_dictionary) {
+		common.Errorf(ctx, "Template dictionary %v does not match expected template dictionary %v", recipeRecord.Template_dictionary, existingRecipeRecord.Template_dictionary)
 		ok = false
 	}
 
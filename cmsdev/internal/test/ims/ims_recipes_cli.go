@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,78 +28,84 @@ package ims
  *
  */
 import (
+	"context"
 	"encoding/json"
 
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 )
 
-func GetDeletedIMSRecipeRecordCLI(recipeId string) (recipeRecord IMSRecipeRecord, ok bool) {
-	common.Infof("Getting deleted recipe record %s in IMS via CLI", recipeId)
-	if cmdOut := runCLICommand("deleted", "recipes", "describe", recipeId); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+func GetDeletedIMSRecipeRecordCLI(ctx context.Context, recipeId string) (recipeRecord IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Getting deleted recipe record %s in IMS via CLI", recipeId)
+	if cmdOut := runCLICommand(ctx, "deleted", "recipes", "describe", recipeId); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &recipeRecord); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func GetDeletedIMSRecipeRecordsCLI() (recipeRecords []IMSRecipeRecord, ok bool) {
-	common.Infof("Getting all deleted recipe records in IMS via CLI")
-	if cmdOut := runCLICommand("deleted", "recipes", "list"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+func GetDeletedIMSRecipeRecordsCLI(ctx context.Context) (recipeRecords []IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Getting all deleted recipe records in IMS via CLI")
+	if cmdOut := runCLICommand(ctx, "deleted", "recipes", "list"); cmdOut != nil {
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &recipeRecords); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func CreateIMSRecipeRecordCLI(recipeName string) (recipeRecord IMSRecipeRecord, ok bool) {
-	common.Infof("Creating recipe %s in IMS via CLI", recipeName)
-	if cmdOut := runCLICommand("recipes", "create", "--name",
+func CreateIMSRecipeRecordCLI(ctx context.Context, recipeName string) (recipeRecord IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Creating recipe %s in IMS via CLI", recipeName)
+	if cmdOut := runCLICommand(ctx, "recipes", "create", "--name",
 		recipeName, "--linux-distribution", "sles15",
 		"--recipe-type", "kiwi-ng", "--template-dictionary-key", "USS_VERSION,FULL_COS_BASE_VERSION",
 		"--template-dictionary-value", "1.1.2-1-cos-base-3.1,3.1.2-1-sle-15.5"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &recipeRecord); err == nil {
+			registerIMSCleanup(ctx, imsRecipeKind, "recipes", recipeRecord.Id)
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func UpdateIMSRecipeRecordCLI(recipeId string, arch string) (recipeRecord IMSRecipeRecord, ok bool) {
-	common.Infof("Updating recipe %s with arch %s", recipeId, arch)
-	if cmdOut := runCLICommand("recipes", "update", recipeId, "--arch", arch,
+func UpdateIMSRecipeRecordCLI(ctx context.Context, recipeId string, arch string) (recipeRecord IMSRecipeRecord, ok bool) {
+	common.Infof(ctx, "Updating recipe %s with arch %s", recipeId, arch)
+	if cmdOut := runCLICommand(ctx, "recipes", "update", recipeId, "--arch", arch,
 		"--template-dictionary-key", "USS_VERSION", "--template-dictionary-value", "1.1.2-1-cos-3.1"); cmdOut != nil {
-		common.Infof("Decoding JSON in command output")
+		common.Infof(ctx, "Decoding JSON in command output")
 		if err := json.Unmarshal(cmdOut, &recipeRecord); err == nil {
 			ok = true
 		} else {
-			common.Error(err)
+			common.Error(ctx, err)
 		}
 	}
 	return
 }
 
-func DeleteIMSRecipeRecordCLI(recipeId string) (ok bool) {
-	common.Infof("Deleting recipe record %s in IMS via CLI", recipeId)
-	return runCLICommand("recipes", "delete", recipeId) != nil
+func DeleteIMSRecipeRecordCLI(ctx context.Context, recipeId string) (ok bool) {
+	common.Infof(ctx, "Deleting recipe record %s in IMS via CLI", recipeId)
+	return runCLICommand(ctx, "recipes", "delete", recipeId) != nil
 }
 
-func UndeleteIMSRecipeRecordCLI(recipeId string) bool {
-	common.Infof("Restoring recipe record %s in IMS via CLI", recipeId)
-	return runCLICommand("deleted", "recipes", "update", recipeId, "--operation", "undelete") != nil
+func UndeleteIMSRecipeRecordCLI(ctx context.Context, recipeId string) bool {
+	common.Infof(ctx, "Restoring recipe record %s in IMS via CLI", recipeId)
+	return runCLICommand(ctx, "deleted", "recipes", "update", recipeId, "--operation", "undelete") != nil
 }
 
-func PermanentDeleteIMSRecipeRecordCLI(recipeId string) bool {
-	common.Infof("Permanently deleting recipe record %s in IMS via CLI", recipeId)
-	return runCLICommand("deleted", "recipes", "delete", recipeId) != nil
+func PermanentDeleteIMSRecipeRecordCLI(ctx context.Context, recipeId string) bool {
+	common.Infof(ctx, "Permanently deleting recipe record %s in IMS via CLI", recipeId)
+	if runCLICommand(ctx, "deleted", "recipes", "delete", recipeId) == nil {
+		return false
+	}
+	common.ResourceDeleted(ctx, imsRecipeKind, recipeId)
+	return true
 }
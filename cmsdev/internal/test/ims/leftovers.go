@@ -0,0 +1,158 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * leftovers.go
+ *
+ * Finding IMS images, recipes and public keys left behind by earlier test runs
+ *
+ */
+
+package ims
+
+import (
+	"context"
+	"regexp"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
+)
+
+// The id and name of an IMS record of any kind
+type imsRecordName struct {
+	id, name string
+}
+
+// A kind of IMS record which the tests create, the names they generate for it, and functions
+// to list the live and soft deleted records
+type imsLeftoverKind struct {
+	kind, endpoint string
+	namePattern    *regexp.Regexp
+	list           func(ctx context.Context) ([]imsRecordName, bool)
+	listDeleted    func(ctx context.Context) ([]imsRecordName, bool)
+}
+
+var imsLeftoverKinds = []imsLeftoverKind{
+	{
+		kind: imsImageKind, endpoint: "images",
+		namePattern: regexp.MustCompile(`^image_[a-z]{10}$`),
+		list: func(ctx context.Context) ([]imsRecordName, bool) {
+			records, ok := GetIMSImageRecordsAPI(ctx)
+			return imageRecordNames(records), ok
+		},
+		listDeleted: func(ctx context.Context) ([]imsRecordName, bool) {
+			records, ok := GetDeletedIMSImageRecordsAPI(ctx)
+			return imageRecordNames(records), ok
+		},
+	},
+	{
+		kind: imsRecipeKind, endpoint: "recipes",
+		namePattern: regexp.MustCompile(`^recipe_[a-z]{10}$`),
+		list: func(ctx context.Context) ([]imsRecordName, bool) {
+			records, ok := GetIMSRecipeRecordsAPI(ctx)
+			return recipeRecordNames(records), ok
+		},
+		listDeleted: func(ctx context.Context) ([]imsRecordName, bool) {
+			records, ok := GetDeletedIMSRecipeRecordsAPI(ctx)
+			return recipeRecordNames(records), ok
+		},
+	},
+	{
+		kind: imsPublicKeyKind, endpoint: "public_keys",
+		namePattern: regexp.MustCompile(`^public_key_[a-z]{10}$`),
+		list: func(ctx context.Context) ([]imsRecordName, bool) {
+			records, ok := GetIMSPublicKeyRecordsAPI(ctx)
+			return publicKeyRecordNames(records), ok
+		},
+		listDeleted: func(ctx context.Context) ([]imsRecordName, bool) {
+			records, ok := GetDeletedIMSPublicKeyRecordsAPI(ctx)
+			return publicKeyRecordNames(records), ok
+		},
+	},
+}
+
+func imageRecordNames(records []IMSImageRecord) (names []imsRecordName) {
+	for _, record := range records {
+		names = append(names, imsRecordName{id: record.Id, name: record.Name})
+	}
+	return
+}
+
+func recipeRecordNames(records []IMSRecipeRecord) (names []imsRecordName) {
+	for _, record := range records {
+		names = append(names, imsRecordName{id: record.Id, name: record.Name})
+	}
+	return
+}
+
+func publicKeyRecordNames(records []IMSPublicKeyRecord) (names []imsRecordName) {
+	for _, record := range records {
+		names = append(names, imsRecordName{id: record.Id, name: record.Name})
+	}
+	return
+}
+
+// Find the IMS images, recipes and public keys created by cmsdev. These are soft deleted, unless
+// opts.PurgeDeleted is set, in which case they are also permanently deleted, as are any soft
+// deleted records created by cmsdev.
+func findIMSLeftovers(opts registry.CleanupOptions) (leftovers []registry.Leftover, ok bool) {
+	ctx := opts.Context
+	ok = true
+	for _, k := range imsLeftoverKinds {
+		if records, listed := k.list(ctx); !listed {
+			ok = false
+		} else {
+			for _, record := range records {
+				if !k.namePattern.MatchString(record.name) {
+					continue
+				}
+				liveUrl, deletedUrl := imsRecordUrls(ctx, k.endpoint, record.id)
+				urls := []string{liveUrl}
+				if opts.PurgeDeleted {
+					urls = append(urls, deletedUrl)
+				}
+				leftovers = append(leftovers, registry.Leftover{
+					Kind: k.kind, Name: record.name + " (" + record.id + ")",
+					Delete: func() bool { return test.DeleteURLs(ctx, urls...) },
+				})
+			}
+		}
+		if !opts.PurgeDeleted {
+			continue
+		}
+		if records, listed := k.listDeleted(ctx); !listed {
+			ok = false
+		} else {
+			for _, record := range records {
+				if !k.namePattern.MatchString(record.name) {
+					continue
+				}
+				_, deletedUrl := imsRecordUrls(ctx, k.endpoint, record.id)
+				leftovers = append(leftovers, registry.Leftover{
+					Kind: "deleted " + k.kind, Name: record.name + " (" + record.id + ")",
+					Delete: func() bool { return test.DeleteURLs(ctx, deletedUrl) },
+				})
+			}
+		}
+	}
+	return
+}
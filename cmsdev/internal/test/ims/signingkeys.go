@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2021-2023, 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2021-2023, 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,6 +29,7 @@ package ims
  */
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"regexp"
@@ -45,11 +46,11 @@ const imageFieldName = "image_job_create.yaml.template"
 // Retrieve the cray-configmap-ims-v2-image-create-kiwi-ng configmap in kubernetes
 // Look at the data field for "image_job_create.yaml.template"
 // Parse that field to find the image which has kiwi-ng in its name, and return that name
-func getOpensuseImage() (imagename string, err error) {
+func getOpensuseImage(ctx context.Context) (imagename string, err error) {
 	imagename = ""
 
 	// Retrieve image_job_create.yaml.template data field from the config map
-	imageFieldBytes, err := k8s.GetConfigMapDataField(common.NAMESPACE, configMapName, imageFieldName)
+	imageFieldBytes, err := k8s.GetConfigMapDataField(ctx, common.NAMESPACE, configMapName, imageFieldName)
 	if err != nil {
 		return
 	}
@@ -68,13 +69,13 @@ func getOpensuseImage() (imagename string, err error) {
 	var in ImageName
 	err = yaml.Unmarshal(imageFieldBytes, &in)
 	if err != nil {
-		common.Errorf("Error parsing config map data as YAML")
+		common.Errorf(ctx, "Error parsing config map data as YAML")
 		return
 	}
-	common.Debugf("SPEC: %s", in)
-	common.Debugf("Find image with name containing kiwi-ng")
+	common.Debugf(ctx, "SPEC: %s", in)
+	common.Debugf(ctx, "Find image with name containing kiwi-ng")
 	for _, cont := range in.Spec.Template.Spec.InitContainers {
-		common.Debugf("NAME: %s", cont.Image)
+		common.Debugf(ctx, "NAME: %s", cont.Image)
 		if strings.Contains(cont.Image, "kiwi-ng") {
 			imagename = cont.Image
 			return
@@ -86,16 +87,16 @@ func getOpensuseImage() (imagename string, err error) {
 
 // Run the command podman run --entrypoint "" registry.local/imagename ls /signing-keys
 // return the signing keys found
-func getSigningkeys(imagename string) (keys []string, err error) {
+func getSigningkeys(ctx context.Context, imagename string) (keys []string, err error) {
 	var cmd *exec.Cmd
 	var cmdOut []byte
 	// Run the command podman run --entrypoint "" registry.local/imagename ls /signing-keys
 	cmd = exec.Command("podman", "run", "--entrypoint", "", "registry.local/"+imagename, "ls", "/signing-keys")
-	common.Infof("Running command: %s", cmd)
+	common.Infof(ctx, "Running command: %s", cmd)
 	cmdOut, err = cmd.CombinedOutput()
-	common.Debugf("OUT: %s", cmdOut)
+	common.Debugf(ctx, "OUT: %s", cmdOut)
 	if err != nil {
-		common.Errorf("Error running podman ls /signing-keys command")
+		common.Errorf(ctx, "Error running podman ls /signing-keys command")
 		return
 	}
 	// Find signing keys by finding strings that end with .asc
@@ -106,35 +107,35 @@ func getSigningkeys(imagename string) (keys []string, err error) {
 		return
 	}
 	for _, key := range keys {
-		common.Debugf("Found Key: %s", key)
+		common.Debugf(ctx, "Found Key: %s", key)
 	}
 	return
 }
 
 // Run the signing keys test
-func signingkeysTest() bool {
-	common.Infof("Performing RPM signing keys test")
+func signingkeysTest(ctx context.Context) (passed bool) {
+	common.Infof(ctx, "Performing RPM signing keys test")
 
 	// Get opensuse image
-	imagename, err := getOpensuseImage()
+	imagename, err := getOpensuseImage(ctx)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 
 	// Get signing keys
-	keys, err := getSigningkeys(imagename)
+	keys, err := getSigningkeys(ctx, imagename)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return false
 	}
 
 	// Verify there are signing keys baked in the image
 	if len(keys) == 0 {
-		common.Errorf("No signing keys found in image %s", imagename)
+		common.Errorf(ctx, "No signing keys found in image %s", imagename)
 		return false
 	}
 
-	common.Infof("RPM signing keys test passed")
+	common.Infof(ctx, "RPM signing keys test passed")
 	return true
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -22,6 +22,7 @@
 package ims
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -36,199 +37,205 @@ import (
  */
 
 // Test image CRUD operations using all supported API versions
-func TestImageCRUDOperationUsingAPIVersions() (passed bool) {
+func TestImageCRUDOperationUsingAPIVersions(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestImageCRUDOperationUsingAPIVersions").End(ctx, &passed)
 	passed = true
 
 	for _, version := range common.IMSAPIVERSIONS {
-		common.PrintLog(fmt.Sprintf("Testing image CRUD operations using API version: %s", version))
-		common.SetIMSAPIVersion(version)
-		passed = passed && TestImageCRUDOperation(version)
+		common.PrintLog(ctx, fmt.Sprintf("Testing image CRUD operations using API version: %s", version))
+		common.SetIMSAPIVersion(ctx, version)
+		passed = passed && TestImageCRUDOperation(ctx, version)
 	}
 
 	// default API version
-	common.SetIMSAPIVersion("")
-	common.PrintLog("Testing image CRUD operations using default API version")
-	passed = passed && TestImageCRUDOperation(common.GetIMSAPIVersion())
+	common.SetIMSAPIVersion(ctx, "")
+	common.PrintLog(ctx, "Testing image CRUD operations using default API version")
+	passed = passed && TestImageCRUDOperation(ctx, common.GetIMSAPIVersion(ctx))
 	return passed
 }
 
-func TestImageCRUDOperation(apiVersion string) (passed bool) {
+func TestImageCRUDOperation(ctx context.Context, apiVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestImageCRUDOperation").End(ctx, &passed)
 	// Test creating an image
-	imageRecord, success := TestImageCreate()
+	imageRecord, success := TestImageCreate(ctx)
 	if !success {
 		return false
 	}
 
 	// Test updating the image
-	updated := TestImageUpdate(imageRecord.Id)
+	updated := TestImageUpdate(ctx, imageRecord.Id)
 
 	// Test get all images
-	getAll := TestGetAllImages()
+	getAll := TestGetAllImages(ctx)
 
 	if apiVersion == "v3" {
 
 		// Test soft deleting the image
-		deleted := TestImageDelete(imageRecord.Id)
+		deleted := TestImageDelete(ctx, imageRecord.Id)
 
 		// Test undeleting the image
-		undeleted := TestImageUndelete(imageRecord.Id)
+		undeleted := TestImageUndelete(ctx, imageRecord.Id)
 
 		// Test hard deleting the image
-		permDeleted := TestImagePermanentDelete(imageRecord.Id)
+		permDeleted := TestImagePermanentDelete(ctx, imageRecord.Id)
 
 		return updated && deleted && undeleted && permDeleted && getAll
 	}
 
 	// Test deleting the image
-	deleted := TestImageDeleteV2(imageRecord.Id)
+	deleted := TestImageDeleteV2(ctx, imageRecord.Id)
 
 	return updated && deleted && getAll
 }
 
-func TestImagePermanentDelete(imageId string) (passed bool) {
+func TestImagePermanentDelete(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestImagePermanentDelete").End(ctx, &passed)
 	// Soft delete the image
-	if success := DeleteIMSImageRecordAPI(imageId); !success {
+	if success := DeleteIMSImageRecordAPI(ctx, imageId); !success {
 		return false
 	}
 
 	// Permanently delete the image
-	if success := PermanentDeleteIMSImageRecordAPI(imageId); !success {
+	if success := PermanentDeleteIMSImageRecordAPI(ctx, imageId); !success {
 		return false
 	}
 
 	// Verify the image is permanently deleted
-	if _, success := GetDeletedIMSImageRecordAPI(imageId, http.StatusNotFound); !success {
-		common.Errorf("Image %s was not permanently deleted", imageId)
+	if _, success := GetDeletedIMSImageRecordAPI(ctx, imageId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Image %s was not permanently deleted", imageId)
 		return false
 	}
 	// Verify the image is not in the list of images
-	if _, success := GetIMSImageRecordAPI(imageId, http.StatusNotFound); !success {
-		common.Errorf("Image %s was not permanently deleted", imageId)
+	if _, success := GetIMSImageRecordAPI(ctx, imageId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Image %s was not permanently deleted", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of all images
-	imageRecords, success := GetIMSImageRecordsAPI()
+	imageRecords, success := GetIMSImageRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if ImageRecordExists(imageId, imageRecords) {
-		common.Errorf("Image %s was not permanently deleted", imageId)
+	if ImageRecordExists(ctx, imageId, imageRecords) {
+		common.Errorf(ctx, "Image %s was not permanently deleted", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of deleted images
-	deletedImageRecords, success := GetDeletedIMSImageRecordsAPI()
+	deletedImageRecords, success := GetDeletedIMSImageRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if ImageRecordExists(imageId, deletedImageRecords) {
-		common.Errorf("Image %s was not permanently deleted", imageId)
+	if ImageRecordExists(ctx, imageId, deletedImageRecords) {
+		common.Errorf(ctx, "Image %s was not permanently deleted", imageId)
 		return false
 	}
 
-	common.Infof("Image %s was permanently deleted", imageId)
+	common.Infof(ctx, "Image %s was permanently deleted", imageId)
 	return true
 }
 
-func TestImageUndelete(imageId string) (passed bool) {
+func TestImageUndelete(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestImageUndelete").End(ctx, &passed)
 	// Get the image details before restoration
-	existingImageRecord, success := GetDeletedIMSImageRecordAPI(imageId, http.StatusOK)
+	existingImageRecord, success := GetDeletedIMSImageRecordAPI(ctx, imageId, http.StatusOK)
 	if !success {
-		common.Errorf("Unable to fetch Image %s ", imageId)
+		common.Errorf(ctx, "Unable to fetch Image %s ", imageId)
 		return false
 	}
 
-	if success := UndeleteIMSImageRecordAPI(imageId); !success {
+	if success := UndeleteIMSImageRecordAPI(ctx, imageId); !success {
 		return false
 	}
 
 	// Verify the image is undeleted
-	imageRecord, success := GetIMSImageRecordAPI(imageId, http.StatusOK)
+	imageRecord, success := GetIMSImageRecordAPI(ctx, imageId, http.StatusOK)
 	if !success {
-		common.Errorf("Image %s was not restored", imageId)
+		common.Errorf(ctx, "Image %s was not restored", imageId)
 		return false
 	}
 
 	// Verify the image details are the same after restoration
-	if !VerifyIMSImageRecord(imageRecord, existingImageRecord) {
-		common.Errorf("Image %s details do not match after restore", imageId)
+	if !VerifyIMSImageRecord(ctx, imageRecord, existingImageRecord) {
+		common.Errorf(ctx, "Image %s details do not match after restore", imageId)
 		return false
 	}
 
-	common.Infof("Image %s was restored", imageId)
+	common.Infof(ctx, "Image %s was restored", imageId)
 	return true
 }
 
-func TestImageDelete(imageId string) (passed bool) {
+func TestImageDelete(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestImageDelete").End(ctx, &passed)
 	// Get the image details before deletion
-	existingImageRecord, success := GetIMSImageRecordAPI(imageId, http.StatusOK)
+	existingImageRecord, success := GetIMSImageRecordAPI(ctx, imageId, http.StatusOK)
 	if !success {
-		common.Errorf("Unable to fetch Image %s ", imageId)
+		common.Errorf(ctx, "Unable to fetch Image %s ", imageId)
 		return false
 	}
 
-	if success := DeleteIMSImageRecordAPI(imageId); !success {
+	if success := DeleteIMSImageRecordAPI(ctx, imageId); !success {
 		return false
 	}
 
 	// Verify the image is deleted
-	deletedImageRecord, success := GetDeletedIMSImageRecordAPI(imageId, http.StatusOK)
+	deletedImageRecord, success := GetDeletedIMSImageRecordAPI(ctx, imageId, http.StatusOK)
 	if !success {
-		common.Errorf("Image %s was not soft deleted", imageId)
+		common.Errorf(ctx, "Image %s was not soft deleted", imageId)
 		return false
 	}
 
 	// Verify the image details are the same as before deletion
-	if !VerifyIMSImageRecord(deletedImageRecord, existingImageRecord) {
-		common.Errorf("Image %s details do not match after soft delete", imageId)
+	if !VerifyIMSImageRecord(ctx, deletedImageRecord, existingImageRecord) {
+		common.Errorf(ctx, "Image %s details do not match after soft delete", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of images
-	if _, success := GetIMSImageRecordAPI(imageId, http.StatusNotFound); !success {
-		common.Errorf("Image %s was not soft deleted", imageId)
+	if _, success := GetIMSImageRecordAPI(ctx, imageId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Image %s was not soft deleted", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of all images
-	imageRecords, success := GetIMSImageRecordsAPI()
+	imageRecords, success := GetIMSImageRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if ImageRecordExists(imageId, imageRecords) {
-		common.Errorf("Image %s was not soft deleted", imageId)
+	if ImageRecordExists(ctx, imageId, imageRecords) {
+		common.Errorf(ctx, "Image %s was not soft deleted", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of deleted images
-	deletedImageRecords, success := GetDeletedIMSImageRecordsAPI()
+	deletedImageRecords, success := GetDeletedIMSImageRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if !ImageRecordExists(imageId, deletedImageRecords) {
-		common.Errorf("Image %s was not found in the list of deleted images", imageId)
+	if !ImageRecordExists(ctx, imageId, deletedImageRecords) {
+		common.Errorf(ctx, "Image %s was not found in the list of deleted images", imageId)
 		return false
 	}
 
-	common.Infof("Image %s was deleted", imageId)
+	common.Infof(ctx, "Image %s was deleted", imageId)
 	return true
 }
 
-func TestImageUpdate(imageId string) (passed bool) {
+func TestImageUpdate(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestImageUpdate").End(ctx, &passed)
 	// getting the existing metedata info for the image and updating it as per the test
-	existingImageRecord, success := GetIMSImageRecordAPI(imageId, http.StatusOK)
+	existingImageRecord, success := GetIMSImageRecordAPI(ctx, imageId, http.StatusOK)
 	if !success {
-		common.Errorf("Unable to fetch Image %s ", imageId)
+		common.Errorf(ctx, "Unable to fetch Image %s ", imageId)
 		return false
 	}
 	expectedMetadata := existingImageRecord.Metadata
 	expectedMetadata["project"] = "csm"
-	common.Infof("Expected metadata %v", expectedMetadata)
+	common.Infof(ctx, "Expected metadata %v", expectedMetadata)
 
 	arch := "aarch64"
 	metadata := map[string]string{
@@ -236,24 +243,25 @@ func TestImageUpdate(imageId string) (passed bool) {
 		"key":       "project",
 		"value":     "csm",
 	}
-	imageRecord, success := UpdateIMSImageRecordAPI(imageId, arch, metadata)
+	imageRecord, success := UpdateIMSImageRecordAPI(ctx, imageId, arch, metadata)
 	if !success || imageRecord.Id == "" {
 		return false
 	}
 
 	if imageRecord.Arch != arch {
-		common.Errorf("Expected architecture %s, got %s", arch, imageRecord.Arch)
+		common.Errorf(ctx, "Expected architecture %s, got %s", arch, imageRecord.Arch)
 		return false
 	}
 
-	if !common.CompareMaps(imageRecord.Metadata, expectedMetadata) {
-		common.Errorf("Expected metadata %v, got %v", expectedMetadata, imageRecord.Metadata)
+	if !common.CompareMaps(ctx, imageRecord.Metadata, expectedMetadata) {
+		common.Errorf(ctx, "Expected metadata %v, got %v", expectedMetadata, imageRecord.Metadata)
 		return false
 	}
 	return true
 }
 
-func TestImageCreate() (imageRecord IMSImageRecord, passed bool) {
+func TestImageCreate(ctx context.Context) (imageRecord IMSImageRecord, passed bool) {
+	defer common.StartSubtest(ctx, "TestImageCreate").End(ctx, &passed)
 	// Create a new image
 	imageName := "image_" + string(common.GetRandomString(10))
 	metadata := map[string]string{
@@ -264,70 +272,72 @@ func TestImageCreate() (imageRecord IMSImageRecord, passed bool) {
 		metadata["key"]: metadata["value"],
 	}
 
-	imageRecord, success := CreateIMSImageRecordAPI(imageName, metadata)
+	imageRecord, success := CreateIMSImageRecordAPI(ctx, imageName, metadata)
 	if !success || imageRecord.Id == "" {
 		return IMSImageRecord{}, false
 	}
 
 	// Get the created image details
-	if imageRecord, success = GetIMSImageRecordAPI(imageRecord.Id, http.StatusOK); !success {
+	if imageRecord, success = GetIMSImageRecordAPI(ctx, imageRecord.Id, http.StatusOK); !success {
 		return IMSImageRecord{}, false
 	}
 	if imageRecord.Name != imageName {
-		common.Errorf("Expected image name %s, got %s", imageName, imageRecord.Name)
+		common.Errorf(ctx, "Expected image name %s, got %s", imageName, imageRecord.Name)
 		return IMSImageRecord{}, false
 	}
-	if !common.CompareMaps(imageRecord.Metadata, expectedMetadata) {
-		common.Errorf("Expected metadata %v, got %v", expectedMetadata, imageRecord.Metadata)
+	if !common.CompareMaps(ctx, imageRecord.Metadata, expectedMetadata) {
+		common.Errorf(ctx, "Expected metadata %v, got %v", expectedMetadata, imageRecord.Metadata)
 		return IMSImageRecord{}, false
 	}
 
 	// Verify the image is in the list of images
-	imageRecords, success := GetIMSImageRecordsAPI()
+	imageRecords, success := GetIMSImageRecordsAPI(ctx)
 	if !success {
 		return IMSImageRecord{}, false
 	}
 
-	if !ImageRecordExists(imageRecord.Id, imageRecords) {
-		common.Errorf("Image %s was not found in the list of images", imageRecord.Id)
+	if !ImageRecordExists(ctx, imageRecord.Id, imageRecords) {
+		common.Errorf(ctx, "Image %s was not found in the list of images", imageRecord.Id)
 		return IMSImageRecord{}, false
 	}
 
-	common.Infof("Image %s was created with id %s", imageName, imageRecord.Id)
+	common.Infof(ctx, "Image %s was created with id %s", imageName, imageRecord.Id)
 	return imageRecord, true
 }
 
-func TestGetAllImages() (passed bool) {
-	_, success := GetIMSImageRecordsAPI()
+func TestGetAllImages(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestGetAllImages").End(ctx, &passed)
+	_, success := GetIMSImageRecordsAPI(ctx)
 	if !success {
 		return false
 	}
-	common.Infof("All images were retrieved successfully")
+	common.Infof(ctx, "All images were retrieved successfully")
 	return true
 }
 
-func TestImageDeleteV2(imageId string) (passed bool) {
-	if success := DeleteIMSImageRecordAPI(imageId); !success {
+func TestImageDeleteV2(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestImageDeleteV2").End(ctx, &passed)
+	if success := DeleteIMSImageRecordAPI(ctx, imageId); !success {
 		return false
 	}
 
 	// Verify the image is not in the list of images
-	if _, success := GetIMSImageRecordAPI(imageId, http.StatusNotFound); !success {
-		common.Errorf("Image %s was not deleted", imageId)
+	if _, success := GetIMSImageRecordAPI(ctx, imageId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Image %s was not deleted", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of all images
-	imageRecords, success := GetIMSImageRecordsAPI()
+	imageRecords, success := GetIMSImageRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if ImageRecordExists(imageId, imageRecords) {
-		common.Errorf("Image %s was not deleted", imageId)
+	if ImageRecordExists(ctx, imageId, imageRecords) {
+		common.Errorf(ctx, "Image %s was not deleted", imageId)
 		return false
 	}
 
-	common.Infof("Image %s was deleted", imageId)
+	common.Infof(ctx, "Image %s was deleted", imageId)
 	return true
 }
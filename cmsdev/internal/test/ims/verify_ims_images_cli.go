@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -28,36 +28,39 @@ package ims
  *
  */
 import (
+	"context"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
-func TestImageCRUDOperationUsingCLI() (passed bool) {
-	common.PrintLog("Testing image CRUD operations using CLI")
+func TestImageCRUDOperationUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestImageCRUDOperationUsingCLI").End(ctx, &passed)
+	common.PrintLog(ctx, "Testing image CRUD operations using CLI")
 	// Test creating an image
-	imageRecord, success := TestCLIImageCreate()
+	imageRecord, success := TestCLIImageCreate(ctx)
 	if !success {
 		return false
 	}
 	// Test updating the image
-	updated := TestCLIImageUpdate(imageRecord.Id)
+	updated := TestCLIImageUpdate(ctx, imageRecord.Id)
 
 	// Test soft deleting the image
-	deleted := TestCLIImageDelete(imageRecord.Id)
+	deleted := TestCLIImageDelete(ctx, imageRecord.Id)
 
 	// Test undeleting the image
-	undeleted := TestCLIImageUndelete(imageRecord.Id)
+	undeleted := TestCLIImageUndelete(ctx, imageRecord.Id)
 
 	// Test hard deleting the image
-	permDeleted := TestCLIImagePermanentDelete(imageRecord.Id)
+	permDeleted := TestCLIImagePermanentDelete(ctx, imageRecord.Id)
 
 	// Test get all images
-	getAll := TestCLIGetAllImages()
+	getAll := TestCLIGetAllImages(ctx)
 
 	return updated && deleted && undeleted && permDeleted && getAll
 }
 
-func TestCLIImageCreate() (imageRecord IMSImageRecord, passed bool) {
+func TestCLIImageCreate(ctx context.Context) (imageRecord IMSImageRecord, passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIImageCreate").End(ctx, &passed)
 
 	// Create the image
 	imageName := "image_" + string(common.GetRandomString(10))
@@ -66,189 +69,194 @@ func TestCLIImageCreate() (imageRecord IMSImageRecord, passed bool) {
 		"name": imageName,
 	}
 
-	imageRecord, success := CreateIMSImageRecordCLI(imageName)
+	imageRecord, success := CreateIMSImageRecordCLI(ctx, imageName)
 	if !success {
 		return IMSImageRecord{}, false
 	}
 	// Get the image record
-	imageRecord, success = getIMSImageRecordCLI(imageRecord.Id)
+	imageRecord, success = getIMSImageRecordCLI(ctx, imageRecord.Id)
 	if !success {
 		return IMSImageRecord{}, false
 	}
 	if imageRecord.Name != imageName {
-		common.Errorf("Image %s was not created with name %s", imageRecord.Id, imageName)
+		common.Errorf(ctx, "Image %s was not created with name %s", imageRecord.Id, imageName)
 		return IMSImageRecord{}, false
 	}
 
-	if !common.CompareMaps(imageRecord.Metadata, expectedMetadata) {
-		common.Errorf("Expected metadata %v, got %v", expectedMetadata, imageRecord.Metadata)
+	if !common.CompareMaps(ctx, imageRecord.Metadata, expectedMetadata) {
+		common.Errorf(ctx, "Expected metadata %v, got %v", expectedMetadata, imageRecord.Metadata)
 		return IMSImageRecord{}, false
 	}
 
 	// Verify the image is in the list of images
-	imageRecords, success := getIMSImageRecordsCLI()
+	imageRecords, success := getIMSImageRecordsCLI(ctx)
 	if !success {
 		return IMSImageRecord{}, false
 	}
 
-	if !ImageRecordExists(imageRecord.Id, imageRecords) {
-		common.Errorf("Image %s was not found in the list of images", imageRecord.Id)
+	if !ImageRecordExists(ctx, imageRecord.Id, imageRecords) {
+		common.Errorf(ctx, "Image %s was not found in the list of images", imageRecord.Id)
 		return IMSImageRecord{}, false
 	}
 
-	common.Infof("Created image %s with ID %s", imageName, imageRecord.Id)
+	common.Infof(ctx, "Created image %s with ID %s", imageName, imageRecord.Id)
 	return imageRecord, true
 }
 
-func TestCLIImageUpdate(imageId string) (passed bool) {
+func TestCLIImageUpdate(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIImageUpdate").End(ctx, &passed)
 	// build the expected metadata
-	existingImageRecord, success := getIMSImageRecordCLI(imageId)
+	existingImageRecord, success := getIMSImageRecordCLI(ctx, imageId)
 	expectedMetadata := existingImageRecord.Metadata
 	expectedMetadata["project"] = "csm"
-	common.Infof(("Expected metadata: %v"), expectedMetadata)
+	common.Infof(ctx, ("Expected metadata: %v"), expectedMetadata)
 	// Update the image
 	arch := "aarch64"
-	if _, success := UpdateIMSImageRecordCLI(imageId, arch); !success {
+	if _, success := UpdateIMSImageRecordCLI(ctx, imageId, arch); !success {
 		return false
 	}
 	// Verify the image is updated
-	imageRecord, success := getIMSImageRecordCLI(imageId)
+	imageRecord, success := getIMSImageRecordCLI(ctx, imageId)
 
 	if !success || imageRecord.Arch != arch {
-		common.Errorf("Image %s was not updated with arch %s", imageId, arch)
+		common.Errorf(ctx, "Image %s was not updated with arch %s", imageId, arch)
 		return false
 	}
 
-	if !common.CompareMaps(imageRecord.Metadata, expectedMetadata) {
-		common.Errorf("Expected metadata %v, got %v", expectedMetadata, imageRecord.Metadata)
+	if !common.CompareMaps(ctx, imageRecord.Metadata, expectedMetadata) {
+		common.Errorf(ctx, "Expected metadata %v, got %v", expectedMetadata, imageRecord.Metadata)
 		return false
 	}
-	common.Infof("Updated image %s with arch %s", imageId, arch)
+	common.Infof(ctx, "Updated image %s with arch %s", imageId, arch)
 	return true
 }
 
-func TestCLIImageDelete(imageId string) (passed bool) {
+func TestCLIImageDelete(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIImageDelete").End(ctx, &passed)
 	// Soft delete the image
-	if success := DeleteIMSImageRecordCLI(imageId); !success {
+	if success := DeleteIMSImageRecordCLI(ctx, imageId); !success {
 		return false
 	}
 	// Verify the image is soft deleted
-	if _, success := GetDeletedIMSImageRecordCLI(imageId); !success {
-		common.Errorf("Image %s was not soft deleted", imageId)
+	if _, success := GetDeletedIMSImageRecordCLI(ctx, imageId); !success {
+		common.Errorf(ctx, "Image %s was not soft deleted", imageId)
 		return false
 	}
 
 	// Set the CLI execution return code to 2. Since the image is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 	// Verify the image is not in the list of images
-	if _, success := getIMSImageRecordCLI(imageId); success {
-		common.Errorf("Image %s was not soft deleted", imageId)
+	if _, success := getIMSImageRecordCLI(ctx, imageId); success {
+		common.Errorf(ctx, "Image %s was not soft deleted", imageId)
 		return false
 	}
 
 	// Set the CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// Verify the image is not in the list of images
-	imageRecords, success := getIMSImageRecordsCLI()
+	imageRecords, success := getIMSImageRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if ImageRecordExists(imageId, imageRecords) {
-		common.Errorf("Image %s was not soft deleted", imageId)
+	if ImageRecordExists(ctx, imageId, imageRecords) {
+		common.Errorf(ctx, "Image %s was not soft deleted", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of deleted images
-	deletedImageRecords, success := GetDeletedIMSImageRecordsCLI()
+	deletedImageRecords, success := GetDeletedIMSImageRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if !ImageRecordExists(imageId, deletedImageRecords) {
-		common.Errorf("Image %s was not found in the list of deleted images", imageId)
+	if !ImageRecordExists(ctx, imageId, deletedImageRecords) {
+		common.Errorf(ctx, "Image %s was not found in the list of deleted images", imageId)
 		return false
 	}
 
-	common.Infof("Image %s was soft deleted", imageId)
+	common.Infof(ctx, "Image %s was soft deleted", imageId)
 	return true
 }
 
-func TestCLIImageUndelete(imageId string) (passed bool) {
+func TestCLIImageUndelete(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIImageUndelete").End(ctx, &passed)
 	// Undelete the image
-	if success := UndeleteIMSImageRecordCLI(imageId); !success {
+	if success := UndeleteIMSImageRecordCLI(ctx, imageId); !success {
 		return false
 	}
 	// Verify the image is undeleted
-	if _, success := getIMSImageRecordCLI(imageId); !success {
-		common.Errorf("Image %s was not restored", imageId)
+	if _, success := getIMSImageRecordCLI(ctx, imageId); !success {
+		common.Errorf(ctx, "Image %s was not restored", imageId)
 		return false
 	}
-	common.Infof("Image %s was restored", imageId)
+	common.Infof(ctx, "Image %s was restored", imageId)
 	return true
 }
 
-func TestCLIImagePermanentDelete(imageId string) (passed bool) {
+func TestCLIImagePermanentDelete(ctx context.Context, imageId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIImagePermanentDelete").End(ctx, &passed)
 	// Soft delete the image
-	if success := DeleteIMSImageRecordCLI(imageId); !success {
+	if success := DeleteIMSImageRecordCLI(ctx, imageId); !success {
 		return false
 	}
 
 	// Permanently delete the image
-	if success := PermanentDeleteIMSImageRecordCLI(imageId); !success {
+	if success := PermanentDeleteIMSImageRecordCLI(ctx, imageId); !success {
 		return false
 	}
 
 	// Set the CLI execution return code to 2. Since the image is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 
 	// Verify the image is hard deleted
-	if _, success := GetDeletedIMSImageRecordCLI(imageId); success {
-		common.Errorf("Image %s was not  permanently deleted", imageId)
+	if _, success := GetDeletedIMSImageRecordCLI(ctx, imageId); success {
+		common.Errorf(ctx, "Image %s was not  permanently deleted", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of images
-	if _, success := getIMSImageRecordCLI(imageId); success {
-		common.Errorf("Image %s was not permanently deleted", imageId)
+	if _, success := getIMSImageRecordCLI(ctx, imageId); success {
+		common.Errorf(ctx, "Image %s was not permanently deleted", imageId)
 		return false
 	}
 
 	// Set the CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// Verify the image is not in the list of images
-	imageRecords, success := getIMSImageRecordsCLI()
+	imageRecords, success := getIMSImageRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if ImageRecordExists(imageId, imageRecords) {
-		common.Errorf("Image %s was not permanently deleted", imageId)
+	if ImageRecordExists(ctx, imageId, imageRecords) {
+		common.Errorf(ctx, "Image %s was not permanently deleted", imageId)
 		return false
 	}
 
 	// Verify the image is not in the list of deleted images
-	deletedImageRecords, success := GetDeletedIMSImageRecordsCLI()
+	deletedImageRecords, success := GetDeletedIMSImageRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if ImageRecordExists(imageId, deletedImageRecords) {
-		common.Errorf("Image %s was not permanently deleted", imageId)
+	if ImageRecordExists(ctx, imageId, deletedImageRecords) {
+		common.Errorf(ctx, "Image %s was not permanently deleted", imageId)
 		return false
 	}
 
-	common.Infof("Image %s was permanently deleted", imageId)
+	common.Infof(ctx, "Image %s was permanently deleted", imageId)
 	return true
 }
 
-func TestCLIGetAllImages() (passed bool) {
+func TestCLIGetAllImages(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIGetAllImages").End(ctx, &passed)
 	// Get all images
-	if _, success := getIMSImageRecordsCLI(); !success {
+	if _, success := getIMSImageRecordsCLI(ctx); !success {
 		return false
 	}
-	common.Infof("Got all images")
+	common.Infof(ctx, "Got all images")
 	return true
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -22,6 +22,7 @@
 package ims
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -35,215 +36,223 @@ import (
  *
  */
 
-func TestPublicKeyCRUDOperationUsingAPIVersions() (passed bool) {
+func TestPublicKeyCRUDOperationUsingAPIVersions(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestPublicKeyCRUDOperationUsingAPIVersions").End(ctx, &passed)
 	passed = true
 
 	for _, apiVersion := range common.IMSAPIVERSIONS {
-		common.PrintLog(fmt.Sprintf("Testing IMS Public Key CRUD operations using API version: %s", apiVersion))
-		common.SetIMSAPIVersion(apiVersion)
-		passed = passed && TestPublicKeyCRUDOperation(apiVersion)
+		common.PrintLog(ctx, fmt.Sprintf("Testing IMS Public Key CRUD operations using API version: %s", apiVersion))
+		common.SetIMSAPIVersion(ctx, apiVersion)
+		passed = passed && TestPublicKeyCRUDOperation(ctx, apiVersion)
 	}
 
 	// Test the default API version
-	common.PrintLog("Testing IMS Public Key CRUD operations using default API version.")
-	common.SetIMSAPIVersion("")
-	passed = passed && TestPublicKeyCRUDOperation(common.GetIMSAPIVersion())
+	common.PrintLog(ctx, "Testing IMS Public Key CRUD operations using default API version.")
+	common.SetIMSAPIVersion(ctx, "")
+	passed = passed && TestPublicKeyCRUDOperation(ctx, common.GetIMSAPIVersion(ctx))
 
 	return passed
 }
 
-func TestPublicKeyCRUDOperation(apiVersion string) (passed bool) {
+func TestPublicKeyCRUDOperation(ctx context.Context, apiVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestPublicKeyCRUDOperation").End(ctx, &passed)
 	// Test creating a public key
-	publicKeyRecord, success := TestPublicKeyCreate()
+	publicKeyRecord, success := TestPublicKeyCreate(ctx)
 	if !success {
 		return false
 	}
 
 	// Test get all public keys
-	getAll := TestGetAllPublicKeys()
+	getAll := TestGetAllPublicKeys(ctx)
 
 	if apiVersion == "v3" {
 		// Test soft deleting the public key
-		deleted := TestPublicKeyDelete(publicKeyRecord.Id)
+		deleted := TestPublicKeyDelete(ctx, publicKeyRecord.Id)
 
 		// Test undeleting the public key
-		undeleted := TestPublicKeyUndelete(publicKeyRecord.Id)
+		undeleted := TestPublicKeyUndelete(ctx, publicKeyRecord.Id)
 
 		// Test permanent deleting the public key
-		permDeleted := TestPublicKeyPermanentDelete(publicKeyRecord.Id)
+		permDeleted := TestPublicKeyPermanentDelete(ctx, publicKeyRecord.Id)
 
 		return deleted && undeleted && permDeleted && getAll
 	}
 	// Test deleting the public key
-	deleted := TestPublicKeyDeleteV2(publicKeyRecord.Id)
+	deleted := TestPublicKeyDeleteV2(ctx, publicKeyRecord.Id)
 
 	return deleted && getAll
 }
 
-func TestPublicKeyDelete(publicKeyId string) (passed bool) {
-	if success := DeleteIMSPublicKeyRecordAPI(publicKeyId); !success {
+func TestPublicKeyDelete(ctx context.Context, publicKeyId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestPublicKeyDelete").End(ctx, &passed)
+	if success := DeleteIMSPublicKeyRecordAPI(ctx, publicKeyId); !success {
 		return false
 	}
 
 	// Verify the public key is soft deleted
-	if _, success := GetDeletedIMSPublicKeyRecordAPI(publicKeyId, http.StatusOK); !success {
+	if _, success := GetDeletedIMSPublicKeyRecordAPI(ctx, publicKeyId, http.StatusOK); !success {
 		return false
 	}
 
 	// Verify the public key is not in the list of public keys
-	if _, success := GetIMSPublicKeyRecordAPI(publicKeyId, http.StatusNotFound); !success {
-		common.Errorf("Public key %s was not soft deleted", publicKeyId)
+	if _, success := GetIMSPublicKeyRecordAPI(ctx, publicKeyId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Public key %s was not soft deleted", publicKeyId)
 		return false
 	}
 
 	// Verify the public key is in the list of all deleted public keys
-	deletedPublicKeyRecords, success := GetDeletedIMSPublicKeyRecordsAPI()
+	deletedPublicKeyRecords, success := GetDeletedIMSPublicKeyRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if !PublicKeyRecordExists(publicKeyId, deletedPublicKeyRecords) {
-		common.Errorf("Public key %s was not deleted", publicKeyId)
+	if !PublicKeyRecordExists(ctx, publicKeyId, deletedPublicKeyRecords) {
+		common.Errorf(ctx, "Public key %s was not deleted", publicKeyId)
 		return false
 	}
 
 	// Verify the public key is not in the list of all public keys
-	publicKeyRecords, success := GetIMSPublicKeyRecordsAPI()
+	publicKeyRecords, success := GetIMSPublicKeyRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if PublicKeyRecordExists(publicKeyId, publicKeyRecords) {
-		common.Errorf("Public key %s was not deleted", publicKeyId)
+	if PublicKeyRecordExists(ctx, publicKeyId, publicKeyRecords) {
+		common.Errorf(ctx, "Public key %s was not deleted", publicKeyId)
 		return false
 	}
 
-	common.Infof("Public key %s successfully soft deleted", publicKeyId)
+	common.Infof(ctx, "Public key %s successfully soft deleted", publicKeyId)
 	return true
 }
 
-func TestPublicKeyUndelete(publicKeyId string) (passed bool) {
-	if success := UndeleteIMSPublicKeyRecordAPI(publicKeyId); !success {
+func TestPublicKeyUndelete(ctx context.Context, publicKeyId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestPublicKeyUndelete").End(ctx, &passed)
+	if success := UndeleteIMSPublicKeyRecordAPI(ctx, publicKeyId); !success {
 		return false
 	}
 
 	// Verify the public key is not soft deleted
-	if _, success := GetIMSPublicKeyRecordAPI(publicKeyId, http.StatusOK); !success {
+	if _, success := GetIMSPublicKeyRecordAPI(ctx, publicKeyId, http.StatusOK); !success {
 		return false
 	}
-	common.Infof("Public key %s successfully restored", publicKeyId)
+	common.Infof(ctx, "Public key %s successfully restored", publicKeyId)
 	return true
 }
 
-func TestPublicKeyPermanentDelete(publicKeyId string) (passed bool) {
+func TestPublicKeyPermanentDelete(ctx context.Context, publicKeyId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestPublicKeyPermanentDelete").End(ctx, &passed)
 	// Soft delete the public key
-	if success := DeleteIMSPublicKeyRecordAPI(publicKeyId); !success {
+	if success := DeleteIMSPublicKeyRecordAPI(ctx, publicKeyId); !success {
 		return false
 	}
 
 	// Permanently delete the public key
-	if success := PermanentDeleteIMSPublicKeyRecordAPI(publicKeyId); !success {
+	if success := PermanentDeleteIMSPublicKeyRecordAPI(ctx, publicKeyId); !success {
 		return false
 	}
 
 	// Verify the public key is hard deleted
-	if _, success := GetDeletedIMSPublicKeyRecordAPI(publicKeyId, http.StatusNotFound); !success {
-		common.Errorf("Public key %s was not permanently deleted", publicKeyId)
+	if _, success := GetDeletedIMSPublicKeyRecordAPI(ctx, publicKeyId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Public key %s was not permanently deleted", publicKeyId)
 		return false
 	}
 	// Verify the public key is not in the list of public keys
-	if _, success := GetIMSPublicKeyRecordAPI(publicKeyId, http.StatusNotFound); !success {
-		common.Errorf("Public key %s was not permanently deleted", publicKeyId)
+	if _, success := GetIMSPublicKeyRecordAPI(ctx, publicKeyId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Public key %s was not permanently deleted", publicKeyId)
 		return false
 	}
 
 	// Verify the public key is not in the list of all deleted public keys
-	deletedPublicKeyRecords, success := GetDeletedIMSPublicKeyRecordsAPI()
+	deletedPublicKeyRecords, success := GetDeletedIMSPublicKeyRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if PublicKeyRecordExists(publicKeyId, deletedPublicKeyRecords) {
-		common.Errorf("Public key %s was not deleted", publicKeyId)
+	if PublicKeyRecordExists(ctx, publicKeyId, deletedPublicKeyRecords) {
+		common.Errorf(ctx, "Public key %s was not deleted", publicKeyId)
 		return false
 	}
 
 	// Verify the public key is not in the list of all public keys
-	publicKeyRecords, success := GetIMSPublicKeyRecordsAPI()
+	publicKeyRecords, success := GetIMSPublicKeyRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if PublicKeyRecordExists(publicKeyId, publicKeyRecords) {
-		common.Errorf("Public key %s was not deleted", publicKeyId)
+	if PublicKeyRecordExists(ctx, publicKeyId, publicKeyRecords) {
+		common.Errorf(ctx, "Public key %s was not deleted", publicKeyId)
 		return false
 	}
 
-	common.Infof("Public key %s was permanently deleted", publicKeyId)
+	common.Infof(ctx, "Public key %s was permanently deleted", publicKeyId)
 	return true
 }
 
-func TestGetAllPublicKeys() (passed bool) {
+func TestGetAllPublicKeys(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestGetAllPublicKeys").End(ctx, &passed)
 	// Get all public keys
-	_, success := GetIMSPublicKeyRecordsAPI()
+	_, success := GetIMSPublicKeyRecordsAPI(ctx)
 	if !success {
 		return false
 	}
-	common.Infof("Public key records were successfully retrieved")
+	common.Infof(ctx, "Public key records were successfully retrieved")
 	return true
 }
 
-func TestPublicKeyCreate() (publicKeyRecord IMSPublicKeyRecord, passed bool) {
+func TestPublicKeyCreate(ctx context.Context) (publicKeyRecord IMSPublicKeyRecord, passed bool) {
+	defer common.StartSubtest(ctx, "TestPublicKeyCreate").End(ctx, &passed)
 	publicKeyName := "public_key_" + string(common.GetRandomString(10))
-	publicKeyRecord, success := CreateIMSPublicKeyRecordAPI(publicKeyName)
+	publicKeyRecord, success := CreateIMSPublicKeyRecordAPI(ctx, publicKeyName)
 	if !success {
 		return IMSPublicKeyRecord{}, false
 	}
 
 	// Verify the public key is created
-	publicKeyRecord, success = GetIMSPublicKeyRecordAPI(publicKeyRecord.Id, http.StatusOK)
+	publicKeyRecord, success = GetIMSPublicKeyRecordAPI(ctx, publicKeyRecord.Id, http.StatusOK)
 	if !success || publicKeyRecord.Name != publicKeyName {
-		common.Errorf("Public key %s was not created", publicKeyName)
+		common.Errorf(ctx, "Public key %s was not created", publicKeyName)
 		return IMSPublicKeyRecord{}, false
 	}
 
 	// Verify the public key is in the list of public keys
-	publicKeyRecords, success := GetIMSPublicKeyRecordsAPI()
+	publicKeyRecords, success := GetIMSPublicKeyRecordsAPI(ctx)
 	if !success {
 		return IMSPublicKeyRecord{}, false
 	}
 
-	if !PublicKeyRecordExists(publicKeyRecord.Id, publicKeyRecords) {
-		common.Errorf("Public key %s was not found in the list of public keys", publicKeyRecord.Id)
+	if !PublicKeyRecordExists(ctx, publicKeyRecord.Id, publicKeyRecords) {
+		common.Errorf(ctx, "Public key %s was not found in the list of public keys", publicKeyRecord.Id)
 		return IMSPublicKeyRecord{}, false
 	}
 
-	common.Infof("Public key %s was created with id %s", publicKeyName, publicKeyRecord.Id)
+	common.Infof(ctx, "Public key %s was created with id %s", publicKeyName, publicKeyRecord.Id)
 	return publicKeyRecord, true
 }
 
-func TestPublicKeyDeleteV2(publicKeyId string) (passed bool) {
-	if success := DeleteIMSPublicKeyRecordAPI(publicKeyId); !success {
+func TestPublicKeyDeleteV2(ctx context.Context, publicKeyId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestPublicKeyDeleteV2").End(ctx, &passed)
+	if success := DeleteIMSPublicKeyRecordAPI(ctx, publicKeyId); !success {
 		return false
 	}
 
 	// Verify the public key is not in the list of public keys
-	if _, success := GetIMSPublicKeyRecordAPI(publicKeyId, http.StatusNotFound); !success {
-		common.Errorf("Public key %s was not deleted", publicKeyId)
+	if _, success := GetIMSPublicKeyRecordAPI(ctx, publicKeyId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Public key %s was not deleted", publicKeyId)
 		return false
 	}
 
 	// Verify the public key is not in the list of all public keys
-	publicKeyRecords, success := GetIMSPublicKeyRecordsAPI()
+	publicKeyRecords, success := GetIMSPublicKeyRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if PublicKeyRecordExists(publicKeyId, publicKeyRecords) {
-		common.Errorf("Public key %s was not deleted", publicKeyId)
+	if PublicKeyRecordExists(ctx, publicKeyId, publicKeyRecords) {
+		common.Errorf(ctx, "Public key %s was not deleted", publicKeyId)
 		return false
 	}
 
-	common.Infof("Public key %s successfully deleted", publicKeyId)
+	common.Infof(ctx, "Public key %s successfully deleted", publicKeyId)
 	return true
 }
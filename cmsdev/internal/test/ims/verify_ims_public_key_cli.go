@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -22,6 +22,7 @@
 package ims
 
 import (
+	"context"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
@@ -33,174 +34,180 @@ import (
  *
  */
 
-func TestPublicKeyCRUDOperationUsingCLI() (passed bool) {
-	common.PrintLog("Testing Public Key CRUD operations using CLI")
+func TestPublicKeyCRUDOperationUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestPublicKeyCRUDOperationUsingCLI").End(ctx, &passed)
+	common.PrintLog(ctx, "Testing Public Key CRUD operations using CLI")
 	// Test creating a public key
-	publicKeyRecord, success := TestCLIPublicKeyCreate()
+	publicKeyRecord, success := TestCLIPublicKeyCreate(ctx)
 	if !success {
 		return false
 	}
 
 	// Test soft deleting the public key
-	deleted := TestCLIPublicKeyDelete(publicKeyRecord.Id)
+	deleted := TestCLIPublicKeyDelete(ctx, publicKeyRecord.Id)
 
 	// Test undeleting the public key
-	undeleted := TestCLIPublicKeyUndelete(publicKeyRecord.Id)
+	undeleted := TestCLIPublicKeyUndelete(ctx, publicKeyRecord.Id)
 
 	// Test hard deleting the public key
-	permDeleted := TestCLIPublicKeyPermanentDelete(publicKeyRecord.Id)
+	permDeleted := TestCLIPublicKeyPermanentDelete(ctx, publicKeyRecord.Id)
 
 	// Test get all public keys
-	getAll := TestCLIGetAllPublicKeys()
+	getAll := TestCLIGetAllPublicKeys(ctx)
 
 	return deleted && undeleted && permDeleted && getAll
 }
 
-func TestCLIPublicKeyCreate() (publicKeyRecord IMSPublicKeyRecord, passed bool) {
+func TestCLIPublicKeyCreate(ctx context.Context) (publicKeyRecord IMSPublicKeyRecord, passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIPublicKeyCreate").End(ctx, &passed)
 	publicKeyName := "public_key_" + string(common.GetRandomString(10))
-	publicKeyRecord, success := CreateIMSPublicKeyRecordCLI(publicKeyName)
+	publicKeyRecord, success := CreateIMSPublicKeyRecordCLI(ctx, publicKeyName)
 	if !success {
 		return IMSPublicKeyRecord{}, false
 	}
 
 	// Get the public key record
-	publicKeyRecord, success = getIMSPublicKeyRecordCLI(publicKeyRecord.Id)
+	publicKeyRecord, success = getIMSPublicKeyRecordCLI(ctx, publicKeyRecord.Id)
 	if !success {
 		return IMSPublicKeyRecord{}, false
 	}
 
 	// Verify the public key exists in the list of public keys
-	publickeyRecords, success := getIMSPublicKeyRecordsCLI()
+	publickeyRecords, success := getIMSPublicKeyRecordsCLI(ctx)
 	if !success {
 		return IMSPublicKeyRecord{}, false
 	}
 
-	if !PublicKeyRecordExists(publicKeyRecord.Id, publickeyRecords) {
-		common.Errorf("Public key %s was not found in the list of public keys", publicKeyRecord.Id)
+	if !PublicKeyRecordExists(ctx, publicKeyRecord.Id, publickeyRecords) {
+		common.Errorf(ctx, "Public key %s was not found in the list of public keys", publicKeyRecord.Id)
 		return IMSPublicKeyRecord{}, false
 	}
 
 	return publicKeyRecord, true
 }
 
-func TestCLIPublicKeyDelete(publicKeyId string) (passed bool) {
-	if success := DeleteIMSPublicKeyRecordCLI(publicKeyId); !success {
+func TestCLIPublicKeyDelete(ctx context.Context, publicKeyId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIPublicKeyDelete").End(ctx, &passed)
+	if success := DeleteIMSPublicKeyRecordCLI(ctx, publicKeyId); !success {
 		return false
 	}
 	// Verify the public key is soft deleted
-	if _, success := GetDeletedIMSPublicKeyRecordCLI(publicKeyId); !success {
+	if _, success := GetDeletedIMSPublicKeyRecordCLI(ctx, publicKeyId); !success {
 		return false
 	}
 
 	// Set the CLI execution return code to 2. Since the public key is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 	// verify the public key is not in the list of public keys
-	if _, success := getIMSPublicKeyRecordCLI(publicKeyId); success {
+	if _, success := getIMSPublicKeyRecordCLI(ctx, publicKeyId); success {
 		return false
 	}
 
 	// Set the CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// verify the public key is not in the list of all public keys
-	publickeyRecords, success := getIMSPublicKeyRecordsCLI()
+	publickeyRecords, success := getIMSPublicKeyRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if PublicKeyRecordExists(publicKeyId, publickeyRecords) {
-		common.Errorf("Public key %s was not soft deleted", publicKeyId)
+	if PublicKeyRecordExists(ctx, publicKeyId, publickeyRecords) {
+		common.Errorf(ctx, "Public key %s was not soft deleted", publicKeyId)
 		return false
 	}
 
 	// verify the public key is in the list of all deleted public keys
-	deletedpublicKeyRecords, success := GetDeletedIMSPublicKeyRecordCLIs()
+	deletedpublicKeyRecords, success := GetDeletedIMSPublicKeyRecordCLIs(ctx)
 	if !success {
 		return false
 	}
 
-	if !PublicKeyRecordExists(publicKeyId, deletedpublicKeyRecords) {
-		common.Errorf("Public key %s was not found in the list of deleted public keys", publicKeyId)
+	if !PublicKeyRecordExists(ctx, publicKeyId, deletedpublicKeyRecords) {
+		common.Errorf(ctx, "Public key %s was not found in the list of deleted public keys", publicKeyId)
 		return false
 	}
 
-	common.Infof("Public key %s successfully soft deleted", publicKeyId)
+	common.Infof(ctx, "Public key %s successfully soft deleted", publicKeyId)
 	return true
 }
 
-func TestCLIPublicKeyUndelete(publicKeyId string) (passed bool) {
-	if success := UndeleteIMSPublicKeyRecordCLI(publicKeyId); !success {
+func TestCLIPublicKeyUndelete(ctx context.Context, publicKeyId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIPublicKeyUndelete").End(ctx, &passed)
+	if success := UndeleteIMSPublicKeyRecordCLI(ctx, publicKeyId); !success {
 		return false
 	}
 	// Verify the public key is restored
-	if _, success := getIMSPublicKeyRecordCLI(publicKeyId); !success {
+	if _, success := getIMSPublicKeyRecordCLI(ctx, publicKeyId); !success {
 		return false
 	}
-	common.Infof("Public key %s successfully restored", publicKeyId)
+	common.Infof(ctx, "Public key %s successfully restored", publicKeyId)
 	return true
 }
 
-func TestCLIPublicKeyPermanentDelete(publicKeyId string) (passed bool) {
+func TestCLIPublicKeyPermanentDelete(ctx context.Context, publicKeyId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIPublicKeyPermanentDelete").End(ctx, &passed)
 	// soft delete the public key
-	if success := DeleteIMSPublicKeyRecordCLI(publicKeyId); !success {
+	if success := DeleteIMSPublicKeyRecordCLI(ctx, publicKeyId); !success {
 		return false
 	}
 
 	// hard delete the public key
-	if success := PermanentDeleteIMSPublicKeyRecordCLI(publicKeyId); !success {
+	if success := PermanentDeleteIMSPublicKeyRecordCLI(ctx, publicKeyId); !success {
 		return false
 	}
 
 	// Set the CLI execution return code to 2. Since the public key is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 
 	// Verify the public key is hard deleted
-	if _, success := GetDeletedIMSPublicKeyRecordCLI(publicKeyId); success {
+	if _, success := GetDeletedIMSPublicKeyRecordCLI(ctx, publicKeyId); success {
 		return false
 	}
 
 	// Verify the public key is not in the list of public keys
-	if _, success := getIMSPublicKeyRecordCLI(publicKeyId); success {
-		common.Errorf("Public key %s was not permanently deleted", publicKeyId)
+	if _, success := getIMSPublicKeyRecordCLI(ctx, publicKeyId); success {
+		common.Errorf(ctx, "Public key %s was not permanently deleted", publicKeyId)
 		return false
 	}
 
 	// Set the CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// verify the public key is not in the list of all public keys
-	publickeyRecords, success := getIMSPublicKeyRecordsCLI()
+	publickeyRecords, success := getIMSPublicKeyRecordsCLI(ctx)
 	if !success {
-		common.Errorf("Public key %s was not permanently deleted", publicKeyId)
+		common.Errorf(ctx, "Public key %s was not permanently deleted", publicKeyId)
 		return false
 	}
 
-	if PublicKeyRecordExists(publicKeyId, publickeyRecords) {
-		common.Errorf("Public key %s was not permanently deleted", publicKeyId)
+	if PublicKeyRecordExists(ctx, publicKeyId, publickeyRecords) {
+		common.Errorf(ctx, "Public key %s was not permanently deleted", publicKeyId)
 		return false
 	}
 
 	// verify the public key is not in the list of all deleted public keys
-	deletedpublicKeyRecords, success := GetDeletedIMSPublicKeyRecordCLIs()
+	deletedpublicKeyRecords, success := GetDeletedIMSPublicKeyRecordCLIs(ctx)
 	if !success {
 		return false
 	}
 
-	if PublicKeyRecordExists(publicKeyId, deletedpublicKeyRecords) {
-		common.Errorf("Public key %s was not permanently deleted", publicKeyId)
+	if PublicKeyRecordExists(ctx, publicKeyId, deletedpublicKeyRecords) {
+		common.Errorf(ctx, "Public key %s was not permanently deleted", publicKeyId)
 		return false
 	}
 
-	common.Infof("Public key %s successfully hard deleted", publicKeyId)
+	common.Infof(ctx, "Public key %s successfully hard deleted", publicKeyId)
 	return true
 }
 
-func TestCLIGetAllPublicKeys() (passed bool) {
+func TestCLIGetAllPublicKeys(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIGetAllPublicKeys").End(ctx, &passed)
 	// Get all public keys
-	_, success := getIMSPublicKeyRecordsCLI()
+	_, success := getIMSPublicKeyRecordsCLI(ctx)
 	if !success {
 		return false
 	}
-	common.Infof("Public key records successfully retrieved")
+	common.Infof(ctx, "Public key records successfully retrieved")
 	return true
 }
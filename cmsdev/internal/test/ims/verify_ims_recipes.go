@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -22,6 +22,7 @@
 package ims
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -35,103 +36,107 @@ import (
  *
  */
 
-func TestRecipeCRUDOperationUsingAPIVersions() (passed bool) {
+func TestRecipeCRUDOperationUsingAPIVersions(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipeCRUDOperationUsingAPIVersions").End(ctx, &passed)
 	passed = true
 	for _, apiVersion := range common.IMSAPIVERSIONS {
-		common.PrintLog(fmt.Sprintf("Testing recipe CRUD operations using IMS API version: %s", apiVersion))
-		common.SetIMSAPIVersion(apiVersion)
-		passed = passed && TestRecipeCRUDOperation(apiVersion)
+		common.PrintLog(ctx, fmt.Sprintf("Testing recipe CRUD operations using IMS API version: %s", apiVersion))
+		common.SetIMSAPIVersion(ctx, apiVersion)
+		passed = passed && TestRecipeCRUDOperation(ctx, apiVersion)
 	}
 
 	// Reset the IMS API version to the default value
-	common.SetIMSAPIVersion("")
-	common.PrintLog("Testing recipe CRUD operations using default API version")
-	passed = passed && TestRecipeCRUDOperation(common.GetIMSAPIVersion())
+	common.SetIMSAPIVersion(ctx, "")
+	common.PrintLog(ctx, "Testing recipe CRUD operations using default API version")
+	passed = passed && TestRecipeCRUDOperation(ctx, common.GetIMSAPIVersion(ctx))
 	return passed
 }
 
-func TestRecipeCRUDOperation(apiVersion string) (passed bool) {
+func TestRecipeCRUDOperation(ctx context.Context, apiVersion string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipeCRUDOperation").End(ctx, &passed)
 	// Test creating a recipe
-	recipeRecord, success := TestRecipeCreate()
+	recipeRecord, success := TestRecipeCreate(ctx)
 	if !success {
 		return false
 	}
 
 	// Test updating the recipe
-	updated := TestRecipeUpdate(recipeRecord.Id)
+	updated := TestRecipeUpdate(ctx, recipeRecord.Id)
 
 	// Test get all recipes
-	getAll := TestGetAllRecipes()
+	getAll := TestGetAllRecipes(ctx)
 
 	if apiVersion == "v3" {
 
 		// Test soft deleting the recipe
-		deleted := TestRecipeDelete(recipeRecord.Id)
+		deleted := TestRecipeDelete(ctx, recipeRecord.Id)
 
 		// Test undeleting the recipe
-		undeleted := TestRecipeUndelete(recipeRecord.Id)
+		undeleted := TestRecipeUndelete(ctx, recipeRecord.Id)
 
 		// Test hard deleting the recipe
-		permDeleted := TestRecipePermanentDelete(recipeRecord.Id)
+		permDeleted := TestRecipePermanentDelete(ctx, recipeRecord.Id)
 
 		return updated && deleted && undeleted && permDeleted && getAll
 	}
 
 	// Test deleting the recipe
-	deleted := TestRecipeDeleteV2(recipeRecord.Id)
+	deleted := TestRecipeDeleteV2(ctx, recipeRecord.Id)
 
 	return updated && deleted && getAll
 
 }
 
-func TestRecipePermanentDelete(recipeId string) (passed bool) {
+func TestRecipePermanentDelete(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipePermanentDelete").End(ctx, &passed)
 	// Soft delete the recipe
-	if success := DeleteIMSRecipeRecordAPI(recipeId); !success {
+	if success := DeleteIMSRecipeRecordAPI(ctx, recipeId); !success {
 		return false
 	}
 
 	// Permanently delete the recipe
-	if success := PermanentDeleteIMSRecipeRecordAPI(recipeId); !success {
+	if success := PermanentDeleteIMSRecipeRecordAPI(ctx, recipeId); !success {
 		return false
 	}
 	// Verify the recipe is hard deleted
-	if _, success := GetDeletedIMSRecipeRecordAPI(recipeId, http.StatusNotFound); !success {
-		common.Errorf("Recipe %s was not permanently deleted", recipeId)
+	if _, success := GetDeletedIMSRecipeRecordAPI(ctx, recipeId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Recipe %s was not permanently deleted", recipeId)
 		return false
 	}
 	// Verify the recipe is not in the list of recipes
-	if _, success := GetIMSRecipeRecordAPI(recipeId, http.StatusNotFound); !success {
-		common.Errorf("Recipe %s was not permanently deleted", recipeId)
+	if _, success := GetIMSRecipeRecordAPI(ctx, recipeId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Recipe %s was not permanently deleted", recipeId)
 		return false
 	}
 
 	// Verify the recipe is not in the list of all recipes
-	recipeRecords, success := GetIMSRecipeRecordsAPI()
+	recipeRecords, success := GetIMSRecipeRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if RecipeRecordExists(recipeId, recipeRecords) {
-		common.Errorf("Recipe %s was not deleted", recipeId)
+	if RecipeRecordExists(ctx, recipeId, recipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not deleted", recipeId)
 		return false
 	}
 
 	// Verify the recipe is not in the list of all deleted recipes
-	deletedRecipeRecords, success := GetDeletedIMSRecipeRecordsAPI()
+	deletedRecipeRecords, success := GetDeletedIMSRecipeRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if RecipeRecordExists(recipeId, deletedRecipeRecords) {
-		common.Errorf("Recipe %s was not deleted", recipeId)
+	if RecipeRecordExists(ctx, recipeId, deletedRecipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not deleted", recipeId)
 		return false
 	}
 
-	common.Infof("Recipe %s was permanently deleted", recipeId)
+	common.Infof(ctx, "Recipe %s was permanently deleted", recipeId)
 	return true
 }
 
-func TestRecipeCreate() (recipeRecord IMSRecipeRecord, passed bool) {
+func TestRecipeCreate(ctx context.Context) (recipeRecord IMSRecipeRecord, passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipeCreate").End(ctx, &passed)
 	recipeName := "recipe_" + string(common.GetRandomString(10))
 	templatesDict := []map[string]string{
 		{
@@ -144,38 +149,39 @@ func TestRecipeCreate() (recipeRecord IMSRecipeRecord, passed bool) {
 		},
 	}
 	requireDKMS := false
-	recipeRecord, success := CreateIMSRecipeRecordAPI(recipeName, templatesDict, requireDKMS)
+	recipeRecord, success := CreateIMSRecipeRecordAPI(ctx, recipeName, templatesDict, requireDKMS)
 	if !success {
 		return IMSRecipeRecord{}, false
 	}
 
 	// Verify the recipe is created
-	recipeRecord, success = GetIMSRecipeRecordAPI(recipeRecord.Id, http.StatusOK)
+	recipeRecord, success = GetIMSRecipeRecordAPI(ctx, recipeRecord.Id, http.StatusOK)
 	if !success ||
 		recipeRecord.Name != recipeName ||
-		!common.CompareSlicesOfMaps(recipeRecord.Template_dictionary, templatesDict) ||
+		!common.CompareSlicesOfMaps(ctx, recipeRecord.Template_dictionary, templatesDict) ||
 		recipeRecord.Require_dkms != requireDKMS {
 
-		common.Errorf("Recipe %s was not created", recipeName)
+		common.Errorf(ctx, "Recipe %s was not created", recipeName)
 		return IMSRecipeRecord{}, false
 	}
 
 	// Verify the recipe is in the list of recipes
-	recipeRecords, success := GetIMSRecipeRecordsAPI()
+	recipeRecords, success := GetIMSRecipeRecordsAPI(ctx)
 	if !success {
 		return IMSRecipeRecord{}, false
 	}
 
-	if !RecipeRecordExists(recipeRecord.Id, recipeRecords) {
-		common.Errorf("Recipe %s was not found in the list of recipes", recipeRecord.Id)
+	if !RecipeRecordExists(ctx, recipeRecord.Id, recipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not found in the list of recipes", recipeRecord.Id)
 		return IMSRecipeRecord{}, false
 	}
 
-	common.Infof("Recipe %s was created with id %s", recipeName, recipeRecord.Id)
+	common.Infof(ctx, "Recipe %s was created with id %s", recipeName, recipeRecord.Id)
 	return recipeRecord, true
 }
 
-func TestRecipeUpdate(recipeId string) (passed bool) {
+func TestRecipeUpdate(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipeUpdate").End(ctx, &passed)
 	arch := "aarch64"
 	templatesDict := []map[string]string{
 		{
@@ -183,137 +189,141 @@ func TestRecipeUpdate(recipeId string) (passed bool) {
 			"value": "1.1.2-1-cos-3.1",
 		},
 	}
-	if _, success := UpdateIMSRecipeRecordAPI(recipeId, arch, templatesDict); !success {
+	if _, success := UpdateIMSRecipeRecordAPI(ctx, recipeId, arch, templatesDict); !success {
 		return false
 	}
 
 	// Verify the recipe is updated
-	recipeRecord, success := GetIMSRecipeRecordAPI(recipeId, http.StatusOK)
+	recipeRecord, success := GetIMSRecipeRecordAPI(ctx, recipeId, http.StatusOK)
 	if !success ||
 		recipeRecord.Arch != arch ||
-		!common.CompareSlicesOfMaps(recipeRecord.Template_dictionary, templatesDict) {
-		common.Errorf("Recipe %s was not updated", recipeId)
+		!common.CompareSlicesOfMaps(ctx, recipeRecord.Template_dictionary, templatesDict) {
+		common.Errorf(ctx, "Recipe %s was not updated", recipeId)
 		return false
 	}
-	common.Infof("Recipe %s was updated with arch %s", recipeId, arch)
+	common.Infof(ctx, "Recipe %s was updated with arch %s", recipeId, arch)
 	return true
 }
 
-func TestRecipeDelete(recipeId string) (passed bool) {
+func TestRecipeDelete(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipeDelete").End(ctx, &passed)
 	// Get the recipe record before deleting it
-	existingRecipeRecord, success := GetIMSRecipeRecordAPI(recipeId, http.StatusOK)
+	existingRecipeRecord, success := GetIMSRecipeRecordAPI(ctx, recipeId, http.StatusOK)
 	if !success {
-		common.Errorf("Recipe %s was not found", recipeId)
+		common.Errorf(ctx, "Recipe %s was not found", recipeId)
 		return false
 	}
 
-	if success := DeleteIMSRecipeRecordAPI(recipeId); !success {
+	if success := DeleteIMSRecipeRecordAPI(ctx, recipeId); !success {
 		return false
 	}
 
 	// Verify the recipe is deleted
-	recipeRecord, success := GetDeletedIMSRecipeRecordAPI(recipeId, http.StatusOK)
+	recipeRecord, success := GetDeletedIMSRecipeRecordAPI(ctx, recipeId, http.StatusOK)
 	if !success {
-		common.Errorf("Recipe %s was not deleted", recipeId)
+		common.Errorf(ctx, "Recipe %s was not deleted", recipeId)
 		return false
 	}
 
 	// Verify the recipe record is the same as the one before deleting it
-	if !VerifyIMSRecipeRecord(recipeRecord, existingRecipeRecord) {
-		common.Errorf("Recipe %s details do not match after soft delete", recipeId)
+	if !VerifyIMSRecipeRecord(ctx, recipeRecord, existingRecipeRecord) {
+		common.Errorf(ctx, "Recipe %s details do not match after soft delete", recipeId)
 		return false
 	}
 
 	// Verify the recipe is not in the list of recipes
-	if _, success := GetIMSRecipeRecordAPI(recipeId, http.StatusNotFound); !success {
-		common.Errorf("Recipe %s was not soft deleted", recipeId)
+	if _, success := GetIMSRecipeRecordAPI(ctx, recipeId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Recipe %s was not soft deleted", recipeId)
 		return false
 	}
 
 	// Verify the recipe is not in the list of all recipes
-	recipeRecords, success := GetIMSRecipeRecordsAPI()
+	recipeRecords, success := GetIMSRecipeRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if RecipeRecordExists(recipeId, recipeRecords) {
-		common.Errorf("Recipe %s was not deleted", recipeId)
+	if RecipeRecordExists(ctx, recipeId, recipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not deleted", recipeId)
 		return false
 	}
 
 	// Verify the recipe is in the list of all deleted recipes
-	deletedRecipeRecords, success := GetDeletedIMSRecipeRecordsAPI()
+	deletedRecipeRecords, success := GetDeletedIMSRecipeRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if !RecipeRecordExists(recipeId, deletedRecipeRecords) {
-		common.Errorf("Recipe %s was not deleted", recipeId)
+	if !RecipeRecordExists(ctx, recipeId, deletedRecipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not deleted", recipeId)
 		return false
 	}
 
-	common.Infof("Recipe %s was deleted", recipeId)
+	common.Infof(ctx, "Recipe %s was deleted", recipeId)
 	return true
 }
 
-func TestRecipeUndelete(recipeId string) (passed bool) {
+func TestRecipeUndelete(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipeUndelete").End(ctx, &passed)
 	// Get the recipe record before restoring it
-	existingRecipeRecord, success := GetDeletedIMSRecipeRecordAPI(recipeId, http.StatusOK)
+	existingRecipeRecord, success := GetDeletedIMSRecipeRecordAPI(ctx, recipeId, http.StatusOK)
 	if !success {
-		common.Errorf("Recipe %s was not found", recipeId)
+		common.Errorf(ctx, "Recipe %s was not found", recipeId)
 		return false
 	}
 
-	if success := UndeleteIMSRecipeRecordAPI(recipeId); !success {
+	if success := UndeleteIMSRecipeRecordAPI(ctx, recipeId); !success {
 		return false
 	}
 
 	// Verify the recipe is
-	recipeRecord, success := GetIMSRecipeRecordAPI(recipeId, http.StatusOK)
+	recipeRecord, success := GetIMSRecipeRecordAPI(ctx, recipeId, http.StatusOK)
 	if !success {
-		common.Errorf("Recipe %s was not restored", recipeId)
+		common.Errorf(ctx, "Recipe %s was not restored", recipeId)
 		return false
 	}
 
-	if !VerifyIMSRecipeRecord(recipeRecord, existingRecipeRecord) {
-		common.Errorf("Recipe %s details do not match after restore", recipeId)
+	if !VerifyIMSRecipeRecord(ctx, recipeRecord, existingRecipeRecord) {
+		common.Errorf(ctx, "Recipe %s details do not match after restore", recipeId)
 		return false
 	}
 
-	common.Infof("Recipe %s was restored", recipeId)
+	common.Infof(ctx, "Recipe %s was restored", recipeId)
 	return true
 }
 
-func TestGetAllRecipes() (passed bool) {
-	if _, success := GetIMSRecipeRecordsAPI(); !success {
+func TestGetAllRecipes(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestGetAllRecipes").End(ctx, &passed)
+	if _, success := GetIMSRecipeRecordsAPI(ctx); !success {
 		return false
 	}
-	common.Infof("All recipes were retrieved")
+	common.Infof(ctx, "All recipes were retrieved")
 	return true
 }
 
-func TestRecipeDeleteV2(recipeId string) (passed bool) {
-	if success := DeleteIMSRecipeRecordAPI(recipeId); !success {
+func TestRecipeDeleteV2(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipeDeleteV2").End(ctx, &passed)
+	if success := DeleteIMSRecipeRecordAPI(ctx, recipeId); !success {
 		return false
 	}
 
 	// Verify the recipe is not in the list of recipes
-	if _, success := GetIMSRecipeRecordAPI(recipeId, http.StatusNotFound); !success {
-		common.Errorf("Recipe %s was not deleted", recipeId)
+	if _, success := GetIMSRecipeRecordAPI(ctx, recipeId, http.StatusNotFound); !success {
+		common.Errorf(ctx, "Recipe %s was not deleted", recipeId)
 		return false
 	}
 
 	// Verify the recipe is not in the list of all recipes
-	recipeRecords, success := GetIMSRecipeRecordsAPI()
+	recipeRecords, success := GetIMSRecipeRecordsAPI(ctx)
 	if !success {
 		return false
 	}
 
-	if RecipeRecordExists(recipeId, recipeRecords) {
-		common.Errorf("Recipe %s was not deleted", recipeId)
+	if RecipeRecordExists(ctx, recipeId, recipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not deleted", recipeId)
 		return false
 	}
 
-	common.Infof("Recipe %s was deleted", recipeId)
+	common.Infof(ctx, "Recipe %s was deleted", recipeId)
 	return true
 }
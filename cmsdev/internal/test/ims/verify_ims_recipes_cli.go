@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2025-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -22,6 +22,7 @@
 package ims
 
 import (
+	"context"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
@@ -33,32 +34,34 @@ import (
  *
  */
 
-func TestRecipeCRUDOperationUsingCLI() (passed bool) {
-	common.PrintLog("Testing recipe CRUD operations using CLI")
+func TestRecipeCRUDOperationUsingCLI(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestRecipeCRUDOperationUsingCLI").End(ctx, &passed)
+	common.PrintLog(ctx, "Testing recipe CRUD operations using CLI")
 	// Test creating a recipe
-	recipeRecord, success := TestCLIRecipeCreate()
+	recipeRecord, success := TestCLIRecipeCreate(ctx)
 	if !success {
 		return false
 	}
 	// Test updating the recipe
-	updated := TestCLIRecipeUpdate(recipeRecord.Id)
+	updated := TestCLIRecipeUpdate(ctx, recipeRecord.Id)
 
 	// Test soft deleting the recipe
-	deleted := TestCLIRecipeDelete(recipeRecord.Id)
+	deleted := TestCLIRecipeDelete(ctx, recipeRecord.Id)
 
 	// Test undeleting the recipe
-	undeleted := TestCLIRecipeUndelete(recipeRecord.Id)
+	undeleted := TestCLIRecipeUndelete(ctx, recipeRecord.Id)
 
 	// Test permanent deleting the recipe
-	permDeleted := TestCLIRecipePermanentDelete(recipeRecord.Id)
+	permDeleted := TestCLIRecipePermanentDelete(ctx, recipeRecord.Id)
 
 	// Test get all recipes
-	getAll := TestCLIGetAllRecipes()
+	getAll := TestCLIGetAllRecipes(ctx)
 
 	return updated && deleted && undeleted && permDeleted && getAll
 }
 
-func TestCLIRecipeCreate() (recipeRecord IMSRecipeRecord, passed bool) {
+func TestCLIRecipeCreate(ctx context.Context) (recipeRecord IMSRecipeRecord, passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIRecipeCreate").End(ctx, &passed)
 
 	// Create the recipe
 	recipeName := "recipe_" + string(common.GetRandomString(10))
@@ -74,39 +77,40 @@ func TestCLIRecipeCreate() (recipeRecord IMSRecipeRecord, passed bool) {
 		},
 	}
 
-	recipeRecord, success := CreateIMSRecipeRecordCLI(recipeName)
+	recipeRecord, success := CreateIMSRecipeRecordCLI(ctx, recipeName)
 	if !success {
 		return IMSRecipeRecord{}, false
 	}
 	// Get the recipe record
-	recipeRecord, success = getIMSRecipeRecordCLI(recipeRecord.Id)
+	recipeRecord, success = getIMSRecipeRecordCLI(ctx, recipeRecord.Id)
 	if !success || recipeRecord.Name != recipeName {
-		common.Errorf("Recipe %s was not created with name %s", recipeRecord.Id, recipeName)
+		common.Errorf(ctx, "Recipe %s was not created with name %s", recipeRecord.Id, recipeName)
 		return IMSRecipeRecord{}, false
 	}
 
 	// Verify the recipe metadata
-	if !common.CompareSlicesOfMaps(recipeRecord.Template_dictionary, expectedTemplatesDict) {
-		common.Errorf("Expected template dictionary %v, got %v", expectedTemplatesDict, recipeRecord.Template_dictionary)
+	if !common.CompareSlicesOfMaps(ctx, recipeRecord.Template_dictionary, expectedTemplatesDict) {
+		common.Errorf(ctx, "Expected template dictionary %v, got %v", expectedTemplatesDict, recipeRecord.Template_dictionary)
 		return IMSRecipeRecord{}, false
 	}
 
 	// Verfy the recipe is in the list of recipes
-	recipeRecords, success := getIMSRecipeRecordsCLI()
+	recipeRecords, success := getIMSRecipeRecordsCLI(ctx)
 	if !success {
 		return IMSRecipeRecord{}, false
 	}
 
-	if !RecipeRecordExists(recipeRecord.Id, recipeRecords) {
-		common.Errorf("Recipe %s was not found in the list of recipes", recipeRecord.Id)
+	if !RecipeRecordExists(ctx, recipeRecord.Id, recipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not found in the list of recipes", recipeRecord.Id)
 		return IMSRecipeRecord{}, false
 	}
 
-	common.Infof("Created recipe ID %s with name %s", recipeRecord.Id, recipeName)
+	common.Infof(ctx, "Created recipe ID %s with name %s", recipeRecord.Id, recipeName)
 	return recipeRecord, true
 }
 
-func TestCLIRecipeUpdate(recipeId string) (passed bool) {
+func TestCLIRecipeUpdate(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIRecipeUpdate").End(ctx, &passed)
 	arch := "aarch64"
 	expectedTemplatesDict := []map[string]string{
 		{
@@ -114,144 +118,148 @@ func TestCLIRecipeUpdate(recipeId string) (passed bool) {
 			"value": "1.1.2-1-cos-3.1",
 		},
 	}
-	if _, success := UpdateIMSRecipeRecordCLI(recipeId, arch); !success {
+	if _, success := UpdateIMSRecipeRecordCLI(ctx, recipeId, arch); !success {
 		return false
 	}
 	// Get the recipe record
-	recipeRecord, success := getIMSRecipeRecordCLI(recipeId)
+	recipeRecord, success := getIMSRecipeRecordCLI(ctx, recipeId)
 	if !success || recipeRecord.Arch != arch {
-		common.Errorf("Recipe %s was not updated with arch %s", recipeId, arch)
+		common.Errorf(ctx, "Recipe %s was not updated with arch %s", recipeId, arch)
 		return false
 	}
 
 	// Verify the recipe metadata
-	if !common.CompareSlicesOfMaps(recipeRecord.Template_dictionary, expectedTemplatesDict) {
-		common.Errorf("Expected template dictionary %v, got %v", expectedTemplatesDict, recipeRecord.Template_dictionary)
+	if !common.CompareSlicesOfMaps(ctx, recipeRecord.Template_dictionary, expectedTemplatesDict) {
+		common.Errorf(ctx, "Expected template dictionary %v, got %v", expectedTemplatesDict, recipeRecord.Template_dictionary)
 		return false
 	}
-	common.Infof("Updated recipe ID %s with arch %s", recipeRecord.Id, arch)
+	common.Infof(ctx, "Updated recipe ID %s with arch %s", recipeRecord.Id, arch)
 	return true
 }
 
-func TestCLIRecipeDelete(recipeId string) (passed bool) {
-	if success := DeleteIMSRecipeRecordCLI(recipeId); !success {
+func TestCLIRecipeDelete(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIRecipeDelete").End(ctx, &passed)
+	if success := DeleteIMSRecipeRecordCLI(ctx, recipeId); !success {
 		return false
 	}
 	// Verify the recipe is soft deleted
-	if _, success := GetDeletedIMSRecipeRecordCLI(recipeId); !success {
-		common.Errorf("Recipe %s was not soft deleted", recipeId)
+	if _, success := GetDeletedIMSRecipeRecordCLI(ctx, recipeId); !success {
+		common.Errorf(ctx, "Recipe %s was not soft deleted", recipeId)
 		return false
 	}
 
 	// Set the CLI execution return code to 2. Since the recipe is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 
 	// Verify the recipe is not in the list of recipes
-	if _, success := getIMSRecipeRecordCLI(recipeId); success {
-		common.Errorf("Recipe %s was not soft deleted", recipeId)
+	if _, success := getIMSRecipeRecordCLI(ctx, recipeId); success {
+		common.Errorf(ctx, "Recipe %s was not soft deleted", recipeId)
 		return false
 	}
 
 	// Set the CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// Verify the recipe is not in the list of all recipes
-	recipeRecords, success := getIMSRecipeRecordsCLI()
+	recipeRecords, success := getIMSRecipeRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if RecipeRecordExists(recipeId, recipeRecords) {
-		common.Errorf("Recipe %s was not soft deleted", recipeId)
+	if RecipeRecordExists(ctx, recipeId, recipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not soft deleted", recipeId)
 		return false
 	}
 
 	// verify the recipe is in the list of all deleted recipes
-	deletedRecipeRecords, success := GetDeletedIMSRecipeRecordsCLI()
+	deletedRecipeRecords, success := GetDeletedIMSRecipeRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if !RecipeRecordExists(recipeId, deletedRecipeRecords) {
-		common.Errorf("Recipe %s was not found in the list of deleted recipes", recipeId)
+	if !RecipeRecordExists(ctx, recipeId, deletedRecipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not found in the list of deleted recipes", recipeId)
 		return false
 	}
 
-	common.Infof("Soft deleted recipe ID %s", recipeId)
+	common.Infof(ctx, "Soft deleted recipe ID %s", recipeId)
 	return true
 }
 
-func TestCLIRecipeUndelete(recipeId string) (passed bool) {
-	if success := UndeleteIMSRecipeRecordCLI(recipeId); !success {
+func TestCLIRecipeUndelete(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIRecipeUndelete").End(ctx, &passed)
+	if success := UndeleteIMSRecipeRecordCLI(ctx, recipeId); !success {
 		return false
 	}
 	// Verify the recipe is undeleted
-	if _, success := getIMSRecipeRecordCLI(recipeId); !success {
-		common.Errorf("Recipe %s was not restored", recipeId)
+	if _, success := getIMSRecipeRecordCLI(ctx, recipeId); !success {
+		common.Errorf(ctx, "Recipe %s was not restored", recipeId)
 		return false
 	}
-	common.Infof("Restored recipe ID %s", recipeId)
+	common.Infof(ctx, "Restored recipe ID %s", recipeId)
 	return true
 }
 
-func TestCLIRecipePermanentDelete(recipeId string) (passed bool) {
+func TestCLIRecipePermanentDelete(ctx context.Context, recipeId string) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIRecipePermanentDelete").End(ctx, &passed)
 	// Soft delete the recipe
-	if success := DeleteIMSRecipeRecordCLI(recipeId); !success {
+	if success := DeleteIMSRecipeRecordCLI(ctx, recipeId); !success {
 		return false
 	}
 
-	if success := PermanentDeleteIMSRecipeRecordCLI(recipeId); !success {
+	if success := PermanentDeleteIMSRecipeRecordCLI(ctx, recipeId); !success {
 		return false
 	}
 
 	// Set the CLI execution return code to 2. Since the recipe is deleted, the command should return 2.
-	test.SetCliExecreturnCode(2)
+	test.SetCliExecreturnCode(ctx, 2)
 
 	// Verify the recipe is hard deleted
-	if _, success := GetDeletedIMSRecipeRecordCLI(recipeId); success {
-		common.Errorf("Recipe %s was not permanently deleted", recipeId)
+	if _, success := GetDeletedIMSRecipeRecordCLI(ctx, recipeId); success {
+		common.Errorf(ctx, "Recipe %s was not permanently deleted", recipeId)
 		return false
 	}
 
 	// Verify the recipe is not in the list of recipes
-	if _, success := getIMSRecipeRecordCLI(recipeId); success {
-		common.Errorf("Recipe %s was not permanently deleted", recipeId)
+	if _, success := getIMSRecipeRecordCLI(ctx, recipeId); success {
+		common.Errorf(ctx, "Recipe %s was not permanently deleted", recipeId)
 		return false
 	}
 
 	// Set the CLI execution return code to 0.
-	test.SetCliExecreturnCode(0)
+	test.SetCliExecreturnCode(ctx, 0)
 
 	// Verify the recipe is not in the list of all recipes
-	recipeRecords, success := getIMSRecipeRecordsCLI()
+	recipeRecords, success := getIMSRecipeRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if RecipeRecordExists(recipeId, recipeRecords) {
-		common.Errorf("Recipe %s was not permanently deleted", recipeId)
+	if RecipeRecordExists(ctx, recipeId, recipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not permanently deleted", recipeId)
 		return false
 	}
 
 	// verify the recipe is in the list of all deleted recipes
-	deletedRecipeRecords, success := GetDeletedIMSRecipeRecordsCLI()
+	deletedRecipeRecords, success := GetDeletedIMSRecipeRecordsCLI(ctx)
 	if !success {
 		return false
 	}
 
-	if RecipeRecordExists(recipeId, deletedRecipeRecords) {
-		common.Errorf("Recipe %s was not permanently deleted", recipeId)
+	if RecipeRecordExists(ctx, recipeId, deletedRecipeRecords) {
+		common.Errorf(ctx, "Recipe %s was not permanently deleted", recipeId)
 		return false
 	}
 
-	common.Infof("Permanently deleted recipe ID %s", recipeId)
+	common.Infof(ctx, "Permanently deleted recipe ID %s", recipeId)
 	return true
 }
 
-func TestCLIGetAllRecipes() (passed bool) {
-	if _, success := getIMSRecipeRecordsCLI(); !success {
+func TestCLIGetAllRecipes(ctx context.Context) (passed bool) {
+	defer common.StartSubtest(ctx, "TestCLIGetAllRecipes").End(ctx, &passed)
+	if _, success := getIMSRecipeRecordsCLI(ctx); !success {
 		return false
 	}
-	common.Infof("Got all recipes")
+	common.Infof(ctx, "Got all recipes")
 	return true
 }
@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,7 +29,9 @@ package ipxe_tftp
  */
 
 import (
+	"context"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 )
 
@@ -42,96 +44,141 @@ var tftpServiceNames = []string{
 	"cray-tftp-hmn",
 }
 
-func AreTheyRunning() (passed bool) {
+// Subtest names, for use with the --only and --skip options
+var tftpSubtests = []string{"tftp.ipxe-pods", "tftp.pods", "tftp.ipxe-binaries", "tftp.file-transfer"}
+
+func init() {
+	registry.Register(registry.ServiceTest{
+		Name:           "tftp",
+		Aliases:        []string{"ipxe"},
+		Description:    "iPXE and TFTP services: pods, PVC, iPXE binaries and TFTP file transfers",
+		DefaultTimeout: 300,
+		Subtests:       tftpSubtests,
+		// Every subtest checks the pods or their configmaps, or runs commands in them
+		ClusterSubtests: tftpSubtests,
+		Run: func(opts registry.RunOptions) bool {
+			return AreTheyRunning(opts.Context)
+		},
+	})
+}
+
+func AreTheyRunning(ctx context.Context) (passed bool) {
 	passed = true
 	var podNames []string
-	var arch, pvcName string
 	var ok, onMaster bool
 	var err error
 
 	iPxePodNameByArch := make(map[string]string)
+	fileTransfer := []string{"tftp.file-transfer"}
 
-	// Binaries for each architecture are built in an iPXE pod for that architecture
-	for _, arch = range IpxeBinaryArchitectures {
-		// Find iPXE pod and verify status
-		podNames, ok = test.GetPodNamesInNamespace(common.NAMESPACE, IpxePodPrefixByArch[arch], 1, 1)
-		if !ok {
-			passed = false
-			common.Infof("Found %d %s iPXE pod(s)", len(podNames), arch)
-		} else {
-			iPxePodNameByArch[arch] = podNames[0]
-			common.Infof("Found %s iPXE pod: %s", arch, iPxePodNameByArch[arch])
-			if !IpxeContainerReady(iPxePodNameByArch[arch]) {
-				passed = false
-			}
-		}
-
-		if !test.CheckPodListStats(podNames) {
-			passed = false
-		}
-	}
-
-	// Next validate TFTP k8s status
-	podNames, ok = test.GetPodNamesByPrefixKey("tftp", 1, -1)
-	if !ok {
-		passed = false
-	}
-	common.Infof("Found %d tftp pods", len(podNames))
-	if !test.CheckPodListStats(podNames) {
+	// The file transfer subtest needs the iPXE pod names and binary names, so the subtests
+	// which find them are run if it is selected
+	if !registry.RunSubtestNeededBy(ctx, "tftp.ipxe-pods", fileTransfer, func(ctx context.Context) bool { return checkIpxePods(ctx, iPxePodNameByArch) }) {
 		passed = false
 	}
 
-	// check pvc status
-	for _, pvcName = range pvcNames {
-		if !test.CheckPVCStatus(pvcName) {
-			passed = false
-		}
+	if !registry.RunSubtest(ctx, "tftp.pods", func(ctx context.Context) bool { podNames, ok = checkTftpPods(ctx); return ok }) {
+		passed = false
 	}
 
 	if !passed {
-		common.ArtifactsKubernetes()
+		common.ArtifactsKubernetes(ctx)
 		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, podNames)
 		}
 		if len(pvcNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, pvcNames)
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, pvcNames)
 		}
-		common.Infof("Because of previous failures, skipping remaining tftp checks")
+		common.Infof(ctx, "Because of previous failures, skipping remaining tftp checks")
 		return
 	}
 
 	// Even though the file transfer subtest will not run if this is a master NCN,
 	// we always have the test check the configmap, just to make sure it doesn't have
 	// errors.
-	if !GetIpxeBinaryNames() {
+	if !registry.RunSubtestNeededBy(ctx, "tftp.ipxe-binaries", fileTransfer, GetIpxeBinaryNames) {
 		passed = false
 	}
 
-	// The file transfer subtest cannot run from master NCNs
-	onMaster, err = common.RunningOnMaster()
-	if err != nil {
-		common.Error(err)
-		common.Errorf("Error checking node hostname")
-		passed = false
-	} else if onMaster == true {
-		common.Infof("tftp file transfer test cannot run on master NCNs -- skipping")
-	} else {
+	if !registry.RunSubtest(ctx, "tftp.file-transfer", func(ctx context.Context) bool {
+		// The file transfer subtest cannot run from master NCNs
+		onMaster, err = common.RunningOnMaster(ctx)
+		if err != nil {
+			common.Error(ctx, err)
+			common.Errorf(ctx, "Error checking node hostname")
+			return false
+		} else if onMaster == true {
+			common.Infof(ctx, "tftp file transfer test cannot run on master NCNs -- skipping")
+			return true
+		}
+		transferPassed := true
 		for _, srvName := range tftpServiceNames {
-			if !TftpServiceFileTransferTest(srvName, iPxePodNameByArch) {
-				passed = false
+			if !TftpServiceFileTransferTest(ctx, srvName, iPxePodNameByArch) {
+				transferPassed = false
 			}
 		}
+		return transferPassed
+	}) {
+		passed = false
 	}
 
 	if !passed {
-		common.ArtifactsKubernetes()
+		common.ArtifactsKubernetes(ctx)
 		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, podNames)
 		}
 		if len(pvcNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, pvcNames)
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, pvcNames)
 		}
 	}
 
 	return
 }
+
+// Find the iPXE pod for each architecture and verify its status. The pod names are added to
+// the specified map.
+func checkIpxePods(ctx context.Context, iPxePodNameByArch map[string]string) (passed bool) {
+	passed = true
+	minCount, maxCount := common.ExpectedPodCount(ctx, "ipxe")
+	// Binaries for each architecture are built in an iPXE pod for that architecture
+	for _, arch := range IpxeBinaryArchitectures {
+		// Find iPXE pod and verify status
+		podNames, ok := test.GetPodNamesInNamespace(ctx, common.NAMESPACE, IpxePodPrefixByArch[arch], minCount, maxCount)
+		if !ok {
+			passed = false
+			common.Infof(ctx, "Found %d %s iPXE pod(s)", len(podNames), arch)
+		} else {
+			iPxePodNameByArch[arch] = podNames[0]
+			common.Infof(ctx, "Found %s iPXE pod: %s", arch, iPxePodNameByArch[arch])
+			if !IpxeContainerReady(ctx, iPxePodNameByArch[arch]) {
+				passed = false
+			}
+		}
+
+		if !test.CheckPodListStats(ctx, podNames) {
+			passed = false
+		}
+	}
+	return
+}
+
+// Validate TFTP k8s status. Returns the pod names and true if they look okay.
+func checkTftpPods(ctx context.Context) (podNames []string, passed bool) {
+	passed = true
+	podNames, ok := test.GetPodNamesByPrefixKey(ctx, "tftp")
+	if !ok {
+		passed = false
+	}
+	common.Infof(ctx, "Found %d tftp pods", len(podNames))
+	if !test.CheckPodListStats(ctx, podNames) {
+		passed = false
+	}
+
+	// check pvc status
+	for _, pvcName := range pvcNames {
+		if !test.CheckPVCStatus(ctx, pvcName) {
+			passed = false
+		}
+	}
+	return
+}
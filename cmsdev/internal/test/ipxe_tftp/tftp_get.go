@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2020-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2020-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -30,6 +30,7 @@ package ipxe_tftp
  */
 
 import (
+	"context"
 	"fmt"
 	"github.com/pin/tftp"
 	"os"
@@ -101,7 +102,7 @@ var ipxeBinaryNames = map[string][]string{
 // Retrieve the cray-ipxe-settings Kubernetes ConfigMap,
 // look up the data."settings.yaml" data, and use it to determine the names of the iPXE binaries
 // being generated.
-func GetIpxeBinaryNames() (passed bool) {
+func GetIpxeBinaryNames(ctx context.Context) (passed bool) {
 	var totalNames int
 	var buildArch, buildBinary bool
 	var binaryName string
@@ -109,115 +110,115 @@ func GetIpxeBinaryNames() (passed bool) {
 	totalNames = 0
 
 	// Retrieve settings data field from the config map
-	common.Infof("Retrieving iPXE settings from Kubernetes %s configmap", IpxeConfigMapName)
-	ipxeSettingsBytes, err := k8s.GetConfigMapDataField(common.NAMESPACE, IpxeConfigMapName, IpxeConfigMapSettingsFieldName)
+	common.Infof(ctx, "Retrieving iPXE settings from Kubernetes %s configmap", IpxeConfigMapName)
+	ipxeSettingsBytes, err := k8s.GetConfigMapDataField(ctx, common.NAMESPACE, IpxeConfigMapName, IpxeConfigMapSettingsFieldName)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 	ipxeSettingsMap, err := common.DecodeYAMLIntoStringMap(ipxeSettingsBytes)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
 	passed = true
 
 	for arch, cmArchBin := range cmBinaries {
-		buildArch, err = common.GetBoolFieldFromMapObjectWithDefault(cmArchBin.Build.Field, ipxeSettingsMap, cmArchBin.Build.Default)
+		buildArch, err = common.GetBoolFieldFromMapObjectWithDefault(ctx, cmArchBin.Build.Field, ipxeSettingsMap, cmArchBin.Build.Default)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			passed = false
 			return
 		}
-		common.Debugf("buildArch = %t", buildArch)
+		common.Debugf(ctx, "buildArch = %t", buildArch)
 		if !buildArch {
-			common.Infof("According to the configmap, no binaries are being built for %s architecture", arch)
+			common.Infof(ctx, "According to the configmap, no binaries are being built for %s architecture", arch)
 			continue
 		}
-		binaryName, err = common.GetStringFieldFromMapObjectWithDefault(cmArchBin.RegularName.Field, ipxeSettingsMap, cmArchBin.RegularName.Default)
+		binaryName, err = common.GetStringFieldFromMapObjectWithDefault(ctx, cmArchBin.RegularName.Field, ipxeSettingsMap, cmArchBin.RegularName.Default)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			passed = false
 			return
 		}
-		common.Debugf("binaryName = %s", binaryName)
+		common.Debugf(ctx, "binaryName = %s", binaryName)
 		if len(binaryName) > 0 {
-			common.Debugf("Appending %s to binary list for %s architecture", binaryName, arch)
+			common.Debugf(ctx, "Appending %s to binary list for %s architecture", binaryName, arch)
 			ipxeBinaryNames[arch][0] = binaryName
 			totalNames += 1
 		} else {
-			common.Errorf("%s is true in %s configmap, but the binary name is blank", cmArchBin.Build.Field, IpxeConfigMapName)
+			common.Errorf(ctx, "%s is true in %s configmap, but the binary name is blank", cmArchBin.Build.Field, IpxeConfigMapName)
 			passed = false
 		}
 
 		// Now check for debug binary
-		buildBinary, err = common.GetBoolFieldFromMapObjectWithDefault(cmArchBin.BuildDebug.Field, ipxeSettingsMap, cmArchBin.BuildDebug.Default)
+		buildBinary, err = common.GetBoolFieldFromMapObjectWithDefault(ctx, cmArchBin.BuildDebug.Field, ipxeSettingsMap, cmArchBin.BuildDebug.Default)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			passed = false
 			return
 		}
-		common.Debugf("debug buildBinary = %t", buildBinary)
+		common.Debugf(ctx, "debug buildBinary = %t", buildBinary)
 		if !buildBinary {
-			common.Infof("According to the configmap, no debug binaries are being built for %s architecture", arch)
+			common.Infof(ctx, "According to the configmap, no debug binaries are being built for %s architecture", arch)
 			continue
 		}
-		binaryName, err = common.GetStringFieldFromMapObjectWithDefault(cmArchBin.DebugName.Field, ipxeSettingsMap, cmArchBin.DebugName.Default)
+		binaryName, err = common.GetStringFieldFromMapObjectWithDefault(ctx, cmArchBin.DebugName.Field, ipxeSettingsMap, cmArchBin.DebugName.Default)
 		if err != nil {
-			common.Error(err)
+			common.Error(ctx, err)
 			passed = false
 			return
 		}
-		common.Debugf("debug binaryName = %s", binaryName)
+		common.Debugf(ctx, "debug binaryName = %s", binaryName)
 		if len(binaryName) > 0 {
-			common.Debugf("Appending %s to binary list for %s architecture", binaryName, arch)
+			common.Debugf(ctx, "Appending %s to binary list for %s architecture", binaryName, arch)
 			ipxeBinaryNames[arch][1] = binaryName
 			totalNames += 1
 		} else {
-			common.Errorf("%s is true in %s configmap, but the binary name is blank", cmArchBin.BuildDebug.Field, IpxeConfigMapName)
+			common.Errorf(ctx, "%s is true in %s configmap, but the binary name is blank", cmArchBin.BuildDebug.Field, IpxeConfigMapName)
 			passed = false
 		}
 	}
 
 	if totalNames == 0 {
-		common.Infof("Based on the %s configmap, no iPXE binaries are being built.", IpxeConfigMapName)
+		common.Infof(ctx, "Based on the %s configmap, no iPXE binaries are being built.", IpxeConfigMapName)
 	}
 	return
 }
 
 // Just a wrapper function for calling k8s.RunCommandInContainer on the ipxe container
-func RunCommandInIpxeContainer(podName string, cmdStrings ...string) (string, error) {
-	return k8s.RunCommandInContainer(podName, common.NAMESPACE, IpxeContainerName, cmdStrings...)
+func RunCommandInIpxeContainer(ctx context.Context, podName string, cmdStrings ...string) (string, error) {
+	return k8s.RunCommandInContainer(ctx, podName, common.NAMESPACE, IpxeContainerName, cmdStrings...)
 }
 
 // Verify:
 // 1) can run command on ipxe container
 // 2) IpxePathname directory exists and is not empty
 // If any are not true, log the error and return false. Otherwise return true.
-func IpxeContainerReady(ipxePodName string) bool {
+func IpxeContainerReady(ctx context.Context, ipxePodName string) bool {
 	// Can we even run a basic command in the container?
-	common.Infof("Trying to run test command in %s container (%s pod, %s namespace)", IpxeContainerName, ipxePodName,
+	common.Infof(ctx, "Trying to run test command in %s container (%s pod, %s namespace)", IpxeContainerName, ipxePodName,
 		common.NAMESPACE)
-	if _, err := RunCommandInIpxeContainer(ipxePodName, "date"); err != nil {
-		common.Error(err)
-		common.Errorf("Unable to run even basic command in ipxe container")
+	if _, err := RunCommandInIpxeContainer(ctx, ipxePodName, "date"); err != nil {
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Unable to run even basic command in ipxe container")
 		return false
 	}
 
 	// Verify the IpxePathname directory exists in the container (well, in the shared storage space, but we're all
 	// friends here -- let's not split hairs) and that it is not empty
-	common.Infof("Checking to see if directory %s in %s container (%s pod, %s namespace) exists and is not empty", IpxePathname,
+	common.Infof(ctx, "Checking to see if directory %s in %s container (%s pod, %s namespace) exists and is not empty", IpxePathname,
 		IpxeContainerName, ipxePodName, common.NAMESPACE)
-	if cmdOut, err := RunCommandInIpxeContainer(ipxePodName, "sh", "-c", "[ -d "+IpxePathname+" ] && find "+IpxePathname+"  -maxdepth 0 -type d -empty"); err != nil {
+	if cmdOut, err := RunCommandInIpxeContainer(ctx, ipxePodName, "sh", "-c", "[ -d "+IpxePathname+" ] && find "+IpxePathname+"  -maxdepth 0 -type d -empty"); err != nil {
 		// If this command fails, then it means either the directory does not exist or it is not a directory.
-		common.Error(err)
-		common.Errorf("In the %s container (%s pod, %s namespace), it seems that %s either does not exist or is not a directory", IpxeContainerName, ipxePodName,
+		common.Error(ctx, err)
+		common.Errorf(ctx, "In the %s container (%s pod, %s namespace), it seems that %s either does not exist or is not a directory", IpxeContainerName, ipxePodName,
 			common.NAMESPACE, IpxePathname)
 		return false
 	} else if cmdOut != "" {
 		// The command should give no output unless the directory is empty
-		common.Errorf("In the %s container (%s pod, %s namespace), it seems that %s is a directory but is empty", IpxeContainerName, ipxePodName,
+		common.Errorf(ctx, "In the %s container (%s pod, %s namespace), it seems that %s is a directory but is empty", IpxeContainerName, ipxePodName,
 			common.NAMESPACE, IpxePathname)
 		return false
 	}
@@ -227,26 +228,27 @@ func IpxeContainerReady(ipxePodName string) bool {
 // 1) Get the port number, external IP, and cluster IP for the specified tftp service
 // 2) For each IP, perform a tftp get test for each iPXE binary specified
 // Return true if no errors, false otherwise
-func TftpServiceFileTransferTest(serviceName string, ipxePodNameByArch map[string]string) (passed bool) {
+func TftpServiceFileTransferTest(ctx context.Context, serviceName string, ipxePodNameByArch map[string]string) (passed bool) {
+	defer common.StartSubtest(ctx, "TftpServiceFileTransferTest").End(ctx, &passed)
 
 	var IPPort string
 	var totalNames int
 	passed = true
 
-	common.Infof("Performing TFTP file transfer test for service %s", serviceName)
+	common.Infof(ctx, "Performing TFTP file transfer test for service %s", serviceName)
 
 	// First, get the IP addresses and port for the service
-	clusterIP, externalIP, mainPort, ok := GetTftpIPsPort(serviceName)
+	clusterIP, externalIP, mainPort, ok := GetTftpIPsPort(ctx, serviceName)
 	if !ok {
 		passed = false
 		if mainPort < 1 {
-			common.Infof("Cannot run file transfer test without valid port number")
+			common.Infof(ctx, "Cannot run file transfer test without valid port number")
 			return
 		} else if len(clusterIP) == 0 && len(externalIP) == 0 {
-			common.Infof("Cannot run file transfer test without valid IP address")
+			common.Infof(ctx, "Cannot run file transfer test without valid IP address")
 			return
 		}
-		common.Infof("Will run as many file transfer tests as we can, despite previous failures")
+		common.Infof(ctx, "Will run as many file transfer tests as we can, despite previous failures")
 	}
 
 	for _, arch := range IpxeBinaryArchitectures {
@@ -258,7 +260,7 @@ func TftpServiceFileTransferTest(serviceName string, ipxePodNameByArch map[strin
 			}
 		}
 		if totalNames == 0 {
-			common.Infof("No %s iPXE binaries are being built. Will not be able to perform the actual file transfer test for this architecture.", arch)
+			common.Infof(ctx, "No %s iPXE binaries are being built. Will not be able to perform the actual file transfer test for this architecture.", arch)
 			continue
 		}
 
@@ -266,19 +268,19 @@ func TftpServiceFileTransferTest(serviceName string, ipxePodNameByArch map[strin
 			if ipxeBinaryName == "" {
 				continue
 			}
-			common.Infof("Performing file transfer tests for %s %s binary from service %s", arch, ipxeBinaryName, serviceName)
+			common.Infof(ctx, "Performing file transfer tests for %s %s binary from service %s", arch, ipxeBinaryName, serviceName)
 			if len(clusterIP) > 0 {
 				IPPort = fmt.Sprintf("%s:%d", clusterIP, mainPort)
-				common.Infof("Testing tftp file transfer of %s from cluster IP:port (%s)", ipxeBinaryName, IPPort)
-				if !TftpIPPortFileTransferTest(IPPort, ipxePodNameByArch[arch], ipxeBinaryName) {
+				common.Infof(ctx, "Testing tftp file transfer of %s from cluster IP:port (%s)", ipxeBinaryName, IPPort)
+				if !TftpIPPortFileTransferTest(ctx, IPPort, ipxePodNameByArch[arch], ipxeBinaryName) {
 					passed = false
 				}
 			}
 
 			if len(externalIP) > 0 {
 				IPPort = fmt.Sprintf("%s:%d", externalIP, mainPort)
-				common.Infof("Testing tftp file transfer of %s from external IP:port (%s)", ipxeBinaryName, IPPort)
-				if !TftpIPPortFileTransferTest(IPPort, ipxePodNameByArch[arch], ipxeBinaryName) {
+				common.Infof(ctx, "Testing tftp file transfer of %s from external IP:port (%s)", ipxeBinaryName, IPPort)
+				if !TftpIPPortFileTransferTest(ctx, IPPort, ipxePodNameByArch[arch], ipxeBinaryName) {
 					passed = false
 				}
 			}
@@ -289,32 +291,32 @@ func TftpServiceFileTransferTest(serviceName string, ipxePodNameByArch map[strin
 }
 
 // Return a file object for the specified local (e.g. wherever this test is running) file
-func OpenLocalFile(name string) (*os.File, bool) {
-	common.Infof("Creating local file for transfer test: %s", name)
+func OpenLocalFile(ctx context.Context, name string) (*os.File, bool) {
+	common.Infof(ctx, "Creating local file for transfer test: %s", name)
 	localFile, err := os.Create(name)
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Error creating file: %s", name)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error creating file: %s", name)
 		return nil, false
 	}
 	return localFile, true
 }
 
 // If the file still exists, remove it
-func RemoveLocalFile(name string) {
+func RemoveLocalFile(ctx context.Context, name string) {
 	// If it still exists, remove it
 	if _, err := os.Stat(name); os.IsNotExist(err) {
 		return
 	}
-	common.Infof("Removing temporary local file %s", name)
+	common.Infof(ctx, "Removing temporary local file %s", name)
 	if err := os.Remove(name); err != nil {
-		common.Error(err)
-		common.Errorf("Error deleting temporary file %s", name)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error deleting temporary file %s", name)
 	}
 }
 
 // Sync and Close local file
-func SyncCloseLocalFile(name string, file *os.File) (ok bool) {
+func SyncCloseLocalFile(ctx context.Context, name string, file *os.File) (ok bool) {
 	ok = true
 
 	// First, sync data to file, in case there are errors
@@ -322,13 +324,13 @@ func SyncCloseLocalFile(name string, file *os.File) (ok bool) {
 	// the file is closed, otherwise, so this is our only chance
 	// to definitely detect a write error to the file)
 	if err := file.Sync(); err != nil {
-		common.Error(err)
-		common.Errorf("Error Syncing file: %s", name)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error Syncing file: %s", name)
 		ok = false
 	}
 	if err := file.Close(); err != nil {
-		common.Error(err)
-		common.Errorf("Error closing file: %s", name)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error closing file: %s", name)
 		ok = false
 	}
 	return
@@ -337,44 +339,44 @@ func SyncCloseLocalFile(name string, file *os.File) (ok bool) {
 // 1) Perform a tftp get of the ipxe.efi file using the provided tftp client
 // 2) Generate the md5sum of the received file
 // If no problems, return the md5sum and true. Otherwise, return an empty string and false.
-func GetFileAndSum(tftpClient *tftp.Client, IPPort, ipxePodName, ipxeBinaryName, localFileName string,
+func GetFileAndSum(ctx context.Context, tftpClient *tftp.Client, IPPort, ipxePodName, ipxeBinaryName, localFileName string,
 	localFile *os.File) (string, bool) {
-	common.Infof("Opening tftp receive for %s from %s", ipxeBinaryName, IPPort)
+	common.Infof(ctx, "Opening tftp receive for %s from %s", ipxeBinaryName, IPPort)
 	writerTo, err := tftpClient.Receive(ipxeBinaryName, "octet")
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Error receiving file %s via tftp from %s", ipxeBinaryName, IPPort)
-		SyncCloseLocalFile(localFileName, localFile)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error receiving file %s via tftp from %s", ipxeBinaryName, IPPort)
+		SyncCloseLocalFile(ctx, localFileName, localFile)
 		return "", false
 	}
-	common.Infof("Writing remote file data to %s", localFileName)
+	common.Infof(ctx, "Writing remote file data to %s", localFileName)
 	numBytes, err := writerTo.WriteTo(localFile)
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Error with tftp transfer of %s from %s to %s", ipxeBinaryName, IPPort, localFileName)
-		SyncCloseLocalFile(localFileName, localFile)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error with tftp transfer of %s from %s to %s", ipxeBinaryName, IPPort, localFileName)
+		SyncCloseLocalFile(ctx, localFileName, localFile)
 		return "", false
 	}
-	common.Infof("tftp transfer of %s from %s to %s completed (%d bytes)", ipxeBinaryName, IPPort, localFileName,
+	common.Infof(ctx, "tftp transfer of %s from %s to %s completed (%d bytes)", ipxeBinaryName, IPPort, localFileName,
 		numBytes)
-	common.Infof("Closing local file %s", localFileName)
-	if !SyncCloseLocalFile(localFileName, localFile) {
+	common.Infof(ctx, "Closing local file %s", localFileName)
+	if !SyncCloseLocalFile(ctx, localFileName, localFile) {
 		return "", false
 	}
-	return GetMd5sumInIpxePod(ipxePodName, ipxeBinaryName)
+	return GetMd5sumInIpxePod(ctx, ipxePodName, ipxeBinaryName)
 }
 
 // Take the output of the md5sum command, along with the filename of the target file, and extract just the md5sum
 // string. If no errors, return that string and true. Otherwise return an empty string and false.
-func GetMd5sumFromCmdOut(cmdOut, fileName string) (string, bool) {
+func GetMd5sumFromCmdOut(ctx context.Context, cmdOut, fileName string) (string, bool) {
 	reString := fmt.Sprintf("^[0-9a-f]{32}  %s\n$", fileName)
 	md5sumRe, err := regexp.Compile(reString)
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Error compiling regular expression to parse md5sum command output")
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error compiling regular expression to parse md5sum command output")
 		return "", false
 	} else if !md5sumRe.MatchString(cmdOut) {
-		common.Errorf("Command output does not match the expected format for an md5sum command")
+		common.Errorf(ctx, "Command output does not match the expected format for an md5sum command")
 		return "", false
 	}
 	return string(cmdOut[:32]), true
@@ -383,74 +385,74 @@ func GetMd5sumFromCmdOut(cmdOut, fileName string) (string, bool) {
 // Call the md5sum command on the ipxe.efi file in the ipxe pod.
 // Return the md5sum string for the file and true, if no errors.
 // Otherwise return an empty string and false.
-func GetMd5sumInIpxePod(podName, ipxeBinaryName string) (cksum string, ok bool) {
+func GetMd5sumInIpxePod(ctx context.Context, podName, ipxeBinaryName string) (cksum string, ok bool) {
 	ok = false
 	cksum = ""
 	ipxeFilename := IpxePathname + "/" + ipxeBinaryName
 
-	cmdOut, err := RunCommandInIpxeContainer(podName, "md5sum", ipxeFilename)
+	cmdOut, err := RunCommandInIpxeContainer(ctx, podName, "md5sum", ipxeFilename)
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Unable to generate md5sum of %s file in the ipxe container", ipxeFilename)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Unable to generate md5sum of %s file in the ipxe container", ipxeFilename)
 		return
 	}
-	return GetMd5sumFromCmdOut(cmdOut, ipxeFilename)
+	return GetMd5sumFromCmdOut(ctx, cmdOut, ipxeFilename)
 }
 
 // For the specified k8s service name, get its cluster IP, external IP, and port number.
 // Return those and true if no errors. Otherwise return null values and false.
-func GetTftpIPsPort(serviceName string) (clusterIP, externalIP string, port int32, ok bool) {
+func GetTftpIPsPort(ctx context.Context, serviceName string) (clusterIP, externalIP string, port int32, ok bool) {
 	ok = true
 	clusterIP = ""
 	externalIP = ""
 	port = 0
 
-	common.Infof("Looking up k8s service %s in namespace %s", serviceName, common.NAMESPACE)
-	srv, err := k8s.GetService(common.NAMESPACE, serviceName)
+	common.Infof(ctx, "Looking up k8s service %s in namespace %s", serviceName, common.NAMESPACE)
+	srv, err := k8s.GetService(ctx, common.NAMESPACE, serviceName)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		ok = false
 		return
 	}
 
 	if len(srv.Spec.ClusterIP) == 0 {
-		common.Errorf("Unable to determine cluster IP for %s service in namespace %s", serviceName, common.NAMESPACE)
+		common.Errorf(ctx, "Unable to determine cluster IP for %s service in namespace %s", serviceName, common.NAMESPACE)
 		ok = false
 	}
 	clusterIP = srv.Spec.ClusterIP
-	common.Infof("Service %s, namespace %s, cluster IP = %s",
+	common.Infof(ctx, "Service %s, namespace %s, cluster IP = %s",
 		serviceName, common.NAMESPACE, clusterIP)
 
 	// Weirdly, when I run "kubectl get service" to show the service, it shows this as the external IP, but
 	// using the golang client, the ExternalIP field is blank and the IP is stored in the LoadBalancerIP field.
 	if len(srv.Spec.LoadBalancerIP) == 0 {
-		common.Errorf("Unable to determine external IP for %s service in namespace %s", serviceName, common.NAMESPACE)
+		common.Errorf(ctx, "Unable to determine external IP for %s service in namespace %s", serviceName, common.NAMESPACE)
 		return
 	}
 	externalIP = srv.Spec.LoadBalancerIP
-	common.Infof("Service %s, namespace %s, external IP = %s", serviceName, common.NAMESPACE, externalIP)
+	common.Infof(ctx, "Service %s, namespace %s, external IP = %s", serviceName, common.NAMESPACE, externalIP)
 
 	if len(srv.Spec.Ports) < 1 {
-		common.Errorf("No ports defined for service %s in namespace %s", serviceName, common.NAMESPACE)
+		common.Errorf(ctx, "No ports defined for service %s in namespace %s", serviceName, common.NAMESPACE)
 		return
 	}
 	port = srv.Spec.Ports[0].Port
 	if port > 0 {
-		common.Infof("Service %s, namespace %s, port = %d", serviceName, common.NAMESPACE, port)
+		common.Infof(ctx, "Service %s, namespace %s, port = %d", serviceName, common.NAMESPACE, port)
 		ok = true
 	} else {
-		common.Errorf("Invalid port (%d) for service %s in namespace %s", port, serviceName, common.NAMESPACE)
+		common.Errorf(ctx, "Invalid port (%d) for service %s in namespace %s", port, serviceName, common.NAMESPACE)
 	}
 
 	if len(srv.Spec.Ports) > 1 {
-		common.Warnf("%d ports defined for service %s in namespace %s", len(srv.Spec.Ports), serviceName,
+		common.Warnf(ctx, "%d ports defined for service %s in namespace %s", len(srv.Spec.Ports), serviceName,
 			common.NAMESPACE)
 		for _, extraport := range srv.Spec.Ports[1:] {
 			if extraport.Port > 0 {
-				common.Warnf("Service %s, namespace %s, extra port = %d", serviceName, common.NAMESPACE,
+				common.Warnf(ctx, "Service %s, namespace %s, extra port = %d", serviceName, common.NAMESPACE,
 					extraport.Port)
 			} else {
-				common.Errorf("Invalid extra port (%d) for service %s in namespace %s", extraport.Port, serviceName,
+				common.Errorf(ctx, "Invalid extra port (%d) for service %s in namespace %s", extraport.Port, serviceName,
 					common.NAMESPACE)
 				ok = false
 			}
@@ -462,81 +464,82 @@ func GetTftpIPsPort(serviceName string) (clusterIP, externalIP string, port int3
 // Perform a tftp get of the ipxe.efi file from the specified IP address and port.
 // Verify via md5sum that the received file matches the remote file
 // If error, return false, otherwise return true.
-func TftpIPPortFileTransferTest(IPPort, ipxePodName, ipxeBinaryName string) bool {
+func TftpIPPortFileTransferTest(ctx context.Context, IPPort, ipxePodName, ipxeBinaryName string) (passed bool) {
+	defer common.StartSubtest(ctx, "TftpIPPortFileTransferTest").End(ctx, &passed)
 	var remoteSumBefore, remoteSumAfter, localSum, localFileName string
 	var ok bool
 	var localFile *os.File
 
-	common.Infof("Initializing tftp client for %s", IPPort)
+	common.Infof(ctx, "Initializing tftp client for %s", IPPort)
 	tftpClient, err := tftp.NewClient(IPPort)
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Unable to initialize tftp client for %s", IPPort)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Unable to initialize tftp client for %s", IPPort)
 		return false
 	}
 
 	localFileName = fmt.Sprintf("/tmp/cmsdev-ipxetftp-%s-%s.tmp", common.AlnumString(6), ipxeBinaryName)
-	localFile, ok = OpenLocalFile(localFileName)
+	localFile, ok = OpenLocalFile(ctx, localFileName)
 	if !ok {
 		return false
 	}
-	defer RemoveLocalFile(localFileName)
+	defer RemoveLocalFile(ctx, localFileName)
 
 	// The file is periodically re-generated, meaning that if we just take the md5sum and transfer the file,
 	// we may not have recorded the right sum. Therefore we must take the sum both before and after the transfer.
 	// If they do not match, we must repeat the process until they do
 
-	remoteSumBefore, ok = GetMd5sumInIpxePod(ipxePodName, ipxeBinaryName)
+	remoteSumBefore, ok = GetMd5sumInIpxePod(ctx, ipxePodName, ipxeBinaryName)
 	if !ok {
-		SyncCloseLocalFile(localFileName, localFile)
+		SyncCloseLocalFile(ctx, localFileName, localFile)
 		return false
 	}
-	remoteSumAfter, ok = GetFileAndSum(tftpClient, IPPort, ipxePodName, ipxeBinaryName, localFileName, localFile)
+	remoteSumAfter, ok = GetFileAndSum(ctx, tftpClient, IPPort, ipxePodName, ipxeBinaryName, localFileName, localFile)
 	if !ok {
 		return false
 	}
 	for remoteSumBefore != remoteSumAfter {
-		common.Infof("Remote file changed around the time of our transfer. Must redo transfer.")
-		localFile, ok = OpenLocalFile(localFileName)
+		common.Infof(ctx, "Remote file changed around the time of our transfer. Must redo transfer.")
+		localFile, ok = OpenLocalFile(ctx, localFileName)
 		if !ok {
 			return false
 		}
 		remoteSumBefore = remoteSumAfter
-		remoteSumAfter, ok = GetFileAndSum(tftpClient, IPPort, ipxePodName, ipxeBinaryName, localFileName, localFile)
+		remoteSumAfter, ok = GetFileAndSum(ctx, tftpClient, IPPort, ipxePodName, ipxeBinaryName, localFileName, localFile)
 		if !ok {
 			return false
 		}
 	}
 
-	common.Infof("Remote file did not change around the time of our transfer, proceeding")
+	common.Infof(ctx, "Remote file did not change around the time of our transfer, proceeding")
 	// Now verify that the md5sum of the local file matches
 	path, err := exec.LookPath("md5sum")
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Unable to local path to md5sum locally")
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Unable to local path to md5sum locally")
 		return false
 	}
 
 	cmd := exec.Command(path, localFileName)
-	common.Infof("Running command: %s", cmd)
+	common.Infof(ctx, "Running command: %s", cmd)
 	cmdOut, err := cmd.CombinedOutput()
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Error determining md5sum of local file %s", localFileName)
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error determining md5sum of local file %s", localFileName)
 		return false
 	} else if len(cmdOut) == 0 {
-		common.Errorf("Blank output from md5sum command. Error determining md5sum of local file %s", localFileName)
+		common.Errorf(ctx, "Blank output from md5sum command. Error determining md5sum of local file %s", localFileName)
 		return false
 	}
-	localSum, ok = GetMd5sumFromCmdOut(string(cmdOut), localFileName)
+	localSum, ok = GetMd5sumFromCmdOut(ctx, string(cmdOut), localFileName)
 	if !ok {
 		return false
 	} else if localSum != remoteSumAfter {
-		common.Errorf("md5sum of remote file (%s) does not match that of received file (%s)", remoteSumAfter, localSum)
+		common.Errorf(ctx, "md5sum of remote file (%s) does not match that of received file (%s)", remoteSumAfter, localSum)
 		return false
 	}
-	common.Infof("md5sum of received file matches that of remote file")
+	common.Infof(ctx, "md5sum of received file matches that of remote file")
 
-	common.Infof("tftp receive test succeeded from %s", IPPort)
+	common.Infof(ctx, "tftp receive test succeeded from %s", IPPort)
 	return true
 }
@@ -0,0 +1,117 @@
+//
+//  MIT License
+//
+//  (C) Copyright 2026 Hewlett Packard Enterprise Development LP
+//
+//  Permission is hereby granted, free of charge, to any person obtaining a
+//  copy of this software and associated documentation files (the "Software"),
+//  to deal in the Software without restriction, including without limitation
+//  the rights to use, copy, modify, merge, publish, distribute, sublicense,
+//  and/or sell copies of the Software, and to permit persons to whom the
+//  Software is furnished to do so, subject to the following conditions:
+//
+//  The above copyright notice and this permission notice shall be included
+//  in all copies or substantial portions of the Software.
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+//  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+//  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+//  THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+//  OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+//  ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+//  OTHER DEALINGS IN THE SOFTWARE.
+/*
+ * leftovers.go
+ *
+ * Finding vcs organizations and repositories left behind by earlier test runs
+ *
+ */
+
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
+)
+
+// The names generated by repoTest for the organizations and repositories it creates
+var vcsOrgNamePattern = regexp.MustCompile(`^test-cmsdev-[A-Za-z0-9]{8}$`)
+var vcsRepoNamePattern = regexp.MustCompile(`^harf-[A-Za-z0-9]{8}$`)
+
+// Number of items requested per page when listing vcs organizations and repositories
+const vcsPageLimit = 50
+
+// The fields we need from vcs organization and repository records. Depending on the Gitea
+// version, the organization name is in the username field, the name field, or both.
+type vcsNamedRecord struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+func (record vcsNamedRecord) name() string {
+	if len(record.Username) > 0 {
+		return record.Username
+	}
+	return record.Name
+}
+
+// List the names of all of the records from a paginated vcs list request
+func vcsListNames(ctx context.Context, requestUri string) (names []string, ok bool) {
+	for page := 1; ; page++ {
+		var records []vcsNamedRecord
+		resp, ok := vcsRequestResponse(ctx, "GET", fmt.Sprintf("%s?page=%d&limit=%d", requestUri, page, vcsPageLimit), "", http.StatusOK)
+		if !ok {
+			return nil, false
+		} else if err := json.Unmarshal(resp.Body(), &records); err != nil {
+			common.Errorf(ctx, "Error decoding response from GET %s: %v", requestUri, err)
+			return nil, false
+		}
+		for _, record := range records {
+			names = append(names, record.name())
+		}
+		if len(records) < vcsPageLimit {
+			return names, true
+		}
+	}
+}
+
+// Find the vcs organizations created by cmsdev, and the repositories created in them. The
+// repositories are listed before their organizations, since an organization cannot be
+// deleted while it has repositories.
+func findVCSLeftovers(opts registry.CleanupOptions) (leftovers []registry.Leftover, ok bool) {
+	ctx := opts.Context
+	orgNames, ok := vcsListNames(ctx, "/orgs")
+	if !ok {
+		return
+	}
+	for _, orgName := range orgNames {
+		if !vcsOrgNamePattern.MatchString(orgName) {
+			continue
+		}
+		orgUri := "/orgs/" + orgName
+		if repoNames, listed := vcsListNames(ctx, orgUri+"/repos"); !listed {
+			ok = false
+		} else {
+			for _, repoName := range repoNames {
+				if vcsRepoNamePattern.MatchString(repoName) {
+					repoUri := "/repos/" + orgName + "/" + repoName
+					leftovers = append(leftovers, registry.Leftover{
+						Kind: vcsRepoKind, Name: orgName + "/" + repoName,
+						Delete: func() bool { return deleteVCSResource(ctx, repoUri) },
+					})
+				}
+			}
+		}
+		leftovers = append(leftovers, registry.Leftover{
+			Kind: vcsOrgKind, Name: orgName,
+			Delete: func() bool { return deleteVCSResource(ctx, orgUri) },
+		})
+	}
+	return
+}
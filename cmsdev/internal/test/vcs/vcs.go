@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2019-2023 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2019-2023, 2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,9 +29,11 @@ package vcs
  */
 
 import (
+	"context"
 	"regexp"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/test"
 	"strings"
 
@@ -44,21 +46,69 @@ type backupPod struct {
 	Status  string
 }
 
-func IsVCSRunning() (passed bool) {
+// Subtest names, for use with the --only and --skip options
+var vcsSubtests = []string{"vcs.pods", "vcs.repo", "vcs.clone", "vcs.clone-deleted"}
+
+func init() {
+	registry.Register(registry.ServiceTest{
+		Name:           "vcs",
+		Aliases:        []string{"gitea"},
+		Description:    "Version Control Service (Gitea): pods, PVCs, database backups, org and repo operations",
+		DefaultTimeout: 300,
+		Subtests:       vcsSubtests,
+		// The pods are checked, and the VCS credentials are read from a Kubernetes secret
+		ClusterSubtests: vcsSubtests,
+		Run: func(opts registry.RunOptions) bool {
+			return IsVCSRunning(opts.Context)
+		},
+		FindLeftovers: findVCSLeftovers,
+	})
+}
+
+func IsVCSRunning(ctx context.Context) (passed bool) {
+	var podNames, pvcNames []string
+	var ok bool
+
+	passed = true
+	if !registry.RunSubtest(ctx, "vcs.pods", func(ctx context.Context) bool { podNames, pvcNames, ok = checkVCSPods(ctx); return ok }) {
+		passed = false
+	}
+
+	if !passed {
+		common.ArtifactsKubernetes(ctx)
+		if len(podNames) > 0 {
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, podNames)
+		}
+		if len(pvcNames) > 0 {
+			common.ArtifactDescribeNamespacePods(ctx, common.NAMESPACE, pvcNames)
+		}
+	}
+
+	// The clone subtests are run as part of the repo subtest
+	if !registry.RunSubtestNeededBy(ctx, "vcs.repo", []string{"vcs.clone", "vcs.clone-deleted"}, repoTest) {
+		passed = false
+	}
+
+	return
+}
+
+// Check the status of the VCS pods, PVCs, and database backups. Returns the pod and PVC names,
+// and true if they all look okay.
+func checkVCSPods(ctx context.Context) (podNames, pvcNames []string, passed bool) {
 	var latestBackupPod backupPod
 
 	passed = true
-	// We expect to find 2 or more gitea-vcs pods (one main pod, at least one postgres pod,
+	// By default we expect to find 2 or more gitea-vcs pods (one main pod, at least one postgres pod,
 	// and one or more postgres backup pod)
-	podNames, ok := test.GetPodNamesByPrefixKey("vcs", 2, -1)
+	podNames, ok := test.GetPodNamesByPrefixKey(ctx, "vcs")
 	if !ok {
 		passed = false
 	}
-	common.Infof("Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
+	common.Infof(ctx, "Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
 
-	pvcNames := make([]string, 0, 4)
+	pvcNames = make([]string, 0, 4)
 	pvcNames = append(pvcNames, "gitea-vcs-data-claim")
-	if !test.CheckPVCStatus(pvcNames[0]) {
+	if !test.CheckPVCStatus(ctx, pvcNames[0]) {
 		passed = false
 	}
 	// In addition to the main gitea-vcs pod (which should be in Running state), we expect:
@@ -79,31 +129,31 @@ func IsVCSRunning() (passed bool) {
 			postgresPodCount += 1
 			// There should be a corresponding pvc
 			pvcName := "pgdata-" + podName
-			common.Infof("There should be a corresponding pvc with name %s", pvcName)
+			common.Infof(ctx, "There should be a corresponding pvc with name %s", pvcName)
 			pvcNames = append(pvcNames, pvcName)
-			if !test.CheckPVCStatus(pvcName) {
+			if !test.CheckPVCStatus(ctx, pvcName) {
 				passed = false
 			}
 		} else if strings.HasPrefix(podName, "logical-backup-gitea-vcs-postgres-") {
-			common.Infof("checking pod start time for %s", podName)
-			podStarted, err := k8s.GetPodStartTime(common.NAMESPACE, podName)
+			common.Infof(ctx, "checking pod start time for %s", podName)
+			podStarted, err := k8s.GetPodStartTime(ctx, common.NAMESPACE, podName)
 			if err != nil {
-				common.VerboseFailedf(err.Error())
+				common.VerboseFailedf(ctx, "%s", err.Error())
 				passed = false
 				continue
 			}
-			common.Infof("Pod start time is %v", podStarted)
-			common.Infof("checking pod status for %s", podName)
-			podStatus, err := k8s.GetPodStatus(common.NAMESPACE, podName)
+			common.Infof(ctx, "Pod start time is %v", podStarted)
+			common.Infof(ctx, "checking pod status for %s", podName)
+			podStatus, err := k8s.GetPodStatus(ctx, common.NAMESPACE, podName)
 			if err != nil {
-				common.VerboseFailedf(err.Error())
+				common.VerboseFailedf(ctx, "%s", err.Error())
 				passed = false
 				continue
 			}
 			if podStatus != "Succeeded" && podStatus != "Running" && podStatus != "Pending" {
-				common.Warnf("Pod %s status is %s", podName, podStatus)
+				common.Warnf(ctx, "Pod %s status is %s", podName, podStatus)
 			} else {
-				common.Infof("Pod %s status is %s", podName, podStatus)
+				common.Infof(ctx, "Pod %s status is %s", podName, podStatus)
 			}
 			if latestBackupPod.Name == "" || podStarted.After(latestBackupPod.Started.Time) {
 				latestBackupPod.Name = podName
@@ -114,82 +164,68 @@ func IsVCSRunning() (passed bool) {
 		} else {
 			mainPodCount += 1
 		}
-		common.Infof("checking pod status for %s expecting %s", podName, expectedStatus)
-		status, err := k8s.GetPodStatus(common.NAMESPACE, podName)
+		common.Infof(ctx, "checking pod status for %s expecting %s", podName, expectedStatus)
+		status, err := k8s.GetPodStatus(ctx, common.NAMESPACE, podName)
 		if err != nil {
-			common.VerboseFailedf(err.Error())
+			common.VerboseFailedf(ctx, "%s", err.Error())
 			passed = false
 			continue
 		}
-		common.Infof("Pod status is %s", status)
+		common.Infof(ctx, "Pod status is %s", status)
 		if status == "Succeeded" && expectedStatus != "Succeeded" {
 			if !listedPodsOnError {
-				common.Printf("Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
+				common.Printf(ctx, "Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
 				listedPodsOnError = true
 			}
-			common.Warnf("Pod %s has status %s", podName, status)
+			common.Warnf(ctx, "Pod %s has status %s", podName, status)
 		} else if status != expectedStatus {
 			if !listedPodsOnError {
-				common.Printf("Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
+				common.Printf(ctx, "Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
 				listedPodsOnError = true
 			}
-			common.VerboseFailedf("expected status=%s, found status=%s for podName=%s", expectedStatus, status, podName)
+			common.VerboseFailedf(ctx, "expected status=%s, found status=%s for podName=%s", expectedStatus, status, podName)
 			passed = false
 		} else {
-			common.VerboseOkay()
+			common.VerboseOkay(ctx)
 		}
 	}
 	if mainPodCount != 1 {
 		if !listedPodsOnError {
-			common.Printf("Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
+			common.Printf(ctx, "Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
 			listedPodsOnError = true
 		}
-		common.VerboseFailedf("Expected exactly 1 main gitea-vcs- pod but found %d", mainPodCount)
+		common.VerboseFailedf(ctx, "Expected exactly 1 main gitea-vcs- pod but found %d", mainPodCount)
 		passed = false
 	}
 	if postgresPodCount < 1 {
 		if !listedPodsOnError {
-			common.Printf("Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
+			common.Printf(ctx, "Found %d vcs pods: %s", len(podNames), strings.Join(podNames, ", "))
 			listedPodsOnError = true
 		}
-		common.VerboseFailedf("Expected at least 1 gitea-vcs-postgres pod but found %d", postgresPodCount)
+		common.VerboseFailedf(ctx, "Expected at least 1 gitea-vcs-postgres pod but found %d", postgresPodCount)
 		passed = false
 	}
 
 	// Verify if postgres backup k8s cronjob exists
-	err := k8s.VerifyCronJobExists(common.NAMESPACE, "logical-backup-gitea-vcs-postgres")
+	err := k8s.VerifyCronJobExists(ctx, common.NAMESPACE, "logical-backup-gitea-vcs-postgres")
 	if err == nil {
-		common.Infof("kubernetes CronJob found in namespace %s with name %s", common.NAMESPACE, "logical-backup-gitea-vcs-postgres")
+		common.Infof(ctx, "kubernetes CronJob found in namespace %s with name %s", common.NAMESPACE, "logical-backup-gitea-vcs-postgres")
 	} else {
-		common.VerboseFailedf(err.Error())
+		common.VerboseFailedf(ctx, "%s", err.Error())
 		passed = false
 	}
 
 	if latestBackupPod.Name == "" {
-		common.Warnf("No logical-backup-gitea-vcs-postgres pods found -- db has not yet been backed up")
+		common.Warnf(ctx, "No logical-backup-gitea-vcs-postgres pods found -- db has not yet been backed up")
 	} else if latestBackupPod.Status == "Succeeded" {
-		common.Infof("The latest gitea-vcs-postgresql-db backup pod (%s) completed successfully", latestBackupPod.Name)
+		common.Infof(ctx, "The latest gitea-vcs-postgresql-db backup pod (%s) completed successfully", latestBackupPod.Name)
 	} else if latestBackupPod.Status == "Running" || latestBackupPod.Status == "Pending" {
-		common.VerboseFailedf("The most recent logical-backup-gitea-vcs-postgres pod (%s) has not yet completed; re-run this test after it is done", latestBackupPod.Name)
+		common.VerboseFailedf(ctx, "The most recent logical-backup-gitea-vcs-postgres pod (%s) has not yet completed; re-run this test after it is done", latestBackupPod.Name)
 	} else if latestBackupPod.Status == "Failed" {
-		common.VerboseFailedf("The most recent logical-backup-gitea-vcs-postgres pod (%s) failed", latestBackupPod.Name)
+		common.VerboseFailedf(ctx, "The most recent logical-backup-gitea-vcs-postgres pod (%s) failed", latestBackupPod.Name)
 		passed = false
 	} else {
-		common.VerboseFailedf("The most recent logical-backup-gitea-vcs-postgres pod (%s) has an unexpected status (%s)", latestBackupPod.Name, latestBackupPod.Status)
-		passed = false
-	}
-
-	if !passed {
-		common.ArtifactsKubernetes()
-		if len(podNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, podNames)
-		}
-		if len(pvcNames) > 0 {
-			common.ArtifactDescribeNamespacePods(common.NAMESPACE, pvcNames)
-		}
-	}
-
-	if !repoTest() {
+		common.VerboseFailedf(ctx, "The most recent logical-backup-gitea-vcs-postgres pod (%s) has an unexpected status (%s)", latestBackupPod.Name, latestBackupPod.Status)
 		passed = false
 	}
 
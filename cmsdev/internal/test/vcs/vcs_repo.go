@@ -1,6 +1,6 @@
 // MIT License
 //
-// (C) Copyright 2020-2025 Hewlett Packard Enterprise Development LP
+// (C) Copyright 2020-2026 Hewlett Packard Enterprise Development LP
 //
 // Permission is hereby granted, free of charge, to any person obtaining a
 // copy of this software and associated documentation files (the "Software"),
@@ -29,19 +29,20 @@ package vcs
  */
 
 import (
-	"crypto/tls"
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
-	"time"
 
 	resty "gopkg.in/resty.v1"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/common"
 	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/k8s"
+	"stash.us.cray.com/SCMS/cms-tools/cmsdev/internal/lib/registry"
 )
 
-const VCSURL = common.BASEURL + "/vcs/api/v1"
+// The VCS API URL depends on the configured API gateway
+func vcsUrl() string { return common.BASEURL + "/vcs/api/v1" }
 
 var useInsecure bool = false
 
@@ -69,7 +70,7 @@ func (gitRepo *GitRepo) GitCmdEnvVars() map[string]string {
 
 // When running git commands, just like with the vcs requests we want to retry them insecurely
 // if needed
-func (gitRepo *GitRepo) runGitCmd(shouldPass bool, cmdArgs ...string) bool {
+func (gitRepo *GitRepo) runGitCmd(ctx context.Context, shouldPass bool, cmdArgs ...string) bool {
 	var cmdResult *common.CommandResult
 	var err error
 	var tryInsecure bool
@@ -85,30 +86,30 @@ func (gitRepo *GitRepo) runGitCmd(shouldPass bool, cmdArgs ...string) bool {
 		// variadic cmdArgs argument
 		newCmdArgs = make([]string, 0, 2+len(cmdArgs))
 		newCmdArgs = append(append(newCmdArgs, "-c", "http.sslVerify=false"), cmdArgs...)
-		return runCmdWithEnv(shouldPass, gitRepo.GitCmdEnvVars(), "git", newCmdArgs...)
+		return runCmdWithEnv(ctx, shouldPass, gitRepo.GitCmdEnvVars(), "git", newCmdArgs...)
 	} else if !useInsecure {
-		cmdResult, err = common.RunNameWithEnv(gitRepo.GitCmdEnvVars(), "git", cmdArgs...)
+		cmdResult, err = common.RunNameWithEnv(ctx, gitRepo.GitCmdEnvVars(), "git", cmdArgs...)
 		if err != nil {
-			common.Error(err)
-			common.Errorf("Error attempting to run command")
+			common.Error(ctx, err)
+			common.Errorf(ctx, "Error attempting to run command")
 			return false
 		} else if cmdResult.Rc == 0 {
 			return true
 		}
 		// Check to see if this may be an SSL error
 		if strings.Contains(cmdResult.ErrString(), "SSL") {
-			common.Infof("Command failure may be an SSL issue. Will retry insecurely.")
-			common.Infof("Important: This means the overall test will fail even if this command works on retry!")
+			common.Infof(ctx, "Command failure may be an SSL issue. Will retry insecurely.")
+			common.Infof(ctx, "Important: This means the overall test will fail even if this command works on retry!")
 			tryInsecure = true
 		} else {
-			common.Errorf("Command failed")
+			common.Errorf(ctx, "Command failed")
 			return false
 		}
 	}
 	// Run the command insecurely
 	newCmdArgs = make([]string, 0, 2+len(cmdArgs))
 	newCmdArgs = append(append(newCmdArgs, "-c", "http.sslVerify=false"), cmdArgs...)
-	ok := runCmdWithEnv(shouldPass, gitRepo.GitCmdEnvVars(), "git", newCmdArgs...)
+	ok := runCmdWithEnv(ctx, shouldPass, gitRepo.GitCmdEnvVars(), "git", newCmdArgs...)
 	if ok && tryInsecure {
 		// Let's remember that we had to run this command insecurely in order for it to work, so that
 		// we'll be insecure for our future git commands in this test
@@ -118,124 +119,92 @@ func (gitRepo *GitRepo) runGitCmd(shouldPass bool, cmdArgs ...string) bool {
 }
 
 // Perform a VCS request of the specified type to the specified uri, with the specified JSON data (if any)
-// If the request fails, it is retried with authentication disabled.
+// If the certificate of the server cannot be verified, the request is retried insecurely (see common.Restful).
 // Verify that the request succeeded and that the response had the expected status code
 // Log any errors found
-// Returns true if the request worked as expected (either originally or on unauthenticated retry)
+// Returns true if the request worked as expected (either originally or on insecure retry)
 // Otherwise returns false
-func vcsRequest(requestType, requestUri, jsonString string, expectedStatusCode int) (ok bool) {
-	var resp *resty.Response
-	var dataArray []byte
-	var tryInsecure bool
+func vcsRequest(ctx context.Context, requestType, requestUri, jsonString string, expectedStatusCode int) (ok bool) {
+	_, ok = vcsRequestResponse(ctx, requestType, requestUri, jsonString, expectedStatusCode)
+	return
+}
 
+// Like vcsRequest, but also returns the response
+func vcsRequestResponse(ctx context.Context, requestType, requestUri, jsonString string, expectedStatusCode int) (resp *resty.Response, ok bool) {
 	ok = false
-	tryInsecure = false
 
 	// Get vcs user and password
-	common.Debugf("Getting vcs user and password")
-	vcsUser, vcsPass, err := k8s.GetVcsUsernamePassword()
+	common.Debugf(ctx, "Getting vcs user and password")
+	vcsUser, vcsPass, err := k8s.GetVcsUsernamePassword(ctx)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		return
 	}
 
-	client := resty.New()
-	client.SetTimeout(common.API_TIMEOUT_SECONDS)
-	client.SetRetryCount(common.API_RETRY_COUNT)
-	client.SetRetryWaitTime(common.API_RETRY_WAIT_SECONDS * time.Second)
-	client.SetHeader("Content-Type", "application/json")
-	client.SetBasicAuth(vcsUser, vcsPass)
-
-	// Add retry condition for HTTP 503 status code
-	client.AddRetryCondition(func(r *resty.Response) (bool, error) {
-		if r.StatusCode() == 503 {
-			fmt.Printf("Received HTTP code 503 from server, Waiting for: %d seconds before retry\n", common.API_RETRY_WAIT_SECONDS)
-			return true, nil
-		}
-		return false, nil
-	})
-	requestUrl := VCSURL + requestUri
-
-	for true {
-		if useInsecure || tryInsecure {
-			common.Infof("InsecureSkipVerify=true %s %s", requestType, requestUrl)
-			client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
-		} else {
-			common.Infof("%s %s", requestType, requestUrl)
-		}
-		if len(jsonString) > 0 {
-			dataArray = []byte(jsonString)
-			common.Debugf("data: %s", jsonString)
-		}
-		if requestType == "DELETE" {
-			if len(jsonString) > 0 {
-				resp, err = client.R().SetBody(dataArray).Delete(requestUrl)
-			} else {
-				resp, err = client.R().Delete(requestUrl)
-			}
-		} else if requestType == "GET" {
-			if len(jsonString) > 0 {
-				resp, err = client.R().SetBody(dataArray).Get(requestUrl)
-			} else {
-				resp, err = client.R().Get(requestUrl)
-			}
-		} else if requestType == "POST" {
-			if len(jsonString) > 0 {
-				resp, err = client.R().SetBody(dataArray).Post(requestUrl)
-			} else {
-				resp, err = client.R().Post(requestUrl)
-			}
-		} else {
-			common.Errorf("PROGRAMMING LOGIC ERROR: Invalid request type: %s", requestType)
-			return
-		}
-		common.PrettyPrintJSON(resp)
-
-		if err != nil {
-			common.Error(err)
-			if useInsecure || tryInsecure {
-				// Failed even with verify set to false
-				return
-			}
-			common.Infof("This is a failure, but will retry request with InsecureSkipVerify set to true")
-			common.Infof("Important: This means the overall test will fail even if this request works on retry!")
-			client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
-			tryInsecure = true
-			continue
-		}
-		break
+	requestUrl := vcsUrl() + requestUri
+	if requestType != "DELETE" && requestType != "GET" && requestType != "POST" {
+		common.Errorf(ctx, "PROGRAMMING LOGIC ERROR: Invalid request type: %s", requestType)
+		return
+	}
+	common.Infof(ctx, "%s %s", requestType, requestUrl)
+	options := []common.RequestOption{common.WithBasicAuth(vcsUser, vcsPass)}
+	if len(jsonString) > 0 {
+		common.Debugf(ctx, "data: %s", jsonString)
+		// The body is sent with every request type, so set it the same way for all of them
+		options = append(options, common.WithBody([]byte(jsonString)))
 	}
+	resp, err = common.Restful(ctx, requestType, requestUrl, common.Params{}, options...)
+	if common.UsedInsecureFallback(ctx) {
+		// Let's remember that we had to use insecure in order for this to work, so that
+		// we'll be insecure for our future git commands in this test
+		useInsecure = true
+	}
+	if err != nil {
+		common.Error(ctx, err)
+		return
+	}
+	common.PrettyPrintJSON(ctx, resp)
 
 	if resp.StatusCode() != expectedStatusCode {
-		common.Errorf("%s %s: expected status code %d, got %d", requestType, requestUrl, expectedStatusCode, resp.StatusCode())
+		common.Errorf(ctx, "%s %s: expected status code %d, got %d", requestType, requestUrl, expectedStatusCode, resp.StatusCode())
 		return
 	}
-	common.Infof("%s %s: expected status code %d and got it", requestType, requestUrl, expectedStatusCode)
-	if tryInsecure {
-		// Let's remember that we had to use insecure in order for this to work, so we won't bother
-		// trying securely for future requests in this test
-		useInsecure = true
-	}
+	common.Infof(ctx, "%s %s: expected status code %d and got it", requestType, requestUrl, expectedStatusCode)
 	ok = true
 	return
 }
 
 // Wrapper for vcs delete calls. In this test, these calls never include JSON data and
 // always expect StatusNoContent, so those are set appropriately.
-func vcsDelete(requestUri string) bool {
-	return vcsRequest("DELETE", requestUri, "", http.StatusNoContent)
+func vcsDelete(ctx context.Context, requestUri string) bool {
+	return vcsRequest(ctx, "DELETE", requestUri, "", http.StatusNoContent)
 }
 
 // Wrapper for vcs get calls. In this test, these calls never include JSON data, so
 // that is set to an empty string
-func vcsGet(requestUri string, expectedStatusCode int) bool {
-	return vcsRequest("GET", requestUri, "", expectedStatusCode)
+func vcsGet(ctx context.Context, requestUri string, expectedStatusCode int) bool {
+	return vcsRequest(ctx, "GET", requestUri, "", expectedStatusCode)
 }
 
 // Wrapper for vcs delete calls. In this test, these calls always expect StatusCreated,
 // so that is set appropriately.
-func vcsPost(requestUri, jsonString string) bool {
-	return vcsRequest("POST", requestUri, jsonString, http.StatusCreated)
+func vcsPost(ctx context.Context, requestUri, jsonString string) bool {
+	return vcsRequest(ctx, "POST", requestUri, jsonString, http.StatusCreated)
+}
+
+// Kinds of vcs resources, for the cleanup registry
+const vcsOrgKind = "VCS organization"
+const vcsRepoKind = "VCS repository"
+
+// Delete a vcs organization or repository. A failed delete still counts as success if
+// the resource is not found afterwards.
+func deleteVCSResource(ctx context.Context, requestUri string) bool {
+	return vcsDelete(ctx, requestUri) || vcsGet(ctx, requestUri, http.StatusNotFound)
+}
+
+// Register a cleanup for a vcs organization or repository created by this test
+func registerVCSCleanup(ctx context.Context, kind, name, requestUri string) {
+	common.RegisterCleanup(ctx, kind, name, func() bool { return deleteVCSResource(ctx, requestUri) })
 }
 
 // Does the following:
@@ -249,61 +218,63 @@ func vcsPost(requestUri, jsonString string) bool {
 // 8) Performs the cloneTest() function on the repo and verifies it fails to clone
 // 9) Deletes the new org via API
 // 10) Queries the new org via API to verify it is not found
-func repoTest() (passed bool) {
+func repoTest(ctx context.Context) (passed bool) {
 	var vcsUser, vcsPass string
 
 	passed = true
 
 	// Attempt to create a temporary organization
 	orgName := "test-cmsdev-" + common.AlnumString(8)
-	common.Infof("Create vcs organization named %s", orgName)
+	common.Infof(ctx, "Create vcs organization named %s", orgName)
 	orgDataJsonString := fmt.Sprintf(
 		`{ "username": "%s", "visibility": "public", "description": "Test org created by cmsdev" }`,
 		orgName)
-	if ok := vcsPost("/orgs", orgDataJsonString); ok {
-		common.Infof("Org created successfully")
+	orgUri := "/orgs/" + orgName
+	if ok := vcsPost(ctx, "/orgs", orgDataJsonString); ok {
+		common.Infof(ctx, "Org created successfully")
+		registerVCSCleanup(ctx, vcsOrgKind, orgName, orgUri)
 	} else {
 		passed = false
-		common.Errorf("Failed to create vcs organization")
+		common.Errorf(ctx, "Failed to create vcs organization")
 		return
 	}
 
 	// Query new org
-	orgUri := "/orgs/" + orgName
-	common.Infof("Query new vcs org")
-	if ok := vcsGet(orgUri, http.StatusOK); ok {
-		common.Infof("Successfully queried new vcs org")
+	common.Infof(ctx, "Query new vcs org")
+	if ok := vcsGet(ctx, orgUri, http.StatusOK); ok {
+		common.Infof(ctx, "Successfully queried new vcs org")
 	} else {
 		passed = false
-		common.Errorf("Failed to query vcs organization")
+		common.Errorf(ctx, "Failed to query vcs organization")
 		// Despite the query failure, we will proceed with the test, since the
 		// create request did appear to work
 	}
 
 	// Attempt to create a repo in our org
 	repoName := "harf-" + common.AlnumString(8)
-	common.Infof("Create vcs repository named %s in organization %s", repoName, orgName)
+	common.Infof(ctx, "Create vcs repository named %s in organization %s", repoName, orgName)
 	repoDataJsonString := fmt.Sprintf(
 		`{ "name": "%s", "auto_init": null, "description": "Test repo created by cmsdev", "gitignores": null, "license": null, "private": false, "readme": null }`,
 		repoName)
-	if ok := vcsPost("/org/"+orgName+"/repos", repoDataJsonString); ok {
-		common.Infof("Repo created successfully")
+	repoUri := "/repos/" + orgName + "/" + repoName
+	if ok := vcsPost(ctx, "/org/"+orgName+"/repos", repoDataJsonString); ok {
+		common.Infof(ctx, "Repo created successfully")
+		registerVCSCleanup(ctx, vcsRepoKind, orgName+"/"+repoName, repoUri)
 	} else {
 		passed = false
-		common.Errorf("Failed to create vcs repo")
-		common.Infof("Try to delete org")
-		vcsDelete(orgUri)
+		common.Errorf(ctx, "Failed to create vcs repo")
+		common.Infof(ctx, "Try to delete org")
+		vcsDelete(ctx, orgUri)
 		return
 	}
 
 	// Verify we can query new repo via API
-	repoUri := "/repos/" + orgName + "/" + repoName
-	common.Infof("Query new vcs repo")
-	if ok := vcsGet(repoUri, http.StatusOK); ok {
-		common.Infof("Successfully queried new vcs repo")
+	common.Infof(ctx, "Query new vcs repo")
+	if ok := vcsGet(ctx, repoUri, http.StatusOK); ok {
+		common.Infof(ctx, "Successfully queried new vcs repo")
 	} else {
 		passed = false
-		common.Errorf("Failed to query vcs repo")
+		common.Errorf(ctx, "Failed to query vcs repo")
 		// Despite the query failure, we will proceed with the test, since the
 		// create request did appear to work
 	}
@@ -314,73 +285,75 @@ func repoTest() (passed bool) {
 	gitRepo.RepoName = repoName
 
 	// Get vcs user and password
-	common.Debugf("Getting vcs user and password")
-	vcsUser, vcsPass, err := k8s.GetVcsUsernamePassword()
+	common.Debugf(ctx, "Getting vcs user and password")
+	vcsUser, vcsPass, err := k8s.GetVcsUsernamePassword(ctx)
 	if err != nil {
-		common.Error(err)
+		common.Error(ctx, err)
 		passed = false
-		common.Infof("Unable to perform clone test without vcs user credentials")
+		common.Infof(ctx, "Unable to perform clone test without vcs user credentials")
 	} else {
 		gitRepo.Username = vcsUser
 		gitRepo.Password = vcsPass
-		if !gitRepo.cloneTest() {
+		if !registry.RunSubtest(ctx, "vcs.clone", gitRepo.cloneTest) {
 			passed = false
 		}
 	}
 
 	// Delete repo
-	common.Infof("Delete repo %s", repoName)
-	if ok := vcsDelete(repoUri); ok {
-		common.Infof("Successfully deleted vcs repo")
+	common.Infof(ctx, "Delete repo %s", repoName)
+	if ok := vcsDelete(ctx, repoUri); ok {
+		common.Infof(ctx, "Successfully deleted vcs repo")
+		common.ResourceDeleted(ctx, vcsRepoKind, orgName+"/"+repoName)
 	} else {
 		passed = false
-		common.Errorf("Failed to delete vcs repo")
-		common.Infof("Try to delete org anyway")
-		vcsDelete(orgUri)
+		common.Errorf(ctx, "Failed to delete vcs repo")
+		common.Infof(ctx, "Try to delete org anyway")
+		vcsDelete(ctx, orgUri)
 		return
 	}
 
 	// Try API query to verify that it fails
-	common.Infof("Verify that API query of repo now returns not found status")
-	if ok := vcsGet(repoUri, http.StatusNotFound); ok {
-		common.Infof("Deleted repo not found by API query, as expected")
+	common.Infof(ctx, "Verify that API query of repo now returns not found status")
+	if ok := vcsGet(ctx, repoUri, http.StatusNotFound); ok {
+		common.Infof(ctx, "Deleted repo not found by API query, as expected")
 	} else {
 		passed = false
-		common.Errorf("Deleted repo was found by API query")
-		common.Infof("Try to delete org anyway")
-		vcsDelete(orgUri)
+		common.Errorf(ctx, "Deleted repo was found by API query")
+		common.Infof(ctx, "Try to delete org anyway")
+		vcsDelete(ctx, orgUri)
 		return
 	}
 
 	// Try a clone to verify that it now fails
 	if len(gitRepo.Username) == 0 {
-		common.Infof("Unable to perform bad path clone test without vcs user credentials")
-	} else if !gitRepo.cloneShouldFail() {
+		common.Infof(ctx, "Unable to perform bad path clone test without vcs user credentials")
+	} else if !registry.RunSubtest(ctx, "vcs.clone-deleted", gitRepo.cloneShouldFail) {
 		passed = false
 	}
 
 	// Delete vcs org
-	common.Infof("Delete org %s", orgName)
-	if ok := vcsDelete(orgUri); ok {
-		common.Infof("Successfully deleted vcs org")
+	common.Infof(ctx, "Delete org %s", orgName)
+	if ok := vcsDelete(ctx, orgUri); ok {
+		common.Infof(ctx, "Successfully deleted vcs org")
+		common.ResourceDeleted(ctx, vcsOrgKind, orgName)
 	} else {
 		passed = false
-		common.Errorf("Failed to delete vcs org")
+		common.Errorf(ctx, "Failed to delete vcs org")
 		return
 	}
 
 	// Try API query to verify that it fails
-	common.Infof("Verify that API query of org now returns not found status")
-	if ok := vcsGet(orgUri, http.StatusNotFound); ok {
-		common.Infof("Deleted org not found by API query, as expected")
+	common.Infof(ctx, "Verify that API query of org now returns not found status")
+	if ok := vcsGet(ctx, orgUri, http.StatusNotFound); ok {
+		common.Infof(ctx, "Deleted org not found by API query, as expected")
 	} else {
 		passed = false
-		common.Errorf("Deleted org was found by API query")
+		common.Errorf(ctx, "Deleted org was found by API query")
 	}
 
 	// We return failure if we had to use insecure requests or git operations
 	if passed && useInsecure {
-		common.Errorf("Even though all operations succeeded, test failed because insecure operations were required")
+		common.Errorf(ctx, "Even though all operations succeeded, test failed because insecure operations were required")
 		passed = false
 	}
 
@@ -390,20 +363,20 @@ func repoTest() (passed bool) {
 // Wrapper to run a command and verify it passes or fails
 // Logs errors if any
 // Returns true if no errors, false otherwise
-func runCmdWithEnv(shouldPass bool, cmdEnv map[string]string, cmdName string, cmdArgs ...string) bool {
+func runCmdWithEnv(ctx context.Context, shouldPass bool, cmdEnv map[string]string, cmdName string, cmdArgs ...string) bool {
 	var cmdResult *common.CommandResult
 	var err error
 	if !shouldPass {
-		common.Debugf("WE EXPECT THE FOLLOWING %s COMMAND TO FAIL", cmdName)
+		common.Debugf(ctx, "WE EXPECT THE FOLLOWING %s COMMAND TO FAIL", cmdName)
 	}
-	cmdResult, err = common.RunNameWithEnv(cmdEnv, cmdName, cmdArgs...)
+	cmdResult, err = common.RunNameWithEnv(ctx, cmdEnv, cmdName, cmdArgs...)
 	if err != nil {
-		common.Error(err)
-		common.Errorf("Error attempting to run command")
+		common.Error(ctx, err)
+		common.Errorf(ctx, "Error attempting to run command")
 		return false
 	} else if cmdResult.Rc != 0 {
 		if !cmdResult.Ran || shouldPass {
-			common.Errorf("Command failed")
+			common.Errorf(ctx, "Command failed")
 			return false
 		} else {
 			return true
@@ -411,14 +384,14 @@ func runCmdWithEnv(shouldPass bool, cmdEnv map[string]string, cmdName string, cm
 	} else if shouldPass {
 		return true
 	} else {
-		common.Errorf("Command passed but we expected it to fail")
+		common.Errorf(ctx, "Command passed but we expected it to fail")
 		return false
 	}
 }
 
 // Wrapper to runCmdWithEnv with no env variables set
-func runCmd(shouldPass bool, cmdName string, cmdArgs ...string) bool {
-	return runCmdWithEnv(shouldPass, nil, cmdName, cmdArgs...)
+func runCmd(ctx context.Context, shouldPass bool, cmdName string, cmdArgs ...string) bool {
+	return runCmdWithEnv(ctx, shouldPass, nil, cmdName, cmdArgs...)
 }
 
 // Does the following:
@@ -431,24 +404,24 @@ func runCmd(shouldPass bool, cmdName string, cmdArgs ...string) bool {
 // 7) Deletes the local copy of repo
 // Logs errors if any
 // Returns true if no errors, false otherwise
-func (gitRepo *GitRepo) cloneTest() bool {
+func (gitRepo *GitRepo) cloneTest(ctx context.Context) (passed bool) {
 	var repoDir string
 	var err error
 
 	repoDir = fmt.Sprintf("/tmp/%s", gitRepo.RepoName)
 
-	if !gitRepo.runGitCmd(true, "clone", gitRepo.Url(), repoDir) {
+	if !gitRepo.runGitCmd(ctx, true, "clone", gitRepo.Url(), repoDir) {
 		return false
 	}
 
 	// set user.email and user.name in cloned repo, to avoid errors/warnings
-	if !gitRepo.runGitCmd(true, "-C", repoDir, "config", "user.email", "catfood@dogfood.gov") {
-		runCmd(true, "rm", "-r", repoDir)
+	if !gitRepo.runGitCmd(ctx, true, "-C", repoDir, "config", "user.email", "catfood@dogfood.gov") {
+		runCmd(ctx, true, "rm", "-r", repoDir)
 		return false
 	}
 
-	if !gitRepo.runGitCmd(true, "-C", repoDir, "config", "user.name", "Joseph Catfood") {
-		runCmd(true, "rm", "-r", repoDir)
+	if !gitRepo.runGitCmd(ctx, true, "-C", repoDir, "config", "user.name", "Joseph Catfood") {
+		runCmd(ctx, true, "rm", "-r", repoDir)
 		return false
 	}
 
@@ -456,84 +429,84 @@ func (gitRepo *GitRepo) cloneTest() bool {
 	textFileBase := fmt.Sprintf("vcs_repo_test_textfile.%d.%d.txt", common.Intn(1000000), common.Intn(1000000))
 	textFile := "/tmp/" + textFileBase
 	textFileSizeBytes := common.IntInRange(1, 20*1024)
-	common.Debugf("Creating file to put in new repo: %s", textFile)
+	common.Debugf(ctx, "Creating file to put in new repo: %s", textFile)
 	f, err := os.Create(textFile)
 	if err != nil {
-		common.Error(err)
-		runCmd(true, "rm", "-r", repoDir)
+		common.Error(ctx, err)
+		runCmd(ctx, true, "rm", "-r", repoDir)
 		return false
 	}
 	defer f.Close()
 
-	common.Debugf("Writing %d characters to %s", textFileSizeBytes, textFile)
+	common.Debugf(ctx, "Writing %d characters to %s", textFileSizeBytes, textFile)
 	_, err = f.WriteString(common.TextStringWithNewlines(textFileSizeBytes))
 	if err != nil {
-		common.Error(err)
-		runCmd(true, "rm", "-r", repoDir)
+		common.Error(ctx, err)
+		runCmd(ctx, true, "rm", "-r", repoDir)
 		return false
 	}
 	f.Sync()
 
 	// Copy text file into repo
-	if !runCmd(true, "cp", textFile, repoDir) {
-		runCmd(true, "rm", "-r", repoDir, textFile)
+	if !runCmd(ctx, true, "cp", textFile, repoDir) {
+		runCmd(ctx, true, "rm", "-r", repoDir, textFile)
 		return false
 	}
 
 	// git add
-	if !gitRepo.runGitCmd(true, "-C", repoDir, "add", ".") {
-		runCmd(true, "rm", "-r", repoDir, textFile)
+	if !gitRepo.runGitCmd(ctx, true, "-C", repoDir, "add", ".") {
+		runCmd(ctx, true, "rm", "-r", repoDir, textFile)
 		return false
 	}
 
 	// git commit
-	if !gitRepo.runGitCmd(true, "-C", repoDir, "commit", "-m", "Test commit") {
-		runCmd(true, "rm", "-r", repoDir, textFile)
+	if !gitRepo.runGitCmd(ctx, true, "-C", repoDir, "commit", "-m", "Test commit") {
+		runCmd(ctx, true, "rm", "-r", repoDir, textFile)
 		return false
 	}
 
 	// git push
-	if !gitRepo.runGitCmd(true, "-C", repoDir, "push") {
-		runCmd(true, "rm", "-r", repoDir, textFile)
+	if !gitRepo.runGitCmd(ctx, true, "-C", repoDir, "push") {
+		runCmd(ctx, true, "rm", "-r", repoDir, textFile)
 		return false
 	}
 
 	// Remove the cloned directory
-	if !runCmd(true, "rm", "-r", repoDir) {
-		runCmd(true, "rm", textFile)
+	if !runCmd(ctx, true, "rm", "-r", repoDir) {
+		runCmd(ctx, true, "rm", textFile)
 		return false
 	}
 
 	// Clone into new directory
 	repoDir = fmt.Sprintf("/tmp/%s-take2", gitRepo.RepoName)
-	if !gitRepo.runGitCmd(true, "clone", gitRepo.Url(), repoDir) {
-		runCmd(true, "rm", textFile)
+	if !gitRepo.runGitCmd(ctx, true, "clone", gitRepo.Url(), repoDir) {
+		runCmd(ctx, true, "rm", textFile)
 		return false
 	}
 
 	// Compare the existing text file to the one in the repo
 	repoFilePath := fmt.Sprintf("%s/%s", repoDir, textFileBase)
-	if !runCmd(true, "cmp", textFile, repoFilePath) {
-		runCmd(true, "rm", "-r", repoDir, textFile)
+	if !runCmd(ctx, true, "cmp", textFile, repoFilePath) {
+		runCmd(ctx, true, "rm", "-r", repoDir, textFile)
 		return false
 	}
 
 	// Remove the repo dir
-	if !runCmd(true, "rm", "-r", repoDir, textFile) {
+	if !runCmd(ctx, true, "rm", "-r", repoDir, textFile) {
 		return false
 	}
 	return true
 }
 
-func (gitRepo *GitRepo) cloneShouldFail() bool {
+func (gitRepo *GitRepo) cloneShouldFail(ctx context.Context) (passed bool) {
 	var repoDir string
 
 	repoDir = fmt.Sprintf("/tmp/%s-shouldfail", gitRepo.RepoName)
-	if gitRepo.runGitCmd(false, "clone", gitRepo.Url(), repoDir) {
+	if gitRepo.runGitCmd(ctx, false, "clone", gitRepo.Url(), repoDir) {
 		return true
 	}
 
 	// Cleanup the repo dir, since the clone was unexpectedly successful
-	runCmd(true, "rm", "-r", repoDir)
+	runCmd(ctx, true, "rm", "-r", repoDir)
 	return false
 }
@@ -1,4 +1,4 @@
-# Copyright 2019-2025 Hewlett Packard Enterprise Development LP
+# Copyright 2019-2026 Hewlett Packard Enterprise Development LP
 #
 # Permission is hereby granted, free of charge, to any person obtaining a
 # copy of this software and associated documentation files (the "Software"),
@@ -21,14 +21,8 @@
 # (MIT License)
 
 # The following environment variables are set in the Makefile
-%define bbit_logdir %(echo ${BBIT_LOGDIR})
 %define cmsdev_logdir %(echo ${CMSDEV_LOGDIR})
-%define install_venv_base_dir %(echo ${INSTALL_VENV_BASE_DIR})
-%define install_venv_python_base_dir %(echo ${INSTALL_VENV_PYTHON_BASE_DIR})
-%define local_venv_python_base_dir %(echo ${LOCAL_VENV_PYTHON_BASE_DIR})
 %define summary %(echo ${DESCRIPTION})
-%define num_py_versions %(echo ${NUM_PY_VERSIONS})
-%define cmstools_venv_name %(echo ${CMSTOOLS_VENV_NAME})
 
 Name: %(echo ${RPM_NAME})
 License: MIT
@@ -43,7 +37,8 @@ Vendor: HPE
 BuildRequires: rpm >= 4.13
 BuildRequires: rpm-build >= 4.13
 Requires: rpm >= 4.13
-Requires: %(echo ${RPM_PYTHON_REQUIREMENTS})
+# For redact_cmsdev_log.py
+Requires: python3-base
 
 %description
 %{summary}
@@ -53,10 +48,6 @@ Requires: %(echo ${RPM_PYTHON_REQUIREMENTS})
 %build
 
 %install
-# Log directory for barebones image test
-install -m 755 -d %{buildroot}%{bbit_logdir}
-echo %{bbit_logdir} | tee -a INSTALLED_FILES
-
 install -m 755 -d %{buildroot}/usr/local/bin/
 echo /usr/local/bin | tee -a INSTALLED_FILES
 
@@ -79,31 +70,13 @@ echo /usr/local/bin/cray-tftp-upload | tee -a INSTALLED_FILES
 install -m 700 cms-tftp/cray-upload-recovery-images %{buildroot}/usr/local/bin/cray-upload-recovery-images
 echo /usr/local/bin/cray-upload-recovery-images | tee -a INSTALLED_FILES
 
-# Copy the Python virtual environments
-install -m 755 -d %{buildroot}%{install_venv_python_base_dir}
-echo %{install_venv_base_dir} | tee -a INSTALLED_FILES
-echo %{install_venv_python_base_dir} | tee -a INSTALLED_FILES
-cp -prv %{local_venv_python_base_dir}/* %{buildroot}%{install_venv_python_base_dir}
-find %{local_venv_python_base_dir} -print | sed 's#^%{local_venv_python_base_dir}#%{install_venv_python_base_dir}#' | tee -a INSTALLED_FILES
-
-# Add script to launch the cmstools test in /opt/cray/tests/integration/csm
+# Add the scripts to launch the integration tests in /opt/cray/tests/integration/csm
 install -m 755 -d %{buildroot}/opt/cray/tests/integration/csm/
 echo /opt/cray/tests/integration/csm | tee -a INSTALLED_FILES
 
-# If the RPM contains just a single Python version, then we can use a simple symlink.
-# Otherwise we should use the run_cmstools_test.sh script
-# to run the barebones_image_test and cfs_sessions_rc_test
-%if %{num_py_versions} == 1
-pushd %{buildroot}/opt/cray/tests/integration/csm
-ln -s ../../../../..%{install_venv_python_base_dir}/*/%{cmstools_venv_name}/bin/barebones_image_test barebones_image_test
-ln -s ../../../../..%{install_venv_python_base_dir}/*/%{cmstools_venv_name}/bin/cfs_sessions_rc_test cfs_sessions_rc_test
-popd
-%else
-install -m 755 run_cmstools_test.sh %{buildroot}/opt/cray/tests/integration/csm/run_cmstools_test.sh
-echo /opt/cray/tests/integration/csm/run_cmstools_test.sh | tee -a INSTALLED_FILES
+# The barebones image boot and CFS sessions race condition tests are run by cmsdev
 install -m 755 barebones_image_test.sh %{buildroot}/opt/cray/tests/integration/csm/barebones_image_test
-install -m 755 cfs_sessions_rc_test.sh  %{buildroot}/opt/cray/tests/integration/csm/cfs_sessions_rc_test
-%endif
+install -m 755 cfs_sessions_rc_test.sh %{buildroot}/opt/cray/tests/integration/csm/cfs_sessions_rc_test
 
 echo /opt/cray/tests/integration/csm/barebones_image_test | tee -a INSTALLED_FILES
 echo /opt/cray/tests/integration/csm/cfs_sessions_rc_test | tee -a INSTALLED_FILES
@@ -1,49 +0,0 @@
-#
-# MIT License
-#
-# (C) Copyright 2021-2022, 2024-2025 Hewlett Packard Enterprise Development LP
-#
-# Permission is hereby granted, free of charge, to any person obtaining a
-# copy of this software and associated documentation files (the "Software"),
-# to deal in the Software without restriction, including without limitation
-# the rights to use, copy, modify, merge, publish, distribute, sublicense,
-# and/or sell copies of the Software, and to permit persons to whom the
-# Software is furnished to do so, subject to the following conditions:
-#
-# The above copyright notice and this permission notice shall be included
-# in all copies or substantial portions of the Software.
-#
-# THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
-# IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
-# FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
-# THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
-# OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
-# ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
-# OTHER DEALINGS IN THE SOFTWARE.
-#
-
-"""
-Definitions and constants
-"""
-
-import datetime
-
-TEST_TIMESTAMP = datetime.datetime.now().strftime("%Y%m%d%H%M%S")
-
-# Constants
-ARM_ARCH = "arm"
-X86_ARCH = "x86"
-
-# First in the list is the default for the test
-ARCH_LIST = [ X86_ARCH, ARM_ARCH ]
-
-# To help for type hinting
-JsonObject = str|int|float|list|dict|bool|None
-JsonDict = dict[str, JsonObject]
-
-
-class CmstoolsException(Exception):
-    """
-    This is the base exception for all custom exceptions that can be raised from
-    cmstools tests and applications.
-    """